@@ -42,3 +42,17 @@ func (c *WaspClient) CallView(chainID *iscp.ChainID, hContract iscp.Hname, funct
 		}
 	}
 }
+
+// CallViewAtBlockIndex calls the view function on the state of the chain as it was after the block with the given index
+func (c *WaspClient) CallViewAtBlockIndex(chainID *iscp.ChainID, hContract iscp.Hname, functionName string, args dict.Dict, blockIndex uint32) (dict.Dict, error) {
+	arguments := args
+	if arguments == nil {
+		arguments = dict.Dict(nil)
+	}
+	var res dict.Dict
+	route := routes.AtBlockIndex(routes.CallView(chainID.Base58(), hContract.String(), functionName), blockIndex)
+	if err := c.do(http.MethodPost, route, arguments, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
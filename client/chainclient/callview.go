@@ -11,3 +11,9 @@ import (
 func (c *Client) CallView(hContract iscp.Hname, functionName string, args dict.Dict, optimisticReadTimeout ...time.Duration) (dict.Dict, error) {
 	return c.WaspClient.CallView(c.ChainID, hContract, functionName, args, optimisticReadTimeout...)
 }
+
+// CallViewAtBlockIndex calls a view function of a given contract on the state of the chain
+// as it was after the block with the given index
+func (c *Client) CallViewAtBlockIndex(hContract iscp.Hname, functionName string, args dict.Dict, blockIndex uint32) (dict.Dict, error) {
+	return c.WaspClient.CallViewAtBlockIndex(c.ChainID, hContract, functionName, args, blockIndex)
+}
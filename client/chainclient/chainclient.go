@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 )
 
 // Client allows to interact with a specific chain in the node, for example to send on-ledger or off-ledger requests
@@ -41,9 +42,10 @@ func New(
 }
 
 type PostRequestParams struct {
-	Transfer colored.Balances
-	Args     requestargs.RequestArgs
-	Nonce    uint64
+	Transfer  colored.Balances
+	Args      requestargs.RequestArgs
+	Nonce     uint64
+	GasBudget uint64
 }
 
 // Post1Request sends an on-ledger transaction with one request on it to the chain
@@ -64,6 +66,7 @@ func (c *Client) Post1Request(
 			EntryPoint: entryPoint,
 			Transfer:   par.Transfer,
 			Args:       par.Args,
+			GasBudget:  par.GasBudget,
 		}},
 	})
 }
@@ -74,6 +77,57 @@ func (c *Client) PostOffLedgerRequest(
 	entrypoint iscp.Hname,
 	params ...PostRequestParams,
 ) (*request.OffLedger, error) {
+	offledgerReq := c.newOffLedgerRequest(contractHname, entrypoint, params...)
+	return offledgerReq, c.WaspClient.PostOffLedgerRequest(c.ChainID, offledgerReq)
+}
+
+// DryRunOffLedgerRequest runs the off-ledger request on the current state of the chain without posting it
+// and returns what the request would do if it was posted
+func (c *Client) DryRunOffLedgerRequest(
+	contractHname iscp.Hname,
+	entrypoint iscp.Hname,
+	params ...PostRequestParams,
+) (*model.DryRunResponse, error) {
+	return c.WaspClient.DryRunRequest(c.ChainID, c.newOffLedgerRequest(contractHname, entrypoint, params...))
+}
+
+// DryRunOnLedgerRequest builds the on-ledger request transaction and runs the request on the current state
+// of the chain without posting the transaction, and returns what the request would do if it was posted
+func (c *Client) DryRunOnLedgerRequest(
+	contractHname iscp.Hname,
+	entryPoint iscp.Hname,
+	params ...PostRequestParams,
+) (*model.DryRunResponse, error) {
+	par := PostRequestParams{}
+	if len(params) > 0 {
+		par = params[0]
+	}
+	tx, err := c.GoshimmerClient.NewRequestTransaction(transaction.NewRequestTransactionParams{
+		SenderKeyPair: c.KeyPair,
+		Requests: []transaction.RequestParams{{
+			ChainID:    c.ChainID,
+			Contract:   contractHname,
+			EntryPoint: entryPoint,
+			Transfer:   par.Transfer,
+			Args:       par.Args,
+			GasBudget:  par.GasBudget,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	requests, err := request.OnLedgerFromTransaction(tx, c.ChainID.AsAddress())
+	if err != nil {
+		return nil, err
+	}
+	return c.WaspClient.DryRunRequest(c.ChainID, requests[0])
+}
+
+func (c *Client) newOffLedgerRequest(
+	contractHname iscp.Hname,
+	entrypoint iscp.Hname,
+	params ...PostRequestParams,
+) *request.OffLedger {
 	par := PostRequestParams{}
 	if len(params) > 0 {
 		par = params[0]
@@ -82,10 +136,12 @@ func (c *Client) PostOffLedgerRequest(
 		c.nonces[c.KeyPair.PublicKey]++
 		par.Nonce = c.nonces[c.KeyPair.PublicKey]
 	}
-	offledgerReq := request.NewOffLedger(c.ChainID, contractHname, entrypoint, par.Args).WithTransfer(par.Transfer)
+	offledgerReq := request.NewOffLedger(c.ChainID, contractHname, entrypoint, par.Args).
+		WithTransfer(par.Transfer).
+		WithGasBudget(par.GasBudget)
 	offledgerReq.WithNonce(par.Nonce)
 	offledgerReq.Sign(c.KeyPair)
-	return offledgerReq, c.WaspClient.PostOffLedgerRequest(c.ChainID, offledgerReq)
+	return offledgerReq
 }
 
 func (c *Client) DepositFunds(n uint64) (*ledgerstate.Transaction, error) {
@@ -124,6 +180,11 @@ func (par *PostRequestParams) WithTransferEncoded(colval ...interface{}) *PostRe
 	return par
 }
 
+func (par *PostRequestParams) WithGasBudget(gasBudget uint64) *PostRequestParams {
+	par.GasBudget = gasBudget
+	return par
+}
+
 func (par *PostRequestParams) WithIotas(i uint64) *PostRequestParams {
 	return par.WithTransferEncoded(colored.IOTA, i)
 }
@@ -1,6 +1,40 @@
 package chainclient
 
+import (
+	"github.com/iotaledger/wasp/client"
+	"golang.org/x/xerrors"
+)
+
 // StateGet fetches the raw value associated with the given key in the chain state
 func (c *Client) StateGet(key string) ([]byte, error) {
 	return c.WaspClient.StateGet(c.ChainID, key)
 }
+
+// StateGetAtBlockIndex fetches the raw value associated with the given key in the chain state
+// as it was after the block with the given index
+func (c *Client) StateGetAtBlockIndex(key string, blockIndex uint32) ([]byte, error) {
+	return c.WaspClient.StateGetAtBlockIndex(c.ChainID, key, blockIndex)
+}
+
+// StateGetVerified fetches the value associated with the given key in the chain state and verifies its proof
+// against the state hash held in the current unspent alias output of the chain on the ledger.
+// Returns nil if the absence of the key was proven
+func (c *Client) StateGetVerified(key string) ([]byte, error) {
+	res, err := c.WaspClient.StateGetProof(c.ChainID, key)
+	if err != nil {
+		return nil, err
+	}
+	stateOutputID, err := res.StateOutputID.ID()
+	if err != nil {
+		return nil, err
+	}
+	stateOutput, err := c.GoshimmerClient.GetAliasOutput(c.ChainID.AsAliasAddress())
+	if err != nil {
+		return nil, err
+	}
+	if stateOutput.ID() != stateOutputID {
+		return nil, xerrors.Errorf("the proof is for the state output %s, the current state output of the chain is %s",
+			stateOutputID.Base58(), stateOutput.ID().Base58())
+	}
+	return client.VerifyStateProof(c.ChainID, res, stateOutput)
+}
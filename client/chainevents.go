@@ -0,0 +1,58 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"golang.org/x/xerrors"
+	"nhooyr.io/websocket"
+)
+
+// maxChainEventSize is the maximum size of a message of the event stream
+const maxChainEventSize = 1 << 20
+
+// ChainEvents connects to the event stream of the chain and calls the handler with each event, until the
+// context is done or the connection is closed. If since is nil, only the events of new blocks are streamed
+func (c *WaspClient) ChainEvents(ctx context.Context, chainID *iscp.ChainID, since *uint32, handler func(evt *publisher.ChainEvent)) error {
+	url := fmt.Sprintf("%s/%s", strings.TrimRight(c.baseURL, "/"), strings.TrimLeft(routes.ChainEvents(chainID.Base58()), "/"))
+	if since != nil {
+		url += fmt.Sprintf("?since=%d", *since)
+	}
+	// http -> ws, https -> wss
+	url = "ws" + strings.TrimPrefix(url, "http")
+
+	header := http.Header{}
+	if c.token != "" {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	conn, _, err := websocket.Dial(ctx, url, &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		return xerrors.Errorf("GET %s: %w", url, err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+	conn.SetReadLimit(maxChainEventSize)
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil || websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				return nil
+			}
+			return xerrors.Errorf("reading event stream: %w", err)
+		}
+		evt := &publisher.ChainEvent{}
+		if err := json.Unmarshal(data, evt); err != nil {
+			return xerrors.Errorf("decoding chain event: %w", err)
+		}
+		handler(evt)
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func TestChainEvents(t *testing.T) {
+	chainID := iscp.RandomChainID()
+	evts := []*publisher.ChainEvent{
+		{Type: publisher.ChainEventRequestReceipt, ChainID: chainID.Base58(), Cursor: publisher.Cursor{BlockIndex: 3}},
+		{Type: publisher.ChainEventBlockCommitted, ChainID: chainID.Base58(), Cursor: publisher.Cursor{BlockIndex: 3, EventIndex: 1}},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, routes.ChainEvents(chainID.Base58()), r.URL.Path)
+		require.Equal(t, "3", r.URL.Query().Get("since"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		c, err := websocket.Accept(w, r, nil)
+		require.NoError(t, err)
+		for _, evt := range evts {
+			data, err := json.Marshal(evt)
+			require.NoError(t, err)
+			require.NoError(t, c.Write(r.Context(), websocket.MessageText, data))
+		}
+		c.Close(websocket.StatusNormalClosure, "")
+	}))
+	defer srv.Close()
+
+	received := make([]*publisher.ChainEvent, 0)
+	since := uint32(3)
+	err := NewWaspClient(srv.URL).WithToken("token").ChainEvents(context.Background(), chainID, &since, func(evt *publisher.ChainEvent) {
+		received = append(received, evt)
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	for i, evt := range received {
+		require.Equal(t, evts[i].Type, evt.Type)
+		require.Equal(t, evts[i].Cursor, evt.Cursor)
+	}
+}
@@ -16,6 +16,7 @@ import (
 type WaspClient struct {
 	httpClient http.Client
 	baseURL    string
+	token      string
 }
 
 // NewWaspClient returns a new *WaspClient with the given baseURL and httpClient.
@@ -29,6 +30,18 @@ func NewWaspClient(baseURL string, httpClient ...http.Client) *WaspClient {
 	return &WaspClient{baseURL: baseURL}
 }
 
+// WithToken sets the JWT token sent with each request to the Wasp web API.
+func (c *WaspClient) WithToken(token string) *WaspClient {
+	c.token = token
+	return c
+}
+
+func (c *WaspClient) setAuthHeader(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
 func processResponse(res *http.Response, decodeTo interface{}) error {
 	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
@@ -77,6 +90,7 @@ func (c *WaspClient) do(method, route string, reqObj, resObj interface{}) error
 	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	c.setAuthHeader(req)
 
 	// make the request
 	res, err := c.httpClient.Do(req)
@@ -28,3 +28,10 @@ func (c *WaspClient) DKSharesGet(addr ledgerstate.Address) (*model.DKSharesInfo,
 	err := c.do(http.MethodGet, routes.DKSharesGet(addrStr), nil, &response)
 	return &response, err
 }
+
+// DKSharesReshare redistributes an existing DKShare to a new set of peers and returns its new state.
+func (c *WaspClient) DKSharesReshare(addr ledgerstate.Address, request *model.DKSharesReshareRequest) (*model.DKSharesInfo, error) {
+	var response model.DKSharesInfo
+	err := c.do(http.MethodPost, routes.DKSharesReshare(addr.Base58()), request, &response)
+	return &response, err
+}
@@ -148,6 +148,19 @@ func (c *Client) WaitForConfirmation(txid ledgerstate.TransactionID) error {
 }
 
 func (c *Client) PostRequestTransaction(par transaction.NewRequestTransactionParams) (*ledgerstate.Transaction, error) {
+	tx, err := c.NewRequestTransaction(par)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.PostTransaction(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewRequestTransaction builds the request transaction from the confirmed outputs of the sender, without posting it
+func (c *Client) NewRequestTransaction(par transaction.NewRequestTransactionParams) (*ledgerstate.Transaction, error) {
 	var err error
 
 	if len(par.UnspentOutputs) == 0 {
@@ -157,14 +170,19 @@ func (c *Client) PostRequestTransaction(par transaction.NewRequestTransactionPar
 			return nil, fmt.Errorf("can't get outputs from the node: %v", err)
 		}
 	}
+	return transaction.NewRequestTransaction(par)
+}
 
-	tx, err := transaction.NewRequestTransaction(par)
+// GetAliasOutput fetches the confirmed unspent alias output of the given alias address from the ledger
+func (c *Client) GetAliasOutput(address *ledgerstate.AliasAddress) (*ledgerstate.AliasOutput, error) {
+	outs, err := c.GetConfirmedOutputs(address)
 	if err != nil {
 		return nil, err
 	}
-
-	if err := c.PostTransaction(tx); err != nil {
-		return nil, err
+	for _, out := range outs {
+		if aliasOutput, ok := out.(*ledgerstate.AliasOutput); ok && aliasOutput.GetAliasAddress().Equals(address) {
+			return aliasOutput, nil
+		}
 	}
-	return tx, nil
+	return nil, fmt.Errorf("GetAliasOutput: no confirmed unspent alias output for %s", address.Base58())
 }
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+)
+
+// KeystoreStatus fetches the status of the keystore of the node
+func (c *WaspClient) KeystoreStatus() (*model.KeystoreStatus, error) {
+	res := &model.KeystoreStatus{}
+	if err := c.do(http.MethodGet, routes.KeystoreStatus(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// KeystoreUnlock unlocks the keystore of the node with the passphrase or the key of the operator
+func (c *WaspClient) KeystoreUnlock(secret []byte) error {
+	return c.do(http.MethodPost, routes.KeystoreUnlock(), &model.KeystoreUnlockRequest{
+		Secret: model.NewBytes(secret),
+	}, nil)
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+)
+
+// Login requests a JWT token for the given user. The token is not stored in the client;
+// use WithToken to authenticate the subsequent requests.
+func (c *WaspClient) Login(username, password string) (*model.LoginResponse, error) {
+	var res model.LoginResponse
+	err := c.do(http.MethodPost, routes.Login(), &model.LoginRequest{Username: username, Password: password}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"net/http"
+
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/webapi/model"
@@ -13,3 +15,16 @@ func (c *WaspClient) PostOffLedgerRequest(chainID *iscp.ChainID, req *request.Of
 	}
 	return c.do("POST", routes.NewRequest(chainID.Base58()), data, nil)
 }
+
+// DryRunRequest runs the off-ledger or on-ledger request on the current state of the chain without committing anything.
+// The off-ledger request does not need to be signed, and the transaction of the on-ledger request does not need to be posted
+func (c *WaspClient) DryRunRequest(chainID *iscp.ChainID, req iscp.Request) (*model.DryRunResponse, error) {
+	data := model.OffLedgerRequestBody{
+		Request: model.NewBytes(req.Bytes()),
+	}
+	var res model.DryRunResponse
+	if err := c.do(http.MethodPost, routes.DryRunRequest(chainID.Base58()), data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
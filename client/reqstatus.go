@@ -1,10 +1,12 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/webapi/model"
@@ -20,6 +22,25 @@ func (c *WaspClient) RequestStatus(chainID *iscp.ChainID, reqID iscp.RequestID)
 	return res, nil
 }
 
+// PendingRequests fetches a page of at most limit requests waiting to be processed in the mempool of the node,
+// ordered by request ID, after skipping offset requests. Returns the total number of pending requests too
+func (c *WaspClient) PendingRequests(chainID *iscp.ChainID, offset, limit int) ([]iscp.Request, int, error) {
+	res := &model.PendingRequestsResponse{}
+	route := fmt.Sprintf("%s?offset=%d&limit=%d", routes.PendingRequests(chainID.Base58()), offset, limit)
+	if err := c.do(http.MethodGet, route, nil, res); err != nil {
+		return nil, 0, err
+	}
+	ret := make([]iscp.Request, len(res.Requests))
+	for i, data := range res.Requests {
+		req, err := request.FromMarshalUtil(marshalutil.New(data.Bytes()))
+		if err != nil {
+			return nil, 0, err
+		}
+		ret[i] = req
+	}
+	return ret, res.Total, nil
+}
+
 // WaitUntilRequestProcessed blocks until the request has been processed by the node
 func (c *WaspClient) WaitUntilRequestProcessed(chainID *iscp.ChainID, reqID iscp.RequestID, timeout time.Duration) error {
 	if timeout == 0 {
@@ -5,6 +5,7 @@ import (
 	"github.com/iotaledger/wasp/client/chainclient"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 )
 
 func (c *SCClient) PostRequest(fname string, params ...chainclient.PostRequestParams) (*ledgerstate.Transaction, error) {
@@ -14,3 +15,11 @@ func (c *SCClient) PostRequest(fname string, params ...chainclient.PostRequestPa
 func (c *SCClient) PostOffLedgerRequest(fname string, params ...chainclient.PostRequestParams) (*request.OffLedger, error) {
 	return c.ChainClient.PostOffLedgerRequest(c.ContractHname, iscp.Hn(fname), params...)
 }
+
+func (c *SCClient) DryRunOffLedgerRequest(fname string, params ...chainclient.PostRequestParams) (*model.DryRunResponse, error) {
+	return c.ChainClient.DryRunOffLedgerRequest(c.ContractHname, iscp.Hn(fname), params...)
+}
+
+func (c *SCClient) DryRunOnLedgerRequest(fname string, params ...chainclient.PostRequestParams) (*model.DryRunResponse, error) {
+	return c.ChainClient.DryRunOnLedgerRequest(c.ContractHname, iscp.Hn(fname), params...)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"golang.org/x/xerrors"
+)
+
+// ExportSnapshot downloads a snapshot of the chain state and writes it to w.
+// The snapshot is taken at the latest block, unless a block index is given
+func (c *WaspClient) ExportSnapshot(chID *iscp.ChainID, w io.Writer, blockIndex ...uint32) error {
+	route := routes.ChainSnapshot(chID.Base58())
+	if len(blockIndex) > 0 {
+		route = routes.AtBlockIndex(route, blockIndex[0])
+	}
+	url := fmt.Sprintf("%s/%s", strings.TrimRight(c.baseURL, "/"), strings.TrimLeft(route, "/"))
+	req, err := http.NewRequest(http.MethodGet, url, nil) //nolint:noctx
+	if err != nil {
+		return xerrors.Errorf("http.NewRequest [GET %s]: %w", url, err)
+	}
+	c.setAuthHeader(req)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("GET %s: %w", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return xerrors.Errorf("GET %s: %w", url, processResponse(res, nil))
+	}
+	defer res.Body.Close()
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return xerrors.Errorf("GET %s: %w", url, err)
+	}
+	return nil
+}
+
+// ImportSnapshot uploads a snapshot of the chain state to the wasp node. The chain must not be active in the node
+func (c *WaspClient) ImportSnapshot(chID *iscp.ChainID, r io.Reader) (*model.SnapshotInfo, error) {
+	route := routes.ChainSnapshot(chID.Base58())
+	url := fmt.Sprintf("%s/%s", strings.TrimRight(c.baseURL, "/"), strings.TrimLeft(route, "/"))
+	req, err := http.NewRequest(http.MethodPost, url, r) //nolint:noctx
+	if err != nil {
+		return nil, xerrors.Errorf("http.NewRequest [POST %s]: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	c.setAuthHeader(req)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("POST %s: %w", url, err)
+	}
+	info := &model.SnapshotInfo{}
+	if err := processResponse(res, info); err != nil {
+		return nil, xerrors.Errorf("POST %s: %w", url, err)
+	}
+	return info, nil
+}
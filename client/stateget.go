@@ -1,11 +1,17 @@
 package client
 
 import (
+	"bytes"
 	"encoding/hex"
 	"net/http"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/state/trie"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"golang.org/x/xerrors"
 )
 
 // StateGet fetches the raw value associated with the given key in the chain state
@@ -16,3 +22,66 @@ func (c *WaspClient) StateGet(chainID *iscp.ChainID, key string) ([]byte, error)
 	}
 	return res, nil
 }
+
+// StateGetAtBlockIndex fetches the raw value associated with the given key in the chain state
+// as it was after the block with the given index
+func (c *WaspClient) StateGetAtBlockIndex(chainID *iscp.ChainID, key string, blockIndex uint32) ([]byte, error) {
+	var res []byte
+	route := routes.AtBlockIndex(routes.StateGet(chainID.Base58(), hex.EncodeToString([]byte(key))), blockIndex)
+	if err := c.do(http.MethodGet, route, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// StateGetProof fetches the value associated with the given key in the chain state together with the proof
+// of its inclusion (or absence) against the committed state hash.
+// The proof is not verified, use VerifyStateProof for it
+func (c *WaspClient) StateGetProof(chainID *iscp.ChainID, key string) (*model.StateProof, error) {
+	res := &model.StateProof{}
+	if err := c.do(http.MethodGet, routes.StateGetProof(chainID.Base58(), hex.EncodeToString([]byte(key))), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// VerifyStateProof checks the proof returned by StateGetProof against the state hash held in the alias output
+// of the chain, as it was fetched from the ledger. Returns the proven value, or nil if absence of the key is proven
+func VerifyStateProof(chainID *iscp.ChainID, res *model.StateProof, stateOutput ledgerstate.Output) ([]byte, error) {
+	aliasOutput, ok := stateOutput.(*ledgerstate.AliasOutput)
+	if !ok {
+		return nil, xerrors.New("VerifyStateProof: state output is not an alias output")
+	}
+	stateOutputID, err := res.StateOutputID.ID()
+	if err != nil {
+		return nil, xerrors.Errorf("VerifyStateProof: %w", err)
+	}
+	if aliasOutput.ID() != stateOutputID {
+		return nil, xerrors.New("VerifyStateProof: state output ID does not match the proof")
+	}
+	if !aliasOutput.GetAliasAddress().Equals(chainID.AsAliasAddress()) {
+		return nil, xerrors.New("VerifyStateProof: state output does not belong to the chain")
+	}
+	stateHash, err := hashing.HashValueFromBytes(aliasOutput.GetStateData())
+	if err != nil {
+		return nil, xerrors.Errorf("VerifyStateProof: %w", err)
+	}
+	if stateHash != res.StateHash.HashValue() {
+		return nil, xerrors.New("VerifyStateProof: state hash does not match the state output")
+	}
+	proof, err := trie.ProofFromBytes(res.Proof.Bytes())
+	if err != nil {
+		return nil, xerrors.Errorf("VerifyStateProof: %w", err)
+	}
+	if !bytes.Equal(proof.Key, res.Key.Bytes()) {
+		return nil, xerrors.New("VerifyStateProof: key does not match the proof")
+	}
+	var value []byte
+	if res.Exists {
+		value = res.Value.Bytes()
+	}
+	if err := proof.Verify(stateHash, value); err != nil {
+		return nil, xerrors.Errorf("VerifyStateProof: %w", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyStateProof(t *testing.T) {
+	vs, err := state.CreateOriginState(mapdb.NewMapDB(), nil)
+	require.NoError(t, err)
+	vs.KVStore().Set("key", []byte("value"))
+	stateHash := vs.StateCommitment()
+
+	keyPair := ed25519.GenerateKeyPair()
+	output, err := ledgerstate.NewAliasOutputMint(
+		map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: ledgerstate.DustThresholdAliasOutputIOTA},
+		ledgerstate.NewED25519Address(keyPair.PublicKey),
+	)
+	require.NoError(t, err)
+	require.NoError(t, output.SetStateData(stateHash.Bytes()))
+	outputID := ledgerstate.NewOutputID(ledgerstate.TransactionID{1}, 0)
+	stateOutput := output.SetID(outputID)
+	chainID := iscp.NewChainID(stateOutput.(*ledgerstate.AliasOutput).GetAliasAddress())
+
+	proofOf := func(key string) *model.StateProof {
+		value := vs.KVStore().MustGet(kv.Key(key))
+		return &model.StateProof{
+			Key:           model.NewBytes([]byte(key)),
+			Value:         model.NewBytes(value),
+			Exists:        value != nil,
+			Proof:         model.NewBytes(vs.GetProof(kv.Key(key)).Bytes()),
+			StateHash:     model.NewHashValue(stateHash),
+			StateOutputID: model.NewOutputID(outputID),
+		}
+	}
+
+	// a valid proof of a value, and of the absence of a key
+	value, err := VerifyStateProof(chainID, proofOf("key"), stateOutput)
+	require.NoError(t, err)
+	require.EqualValues(t, "value", value)
+	value, err = VerifyStateProof(chainID, proofOf("nokey"), stateOutput)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// a tampered value
+	res := proofOf("key")
+	res.Value = model.NewBytes([]byte("other"))
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+	res = proofOf("nokey")
+	res.Exists = true
+	res.Value = model.NewBytes([]byte("value"))
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+
+	// a tampered key
+	res = proofOf("key")
+	res.Key = model.NewBytes([]byte("nokey"))
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+
+	// a tampered commitment
+	res = proofOf("key")
+	res.StateHash = model.NewHashValue(hashing.HashStrings("other"))
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+
+	// the proof must be anchored by the state output of the chain
+	res = proofOf("key")
+	res.StateOutputID = model.NewOutputID(ledgerstate.NewOutputID(ledgerstate.TransactionID{2}, 0))
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+	res.StateOutputID = "not an output ID"
+	_, err = VerifyStateProof(chainID, res, stateOutput)
+	require.Error(t, err)
+	_, err = VerifyStateProof(iscp.RandomChainID(), proofOf("key"), stateOutput)
+	require.Error(t, err)
+}
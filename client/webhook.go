@@ -0,0 +1,33 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+)
+
+func (c *WaspClient) GetWebhookList() ([]*model.WebhookSubscription, error) {
+	var response []*model.WebhookSubscription
+	err := c.do(http.MethodGet, routes.WebhookList(), nil, &response)
+	return response, err
+}
+
+func (c *WaspClient) GetWebhook(id string) (*model.WebhookSubscription, error) {
+	var response *model.WebhookSubscription
+	err := c.do(http.MethodGet, routes.WebhookGet(id), nil, &response)
+	return response, err
+}
+
+func (c *WaspClient) PostWebhook(sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	var response model.WebhookSubscription
+	err := c.do(http.MethodPost, routes.WebhookPost(), sub, &response)
+	return &response, err
+}
+
+func (c *WaspClient) DeleteWebhook(id string) error {
+	return c.do(http.MethodDelete, routes.WebhookDelete(id), nil, nil)
+}
@@ -364,7 +364,7 @@ func (e *EVMEmulator) CallContract(call ethereum.CallMsg, blockNumber *big.Int)
 	if err != nil {
 		return nil, err
 	}
-	res, err := e.callContract(call, header, stateDB)
+	res, err := e.callContract(call, header, stateDB, vmConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -429,7 +429,7 @@ func (e *EVMEmulator) EstimateGas(call ethereum.CallMsg) (uint64, error) {
 		call.Gas = gas
 
 		snapshot := e.pending.state.Snapshot()
-		res, err := e.callContract(call, e.pending.header, e.pending.state)
+		res, err := e.callContract(call, e.pending.header, e.pending.state, vmConfig)
 		e.pending.state.RevertToSnapshot(snapshot)
 
 		if err != nil {
@@ -478,7 +478,7 @@ func (e *EVMEmulator) EstimateGas(call ethereum.CallMsg) (uint64, error) {
 
 // callContract implements common code between normal and pending contract calls.
 // state is modified during execution, make sure to copy it if necessary.
-func (e *EVMEmulator) callContract(call ethereum.CallMsg, header *types.Header, stateDB *state.StateDB) (*core.ExecutionResult, error) {
+func (e *EVMEmulator) callContract(call ethereum.CallMsg, header *types.Header, stateDB *state.StateDB, config vm.Config) (*core.ExecutionResult, error) {
 	// Ensure message is initialized properly.
 	if call.GasPrice == nil {
 		call.GasPrice = big.NewInt(1)
@@ -499,15 +499,17 @@ func (e *EVMEmulator) callContract(call ethereum.CallMsg, header *types.Header,
 	evmContext := core.NewEVMBlockContext(header, e.blockchain, nil)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
-	vmEnv := vm.NewEVM(evmContext, txContext, stateDB, e.blockchain.Config(), vmConfig)
+	vmEnv := vm.NewEVM(evmContext, txContext, stateDB, e.blockchain.Config(), config)
 	gasPool := new(core.GasPool).AddGas(math.MaxUint64)
 
 	return core.NewStateTransition(vmEnv, msg, gasPool).TransitionDb()
 }
 
 // SendTransaction updates the pending block to include the given transaction.
-// It returns an error if the transaction is invalid.
-func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, error) {
+// It returns an error if the transaction is invalid, or if its gas limit is higher than gasLimit.
+// The transactions are executed again when the block is committed, so the gas limit of the
+// transaction cannot be lowered here.
+func (e *EVMEmulator) SendTransaction(tx *types.Transaction, gasLimit uint64) (*types.Receipt, error) {
 	sender, err := types.Sender(e.Signer(), tx)
 	if err != nil {
 		return nil, xerrors.Errorf("invalid transaction: %w", err)
@@ -516,6 +518,9 @@ func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, er
 	if tx.Nonce() != nonce {
 		return nil, xerrors.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce)
 	}
+	if tx.Gas() > gasLimit {
+		return nil, xerrors.Errorf("transaction gas limit too high: got %d, max %d", tx.Gas(), gasLimit)
+	}
 
 	snap := e.pending.state.Snapshot()
 
@@ -543,6 +548,69 @@ func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, er
 	return receipt, nil
 }
 
+// TraceCall executes a contract call on top of the state of the given block (or the latest block
+// if nil), with the given tracer attached to the EVM.
+func (e *EVMEmulator) TraceCall(call ethereum.CallMsg, blockNumber *big.Int, tracer vm.Tracer) (*core.ExecutionResult, error) {
+	header, err := e.HeaderByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, ErrBlockDoesNotExist
+	}
+	stateDB, err := e.blockchain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	return e.callContract(call, header, stateDB, vm.Config{Debug: true, Tracer: tracer, NoBaseFee: true})
+}
+
+// TraceTransaction re-executes a committed transaction with the given tracer attached to
+// the EVM. The state is rebuilt by replaying the preceding transactions of the same block
+// on top of the state of the parent block.
+func (e *EVMEmulator) TraceTransaction(txHash common.Hash, tracer vm.Tracer) (*core.ExecutionResult, error) {
+	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(e.database, txHash)
+	if tx == nil {
+		return nil, ErrTransactionDoesNotExist
+	}
+	block := e.blockchain.GetBlock(blockHash, blockNumber)
+	if block == nil {
+		return nil, ErrBlockDoesNotExist
+	}
+	parent := e.blockchain.GetBlock(block.ParentHash(), blockNumber-1)
+	if parent == nil {
+		return nil, ErrBlockDoesNotExist
+	}
+	stateDB, err := e.blockchain.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	config := e.blockchain.Config()
+	signer := types.MakeSigner(config, block.Number())
+	blockContext := core.NewEVMBlockContext(block.Header(), e.blockchain, nil)
+	for i, prevTx := range block.Transactions()[:index] {
+		msg, err := prevTx.AsMessage(signer, block.BaseFee())
+		if err != nil {
+			return nil, err
+		}
+		stateDB.Prepare(prevTx.Hash(), i)
+		vmEnv := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), stateDB, config, vmConfig)
+		if _, err := core.ApplyMessage(vmEnv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+			return nil, xerrors.Errorf("replaying transaction %s: %w", prevTx.Hash().Hex(), err)
+		}
+		stateDB.Finalise(config.IsEIP158(block.Number()))
+	}
+
+	msg, err := tx.AsMessage(signer, block.BaseFee())
+	if err != nil {
+		return nil, err
+	}
+	stateDB.Prepare(txHash, int(index))
+	vmEnv := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), stateDB, config, vm.Config{Debug: true, Tracer: tracer, NoBaseFee: true})
+	return core.ApplyMessage(vmEnv, msg, new(core.GasPool).AddGas(msg.Gas()))
+}
+
 // FilterLogs executes a log filter operation, blocking during execution and
 // returning all the results in one batch.
 func (e *EVMEmulator) FilterLogs(query *ethereum.FilterQuery) ([]*types.Log, error) {
@@ -52,7 +52,7 @@ func sendTransaction(t testing.TB, emu *EVMEmulator, sender *ecdsa.PrivateKey, r
 	)
 	require.NoError(t, err)
 
-	_, err = emu.SendTransaction(tx)
+	_, err = emu.SendTransaction(tx, tx.Gas())
 	require.NoError(t, err)
 	emu.Commit()
 
@@ -231,7 +231,7 @@ func deployEVMContract(t testing.TB, emu *EVMEmulator, creator *ecdsa.PrivateKey
 	)
 	require.NoError(t, err)
 
-	_, err = emu.SendTransaction(tx)
+	_, err = emu.SendTransaction(tx, tx.Gas())
 	require.NoError(t, err)
 	emu.Commit()
 
@@ -523,7 +523,7 @@ func benchmarkEVMEmulator(b *testing.B, k int) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := emu.SendTransaction(txs[i])
+		_, err := emu.SendTransaction(txs[i], txs[i].Gas())
 		require.NoError(b, err)
 
 		// commit a block every n txs
@@ -5,8 +5,11 @@ package evmchain
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/iotaledger/wasp/contracts/native/evm"
 	"github.com/iotaledger/wasp/contracts/native/evm/evmchain/emulator"
 	"github.com/iotaledger/wasp/contracts/native/evm/evminternal"
@@ -37,6 +40,9 @@ var Processor = Contract.Processor(initialize, append(
 	evm.FuncGetTransactionCountByBlockNumber.WithHandler(getTransactionCountByBlockNumber),
 	evm.FuncGetStorage.WithHandler(getStorage),
 	evm.FuncGetLogs.WithHandler(getLogs),
+	evm.FuncTraceTransaction.WithHandler(traceTransaction),
+	evm.FuncTraceCall.WithHandler(traceCall),
+	evm.FuncGetISCPBlockIndex.WithHandler(getISCPBlockIndex),
 )...)
 
 func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
@@ -61,9 +67,9 @@ func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
 }
 
 func applyTransaction(ctx iscp.Sandbox) (dict.Dict, error) {
-	return evminternal.ApplyTransaction(ctx, func(tx *types.Transaction, _ uint32) (*types.Receipt, error) {
+	return evminternal.ApplyTransaction(ctx, func(tx *types.Transaction, gasLimit uint64, _ uint32) (*types.Receipt, error) {
 		emu := getEmulatorInBlockContext(ctx)
-		return emu.SendTransaction(tx)
+		return emu.SendTransaction(tx, gasLimit)
 	})
 }
 
@@ -225,3 +231,49 @@ func estimateGas(ctx iscp.SandboxView) (dict.Dict, error) {
 		return evminternal.Result(codec.EncodeUint64(gas)), nil
 	})
 }
+
+func traceTransaction(ctx iscp.SandboxView) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	txHash := common.BytesToHash(ctx.Params().MustGet(evm.FieldTransactionHash))
+	config, err := evmtypes.DecodeTraceConfig(ctx.Params().MustGet(evm.FieldTraceConfig))
+	a.RequireNoError(err)
+
+	return withEmulatorR(ctx, func(emu *emulator.EVMEmulator) (dict.Dict, error) {
+		receipt, err := emu.TransactionReceipt(txHash)
+		a.RequireNoError(err)
+		a.Require(receipt != nil, "transaction not found")
+		txctx := &tracers.Context{
+			BlockHash: receipt.BlockHash,
+			TxIndex:   int(receipt.TransactionIndex),
+			TxHash:    txHash,
+		}
+		res, err := evminternal.Trace(config, txctx, func(tracer vm.Tracer) (*core.ExecutionResult, error) {
+			return emu.TraceTransaction(txHash, tracer)
+		})
+		a.RequireNoError(err)
+		return evminternal.Result(res), nil
+	})
+}
+
+func traceCall(ctx iscp.SandboxView) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	callMsg, err := evmtypes.DecodeCallMsg(ctx.Params().MustGet(evm.FieldCallMsg))
+	a.RequireNoError(err)
+	config, err := evmtypes.DecodeTraceConfig(ctx.Params().MustGet(evm.FieldTraceConfig))
+	a.RequireNoError(err)
+
+	return withEmulatorR(ctx, func(emu *emulator.EVMEmulator) (dict.Dict, error) {
+		blockNumber := paramBlockNumberOrHashAsNumber(ctx, emu)
+		res, err := evminternal.Trace(config, new(tracers.Context), func(tracer vm.Tracer) (*core.ExecutionResult, error) {
+			return emu.TraceCall(callMsg, blockNumber, tracer)
+		})
+		a.RequireNoError(err)
+		return evminternal.Result(res), nil
+	})
+}
+
+// getISCPBlockIndex returns an empty result: the emulator keeps the state of all past EVM blocks,
+// so they can be queried on the latest ISCP state
+func getISCPBlockIndex(ctx iscp.SandboxView) (dict.Dict, error) {
+	return nil, nil
+}
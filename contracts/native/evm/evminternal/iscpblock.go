@@ -0,0 +1,38 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package evminternal
+
+import (
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+)
+
+// keyISCPBlockIndex is the map EVM block number => index of the ISCP block in which the EVM block was minted
+const keyISCPBlockIndex = "x"
+
+// CurrentISCPBlockIndex returns the index of the ISCP block being produced
+func CurrentISCPBlockIndex(ctx iscp.Sandbox) uint32 {
+	return ctx.StateAnchor().StateIndex() + 1
+}
+
+// SetISCPBlockIndex records the index of the ISCP block in which the EVM block was minted, so that
+// the state of the EVM at that block can be queried later on the historical state of the chain
+func SetISCPBlockIndex(state kv.KVStore, evmBlockNumber uint64, blockIndex uint32) {
+	collections.NewMap(state, keyISCPBlockIndex).MustSetAt(codec.EncodeUint64(evmBlockNumber), codec.EncodeUint32(blockIndex))
+}
+
+// GetISCPBlockIndex returns the index of the ISCP block in which the EVM block was minted, if recorded
+func GetISCPBlockIndex(state kv.KVStoreReader, evmBlockNumber uint64) (uint32, bool) {
+	b := collections.NewMapReadOnly(state, keyISCPBlockIndex).MustGetAt(codec.EncodeUint64(evmBlockNumber))
+	if b == nil {
+		return 0, false
+	}
+	blockIndex, err := codec.DecodeUint32(b)
+	if err != nil {
+		panic(err)
+	}
+	return blockIndex, true
+}
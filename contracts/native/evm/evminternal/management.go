@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 const (
@@ -162,7 +163,9 @@ func withdrawGasFees(ctx iscp.Sandbox) (dict.Dict, error) {
 	return nil, nil
 }
 
-func ApplyTransaction(ctx iscp.Sandbox, apply func(tx *types.Transaction, blockTime uint32) (*types.Receipt, error)) (dict.Dict, error) {
+// ApplyTransaction applies the EVM transaction. The remaining gas budget of the request is passed to apply
+// as the EVM gas limit, and the gas used by the EVM is then burned from the budget
+func ApplyTransaction(ctx iscp.Sandbox, apply func(tx *types.Transaction, gasLimit uint64, blockTime uint32) (*types.Receipt, error)) (dict.Dict, error) {
 	a := assert.NewAssert(ctx.Log())
 
 	tx := &types.Transaction{}
@@ -172,9 +175,12 @@ func ApplyTransaction(ctx iscp.Sandbox, apply func(tx *types.Transaction, blockT
 	transferredIotas, gasPerIota := takeGasFee(ctx, tx)
 
 	blockTime := getBlockTime(ctx.State())
-	receipt, err := apply(tx, blockTime)
+	receipt, err := apply(tx, gas.ToEVM(ctx.Gas().Budget()), blockTime)
 	a.RequireNoError(err)
 
+	// gas used by the EVM is charged to the gas budget of the request
+	ctx.Gas().Burn(gas.FromEVM(receipt.GasUsed))
+
 	return refundUnusedGasFee(ctx, ctx.Caller(), transferredIotas, gasPerIota, receipt.GasUsed), nil
 }
 
@@ -218,15 +224,15 @@ func refundUnusedGasFee(ctx iscp.Sandbox, caller *iscp.AgentID, transferredIotas
 func getFeeColor(ctx iscp.Sandbox) colored.Color {
 	a := assert.NewAssert(ctx.Log())
 
-	// call root contract view to get the feecolor
+	// call governance contract view to get the feecolor
 	feeInfo, err := ctx.Call(
 		governance.Contract.Hname(),
-		governance.FuncGetFeeInfo.Hname(),
-		dict.Dict{governance.ParamHname: ctx.Contract().Bytes()},
+		governance.FuncGetFeePolicy.Hname(),
+		nil,
 		nil,
 	)
 	a.RequireNoError(err)
-	feeColor, err := codec.DecodeColor(feeInfo.MustGet(governance.ParamFeeColor))
+	feeColor, err := codec.DecodeColor(feeInfo.MustGet(governance.VarFeeColor))
 	a.RequireNoError(err)
 	return feeColor
 }
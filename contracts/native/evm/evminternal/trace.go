@@ -0,0 +1,130 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package evminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
+	"golang.org/x/xerrors"
+)
+
+// defaultTraceTimeout is the maximum execution time of a JavaScript tracer, unless
+// specified otherwise in the trace config
+const defaultTraceTimeout = 5 * time.Second
+
+// executionResult is the output of the struct logger, in the same format as go-ethereum
+type executionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []structLogRes `json:"structLogs"`
+}
+
+type structLogRes struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   int                `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+}
+
+// Trace creates the tracer specified by the config, runs the EVM message with it and returns
+// the JSON-encoded trace result
+func Trace(
+	config *evmtypes.TraceConfig,
+	txctx *tracers.Context,
+	run func(tracer vm.Tracer) (*core.ExecutionResult, error),
+) ([]byte, error) {
+	var tracer vm.Tracer
+	if config.Tracer != nil {
+		timeout := defaultTraceTimeout
+		if config.Timeout != nil {
+			var err error
+			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+				return nil, err
+			}
+		}
+		jsTracer, err := tracers.New(*config.Tracer, txctx)
+		if err != nil {
+			return nil, err
+		}
+		deadline := time.AfterFunc(timeout, func() {
+			jsTracer.Stop(xerrors.New("execution timeout"))
+		})
+		defer deadline.Stop()
+		tracer = jsTracer
+	} else {
+		tracer = vm.NewStructLogger(config.LogConfig)
+	}
+
+	result, err := run(tracer)
+	if err != nil {
+		return nil, xerrors.Errorf("tracing failed: %w", err)
+	}
+
+	switch tracer := tracer.(type) {
+	case *vm.StructLogger:
+		returnVal := fmt.Sprintf("%x", result.Return())
+		if len(result.Revert()) > 0 {
+			returnVal = fmt.Sprintf("%x", result.Revert())
+		}
+		return json.Marshal(&executionResult{
+			Gas:         result.UsedGas,
+			Failed:      result.Failed(),
+			ReturnValue: returnVal,
+			StructLogs:  formatLogs(tracer.StructLogs()),
+		})
+	case *tracers.Tracer:
+		return tracer.GetResult()
+	default:
+		panic(fmt.Sprintf("bad tracer type %T", tracer))
+	}
+}
+
+func formatLogs(logs []vm.StructLog) []structLogRes {
+	formatted := make([]structLogRes, len(logs))
+	for index := range logs {
+		trace := &logs[index]
+		formatted[index] = structLogRes{
+			Pc:      trace.Pc,
+			Op:      trace.Op.String(),
+			Gas:     trace.Gas,
+			GasCost: trace.GasCost,
+			Depth:   trace.Depth,
+			Error:   trace.ErrorString(),
+		}
+		if trace.Stack != nil {
+			stack := make([]string, len(trace.Stack))
+			for i, stackValue := range trace.Stack {
+				stack[i] = stackValue.Hex()
+			}
+			formatted[index].Stack = &stack
+		}
+		if trace.Memory != nil {
+			memory := make([]string, 0, (len(trace.Memory)+31)/32)
+			for i := 0; i+32 <= len(trace.Memory); i += 32 {
+				memory = append(memory, fmt.Sprintf("%x", trace.Memory[i:i+32]))
+			}
+			formatted[index].Memory = &memory
+		}
+		if trace.Storage != nil {
+			storage := make(map[string]string)
+			for i, storageValue := range trace.Storage {
+				storage[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", storageValue)
+			}
+			formatted[index].Storage = &storage
+		}
+	}
+	return formatted
+}
@@ -34,6 +34,8 @@ const (
 	keyTransactionsByBlockNumber = "n:t"
 	keyReceiptsByBlockNumber     = "n:r"
 	keyBlockHeaderByBlockNumber  = "n:bh"
+	// previous values of the state keys modified in each block
+	keyUndoLogByBlockNumber = "n:u"
 
 	// indexes:
 
@@ -42,6 +44,10 @@ const (
 	keyBlockIndexByTxHash     = "th:i"
 )
 
+// undoLogKeepAmount is the amount of blocks for which the undo log is kept, regardless of the
+// amount of blocks kept in DB. Older blocks cannot be traced.
+const undoLogKeepAmount = 128
+
 // BlockchainDB contains logic for storing a fake blockchain (more like a list of blocks),
 // intended for satisfying EVM tools that depend on the concept of a block.
 type BlockchainDB struct {
@@ -135,6 +141,10 @@ func makeBlockHeaderByBlockNumberKey(blockNumber uint64) kv.Key {
 	return keyBlockHeaderByBlockNumber + kv.Key(codec.EncodeUint64(blockNumber))
 }
 
+func makeUndoLogByBlockNumberKey(blockNumber uint64) kv.Key {
+	return keyUndoLogByBlockNumber + kv.Key(codec.EncodeUint64(blockNumber))
+}
+
 func makeBlockNumberByBlockHashKey(hash common.Hash) kv.Key {
 	return keyBlockNumberByBlockHash + kv.Key(hash.Bytes())
 }
@@ -155,6 +165,10 @@ func (bc *BlockchainDB) getReceiptArray(blockNumber uint64) *collections.Array32
 	return collections.NewArray32(bc.kv, string(makeReceiptsByBlockNumberKey(blockNumber)))
 }
 
+func (bc *BlockchainDB) getUndoLogMap(blockNumber uint64) *collections.Map {
+	return collections.NewMap(bc.kv, string(makeUndoLogByBlockNumberKey(blockNumber)))
+}
+
 func (bc *BlockchainDB) GetPendingBlockNumber() uint64 {
 	return bc.GetNumber() + 1
 }
@@ -199,6 +213,39 @@ func (bc *BlockchainDB) AddTransaction(tx *types.Transaction, receipt *types.Rec
 	receiptArray.MustPush(evmtypes.EncodeReceipt(receipt))
 }
 
+// AddUndoLog records the values that the given state keys had before being modified by a
+// transaction of the pending block (a nil value means that the key did not exist).
+// Only the first modification of each key in the block is relevant, so that the undo log
+// allows to reconstruct the state at the beginning of the block.
+func (bc *BlockchainDB) AddUndoLog(prev map[kv.Key][]byte) {
+	undoLog := bc.getUndoLogMap(bc.GetPendingBlockNumber())
+	for k, v := range prev {
+		if undoLog.MustHasAt([]byte(k)) {
+			continue
+		}
+		if v == nil {
+			undoLog.MustSetAt([]byte(k), []byte{0})
+		} else {
+			undoLog.MustSetAt([]byte(k), append([]byte{1}, v...))
+		}
+	}
+}
+
+// GetUndoLog returns the values that the state keys modified in the given block had at the
+// beginning of the block (a nil value means that the key did not exist)
+func (bc *BlockchainDB) GetUndoLog(blockNumber uint64) map[kv.Key][]byte {
+	ret := make(map[kv.Key][]byte)
+	bc.getUndoLogMap(blockNumber).MustIterate(func(k, v []byte) bool {
+		if v[0] == 0 {
+			ret[kv.Key(k)] = nil
+		} else {
+			ret[kv.Key(k)] = v[1:]
+		}
+		return true
+	})
+	return ret
+}
+
 func (bc *BlockchainDB) MintBlock(timestamp uint64) {
 	blockNumber := bc.GetPendingBlockNumber()
 	header := bc.makeHeader(
@@ -209,6 +256,25 @@ func (bc *BlockchainDB) MintBlock(timestamp uint64) {
 	)
 	bc.addBlock(header, timestamp)
 	bc.prune(header.Number.Uint64())
+	bc.pruneUndoLog(header.Number.Uint64())
+}
+
+// pruneUndoLog deletes the undo log of the block that falls out of the last undoLogKeepAmount blocks
+func (bc *BlockchainDB) pruneUndoLog(currentNumber uint64) {
+	if currentNumber <= undoLogKeepAmount {
+		return
+	}
+	bc.getUndoLogMap(currentNumber - undoLogKeepAmount).Erase()
+}
+
+// HasUndoLog returns true if the undo log of the given block is available, i.e. the block
+// is the pending block or one of the last undoLogKeepAmount blocks, and it has not been pruned
+func (bc *BlockchainDB) HasUndoLog(blockNumber uint64) bool {
+	pending := bc.GetPendingBlockNumber()
+	if blockNumber == pending {
+		return true
+	}
+	return blockNumber < pending && blockNumber+undoLogKeepAmount > bc.GetNumber() && bc.HasBlock(blockNumber)
 }
 
 func (bc *BlockchainDB) prune(currentNumber uint64) {
@@ -241,6 +307,7 @@ func (bc *BlockchainDB) deleteBlock(blockNumber uint64) {
 	}
 	txs.MustErase()
 	bc.getReceiptArray(blockNumber).MustErase()
+	bc.getUndoLogMap(blockNumber).Erase()
 	bc.kv.Del(makeBlockHeaderByBlockNumberKey(blockNumber))
 	bc.kv.Del(makeBlockNumberByBlockHashKey(header.Hash))
 }
@@ -341,6 +408,25 @@ func (bc *BlockchainDB) GetReceiptByBlockNumberAndIndex(blockNumber uint64, i ui
 	}
 	r.BlockHash = bc.GetBlockHashByBlockNumber(blockNumber)
 	r.BlockNumber = new(big.Int).SetUint64(blockNumber)
+	r.TransactionIndex = uint(i)
+
+	// the log index is the position of the log in the block
+	logIndex := uint(0)
+	for j := uint32(0); j < i; j++ {
+		prev, err := evmtypes.DecodeReceipt(receipts.MustGetAt(j))
+		if err != nil {
+			panic(err)
+		}
+		logIndex += uint(len(prev.Logs))
+	}
+	for _, log := range r.Logs {
+		log.BlockNumber = blockNumber
+		log.TxHash = r.TxHash
+		log.TxIndex = uint(i)
+		log.BlockHash = r.BlockHash
+		log.Index = logIndex
+		logIndex++
+	}
 	return r
 }
 
@@ -451,9 +537,18 @@ func (bc *BlockchainDB) GetHeaderByBlockNumber(blockNumber uint64) *types.Header
 	if blockNumber > bc.GetNumber() {
 		return nil
 	}
+	if !bc.HasBlock(blockNumber) {
+		// pruned
+		return nil
+	}
 	return bc.headerFromGob(bc.getHeaderGobByBlockNumber(blockNumber), blockNumber)
 }
 
+// HasBlock returns true if the given block has been minted and not pruned
+func (bc *BlockchainDB) HasBlock(blockNumber uint64) bool {
+	return bc.kv.MustHas(makeBlockHeaderByBlockNumberKey(blockNumber))
+}
+
 func (bc *BlockchainDB) getHeaderGobByBlockNumber(blockNumber uint64) *headerGob {
 	b := bc.kv.MustGet(makeBlockHeaderByBlockNumberKey(blockNumber))
 	if b == nil {
@@ -227,7 +227,17 @@ func (e *EVMEmulator) ChainContext() core.ChainContext {
 }
 
 func (e *EVMEmulator) callContract(call ethereum.CallMsg) (*core.ExecutionResult, error) {
-	// Ensure message is initialized properly.
+	msg := e.makeCallMsg(call)
+	pendingHeader := e.BlockchainDB().GetPendingHeader()
+
+	// run the EVM code on a buffered state (so that writes are not committed)
+	statedb := e.StateDB().Buffered().StateDB()
+
+	return e.applyMessage(msg, statedb, pendingHeader, e.vmConfig())
+}
+
+// makeCallMsg ensures that the call message is initialized properly
+func (e *EVMEmulator) makeCallMsg(call ethereum.CallMsg) callMsg {
 	if call.GasPrice == nil {
 		call.GasPrice = big.NewInt(0)
 	}
@@ -237,20 +247,13 @@ func (e *EVMEmulator) callContract(call ethereum.CallMsg) (*core.ExecutionResult
 	if call.Value == nil {
 		call.Value = new(big.Int)
 	}
-
-	msg := callMsg{call}
-	pendingHeader := e.BlockchainDB().GetPendingHeader()
-
-	// run the EVM code on a buffered state (so that writes are not committed)
-	statedb := e.StateDB().Buffered().StateDB()
-
-	return e.applyMessage(msg, statedb, pendingHeader)
+	return callMsg{call}
 }
 
-func (e *EVMEmulator) applyMessage(msg core.Message, statedb vm.StateDB, header *types.Header) (*core.ExecutionResult, error) {
+func (e *EVMEmulator) applyMessage(msg core.Message, statedb vm.StateDB, header *types.Header, config vm.Config) (*core.ExecutionResult, error) {
 	blockContext := core.NewEVMBlockContext(header, e.ChainContext(), nil)
 	txContext := core.NewEVMTxContext(msg)
-	vmEnv := vm.NewEVM(blockContext, txContext, statedb, e.chainConfig, e.vmConfig())
+	vmEnv := vm.NewEVM(blockContext, txContext, statedb, e.chainConfig, config)
 	gasPool := core.GasPool(msg.Gas())
 	vmEnv.Reset(txContext, statedb)
 	return core.ApplyMessage(vmEnv, msg, &gasPool)
@@ -266,7 +269,9 @@ func (e *EVMEmulator) GetIEVMBackend() vm.ISCPBackend {
 	return e.IEVMBackend
 }
 
-func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, error) {
+// SendTransaction executes the transaction and adds it to the pending block. The transaction uses
+// at most gasLimit gas, even if its own gas limit is higher
+func (e *EVMEmulator) SendTransaction(tx *types.Transaction, gasLimit uint64) (*types.Receipt, error) {
 	buf := e.StateDB().Buffered()
 	statedb := buf.StateDB()
 	pendingHeader := e.BlockchainDB().GetPendingHeader()
@@ -284,8 +289,9 @@ func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, er
 	if err != nil {
 		return nil, err
 	}
+	msg = evmtypes.CapMessageGas(msg, gasLimit)
 
-	result, err := e.applyMessage(msg, statedb, pendingHeader)
+	result, err := e.applyMessage(msg, statedb, pendingHeader, e.vmConfig())
 	if err != nil {
 		return nil, err
 	}
@@ -317,12 +323,101 @@ func (e *EVMEmulator) SendTransaction(tx *types.Transaction) (*types.Receipt, er
 		receipt.ContractAddress = crypto.CreateAddress(msg.From(), tx.Nonce())
 	}
 
+	e.BlockchainDB().AddUndoLog(buf.previousValues())
 	buf.Commit()
 	e.BlockchainDB().AddTransaction(tx, receipt)
 
 	return receipt, nil
 }
 
+// TraceCall executes a contract call with the given tracer attached to the EVM, without committing
+// changes to the state. If blockNumber is the latest block, the call is executed on the current
+// state, as in CallContract; otherwise it is executed on top of the state at the end of the given block.
+func (e *EVMEmulator) TraceCall(call ethereum.CallMsg, blockNumber uint64, tracer vm.Tracer) (*core.ExecutionResult, error) {
+	bc := e.BlockchainDB()
+	var header *types.Header
+	var buf *BufferedStateDB
+	if blockNumber == bc.GetNumber() {
+		header = bc.GetPendingHeader()
+		buf = e.StateDB().Buffered()
+	} else {
+		header = bc.GetHeaderByBlockNumber(blockNumber)
+		if header == nil {
+			return nil, xerrors.Errorf("block %d not found", blockNumber)
+		}
+		var err error
+		if buf, err = e.stateAtBeginningOfBlock(blockNumber + 1); err != nil {
+			return nil, err
+		}
+	}
+	return e.applyMessage(e.makeCallMsg(call), buf.StateDB(), header, e.tracingVMConfig(tracer))
+}
+
+// TraceTransaction re-executes a transaction with the given tracer attached to the EVM.
+// The state prior to the transaction is reconstructed by reverting the current state with the
+// undo logs of the subsequent blocks, and replaying the preceding transactions of the same block.
+func (e *EVMEmulator) TraceTransaction(txHash common.Hash, tracer vm.Tracer) (*core.ExecutionResult, error) {
+	bc := e.BlockchainDB()
+	blockNumber, ok := bc.GetBlockNumberByTxHash(txHash)
+	if !ok {
+		return nil, xerrors.New("transaction not found")
+	}
+	index := bc.GetBlockIndexByTxHash(txHash)
+
+	buf, err := e.stateAtBeginningOfBlock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	header := bc.GetPendingHeader()
+	if blockNumber != header.Number.Uint64() {
+		header = bc.GetHeaderByBlockNumber(blockNumber)
+	}
+	signer := types.MakeSigner(e.chainConfig, header.Number)
+
+	for i := uint32(0); i < index; i++ {
+		tx := bc.GetTransactionByBlockNumberAndIndex(blockNumber, i)
+		msg, err := tx.AsMessage(signer, header.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := e.applyMessage(msg, buf.StateDB(), header, e.vmConfig()); err != nil {
+			return nil, xerrors.Errorf("replaying transaction %s: %w", tx.Hash().Hex(), err)
+		}
+	}
+
+	msg, err := bc.GetTransactionByBlockNumberAndIndex(blockNumber, index).AsMessage(signer, header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	return e.applyMessage(msg, buf.StateDB(), header, e.tracingVMConfig(tracer))
+}
+
+// stateAtBeginningOfBlock returns a buffered copy of the state as it was before the first
+// transaction of the given block (which may be the pending block)
+func (e *EVMEmulator) stateAtBeginningOfBlock(blockNumber uint64) (*BufferedStateDB, error) {
+	bc := e.BlockchainDB()
+	pending := bc.GetPendingBlockNumber()
+	if blockNumber > pending {
+		return nil, xerrors.Errorf("block %d not found", blockNumber)
+	}
+	buf := e.StateDB().Buffered()
+	for n := pending; n >= blockNumber && n > 0; n-- {
+		if !bc.HasUndoLog(n) {
+			return nil, xerrors.Errorf("state at block %d is not available (pruned)", blockNumber)
+		}
+		buf.revert(bc.GetUndoLog(n))
+	}
+	return buf, nil
+}
+
+func (e *EVMEmulator) tracingVMConfig(tracer vm.Tracer) vm.Config {
+	config := e.vmConfig()
+	config.Debug = true
+	config.Tracer = tracer
+	config.NoBaseFee = true
+	return config
+}
+
 func (e *EVMEmulator) MintBlock() {
 	e.BlockchainDB().MintBlock(e.timestamp)
 }
@@ -50,7 +50,7 @@ func sendTransaction(t testing.TB, emu *EVMEmulator, sender *ecdsa.PrivateKey, r
 	)
 	require.NoError(t, err)
 
-	receipt, err := emu.SendTransaction(tx)
+	receipt, err := emu.SendTransaction(tx, tx.Gas())
 	require.NoError(t, err)
 	emu.MintBlock()
 
@@ -135,6 +135,39 @@ func TestBlockchain(t *testing.T) {
 	}
 }
 
+func TestUndoLogPruning(t *testing.T) {
+	faucet, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	faucetAddress := crypto.PubkeyToAddress(faucet.PublicKey)
+	receiverAddress := common.Address{1}
+
+	genesisAlloc := map[common.Address]core.GenesisAccount{
+		faucetAddress: {Balance: big.NewInt(1_000_000)},
+	}
+
+	db := dict.Dict{}
+	Init(db, evm.DefaultChainID, evm.BlockKeepAll, evm.GasLimitDefault, 0, genesisAlloc)
+	emu := NewEVMEmulator(db, 1, &iscpBackend{})
+
+	for i := 0; i < undoLogKeepAmount+1; i++ {
+		sendTransaction(t, emu, faucet, receiverAddress, big.NewInt(1), nil)
+	}
+	bc := emu.BlockchainDB()
+	require.EqualValues(t, undoLogKeepAmount+1, bc.GetNumber())
+
+	// the block is kept, but its undo log is not
+	require.True(t, bc.HasBlock(1))
+	require.False(t, bc.HasUndoLog(1))
+	require.Empty(t, bc.GetUndoLog(1))
+	_, err = emu.stateAtBeginningOfBlock(1)
+	require.Error(t, err)
+
+	require.True(t, bc.HasUndoLog(2))
+	buf, err := emu.stateAtBeginningOfBlock(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, buf.StateDB().GetBalance(receiverAddress).Uint64())
+}
+
 func TestBlockchainPersistence(t *testing.T) {
 	// faucet address with initial supply
 	faucet, err := crypto.GenerateKey()
@@ -199,7 +232,7 @@ func deployEVMContract(t testing.TB, emu *EVMEmulator, creator *ecdsa.PrivateKey
 	)
 	require.NoError(t, err)
 
-	receipt, err := emu.SendTransaction(tx)
+	receipt, err := emu.SendTransaction(tx, tx.Gas())
 	require.NoError(t, err)
 	emu.MintBlock()
 
@@ -486,7 +519,7 @@ func benchmarkEVMEmulator(b *testing.B, k int) {
 	b.ResetTimer()
 	for _, chunk := range chunks {
 		for _, tx := range chunk {
-			receipt, err := emu.SendTransaction(tx)
+			receipt, err := emu.SendTransaction(tx, tx.Gas())
 			require.NoError(b, err)
 			require.Equal(b, types.ReceiptStatusSuccessful, receipt.Status)
 		}
@@ -244,3 +244,37 @@ func (b *BufferedStateDB) StateDB() *StateDB {
 func (b *BufferedStateDB) Commit() {
 	b.buf.Mutations().ApplyTo(b.base)
 }
+
+// previousValues returns the values in the base state of the keys modified in the buffer
+// (nil if the key does not exist)
+func (b *BufferedStateDB) previousValues() map[kv.Key][]byte {
+	muts := b.buf.Mutations()
+	ret := make(map[kv.Key][]byte, len(muts.Sets)+len(muts.Dels))
+	get := func(k kv.Key) []byte {
+		if !b.base.MustHas(k) {
+			return nil
+		}
+		if v := b.base.MustGet(k); v != nil {
+			return v
+		}
+		return []byte{}
+	}
+	for k := range muts.Sets {
+		ret[k] = get(k)
+	}
+	for k := range muts.Dels {
+		ret[k] = get(k)
+	}
+	return ret
+}
+
+// revert restores the values recorded in an undo log
+func (b *BufferedStateDB) revert(undoLog map[kv.Key][]byte) {
+	for k, v := range undoLog {
+		if v == nil {
+			b.buf.Del(k)
+		} else {
+			b.buf.Set(k, v)
+		}
+	}
+}
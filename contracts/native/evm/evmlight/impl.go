@@ -7,7 +7,10 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/iotaledger/wasp/contracts/native/evm"
 	"github.com/iotaledger/wasp/contracts/native/evm/evminternal"
 	"github.com/iotaledger/wasp/contracts/native/evm/evmlight/emulator"
@@ -40,6 +43,9 @@ var Processor = Contract.Processor(initialize, append(
 	evm.FuncGetTransactionCountByBlockNumber.WithHandler(getTransactionCountByBlockNumber),
 	evm.FuncGetStorage.WithHandler(getStorage),
 	evm.FuncGetLogs.WithHandler(getLogs),
+	evm.FuncTraceTransaction.WithHandler(traceTransaction),
+	evm.FuncTraceCall.WithHandler(traceCall),
+	evm.FuncGetISCPBlockIndex.WithHandler(getISCPBlockIndex),
 )...)
 
 func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
@@ -66,19 +72,19 @@ func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
 		timestamp(ctx),
 		genesisAlloc,
 	)
+	evminternal.SetISCPBlockIndex(ctx.State(), 0, evminternal.CurrentISCPBlockIndex(ctx))
 	evminternal.InitializeManagement(ctx)
 	return nil, nil
 }
 
 func mintBlock(ctx iscp.Sandbox) (dict.Dict, error) {
 	evminternal.ScheduleNextBlock(ctx)
-	emu := createEmulator(ctx)
-	emu.MintBlock()
+	mintBlockInState(createEmulator(ctx), ctx.State(), evminternal.CurrentISCPBlockIndex(ctx))
 	return nil, nil
 }
 
 func applyTransaction(ctx iscp.Sandbox) (dict.Dict, error) {
-	return evminternal.ApplyTransaction(ctx, func(tx *types.Transaction, blockTime uint32) (*types.Receipt, error) {
+	return evminternal.ApplyTransaction(ctx, func(tx *types.Transaction, gasLimit uint64, blockTime uint32) (*types.Receipt, error) {
 		var emu *emulator.EVMEmulator
 		if blockTime > 0 {
 			// next block will be minted when mintBlock() is called (via timelocked request)
@@ -87,7 +93,7 @@ func applyTransaction(ctx iscp.Sandbox) (dict.Dict, error) {
 			// next block will be minted when the ISCP block is closed
 			emu = getEmulatorInBlockContext(ctx)
 		}
-		return emu.SendTransaction(tx)
+		return emu.SendTransaction(tx, gasLimit)
 	})
 }
 
@@ -193,3 +199,51 @@ func estimateGas(ctx iscp.SandboxView) (dict.Dict, error) {
 	a.RequireNoError(err)
 	return evminternal.Result(codec.EncodeUint64(gas)), nil
 }
+
+func traceTransaction(ctx iscp.SandboxView) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	txHash := common.BytesToHash(ctx.Params().MustGet(evm.FieldTransactionHash))
+	config, err := evmtypes.DecodeTraceConfig(ctx.Params().MustGet(evm.FieldTraceConfig))
+	a.RequireNoError(err)
+
+	emu := createEmulatorForTracing(ctx)
+	bc := emu.BlockchainDB()
+	blockNumber, ok := bc.GetBlockNumberByTxHash(txHash)
+	a.Require(ok, "transaction not found")
+	txctx := &tracers.Context{
+		BlockHash: bc.GetBlockHashByBlockNumber(blockNumber),
+		TxIndex:   int(bc.GetBlockIndexByTxHash(txHash)),
+		TxHash:    txHash,
+	}
+	res, err := evminternal.Trace(config, txctx, func(tracer vm.Tracer) (*core.ExecutionResult, error) {
+		return emu.TraceTransaction(txHash, tracer)
+	})
+	a.RequireNoError(err)
+	return evminternal.Result(res), nil
+}
+
+func traceCall(ctx iscp.SandboxView) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	callMsg, err := evmtypes.DecodeCallMsg(ctx.Params().MustGet(evm.FieldCallMsg))
+	a.RequireNoError(err)
+	config, err := evmtypes.DecodeTraceConfig(ctx.Params().MustGet(evm.FieldTraceConfig))
+	a.RequireNoError(err)
+
+	emu := createEmulatorForTracing(ctx)
+	blockNumber := paramBlockNumberOrHashAsNumber(ctx, emu, true)
+	res, err := evminternal.Trace(config, new(tracers.Context), func(tracer vm.Tracer) (*core.ExecutionResult, error) {
+		return emu.TraceCall(callMsg, blockNumber, tracer)
+	})
+	a.RequireNoError(err)
+	return evminternal.Result(res), nil
+}
+
+func getISCPBlockIndex(ctx iscp.SandboxView) (dict.Dict, error) {
+	emu := createEmulatorR(ctx)
+	blockNumber := paramBlockNumberOrHashAsNumber(ctx, emu, true)
+	blockIndex, ok := evminternal.GetISCPBlockIndex(ctx.State(), blockNumber)
+	if !ok {
+		return nil, nil
+	}
+	return evminternal.Result(codec.EncodeUint32(blockIndex)), nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/assert"
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/buffered"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -25,13 +26,21 @@ import (
 // in the ISCP block; otherwise it returns the previously created instance. The purpose is to
 // create a single Ethereum block for each ISCP block.
 func getEmulatorInBlockContext(ctx iscp.Sandbox) *emulator.EVMEmulator {
+	state := ctx.State()
+	blockIndex := evminternal.CurrentISCPBlockIndex(ctx)
 	bctx := ctx.BlockContext(
 		func(ctx iscp.Sandbox) interface{} { return createEmulator(ctx) },
-		func(bctx interface{}) { bctx.(*emulator.EVMEmulator).MintBlock() },
+		func(bctx interface{}) { mintBlockInState(bctx.(*emulator.EVMEmulator), state, blockIndex) },
 	)
 	return bctx.(*emulator.EVMEmulator)
 }
 
+// mintBlockInState mints the pending EVM block and records the ISCP block index in which it was minted
+func mintBlockInState(emu *emulator.EVMEmulator, state kv.KVStore, blockIndex uint32) {
+	emu.MintBlock()
+	evminternal.SetISCPBlockIndex(state, emu.BlockchainDB().GetNumber(), blockIndex)
+}
+
 func createEmulator(ctx iscp.Sandbox) *emulator.EVMEmulator {
 	return emulator.NewEVMEmulator(evminternal.EVMStateSubrealm(ctx.State()), timestamp(ctx), &iscpBackend{ctx})
 }
@@ -40,6 +49,13 @@ func createEmulatorR(ctx iscp.SandboxView) *emulator.EVMEmulator {
 	return emulator.NewEVMEmulator(evminternal.EVMStateSubrealm(buffered.NewBufferedKVStoreAccess(ctx.State())), timestamp(ctx), &iscpBackendR{ctx})
 }
 
+// createEmulatorForTracing creates an emulator for re-executing past transactions in a view.
+// Since the ISCP context of the original request is not available, ISCP events emitted by the EVM
+// contracts are discarded, and the ISCP entropy is always zero.
+func createEmulatorForTracing(ctx iscp.SandboxView) *emulator.EVMEmulator {
+	return emulator.NewEVMEmulator(evminternal.EVMStateSubrealm(buffered.NewBufferedKVStoreAccess(ctx.State())), timestamp(ctx), &iscpBackendTracing{})
+}
+
 // timestamp returns the current timestamp in seconds since epoch
 func timestamp(ctx iscp.SandboxBase) uint64 {
 	tsNano := time.Duration(ctx.GetTimestamp()) * time.Nanosecond
@@ -142,7 +158,7 @@ func paramBlockNumber(ctx iscp.SandboxView, emu *emulator.EVMEmulator, allowPrev
 	return current
 }
 
-func paramBlockNumberOrHashAsNumber(ctx iscp.SandboxView, emu *emulator.EVMEmulator, allowPrevious bool) uint64 { // nolint:unparam
+func paramBlockNumberOrHashAsNumber(ctx iscp.SandboxView, emu *emulator.EVMEmulator, allowPrevious bool) uint64 {
 	if ctx.Params().MustHas(evm.FieldBlockHash) {
 		a := assert.NewAssert(ctx.Log())
 		blockHash := common.BytesToHash(ctx.Params().MustGet(evm.FieldBlockHash))
@@ -175,3 +191,10 @@ var _ vm.ISCPBackend = &iscpBackendR{}
 
 func (i *iscpBackendR) Event(s string)    { panic("should not happen") }
 func (i *iscpBackendR) Entropy() [32]byte { panic("should not happen") }
+
+type iscpBackendTracing struct{}
+
+var _ vm.ISCPBackend = &iscpBackendTracing{}
+
+func (i *iscpBackendTracing) Event(s string)    {}
+func (i *iscpBackendTracing) Entropy() [32]byte { return [32]byte{} }
@@ -24,6 +24,7 @@ import (
 	"github.com/iotaledger/wasp/packages/solo/solobench"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/stretchr/testify/require"
 )
 
@@ -343,6 +344,39 @@ func TestLoop(t *testing.T) {
 	})
 }
 
+// ensure the EVM cannot use more gas than the remaining gas budget of the request, even if the gas limit
+// of the transaction is higher
+func TestLoopGasBudget(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		evmChain := initEVMChain(t, evmFlavor)
+		loop := evmChain.deployLoopContract(evmChain.faucetKey)
+		gasPerIotas := evmChain.getGasPerIotas()
+
+		iotaWallet, _ := evmChain.solo.NewKeyPairWithFunds()
+		iotas := uint64(100_000)
+		gasBudget := uint64(1_000_000)
+		require.Greater(t, iotas*gasPerIotas, gas.ToEVM(gasBudget))
+		_, err := loop.loop(ethCallOptions{
+			gasLimit: iotas * gasPerIotas,
+			iota:     iotaCallOptions{wallet: iotaWallet, transfer: iotas, gasBudget: gasBudget},
+		})
+		require.Error(t, err)
+		switch evmFlavor.Name {
+		case evmlight.Contract.Name:
+			// the EVM runs out of gas at the budget
+			require.Contains(t, err.Error(), gas.ErrNotEnoughGas.Error())
+		case evmchain.Contract.Name:
+			// the transaction is rejected before running
+			require.Contains(t, err.Error(), "transaction gas limit too high")
+		}
+
+		recs := evmChain.soloChain.GetRequestReceiptsForBlock(evmChain.soloChain.GetLatestBlockInfo().BlockIndex)
+		require.Len(t, recs, 1)
+		require.EqualValues(t, gasBudget, recs[0].GasBudget)
+		require.LessOrEqual(t, recs[0].GasBurned, gasBudget)
+	})
+}
+
 func TestNonFaucetUsers(t *testing.T) {
 	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
 		evmChain := initEVMChain(t, evmFlavor)
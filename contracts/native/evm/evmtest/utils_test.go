@@ -70,8 +70,9 @@ type loopContractInstance struct {
 }
 
 type iotaCallOptions struct {
-	wallet   *ed25519.KeyPair
-	transfer uint64
+	wallet    *ed25519.KeyPair
+	transfer  uint64
+	gasBudget uint64
 }
 
 type ethCallOptions struct {
@@ -132,7 +133,7 @@ func (e *evmChainInstance) buildSoloRequest(funName string, transfer uint64, par
 func (e *evmChainInstance) postRequest(opts []iotaCallOptions, funName string, params ...interface{}) (dict.Dict, error) {
 	opt := e.parseIotaCallOptions(opts)
 	return e.soloChain.PostRequestSync(
-		e.buildSoloRequest(funName, opt.transfer, params...),
+		e.buildSoloRequest(funName, opt.transfer, params...).WithGasBudget(opt.gasBudget),
 		opt.wallet,
 	)
 }
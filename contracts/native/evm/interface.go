@@ -29,6 +29,10 @@ var (
 	FuncGetStorage                          = coreutil.ViewFunc("getStorage")
 	FuncGetLogs                             = coreutil.ViewFunc("getLogs")
 
+	// Debugging
+	FuncTraceTransaction = coreutil.ViewFunc("traceTransaction")
+	FuncTraceCall        = coreutil.ViewFunc("traceCall")
+
 	// EVMchain SC management
 	FuncSetNextOwner    = coreutil.Func("setNextOwner")
 	FuncClaimOwnership  = coreutil.Func("claimOwnership")
@@ -38,6 +42,10 @@ var (
 	FuncWithdrawGasFees = coreutil.Func("withdrawGasFees")
 	FuncSetBlockTime    = coreutil.Func("setBlockTime") // only implemented by evmlight
 	FuncMintBlock       = coreutil.Func("mintBlock")    // only implemented by evmlight
+
+	// FuncGetISCPBlockIndex returns the index of the ISCP block in which the given EVM block was minted.
+	// The result is empty if the contract keeps the history of the EVM state by itself (evmchain)
+	FuncGetISCPBlockIndex = coreutil.ViewFunc("getISCPBlockIndex")
 )
 
 const (
@@ -62,6 +70,7 @@ const (
 	FieldGasUsed                 = "gu"
 	FieldGasLimit                = "gl"
 	FieldFilterQuery             = "fq"
+	FieldTraceConfig             = "tc"
 
 	// evmlight only:
 
@@ -5,6 +5,7 @@ package fairauction
 
 import (
 	"github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib"
+	"github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/corescheduler"
 	"github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
 )
 
@@ -178,9 +179,14 @@ func funcStartAuction(ctx wasmlib.ScFuncContext, f *StartAuctionContext) {
 	}
 	currentAuction.SetValue(auction)
 
-	fa := ScFuncs.FinalizeAuction(ctx)
-	fa.Params.Color().SetValue(auction.Color)
-	fa.Func.Delay(duration * 60).Post()
+	// have the scheduler call finalizeAuction when the auction ends
+	args := wasmlib.NewScDict()
+	args.Set([]byte(ParamColor), wasmtypes.ColorToBytes(auction.Color))
+	sc := corescheduler.ScFuncs.Schedule(ctx)
+	sc.Params.EntryPoint().SetValue(HFuncFinalizeAuction)
+	sc.Params.Delay().SetValue(duration * 60)
+	sc.Params.Args().SetValue(args.Bytes())
+	sc.Func.TransferIotas(1).Call()
 }
 
 func viewGetInfo(ctx wasmlib.ScViewContext, f *GetInfoContext) {
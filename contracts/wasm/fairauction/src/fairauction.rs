@@ -175,9 +175,14 @@ pub fn func_start_auction(ctx: &ScFuncContext, f: &StartAuctionContext) {
     };
     current_auction.set_value(&auction);
 
-    let fa = ScFuncs::finalize_auction(ctx);
-    fa.params.color().set_value(&auction.color);
-    fa.func.delay(duration * 60).post();
+    // have the scheduler call finalizeAuction when the auction ends
+    let args = ScDict::new(&[]);
+    args.set(&string_to_bytes(PARAM_COLOR), &color_to_bytes(&auction.color));
+    let sc = corescheduler::ScFuncs::schedule(ctx);
+    sc.params.entry_point().set_value(HFUNC_FINALIZE_AUCTION);
+    sc.params.delay().set_value(duration * 60);
+    sc.params.args().set_value(&args.to_bytes());
+    sc.func.transfer_iotas(1).call();
 }
 
 pub fn view_get_info(ctx: &ScViewContext, f: &GetInfoContext) {
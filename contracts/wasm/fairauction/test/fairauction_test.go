@@ -42,6 +42,12 @@ func startAuction(t *testing.T) *wasmsolo.SoloContext {
 	return ctx
 }
 
+// endAuction lets the auction time pass and waits for the block in which the scheduler runs finalize_auction
+func endAuction(ctx *wasmsolo.SoloContext) {
+	ctx.AdvanceClockBy(61 * time.Minute)
+	ctx.Chain.Sync()
+}
+
 func TestDeploy(t *testing.T) {
 	ctx := wasmsolo.NewSoloContext(t, fairauction.ScName, fairauction.OnLoad)
 	require.NoError(t, ctx.ContractExists(fairauction.ScName))
@@ -50,7 +56,7 @@ func TestDeploy(t *testing.T) {
 func TestFaStartAuction(t *testing.T) {
 	ctx := startAuction(t)
 
-	// note 1 iota should be stuck in the delayed finalize_auction
+	// note 1 iota is held by the scheduler as deposit for finalize_auction
 	require.EqualValues(t, 25-1, ctx.Balance(ctx.Account()))
 	require.EqualValues(t, 10, ctx.Balance(ctx.Account(), tokenColor))
 
@@ -59,9 +65,8 @@ func TestFaStartAuction(t *testing.T) {
 	require.EqualValues(t, 0, auctioneer.Balance(tokenColor))
 	require.EqualValues(t, 0, ctx.Balance(auctioneer))
 
-	// remove pending finalize_auction from backlog
-	ctx.AdvanceClockBy(61 * time.Minute)
-	require.True(t, ctx.WaitForPendingRequests(1))
+	// run the scheduled finalize_auction
+	endAuction(ctx)
 }
 
 func TestFaAuctionInfo(t *testing.T) {
@@ -75,17 +80,15 @@ func TestFaAuctionInfo(t *testing.T) {
 	require.EqualValues(t, auctioneer.ScAgentID(), getInfo.Results.Creator().Value())
 	require.EqualValues(t, 0, getInfo.Results.Bidders().Value())
 
-	// remove pending finalize_auction from backlog
-	ctx.AdvanceClockBy(61 * time.Minute)
-	require.True(t, ctx.WaitForPendingRequests(1))
+	// run the scheduled finalize_auction
+	endAuction(ctx)
 }
 
 func TestFaNoBids(t *testing.T) {
 	ctx := startAuction(t)
 
 	// wait for finalize_auction
-	ctx.AdvanceClockBy(61 * time.Minute)
-	require.True(t, ctx.WaitForPendingRequests(1))
+	endAuction(ctx)
 
 	getInfo := fairauction.ScFuncs.GetInfo(ctx)
 	getInfo.Params.Color().SetValue(tokenColor)
@@ -104,15 +107,8 @@ func TestFaOneBidTooLow(t *testing.T) {
 	placeBid.Func.TransferIotas(100).Post()
 	require.Error(t, ctx.Err)
 
-	// TODO this should be a simple 1 request to wait for, but sometimes
-	// the finalizeAuction will have already been triggered (bug), so
-	// instead of waiting for that single finalizeAuction request we
-	// will (erroneously) wait for the inbuf and outbuf counts to equalize
-	info := ctx.Chain.MempoolInfo()
-
 	// wait for finalize_auction
-	ctx.AdvanceClockBy(61 * time.Minute)
-	require.True(t, ctx.WaitForPendingRequests(-info.InBufCounter))
+	endAuction(ctx)
 
 	getInfo := fairauction.ScFuncs.GetInfo(ctx)
 	getInfo.Params.Color().SetValue(tokenColor)
@@ -132,15 +128,8 @@ func TestFaOneBid(t *testing.T) {
 	placeBid.Func.TransferIotas(5000).Post()
 	require.NoError(t, ctx.Err)
 
-	// TODO this should be a simple 1 request to wait for, but sometimes
-	// the finalizeAuction will have already been triggered (bug), so
-	// instead of waiting for that single finalizeAuction request we
-	// will (erroneously) wait for the inbuf and outbuf counts to equalize
-	info := ctx.Chain.MempoolInfo()
-
 	// wait for finalize_auction
-	ctx.AdvanceClockBy(61 * time.Minute)
-	require.True(t, ctx.WaitForPendingRequests(-info.InBufCounter))
+	endAuction(ctx)
 
 	getInfo := fairauction.ScFuncs.GetInfo(ctx)
 	getInfo.Params.Color().SetValue(tokenColor)
@@ -150,4 +139,7 @@ func TestFaOneBid(t *testing.T) {
 	require.EqualValues(t, 1, getInfo.Results.Bidders().Value())
 	require.EqualValues(t, 5000, getInfo.Results.HighestBid().Value())
 	require.Equal(t, bidder.ScAddress().AsAgentID(), getInfo.Results.HighestBidder().Value())
+
+	// the auction was finalized by the scheduler, the highest bidder got the tokens
+	require.EqualValues(t, 10, bidder.Balance(tokenColor))
 }
@@ -2,6 +2,7 @@
 // SPDX-License-Identifier: Apache-2.0
 
 import * as wasmlib from "wasmlib"
+import * as corescheduler from "wasmlib/corescheduler"
 import * as wasmtypes from "wasmlib/wasmtypes";
 import * as sc from "./index";
 
@@ -170,9 +171,14 @@ export function funcStartAuction(ctx: wasmlib.ScFuncContext, f: sc.StartAuctionC
     auction.whenStarted = ctx.timestamp();
     currentAuction.setValue(auction);
 
-    let fa = sc.ScFuncs.finalizeAuction(ctx);
-    fa.params.color().setValue(auction.color);
-    fa.func.delay(duration * 60).post();
+    // have the scheduler call finalizeAuction when the auction ends
+    let args = new wasmlib.ScDict([]);
+    args.set(wasmtypes.stringToBytes(sc.ParamColor), wasmtypes.colorToBytes(auction.color));
+    let schedule = corescheduler.ScFuncs.schedule(ctx);
+    schedule.params.entryPoint().setValue(sc.HFuncFinalizeAuction);
+    schedule.params.delay().setValue(duration * 60);
+    schedule.params.args().setValue(args.toBytes());
+    schedule.func.transferIotas(1).call();
 }
 
 export function viewGetInfo(ctx: wasmlib.ScViewContext, f: sc.GetInfoContext): void {
@@ -6,10 +6,8 @@ package test
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/iotaledger/wasp/contracts/wasm/inccounter/go/inccounter"
-	"github.com/iotaledger/wasp/packages/wasmvm/wasmhost"
 	"github.com/iotaledger/wasp/packages/wasmvm/wasmsolo"
 	"github.com/stretchr/testify/require"
 )
@@ -150,10 +148,7 @@ func TestVliCodec(t *testing.T) {
 	ctx := setupTest(t)
 
 	f := inccounter.ScFuncs.TestVliCodec(ctx)
-	save := wasmhost.DisableWasmTimeout
-	wasmhost.DisableWasmTimeout = false
 	f.Func.Post()
-	wasmhost.DisableWasmTimeout = save
 	require.NoError(t, ctx.Err)
 }
 
@@ -161,10 +156,7 @@ func TestVluCodec(t *testing.T) {
 	ctx := setupTest(t)
 
 	f := inccounter.ScFuncs.TestVluCodec(ctx)
-	save := wasmhost.DisableWasmTimeout
-	wasmhost.DisableWasmTimeout = false
 	f.Func.Post()
-	wasmhost.DisableWasmTimeout = save
 	require.NoError(t, ctx.Err)
 }
 
@@ -200,19 +192,15 @@ func TestLoop(t *testing.T) {
 	ctx := setupTest(t)
 
 	if !ctx.IsWasm || *wasmsolo.UseWasmEdge {
-		// no timeout possible because goroutines cannot be killed
-		// or because there is no way to interrupt the Wasm code
+		// no gas metering possible because Go code is not metered
+		// or because WasmEdge does not consume fuel
 		t.SkipNow()
 	}
 
-	save := wasmhost.DisableWasmTimeout
-	wasmhost.DisableWasmTimeout = false
-	wasmhost.WasmTimeout = 1 * time.Second
 	endlessLoop := inccounter.ScFuncs.EndlessLoop(ctx)
 	endlessLoop.Func.Post()
 	require.Error(t, ctx.Err)
-	require.Contains(t, ctx.Err.Error(), "interrupt")
-	wasmhost.DisableWasmTimeout = save
+	require.Contains(t, ctx.Err.Error(), "gas budget exceeded")
 
 	inccounter.ScFuncs.Increment(ctx).Func.Post()
 	require.NoError(t, ctx.Err)
@@ -85,13 +85,13 @@ func TestOffLedgerFeesEnough(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 10)
+		require.EqualValues(t, solo.Saldo-10, user.Balance())
+		require.EqualValues(t, 10, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+10)
 
-		deposit(t, ctx, user, nil, 10)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-10, user.Balance())
 		require.EqualValues(t, 10, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
@@ -120,13 +120,13 @@ func TestOffLedgerFeesNotEnough(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 9)
+		require.EqualValues(t, solo.Saldo-9, user.Balance())
+		require.EqualValues(t, 9, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+9)
 
-		deposit(t, ctx, user, nil, 9)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-9, user.Balance())
 		require.EqualValues(t, 9, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
@@ -156,13 +156,13 @@ func TestOffLedgerFeesExtra(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 11)
+		require.EqualValues(t, solo.Saldo-11, user.Balance())
+		require.EqualValues(t, 11, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+11)
 
-		deposit(t, ctx, user, nil, 11)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-11, user.Balance())
 		require.EqualValues(t, 11, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
@@ -191,13 +191,13 @@ func TestOffLedgerTransferWithFeesEnough(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 10+42)
+		require.EqualValues(t, solo.Saldo-10-42, user.Balance())
+		require.EqualValues(t, 10+42, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+10+42)
 
-		deposit(t, ctx, user, nil, 10+42)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-10-42, user.Balance())
 		require.EqualValues(t, 10+42, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
@@ -226,13 +226,13 @@ func TestOffLedgerTransferWithFeesNotEnough(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 10+41)
+		require.EqualValues(t, solo.Saldo-10-41, user.Balance())
+		require.EqualValues(t, 10+41, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+10+41)
 
-		deposit(t, ctx, user, nil, 10+41)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-10-41, user.Balance())
 		require.EqualValues(t, 10+41, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
@@ -261,13 +261,13 @@ func TestOffLedgerTransferWithFeesExtra(t *testing.T) {
 		require.EqualValues(t, 0, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
 
-		setOwnerFee(t, ctx, 10)
-		require.EqualValues(t, solo.Saldo, user.Balance())
-		require.EqualValues(t, 0, ctx.Balance(user))
+		deposit(t, ctx, user, nil, 10+43)
+		require.EqualValues(t, solo.Saldo-10-43, user.Balance())
+		require.EqualValues(t, 10+43, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
-		chainAccountBalances(ctx, w, 3, 3)
+		chainAccountBalances(ctx, w, 2, 2+10+43)
 
-		deposit(t, ctx, user, nil, 10+43)
+		setOwnerFee(t, ctx, 10)
 		require.EqualValues(t, solo.Saldo-10-43, user.Balance())
 		require.EqualValues(t, 10+43, ctx.Balance(user))
 		require.EqualValues(t, 0, ctx.Balance(ctx.Account()))
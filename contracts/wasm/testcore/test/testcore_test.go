@@ -149,9 +149,8 @@ func setDeployer(t *testing.T, ctx *wasmsolo.SoloContext, deployer *wasmsolo.Sol
 
 func setOwnerFee(t *testing.T, ctx *wasmsolo.SoloContext, amount int64) {
 	ctxGov := ctx.SoloContextForCore(t, coregovernance.ScName, coregovernance.OnLoad)
-	f := coregovernance.ScFuncs.SetContractFee(ctxGov)
-	f.Params.Hname().SetValue(testcore.HScName)
-	f.Params.OwnerFee().SetValue(amount)
+	f := coregovernance.ScFuncs.SetFeePolicy(ctxGov)
+	f.Params.MinFee().SetValue(amount)
 	f.Func.Post()
 	require.NoError(t, ctxGov.Err)
 }
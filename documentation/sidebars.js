@@ -98,6 +98,11 @@ module.exports = {
                             label: 'Governance',
                             id: 'guide/core_concepts/core_contracts/governance',
                         },
+                        {
+                            type: 'doc',
+                            label: 'Scheduler',
+                            id: 'guide/core_concepts/core_contracts/scheduler',
+                        },
                     ],
                 },
                 {
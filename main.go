@@ -24,6 +24,7 @@ import (
 	"github.com/iotaledger/wasp/plugins/wal"
 	"github.com/iotaledger/wasp/plugins/wasmtimevm"
 	"github.com/iotaledger/wasp/plugins/webapi"
+	"github.com/iotaledger/wasp/plugins/webhooks"
 )
 
 func main() {
@@ -47,6 +48,7 @@ func main() {
 		wal.Init(),
 		chains.Init(),
 		metrics.Init(),
+		webhooks.Init(),
 		webapi.Init(),
 		publishernano.Init(),
 		dashboard.Init(),
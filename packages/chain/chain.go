@@ -20,6 +20,7 @@ import (
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/util/ready"
+	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 )
@@ -32,6 +33,7 @@ type ChainCore interface {
 	Processors() *processors.Cache
 	GlobalStateSync() coreutil.ChainStateSync
 	GetStateReader() state.OptimisticStateReader
+	GetStateReaderAtIndex(blockIndex uint32) (state.OptimisticStateReader, error)
 	GetChainNodes() []peering.PeerStatusProvider     // CommitteeNodes + AccessNodes
 	GetCandidateNodes() []*governance.AccessNodeInfo // All the current candidates.
 	Log() *logger.Logger
@@ -57,8 +59,10 @@ type ChainEntry interface {
 // ChainRequests is an interface to query status of the request
 type ChainRequests interface {
 	GetRequestProcessingStatus(id iscp.RequestID) RequestProcessingStatus
+	GetPendingRequests() []iscp.Request
 	AttachToRequestProcessed(func(iscp.RequestID)) (attachID *events.Closure)
 	DetachFromRequestProcessed(attachID *events.Closure)
+	DryRunRequest(req iscp.Request) (*vm.DryRunResult, error)
 }
 
 type ChainMetrics interface {
@@ -170,6 +174,7 @@ type Mempool interface {
 	ReadyFromIDs(nowis time.Time, reqIDs ...iscp.RequestID) ([]iscp.Request, []int, bool)
 	HasRequest(id iscp.RequestID) bool
 	GetRequest(id iscp.RequestID) iscp.Request
+	GetPendingRequests() []iscp.Request
 	Info() MempoolInfo
 	WaitRequestInPool(reqid iscp.RequestID, timeout ...time.Duration) bool // for testing
 	WaitInBufferEmpty(timeout ...time.Duration) bool                       // for testing
@@ -188,12 +193,20 @@ type WAL interface {
 }
 
 type MempoolInfo struct {
-	TotalPool      int
-	ReadyCounter   int
-	InBufCounter   int
-	OutBufCounter  int
-	InPoolCounter  int
-	OutPoolCounter int
+	TotalPool       int
+	ReadyCounter    int
+	InBufCounter    int
+	OutBufCounter   int
+	InPoolCounter   int
+	OutPoolCounter  int
+	EvictedCounter  int // off-ledger requests evicted after the TTL
+	RejectedCounter int // off-ledger requests rejected because of the per-sender limit
+	// limits of the mempool, 0 means no limit
+	MaxBatchRequests          int
+	MaxBatchBytes             int
+	MaxBatchRequestsPerSender int
+	MaxPoolRequestsPerSender  int
+	OffLedgerTTL              time.Duration
 }
 
 type SyncInfo struct {
@@ -46,6 +46,7 @@ var (
 
 type chainObj struct {
 	committee                          atomic.Value
+	chainOutput                        atomic.Value // *ledgerstate.AliasOutput of the last chain transition
 	mempool                            chain.Mempool
 	mempoolLastCleanedIndex            uint32
 	dismissed                          atomic.Bool
@@ -102,6 +103,7 @@ func NewChain(
 	offledgerBroadcastUpToNPeers int,
 	offledgerBroadcastInterval time.Duration,
 	pullMissingRequestsFromCommittee bool,
+	mempoolConfig *mempool.Config,
 	chainMetrics metrics.ChainMetrics,
 	wal chain.WAL,
 ) chain.Chain {
@@ -110,7 +112,7 @@ func NewChain(
 	chainLog := log.Named(chainID.Base58()[:6] + ".")
 	chainStateSync := coreutil.NewChainStateSync()
 	ret := &chainObj{
-		mempool:        mempool.New(state.NewOptimisticStateReader(db, chainStateSync), blobProvider, chainLog, chainMetrics),
+		mempool:        mempool.New(state.NewOptimisticStateReader(db, chainStateSync), blobProvider, chainLog, chainMetrics, mempoolConfig),
 		procset:        processors.MustNew(processorConfig),
 		chainID:        chainID,
 		log:            chainLog,
@@ -237,6 +239,7 @@ func (c *chainObj) receiveChainPeerMessages(peerMsg *peering.PeerMessageIn) {
 func (c *chainObj) processChainTransition(msg *chain.ChainTransitionEventData) {
 	stateIndex := msg.VirtualState.BlockIndex()
 	c.log.Debugf("processChainTransition: processing state %d", stateIndex)
+	c.chainOutput.Store(msg.ChainOutput)
 	if !msg.ChainOutput.GetIsGovernanceUpdated() {
 		c.log.Debugf("processChainTransition state %d: output %s is not governance updated; state hash %s; last cleaned state is %d",
 			stateIndex, iscp.OID(msg.ChainOutput.ID()), msg.VirtualState.StateCommitment().String(), c.mempoolLastCleanedIndex)
@@ -263,7 +266,8 @@ func (c *chainObj) processChainTransition(msg *chain.ChainTransitionEventData) {
 			for _, reqid := range reqids {
 				c.eventRequestProcessed.Trigger(reqid)
 			}
-			c.publishNewBlockEvents(stateIndex)
+			c.publishNewBlockEvents(i)
+			c.publishChainEvents(chainID, i)
 
 			c.log.Debugf("processChainTransition state %d: state %d cleaned, deleted requests: %+v",
 				stateIndex, i, iscp.ShortRequestIDs(reqids))
@@ -357,6 +361,16 @@ func (c *chainObj) publishNewBlockEvents(blockIndex uint32) {
 	}()
 }
 
+// publishChainEvents publishes the structured events of the block for the event stream
+func (c *chainObj) publishChainEvents(chainID *iscp.ChainID, blockIndex uint32) {
+	if blockIndex == 0 {
+		return
+	}
+	if err := chain.PublishBlockEvents(chainID, c.stateReader.KVStoreReader(), blockIndex); err != nil {
+		c.log.Errorf("publishChainEvents: failed to publish events of block %d: %v", blockIndex, err)
+	}
+}
+
 func (c *chainObj) getCommittee() chain.Committee {
 	ret := c.committee.Load().(*committeeStruct)
 	if !ret.valid {
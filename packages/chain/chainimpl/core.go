@@ -56,6 +56,11 @@ func (c *chainObj) GetStateReader() state.OptimisticStateReader {
 	return state.NewOptimisticStateReader(c.db, c.chainStateSync)
 }
 
+// GetStateReaderAtIndex returns the read-only access to the state as it was after the block with the given index
+func (c *chainObj) GetStateReaderAtIndex(blockIndex uint32) (state.OptimisticStateReader, error) {
+	return state.NewStateReaderAtIndex(c.db, c.chainID, blockIndex)
+}
+
 func (c *chainObj) GetChainNodes() []peering.PeerStatusProvider {
 	return c.chainPeers.PeerStatus()
 }
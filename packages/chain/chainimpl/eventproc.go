@@ -4,6 +4,7 @@
 package chainimpl
 
 import (
+	"bytes"
 	"errors"
 	"time"
 
@@ -133,11 +134,14 @@ func (c *chainObj) handleLedgerState(msg *messages.StateMsg) {
 
 func (c *chainObj) rotateCommitteeIfNeeded(anchorOutput *ledgerstate.AliasOutput, currentCmt chain.Committee) error {
 	if currentCmt.Address().Equals(anchorOutput.GetStateAddress()) {
-		// nothing changed. no rotation
-		return nil
-	}
-	// address changed
-	if !anchorOutput.GetIsGovernanceUpdated() {
+		if !c.isDKShareReplaced(currentCmt) {
+			// nothing changed. no rotation
+			return nil
+		}
+		// the key was reshared: the address is the same, the committee is not
+		c.log.Infof("the key share of the committee for %s has been replaced", currentCmt.Address().Base58())
+	} else if !anchorOutput.GetIsGovernanceUpdated() {
+		// address changed
 		return xerrors.Errorf("rotateCommitteeIfNeeded: inconsistency. Governance transition expected... New output: %s", anchorOutput.String())
 	}
 	dkShare, err := c.getChainDKShare(anchorOutput.GetStateAddress())
@@ -181,6 +185,23 @@ func (c *chainObj) createCommitteeIfNeeded(anchorOutput *ledgerstate.AliasOutput
 	return nil
 }
 
+// isDKShareReplaced is true if the key share of the committee in the registry is not the one the committee
+// was created with, i.e. the key was reshared or this node left the committee
+func (c *chainObj) isDKShareReplaced(cmt chain.Committee) bool {
+	if cmt.DKShare() == nil {
+		return false
+	}
+	dkShare, err := c.dksProvider.LoadDKShare(cmt.Address())
+	if errors.Is(err, registry.ErrDKShareNotFound) {
+		return true
+	}
+	if err != nil {
+		c.log.Warnf("isDKShareReplaced: unable to load dkShare: %v", err)
+		return false
+	}
+	return !bytes.Equal(dkShare.Bytes(), cmt.DKShare().Bytes())
+}
+
 func (c *chainObj) getChainDKShare(addr ledgerstate.Address) (*tcrypto.DKShare, error) {
 	//
 	// just in case check if I am among committee nodes
@@ -392,6 +413,7 @@ func (c *chainObj) handleTimerTick(msg messages.TimerTick) {
 	}
 	if msg%40 == 0 {
 		stats := c.mempool.Info()
-		c.log.Debugf("mempool total = %d, ready = %d, in = %d, out = %d", stats.TotalPool, stats.ReadyCounter, stats.InPoolCounter, stats.OutPoolCounter)
+		c.log.Debugf("mempool total = %d, ready = %d, in = %d, out = %d, evicted = %d, rejected = %d",
+			stats.TotalPool, stats.ReadyCounter, stats.InPoolCounter, stats.OutPoolCounter, stats.EvictedCounter, stats.RejectedCounter)
 	}
 }
@@ -5,10 +5,21 @@
 package chainimpl
 
 import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/kv/optimism"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/runvm"
+	"golang.org/x/xerrors"
 )
 
 func (c *chainObj) GetRequestProcessingStatus(reqID iscp.RequestID) chain.RequestProcessingStatus {
@@ -28,6 +39,14 @@ func (c *chainObj) GetRequestProcessingStatus(reqID iscp.RequestID) chain.Reques
 	return chain.RequestProcessingStatusCompleted
 }
 
+// GetPendingRequests returns the requests in the mempool of the node which are not processed yet
+func (c *chainObj) GetPendingRequests() []iscp.Request {
+	if c.IsDismissed() {
+		return nil
+	}
+	return c.mempool.GetPendingRequests()
+}
+
 func (c *chainObj) AttachToRequestProcessed(handler func(iscp.RequestID)) *events.Closure {
 	closure := events.NewClosure(handler)
 	c.eventRequestProcessed.Attach(closure)
@@ -37,3 +56,49 @@ func (c *chainObj) AttachToRequestProcessed(handler func(iscp.RequestID)) *event
 func (c *chainObj) DetachFromRequestProcessed(attachID *events.Closure) {
 	c.eventRequestProcessed.Detach(attachID)
 }
+
+// DryRunRequest runs the request on a throwaway copy of the current chain state and returns what
+// the request would do if it was processed now. Nothing is committed
+func (c *chainObj) DryRunRequest(req iscp.Request) (*vm.DryRunResult, error) {
+	if c.IsDismissed() {
+		return nil, xerrors.New("chain is dismissed")
+	}
+	ok, err := request.SolidifyArgs(req, c.blobProvider)
+	if err != nil {
+		return nil, xerrors.Errorf("DryRunRequest: %w", err)
+	}
+	if !ok {
+		return nil, xerrors.New("DryRunRequest: blobs referenced by the request are not available on the node")
+	}
+	var ret *vm.DryRunResult
+	err = optimism.RetryOnStateInvalidated(func() error {
+		chainOutput, ok := c.chainOutput.Load().(*ledgerstate.AliasOutput)
+		if !ok || chainOutput == nil {
+			return xerrors.New("DryRunRequest: chain state is not synced yet")
+		}
+		baseline := c.chainStateSync.GetSolidIndexBaseline()
+		if !baseline.IsValid() {
+			return coreutil.ErrorStateInvalidated
+		}
+		virtualState, exists, err := state.LoadSolidState(c.db, c.chainID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return xerrors.New("DryRunRequest: chain state does not exist")
+		}
+		ret, err = runvm.DryRun(&vm.VMTask{
+			Processors:         c.procset,
+			ChainInput:         chainOutput,
+			VirtualStateAccess: virtualState,
+			SolidStateBaseline: baseline,
+			Requests:           []iscp.Request{req},
+			Timestamp:          time.Now(),
+			Entropy:            hashing.HashData(chainOutput.ID().Bytes()),
+			ValidatorFeeTarget: iscp.NewAgentID(c.chainID.AsAddress(), 0),
+			Log:                c.log,
+		})
+		return err
+	})
+	return ret, err
+}
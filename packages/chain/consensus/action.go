@@ -17,11 +17,14 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/iscp/rotate"
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"golang.org/x/xerrors"
 )
 
@@ -61,8 +64,8 @@ func (c *consensus) proposeBatchIfNeeded() {
 		return
 	}
 	reqs := c.mempool.ReadyNow()
-	if len(reqs) == 0 {
-		c.log.Debugf("proposeBatch not needed: no ready requests in mempool")
+	if len(reqs) == 0 && !c.hasDueScheduledCalls(time.Now()) {
+		c.log.Debugf("proposeBatch not needed: no ready requests in mempool and no scheduled calls due")
 		return
 	}
 	c.log.Debugf("proposeBatch needed: ready requests len = %d", len(reqs))
@@ -106,9 +109,9 @@ func (c *consensus) runVMIfNeeded() {
 		c.pollMissingRequests(missingRequestIndexes)
 		return
 	}
-	if len(reqs) == 0 {
+	if len(reqs) == 0 && !c.hasDueScheduledCalls(c.consensusBatch.Timestamp) {
 		// due to change in time, all requests became non processable ACS must be run again
-		c.log.Debugf("runVM not needed: empty list of processable requests. Reset workflow")
+		c.log.Debugf("runVM not needed: empty list of processable requests and no scheduled calls due. Reset workflow")
 		c.resetWorkflow()
 		return
 	}
@@ -428,6 +431,16 @@ func (c *consensus) pullInclusionStateIfNeeded() {
 	c.log.Debugf("pullInclusionState: request for inclusion state sent")
 }
 
+// hasDueScheduledCalls is true if calls of the scheduler contract are due at the given time.
+// They are run at the start of the block, so a block is needed even if there are no requests
+func (c *consensus) hasDueScheduledCalls(ts time.Time) bool {
+	if c.currentState == nil {
+		return false
+	}
+	schedulerState := subrealm.NewReadOnly(c.currentState.KVStoreReader(), kv.Key(scheduler.Contract.Hname().Bytes()))
+	return scheduler.HasDueCalls(schedulerState, ts)
+}
+
 // prepareBatchProposal creates a batch proposal structure out of requests
 func (c *consensus) prepareBatchProposal(reqs []iscp.Request) *BatchProposal {
 	ts := time.Now()
@@ -530,15 +543,6 @@ func (c *consensus) receiveACS(values [][]byte, sessionID uint64) {
 
 	// calculate intersection of proposals
 	inBatchIDs, inBatchHashes := calcIntersection(acs, c.committee.Size())
-	if len(inBatchIDs) == 0 {
-		// if intersection is empty, reset workflow and retry after some time. It means not all requests
-		// reached nodes and we have give it a time. Should not happen often
-		c.log.Warnf("receiveACS: ACS intersection (light) is empty. reset workflow. State index: %d, ACS sessionID %d",
-			c.stateOutput.GetStateIndex(), sessionID)
-		c.resetWorkflow()
-		c.delayBatchProposalUntil = time.Now().Add(c.timers.ProposeBatchRetry)
-		return
-	}
 	// calculate other batch parameters in a deterministic way
 	par, err := c.calcBatchParameters(acs)
 	if err != nil {
@@ -547,6 +551,17 @@ func (c *consensus) receiveACS(values [][]byte, sessionID uint64) {
 			c.stateOutput.GetStateIndex(), sessionID, err)
 		c.resetWorkflow()
 		c.delayBatchProposalUntil = time.Now().Add(c.timers.ProposeBatchRetry)
+		return
+	}
+	if len(inBatchIDs) == 0 && !c.hasDueScheduledCalls(par.timestamp) {
+		// if intersection is empty, reset workflow and retry after some time. It means not all requests
+		// reached nodes and we have give it a time. Should not happen often.
+		// An empty batch is run only for the scheduled calls which are due at the timestamp of the batch
+		c.log.Warnf("receiveACS: ACS intersection (light) is empty. reset workflow. State index: %d, ACS sessionID %d",
+			c.stateOutput.GetStateIndex(), sessionID)
+		c.resetWorkflow()
+		c.delayBatchProposalUntil = time.Now().Add(c.timers.ProposeBatchRetry)
+		return
 	}
 	c.consensusBatch = &BatchProposal{
 		ValidatorIndex:      c.committee.OwnPeerIndex(),
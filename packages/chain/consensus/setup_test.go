@@ -202,7 +202,7 @@ func (env *MockedEnv) NewNode(nodeIndex uint16, timers ConsensusTimers) *mockedN
 		}
 	})
 	mempoolMetrics := metrics.DefaultChainMetrics()
-	ret.Mempool = mempool.New(ret.ChainCore.GetStateReader(), iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	ret.Mempool = mempool.New(ret.ChainCore.GetStateReader(), iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 
 	//
 	// Pass the ACS mock, if it was set in env.MockedACS.
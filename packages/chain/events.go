@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/optimism"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"golang.org/x/xerrors"
+)
+
+// BlockEvents builds the structured events of the block from the blocklog in the chain state:
+// 'chain_rotated' if the state controller has changed, then 'request_receipt' followed by the 'contract_event's
+// of each request, and 'block_committed' as the last event.
+// Returns false if the block does not exist yet. Returns an error if the receipts of the block have been pruned
+func BlockEvents(chainID *iscp.ChainID, stateReader kv.KVStoreReader, blockIndex uint32) ([]*publisher.ChainEvent, bool, error) {
+	blockInfo, ok, err := blocklog.GetBlockInfo(stateReader, blockIndex)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	receipts, err := blocklog.GetRequestReceiptsForBlock(stateReader, blockIndex)
+	if err != nil {
+		return nil, false, xerrors.Errorf("BlockEvents: %w", err)
+	}
+	ret := make([]*publisher.ChainEvent, 0, 2*len(receipts)+1)
+	add := func(typ string, payload interface{}) {
+		ret = append(ret, &publisher.ChainEvent{
+			Type:    typ,
+			ChainID: chainID.Base58(),
+			Cursor:  publisher.Cursor{BlockIndex: blockIndex, EventIndex: uint32(len(ret))},
+			Payload: payload,
+		})
+	}
+
+	addrs, err := blocklog.GetControlAddressesChangedInBlock(stateReader, blockIndex)
+	if err != nil {
+		return nil, false, xerrors.Errorf("BlockEvents: %w", err)
+	}
+	if addrs != nil {
+		add(publisher.ChainEventChainRotated, &publisher.ChainRotated{
+			StateControllerAddress: addrs.StateAddress.Base58(),
+			GoverningAddress:       addrs.GoverningAddress.Base58(),
+		})
+	}
+	for _, rec := range receipts {
+		target := rec.Request.Target()
+		add(publisher.ChainEventRequestReceipt, &publisher.RequestReceipt{
+			RequestID:    rec.Request.ID().String(),
+			RequestIndex: rec.RequestIndex,
+			OffLedger:    rec.Request.IsOffLedger(),
+			Sender:       rec.Request.SenderAccount().String(),
+			Contract:     target.Contract.String(),
+			EntryPoint:   target.EntryPoint.String(),
+			Error:        rec.Error,
+			GasBudget:    rec.GasBudget,
+			GasBurned:    rec.GasBurned,
+		})
+		evts, err := blocklog.GetRequestEvents(stateReader, blockIndex, rec.RequestIndex)
+		if err != nil {
+			return nil, false, xerrors.Errorf("BlockEvents: %w", err)
+		}
+		for _, evt := range evts {
+			add(publisher.ChainEventContractEvent, &publisher.ContractEvent{
+				RequestIndex: rec.RequestIndex,
+				Contract:     evt.Contract.String(),
+				Message:      evt.Message,
+			})
+		}
+	}
+	add(publisher.ChainEventBlockCommitted, &publisher.BlockCommitted{
+		Timestamp:             blockInfo.Timestamp,
+		TotalRequests:         blockInfo.TotalRequests,
+		NumSuccessfulRequests: blockInfo.NumSuccessfulRequests,
+		NumOffLedgerRequests:  blockInfo.NumOffLedgerRequests,
+		NumScheduledCalls:     blockInfo.NumScheduledCalls,
+		PreviousStateHash:     blockInfo.PreviousStateHash.String(),
+	})
+	return ret, true, nil
+}
+
+// PublishBlockEvents publishes the structured events of the committed block
+func PublishBlockEvents(chainID *iscp.ChainID, stateReader kv.KVStoreReader, blockIndex uint32) error {
+	evts, ok, err := BlockEvents(chainID, stateReader, blockIndex)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return xerrors.Errorf("PublishBlockEvents: block #%d not found", blockIndex)
+	}
+	publisher.PublishChainEvents(evts)
+	return nil
+}
+
+// BlockEventSource reads the events of the past blocks of the chain from the blocklog in its state
+type BlockEventSource struct {
+	chain ChainCore
+}
+
+func NewBlockEventSource(ch ChainCore) *BlockEventSource {
+	return &BlockEventSource{chain: ch}
+}
+
+// LatestBlockIndex returns the index of the latest block of the chain state
+func (s *BlockEventSource) LatestBlockIndex() (uint32, error) {
+	var ret uint32
+	err := optimism.RetryOnStateInvalidated(func() error {
+		var err error
+		stateReader := s.chain.GetStateReader()
+		stateReader.SetBaseline()
+		ret, err = stateReader.BlockIndex()
+		return err
+	})
+	return ret, err
+}
+
+// BlockEvents returns the events of the block. Returns false if the block does not exist yet
+func (s *BlockEventSource) BlockEvents(blockIndex uint32) ([]*publisher.ChainEvent, bool, error) {
+	var ret []*publisher.ChainEvent
+	var found bool
+	err := optimism.RetryOnStateInvalidated(func() error {
+		var err error
+		stateReader := s.chain.GetStateReader()
+		stateReader.SetBaseline()
+		ret, found, err = BlockEvents(s.chain.ID(), stateReader.KVStoreReader(), blockIndex)
+		return err
+	})
+	return ret, found, err
+}
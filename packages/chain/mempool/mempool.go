@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/iscp"
@@ -35,6 +36,11 @@ type mempool struct {
 	solidificationLoopDelay time.Duration
 	log                     *logger.Logger
 	mempoolMetrics          metrics.MempoolMetrics
+	config                  Config
+	policy                  SelectionPolicy
+	poolRequestsPerSender   map[[ledgerstate.AddressLength]byte]int
+	evictedCounter          int
+	rejectedCounter         int
 }
 
 type requestRef struct {
@@ -49,15 +55,24 @@ const (
 
 var _ chain.Mempool = &mempool{}
 
-func New(stateReader state.OptimisticStateReader, blobCache registry.BlobCache, log *logger.Logger, mempoolMetrics metrics.MempoolMetrics, solidificationLoopDelay ...time.Duration) chain.Mempool {
+// New creates the mempool. If config is nil, the mempool has no limits
+func New(stateReader state.OptimisticStateReader, blobCache registry.BlobCache, log *logger.Logger, mempoolMetrics metrics.MempoolMetrics, config *Config, solidificationLoopDelay ...time.Duration) chain.Mempool {
 	ret := &mempool{
-		inBuffer:       make(map[iscp.RequestID]iscp.Request),
-		stateReader:    stateReader,
-		pool:           make(map[iscp.RequestID]*requestRef),
-		chStop:         make(chan struct{}),
-		blobCache:      blobCache,
-		log:            log.Named("m"),
-		mempoolMetrics: mempoolMetrics,
+		inBuffer:              make(map[iscp.RequestID]iscp.Request),
+		stateReader:           stateReader,
+		pool:                  make(map[iscp.RequestID]*requestRef),
+		chStop:                make(chan struct{}),
+		blobCache:             blobCache,
+		log:                   log.Named("m"),
+		mempoolMetrics:        mempoolMetrics,
+		poolRequestsPerSender: make(map[[ledgerstate.AddressLength]byte]int),
+	}
+	if config != nil {
+		ret.config = *config
+	}
+	ret.policy = ret.config.SelectionPolicy
+	if ret.policy == nil {
+		ret.policy = NewDefaultSelectionPolicy(&ret.config)
 	}
 	if len(solidificationLoopDelay) > 0 {
 		ret.solidificationLoopDelay = solidificationLoopDelay[0]
@@ -74,6 +89,10 @@ func (m *mempool) addToInBuffer(req iscp.Request) bool {
 	if m.HasRequest(req.ID()) {
 		return false
 	}
+	if m.senderQuotaExceeded(req) {
+		m.log.Debugf("addToInBuffer: request %s rejected, too many requests from %s in the pool", req.ID(), req.SenderAddress().Base58())
+		return false
+	}
 	m.inMutex.Lock()
 	defer m.inMutex.Unlock()
 	// may be repeating but does not matter
@@ -127,6 +146,12 @@ func (m *mempool) addToPool(req iscp.Request) bool {
 		// already there, remove from the in-buffer
 		return true
 	}
+	if m.senderQuotaExceededNoLock(req) {
+		// remove from the in-buffer but not include into the pool
+		m.rejectedCounter++
+		m.log.Debugf("addToPool: request %s rejected, too many requests from %s in the pool", reqid, req.SenderAddress().Base58())
+		return true
+	}
 
 	// put the request to the pool
 	nowis := time.Now()
@@ -138,6 +163,9 @@ func (m *mempool) addToPool(req iscp.Request) bool {
 		req:          req,
 		whenReceived: nowis,
 	}
+	if req.IsOffLedger() {
+		m.poolRequestsPerSender[req.SenderAddress().Array()]++
+	}
 	if _, err := request.SolidifyArgs(req, m.blobCache); err != nil {
 		m.log.Errorf("ReceiveRequest.SolidifyArgs: %s", err)
 	}
@@ -182,11 +210,61 @@ func (m *mempool) RemoveRequests(reqs ...iscp.RequestID) {
 		m.mempoolMetrics.CountBlocksPerChain()
 		elapsed := time.Since(m.pool[rid].whenReceived)
 		m.mempoolMetrics.RecordRequestProcessingTime(rid, elapsed)
-		delete(m.pool, rid)
+		m.deleteFromPool(rid)
 		m.traceOut(rid)
 	}
 }
 
+// evictRequests removes from the pool the off-ledger requests which were not processed within the TTL
+func (m *mempool) evictRequests(reqs ...iscp.RequestID) {
+	m.poolMutex.Lock()
+	defer m.poolMutex.Unlock()
+
+	for _, rid := range reqs {
+		if _, ok := m.pool[rid]; !ok {
+			continue
+		}
+		m.evictedCounter++
+		m.deleteFromPool(rid)
+		m.log.Debugf("EVICTED FROM MEMPOOL %s", rid)
+	}
+}
+
+// deleteFromPool removes the request from the pool. Must be called with the pool mutex locked
+func (m *mempool) deleteFromPool(rid iscp.RequestID) {
+	req := m.pool[rid].req
+	delete(m.pool, rid)
+	if !req.IsOffLedger() {
+		return
+	}
+	sender := req.SenderAddress().Array()
+	if m.poolRequestsPerSender[sender] <= 1 {
+		delete(m.poolRequestsPerSender, sender)
+	} else {
+		m.poolRequestsPerSender[sender]--
+	}
+}
+
+// senderQuotaExceeded returns true if the request is an off-ledger request and its sender has already
+// the maximum number of off-ledger requests in the pool
+func (m *mempool) senderQuotaExceeded(req iscp.Request) bool {
+	m.poolMutex.RLock()
+	defer m.poolMutex.RUnlock()
+	return m.senderQuotaExceededNoLock(req)
+}
+
+func (m *mempool) senderQuotaExceededNoLock(req iscp.Request) bool {
+	if m.config.MaxPoolRequestsPerSender <= 0 || !req.IsOffLedger() {
+		return false
+	}
+	return m.poolRequestsPerSender[req.SenderAddress().Array()] >= m.config.MaxPoolRequestsPerSender
+}
+
+// isExpired returns true if the request is an off-ledger request which is in the pool for longer than the TTL
+func (m *mempool) isExpired(ref *requestRef, nowis time.Time) bool {
+	return m.config.OffLedgerTTL > 0 && ref.req.IsOffLedger() && nowis.Sub(ref.whenReceived) > m.config.OffLedgerTTL
+}
+
 const traceInOut = false
 
 func (m *mempool) traceIn(req iscp.Request) {
@@ -243,7 +321,9 @@ func isRequestReady(ref *requestRef, nowis time.Time) (isReady, shouldBeRemoved
 // ReadyNow returns preliminary batch of requests for consensus.
 // Note that later status of request may change due to the time change and time constraints
 // If there's at least one committee rotation request in the mempool, the ReadyNow returns
-// batch with only one request, the oldest committee rotation request
+// batch with only one request, the oldest committee rotation request.
+// Otherwise the batch is selected from the ready requests by the selection policy of the mempool.
+// Off-ledger requests older than the TTL are evicted from the mempool
 func (m *mempool) ReadyNow(now ...time.Time) []iscp.Request {
 	m.poolMutex.RLock()
 
@@ -255,9 +335,14 @@ func (m *mempool) ReadyNow(now ...time.Time) []iscp.Request {
 	var oldestRotateTime time.Time
 
 	toRemove := []iscp.RequestID{}
+	toEvict := []iscp.RequestID{}
 
-	ret := make([]iscp.Request, 0, len(m.pool))
+	ready := make([]*ReadyRequest, 0, len(m.pool))
 	for _, ref := range m.pool {
+		if m.isExpired(ref, nowis) {
+			toEvict = append(toEvict, ref.req.ID())
+			continue
+		}
 		rdy, shouldBeRemoved := isRequestReady(ref, nowis)
 		if shouldBeRemoved {
 			toRemove = append(toRemove, ref.req.ID())
@@ -266,7 +351,7 @@ func (m *mempool) ReadyNow(now ...time.Time) []iscp.Request {
 		if !rdy {
 			continue
 		}
-		ret = append(ret, ref.req)
+		ready = append(ready, &ReadyRequest{Request: ref.req, WhenReceived: ref.whenReceived})
 		if !rotate.IsRotateStateControllerRequest(ref.req) {
 			continue
 		}
@@ -290,11 +375,18 @@ func (m *mempool) ReadyNow(now ...time.Time) []iscp.Request {
 	}
 	m.poolMutex.RUnlock()
 	go m.RemoveRequests(toRemove...)
+	if len(toEvict) > 0 {
+		go m.evictRequests(toEvict...)
+	}
 
 	if oldestRotate != nil {
 		return []iscp.Request{oldestRotate}
 	}
-	return ret
+	m.stateReader.SetBaseline()
+	if err := setOfferedFees(ready, m.stateReader.KVStoreReader()); err != nil {
+		m.log.Debugf("ReadyNow: can't read the fee policy, requests are not ranked by fees: %v", err)
+	}
+	return m.policy.SelectBatch(ready)
 }
 
 // ReadyFromIDs if successful, function returns a deterministic list of requests for running on the VM
@@ -348,6 +440,25 @@ func (m *mempool) GetRequest(id iscp.RequestID) iscp.Request {
 	return nil
 }
 
+// GetPendingRequests returns all requests which are waiting to be processed, both in the pool and in the in-buffer
+func (m *mempool) GetPendingRequests() []iscp.Request {
+	m.poolMutex.RLock()
+	ret := make([]iscp.Request, 0, len(m.pool))
+	for _, ref := range m.pool {
+		ret = append(ret, ref.req)
+	}
+	m.poolMutex.RUnlock()
+
+	m.inMutex.RLock()
+	defer m.inMutex.RUnlock()
+	for _, req := range m.inBuffer {
+		if !m.HasRequest(req.ID()) {
+			ret = append(ret, req)
+		}
+	}
+	return ret
+}
+
 const waitRequestInPoolTimeoutDefault = 2 * time.Second
 
 // WaitRequestInPool waits until the request appears in the pool but no longer than timeout
@@ -401,11 +512,18 @@ func (m *mempool) Info() chain.MempoolInfo {
 	defer m.poolMutex.RUnlock()
 
 	ret := chain.MempoolInfo{
-		InPoolCounter:  m.inPoolCounter,
-		OutPoolCounter: m.outPoolCounter,
-		InBufCounter:   m.inBufCounter,
-		OutBufCounter:  m.outBufCounter,
-		TotalPool:      len(m.pool),
+		InPoolCounter:             m.inPoolCounter,
+		OutPoolCounter:            m.outPoolCounter,
+		InBufCounter:              m.inBufCounter,
+		OutBufCounter:             m.outBufCounter,
+		TotalPool:                 len(m.pool),
+		EvictedCounter:            m.evictedCounter,
+		RejectedCounter:           m.rejectedCounter,
+		MaxBatchRequests:          m.config.MaxBatchRequests,
+		MaxBatchBytes:             m.config.MaxBatchBytes,
+		MaxBatchRequestsPerSender: m.config.MaxBatchRequestsPerSender,
+		MaxPoolRequestsPerSender:  m.config.MaxPoolRequestsPerSender,
+		OffLedgerTTL:              m.config.OffLedgerTTL,
 	}
 	nowis := time.Now()
 	for _, ref := range m.pool {
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -18,12 +19,16 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/requestargs"
 	"github.com/iotaledger/wasp/packages/iscp/rotate"
 	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/testutil/testkey"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -95,7 +100,7 @@ func TestMempool(t *testing.T) {
 	glb := coreutil.NewChainStateSync()
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	time.Sleep(2 * time.Second)
 	stats := pool.Info()
@@ -113,7 +118,7 @@ func TestAddRequest(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 1)
 
@@ -133,7 +138,7 @@ func TestAddRequestInvalidState(t *testing.T) {
 	glb.InvalidateSolidIndex()
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 1)
 
@@ -162,7 +167,7 @@ func TestAddRequestTwice(t *testing.T) {
 	rdr, _ := createStateReader(t, glb)
 
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 1)
 
@@ -192,7 +197,7 @@ func TestAddOffLedgerRequest(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	onLedgerRequests, keyPair := getRequestsOnLedger(t, 2)
 
@@ -214,6 +219,35 @@ func TestAddOffLedgerRequest(t *testing.T) {
 	require.EqualValues(t, 1, mempoolMetrics.offLedgerRequestCounter)
 }
 
+// Test if requests are listed as pending both in the in-buffer and in the pool
+func TestGetPendingRequests(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
+	rdr, _ := createStateReader(t, glb)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, new(MockMempoolMetrics), nil)
+	require.NotNil(t, pool)
+	requests, _ := getRequestsOnLedger(t, 3)
+
+	require.Empty(t, pool.GetPendingRequests())
+	pool.ReceiveRequests(requests[0], requests[1], requests[2])
+	require.Len(t, pool.GetPendingRequests(), 3)
+	require.True(t, pool.WaitInBufferEmpty())
+	pending := pool.GetPendingRequests()
+	require.Len(t, pending, 3)
+	require.ElementsMatch(t,
+		[]iscp.RequestID{requests[0].ID(), requests[1].ID(), requests[2].ID()},
+		iscp.TakeRequestIDs(pending...),
+	)
+
+	pool.RemoveRequests(requests[1].ID())
+	pending = pool.GetPendingRequests()
+	require.Len(t, pending, 2)
+	require.ElementsMatch(t,
+		[]iscp.RequestID{requests[0].ID(), requests[2].ID()},
+		iscp.TakeRequestIDs(pending...),
+	)
+}
+
 // Test if processed request cannot be added to mempool
 func TestProcessedRequest(t *testing.T) {
 	log := testlogger.NewLogger(t)
@@ -222,7 +256,7 @@ func TestProcessedRequest(t *testing.T) {
 	wrt := vs.KVStore()
 
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 
 	stats := pool.Info()
@@ -260,7 +294,7 @@ func TestAddRemoveRequests(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), log, mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 6)
 
@@ -309,7 +343,7 @@ func TestTimeLock(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 6)
 
@@ -411,7 +445,7 @@ func TestFallbackOptions(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 3)
 
@@ -456,7 +490,7 @@ func TestReadyFromIDs(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 6)
 
@@ -541,7 +575,7 @@ func TestSolidification(t *testing.T) {
 	rdr, _ := createStateReader(t, glb)
 	blobCache := iscp.NewInMemoryBlobCache()
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, blobCache, log, mempoolMetrics, 20*time.Millisecond) // Solidification initiated on pool creation
+	pool := New(rdr, blobCache, log, mempoolMetrics, nil, 20*time.Millisecond) // Solidification initiated on pool creation
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 4)
 
@@ -580,7 +614,7 @@ func TestRotateRequest(t *testing.T) {
 	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
 	rdr, _ := createStateReader(t, glb)
 	mempoolMetrics := new(MockMempoolMetrics)
-	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), mempoolMetrics, nil)
 	require.NotNil(t, pool)
 	requests, _ := getRequestsOnLedger(t, 6)
 
@@ -648,3 +682,174 @@ func TestRotateRequest(t *testing.T) {
 	require.True(t, result)
 	require.True(t, len(ready) == 5)
 }
+
+func getRequestsOffLedger(t *testing.T, keyPair *ed25519.KeyPair, gasBudgets ...uint64) []iscp.Request {
+	chainID := iscp.RandomChainID()
+	ret := make([]iscp.Request, len(gasBudgets))
+	for i, gasBudget := range gasBudgets {
+		req := request.NewOffLedger(chainID, iscp.Hn("dummy"), iscp.Hn("dummy"), requestargs.New(nil)).
+			WithGasBudget(gasBudget).
+			WithTransfer(colored.NewBalancesForIotas(50))
+		req.WithNonce(uint64(i))
+		req.Sign(keyPair)
+		ret[i] = req
+	}
+	return ret
+}
+
+// setFeeState sets the gas price of iotas and the on-chain iota balances of the accounts in the committed state
+func setFeeState(t *testing.T, vs state.VirtualStateAccess, gasPrice uint64, balances map[*iscp.AgentID]uint64) {
+	governanceState := subrealm.New(vs.KVStore(), kv.Key(governance.Contract.Hname().Bytes()))
+	collections.NewMap(governanceState, governance.VarGasPrices).MustSetAt(colored.IOTA.Bytes(), codec.EncodeUint64(gasPrice))
+	accountsState := subrealm.New(vs.KVStore(), kv.Key(accounts.Contract.Hname().Bytes()))
+	for agentID, bal := range balances {
+		accounts.CreditToAccount(accountsState, agentID, colored.NewBalancesForIotas(bal), nil)
+	}
+	require.NoError(t, vs.Commit())
+}
+
+func TestSelectionPolicyOrdering(t *testing.T) {
+	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
+	rdr, vs := createStateReader(t, glb)
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), new(MockMempoolMetrics), nil)
+	require.NotNil(t, pool)
+
+	// 10 iotas per 1000 gas
+	kp, addr := testkey.GenKeyAddr()
+	kpPoor, addrPoor := testkey.GenKeyAddr()
+	setFeeState(t, vs, 10, map[*iscp.AgentID]uint64{
+		iscp.NewAgentID(addr, 0):     100,
+		iscp.NewAgentID(addrPoor, 0): 15,
+	})
+	requests := getRequestsOffLedger(t, kp, 1000, 3000, 2000)
+	// the default gas budget, but only 15 iotas on the chain to pay for it
+	requests = append(requests, getRequestsOffLedger(t, kpPoor, 0)...)
+	for _, req := range requests {
+		pool.ReceiveRequest(req)
+		require.True(t, pool.WaitRequestInPool(req.ID()))
+	}
+	ready := pool.ReadyNow(time.Now())
+	require.EqualValues(t, 4, len(ready))
+	require.EqualValues(t, requests[1].ID(), ready[0].ID())
+	require.EqualValues(t, requests[2].ID(), ready[1].ID())
+	require.EqualValues(t, requests[3].ID(), ready[2].ID())
+	require.EqualValues(t, requests[0].ID(), ready[3].ID())
+	pool.RemoveRequests(requests[3].ID())
+
+	// same fee: ordered by the request ID, not by the time of arrival
+	onLedger, _ := getRequestsOnLedger(t, 2)
+	if bytes.Compare(onLedger[0].ID().Bytes(), onLedger[1].ID().Bytes()) > 0 {
+		onLedger[0], onLedger[1] = onLedger[1], onLedger[0]
+	}
+	pool.ReceiveRequest(onLedger[1])
+	require.True(t, pool.WaitRequestInPool(onLedger[1].ID()))
+	time.Sleep(10 * time.Millisecond)
+	pool.ReceiveRequest(onLedger[0])
+	require.True(t, pool.WaitRequestInPool(onLedger[0].ID()))
+	pool.RemoveRequests(requests[0].ID(), requests[1].ID(), requests[2].ID())
+	ready = pool.ReadyNow(time.Now())
+	require.EqualValues(t, 2, len(ready))
+	require.EqualValues(t, onLedger[0].ID(), ready[0].ID())
+	require.EqualValues(t, onLedger[1].ID(), ready[1].ID())
+}
+
+func TestSelectionPolicyBatchLimits(t *testing.T) {
+	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
+	rdr, _ := createStateReader(t, glb)
+	requests, _ := getRequestsOnLedger(t, 5)
+	size := len(requests[0].Bytes())
+	config := &Config{
+		MaxBatchRequests: 4,
+		MaxBatchBytes:    3*size + size/2,
+	}
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), new(MockMempoolMetrics), config)
+	require.NotNil(t, pool)
+
+	for _, req := range requests {
+		pool.ReceiveRequest(req)
+		require.True(t, pool.WaitRequestInPool(req.ID()))
+	}
+	ready := pool.ReadyNow(time.Now())
+	require.EqualValues(t, 3, len(ready))
+
+	stats := pool.Info()
+	require.EqualValues(t, 5, stats.ReadyCounter)
+	require.EqualValues(t, 4, stats.MaxBatchRequests)
+	require.EqualValues(t, config.MaxBatchBytes, stats.MaxBatchBytes)
+
+	// a single request larger than the limit is still proposed
+	config.MaxBatchBytes = size / 2
+	pool = New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), new(MockMempoolMetrics), config)
+	pool.ReceiveRequest(requests[0])
+	require.True(t, pool.WaitRequestInPool(requests[0].ID()))
+	ready = pool.ReadyNow(time.Now())
+	require.EqualValues(t, 1, len(ready))
+}
+
+func TestSelectionPolicyPerSender(t *testing.T) {
+	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
+	rdr, _ := createStateReader(t, glb)
+	config := &Config{
+		MaxBatchRequestsPerSender: 2,
+		MaxPoolRequestsPerSender:  3,
+	}
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), new(MockMempoolMetrics), config)
+	require.NotNil(t, pool)
+
+	kp1, _ := testkey.GenKeyAddr()
+	kp2, _ := testkey.GenKeyAddr()
+	spam := getRequestsOffLedger(t, kp1, 0, 0, 0, 0)
+	other := getRequestsOffLedger(t, kp2, 0)
+	for _, req := range spam[:3] {
+		require.True(t, pool.ReceiveRequest(req))
+		require.True(t, pool.WaitRequestInPool(req.ID()))
+	}
+	// the sender has already 3 requests in the pool
+	require.False(t, pool.ReceiveRequest(spam[3]))
+	require.False(t, pool.HasRequest(spam[3].ID()))
+	require.True(t, pool.ReceiveRequest(other[0]))
+	require.True(t, pool.WaitRequestInPool(other[0].ID()))
+
+	ready := pool.ReadyNow(time.Now())
+	require.EqualValues(t, 3, len(ready))
+	require.Contains(t, ready, other[0])
+
+	// after processing, the sender can send again
+	pool.RemoveRequests(spam[0].ID())
+	require.True(t, pool.ReceiveRequest(spam[3]))
+	require.True(t, pool.WaitRequestInPool(spam[3].ID()))
+	stats := pool.Info()
+	require.EqualValues(t, 4, stats.TotalPool)
+	require.EqualValues(t, 3, stats.MaxPoolRequestsPerSender)
+	require.EqualValues(t, 2, stats.MaxBatchRequestsPerSender)
+}
+
+func TestOffLedgerTTL(t *testing.T) {
+	glb := coreutil.NewChainStateSync().SetSolidIndex(0)
+	rdr, _ := createStateReader(t, glb)
+	config := &Config{OffLedgerTTL: time.Minute}
+	pool := New(rdr, iscp.NewInMemoryBlobCache(), testlogger.NewLogger(t), new(MockMempoolMetrics), config)
+	require.NotNil(t, pool)
+
+	kp, _ := testkey.GenKeyAddr()
+	offLedger := getRequestsOffLedger(t, kp, 0)
+	onLedger, _ := getRequestsOnLedger(t, 1)
+	pool.ReceiveRequests(offLedger[0], onLedger[0])
+	require.True(t, pool.WaitRequestInPool(offLedger[0].ID()))
+	require.True(t, pool.WaitRequestInPool(onLedger[0].ID()))
+
+	ready := pool.ReadyNow(time.Now())
+	require.EqualValues(t, 2, len(ready))
+
+	// the off-ledger request expires, the on-ledger request stays
+	ready = pool.ReadyNow(time.Now().Add(2 * time.Minute))
+	require.EqualValues(t, 1, len(ready))
+	require.EqualValues(t, onLedger[0].ID(), ready[0].ID())
+	require.Eventually(t, func() bool {
+		return !pool.HasRequest(offLedger[0].ID())
+	}, time.Second, 10*time.Millisecond)
+	stats := pool.Info()
+	require.EqualValues(t, 1, stats.EvictedCounter)
+	require.EqualValues(t, 0, stats.OutPoolCounter)
+	require.EqualValues(t, 1, stats.TotalPool)
+}
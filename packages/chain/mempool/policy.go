@@ -0,0 +1,142 @@
+package mempool
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// Config contains the limits of the mempool. Zero values mean no limit
+type Config struct {
+	// MaxBatchRequests is the maximum number of requests proposed for one batch
+	MaxBatchRequests int
+	// MaxBatchBytes is the maximum total size of the requests proposed for one batch.
+	// A single request larger than the limit is still proposed alone
+	MaxBatchBytes int
+	// MaxBatchRequestsPerSender is the maximum number of requests of the same sender proposed for one batch
+	MaxBatchRequestsPerSender int
+	// MaxPoolRequestsPerSender is the maximum number of off-ledger requests of the same sender kept in the mempool.
+	// Further off-ledger requests of the sender are rejected until some of them are processed
+	MaxPoolRequestsPerSender int
+	// OffLedgerTTL is the time after which the off-ledger requests which were not processed are evicted from the mempool
+	OffLedgerTTL time.Duration
+	// SelectionPolicy selects the requests proposed for the batch among the ready ones.
+	// If nil, the requests are selected by the policy returned by NewDefaultSelectionPolicy
+	SelectionPolicy SelectionPolicy
+}
+
+// ReadyRequest is a request which is ready to be processed, together with the time it arrived to the mempool
+// and the fee it offers
+type ReadyRequest struct {
+	Request      iscp.Request
+	WhenReceived time.Time
+	// OfferedFee is the number of fee tokens the request can actually pay for its gas budget.
+	// It is 0 if the chain charges no fees
+	OfferedFee uint64
+}
+
+// SelectionPolicy selects the requests proposed for the next batch among the ready requests of the mempool
+type SelectionPolicy interface {
+	SelectBatch(ready []*ReadyRequest) []iscp.Request
+}
+
+type defaultSelectionPolicy struct {
+	maxRequests          int
+	maxBytes             int
+	maxRequestsPerSender int
+}
+
+// NewDefaultSelectionPolicy returns the policy which orders the ready requests by the offered fee, then by the request ID,
+// and takes them in that order within the batch limits of the config. The order does not depend on the time the
+// requests arrived to the node, so all the nodes of the committee propose the same requests
+func NewDefaultSelectionPolicy(config *Config) SelectionPolicy {
+	return &defaultSelectionPolicy{
+		maxRequests:          config.MaxBatchRequests,
+		maxBytes:             config.MaxBatchBytes,
+		maxRequestsPerSender: config.MaxBatchRequestsPerSender,
+	}
+}
+
+// setOfferedFees sets the fees offered by the ready requests according to the fee policy in the chain state.
+// Returns an error if the state can't be read, for example because it was invalidated
+func setOfferedFees(ready []*ReadyRequest, chainState kv.KVStoreReader) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			errRecovered, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			for _, rdy := range ready {
+				rdy.OfferedFee = 0
+			}
+			err = errRecovered
+		}
+	}()
+	feePolicy := governance.MustGetFeePolicy(subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes())))
+	if !feePolicy.Enabled() {
+		return nil
+	}
+	accountsState := subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))
+	for _, rdy := range ready {
+		rdy.OfferedFee = offeredFee(rdy.Request, feePolicy, accountsState)
+	}
+	return nil
+}
+
+// offeredFee returns the fee for the gas budget of the request, capped by the fee tokens the request can pay with:
+// the tokens attached to an on-ledger request, or the tokens of an off-ledger request available in the sender account
+func offeredFee(req iscp.Request, feePolicy *governance.FeePolicy, accountsState kv.KVStoreReader) uint64 {
+	var available uint64
+	switch req := req.(type) {
+	case *request.OnLedger:
+		available = colored.BalancesFromL1Balances(req.Output().Balances()).Get(feePolicy.FeeColor)
+	case *request.OffLedger:
+		available = req.Tokens().Get(feePolicy.FeeColor)
+		if onChain := accounts.GetBalance(accountsState, req.SenderAccount(), feePolicy.FeeColor); onChain < available {
+			available = onChain
+		}
+	}
+	if fee := feePolicy.FeeForGas(gas.Budget(req.GasBudget())); fee < available {
+		return fee
+	}
+	return available
+}
+
+func (p *defaultSelectionPolicy) SelectBatch(ready []*ReadyRequest) []iscp.Request {
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].OfferedFee != ready[j].OfferedFee {
+			return ready[i].OfferedFee > ready[j].OfferedFee
+		}
+		return bytes.Compare(ready[i].Request.ID().Bytes(), ready[j].Request.ID().Bytes()) < 0
+	})
+	ret := make([]iscp.Request, 0, len(ready))
+	perSender := make(map[[ledgerstate.AddressLength]byte]int)
+	totalBytes := 0
+	for _, r := range ready {
+		if p.maxRequests > 0 && len(ret) >= p.maxRequests {
+			break
+		}
+		sender := r.Request.SenderAddress().Array()
+		if p.maxRequestsPerSender > 0 && perSender[sender] >= p.maxRequestsPerSender {
+			continue
+		}
+		size := len(r.Request.Bytes())
+		if p.maxBytes > 0 && len(ret) > 0 && totalBytes+size > p.maxBytes {
+			continue
+		}
+		ret = append(ret, r.Request)
+		perSender[sender]++
+		totalBytes += size
+	}
+	return ret
+}
@@ -5,6 +5,7 @@ package statemgr
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -38,6 +39,11 @@ func (sm *stateManager) notifyChainTransitionIfNeeded() {
 		sm.log.Debugf("notifyStateTransition not needed: state manager is not synced at index #%d", sm.solidState.BlockIndex())
 		return
 	}
+	if sm.unverifiedSnapshot != nil {
+		// the consensus must not start on a state which may be forged
+		sm.log.Debugf("notifyStateTransition not needed: the snapshot of block #%d is not verified yet", sm.unverifiedSnapshot.BlockIndex)
+		return
+	}
 
 	sm.notifiedAnchorOutputID = sm.stateOutput.ID()
 	stateOutputID := sm.stateOutput.ID()
@@ -67,6 +73,9 @@ func (sm *stateManager) pullStateIfNeeded() {
 	if nowis.After(sm.pullStateRetryTime) {
 		chainAliasAddress := sm.chain.ID().AsAliasAddress()
 		sm.nodeConn.PullState()
+		if sm.unverifiedSnapshot != nil {
+			sm.nodeConn.PullConfirmedOutput(sm.unverifiedSnapshot.ApprovingOutputID)
+		}
 		sm.pullStateRetryTime = nowis.Add(sm.timers.PullStateRetry)
 		sm.log.Debugf("pullState: pulling state for address %v. Next pull in: %v",
 			chainAliasAddress.Base58(), sm.pullStateRetryTime.Sub(nowis))
@@ -174,3 +183,23 @@ func (sm *stateManager) storeSyncingData() {
 		StateOutputTimestamp:  sm.stateOutputTimestamp,
 	})
 }
+
+// verifySnapshot checks the state hash of the snapshot the solid state was imported from against the alias output
+// which approved the block of the snapshot. The output must belong to the chain, otherwise the approving output
+// of a forged snapshot could be any alias output. The chain is dismissed if they don't match
+func (sm *stateManager) verifySnapshot(output *ledgerstate.AliasOutput) {
+	outputStateHash, err := hashing.HashValueFromBytes(output.GetStateData())
+	if err != nil || !output.GetAliasAddress().Equals(sm.chain.ID().AsAliasAddress()) ||
+		output.GetStateIndex() != sm.unverifiedSnapshot.BlockIndex || outputStateHash != sm.unverifiedSnapshot.StateHash {
+		sm.chain.EnqueueDismissChain(fmt.Sprintf("StateManager.verifySnapshot: the snapshot of block #%d doesn't match output %s",
+			sm.unverifiedSnapshot.BlockIndex, iscp.OID(output.ID())))
+		return
+	}
+	if err := state.MarkSnapshotVerified(sm.store); err != nil {
+		sm.log.Errorf("verifySnapshot: %v", err)
+		return
+	}
+	sm.log.Infof("verifySnapshot: the snapshot of block #%d has been verified against output %s",
+		sm.unverifiedSnapshot.BlockIndex, iscp.OID(output.ID()))
+	sm.unverifiedSnapshot = nil
+}
@@ -89,6 +89,9 @@ func (sm *stateManager) handleOutputMsg(msg ledgerstate.Output) {
 		sm.log.Debugf("EventOutputMsg ignored: output is of type %t, expecting *ledgerstate.AliasOutput", msg)
 		return
 	}
+	if sm.unverifiedSnapshot != nil && chainOutput.ID() == sm.unverifiedSnapshot.ApprovingOutputID {
+		sm.verifySnapshot(chainOutput)
+	}
 	if sm.outputPulled(chainOutput) {
 		sm.takeAction()
 	}
@@ -70,6 +70,8 @@ func (c *MockedStateManagerMetrics) RecordBlockSize(_ uint32, _ float64) {}
 
 func (c *MockedStateManagerMetrics) LastSeenStateIndex(_ uint32) {}
 
+func (c *MockedStateManagerMetrics) CountPrunedBlocks(_, _ int) {}
+
 func NewMockedEnv(nodeCount int, t *testing.T, debug bool) (*MockedEnv, *ledgerstate.Transaction) {
 	level := zapcore.InfoLevel
 	if debug {
@@ -15,6 +15,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/messages"
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/state"
@@ -31,6 +32,7 @@ type stateManager struct {
 	nodeConn                    chain.ChainNodeConnection
 	pullStateRetryTime          time.Time
 	solidState                  state.VirtualStateAccess
+	unverifiedSnapshot          *state.SnapshotInfo
 	stateOutput                 *ledgerstate.AliasOutput
 	stateOutputTimestamp        time.Time
 	currentSyncData             atomic.Value
@@ -159,6 +161,14 @@ func (sm *stateManager) initLoadState() {
 		sm.chain.GlobalStateSync().SetSolidIndex(solidState.BlockIndex())
 		sm.log.Infof("SOLID STATE has been loaded. Block index: #%d, State hash: %s",
 			solidState.BlockIndex(), solidState.StateCommitment().String())
+		if sm.unverifiedSnapshot, err = state.LoadUnverifiedSnapshotInfo(sm.store); err != nil {
+			sm.chain.EnqueueDismissChain(fmt.Sprintf("StateManager.initLoadState: %v", err))
+			return
+		}
+		if sm.unverifiedSnapshot != nil {
+			sm.log.Infof("SOLID STATE was imported from a snapshot, verifying it against output %s",
+				iscp.OID(sm.unverifiedSnapshot.ApprovingOutputID))
+		}
 	} else if err := sm.createOriginState(); err != nil {
 		// create origin state in DB
 		sm.chain.EnqueueDismissChain(fmt.Sprintf("StateManager.initLoadState. Failed to create origin state: %v", err))
@@ -9,9 +9,12 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/wasp/packages/chain/messages"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
 
 func (sm *stateManager) outputPulled(output *ledgerstate.AliasOutput) bool {
@@ -237,4 +240,23 @@ func (sm *stateManager) commitCandidates(candidates []*candidateBlock, tentative
 	sm.solidState = tentativeState
 
 	sm.log.Debugf("commitCandidates: committing of block indices from %v to %v was successful", from, to)
+	sm.pruneBlocks()
+}
+
+// pruneBlocks deletes from DB the blocks which are older than the block retention set in the governance contract
+func (sm *stateManager) pruneBlocks() {
+	governanceState := subrealm.NewReadOnly(sm.solidState.KVStoreReader(), kv.Key(governance.Contract.Hname().Bytes()))
+	blockKeepAmount := governance.MustGetBlockKeepAmount(governanceState)
+	blockIndex := sm.solidState.BlockIndex()
+	if blockKeepAmount == 0 || blockIndex < blockKeepAmount {
+		return
+	}
+	numBlocks, numBytes, err := state.PruneBlocks(sm.store, blockIndex-blockKeepAmount+1)
+	if err != nil {
+		sm.log.Errorf("pruneBlocks: failed to prune blocks: %v", err)
+	}
+	if numBlocks > 0 {
+		sm.stateManagerMetrics.CountPrunedBlocks(numBlocks, numBytes)
+		sm.log.Infof("pruneBlocks: %d blocks pruned, %d bytes reclaimed", numBlocks, numBytes)
+	}
 }
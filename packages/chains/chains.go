@@ -4,6 +4,7 @@
 package chains
 
 import (
+	"io"
 	"sync"
 	"time"
 
@@ -11,12 +12,14 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/chainimpl"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/database/dbmanager"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/metrics/nodeconnmetrics"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 	"github.com/iotaledger/wasp/packages/wal"
 	"golang.org/x/xerrors"
@@ -41,6 +44,7 @@ type Chains struct {
 	offledgerBroadcastUpToNPeers     int
 	offledgerBroadcastInterval       time.Duration
 	pullMissingRequestsFromCommittee bool
+	mempoolConfig                    *mempool.Config
 	networkProvider                  peering.NetworkProvider
 	getOrCreateKVStore               dbmanager.ChainKVStoreProvider
 }
@@ -51,6 +55,7 @@ func New(
 	offledgerBroadcastUpToNPeers int,
 	offledgerBroadcastInterval time.Duration,
 	pullMissingRequestsFromCommittee bool,
+	mempoolConfig *mempool.Config,
 	networkProvider peering.NetworkProvider,
 	getOrCreateKVStore dbmanager.ChainKVStoreProvider,
 ) *Chains {
@@ -61,6 +66,7 @@ func New(
 		offledgerBroadcastUpToNPeers:     offledgerBroadcastUpToNPeers,
 		offledgerBroadcastInterval:       offledgerBroadcastInterval,
 		pullMissingRequestsFromCommittee: pullMissingRequestsFromCommittee,
+		mempoolConfig:                    mempoolConfig,
 		networkProvider:                  networkProvider,
 		getOrCreateKVStore:               getOrCreateKVStore,
 	}
@@ -144,6 +150,7 @@ func (c *Chains) Activate(chr *registry.ChainRecord, registryProvider registry.P
 		c.offledgerBroadcastUpToNPeers,
 		c.offledgerBroadcastInterval,
 		c.pullMissingRequestsFromCommittee,
+		c.mempoolConfig,
 		chainMetrics,
 		chainWAL,
 	)
@@ -193,3 +200,17 @@ func (c *Chains) Get(chainID *iscp.ChainID, includeDeactivated ...bool) chain.Ch
 func (c *Chains) GetNodeConnectionMetrics() nodeconnmetrics.NodeConnectionMetrics {
 	return c.nodeConn.GetMetrics()
 }
+
+// ExportSnapshot writes to w a snapshot of the state of the chain after the block with the given index
+func (c *Chains) ExportSnapshot(w io.Writer, chainID *iscp.ChainID, blockIndex uint32) (*state.SnapshotInfo, error) {
+	return state.WriteSnapshot(w, c.getOrCreateKVStore(chainID), chainID, blockIndex)
+}
+
+// ImportSnapshot stores in the DB of the chain the state read from a snapshot. The chain must not be active.
+// When the chain is activated, the state manager checks the snapshot against the ledger and syncs the blocks after it
+func (c *Chains) ImportSnapshot(chainID *iscp.ChainID, r io.Reader) (*state.SnapshotInfo, error) {
+	if c.Get(chainID) != nil {
+		return nil, xerrors.Errorf("cannot import a snapshot of active chain %s", chainID.String())
+	}
+	return state.ReadSnapshot(c.getOrCreateKVStore(chainID), chainID, r)
+}
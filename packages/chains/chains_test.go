@@ -23,5 +23,5 @@ func TestBasic(t *testing.T) {
 		return db.NewStore()
 	}
 
-	_ = New(logger, processors.NewConfig(), 10, time.Second, false, nil, getOrCreateKVStore)
+	_ = New(logger, processors.NewConfig(), 10, time.Second, false, nil, nil, getOrCreateKVStore)
 }
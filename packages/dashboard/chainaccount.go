@@ -4,11 +4,13 @@ import (
 	_ "embed"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/labstack/echo/v4"
 )
@@ -54,6 +56,33 @@ func (d *Dashboard) handleChainAccount(c echo.Context) error {
 		return err
 	}
 
+	if o := c.QueryParam("offset"); o != "" {
+		offset, err := strconv.ParseUint(o, 10, 32)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		result.HistoryOffset = uint32(offset)
+	}
+	hist, err := d.wasp.CallView(chainID, accounts.Contract.Name, accounts.FuncGetAccountHistory.Name, codec.MakeDict(map[string]interface{}{
+		accounts.ParamAgentID:       codec.EncodeAgentID(agentID),
+		accounts.ParamHistoryOffset: codec.EncodeUint32(result.HistoryOffset),
+	}))
+	if err != nil {
+		return err
+	}
+	result.HistoryLength, err = codec.DecodeUint32(hist.MustGet(accounts.ParamHistoryLength), 0)
+	if err != nil {
+		return err
+	}
+	arr := collections.NewArray16ReadOnly(hist, accounts.ParamHistoryEntries)
+	for i := uint16(0); i < arr.MustLen(); i++ {
+		entry, err := accounts.HistoryEntryFromBytes(arr.MustGetAt(i))
+		if err != nil {
+			return err
+		}
+		result.History = append(result.History, entry)
+	}
+
 	return c.Render(http.StatusOK, c.Path(), result)
 }
 
@@ -64,4 +93,28 @@ type ChainAccountTemplateParams struct {
 	AgentID *iscp.AgentID
 
 	Balances colored.Balances
+
+	// History is a page of the history of the account, the newest entries first
+	History       []*accounts.HistoryEntry
+	HistoryOffset uint32
+	HistoryLength uint32
+}
+
+func (p *ChainAccountTemplateParams) HasNewerHistory() bool {
+	return p.HistoryOffset > 0
+}
+
+func (p *ChainAccountTemplateParams) NewerHistoryOffset() uint32 {
+	if p.HistoryOffset < accounts.MaxHistoryEntriesPerView {
+		return 0
+	}
+	return p.HistoryOffset - accounts.MaxHistoryEntriesPerView
+}
+
+func (p *ChainAccountTemplateParams) HasOlderHistory() bool {
+	return p.HistoryOffset+uint32(len(p.History)) < p.HistoryLength
+}
+
+func (p *ChainAccountTemplateParams) OlderHistoryOffset() uint32 {
+	return p.HistoryOffset + uint32(len(p.History))
 }
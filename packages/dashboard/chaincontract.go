@@ -6,11 +6,9 @@ import (
 	"net/http"
 
 	"github.com/iotaledger/wasp/packages/iscp"
-	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
-	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/labstack/echo/v4"
 )
@@ -56,21 +54,6 @@ func (d *Dashboard) handleChainContract(c echo.Context) error {
 		return err
 	}
 
-	fees, err := d.wasp.CallView(chainID, governance.Contract.Name, governance.FuncGetFeeInfo.Name, codec.MakeDict(map[string]interface{}{
-		governance.ParamHname: codec.EncodeHname(hname),
-	}))
-	if err != nil {
-		return err
-	}
-	result.OwnerFee, err = codec.DecodeUint64(fees.MustGet(governance.VarOwnerFee))
-	if err != nil {
-		return err
-	}
-	result.ValidatorFee, err = codec.DecodeUint64(fees.MustGet(governance.VarValidatorFee))
-	if err != nil {
-		return err
-	}
-
 	r, err = d.wasp.CallView(chainID, blocklog.Contract.Name, blocklog.FuncGetEventsForContract.Name, codec.MakeDict(map[string]interface{}{
 		blocklog.ParamContractHname: codec.EncodeHname(hname),
 	}))
@@ -103,9 +86,6 @@ type ChainContractTemplateParams struct {
 	Hname   iscp.Hname
 
 	ContractRecord *root.ContractRecord
-	OwnerFee       uint64
-	ValidatorFee   uint64
-	FeeColor       colored.Color
 	Log            []string
 	RootInfo       RootInfo
 }
@@ -8,6 +8,9 @@ import (
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/webapi/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -72,6 +75,29 @@ func TestDashboardChainAccount(t *testing.T) {
 	require.Regexp(t, "^A/", html.Find(".value-agentid").Text())
 }
 
+func TestDashboardChainAccountHistory(t *testing.T) {
+	env := initDashboardTest(t)
+	ch := env.newChain()
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamAccountHistory, true,
+	).WithIotas(1)
+	_, err := ch.PostRequestSync(req, nil)
+	require.NoError(t, err)
+
+	user, userAddr := env.solo.NewKeyPairWithFunds()
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(42)
+	_, err = ch.PostRequestSync(req, user)
+	require.NoError(t, err)
+
+	html := testutil.CallHTMLRequestHandler(t, env.echo, env.dashboard.handleChainAccount, "/chain/:chainid/account/:agentid", map[string]string{
+		"chainid": ch.ChainID.Base58(),
+		"agentid": strings.Replace(iscp.NewAgentID(userAddr, 0).String(), "/", ":", 1),
+	})
+	checkProperConversionsToString(t, html)
+	require.Equal(t, "credit", html.Find(".value-history-kind").Text())
+}
+
 func TestDashboardChainBlob(t *testing.T) {
 	env := initDashboardTest(t)
 	ch := env.newChain()
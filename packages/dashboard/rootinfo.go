@@ -2,7 +2,6 @@ package dashboard
 
 import (
 	"github.com/iotaledger/wasp/packages/iscp"
-	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
@@ -18,9 +17,8 @@ type RootInfo struct {
 	Description string
 	Contracts   map[iscp.Hname]*root.ContractRecord
 
-	FeeColor            colored.Color
-	DefaultOwnerFee     uint64
-	DefaultValidatorFee uint64
+	FeePolicy    *governance.FeePolicy
+	GasPriceUnit uint64
 }
 
 func (d *Dashboard) fetchRootInfo(chainID *iscp.ChainID) (ret RootInfo, err error) {
@@ -46,10 +44,15 @@ func (d *Dashboard) fetchRootInfo(chainID *iscp.ChainID) (ret RootInfo, err erro
 		return
 	}
 
-	ret.FeeColor, ret.DefaultOwnerFee, ret.DefaultValidatorFee, err = governance.GetDefaultFeeInfo(info)
+	fees, err := d.wasp.CallView(chainID, governance.Contract.Name, governance.FuncGetFeePolicy.Name, nil)
 	if err != nil {
 		return
 	}
+	ret.FeePolicy, err = governance.DecodeFeePolicy(fees)
+	if err != nil {
+		return
+	}
+	ret.GasPriceUnit = governance.GasPriceUnit
 
 	recs, err := d.wasp.CallView(chainID, root.Contract.Name, root.FuncGetContractRecords.Name, nil)
 	if err != nil {
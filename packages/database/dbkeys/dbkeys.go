@@ -16,6 +16,14 @@ const (
 	ObjectTypeBlobCache
 	ObjectTypeBlobCacheTTL
 	ObjectTypeTrustedPeer
+	ObjectTypeTrieNode
+	ObjectTypeFirstBlockIndex
+	ObjectTypeSnapshotInfo
+	ObjectTypeWebhookSubscription
+	ObjectTypeWebhookDelivery
+	ObjectTypeKeystore
+	ObjectTypeBaseStateVariable
+	ObjectTypeWebhookCursor
 )
 
 // MakeKey makes key within the partition. It consists to one byte for object type
@@ -32,7 +32,8 @@ const (
 	//
 	// NOTE: initiatorInitMsgType must be unique across all the uses of peering package,
 	// because it is used to start new chain, thus peeringID is not used for message recognition.
-	initiatorInitMsgType byte = peering.FirstUserMsgCode + 184 // Initiator -> Peer: init new DKG, reply with initiatorStatusMsgType.
+	initiatorInitMsgType    byte = peering.FirstUserMsgCode + 184 // Initiator -> Peer: init new DKG, reply with initiatorStatusMsgType.
+	initiatorReshareMsgType byte = peering.FirstUserMsgCode + 185 // Initiator -> Peer: init resharing of existing key, reply with initiatorStatusMsgType.
 	//
 	// Initiator <-> Peer proc communication.
 	initiatorMsgBase         byte = peering.FirstUserMsgCode + 4 // 4 to align with round numbers.
@@ -50,7 +51,8 @@ const (
 	rabinSecretCommitsMsgType      byte = rabinMsgBase + 4
 	rabinComplaintCommitsMsgType   byte = rabinMsgBase + 5
 	rabinReconstructCommitsMsgType byte = rabinMsgBase + 6
-	rabinMsgFree                   byte = rabinMsgBase + 7 // Just a placeholder for first unallocated message type.
+	reshareDealMsgType             byte = rabinMsgBase + 7 // Deals of the resharing, handled as the Rabin round messages.
+	rabinMsgFree                   byte = rabinMsgBase + 8 // Just a placeholder for first unallocated message type.
 	//
 	// Peer <-> Peer communication for the Rabin protocol, messages repeatedly sent
 	// in response to duplicated messages from other peers. They should be treated
@@ -62,7 +64,7 @@ var initPeeringID peering.PeeringID
 
 // Checks if that's a Initiator -> PeerNode message.
 func isDkgInitNodeMsg(msgType byte) bool { //nolint:unused,deadcode
-	return msgType == initiatorInitMsgType
+	return msgType == initiatorInitMsgType || msgType == initiatorReshareMsgType
 }
 
 // Checks if that's a Initiator <-> PeerProc message.
@@ -144,6 +146,12 @@ func readInitiatorMsg(peerMessage *peering.PeerMessageData, blsSuite kyber.Group
 			return true, nil, err
 		}
 		return true, &msg, nil
+	case initiatorReshareMsgType:
+		msg := initiatorReshareMsg{}
+		if err := msg.fromBytes(peerMessage.MsgData, blsSuite); err != nil {
+			return true, nil, err
+		}
+		return true, &msg, nil
 	case initiatorStepMsgType:
 		msg := initiatorStepMsg{}
 		if err := msg.fromBytes(peerMessage.MsgData); err != nil {
@@ -192,6 +200,7 @@ type initiatorInitMsg struct {
 
 type initiatorInitMsgIn struct {
 	initiatorInitMsg
+	reshare      *initiatorReshareMsg // Not nil, if the existing key is reshared instead of generating a new one.
 	SenderPubKey *ed25519.PublicKey
 }
 
@@ -310,6 +319,127 @@ func (m *initiatorInitMsg) IsResponse() bool {
 	return false
 }
 
+//
+// initiatorReshareMsg
+//
+// This is a message sent by the initiator to all the peers to
+// initiate the resharing of an existing distributed key. The peers
+// are the new members of the committee (the first newCount of peerPubs)
+// followed by the old members dealing their shares, if they are not in
+// the new committee. The old members in peerPubs act as dealers.
+//
+type initiatorReshareMsg struct {
+	initiatorInitMsg
+	newCount        uint16              // Number of the new members, they are the first in peerPubs.
+	sharedAddress   ledgerstate.Address // Address of the key to reshare.
+	oldThreshold    uint16
+	oldPeerPubs     []*ed25519.PublicKey // Members of the current committee, in the order of their shares.
+	oldPublicShares []kyber.Point        // Public shares of the current committee.
+	blsSuite        kyber.Group          // Transient, for un-marshaling only.
+}
+
+func (m *initiatorReshareMsg) MsgType() byte {
+	return initiatorReshareMsgType
+}
+
+//nolint:gocritic
+func (m *initiatorReshareMsg) Write(w io.Writer) error {
+	var err error
+	if err = m.initiatorInitMsg.Write(w); err != nil {
+		return err
+	}
+	if err = util.WriteUint16(w, m.newCount); err != nil {
+		return err
+	}
+	if err = util.WriteBytes16(w, m.sharedAddress.Bytes()); err != nil {
+		return err
+	}
+	if err = util.WriteUint16(w, m.oldThreshold); err != nil {
+		return err
+	}
+	if err = util.WriteUint16(w, uint16(len(m.oldPeerPubs))); err != nil {
+		return err
+	}
+	for i := range m.oldPeerPubs {
+		if err = util.WriteBytes16(w, m.oldPeerPubs[i].Bytes()); err != nil {
+			return err
+		}
+	}
+	if err = util.WriteUint16(w, uint16(len(m.oldPublicShares))); err != nil {
+		return err
+	}
+	for i := range m.oldPublicShares {
+		if err = util.WriteMarshaled(w, m.oldPublicShares[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:gocritic
+func (m *initiatorReshareMsg) Read(r io.Reader) error {
+	var err error
+	if err = m.initiatorInitMsg.Read(r); err != nil {
+		return err
+	}
+	if err = util.ReadUint16(r, &m.newCount); err != nil {
+		return err
+	}
+	var sharedAddressBin []byte
+	if sharedAddressBin, err = util.ReadBytes16(r); err != nil {
+		return err
+	}
+	if m.sharedAddress, _, err = ledgerstate.AddressFromBytes(sharedAddressBin); err != nil {
+		return err
+	}
+	if err = util.ReadUint16(r, &m.oldThreshold); err != nil {
+		return err
+	}
+	var arrLen uint16
+	if err = util.ReadUint16(r, &arrLen); err != nil {
+		return err
+	}
+	m.oldPeerPubs = make([]*ed25519.PublicKey, arrLen)
+	for i := range m.oldPeerPubs {
+		var peerPubBytes []byte
+		if peerPubBytes, err = util.ReadBytes16(r); err != nil {
+			return err
+		}
+		peerPubKey, _, err := ed25519.PublicKeyFromBytes(peerPubBytes)
+		if err != nil {
+			return err
+		}
+		m.oldPeerPubs[i] = &peerPubKey
+	}
+	if err = util.ReadUint16(r, &arrLen); err != nil {
+		return err
+	}
+	m.oldPublicShares = make([]kyber.Point, arrLen)
+	for i := range m.oldPublicShares {
+		m.oldPublicShares[i] = m.blsSuite.Point()
+		if err = util.ReadMarshaled(r, m.oldPublicShares[i]); err != nil {
+			return xerrors.Errorf("failed to unmarshal initiatorReshareMsg.oldPublicShares: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *initiatorReshareMsg) fromBytes(buf []byte, blsSuite kyber.Group) error {
+	r := bytes.NewReader(buf)
+	m.blsSuite = blsSuite
+	return m.Read(r)
+}
+
+// oldIndex returns the index of the share of a dealer in the current committee.
+func (m *initiatorReshareMsg) oldIndex(pubKey *ed25519.PublicKey) (uint16, bool) {
+	for i := range m.oldPeerPubs {
+		if *m.oldPeerPubs[i] == *pubKey {
+			return uint16(i), true
+		}
+	}
+	return 0, false
+}
+
 //
 // initiatorStepMsg
 //
@@ -1098,6 +1228,78 @@ func (m *rabinReconstructCommitsMsg) fromBytes(buf []byte) error {
 	return m.Read(rdr)
 }
 
+//
+// reshareDealMsg
+//
+// A deal of the resharing, sent by a dealer to all the other peers.
+// It contains the commitments of the dealer's polynomial and the
+// share of the receiver, encrypted with its public key. The share
+// is empty, if the receiver is not a member of the new committee.
+// The non-dealers send empty deals to synchronize the step.
+//
+type reshareDealMsg struct {
+	step     byte
+	commits  []kyber.Point
+	encShare []byte
+	blsSuite kyber.Group // Transient, for un-marshaling only.
+}
+
+func (m *reshareDealMsg) MsgType() byte {
+	return reshareDealMsgType
+}
+
+func (m *reshareDealMsg) Step() byte {
+	return m.step
+}
+
+func (m *reshareDealMsg) SetStep(step byte) {
+	m.step = step
+}
+
+func (m *reshareDealMsg) Write(w io.Writer) error {
+	var err error
+	if err = util.WriteByte(w, m.step); err != nil {
+		return err
+	}
+	if err = util.WriteUint16(w, uint16(len(m.commits))); err != nil {
+		return err
+	}
+	for i := range m.commits {
+		if err = util.WriteMarshaled(w, m.commits[i]); err != nil {
+			return err
+		}
+	}
+	return util.WriteBytes16(w, m.encShare)
+}
+
+func (m *reshareDealMsg) Read(r io.Reader) error {
+	var err error
+	if m.step, err = util.ReadByte(r); err != nil {
+		return err
+	}
+	var arrLen uint16
+	if err = util.ReadUint16(r, &arrLen); err != nil {
+		return err
+	}
+	m.commits = make([]kyber.Point, arrLen)
+	for i := range m.commits {
+		m.commits[i] = m.blsSuite.Point()
+		if err = util.ReadMarshaled(r, m.commits[i]); err != nil {
+			return xerrors.Errorf("failed to unmarshal reshareDealMsg.commits: %w", err)
+		}
+	}
+	if m.encShare, err = util.ReadBytes16(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *reshareDealMsg) fromBytes(buf []byte, blsSuite kyber.Group) error {
+	r := bytes.NewReader(buf)
+	m.blsSuite = blsSuite
+	return m.Read(r)
+}
+
 //
 // type PriShare struct {
 // 	I int          // Index of the private share
@@ -76,18 +76,31 @@ func (n *Node) receiveInitMessage(peerMsg *peering.PeerMessageIn) {
 		panic(fmt.Errorf("DKG init handler does not accept peer messages of other receiver type %v, message type=%v",
 			peerMsg.MsgReceiver, peerMsg.MsgType))
 	}
-	if peerMsg.MsgType != initiatorInitMsgType {
+	switch peerMsg.MsgType {
+	case initiatorInitMsgType:
+		msg := &initiatorInitMsg{}
+		if err := msg.fromBytes(peerMsg.MsgData); err != nil {
+			n.log.Warnf("Dropping unknown message: %v", peerMsg)
+			return
+		}
+		n.initMsgQueue <- &initiatorInitMsgIn{
+			initiatorInitMsg: *msg,
+			SenderPubKey:     peerMsg.SenderPubKey,
+		}
+	case initiatorReshareMsgType:
+		msg := &initiatorReshareMsg{}
+		if err := msg.fromBytes(peerMsg.MsgData, n.blsSuite); err != nil {
+			n.log.Warnf("Dropping unknown message: %v", peerMsg)
+			return
+		}
+		n.initMsgQueue <- &initiatorInitMsgIn{
+			initiatorInitMsg: msg.initiatorInitMsg,
+			reshare:          msg,
+			SenderPubKey:     peerMsg.SenderPubKey,
+		}
+	default:
 		panic(fmt.Errorf("Wrong type of DKG init message: %v", peerMsg.MsgType))
 	}
-	msg := &initiatorInitMsg{}
-	if err := msg.fromBytes(peerMsg.MsgData); err != nil {
-		n.log.Warnf("Dropping unknown message: %v", peerMsg)
-		return
-	}
-	n.initMsgQueue <- &initiatorInitMsgIn{
-		initiatorInitMsg: *msg,
-		SenderPubKey:     peerMsg.SenderPubKey,
-	}
 }
 
 func (n *Node) Close() {
@@ -286,7 +299,12 @@ func (n *Node) onInitMsg(msg *initiatorInitMsgIn) {
 		// This part should be executed async, because it accesses the network again, and can
 		// be locked because of the naive implementation of `events.Event`. It locks on all the callbacks.
 		n.procLock.Lock()
-		if p, err = onInitiatorInit(msg.peeringID, &msg.initiatorInitMsg, n); err == nil {
+		if msg.reshare != nil {
+			p, err = onInitiatorReshare(msg.peeringID, msg.reshare, n)
+		} else {
+			p, err = onInitiatorInit(msg.peeringID, &msg.initiatorInitMsg, n)
+		}
+		if err == nil {
 			n.processes[p.dkgRef] = p
 		}
 		n.procLock.Unlock()
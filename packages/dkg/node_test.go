@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/dkg"
 	"github.com/iotaledger/wasp/packages/peering"
@@ -157,3 +158,80 @@ func TestLowN(t *testing.T) {
 		require.NotNil(t, dkShare.SharedPublic)
 	}
 }
+
+// TestReshare checks, if the key can be reshared to a new committee without changing its address.
+func TestReshare(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	//
+	// Create a fake network and keys for the tests.
+	// The first 4 nodes generate the key, then the node 3 is replaced by the node 4.
+	timeout := 100 * time.Second
+	var threshold uint16 = 3
+	peerNetIDs, peerIdentities := testpeers.SetupKeys(5)
+	var peeringNetwork *testutil.PeeringNetwork = testutil.NewPeeringNetwork(
+		peerNetIDs, peerIdentities, 10000,
+		testutil.NewPeeringNetReliable(log),
+		testlogger.WithLevel(log, logger.LevelWarn, false),
+	)
+	var networkProviders []peering.NetworkProvider = peeringNetwork.NetworkProviders()
+	//
+	// Initialize the DKG subsystem in each node.
+	var dkgNodes []*dkg.Node = make([]*dkg.Node, len(peerNetIDs))
+	var registries []*testutil.DkgRegistryProvider = make([]*testutil.DkgRegistryProvider, len(peerNetIDs))
+	for i := range peerNetIDs {
+		registries[i] = testutil.NewDkgRegistryProvider(tcrypto.DefaultSuite())
+		dkgNode, err := dkg.NewNode(
+			peerIdentities[i], networkProviders[i], registries[i],
+			testlogger.WithLevel(log.With("NetID", peerNetIDs[i]), logger.LevelDebug, false),
+		)
+		require.NoError(t, err)
+		dkgNodes[i] = dkgNode
+	}
+	allPubs := testpeers.PublicKeys(peerIdentities)
+	dkShare, err := dkgNodes[0].GenerateDistributedKey(allPubs[:4], threshold, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	//
+	// Not enough members of the current committee.
+	_, err = dkgNodes[0].ReshareDistributedKey(dkShare.Address, allPubs[3:5], 2, 1*time.Second, 2*time.Second, timeout)
+	require.Error(t, err)
+	//
+	// Reshare the key to the new committee.
+	newPubs := []*ed25519.PublicKey{allPubs[0], allPubs[1], allPubs[4], allPubs[2]}
+	resharedShare, err := dkgNodes[1].ReshareDistributedKey(dkShare.Address, newPubs, threshold, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	require.True(t, dkShare.Address.Equals(resharedShare.Address))
+	require.True(t, dkShare.SharedPublic.Equal(resharedShare.SharedPublic))
+	//
+	// The new committee can sign with the key.
+	data := []byte("hello")
+	newShares := make([]*tcrypto.DKShare, len(newPubs))
+	sigShares := make([][]byte, 0)
+	for i, nodeIdx := range []int{0, 1, 4, 2} {
+		newShares[i], err = registries[nodeIdx].LoadDKShare(dkShare.Address)
+		require.NoError(t, err)
+		require.EqualValues(t, i, *newShares[i].Index)
+		require.EqualValues(t, len(newPubs), newShares[i].N)
+		require.True(t, dkShare.SharedPublic.Equal(newShares[i].SharedPublic))
+		if i < int(threshold) {
+			sigShare, err := newShares[i].SignShare(data)
+			require.NoError(t, err)
+			require.NoError(t, newShares[0].VerifySigShare(data, sigShare))
+			sigShares = append(sigShares, sigShare)
+		}
+	}
+	signature, err := newShares[3].RecoverFullSignature(sigShares, data)
+	require.NoError(t, err)
+	require.NoError(t, newShares[3].VerifyMasterSignature(data, signature.Signature.Bytes()))
+	//
+	// The node 2 leaves the committee, it drops its share after the others have committed theirs.
+	_, err = dkgNodes[2].ReshareDistributedKey(dkShare.Address, newPubs[:3], 2, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	_, err = registries[2].LoadDKShare(dkShare.Address)
+	require.Error(t, err)
+	for _, nodeIdx := range []int{0, 1, 4} {
+		share, err := registries[nodeIdx].LoadDKShare(dkShare.Address)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, share.N)
+	}
+}
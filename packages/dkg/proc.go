@@ -55,6 +55,9 @@ type proc struct {
 	log          *logger.Logger                   // A logger to use.
 	myPubKey     *ed25519.PublicKey               // Just to make logging easier.
 	steps        map[byte]*procStep               // All the steps for the procedure.
+	reshare      *initiatorReshareMsg             // Parameters of the resharing, nil for a new key.
+	reshareOld   *tcrypto.DKShare                 // Share of the key being reshared, if this node is a dealer.
+	reshareOwn   *reshareDealMsg                  // Deal of this node to itself, if it is both a dealer and a new member.
 }
 
 func onInitiatorInit(dkgID peering.PeeringID, msg *initiatorInitMsg, node *Node) (*proc, error) {
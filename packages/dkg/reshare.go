@@ -0,0 +1,518 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+//
+// This file contains the resharing of an existing distributed key.
+//
+// The resharing redistributes the shares of the same key to a new set of
+// members with a new threshold, so the shared public key and the address
+// of the key remain the same. The old members being in the resharing group
+// act as dealers: each of them shares its own share s_i with a new random
+// polynomial f_i of the new threshold, where f_i(0) = s_i. A new member j
+// then combines the received values f_i(j+1) with the Lagrange coefficients
+// of the dealers to get its new share, and the public commitments of the
+// polynomials in the same way to get the new public commitments. At least
+// the old threshold of the dealers must take part in the resharing.
+//
+// The deals are verified against the public shares of the old committee,
+// and the recovered commitments are checked against the shared address.
+//
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/peering"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/encrypt/ecies"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/sign/bdn"
+	"golang.org/x/xerrors"
+)
+
+const (
+	reshareStep0Initialize          = byte(0)
+	reshareStep1SendDeals           = byte(1)
+	reshareStep2ComputeShares       = byte(2)
+	reshareStep3Commit              = byte(3)
+	reshareStep4ReleaseAndTerminate = byte(4)
+)
+
+// ReshareDistributedKey redistributes the shares of an existing distributed key to a new set of peers
+// with a new threshold. The shared public key and the address of the key are not changed.
+// This function is executed on the initiator node, which must hold a share of the key.
+// The members of the current committee in peerPubs, including the initiator, deal their shares,
+// so at least the current threshold of them must be available. The initiator takes part in the
+// resharing even if it is not in peerPubs, it then drops its share at the end, after all the new
+// members have committed their new shares.
+//nolint:funlen,gocritic
+func (n *Node) ReshareDistributedKey(
+	sharedAddress ledgerstate.Address,
+	peerPubs []*ed25519.PublicKey,
+	threshold uint16,
+	roundRetry time.Duration, // Retry for Peer <-> Peer communication.
+	stepRetry time.Duration, // Retry for Initiator -> Peer communication.
+	timeout time.Duration, // Timeout for the entire procedure.
+) (*tcrypto.DKShare, error) {
+	n.log.Infof("Starting DKG resharing of %v, initiator=%v, peers=%+v", sharedAddress.Base58(), n.netProvider.Self().NetID(), peerPubs)
+	var err error
+	peerCount := uint16(len(peerPubs))
+	//
+	// Some validation for the parameters.
+	if peerCount < 1 || threshold < 1 || threshold > peerCount {
+		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: N = %d, T = %d", peerCount, threshold))
+	}
+	if threshold < peerCount/2+1 {
+		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: for N = %d value T must be at least %d", peerCount, peerCount/2+1))
+	}
+	var oldShare *tcrypto.DKShare
+	if oldShare, err = n.registry.LoadDKShare(sharedAddress); err != nil {
+		return nil, invalidParams(xerrors.Errorf("cannot load the key to reshare: %w", err))
+	}
+	if len(oldShare.NodePubKeys) != int(oldShare.N) || len(oldShare.PublicShares) != int(oldShare.N) {
+		return nil, invalidParams(fmt.Errorf("the key %v has no information on the committee members", sharedAddress.Base58()))
+	}
+	groupPubs := make([]*ed25519.PublicKey, 0, peerCount+1)
+	selfFound := false
+	for i := range peerPubs {
+		for j := 0; j < i; j++ {
+			if *peerPubs[i] == *peerPubs[j] {
+				return nil, invalidParams(fmt.Errorf("duplicate peer %v", peerPubs[i].String()))
+			}
+		}
+		if *peerPubs[i] == n.identity.PublicKey {
+			selfFound = true
+		}
+		groupPubs = append(groupPubs, peerPubs[i])
+	}
+	if !selfFound {
+		groupPubs = append(groupPubs, &n.identity.PublicKey)
+	}
+	dealerCount := 0
+	for i := range groupPubs {
+		for j := range oldShare.NodePubKeys {
+			if *groupPubs[i] == *oldShare.NodePubKeys[j] {
+				dealerCount++
+				break
+			}
+		}
+	}
+	if dealerCount < int(oldShare.T) {
+		return nil, invalidParams(fmt.Errorf(
+			"at least %d members of the current committee must take part in the resharing, have %d",
+			oldShare.T, dealerCount,
+		))
+	}
+	//
+	// Setup network connections.
+	dkgID := peering.RandomPeeringID()
+	var netGroup peering.GroupProvider
+	if netGroup, err = n.netProvider.PeerGroup(dkgID, groupPubs); err != nil {
+		return nil, err
+	}
+	defer netGroup.Close()
+	recvCh := make(chan *peering.PeerMessageIn, len(groupPubs)*2)
+	attachID := n.netProvider.Attach(&dkgID, peering.PeerMessageReceiverDkg, func(recv *peering.PeerMessageIn) {
+		recvCh <- recv
+	})
+	defer n.netProvider.Detach(attachID)
+	rTimeout := stepRetry
+	gTimeout := timeout
+	//
+	// Initialize the peers.
+	if err = n.exchangeInitiatorAcks(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep0Initialize,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends step=%v command to %v", reshareStep0Initialize, peer.NetID())
+			peer.SendMsg(makePeerMessage(initPeeringID, peering.PeerMessageReceiverDkgInit, reshareStep0Initialize, &initiatorReshareMsg{
+				initiatorInitMsg: initiatorInitMsg{
+					dkgRef:       dkgID.String(),
+					peeringID:    dkgID,
+					peerPubs:     groupPubs,
+					initiatorPub: &n.identity.PublicKey,
+					threshold:    threshold,
+					timeout:      timeout,
+					roundRetry:   roundRetry,
+				},
+				newCount:        peerCount,
+				sharedAddress:   sharedAddress,
+				oldThreshold:    oldShare.T,
+				oldPeerPubs:     oldShare.NodePubKeys,
+				oldPublicShares: oldShare.PublicShares,
+			}))
+		},
+	); err != nil {
+		return nil, err
+	}
+	//
+	// Exchange the deals.
+	if err = n.exchangeInitiatorStep(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, dkgID, reshareStep1SendDeals); err != nil {
+		return nil, err
+	}
+	//
+	// Compute the new shares and get the public keys.
+	pubShareResponses := map[int]*initiatorPubShareMsg{}
+	if err = n.exchangeInitiatorMsgs(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep2ComputeShares,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends step=%v command to %v", reshareStep2ComputeShares, peer.NetID())
+			peer.SendMsg(makePeerMessage(dkgID, peering.PeerMessageReceiverDkg, reshareStep2ComputeShares, &initiatorStepMsg{}))
+		},
+		func(recv *peering.PeerMessageGroupIn, initMsg initiatorMsg) (bool, error) {
+			switch msg := initMsg.(type) {
+			case *initiatorPubShareMsg:
+				if recv.SenderIndex >= peerCount {
+					return false, errors.New("unexpected initiatorPubShareMsg from a leaving dealer")
+				}
+				pubShareResponses[int(recv.SenderIndex)] = msg
+				return true, nil
+			case *initiatorStatusMsg:
+				if recv.SenderIndex < peerCount {
+					return false, errors.New("unexpected message type instead of initiatorPubShareMsg")
+				}
+				return true, nil
+			default:
+				n.log.Errorf("unexpected message type instead of initiatorPubShareMsg: %V", msg)
+				return false, errors.New("unexpected message type instead of initiatorPubShareMsg")
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+	publicShares := make([]kyber.Point, peerCount)
+	for i := range pubShareResponses {
+		if !sharedAddress.Equals(pubShareResponses[i].sharedAddress) {
+			return nil, fmt.Errorf("nodes reshared to a different address")
+		}
+		if !oldShare.SharedPublic.Equal(pubShareResponses[i].sharedPublic) {
+			return nil, fmt.Errorf("nodes reshared to a different shared public key")
+		}
+		publicShares[i] = pubShareResponses[i].publicShare
+		var pubShareBytes []byte
+		if pubShareBytes, err = pubShareResponses[i].publicShare.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if err = bdn.Verify(n.blsSuite, pubShareResponses[i].publicShare, pubShareBytes, pubShareResponses[i].signature); err != nil {
+			return nil, err
+		}
+	}
+	n.log.Debugf("Reshared SharedAddress=%v", sharedAddress)
+	//
+	// Commit the keys to persistent storage.
+	if err = n.exchangeInitiatorAcks(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep3Commit,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends step=%v command to %v", reshareStep3Commit, peer.NetID())
+			peer.SendMsg(makePeerMessage(dkgID, peering.PeerMessageReceiverDkg, reshareStep3Commit, &initiatorDoneMsg{
+				pubShares: publicShares,
+			}))
+		},
+	); err != nil {
+		return nil, err
+	}
+	//
+	// All the new members have acknowledged their new shares, the leaving dealers can drop the old ones.
+	if err = n.exchangeInitiatorStep(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, dkgID, reshareStep4ReleaseAndTerminate); err != nil {
+		return nil, err
+	}
+	dkShare := tcrypto.DKShare{
+		Address:       sharedAddress,
+		N:             peerCount,
+		T:             threshold,
+		Index:         nil, // Not meaningful in this case.
+		SharedPublic:  oldShare.SharedPublic,
+		PublicCommits: nil, // Not meaningful in this case.
+		PublicShares:  publicShares,
+		PrivateShare:  nil, // Not meaningful in this case.
+		NodePubKeys:   peerPubs,
+	}
+	return &dkShare, nil
+}
+
+func onInitiatorReshare(dkgID peering.PeeringID, msg *initiatorReshareMsg, node *Node) (*proc, error) {
+	log := node.log.With("dkgID", dkgID.String())
+	var err error
+
+	if msg.newCount < 1 || int(msg.newCount) > len(msg.peerPubs) || msg.threshold < 1 || msg.threshold > msg.newCount {
+		return nil, fmt.Errorf("wrong resharing parameters: N = %d, T = %d", msg.newCount, msg.threshold)
+	}
+	if len(msg.oldPeerPubs) != len(msg.oldPublicShares) {
+		return nil, errors.New("wrong resharing parameters: inconsistent current committee")
+	}
+	var netGroup peering.GroupProvider
+	if netGroup, err = node.netProvider.PeerGroup(dkgID, msg.peerPubs); err != nil {
+		return nil, err
+	}
+	p := proc{
+		dkgRef:       msg.dkgRef,
+		dkgID:        dkgID,
+		node:         node,
+		nodeIndex:    netGroup.SelfIndex(),
+		initiatorPub: msg.initiatorPub,
+		threshold:    msg.threshold,
+		roundRetry:   msg.roundRetry,
+		netGroup:     netGroup,
+		dkgLock:      &sync.RWMutex{},
+		peerMsgCh:    make(chan *peering.PeerMessageGroupIn, len(msg.peerPubs)),
+		log:          log,
+		myPubKey:     node.netProvider.Self().PubKey(),
+		reshare:      msg,
+	}
+	if oldIndex, isDealer := msg.oldIndex(&node.identity.PublicKey); isDealer {
+		// Only the dealers have the current share, and it must be the one being reshared.
+		if p.reshareOld, err = node.registry.LoadDKShare(msg.sharedAddress); err != nil {
+			return nil, xerrors.Errorf("cannot load the key to reshare: %w", err)
+		}
+		if p.reshareOld.Index == nil || *p.reshareOld.Index != oldIndex || p.reshareOld.T != msg.oldThreshold {
+			return nil, errors.New("the key to reshare does not match the current committee")
+		}
+		if len(p.reshareOld.PublicShares) != len(msg.oldPublicShares) {
+			return nil, errors.New("the key to reshare does not match the current public shares")
+		}
+		for i := range msg.oldPublicShares {
+			if !p.reshareOld.PublicShares[i].Equal(msg.oldPublicShares[i]) {
+				return nil, errors.New("the key to reshare does not match the current public shares")
+			}
+		}
+	}
+	p.log.Infof("Starting DKG resharing Peer process at %v for DkgID=%v", p.myPubKey.String(), p.dkgID.String())
+	stepsStart := make(chan map[uint16]*peering.PeerMessageData)
+	p.steps = make(map[byte]*procStep)
+	p.steps[reshareStep1SendDeals] = newProcStep(reshareStep1SendDeals, &p,
+		stepsStart,
+		p.reshareStep1SendDealsMakeSent,
+		p.reshareStep1SendDealsMakeResp,
+	)
+	p.steps[reshareStep2ComputeShares] = newProcStep(reshareStep2ComputeShares, &p,
+		p.steps[reshareStep1SendDeals].doneCh,
+		p.reshareStep2ComputeSharesMakeSent,
+		p.reshareStep2ComputeSharesMakeResp,
+	)
+	p.steps[reshareStep3Commit] = newProcStep(reshareStep3Commit, &p,
+		p.steps[reshareStep2ComputeShares].doneCh,
+		p.reshareStep3CommitMakeSent,
+		p.reshareStep3CommitMakeResp,
+	)
+	p.steps[reshareStep4ReleaseAndTerminate] = newProcStep(reshareStep4ReleaseAndTerminate, &p,
+		p.steps[reshareStep3Commit].doneCh,
+		p.reshareStep4ReleaseAndTerminateMakeSent,
+		p.reshareStep4ReleaseAndTerminateMakeResp,
+	)
+	go p.processLoop(msg.timeout, p.steps[reshareStep4ReleaseAndTerminate].doneCh)
+	p.attachID = p.netGroup.Attach(peering.PeerMessageReceiverDkg, p.onPeerMessage)
+	stepsStart <- make(map[uint16]*peering.PeerMessageData)
+	return &p, nil
+}
+
+// reshareStep1SendDeals
+func (p *proc) reshareStep1SendDealsMakeSent(step byte, initRecv *peering.PeerMessageGroupIn, prevMsgs map[uint16]*peering.PeerMessageData) (map[uint16]*peering.PeerMessageData, error) {
+	var err error
+	var priPoly *share.PriPoly
+	var commits []kyber.Point
+	if p.reshareOld != nil {
+		priPoly = share.NewPriPoly(p.node.blsSuite, int(p.reshare.threshold), p.reshareOld.PrivateShare, p.node.blsSuite.RandomStream())
+		_, commits = priPoly.Commit(nil).Info()
+	}
+	sentMsgs := make(map[uint16]*peering.PeerMessageData)
+	for i := uint16(0); i < uint16(len(p.reshare.peerPubs)); i++ {
+		deal := reshareDealMsg{}
+		if priPoly != nil {
+			deal.commits = commits
+			if i < p.reshare.newCount {
+				if deal.encShare, err = p.reshareEncryptShare(priPoly.Eval(int(i)).V, p.reshare.peerPubs[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if i == p.nodeIndex {
+			p.reshareOwn = &deal
+			continue
+		}
+		sentMsgs[i] = makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, &deal)
+	}
+	return sentMsgs, nil
+}
+
+func (p *proc) reshareStep1SendDealsMakeResp(step byte, initRecv *peering.PeerMessageGroupIn, recvMsgs map[uint16]*peering.PeerMessageData) (*peering.PeerMessageData, error) {
+	return makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+}
+
+// reshareStep2ComputeShares
+func (p *proc) reshareStep2ComputeSharesMakeSent(step byte, initRecv *peering.PeerMessageGroupIn, prevMsgs map[uint16]*peering.PeerMessageData) (map[uint16]*peering.PeerMessageData, error) {
+	var err error
+	if p.nodeIndex >= p.reshare.newCount {
+		return make(map[uint16]*peering.PeerMessageData), nil // A leaving dealer gets no share.
+	}
+	//
+	// Verify the received deals against the public shares of the dealers.
+	deals := make(map[uint16]*reshareDealMsg, len(prevMsgs)+1)
+	for i := range prevMsgs {
+		deal := reshareDealMsg{}
+		if err = deal.fromBytes(prevMsgs[i].MsgData, p.node.blsSuite); err != nil {
+			return nil, err
+		}
+		deals[i] = &deal
+	}
+	deals[p.nodeIndex] = p.reshareOwn
+	priShares := make([]*share.PriShare, 0, len(deals))
+	pubShares := make([][]*share.PubShare, p.reshare.threshold)
+	for i := range deals {
+		dealerPub, _ := p.netGroup.PubKeyByIndex(i)
+		oldIndex, isDealer := p.reshare.oldIndex(dealerPub)
+		if !isDealer {
+			if len(deals[i].commits) != 0 {
+				return nil, fmt.Errorf("unexpected deal from %v, it is not a dealer", dealerPub.String())
+			}
+			continue
+		}
+		if len(deals[i].commits) != int(p.reshare.threshold) {
+			return nil, fmt.Errorf("wrong number of commitments in the deal from %v", dealerPub.String())
+		}
+		pubPoly := share.NewPubPoly(p.node.blsSuite, nil, deals[i].commits)
+		if !pubPoly.Commit().Equal(p.reshare.oldPublicShares[oldIndex]) {
+			return nil, fmt.Errorf("the deal from %v does not match its public share", dealerPub.String())
+		}
+		var value kyber.Scalar
+		if value, err = p.reshareDecryptShare(deals[i].encShare); err != nil {
+			return nil, xerrors.Errorf("cannot decrypt the deal from %v: %w", dealerPub.String(), err)
+		}
+		if !pubPoly.Check(&share.PriShare{I: int(p.nodeIndex), V: value}) {
+			return nil, fmt.Errorf("the deal from %v does not match its commitments", dealerPub.String())
+		}
+		priShares = append(priShares, &share.PriShare{I: int(oldIndex), V: value})
+		for k := range pubShares {
+			pubShares[k] = append(pubShares[k], &share.PubShare{I: int(oldIndex), V: deals[i].commits[k]})
+		}
+	}
+	oldThreshold := int(p.reshare.oldThreshold)
+	oldCount := len(p.reshare.oldPeerPubs)
+	if len(priShares) < oldThreshold {
+		return nil, fmt.Errorf("not enough deals: have %d, need %d", len(priShares), oldThreshold)
+	}
+	//
+	// Combine the deals to the new share and the new public commitments.
+	var privateShare kyber.Scalar
+	if privateShare, err = share.RecoverSecret(p.node.blsSuite, priShares, oldThreshold, oldCount); err != nil {
+		return nil, err
+	}
+	publicCommits := make([]kyber.Point, p.reshare.threshold)
+	for k := range publicCommits {
+		if publicCommits[k], err = share.RecoverCommit(p.node.blsSuite, pubShares[k], oldThreshold, oldCount); err != nil {
+			return nil, err
+		}
+	}
+	pubPoly := share.NewPubPoly(p.node.blsSuite, nil, publicCommits)
+	publicShares := make([]kyber.Point, p.reshare.newCount)
+	for i := range publicShares {
+		publicShares[i] = pubPoly.Eval(i).V
+	}
+	if !publicShares[p.nodeIndex].Equal(p.node.blsSuite.Point().Mul(privateShare, nil)) {
+		return nil, errors.New("the new share does not match the new public commitments")
+	}
+	p.dkShare, err = tcrypto.NewDKShare(
+		p.nodeIndex,                             // Index
+		p.reshare.newCount,                      // N
+		p.reshare.threshold,                     // T
+		publicCommits[0],                        // SharedPublic
+		publicCommits,                           // PublicCommits
+		publicShares,                            // PublicShares
+		privateShare,                            // PrivateShare
+		p.reshare.peerPubs[:p.reshare.newCount], // NodePubKeys
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !p.dkShare.Address.Equals(p.reshare.sharedAddress) {
+		p.dkShare = nil
+		return nil, errors.New("the reshared key does not match the shared address")
+	}
+	return make(map[uint16]*peering.PeerMessageData), nil
+}
+
+func (p *proc) reshareStep2ComputeSharesMakeResp(step byte, initRecv *peering.PeerMessageGroupIn, recvMsgs map[uint16]*peering.PeerMessageData) (*peering.PeerMessageData, error) {
+	if p.dkShare == nil {
+		return makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+	}
+	pubShareMsg, err := p.makeInitiatorPubShareMsg(step)
+	if err != nil {
+		return nil, err
+	}
+	return makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, pubShareMsg), nil
+}
+
+// reshareStep3Commit
+func (p *proc) reshareStep3CommitMakeSent(step byte, initRecv *peering.PeerMessageGroupIn, prevMsgs map[uint16]*peering.PeerMessageData) (map[uint16]*peering.PeerMessageData, error) {
+	if p.nodeIndex >= p.reshare.newCount {
+		// The leaving dealer keeps its share until all the new members have committed theirs.
+		return make(map[uint16]*peering.PeerMessageData), nil
+	}
+	doneMsg := initiatorDoneMsg{}
+	if err := doneMsg.fromBytes(initRecv.MsgData, p.node.blsSuite); err != nil {
+		p.log.Warnf("Dropping message, failed to decode: %v", initRecv)
+		return nil, err
+	}
+	if p.dkShare == nil {
+		return nil, errors.New("there is no dkShare to commit")
+	}
+	p.dkShare.PublicShares = doneMsg.pubShares
+	// The current share stays in use until the initiator confirms that all the new members have their new shares.
+	if err := p.node.registry.SavePendingDKShare(p.dkShare); err != nil {
+		return nil, err
+	}
+	return make(map[uint16]*peering.PeerMessageData), nil
+}
+
+func (p *proc) reshareStep3CommitMakeResp(step byte, initRecv *peering.PeerMessageGroupIn, recvMsgs map[uint16]*peering.PeerMessageData) (*peering.PeerMessageData, error) {
+	return makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+}
+
+// reshareStep4ReleaseAndTerminate
+func (p *proc) reshareStep4ReleaseAndTerminateMakeSent(step byte, initRecv *peering.PeerMessageGroupIn, prevMsgs map[uint16]*peering.PeerMessageData) (map[uint16]*peering.PeerMessageData, error) {
+	if p.nodeIndex >= p.reshare.newCount {
+		// The leaving dealer is not a member of the committee anymore.
+		if err := p.node.registry.DeleteDKShare(p.reshare.sharedAddress); err != nil {
+			return nil, err
+		}
+		return make(map[uint16]*peering.PeerMessageData), nil
+	}
+	// The new share replaces the current one. A running chain of the address picks it up with the next state
+	// of the chain and recreates its committee.
+	if err := p.node.registry.CommitPendingDKShare(p.reshare.sharedAddress); err != nil {
+		return nil, err
+	}
+	return make(map[uint16]*peering.PeerMessageData), nil
+}
+
+func (p *proc) reshareStep4ReleaseAndTerminateMakeResp(step byte, initRecv *peering.PeerMessageGroupIn, recvMsgs map[uint16]*peering.PeerMessageData) (*peering.PeerMessageData, error) {
+	return makePeerMessage(p.dkgID, peering.PeerMessageReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+}
+
+// reshareEncryptShare encrypts a share of the deal with the public key of the receiver.
+func (p *proc) reshareEncryptShare(value kyber.Scalar, receiverPub *ed25519.PublicKey) ([]byte, error) {
+	pubKey := p.node.edSuite.Point()
+	if err := pubKey.UnmarshalBinary(receiverPub.Bytes()); err != nil {
+		return nil, err
+	}
+	valueBytes, err := value.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return ecies.Encrypt(p.node.edSuite, pubKey, valueBytes, nil)
+}
+
+// reshareDecryptShare decrypts a share of the deal with the private key of this node.
+func (p *proc) reshareDecryptShare(encShare []byte) (kyber.Scalar, error) {
+	valueBytes, err := ecies.Decrypt(p.node.edSuite, p.node.secKey, encShare, nil)
+	if err != nil {
+		return nil, err
+	}
+	value := p.node.blsSuite.Scalar()
+	if err := value.UnmarshalBinary(valueBytes); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package evmtypes
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// TraceConfig holds the parameters of the debug_traceTransaction and debug_traceCall methods.
+// If Tracer is nil, the struct logger is used, configured by LogConfig; otherwise it is
+// the name of a builtin JavaScript tracer (e.g. "callTracer") or the code of a custom one.
+type TraceConfig struct {
+	*vm.LogConfig
+	Tracer  *string
+	Timeout *string
+}
+
+func EncodeTraceConfig(c *TraceConfig) []byte {
+	if c == nil {
+		c = &TraceConfig{}
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func DecodeTraceConfig(b []byte) (*TraceConfig, error) {
+	c := &TraceConfig{}
+	if len(b) == 0 {
+		return c, nil
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -30,3 +30,23 @@ func GetSender(tx *types.Transaction) common.Address {
 	sender, _ := types.Sender(Signer(tx.ChainId()), tx)
 	return sender
 }
+
+// CapMessageGas returns the message with its gas limit reduced to gasLimit, if it is higher
+func CapMessageGas(msg types.Message, gasLimit uint64) types.Message {
+	if msg.Gas() <= gasLimit {
+		return msg
+	}
+	return types.NewMessage(
+		msg.From(),
+		msg.To(),
+		msg.Nonce(),
+		msg.Value(),
+		gasLimit,
+		msg.GasPrice(),
+		msg.GasFeeCap(),
+		msg.GasTipCap(),
+		msg.Data(),
+		msg.AccessList(),
+		msg.IsFake(),
+	)
+}
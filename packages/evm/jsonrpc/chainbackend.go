@@ -5,6 +5,7 @@ package jsonrpc
 
 import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 )
@@ -13,5 +14,9 @@ type ChainBackend interface {
 	PostOnLedgerRequest(scName string, funName string, transfer colored.Balances, args dict.Dict) error
 	PostOffLedgerRequest(scName string, funName string, transfer colored.Balances, args dict.Dict) error
 	CallView(scName string, funName string, args dict.Dict) (dict.Dict, error)
+	CallViewAtBlockIndex(blockIndex uint32, scName string, funName string, args dict.Dict) (dict.Dict, error)
+	PendingRequests() ([]iscp.Request, error)
 	Signer() *ed25519.KeyPair
+	// SubscribeBlocks calls the handler each time a block is committed on the chain, until unsubscribe is called
+	SubscribeBlocks(handler func()) (unsubscribe func())
 }
@@ -4,6 +4,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -19,7 +20,6 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
-	"github.com/iotaledger/wasp/packages/vm/core/root"
 )
 
 type EVMChain struct {
@@ -56,13 +56,11 @@ func (e *EVMChain) BlockNumber() (*big.Int, error) {
 }
 
 func (e *EVMChain) FeeColor() (colored.Color, error) {
-	feeInfo, err := e.backend.CallView(governance.Contract.Name, governance.FuncGetFeeInfo.Name, dict.Dict{
-		root.ParamHname: iscp.Hn(e.contractName).Bytes(),
-	})
+	feeInfo, err := e.backend.CallView(governance.Contract.Name, governance.FuncGetFeePolicy.Name, nil)
 	if err != nil {
 		return colored.Color{}, err
 	}
-	return codec.DecodeColor(feeInfo.MustGet(governance.ParamFeeColor))
+	return codec.DecodeColor(feeInfo.MustGet(governance.VarFeeColor))
 }
 
 func (e *EVMChain) GasLimitFee(tx *types.Transaction) (colored.Color, uint64, error) {
@@ -139,10 +137,32 @@ func paramsWithOptionalBlockNumberOrHash(blockNumberOrHash rpc.BlockNumberOrHash
 	return ret
 }
 
+// callViewAtBlock calls the view of the EVM contract at the requested EVM block. If the contract knows the ISCP
+// block in which the EVM block was minted (evmlight), the view is called on the state of the chain at that
+// ISCP block; otherwise it is called on the latest state
+func (e *EVMChain) callViewAtBlock(funName string, blockNumberOrHash rpc.BlockNumberOrHash, params dict.Dict) (dict.Dict, error) {
+	params = paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, params)
+	if blockNumber, ok := blockNumberOrHash.Number(); ok && parseBlockNumber(blockNumber) == nil {
+		return e.backend.CallView(e.contractName, funName, params)
+	}
+	ret, err := e.backend.CallView(e.contractName, evm.FuncGetISCPBlockIndex.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, nil))
+	if err != nil {
+		return nil, err
+	}
+	if !ret.MustHas(evm.FieldResult) {
+		return e.backend.CallView(e.contractName, funName, params)
+	}
+	blockIndex, err := codec.DecodeUint32(ret.MustGet(evm.FieldResult))
+	if err != nil {
+		return nil, err
+	}
+	return e.backend.CallViewAtBlockIndex(blockIndex, e.contractName, funName, params)
+}
+
 func (e *EVMChain) Balance(address common.Address, blockNumberOrHash rpc.BlockNumberOrHash) (*big.Int, error) {
-	ret, err := e.backend.CallView(e.contractName, evm.FuncGetBalance.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, dict.Dict{
+	ret, err := e.callViewAtBlock(evm.FuncGetBalance.Name, blockNumberOrHash, dict.Dict{
 		evm.FieldAddress: address.Bytes(),
-	}))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -153,9 +173,9 @@ func (e *EVMChain) Balance(address common.Address, blockNumberOrHash rpc.BlockNu
 }
 
 func (e *EVMChain) Code(address common.Address, blockNumberOrHash rpc.BlockNumberOrHash) ([]byte, error) {
-	ret, err := e.backend.CallView(e.contractName, evm.FuncGetCode.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, dict.Dict{
+	ret, err := e.callViewAtBlock(evm.FuncGetCode.Name, blockNumberOrHash, dict.Dict{
 		evm.FieldAddress: address.Bytes(),
-	}))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -261,9 +281,9 @@ func (e *EVMChain) TransactionReceipt(txHash common.Hash) (*types.Receipt, error
 }
 
 func (e *EVMChain) TransactionCount(address common.Address, blockNumberOrHash rpc.BlockNumberOrHash) (uint64, error) {
-	ret, err := e.backend.CallView(e.contractName, evm.FuncGetNonce.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, dict.Dict{
+	ret, err := e.callViewAtBlock(evm.FuncGetNonce.Name, blockNumberOrHash, dict.Dict{
 		evm.FieldAddress: address.Bytes(),
-	}))
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -271,9 +291,9 @@ func (e *EVMChain) TransactionCount(address common.Address, blockNumberOrHash rp
 }
 
 func (e *EVMChain) CallContract(args ethereum.CallMsg, blockNumberOrHash rpc.BlockNumberOrHash) ([]byte, error) {
-	ret, err := e.backend.CallView(e.contractName, evm.FuncCallContract.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, dict.Dict{
+	ret, err := e.callViewAtBlock(evm.FuncCallContract.Name, blockNumberOrHash, dict.Dict{
 		evm.FieldCallMsg: evmtypes.EncodeCallMsg(args),
-	}))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -291,10 +311,10 @@ func (e *EVMChain) EstimateGas(args ethereum.CallMsg) (uint64, error) {
 }
 
 func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumberOrHash rpc.BlockNumberOrHash) ([]byte, error) {
-	ret, err := e.backend.CallView(e.contractName, evm.FuncGetStorage.Name, paramsWithOptionalBlockNumberOrHash(blockNumberOrHash, dict.Dict{
+	ret, err := e.callViewAtBlock(evm.FuncGetStorage.Name, blockNumberOrHash, dict.Dict{
 		evm.FieldAddress: address.Bytes(),
 		evm.FieldKey:     key.Bytes(),
-	}))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -328,3 +348,51 @@ func (e *EVMChain) Logs(q *ethereum.FilterQuery) ([]*types.Log, error) {
 	}
 	return evmtypes.DecodeLogs(ret.MustGet(evm.FieldResult))
 }
+
+func (e *EVMChain) TraceTransaction(txHash common.Hash, config *evmtypes.TraceConfig) (json.RawMessage, error) {
+	ret, err := e.backend.CallView(e.contractName, evm.FuncTraceTransaction.Name, dict.Dict{
+		evm.FieldTransactionHash: txHash.Bytes(),
+		evm.FieldTraceConfig:     evmtypes.EncodeTraceConfig(config),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.MustGet(evm.FieldResult), nil
+}
+
+func (e *EVMChain) TraceCall(args ethereum.CallMsg, blockNumberOrHash rpc.BlockNumberOrHash, config *evmtypes.TraceConfig) (json.RawMessage, error) {
+	ret, err := e.callViewAtBlock(evm.FuncTraceCall.Name, blockNumberOrHash, dict.Dict{
+		evm.FieldCallMsg:     evmtypes.EncodeCallMsg(args),
+		evm.FieldTraceConfig: evmtypes.EncodeTraceConfig(config),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.MustGet(evm.FieldResult), nil
+}
+
+// PendingTransactions returns the EVM transactions sent to the chain that are still waiting in the mempool
+func (e *EVMChain) PendingTransactions() ([]*types.Transaction, error) {
+	reqs, err := e.backend.PendingRequests()
+	if err != nil {
+		return nil, err
+	}
+	target := iscp.NewRequestTarget(iscp.Hn(e.contractName), evm.FuncSendTransaction.Hname())
+	ret := make([]*types.Transaction, 0)
+	for _, req := range reqs {
+		if req.Target() != target {
+			continue
+		}
+		params, ok := req.Params()
+		if !ok {
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(params.MustGet(evm.FieldTransactionData)); err != nil {
+			// invalid transaction, it will be rejected by the chain
+			continue
+		}
+		ret = append(ret, tx)
+	}
+	return ret, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/xerrors"
+)
+
+// filterTimeout is the time after which a filter that is not polled is uninstalled
+const filterTimeout = 5 * time.Minute
+
+var errFilterNotFound = xerrors.New("filter not found")
+
+type filterType int
+
+const (
+	logsFilter filterType = iota
+	blocksFilter
+	pendingTxFilter
+)
+
+type filter struct {
+	typ   filterType
+	query ethereum.FilterQuery
+	// lastBlock is the last block seen by the filter (for logs and blocks filters)
+	lastBlock uint64
+	// hashes accumulated since the last poll (for pending transaction filters)
+	hashes   []common.Hash
+	deadline time.Time
+}
+
+// filterManager keeps the state of the filters installed with eth_newFilter, eth_newBlockFilter
+// and eth_newPendingTransactionFilter.
+// Logs and blocks filters only remember the last block seen; the changes are fetched from the
+// chain on each poll.
+type filterManager struct {
+	evmChain *EVMChain
+
+	mutex   sync.Mutex
+	filters map[rpc.ID]*filter
+}
+
+func newFilterManager(evmChain *EVMChain) *filterManager {
+	return &filterManager{
+		evmChain: evmChain,
+		filters:  make(map[rpc.ID]*filter),
+	}
+}
+
+func (fm *filterManager) install(typ filterType, query *ethereum.FilterQuery) (rpc.ID, error) {
+	f := &filter{typ: typ}
+	if query != nil {
+		if query.BlockHash != nil {
+			return "", xerrors.New("filtering by block hash is not supported, use eth_getLogs instead")
+		}
+		f.query = *query
+	}
+	if typ != pendingTxFilter {
+		blockNumber, err := fm.evmChain.BlockNumber()
+		if err != nil {
+			return "", err
+		}
+		f.lastBlock = blockNumber.Uint64()
+	}
+
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	fm.removeExpired()
+	id := rpc.NewID()
+	f.deadline = time.Now().Add(filterTimeout)
+	fm.filters[id] = f
+	return id, nil
+}
+
+func (fm *filterManager) uninstall(id rpc.ID) bool {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	_, ok := fm.filters[id]
+	delete(fm.filters, id)
+	return ok
+}
+
+func (fm *filterManager) removeExpired() {
+	now := time.Now()
+	for id, f := range fm.filters {
+		if now.After(f.deadline) {
+			delete(fm.filters, id)
+		}
+	}
+}
+
+// addPendingTransaction is called for every transaction sent through the JSON-RPC service
+func (fm *filterManager) addPendingTransaction(hash common.Hash) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	for _, f := range fm.filters {
+		if f.typ == pendingTxFilter {
+			f.hashes = append(f.hashes, hash)
+		}
+	}
+}
+
+// changes returns the block hashes, transaction hashes or logs since the last poll.
+// The mutex is not held while querying the chain
+func (fm *filterManager) changes(id rpc.ID) (interface{}, error) {
+	fm.mutex.Lock()
+	fm.removeExpired()
+	f, ok := fm.filters[id]
+	if !ok {
+		fm.mutex.Unlock()
+		return nil, errFilterNotFound
+	}
+	f.deadline = time.Now().Add(filterTimeout)
+	if f.typ == pendingTxFilter {
+		hashes := f.hashes
+		f.hashes = nil
+		fm.mutex.Unlock()
+		if hashes == nil {
+			hashes = []common.Hash{}
+		}
+		return hashes, nil
+	}
+	typ, query, from := f.typ, f.query, f.lastBlock+1
+	fm.mutex.Unlock()
+
+	blockNumber, err := fm.evmChain.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	current := blockNumber.Uint64()
+
+	var ret interface{}
+	if typ == blocksFilter {
+		hashes := make([]common.Hash, 0)
+		for n := from; n <= current; n++ {
+			block, err := fm.evmChain.BlockByNumber(new(big.Int).SetUint64(n))
+			if err != nil {
+				return nil, err
+			}
+			if block != nil {
+				hashes = append(hashes, block.Hash())
+			}
+		}
+		ret = hashes
+	} else {
+		logs, err := fm.logsInRange(&query, from, current)
+		if err != nil {
+			return nil, err
+		}
+		ret = logs
+	}
+
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	// the filter may have been uninstalled or polled concurrently in the meantime
+	if current > f.lastBlock {
+		f.lastBlock = current
+	}
+	return ret, nil
+}
+
+// logsInRange returns the logs matching the query in the blocks [from, to], restricted to
+// the FromBlock and ToBlock boundaries of the query
+func (fm *filterManager) logsInRange(query *ethereum.FilterQuery, from, to uint64) ([]*types.Log, error) {
+	if query.FromBlock != nil && query.FromBlock.Sign() >= 0 && query.FromBlock.Uint64() > from {
+		from = query.FromBlock.Uint64()
+	}
+	if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && query.ToBlock.Uint64() < to {
+		to = query.ToBlock.Uint64()
+	}
+	if from > to {
+		return []*types.Log{}, nil
+	}
+	q := *query
+	q.FromBlock = new(big.Int).SetUint64(from)
+	q.ToBlock = new(big.Int).SetUint64(to)
+	logs, err := fm.evmChain.Logs(&q)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+	return logs, nil
+}
+
+// logs returns all logs matching the criteria of a logs filter
+func (fm *filterManager) logs(id rpc.ID) ([]*types.Log, error) {
+	fm.mutex.Lock()
+	f, ok := fm.filters[id]
+	var query ethereum.FilterQuery
+	if ok {
+		f.deadline = time.Now().Add(filterTimeout)
+		query = f.query
+	}
+	fm.mutex.Unlock()
+
+	if !ok || f.typ != logsFilter {
+		return nil, errFilterNotFound
+	}
+	logs, err := fm.evmChain.Logs(&query)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+	return logs, nil
+}
+
+// logMatches checks the log against the address and topic criteria of a filter query
+func logMatches(log *types.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, a := range addresses {
+			if log.Address == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, sub := range topics {
+		if len(sub) == 0 {
+			// empty rule set == wildcard
+			continue
+		}
+		found := false
+		for _, topic := range sub {
+			if log.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -90,6 +90,20 @@ func (e *Env) DeployEVMContract(creator *ecdsa.PrivateKey, contractABI abi.ABI,
 	return tx, crypto.CreateAddress(creatorAddress, nonce)
 }
 
+func (e *Env) SendContractTransaction(sender *ecdsa.PrivateKey, contractAddress common.Address, data []byte, gasLimit uint64) *types.Transaction {
+	nonce := e.NonceAt(crypto.PubkeyToAddress(sender.PublicKey))
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, contractAddress, big.NewInt(0), gasLimit, evm.GasPrice, data),
+		e.signer(),
+		sender,
+	)
+	require.NoError(e.T, err)
+
+	err = e.Client.SendTransaction(context.Background(), tx)
+	require.NoError(e.T, err)
+	return tx
+}
+
 func concatenate(a, b []byte) []byte {
 	r := make([]byte, 0, len(a)+len(b))
 	r = append(r, a...)
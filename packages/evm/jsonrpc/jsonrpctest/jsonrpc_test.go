@@ -6,9 +6,12 @@ package jsonrpctest
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -25,8 +28,12 @@ import (
 	"github.com/iotaledger/wasp/packages/evm/evmtest"
 	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/iscp/requestargs"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/stretchr/testify/require"
 )
@@ -39,7 +46,21 @@ func withEVMFlavors(t *testing.T, f func(*testing.T, *coreutil.ContractInfo)) {
 
 type soloTestEnv struct {
 	Env
-	solo *solo.Solo
+	solo    *solo.Solo
+	chain   *solo.Chain
+	backend *soloTestBackend
+}
+
+// soloTestBackend allows to simulate requests waiting in the mempool, since Solo processes
+// all requests immediately
+type soloTestBackend struct {
+	*jsonrpc.SoloBackend
+	pending []iscp.Request
+}
+
+func (b *soloTestBackend) PendingRequests() ([]iscp.Request, error) {
+	reqs, err := b.SoloBackend.PendingRequests()
+	return append(reqs, b.pending...), err
 }
 
 func newSoloTestEnv(t *testing.T, evmFlavor *coreutil.ContractInfo) *soloTestEnv {
@@ -58,7 +79,7 @@ func newSoloTestEnv(t *testing.T, evmFlavor *coreutil.ContractInfo) *soloTestEnv
 	)
 	require.NoError(t, err)
 	signer, _ := s.NewKeyPairWithFunds()
-	backend := jsonrpc.NewSoloBackend(s, chain, signer)
+	backend := &soloTestBackend{SoloBackend: jsonrpc.NewSoloBackend(s, chain, signer)}
 	evmChain := jsonrpc.NewEVMChain(backend, chainID, evmFlavor.Name)
 
 	accountManager := jsonrpc.NewAccountManager(evmtest.Accounts)
@@ -79,7 +100,9 @@ func newSoloTestEnv(t *testing.T, evmFlavor *coreutil.ContractInfo) *soloTestEnv
 			RawClient: rawClient,
 			ChainID:   chainID,
 		},
-		solo: s,
+		solo:    s,
+		chain:   chain,
+		backend: backend,
 	}
 }
 
@@ -100,6 +123,31 @@ func TestRPCGetBalance(t *testing.T) {
 	})
 }
 
+func TestRPCGetBalanceAtBlock(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		_, receiverAddress := generateKey(t)
+		env.RequestFunds(receiverAddress)
+		blockNumber := env.BlockNumber()
+		block := env.BlockByNumber(new(big.Int).SetUint64(blockNumber))
+		env.RequestFunds(receiverAddress)
+		require.Zero(t, big.NewInt(2e18).Cmp(env.Balance(receiverAddress)))
+
+		bal, err := env.Client.BalanceAt(context.Background(), receiverAddress, new(big.Int).SetUint64(blockNumber))
+		require.NoError(t, err)
+		require.Zero(t, big.NewInt(1e18).Cmp(bal))
+
+		var balByHash hexutil.Big
+		err = env.RawClient.Call(&balByHash, "eth_getBalance", receiverAddress, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+		require.NoError(t, err)
+		require.Zero(t, big.NewInt(1e18).Cmp(balByHash.ToInt()))
+
+		bal, err = env.Client.BalanceAt(context.Background(), receiverAddress, big.NewInt(0))
+		require.NoError(t, err)
+		require.Zero(t, bal.Sign())
+	})
+}
+
 func TestRPCGetCode(t *testing.T) {
 	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
 		env := newSoloTestEnv(t, evmFlavor)
@@ -458,3 +506,323 @@ func TestRPCEthChainID(t *testing.T) {
 		require.EqualValues(t, evm.DefaultChainID, chainID)
 	})
 }
+
+func TestRPCBlockFilter(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+
+		var filterID rpc.ID
+		err := env.RawClient.Call(&filterID, "eth_newBlockFilter")
+		require.NoError(t, err)
+
+		var hashes []common.Hash
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Empty(t, hashes)
+
+		_, receiverAddress := generateKey(t)
+		env.RequestFunds(receiverAddress)
+
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{env.BlockByNumber(big.NewInt(1)).Hash()}, hashes)
+
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Empty(t, hashes)
+
+		var ok bool
+		err = env.RawClient.Call(&ok, "eth_uninstallFilter", filterID)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", filterID)
+		require.Error(t, err)
+	})
+}
+
+func TestRPCPendingTransactionFilter(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+
+		var filterID rpc.ID
+		err := env.RawClient.Call(&filterID, "eth_newPendingTransactionFilter")
+		require.NoError(t, err)
+
+		_, receiverAddress := generateKey(t)
+		tx := env.RequestFunds(receiverAddress)
+
+		var hashes []common.Hash
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{tx.Hash()}, hashes)
+	})
+}
+
+func TestRPCTxPool(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		senderKey, senderAddress := generateKey(t)
+		_, receiverAddress := generateKey(t)
+
+		addPending := func(target iscp.Hname, nonce uint64) *types.Transaction {
+			tx, err := types.SignTx(
+				types.NewTransaction(nonce, receiverAddress, big.NewInt(1), params.TxGas, evm.GasPrice, nil),
+				env.signer(),
+				senderKey,
+			)
+			require.NoError(t, err)
+			txdata, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			req := request.NewOffLedger(env.chain.ChainID, target, evm.FuncSendTransaction.Hname(),
+				requestargs.New().AddEncodeSimpleMany(dict.Dict{evm.FieldTransactionData: txdata}),
+			)
+			_, err = request.SolidifyArgs(req, iscp.NewInMemoryBlobCache())
+			require.NoError(t, err)
+			env.backend.pending = append(env.backend.pending, req)
+			return tx
+		}
+		tx0 := addPending(iscp.Hn(evmFlavor.Name), 0)
+		tx1 := addPending(iscp.Hn(evmFlavor.Name), 1)
+		tx3 := addPending(iscp.Hn(evmFlavor.Name), 3)
+		// not an EVM transaction for this chain
+		addPending(iscp.Hn("other"), 2)
+
+		var status map[string]hexutil.Uint
+		require.NoError(t, env.RawClient.Call(&status, "txpool_status"))
+		require.EqualValues(t, 2, status["pending"])
+		require.EqualValues(t, 1, status["queued"])
+
+		var content map[string]map[string]map[string]*jsonrpc.RPCTransaction
+		require.NoError(t, env.RawClient.Call(&content, "txpool_content"))
+		pending := content["pending"][senderAddress.Hex()]
+		require.Len(t, pending, 2)
+		require.Equal(t, tx0.Hash(), pending["0"].Hash)
+		require.Equal(t, tx1.Hash(), pending["1"].Hash)
+		require.Equal(t, senderAddress, pending["0"].From)
+		require.Nil(t, pending["0"].BlockHash)
+		queued := content["queued"][senderAddress.Hex()]
+		require.Len(t, queued, 1)
+		require.Equal(t, tx3.Hash(), queued["3"].Hash)
+
+		var inspect map[string]map[string]map[string]string
+		require.NoError(t, env.RawClient.Call(&inspect, "txpool_inspect"))
+		require.Equal(t,
+			fmt.Sprintf("%s: 1 wei + %d gas × %v wei", receiverAddress.Hex(), params.TxGas, evm.GasPrice),
+			inspect["queued"][senderAddress.Hex()]["3"],
+		)
+	})
+}
+
+func TestRPCLogFilter(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		creator, creatorAddress := evmtest.Accounts[0], evmtest.AccountAddress(0)
+		contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+		require.NoError(t, err)
+		contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+		var filterID rpc.ID
+		err = env.RawClient.Call(&filterID, "eth_newFilter", map[string]interface{}{
+			"address": contractAddress,
+		})
+		require.NoError(t, err)
+
+		var logs []types.Log
+		err = env.RawClient.Call(&logs, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Empty(t, logs)
+
+		// the ERC20 constructor emits a Transfer event
+		tx, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+
+		err = env.RawClient.Call(&logs, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Equal(t, tx.Hash(), logs[0].TxHash)
+		require.Equal(t, contractAddress, logs[0].Address)
+
+		err = env.RawClient.Call(&logs, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		require.Empty(t, logs)
+
+		err = env.RawClient.Call(&logs, "eth_getFilterLogs", filterID)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+	})
+}
+
+func TestRPCSubscribeNewHeads(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+
+		heads := make(chan *types.Header, 1)
+		sub, err := env.Client.SubscribeNewHead(context.Background(), heads)
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		_, receiverAddress := generateKey(t)
+		env.RequestFunds(receiverAddress)
+
+		select {
+		case h := <-heads:
+			require.EqualValues(t, 1, h.Number.Uint64())
+			require.Equal(t, env.BlockByNumber(big.NewInt(1)).Hash(), h.Hash())
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for the new head")
+		}
+	})
+}
+
+func TestRPCSubscribeLogsWebSocket(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+
+		srv := httptest.NewServer(jsonrpc.NewHTTPHandler(env.Server, []string{"*"}))
+		defer srv.Close()
+		wsClient, err := rpc.DialWebsocket(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), "")
+		require.NoError(t, err)
+		defer wsClient.Close()
+
+		creator, creatorAddress := evmtest.Accounts[0], evmtest.AccountAddress(0)
+		contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+		require.NoError(t, err)
+		contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+		logs := make(chan types.Log, 1)
+		sub, err := ethclient.NewClient(wsClient).SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
+			Addresses: []common.Address{contractAddress},
+		}, logs)
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		tx, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+
+		select {
+		case log := <-logs:
+			require.Equal(t, tx.Hash(), log.TxHash)
+			require.Equal(t, contractAddress, log.Address)
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for the log")
+		}
+	})
+}
+
+type structLoggerResult struct {
+	Gas         uint64 `json:"gas"`
+	Failed      bool   `json:"failed"`
+	ReturnValue string `json:"returnValue"`
+	StructLogs  []struct {
+		Op      string            `json:"op"`
+		Storage map[string]string `json:"storage"`
+	} `json:"structLogs"`
+}
+
+func TestRPCTraceTransaction(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		creator, _ := generateKey(t)
+		contractABI, err := abi.JSON(strings.NewReader(evmtest.StorageContractABI))
+		require.NoError(t, err)
+		_, contractAddress := env.DeployEVMContract(creator, contractABI, evmtest.StorageContractBytecode, uint32(42))
+
+		callArguments, err := contractABI.Pack("store", uint32(43))
+		require.NoError(t, err)
+		tx := env.SendContractTransaction(creator, contractAddress, callArguments, 100_000)
+
+		// modify the state again in a later block
+		callArguments, err = contractABI.Pack("store", uint32(44))
+		require.NoError(t, err)
+		env.SendContractTransaction(creator, contractAddress, callArguments, 100_000)
+
+		var res structLoggerResult
+		err = env.RawClient.Call(&res, "debug_traceTransaction", tx.Hash())
+		require.NoError(t, err)
+		require.False(t, res.Failed)
+		require.EqualValues(t, env.MustTxReceipt(tx.Hash()).GasUsed, res.Gas)
+
+		// the trace is executed on the state prior to the transaction
+		slot := common.Hash{}.Hex()[2:]
+		found := false
+		for _, log := range res.StructLogs {
+			if log.Op == "SLOAD" {
+				require.Equal(t, common.BigToHash(big.NewInt(42)).Hex()[2:], log.Storage[slot])
+				found = true
+			}
+		}
+		require.True(t, found)
+	})
+}
+
+func TestRPCTraceTransactionCallTracer(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		creator := evmtest.Accounts[0]
+		contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+		require.NoError(t, err)
+		_, contractAddress := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+
+		// the sender has no tokens, so the transfer reverts
+		sender, senderAddress := generateKey(t)
+		_, receiverAddress := generateKey(t)
+		callArguments, err := contractABI.Pack("transfer", receiverAddress, big.NewInt(1))
+		require.NoError(t, err)
+		tx := env.SendContractTransaction(sender, contractAddress, callArguments, 100_000)
+		require.EqualValues(t, types.ReceiptStatusFailed, env.MustTxReceipt(tx.Hash()).Status)
+
+		var res struct {
+			Type  string         `json:"type"`
+			From  common.Address `json:"from"`
+			To    common.Address `json:"to"`
+			Input hexutil.Bytes  `json:"input"`
+			Error string         `json:"error"`
+		}
+		err = env.RawClient.Call(&res, "debug_traceTransaction", tx.Hash(), map[string]interface{}{
+			"tracer": "callTracer",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "CALL", res.Type)
+		require.Equal(t, senderAddress, res.From)
+		require.Equal(t, contractAddress, res.To)
+		require.EqualValues(t, callArguments, res.Input)
+		require.Equal(t, "execution reverted", res.Error)
+	})
+}
+
+func TestRPCTraceCall(t *testing.T) {
+	withEVMFlavors(t, func(t *testing.T, evmFlavor *coreutil.ContractInfo) {
+		env := newSoloTestEnv(t, evmFlavor)
+		creator, creatorAddress := generateKey(t)
+		contractABI, err := abi.JSON(strings.NewReader(evmtest.StorageContractABI))
+		require.NoError(t, err)
+		_, contractAddress := env.DeployEVMContract(creator, contractABI, evmtest.StorageContractBytecode, uint32(42))
+		blockNumber := env.BlockNumber()
+
+		callArguments, err := contractABI.Pack("store", uint32(43))
+		require.NoError(t, err)
+		env.SendContractTransaction(creator, contractAddress, callArguments, 100_000)
+
+		retrieve, err := contractABI.Pack("retrieve")
+		require.NoError(t, err)
+		args := map[string]interface{}{
+			"from": creatorAddress,
+			"to":   contractAddress,
+			"data": hexutil.Bytes(retrieve),
+		}
+
+		var res structLoggerResult
+		err = env.RawClient.Call(&res, "debug_traceCall", args, "latest")
+		require.NoError(t, err)
+		require.False(t, res.Failed)
+		require.NotEmpty(t, res.StructLogs)
+		require.Equal(t, common.BigToHash(big.NewInt(43)).Hex()[2:], res.ReturnValue)
+
+		err = env.RawClient.Call(&res, "debug_traceCall", args, hexutil.EncodeUint64(blockNumber))
+		require.NoError(t, err)
+		require.Equal(t, common.BigToHash(big.NewInt(42)).Hex()[2:], res.ReturnValue)
+	})
+}
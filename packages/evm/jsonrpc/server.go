@@ -4,6 +4,9 @@
 package jsonrpc
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -16,7 +19,8 @@ func NewServer(evmChain *EVMChain, accountManager *AccountManager) *rpc.Server {
 		{"web3", NewWeb3Service()},
 		{"net", NewNetService(evmChain.chainID)},
 		{"eth", NewEthService(evmChain, accountManager)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain)},
+		{"debug", NewDebugService(evmChain)},
 	} {
 		err := rpcsrv.RegisterName(srv.namespace, srv.service)
 		if err != nil {
@@ -25,3 +29,21 @@ func NewServer(evmChain *EVMChain, accountManager *AccountManager) *rpc.Server {
 	}
 	return rpcsrv
 }
+
+// NewHTTPHandler returns a handler that serves the JSON-RPC requests over HTTP, and switches to
+// the WebSocket transport (needed for eth_subscribe) when the client requests a connection upgrade
+func NewHTTPHandler(rpcsrv *rpc.Server, wsAllowedOrigins []string) http.Handler {
+	wsHandler := rpcsrv.WebsocketHandler(wsAllowedOrigins)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		rpcsrv.ServeHTTP(w, r)
+	})
+}
+
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
@@ -6,8 +6,10 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum"
@@ -18,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"golang.org/x/crypto/sha3"
 	"golang.org/x/xerrors"
 )
@@ -25,10 +28,17 @@ import (
 type EthService struct {
 	evmChain *EVMChain
 	accounts *AccountManager
+	filters  *filterManager
+	watcher  *blockWatcher
 }
 
 func NewEthService(evmChain *EVMChain, accounts *AccountManager) *EthService {
-	return &EthService{evmChain, accounts}
+	return &EthService{
+		evmChain: evmChain,
+		accounts: accounts,
+		filters:  newFilterManager(evmChain),
+		watcher:  newBlockWatcher(evmChain),
+	}
 }
 
 func (e *EthService) ProtocolVersion() hexutil.Uint {
@@ -142,12 +152,20 @@ func (e *EthService) SendRawTransaction(txBytes hexutil.Bytes) (common.Hash, err
 	if err := rlp.DecodeBytes(txBytes, tx); err != nil {
 		return common.Hash{}, err
 	}
-	if err := e.evmChain.SendTransaction(tx); err != nil {
+	if err := e.sendTransaction(tx); err != nil {
 		return common.Hash{}, err
 	}
 	return tx.Hash(), nil
 }
 
+func (e *EthService) sendTransaction(tx *types.Transaction) error {
+	if err := e.evmChain.SendTransaction(tx); err != nil {
+		return err
+	}
+	e.filters.addPendingTransaction(tx.Hash())
+	return nil
+}
+
 func (e *EthService) Call(args *RPCCallArgs, blockNumberOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
 	ret, err := e.evmChain.CallContract(args.parse(), blockNumberOrHash)
 	return hexutil.Bytes(ret), err
@@ -252,7 +270,7 @@ func (e *EthService) SendTransaction(args *SendTxArgs) (common.Hash, error) {
 	if err != nil {
 		return common.Hash{}, err
 	}
-	if err := e.evmChain.SendTransaction(tx); err != nil {
+	if err := e.sendTransaction(tx); err != nil {
 		return common.Hash{}, err
 	}
 	return tx.Hash(), nil
@@ -273,6 +291,32 @@ func (e *EthService) GetLogs(q *RPCFilterQuery) ([]*types.Log, error) {
 	return e.evmChain.Logs((*ethereum.FilterQuery)(q))
 }
 
+func (e *EthService) NewFilter(q *RPCFilterQuery) (rpc.ID, error) {
+	return e.filters.install(logsFilter, (*ethereum.FilterQuery)(q))
+}
+
+func (e *EthService) NewBlockFilter() (rpc.ID, error) {
+	return e.filters.install(blocksFilter, nil)
+}
+
+func (e *EthService) NewPendingTransactionFilter() (rpc.ID, error) {
+	return e.filters.install(pendingTxFilter, nil)
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) bool {
+	return e.filters.uninstall(id)
+}
+
+// GetFilterChanges returns the block hashes, transaction hashes or logs (depending on the filter type)
+// since the last poll
+func (e *EthService) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	return e.filters.changes(id)
+}
+
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return e.filters.logs(id)
+}
+
 // ChainID implements the eth_chainId method according to https://eips.ethereum.org/EIPS/eip-695
 // method name has to be ChainId instead of ChainID
 func (e *EthService) ChainId() hexutil.Uint { //nolint:revive
@@ -281,12 +325,6 @@ func (e *EthService) ChainId() hexutil.Uint { //nolint:revive
 
 /*
 Not implemented:
-func (e *EthService) NewFilter()
-func (e *EthService) NewBlockFilter()
-func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) UninstallFilter()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
 func (e *EthService) SubmitWork()
 func (e *EthService) GetWork()
 func (e *EthService) SubmitHashrate()
@@ -324,29 +362,160 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
+type DebugService struct {
+	evmChain *EVMChain
+}
 
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+func NewDebugService(evmChain *EVMChain) *DebugService {
+	return &DebugService{evmChain}
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+// TraceTransaction re-executes the given transaction on the EVM state at the moment it was
+// executed, and returns the trace produced by the struct logger, or by the tracer given in the config
+func (d *DebugService) TraceTransaction(txHash common.Hash, config *evmtypes.TraceConfig) (json.RawMessage, error) {
+	return d.evmChain.TraceTransaction(txHash, config)
+}
+
+// TraceCall executes the given call on top of the EVM state of the given block, and returns the
+// trace produced by the struct logger, or by the tracer given in the config
+func (d *DebugService) TraceCall(args *RPCCallArgs, blockNumberOrHash rpc.BlockNumberOrHash, config *evmtypes.TraceConfig) (json.RawMessage, error) {
+	return d.evmChain.TraceCall(args.parse(), blockNumberOrHash, config)
+}
+
+type TxPoolService struct {
+	evmChain *EVMChain
+}
+
+func NewTxPoolService(evmChain *EVMChain) *TxPoolService {
+	return &TxPoolService{evmChain}
+}
+
+// txPool holds the transactions waiting in the mempool, grouped by sender and nonce
+type txPool struct {
+	// pending transactions are executable: their nonces follow the current nonce of the sender without gaps
+	pending map[common.Address]map[uint64]*types.Transaction
+	// queued transactions are not executable until the nonce gap is filled
+	queued map[common.Address]map[uint64]*types.Transaction
+}
+
+func (s *TxPoolService) txPool() (*txPool, error) {
+	txs, err := s.evmChain.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	bySender := make(map[common.Address]map[uint64]*types.Transaction)
+	for _, tx := range txs {
+		sender, err := types.Sender(s.evmChain.Signer(), tx)
+		if err != nil {
+			continue
+		}
+		if bySender[sender] == nil {
+			bySender[sender] = make(map[uint64]*types.Transaction)
+		}
+		bySender[sender][tx.Nonce()] = tx
+	}
+
+	ret := &txPool{
+		pending: make(map[common.Address]map[uint64]*types.Transaction),
+		queued:  make(map[common.Address]map[uint64]*types.Transaction),
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	for sender, txsByNonce := range bySender {
+		next, err := s.evmChain.TransactionCount(sender, latest)
+		if err != nil {
+			return nil, err
+		}
+		nonces := make([]uint64, 0, len(txsByNonce))
+		for nonce := range txsByNonce {
+			nonces = append(nonces, nonce)
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		for _, nonce := range nonces {
+			target := ret.queued
+			switch {
+			case nonce < next:
+				// already executed, the request is about to be removed from the mempool
+				continue
+			case nonce == next:
+				target = ret.pending
+				next++
+			}
+			if target[sender] == nil {
+				target[sender] = make(map[uint64]*types.Transaction)
+			}
+			target[sender][nonce] = txsByNonce[nonce]
+		}
+	}
+	return ret, nil
+}
+
+// Content returns the transactions waiting in the mempool, grouped by sender and nonce
+func (s *TxPoolService) Content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	pool, err := s.txPool()
+	if err != nil {
+		return nil, err
+	}
+	format := func(txs map[common.Address]map[uint64]*types.Transaction) map[string]map[string]*RPCTransaction {
+		ret := make(map[string]map[string]*RPCTransaction)
+		for sender, txsByNonce := range txs {
+			dump := make(map[string]*RPCTransaction)
+			for nonce, tx := range txsByNonce {
+				dump[strconv.FormatUint(nonce, 10)] = newRPCTransaction(tx, common.Hash{}, 0, 0)
+			}
+			ret[sender.Hex()] = dump
+		}
+		return ret
 	}
+	return map[string]map[string]map[string]*RPCTransaction{
+		"pending": format(pool.pending),
+		"queued":  format(pool.queued),
+	}, nil
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
+// Inspect returns a textual summary of the transactions waiting in the mempool, grouped by sender and nonce
+func (s *TxPoolService) Inspect() (map[string]map[string]map[string]string, error) {
+	pool, err := s.txPool()
+	if err != nil {
+		return nil, err
+	}
+	format := func(txs map[common.Address]map[uint64]*types.Transaction) map[string]map[string]string {
+		ret := make(map[string]map[string]string)
+		for sender, txsByNonce := range txs {
+			dump := make(map[string]string)
+			for nonce, tx := range txsByNonce {
+				dump[strconv.FormatUint(nonce, 10)] = inspectTransaction(tx)
+			}
+			ret[sender.Hex()] = dump
+		}
+		return ret
+	}
 	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
+		"pending": format(pool.pending),
+		"queued":  format(pool.queued),
+	}, nil
+}
+
+func inspectTransaction(tx *types.Transaction) string {
+	if to := tx.To(); to != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
 	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
+// Status returns the amount of pending and queued transactions in the mempool
+func (s *TxPoolService) Status() (map[string]hexutil.Uint, error) {
+	pool, err := s.txPool()
+	if err != nil {
+		return nil, err
+	}
+	count := func(txs map[common.Address]map[uint64]*types.Transaction) (n int) {
+		for _, txsByNonce := range txs {
+			n += len(txsByNonce)
+		}
+		return n
 	}
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(count(pool.pending)),
+		"queued":  hexutil.Uint(count(pool.queued)),
+	}, nil
 }
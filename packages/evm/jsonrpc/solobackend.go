@@ -5,8 +5,11 @@ package jsonrpc
 
 import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/publisher"
 	"github.com/iotaledger/wasp/packages/solo"
 )
 
@@ -45,3 +48,24 @@ func (s *SoloBackend) PostOffLedgerRequest(scName, funName string, transfer colo
 func (s *SoloBackend) CallView(scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return s.Chain.CallView(scName, funName, args)
 }
+
+func (s *SoloBackend) CallViewAtBlockIndex(blockIndex uint32, scName, funName string, args dict.Dict) (dict.Dict, error) {
+	return s.Chain.CallViewAtBlockIndex(blockIndex, scName, funName, args)
+}
+
+func (s *SoloBackend) PendingRequests() ([]iscp.Request, error) {
+	return s.Chain.GetPendingRequests(), nil
+}
+
+func (s *SoloBackend) SubscribeBlocks(handler func()) func() {
+	chainID := s.Chain.ChainID.Base58()
+	cl := events.NewClosure(func(evts []*publisher.ChainEvent) {
+		for _, evt := range evts {
+			if evt.ChainID == chainID && evt.Type == publisher.ChainEventBlockCommitted {
+				handler()
+			}
+		}
+	})
+	publisher.ChainEvents.Attach(cl)
+	return func() { publisher.ChainEvents.Detach(cl) }
+}
@@ -0,0 +1,200 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// blockWatcher detects the EVM blocks committed on the chain and publishes their headers
+// and logs to the eth_subscribe subscriptions.
+// The watcher follows the blocks committed on the chain only while there is at least one subscription
+type blockWatcher struct {
+	evmChain *EVMChain
+
+	mutex       sync.Mutex
+	subscribers int
+	stop        func()
+
+	headsFeed event.Feed
+	logsFeed  event.Feed
+}
+
+func newBlockWatcher(evmChain *EVMChain) *blockWatcher {
+	return &blockWatcher{evmChain: evmChain}
+}
+
+// watcherSubscription releases the watcher when unsubscribed
+type watcherSubscription struct {
+	event.Subscription
+	w    *blockWatcher
+	once sync.Once
+}
+
+func (s *watcherSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.Subscription.Unsubscribe()
+		s.w.release()
+	})
+}
+
+func (w *blockWatcher) subscribeNewHeads(ch chan<- *types.Header) event.Subscription {
+	w.acquire()
+	return &watcherSubscription{Subscription: w.headsFeed.Subscribe(ch), w: w}
+}
+
+func (w *blockWatcher) subscribeLogs(ch chan<- []*types.Log) event.Subscription {
+	w.acquire()
+	return &watcherSubscription{Subscription: w.logsFeed.Subscribe(ch), w: w}
+}
+
+func (w *blockWatcher) acquire() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.subscribers++
+	if w.subscribers > 1 {
+		return
+	}
+	// the handler is called on the publishing path of the chain, it only wakes up the watcher
+	signal := make(chan struct{}, 1)
+	unsubscribe := w.evmChain.backend.SubscribeBlocks(func() {
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	})
+	done := make(chan struct{})
+	w.stop = func() {
+		unsubscribe()
+		close(done)
+	}
+	go w.run(signal, done)
+}
+
+func (w *blockWatcher) release() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.subscribers--
+	if w.subscribers == 0 {
+		w.stop()
+	}
+}
+
+// run publishes the blocks each time the chain signals a new block, until done is closed.
+// Only blocks committed after the watcher was started are published
+func (w *blockWatcher) run(signal, done <-chan struct{}) {
+	lastBlock, err := w.evmChain.BlockNumber()
+	for {
+		select {
+		case <-done:
+			return
+		case <-signal:
+		}
+		if err != nil {
+			// retry on the next block
+			lastBlock, err = w.evmChain.BlockNumber()
+			continue
+		}
+		lastBlock = w.publish(lastBlock.Uint64())
+	}
+}
+
+// publish publishes the headers and logs of the blocks committed after lastBlock.
+// Returns the last block published
+func (w *blockWatcher) publish(lastBlock uint64) *big.Int {
+	ret := new(big.Int).SetUint64(lastBlock)
+	blockNumber, err := w.evmChain.BlockNumber()
+	if err != nil {
+		return ret
+	}
+	current := blockNumber.Uint64()
+	for n := lastBlock + 1; n <= current; n++ {
+		bn := new(big.Int).SetUint64(n)
+		block, err := w.evmChain.BlockByNumber(bn)
+		if err != nil {
+			return ret
+		}
+		logs, err := w.evmChain.Logs(&ethereum.FilterQuery{FromBlock: bn, ToBlock: bn})
+		if err != nil {
+			return ret
+		}
+		if block != nil {
+			w.headsFeed.Send(block.Header())
+		}
+		if len(logs) > 0 {
+			w.logsFeed.Send(logs)
+		}
+		ret = bn
+	}
+	return ret
+}
+
+// NewHeads implements the eth_subscribe "newHeads" subscription
+func (e *EthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	headers := make(chan *types.Header)
+	sub := e.watcher.subscribeNewHeads(headers)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case h := <-headers:
+				_ = notifier.Notify(rpcSub.ID, h)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// Logs implements the eth_subscribe "logs" subscription
+func (e *EthService) Logs(ctx context.Context, q *RPCFilterQuery) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	var query ethereum.FilterQuery
+	if q != nil {
+		query = ethereum.FilterQuery(*q)
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	logsCh := make(chan []*types.Log)
+	sub := e.watcher.subscribeLogs(logsCh)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-logsCh:
+				for _, log := range logs {
+					if logMatches(log, query.Addresses, query.Topics) {
+						_ = notifier.Notify(rpcSub.ID, log)
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
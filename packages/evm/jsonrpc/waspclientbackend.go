@@ -4,6 +4,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"time"
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -13,8 +14,13 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/iscp/requestargs"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 )
 
+// eventStreamRetryInterval is the time to wait before reconnecting to the event stream of the node
+const eventStreamRetryInterval = 5 * time.Second
+
 type WaspClientBackend struct {
 	ChainClient *chainclient.Client
 }
@@ -67,3 +73,51 @@ func (w *WaspClientBackend) PostOffLedgerRequest(scName, funName string, transfe
 func (w *WaspClientBackend) CallView(scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return w.ChainClient.CallView(iscp.Hn(scName), funName, args)
 }
+
+func (w *WaspClientBackend) CallViewAtBlockIndex(blockIndex uint32, scName, funName string, args dict.Dict) (dict.Dict, error) {
+	return w.ChainClient.CallViewAtBlockIndex(iscp.Hn(scName), funName, args, blockIndex)
+}
+
+func (w *WaspClientBackend) PendingRequests() ([]iscp.Request, error) {
+	reqs := make([]iscp.Request, 0)
+	for {
+		page, total, err := w.ChainClient.WaspClient.PendingRequests(w.ChainClient.ChainID, len(reqs), model.PendingRequestsMaxLimit)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, page...)
+		if len(page) == 0 || len(reqs) >= total {
+			break
+		}
+	}
+	// the decoded requests carry only the raw arguments
+	blobCache := iscp.NewInMemoryBlobCache()
+	for _, req := range reqs {
+		if _, err := request.SolidifyArgs(req, blobCache); err != nil {
+			return nil, err
+		}
+	}
+	return reqs, nil
+}
+
+// SubscribeBlocks follows the event stream of the chain on the Wasp node. If the connection is lost,
+// it reconnects and calls the handler once, since blocks may have been committed in the meantime
+func (w *WaspClientBackend) SubscribeBlocks(handler func()) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for {
+			_ = w.ChainClient.WaspClient.ChainEvents(ctx, w.ChainClient.ChainID, nil, func(evt *publisher.ChainEvent) {
+				if evt.Type == publisher.ChainEventBlockCommitted {
+					handler()
+				}
+			})
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(eventStreamRetryInterval):
+				handler()
+			}
+		}
+	}()
+	return cancel
+}
@@ -13,6 +13,7 @@ const (
 	CoreContractEventlog        = "eventlog"
 	CoreContractBlocklog        = "blocklog"
 	CoreContractGovernance      = "governance"
+	CoreContractScheduler       = "scheduler"
 	CoreEPRotateStateController = "rotateStateController"
 )
 
@@ -24,6 +25,7 @@ var (
 	CoreContractEventlogHname        = iscp.Hn(CoreContractEventlog)
 	CoreContractBlocklogHname        = iscp.Hn(CoreContractBlocklog)
 	CoreContractGovernanceHname      = iscp.Hn(CoreContractGovernance)
+	CoreContractSchedulerHname       = iscp.Hn(CoreContractScheduler)
 	CoreEPRotateStateControllerHname = iscp.Hn(CoreEPRotateStateController)
 
 	hnames = map[string]iscp.Hname{
@@ -34,6 +36,7 @@ var (
 		CoreContractEventlog:   CoreContractEventlogHname,
 		CoreContractBlocklog:   CoreContractBlocklogHname,
 		CoreContractGovernance: CoreContractGovernanceHname,
+		CoreContractScheduler:  CoreContractSchedulerHname,
 	}
 )
 
@@ -32,6 +32,8 @@ type Request interface {
 	SenderAddress() ledgerstate.Address
 	// returns contract/entry point pair
 	Target() RequestTarget
+	// GasBudget returns the gas budget requested by the sender. 0 means the default budget
+	GasBudget() uint64
 	// Timestamp returns a request TX timestamp, if such TX exist, otherwise zero is returned.
 	Timestamp() time.Time
 	// Bytes returns binary representation of the request
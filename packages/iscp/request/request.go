@@ -22,6 +22,7 @@ import (
 const (
 	onLedgerRequestType byte = iota
 	offLedgerRequestType
+	scheduledRequestType
 )
 
 // FromMarshalUtil re-creates request from bytes. First byte is treated as type of the request
@@ -36,6 +37,8 @@ func FromMarshalUtil(mu *marshalutil.MarshalUtil) (iscp.Request, error) {
 		return onLedgerFromMarshalUtil(mu)
 	case offLedgerRequestType:
 		return offLedgerFromMarshalUtil(mu)
+	case scheduledRequestType:
+		return scheduledFromMarshalUtil(mu)
 	}
 	return nil, xerrors.Errorf("invalid Request Type")
 }
@@ -52,6 +55,8 @@ type Metadata struct {
 	entryPoint iscp.Hname
 	// used to prevent identical outputs from being generated
 	requestNonce uint8
+	// gas budget requested by the sender, 0 means default
+	gasBudget uint64
 	// request arguments, not decoded yet wrt blobRefs
 	args requestargs.RequestArgs
 }
@@ -92,6 +97,11 @@ func (p *Metadata) WithRequestNonce(nonce uint8) *Metadata {
 	return p
 }
 
+func (p *Metadata) WithGasBudget(gasBudget uint64) *Metadata {
+	p.gasBudget = gasBudget
+	return p
+}
+
 func (p *Metadata) WithArgs(args requestargs.RequestArgs) *Metadata {
 	p.args = args.Clone()
 	return p
@@ -132,6 +142,13 @@ func (p *Metadata) EntryPoint() iscp.Hname {
 	return p.entryPoint
 }
 
+func (p *Metadata) GasBudget() uint64 {
+	if !p.ParsedOk() {
+		return 0
+	}
+	return p.gasBudget
+}
+
 func (p *Metadata) Args() requestargs.RequestArgs {
 	if !p.ParsedOk() {
 		return requestargs.RequestArgs(dict.New())
@@ -149,7 +166,8 @@ func (p *Metadata) WriteToMarshalUtil(mu *marshalutil.MarshalUtil) {
 	mu.Write(p.senderContract).
 		Write(p.targetContract).
 		Write(p.entryPoint).
-		WriteByte(p.requestNonce)
+		WriteByte(p.requestNonce).
+		WriteUint64(p.gasBudget)
 	p.args.WriteToMarshalUtil(mu)
 }
 
@@ -167,6 +185,9 @@ func (p *Metadata) ReadFromMarshalUtil(mu *marshalutil.MarshalUtil) error {
 	if p.requestNonce, err = mu.ReadByte(); err != nil {
 		return err
 	}
+	if p.gasBudget, err = mu.ReadUint64(); err != nil {
+		return err
+	}
 	if p.args, err = requestargs.FromMarshalUtil(mu); err != nil {
 		return err
 	}
@@ -310,6 +331,10 @@ func (req *OnLedger) Target() iscp.RequestTarget {
 	return iscp.NewRequestTarget(req.requestMetadata.TargetContract(), req.requestMetadata.EntryPoint())
 }
 
+func (req *OnLedger) GasBudget() uint64 {
+	return req.requestMetadata.GasBudget()
+}
+
 func (req *OnLedger) Timestamp() time.Time {
 	return req.txTimestamp
 }
@@ -370,7 +395,7 @@ func (req *OnLedger) String() string {
 		timelockStr = req.TimeLock().String()
 	}
 	return fmt.Sprintf(
-		"OnLedger::{ ID: %s, sender: %s, senderHname: %s, target: %s, entrypoint: %s, args: %s, nonce: %d, timestamp: %s, fallback: %s, timelock: %s }",
+		"OnLedger::{ ID: %s, sender: %s, senderHname: %s, target: %s, entrypoint: %s, args: %s, nonce: %d, gasBudget: %d, timestamp: %s, fallback: %s, timelock: %s }",
 		req.ID().Base58(),
 		req.senderAddress.Base58(),
 		req.requestMetadata.senderContract.String(),
@@ -378,6 +403,7 @@ func (req *OnLedger) String() string {
 		req.requestMetadata.entryPoint.String(),
 		req.Args().String(),
 		req.requestMetadata.requestNonce,
+		req.requestMetadata.gasBudget,
 		req.txTimestamp.String(),
 		fallbackStr,
 		timelockStr,
@@ -399,6 +425,7 @@ type OffLedger struct {
 	signature  ed25519.Signature
 	nonce      uint64
 	transfer   colored.Balances
+	gasBudget  uint64
 }
 
 // implements iscp.Request interface
@@ -456,7 +483,8 @@ func (req *OffLedger) writeEssenceToMarshalUtil(mu *marshalutil.MarshalUtil) {
 		Write(req.args).
 		WriteBytes(req.publicKey[:]).
 		WriteUint64(req.nonce).
-		Write(req.transfer)
+		Write(req.transfer).
+		WriteUint64(req.gasBudget)
 }
 
 func (req *OffLedger) readEssenceFromMarshalUtil(mu *marshalutil.MarshalUtil) error {
@@ -487,6 +515,9 @@ func (req *OffLedger) readEssenceFromMarshalUtil(mu *marshalutil.MarshalUtil) er
 	if req.transfer, err = colored.BalancesFromMarshalUtil(mu); err != nil {
 		return err
 	}
+	if req.gasBudget, err = mu.ReadUint64(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -514,6 +545,12 @@ func (req *OffLedger) WithTransfer(transfer colored.Balances) *OffLedger {
 	return req
 }
 
+// WithGasBudget sets the gas budget of the request. Must be called before signing
+func (req *OffLedger) WithGasBudget(gasBudget uint64) *OffLedger {
+	req.gasBudget = gasBudget
+	return req
+}
+
 // VerifySignature verifies essence signature
 func (req *OffLedger) VerifySignature() bool {
 	mu := marshalutil.New()
@@ -577,6 +614,10 @@ func (req *OffLedger) Target() iscp.RequestTarget {
 	return iscp.NewRequestTarget(req.contract, req.entryPoint)
 }
 
+func (req *OffLedger) GasBudget() uint64 {
+	return req.gasBudget
+}
+
 func (req *OffLedger) Timestamp() time.Time {
 	// no request TX, return zero time
 	return time.Time{}
@@ -591,13 +632,14 @@ func (req *OffLedger) Args() requestargs.RequestArgs {
 }
 
 func (req *OffLedger) String() string {
-	return fmt.Sprintf("OffLedger::{ ID: %s, sender: %s, target: %s, entrypoint: %s, args: %s, nonce: %d }",
+	return fmt.Sprintf("OffLedger::{ ID: %s, sender: %s, target: %s, entrypoint: %s, args: %s, nonce: %d, gasBudget: %d }",
 		req.ID().Base58(),
 		req.SenderAddress().Base58(),
 		req.contract.String(),
 		req.entryPoint.String(),
 		req.Args().String(),
 		req.nonce,
+		req.gasBudget,
 	)
 }
 
@@ -613,6 +655,7 @@ type SolidifiableRequest interface {
 var (
 	_ SolidifiableRequest = &OnLedger{}
 	_ SolidifiableRequest = &OffLedger{}
+	_ SolidifiableRequest = &Scheduled{}
 )
 
 // SolidifyArgs solidifies the request arguments
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,3 +100,31 @@ func TestOffLedger(t *testing.T) {
 		require.EqualValues(t, req.Bytes(), reqBack.Bytes())
 	})
 }
+
+func TestScheduled(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		chainID := iscp.RandomChainID()
+		target := iscp.Hn("target")
+		ep := iscp.Hn("entry point")
+		params := dict.Dict{"a": []byte{1, 2, 3}}
+		req := NewScheduled(chainID, target, ep, 7, 2, params, 1000, time.Unix(0, 12345), colored.NewBalancesForIotas(42))
+		reqBack, err := FromMarshalUtil(marshalutil.New(req.Bytes()))
+		require.NoError(t, err)
+		back, ok := reqBack.(*Scheduled)
+		require.True(t, ok)
+
+		require.EqualValues(t, req.Bytes(), reqBack.Bytes())
+		require.Equal(t, req.ID(), reqBack.ID())
+		require.EqualValues(t, 7, back.CallID())
+		require.EqualValues(t, 2, back.Execution())
+		require.True(t, iscp.NewAgentID(chainID.AsAddress(), target).Equals(back.SenderAccount()))
+	})
+	t.Run("unique id", func(t *testing.T) {
+		chainID := iscp.RandomChainID()
+		target := iscp.Hn("target")
+		ep := iscp.Hn("entry point")
+		req1 := NewScheduled(chainID, target, ep, 7, 0, nil, 0, time.Unix(0, 12345), nil)
+		req2 := NewScheduled(chainID, target, ep, 7, 1, nil, 0, time.Unix(0, 12345), nil)
+		require.NotEqual(t, req1.ID(), req2.ID())
+	})
+}
@@ -0,0 +1,204 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+)
+
+// region Scheduled  ///////////////////////////////////////////////////////
+
+// Scheduled is a request created by the VM for an execution of a call registered in the scheduler core contract.
+// It is not sent to the chain by anyone: the VM runs it at the start of the block and records its receipt.
+// The sender is the contract which scheduled the call
+type Scheduled struct {
+	chainID    *iscp.ChainID
+	contract   iscp.Hname
+	entryPoint iscp.Hname
+	callID     uint32
+	execution  uint32
+	params     dict.Dict
+	gasBudget  uint64
+	timestamp  time.Time
+	deposit    colored.Balances
+}
+
+// implements iscp.Request interface
+var _ iscp.Request = &Scheduled{}
+
+// NewScheduled creates the request for the given execution of the scheduled call.
+// The deposit is what the fees of the execution are paid from
+func NewScheduled(chainID *iscp.ChainID, contract, entryPoint iscp.Hname, callID, execution uint32, params dict.Dict,
+	gasBudget uint64, timestamp time.Time, deposit colored.Balances) *Scheduled {
+	if params == nil {
+		params = dict.New()
+	}
+	if deposit == nil {
+		deposit = colored.NewBalances()
+	}
+	return &Scheduled{
+		chainID:    chainID,
+		contract:   contract,
+		entryPoint: entryPoint,
+		callID:     callID,
+		execution:  execution,
+		params:     params,
+		gasBudget:  gasBudget,
+		timestamp:  timestamp,
+		deposit:    deposit,
+	}
+}
+
+// Bytes encodes request as bytes with first type byte
+func (req *Scheduled) Bytes() []byte {
+	mu := marshalutil.New()
+	mu.WriteByte(scheduledRequestType)
+	req.writeToMarshalUtil(mu)
+	return mu.Bytes()
+}
+
+// scheduledFromMarshalUtil creates a request from previously serialized bytes. Does not expects type byte
+func scheduledFromMarshalUtil(mu *marshalutil.MarshalUtil) (*Scheduled, error) {
+	req := &Scheduled{}
+	if err := req.readFromMarshalUtil(mu); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (req *Scheduled) writeToMarshalUtil(mu *marshalutil.MarshalUtil) {
+	mu.Write(req.chainID).
+		Write(req.contract).
+		Write(req.entryPoint).
+		WriteUint32(req.callID).
+		WriteUint32(req.execution).
+		Write(req.params).
+		WriteUint64(req.gasBudget).
+		WriteInt64(req.timestamp.UnixNano()).
+		Write(req.deposit)
+}
+
+func (req *Scheduled) readFromMarshalUtil(mu *marshalutil.MarshalUtil) error {
+	var err error
+	if req.chainID, err = iscp.ChainIDFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if err = req.contract.ReadFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if err = req.entryPoint.ReadFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if req.callID, err = mu.ReadUint32(); err != nil {
+		return err
+	}
+	if req.execution, err = mu.ReadUint32(); err != nil {
+		return err
+	}
+	if req.params, err = dict.FromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if req.gasBudget, err = mu.ReadUint64(); err != nil {
+		return err
+	}
+	ts, err := mu.ReadInt64()
+	if err != nil {
+		return err
+	}
+	req.timestamp = time.Unix(0, ts)
+	if req.deposit, err = colored.BalancesFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ID returns request id for this request
+// index part of request id is always 0 for scheduled requests
+func (req *Scheduled) ID() iscp.RequestID {
+	txid := ledgerstate.TransactionID(hashing.HashData(req.Bytes()))
+	return iscp.RequestID(ledgerstate.NewOutputID(txid, 0))
+}
+
+// CallID is the id of the call in the scheduler contract
+func (req *Scheduled) CallID() uint32 {
+	return req.callID
+}
+
+// Execution is the number of previous executions of the scheduled call
+func (req *Scheduled) Execution() uint32 {
+	return req.execution
+}
+
+// Deposit returns the tokens available to pay for the fees of the request
+func (req *Scheduled) Deposit() colored.Balances {
+	return req.deposit
+}
+
+// IsFeePrepaid always true for scheduled requests, fees are paid from the deposit
+func (req *Scheduled) IsFeePrepaid() bool {
+	return true
+}
+
+// IsOffLedger always true, scheduled requests are not backed by an output
+func (req *Scheduled) IsOffLedger() bool {
+	return true
+}
+
+func (req *Scheduled) Params() (dict.Dict, bool) {
+	return req.params, true
+}
+
+func (req *Scheduled) SenderAccount() *iscp.AgentID {
+	return iscp.NewAgentID(req.chainID.AsAddress(), req.contract)
+}
+
+func (req *Scheduled) SenderAddress() ledgerstate.Address {
+	return req.chainID.AsAddress()
+}
+
+func (req *Scheduled) Target() iscp.RequestTarget {
+	return iscp.NewRequestTarget(req.contract, req.entryPoint)
+}
+
+func (req *Scheduled) GasBudget() uint64 {
+	return req.gasBudget
+}
+
+// Timestamp returns the time the call was due
+func (req *Scheduled) Timestamp() time.Time {
+	return req.timestamp
+}
+
+func (req *Scheduled) Hash() [32]byte {
+	return hashing.HashData(req.Bytes())
+}
+
+func (req *Scheduled) SetParams(params dict.Dict) {
+	req.params = params
+}
+
+func (req *Scheduled) Args() requestargs.RequestArgs {
+	return requestargs.New(req.params)
+}
+
+func (req *Scheduled) String() string {
+	return fmt.Sprintf("Scheduled::{ ID: %s, contract: %s, entrypoint: %s, call: %d, execution: %d, params: %s, gasBudget: %d, due: %v }",
+		req.ID().Base58(),
+		req.contract.String(),
+		req.entryPoint.String(),
+		req.callID,
+		req.execution,
+		req.params.String(),
+		req.gasBudget,
+		req.timestamp,
+	)
+}
+
+// endregion /////////////////////////////////////////////////////////////////
@@ -36,6 +36,28 @@ type SandboxBase interface {
 	Log() LogInterface
 	// Utils provides access to common necessary functionality
 	Utils() Utils
+	// Gas provides access to the gas budget of the current call
+	Gas() Gas
+	// WasmLimits returns the limits of the execution of Wasm code set for the chain
+	WasmLimits() WasmLimits
+}
+
+// Gas is the gas budget of the request or of the view call
+type Gas interface {
+	// Burn burns the gas. It panics if the remaining budget is not enough
+	Burn(gas uint64)
+	// Budget returns the remaining gas budget
+	Budget() uint64
+}
+
+// WasmLimits are the limits of the execution of Wasm code. They are the same for all nodes of the chain
+type WasmLimits struct {
+	// MaxMemoryPages is the maximum size of the linear memory, in pages of 64 KiB
+	MaxMemoryPages uint32
+	// MaxTableSize is the maximum number of elements of the tables
+	MaxTableSize uint32
+	// MaxCallDepth is the maximum depth of nested calls between the functions of the Wasm code
+	MaxCallDepth uint32
 }
 
 // Sandbox is an interface given to the processor to access the VMContext
@@ -103,4 +125,5 @@ type SendMetadata struct {
 	TargetContract Hname
 	EntryPoint     Hname
 	Args           dict.Dict
+	GasBudget      uint64 // 0 means default budget
 }
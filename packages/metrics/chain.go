@@ -11,6 +11,7 @@ import (
 type StateManagerMetrics interface {
 	RecordBlockSize(blockIndex uint32, size float64)
 	LastSeenStateIndex(stateIndex uint32)
+	CountPrunedBlocks(numBlocks, numBytes int)
 }
 
 type ChainMetrics interface {
@@ -97,6 +98,11 @@ func (c *chainMetricsObj) LastSeenStateIndex(stateIndex uint32) {
 	c.metrics.lastSeenStateIndex.With(prometheus.Labels{"chain": c.chainID.String()}).Set(float64(stateIndex))
 }
 
+func (c *chainMetricsObj) CountPrunedBlocks(numBlocks, numBytes int) {
+	c.metrics.prunedBlocks.With(prometheus.Labels{"chain": c.chainID.String()}).Add(float64(numBlocks))
+	c.metrics.prunedBytes.With(prometheus.Labels{"chain": c.chainID.String()}).Add(float64(numBytes))
+}
+
 type defaultChainMetrics struct{}
 
 func DefaultChainMetrics() ChainMetrics {
@@ -126,3 +132,5 @@ func (m *defaultChainMetrics) CountBlocksPerChain() {}
 func (m *defaultChainMetrics) RecordBlockSize(_ uint32, _ float64) {}
 
 func (m *defaultChainMetrics) LastSeenStateIndex(stateIndex uint32) {}
+
+func (m *defaultChainMetrics) CountPrunedBlocks(_, _ int) {}
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/metrics/nodeconnmetrics"
+	"github.com/iotaledger/wasp/packages/vm/processors"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,7 +32,10 @@ type Metrics struct {
 	blockSizes              *prometheus.GaugeVec
 	lastSeenStateIndex      *prometheus.GaugeVec
 	lastSeenStateIndexVal   uint32
+	prunedBlocks            *prometheus.CounterVec
+	prunedBytes             *prometheus.CounterVec
 	nodeconnMetrics         nodeconnmetrics.NodeConnectionMetrics
+	processorsConfig        *processors.Config
 }
 
 func (m *Metrics) NewChainMetrics(chainID *iscp.ChainID) ChainMetrics {
@@ -153,6 +157,20 @@ func (m *Metrics) registerMetrics() {
 		Help: "Last seen state index",
 	}, []string{"chain"})
 	prometheus.MustRegister(m.lastSeenStateIndex)
+
+	m.prunedBlocks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "wasp_pruned_blocks",
+		Help: "Number of blocks pruned from DB",
+	}, []string{"chain"})
+	prometheus.MustRegister(m.prunedBlocks)
+
+	m.prunedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "wasp_pruned_bytes",
+		Help: "Number of bytes reclaimed in DB by the pruning of blocks",
+	}, []string{"chain"})
+	prometheus.MustRegister(m.prunedBytes)
+
+	m.registerProcessorCacheMetrics()
 }
 
 func (m *Metrics) GetNodeConnectionMetrics() nodeconnmetrics.NodeConnectionMetrics {
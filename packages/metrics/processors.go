@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/iotaledger/wasp/packages/vm/processors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterProcessorCache sets the node-wide processor cache to be reported, it must be called before Start
+func (m *Metrics) RegisterProcessorCache(config *processors.Config) {
+	if m == nil {
+		return
+	}
+	m.processorsConfig = config
+}
+
+func (m *Metrics) registerProcessorCacheMetrics() {
+	if m.processorsConfig == nil {
+		return
+	}
+	m.log.Info("Registering processor cache metrics to prometheus")
+	stats := m.processorsConfig.CacheStats
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "wasp_processor_cache_entries",
+		Help: "Number of VM processors created from binaries kept in the cache",
+	}, func() float64 { return float64(stats().Entries) }))
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "wasp_processor_cache_bytes",
+		Help: "Estimated memory held by the cached VM processors",
+	}, func() float64 { return float64(stats().Size) }))
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "wasp_processor_cache_budget_bytes",
+		Help: "Memory budget of the VM processor cache",
+	}, func() float64 { return float64(stats().Budget) }))
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name: "wasp_processor_cache_hits",
+		Help: "Number of VM processors found in the cache",
+	}, func() float64 { return float64(stats().Hits) }))
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name: "wasp_processor_cache_misses",
+		Help: "Number of VM processors created because they were not in the cache",
+	}, func() float64 { return float64(stats().Misses) }))
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name: "wasp_processor_cache_evictions",
+		Help: "Number of VM processors evicted from the cache",
+	}, func() float64 { return float64(stats().Evictions) }))
+}
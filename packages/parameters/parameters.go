@@ -31,6 +31,10 @@ const (
 	WebAPIAdminWhitelist         = "webapi.adminWhitelist"
 	WebAPIAdminWhitelistDisabled = "webapi.adminWhitelistDisabled"
 	WebAPIAuth                   = "webapi.auth"
+	WebAPIJWTDuration            = "webapi.jwt.duration"
+	WebAPIJWTUsers               = "webapi.jwt.users"
+	WebAPIJWTUserScopes          = "webapi.jwt.userScopes"
+	WebAPIJWTPublicScopes        = "webapi.jwt.publicScopes"
 
 	DashboardBindAddress       = "dashboard.bindAddress"
 	DashboardExploreAddressURL = "dashboard.exploreAddressUrl"
@@ -50,15 +54,34 @@ const (
 	OffledgerBroadcastInterval   = "offledger.broadcastInterval"
 	OffledgerAPICacheTTL         = "offledger.apiCacheTTL"
 
+	MempoolMaxBatchRequests          = "mempool.maxBatchRequests"
+	MempoolMaxBatchBytes             = "mempool.maxBatchBytes"
+	MempoolMaxBatchRequestsPerSender = "mempool.maxBatchRequestsPerSender"
+	MempoolMaxPoolRequestsPerSender  = "mempool.maxPoolRequestsPerSender"
+	MempoolOffLedgerTTL              = "mempool.offLedgerTTL"
+
 	ProfilingBindAddress   = "profiling.bindAddress"
 	ProfilingEnabled       = "profiling.enabled"
 	ProfilingWriteProfiles = "profiling.writeProfiles"
 
+	ProcessorsCacheSize = "processors.cacheSize"
+	WasmModuleCacheDir  = "wasm.moduleCacheDir"
+
 	MetricsBindAddress = "metrics.bindAddress"
 	MetricsEnabled     = "metrics.enabled"
 
 	WALEnabled   = "wal.enabled"
 	WALDirectory = "wal.directory"
+
+	WebhooksEnabled     = "webhooks.enabled"
+	WebhooksMaxAttempts = "webhooks.maxAttempts"
+	WebhooksMaxQueued   = "webhooks.maxQueued"
+	WebhooksMinBackoff  = "webhooks.minBackoff"
+	WebhooksMaxBackoff  = "webhooks.maxBackoff"
+	WebhooksTimeout     = "webhooks.timeout"
+
+	KeystorePassphraseFile = "keystore.passphraseFile"
+	KeystoreKeyFile        = "keystore.keyFile"
 )
 
 func Init() *configuration.Configuration {
@@ -81,6 +104,10 @@ func Init() *configuration.Configuration {
 	flag.StringSlice(WebAPIAdminWhitelist, []string{}, "IP whitelist for /adm wndpoints")
 	flag.StringToString(WebAPIAuth, nil, "authentication scheme for web API")
 	flag.Bool(WebAPIAdminWhitelistDisabled, false, "Disables IP whitelisting and allows requests from _any_ IP")
+	flag.Int(WebAPIJWTDuration, 24*60*60, "validity of the JWT tokens issued by the web API (in seconds)")
+	flag.StringToString(WebAPIJWTUsers, nil, "users allowed to log in to the web API when the JWT auth scheme is used (username -> password)")
+	flag.StringToString(WebAPIJWTUserScopes, nil, "comma-separated scopes granted to each web API user (username -> scopes)")
+	flag.StringSlice(WebAPIJWTPublicScopes, []string{"read"}, "scopes granted to web API requests without a JWT token")
 
 	flag.String(DashboardBindAddress, "127.0.0.1:7000", "the bind address for the node dashboard")
 	flag.String(DashboardExploreAddressURL, "", "URL to add as href to addresses in the dashboard [default: <nodeconn.address>:8081/explorer/address]")
@@ -101,16 +128,35 @@ func Init() *configuration.Configuration {
 	flag.Int(OffledgerBroadcastInterval, 5000, "time between re-broadcast of offledger requests (in ms)")
 	flag.Int(OffledgerAPICacheTTL, 5*60, "time to keep processed offledger requests in api cache (in seconds)")
 
+	flag.Int(MempoolMaxBatchRequests, 1000, "maximum number of requests in a batch (0 means no limit)")
+	flag.Int(MempoolMaxBatchBytes, 0, "maximum total size of the requests in a batch, in bytes (0 means no limit)")
+	flag.Int(MempoolMaxBatchRequestsPerSender, 100, "maximum number of requests of the same sender in a batch (0 means no limit)")
+	flag.Int(MempoolMaxPoolRequestsPerSender, 0, "maximum number of off-ledger requests of the same sender in the mempool (0 means no limit)")
+	flag.Int(MempoolOffLedgerTTL, 60*60, "time after which unprocessed off-ledger requests are evicted from the mempool (in seconds, 0 means never)")
+
 	flag.String(ProfilingBindAddress, "127.0.0.1:6060", "pprof http server address")
 	flag.Bool(ProfilingEnabled, false, "whether profiling is enabled")
 	flag.Bool(ProfilingWriteProfiles, false, "whether to write profiling profiles to disk on node shutdown (when enabled some metrics will be unavailable via pprof runtime endpoint)")
 
+	flag.Int(ProcessorsCacheSize, 512, "memory budget of the VM processors created from binaries, shared by all chains (in MB, 0 means no limit)")
+	flag.String(WasmModuleCacheDir, "wasmcache", "directory where the compiled Wasm modules are stored, writable by the node only (empty disables the disk cache)")
+
 	flag.String(MetricsBindAddress, "127.0.0.1:2112", "prometheus metrics http server address")
 	flag.Bool(MetricsEnabled, false, "disable and enable prometheus metrics")
 
 	flag.Bool(WALEnabled, true, "enabled wal")
 	flag.String(WALDirectory, "wal", "path to logs folder")
 
+	flag.Bool(WebhooksEnabled, true, "whether chain events are delivered to the webhook subscriptions")
+	flag.Int(WebhooksMaxAttempts, 50, "number of attempts after which a webhook delivery is dropped")
+	flag.Int(WebhooksMaxQueued, 1000, "maximum number of pending deliveries of each webhook subscription, further events are dropped")
+	flag.Int(WebhooksMinBackoff, 1, "delay before the first retry of a failed webhook delivery, doubled at each retry (in seconds)")
+	flag.Int(WebhooksMaxBackoff, 10*60, "maximum delay between the retries of a failed webhook delivery (in seconds)")
+	flag.Int(WebhooksTimeout, 10, "timeout of the webhook HTTP requests (in seconds)")
+
+	flag.String(KeystorePassphraseFile, "", "file containing the passphrase of the encrypted keystore")
+	flag.String(KeystoreKeyFile, "", "key file of the encrypted keystore")
+
 	return all
 }
 
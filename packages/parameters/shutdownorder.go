@@ -7,6 +7,8 @@ const (
 	PriorityPeering
 	PriorityNodeConnection
 	PriorityWebAPI
+	PriorityWebhooks
 	PriorityDBGarbageCollection
 	PriorityMetrics
+	PriorityKeystore
 )
@@ -0,0 +1,85 @@
+package publisher
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+)
+
+// types of the structured chain events
+const (
+	ChainEventBlockCommitted = "block_committed"
+	ChainEventRequestReceipt = "request_receipt"
+	ChainEventContractEvent  = "contract_event"
+	ChainEventChainRotated   = "chain_rotated"
+)
+
+// Cursor is the position of an event in the stream of events of a chain.
+// Events of a block are numbered from 0 and the last event of each block is 'block_committed'
+type Cursor struct {
+	BlockIndex uint32 `json:"blockIndex"`
+	EventIndex uint32 `json:"eventIndex"`
+}
+
+// Less returns true if the cursor is before the other one in the stream
+func (c Cursor) Less(other Cursor) bool {
+	if c.BlockIndex != other.BlockIndex {
+		return c.BlockIndex < other.BlockIndex
+	}
+	return c.EventIndex < other.EventIndex
+}
+
+// ChainEvent is a structured event of a chain, serialized to JSON for the consumers.
+// The type of Payload depends on Type
+type ChainEvent struct {
+	Type    string      `json:"type"`
+	ChainID string      `json:"chainID"`
+	Cursor  Cursor      `json:"cursor"`
+	Payload interface{} `json:"payload"`
+}
+
+// BlockCommitted is the payload of the 'block_committed' event
+type BlockCommitted struct {
+	Timestamp             time.Time `json:"timestamp"`
+	TotalRequests         uint16    `json:"totalRequests"`
+	NumSuccessfulRequests uint16    `json:"numSuccessfulRequests"`
+	NumOffLedgerRequests  uint16    `json:"numOffLedgerRequests"`
+	NumScheduledCalls     uint16    `json:"numScheduledCalls"`
+	PreviousStateHash     string    `json:"previousStateHash"`
+}
+
+// RequestReceipt is the payload of the 'request_receipt' event
+type RequestReceipt struct {
+	RequestID    string `json:"requestID"`
+	RequestIndex uint16 `json:"requestIndex"`
+	OffLedger    bool   `json:"offLedger"`
+	Sender       string `json:"sender"`
+	Contract     string `json:"contract"`
+	EntryPoint   string `json:"entryPoint"`
+	Error        string `json:"error,omitempty"`
+	GasBudget    uint64 `json:"gasBudget"`
+	GasBurned    uint64 `json:"gasBurned"`
+}
+
+// ContractEvent is the payload of the 'contract_event' event
+type ContractEvent struct {
+	RequestIndex uint16 `json:"requestIndex"`
+	Contract     string `json:"contract"`
+	Message      string `json:"message"`
+}
+
+// ChainRotated is the payload of the 'chain_rotated' event. It is emitted with the first block
+// produced after the state controller of the chain has changed
+type ChainRotated struct {
+	StateControllerAddress string `json:"stateControllerAddress"`
+	GoverningAddress       string `json:"governingAddress"`
+}
+
+// ChainEvents is triggered with the structured events of each committed block, in the order of their cursors
+var ChainEvents = events.NewEvent(func(handler interface{}, params ...interface{}) {
+	handler.(func(evts []*ChainEvent))(params[0].([]*ChainEvent))
+})
+
+func PublishChainEvents(evts []*ChainEvent) {
+	ChainEvents.Trigger(evts)
+}
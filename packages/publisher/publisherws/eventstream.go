@@ -0,0 +1,196 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package publisherws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"golang.org/x/xerrors"
+	"nhooyr.io/websocket"
+)
+
+// maxQueuedEvents is the maximum number of live events queued for a slow consumer.
+// When the queue is full, the live events are discarded and the consumer is caught up from the chain state instead
+const maxQueuedEvents = 10000
+
+// ChainEventSource provides the events of the past blocks of a chain, used to backfill the stream
+type ChainEventSource interface {
+	// LatestBlockIndex returns the index of the latest committed block
+	LatestBlockIndex() (uint32, error)
+	// BlockEvents returns the events of the block. Returns false if the block does not exist yet
+	BlockEvents(blockIndex uint32) ([]*publisher.ChainEvent, bool, error)
+}
+
+// EventStream sends the structured events of a chain to a consumer without gaps and without duplicates.
+// It starts from a given block, backfills the past blocks from the chain state and then switches to the live events.
+// If the consumer falls behind, or a gap is detected in the live events, the missing events are backfilled again
+type EventStream struct {
+	chainID *iscp.ChainID
+	source  ChainEventSource
+	send    func(evt *publisher.ChainEvent) error
+	next    publisher.Cursor
+
+	mutex    sync.Mutex
+	queue    []*publisher.ChainEvent
+	overflow bool
+	signal   chan struct{}
+}
+
+func NewEventStream(chainID *iscp.ChainID, source ChainEventSource, send func(evt *publisher.ChainEvent) error) *EventStream {
+	return &EventStream{
+		chainID: chainID,
+		source:  source,
+		send:    send,
+		signal:  make(chan struct{}, 1),
+	}
+}
+
+// Run streams the events starting from the block with index since. If since is nil, only the events
+// of the blocks committed from now on are streamed. Run returns when the context is done or on error
+func (s *EventStream) Run(ctx context.Context, since *uint32) error {
+	cl := events.NewClosure(s.receive)
+	publisher.ChainEvents.Attach(cl)
+	defer publisher.ChainEvents.Detach(cl)
+
+	if since != nil {
+		s.next = publisher.Cursor{BlockIndex: *since}
+	} else {
+		latest, err := s.source.LatestBlockIndex()
+		if err != nil {
+			return err
+		}
+		s.next = publisher.Cursor{BlockIndex: latest + 1}
+	}
+	if err := s.catchUp(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.signal:
+		}
+		s.mutex.Lock()
+		queue, overflow := s.queue, s.overflow
+		s.queue, s.overflow = nil, false
+		s.mutex.Unlock()
+
+		if overflow {
+			if err := s.catchUp(); err != nil {
+				return err
+			}
+		}
+		for _, evt := range queue {
+			if evt.Cursor.BlockIndex > s.next.BlockIndex {
+				// some blocks were not published live (e.g. while the node was syncing)
+				if err := s.catchUp(); err != nil {
+					return err
+				}
+			}
+			if err := s.sendIfNext(evt); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *EventStream) receive(evts []*publisher.ChainEvent) {
+	if len(evts) == 0 || evts[0].ChainID != s.chainID.Base58() {
+		return
+	}
+	s.mutex.Lock()
+	if s.overflow || len(s.queue)+len(evts) > maxQueuedEvents {
+		s.overflow = true
+		s.queue = nil
+	} else {
+		s.queue = append(s.queue, evts...)
+	}
+	s.mutex.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// catchUp sends the events of the blocks from the chain state, up to the latest block
+func (s *EventStream) catchUp() error {
+	for {
+		evts, ok, err := s.source.BlockEvents(s.next.BlockIndex)
+		if err != nil {
+			return xerrors.Errorf("cannot backfill events of block #%d: %w", s.next.BlockIndex, err)
+		}
+		if !ok {
+			return nil
+		}
+		for _, evt := range evts {
+			if err := s.sendIfNext(evt); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendIfNext sends the event unless it has already been sent
+func (s *EventStream) sendIfNext(evt *publisher.ChainEvent) error {
+	if evt.Cursor.Less(s.next) {
+		return nil
+	}
+	if err := s.send(evt); err != nil {
+		return err
+	}
+	if evt.Type == publisher.ChainEventBlockCommitted {
+		s.next = publisher.Cursor{BlockIndex: evt.Cursor.BlockIndex + 1}
+	} else {
+		s.next = publisher.Cursor{BlockIndex: evt.Cursor.BlockIndex, EventIndex: evt.Cursor.EventIndex + 1}
+	}
+	return nil
+}
+
+// ServeChainEvents serves the event stream of the chain through a websocket. Each event is sent as a JSON message
+func ServeChainEvents(log *logger.Logger, chainID *iscp.ChainID, source ChainEventSource, since *uint32, w http.ResponseWriter, r *http.Request) error {
+	c, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		InsecureSkipVerify: true, // TODO: make accept origin configurable
+	})
+	if err != nil {
+		return err
+	}
+	defer c.Close(websocket.StatusInternalError, "something went wrong")
+	ctx := c.CloseRead(r.Context())
+
+	log.Debugf("accepted event stream connection from %s", r.RemoteAddr)
+	defer log.Debugf("closed event stream connection from %s", r.RemoteAddr)
+
+	stream := NewEventStream(chainID, source, func(evt *publisher.ChainEvent) error {
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		return c.Write(ctx, websocket.MessageText, data)
+	})
+	if err := stream.Run(ctx, since); err != nil {
+		log.Warnf("event stream for %s: %v", r.RemoteAddr, err)
+		c.Close(websocket.StatusInternalError, closeReason(err))
+		return nil
+	}
+	c.Close(websocket.StatusNormalClosure, "")
+	return nil
+}
+
+// closeReason returns the error message truncated to the maximum length allowed by the websocket protocol
+func closeReason(err error) string {
+	const maxLen = 123
+	ret := err.Error()
+	if len(ret) > maxLen {
+		ret = ret[:maxLen]
+	}
+	return ret
+}
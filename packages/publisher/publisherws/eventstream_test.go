@@ -0,0 +1,170 @@
+package publisherws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/stretchr/testify/require"
+)
+
+// mockChain produces blocks with a fixed number of events each
+type mockChain struct {
+	chainID *iscp.ChainID
+	mutex   sync.Mutex
+	blocks  [][]*publisher.ChainEvent
+}
+
+func newMockChain() *mockChain {
+	return &mockChain{chainID: iscp.RandomChainID()}
+}
+
+func (m *mockChain) commitBlock(numEvents int) []*publisher.ChainEvent {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	blockIndex := uint32(len(m.blocks))
+	evts := make([]*publisher.ChainEvent, numEvents+1)
+	for i := range evts {
+		evts[i] = &publisher.ChainEvent{
+			Type:    publisher.ChainEventContractEvent,
+			ChainID: m.chainID.Base58(),
+			Cursor:  publisher.Cursor{BlockIndex: blockIndex, EventIndex: uint32(i)},
+		}
+	}
+	evts[numEvents].Type = publisher.ChainEventBlockCommitted
+	m.blocks = append(m.blocks, evts)
+	return evts
+}
+
+func (m *mockChain) LatestBlockIndex() (uint32, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return uint32(len(m.blocks) - 1), nil
+}
+
+func (m *mockChain) BlockEvents(blockIndex uint32) ([]*publisher.ChainEvent, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if int(blockIndex) >= len(m.blocks) {
+		return nil, false, nil
+	}
+	return m.blocks[blockIndex], true, nil
+}
+
+type eventCollector struct {
+	mutex sync.Mutex
+	evts  []*publisher.ChainEvent
+}
+
+func (c *eventCollector) send(evt *publisher.ChainEvent) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.evts = append(c.evts, evt)
+	return nil
+}
+
+func (c *eventCollector) requireSequence(t *testing.T, first publisher.Cursor, n int, eventsPerBlock uint32) {
+	require.Eventually(t, func() bool {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return len(c.evts) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	require.Len(t, c.evts, n)
+	expected := first
+	for _, evt := range c.evts {
+		require.EqualValues(t, expected, evt.Cursor)
+		expected.EventIndex++
+		if expected.EventIndex == eventsPerBlock {
+			expected = publisher.Cursor{BlockIndex: expected.BlockIndex + 1}
+		}
+	}
+}
+
+func runStream(t *testing.T, m *mockChain, since *uint32) (*eventCollector, context.CancelFunc) {
+	c := &eventCollector{}
+	stream := NewEventStream(m.chainID, m, c.send)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		require.NoError(t, stream.Run(ctx, since))
+	}()
+	return c, cancel
+}
+
+func TestEventStreamBackfill(t *testing.T) {
+	m := newMockChain()
+	for i := 0; i < 5; i++ {
+		m.commitBlock(2)
+	}
+	since := uint32(2)
+	c, cancel := runStream(t, m, &since)
+	defer cancel()
+
+	// blocks 2, 3, 4 are backfilled, blocks 5, 6 are live
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		publisher.PublishChainEvents(m.commitBlock(2))
+	}
+	c.requireSequence(t, publisher.Cursor{BlockIndex: 2}, 5*3, 3)
+}
+
+func TestEventStreamLiveOnly(t *testing.T) {
+	m := newMockChain()
+	m.commitBlock(1)
+	c, cancel := runStream(t, m, nil)
+	defer cancel()
+
+	time.Sleep(50 * time.Millisecond)
+	// other chains are ignored
+	other := newMockChain()
+	publisher.PublishChainEvents(other.commitBlock(1))
+	for i := 0; i < 3; i++ {
+		publisher.PublishChainEvents(m.commitBlock(1))
+	}
+	c.requireSequence(t, publisher.Cursor{BlockIndex: 1}, 3*2, 2)
+}
+
+func TestEventStreamGap(t *testing.T) {
+	m := newMockChain()
+	m.commitBlock(1)
+	c, cancel := runStream(t, m, nil)
+	defer cancel()
+
+	time.Sleep(50 * time.Millisecond)
+	// blocks 1 and 2 are not published live, they are backfilled when block 3 arrives
+	m.commitBlock(1)
+	m.commitBlock(1)
+	publisher.PublishChainEvents(m.commitBlock(1))
+	c.requireSequence(t, publisher.Cursor{BlockIndex: 1}, 3*2, 2)
+}
+
+func TestEventStreamOverflow(t *testing.T) {
+	m := newMockChain()
+	m.commitBlock(1)
+	c := &eventCollector{}
+	blocked := make(chan struct{})
+	stream := NewEventStream(m.chainID, m, func(evt *publisher.ChainEvent) error {
+		<-blocked
+		return c.send(evt)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	since := uint32(1)
+	go func() {
+		require.NoError(t, stream.Run(ctx, &since))
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	// the consumer is blocked while more events than the queue can hold are published
+	numBlocks := maxQueuedEvents/100 + 10
+	for i := 0; i < numBlocks; i++ {
+		publisher.PublishChainEvents(m.commitBlock(99))
+	}
+	close(blocked)
+	c.requireSequence(t, publisher.Cursor{BlockIndex: 1}, numBlocks*100, 100)
+}
@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingDKShare(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	store := mapdb.NewMapDB()
+	reg := NewRegistry(log, store)
+	require.NoError(t, reg.EncryptKeystore([]byte("secret")))
+
+	dks := testDKShare(t)
+	require.NoError(t, reg.SaveDKShare(dks))
+	require.ErrorIs(t, reg.CommitPendingDKShare(dks.Address), ErrDKShareNotFound)
+
+	// the pending share is not used until it is committed
+	reshared := testDKShare(t)
+	reshared.Address = dks.Address
+	require.NoError(t, reg.SavePendingDKShare(reshared))
+	requireNotInStore(t, store, reshared.Bytes())
+	dksBack, err := reg.LoadDKShare(dks.Address)
+	require.NoError(t, err)
+	require.EqualValues(t, dks.Bytes(), dksBack.Bytes())
+
+	require.NoError(t, reg.CommitPendingDKShare(dks.Address))
+	dksBack, err = reg.LoadDKShare(dks.Address)
+	require.NoError(t, err)
+	require.EqualValues(t, reshared.Bytes(), dksBack.Bytes())
+	require.ErrorIs(t, reg.CommitPendingDKShare(dks.Address), ErrDKShareNotFound)
+}
@@ -0,0 +1,227 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"os"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/xerrors"
+)
+
+// The keystore encrypts the private records of the registry (the node identity and the DKShares)
+// with a key derived from an operator secret: a passphrase or the contents of a key file.
+// The keystore header, stored in the registry, contains the parameters of the key derivation
+// and a check value used to detect a wrong secret. Registries without the header are not encrypted.
+
+var (
+	ErrKeystoreLocked      = xerrors.New("keystore is locked")
+	ErrKeystoreWrongSecret = xerrors.New("wrong keystore secret")
+)
+
+const (
+	keystoreVersion = byte(0)
+	keystoreSaltLen = 32
+
+	// scrypt parameters of the key derivation for new keystores
+	keystoreScryptN = 1 << 15
+	keystoreScryptR = 8
+	keystoreScryptP = 1
+
+	// bounds of the scrypt parameters read from the header, so that a tampered header cannot make
+	// the key derivation use an unbounded amount of memory or time
+	keystoreScryptMaxN      = 1 << 20
+	keystoreScryptMaxR      = 32
+	keystoreScryptMaxP      = 16
+	keystoreScryptMaxMemory = 1 << 30 // bytes, scrypt uses 128 * N * R
+)
+
+var keystoreCheckValue = []byte("wasp keystore")
+
+type keystoreHeader struct {
+	N     uint32
+	R     uint32
+	P     uint32
+	Salt  []byte
+	Check []byte
+}
+
+func keystoreHeaderFromBytes(data []byte) (*keystoreHeader, error) {
+	mu := marshalutil.New(data)
+	version, err := mu.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != keystoreVersion {
+		return nil, xerrors.Errorf("unsupported keystore version %d", version)
+	}
+	ret := &keystoreHeader{}
+	if ret.N, err = mu.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if ret.R, err = mu.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if ret.P, err = mu.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if err := checkScryptParams(ret.N, ret.R, ret.P); err != nil {
+		return nil, err
+	}
+	if ret.Salt, err = mu.ReadBytes(keystoreSaltLen); err != nil {
+		return nil, err
+	}
+	size, err := mu.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+	if ret.Check, err = mu.ReadBytes(int(size)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func checkScryptParams(n, r, p uint32) error {
+	if n < 2 || n > keystoreScryptMaxN || n&(n-1) != 0 {
+		return xerrors.Errorf("invalid keystore scrypt parameter N=%d: must be a power of 2, at most %d", n, keystoreScryptMaxN)
+	}
+	if r == 0 || r > keystoreScryptMaxR {
+		return xerrors.Errorf("invalid keystore scrypt parameter r=%d: must be between 1 and %d", r, keystoreScryptMaxR)
+	}
+	if p == 0 || p > keystoreScryptMaxP {
+		return xerrors.Errorf("invalid keystore scrypt parameter p=%d: must be between 1 and %d", p, keystoreScryptMaxP)
+	}
+	if 128*uint64(n)*uint64(r) > keystoreScryptMaxMemory {
+		return xerrors.Errorf("invalid keystore scrypt parameters N=%d, r=%d: more than %d bytes of memory", n, r, keystoreScryptMaxMemory)
+	}
+	return nil
+}
+
+func (h *keystoreHeader) Bytes() []byte {
+	return marshalutil.New().
+		WriteByte(keystoreVersion).
+		WriteUint32(h.N).
+		WriteUint32(h.R).
+		WriteUint32(h.P).
+		WriteBytes(h.Salt).
+		WriteUint16(uint16(len(h.Check))).
+		WriteBytes(h.Check).
+		Bytes()
+}
+
+func dbKeyForKeystore() []byte {
+	return dbkeys.MakeKey(dbkeys.ObjectTypeKeystore)
+}
+
+// keystore encrypts and decrypts the records. The records are bound to their DB keys,
+// so an encrypted record cannot be moved to another key
+type keystore struct {
+	header *keystoreHeader
+	aead   cipher.AEAD // nil while locked
+}
+
+// newKeystore creates the header of a new keystore, unlocked with the secret
+func newKeystore(secret []byte) (*keystore, error) {
+	salt := make([]byte, keystoreSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	ret := &keystore{header: &keystoreHeader{
+		N:    keystoreScryptN,
+		R:    keystoreScryptR,
+		P:    keystoreScryptP,
+		Salt: salt,
+	}}
+	var err error
+	if ret.aead, err = ret.deriveCipher(secret); err != nil {
+		return nil, err
+	}
+	if ret.header.Check, err = ret.encrypt(dbKeyForKeystore(), keystoreCheckValue); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (ks *keystore) deriveCipher(secret []byte) (cipher.AEAD, error) {
+	if len(secret) == 0 {
+		return nil, xerrors.New("empty keystore secret")
+	}
+	key, err := scrypt.Key(secret, ks.header.Salt, int(ks.header.N), int(ks.header.R), int(ks.header.P), chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+func (ks *keystore) locked() bool {
+	return ks.aead == nil
+}
+
+// unlock derives the key from the secret and checks it against the header
+func (ks *keystore) unlock(secret []byte) error {
+	aead, err := ks.deriveCipher(secret)
+	if err != nil {
+		return err
+	}
+	unlocked := &keystore{header: ks.header, aead: aead}
+	if _, err := unlocked.decrypt(dbKeyForKeystore(), ks.header.Check); err != nil {
+		return ErrKeystoreWrongSecret
+	}
+	ks.aead = aead
+	return nil
+}
+
+func (ks *keystore) encrypt(dbKey, data []byte) ([]byte, error) {
+	if ks.locked() {
+		return nil, ErrKeystoreLocked
+	}
+	nonce := make([]byte, ks.aead.NonceSize(), ks.aead.NonceSize()+len(data)+ks.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return ks.aead.Seal(nonce, nonce, data, dbKey), nil
+}
+
+func (ks *keystore) decrypt(dbKey, data []byte) ([]byte, error) {
+	if ks.locked() {
+		return nil, ErrKeystoreLocked
+	}
+	if len(data) < ks.aead.NonceSize() {
+		return nil, xerrors.New("keystore: encrypted record too short")
+	}
+	ret, err := ks.aead.Open(nil, data[:ks.aead.NonceSize()], data[ks.aead.NonceSize():], dbKey)
+	if err != nil {
+		return nil, xerrors.Errorf("keystore: cannot decrypt record: %w", err)
+	}
+	return ret, nil
+}
+
+// ReadKeystoreSecret reads the secret of the keystore from a passphrase file or a key file.
+// The trailing newline of the passphrase is ignored, the key file is used as is.
+// Returns nil if no file is given
+func ReadKeystoreSecret(passphraseFile, keyFile string) ([]byte, error) {
+	switch {
+	case passphraseFile != "" && keyFile != "":
+		return nil, xerrors.New("either a passphrase file or a key file must be given, not both")
+	case passphraseFile != "":
+		data, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read the keystore passphrase: %w", err)
+		}
+		return bytes.TrimRight(data, "\r\n"), nil
+	case keyFile != "":
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read the keystore key file: %w", err)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func testDKShare(t *testing.T) *tcrypto.DKShare {
+	suite := tcrypto.DefaultSuite()
+	randomness := random.New()
+	points := make([]kyber.Point, 4)
+	for i := range points {
+		points[i] = suite.G2().Point().Pick(randomness)
+	}
+	pubKey := ed25519.GenerateKeyPair().PublicKey
+	dks, err := tcrypto.NewDKShare(
+		0, 4, 3,
+		suite.G2().Point().Pick(randomness),
+		points, points,
+		suite.G2().Scalar().Pick(randomness),
+		[]*ed25519.PublicKey{&pubKey, &pubKey, &pubKey, &pubKey},
+	)
+	require.NoError(t, err)
+	return dks
+}
+
+// requireNotInStore checks that the plain data is not stored in any record of the store
+func requireNotInStore(t *testing.T, store kvstore.KVStore, data []byte) {
+	err := store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		require.False(t, bytes.Contains(value, data), "plain data found in record %x", key)
+		return true
+	})
+	require.NoError(t, err)
+}
+
+func requireClosed(t *testing.T, ch <-chan struct{}, closed bool) {
+	select {
+	case <-ch:
+		require.True(t, closed, "channel is closed")
+	default:
+		require.False(t, closed, "channel is not closed")
+	}
+}
+
+func TestKeystoreMigration(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	store := mapdb.NewMapDB()
+	reg := NewRegistry(log, store)
+	require.False(t, reg.IsKeystoreEncrypted())
+	require.False(t, reg.IsKeystoreLocked())
+	requireClosed(t, reg.KeystoreUnlocked(), true)
+
+	// plain registry
+	identity, err := reg.GetNodeIdentity()
+	require.NoError(t, err)
+	dks := testDKShare(t)
+	require.NoError(t, reg.SaveDKShare(dks))
+
+	secret := []byte("correct horse battery staple")
+	require.NoError(t, reg.EncryptKeystore(secret))
+	require.Error(t, reg.EncryptKeystore(secret))
+	require.True(t, reg.IsKeystoreEncrypted())
+	require.False(t, reg.IsKeystoreLocked())
+	requireNotInStore(t, store, identity.PrivateKey.Bytes())
+	requireNotInStore(t, store, dks.Bytes())
+
+	// the records are still readable after the migration
+	identityBack, err := reg.GetNodeIdentity()
+	require.NoError(t, err)
+	require.EqualValues(t, identity.PrivateKey, identityBack.PrivateKey)
+	dksBack, err := reg.LoadDKShare(dks.Address)
+	require.NoError(t, err)
+	require.EqualValues(t, dks.Bytes(), dksBack.Bytes())
+
+	// a new registry on the same DB is locked
+	reg = NewRegistry(log, store)
+	require.True(t, reg.IsKeystoreEncrypted())
+	require.True(t, reg.IsKeystoreLocked())
+	requireClosed(t, reg.KeystoreUnlocked(), false)
+	_, err = reg.GetNodeIdentity()
+	require.ErrorIs(t, err, ErrKeystoreLocked)
+	_, err = reg.LoadDKShare(dks.Address)
+	require.ErrorIs(t, err, ErrKeystoreLocked)
+	require.ErrorIs(t, reg.SaveDKShare(testDKShare(t)), ErrKeystoreLocked)
+
+	require.ErrorIs(t, reg.UnlockKeystore([]byte("wrong")), ErrKeystoreWrongSecret)
+	require.True(t, reg.IsKeystoreLocked())
+	requireClosed(t, reg.KeystoreUnlocked(), false)
+	require.NoError(t, reg.UnlockKeystore(secret))
+	require.False(t, reg.IsKeystoreLocked())
+	requireClosed(t, reg.KeystoreUnlocked(), true)
+	// unlocking again is a no-op
+	require.NoError(t, reg.UnlockKeystore(secret))
+
+	identityBack, err = reg.GetNodeIdentity()
+	require.NoError(t, err)
+	require.EqualValues(t, identity.PrivateKey, identityBack.PrivateKey)
+	dksBack, err = reg.LoadDKShare(dks.Address)
+	require.NoError(t, err)
+	require.EqualValues(t, dks.Bytes(), dksBack.Bytes())
+
+	// new records are encrypted too
+	dks2 := testDKShare(t)
+	require.NoError(t, reg.SaveDKShare(dks2))
+	requireNotInStore(t, store, dks2.Bytes())
+	dksBack, err = reg.LoadDKShare(dks2.Address)
+	require.NoError(t, err)
+	require.EqualValues(t, dks2.Bytes(), dksBack.Bytes())
+}
+
+func TestKeystoreRecordBinding(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	store := mapdb.NewMapDB()
+	reg := NewRegistry(log, store)
+	require.NoError(t, reg.EncryptKeystore([]byte("secret")))
+	identity, err := reg.GetNodeIdentity()
+	require.NoError(t, err)
+	requireNotInStore(t, store, identity.PrivateKey.Bytes())
+
+	// an encrypted record moved under another key cannot be decrypted
+	dks := testDKShare(t)
+	data, err := store.Get(dbkeys.MakeKey(dbkeys.ObjectTypeNodeIdentity))
+	require.NoError(t, err)
+	require.NoError(t, store.Set(dbKeyForDKShare(dks.Address), data))
+	_, err = reg.LoadDKShare(dks.Address)
+	require.Error(t, err)
+}
+
+func TestKeystoreHeaderScryptParams(t *testing.T) {
+	header := &keystoreHeader{
+		N:     keystoreScryptN,
+		R:     keystoreScryptR,
+		P:     keystoreScryptP,
+		Salt:  make([]byte, keystoreSaltLen),
+		Check: []byte{1, 2, 3},
+	}
+	_, err := keystoreHeaderFromBytes(header.Bytes())
+	require.NoError(t, err)
+
+	for _, params := range [][3]uint32{
+		{0, 8, 1},
+		{3 << 10, 8, 1},
+		{1 << 21, 8, 1},
+		{1 << 15, 0, 1},
+		{1 << 15, 33, 1},
+		{1 << 15, 8, 0},
+		{1 << 15, 8, 17},
+		{1 << 20, 16, 1},
+	} {
+		h := *header
+		h.N, h.R, h.P = params[0], params[1], params[2]
+		_, err := keystoreHeaderFromBytes(h.Bytes())
+		require.Error(t, err, "N=%d r=%d p=%d", h.N, h.R, h.P)
+	}
+}
@@ -34,6 +34,9 @@ type NodeIdentityProvider interface {
 type DKShareRegistryProvider interface {
 	SaveDKShare(dkShare *tcrypto.DKShare) error
 	LoadDKShare(sharedAddress ledgerstate.Address) (*tcrypto.DKShare, error)
+	SavePendingDKShare(dkShare *tcrypto.DKShare) error
+	CommitPendingDKShare(sharedAddress ledgerstate.Address) error
+	DeleteDKShare(sharedAddress ledgerstate.Address) error
 }
 
 var ErrDKShareNotFound = errors.New("dkShare not found")
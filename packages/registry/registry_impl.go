@@ -6,6 +6,7 @@ package registry
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -18,6 +19,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/tcrypto"
+	"golang.org/x/xerrors"
 )
 
 // region Registry /////////////////////////////////////////////////////////
@@ -27,14 +29,34 @@ import (
 type Impl struct {
 	log   *logger.Logger
 	store kvstore.KVStore
+
+	keystoreMutex    sync.RWMutex
+	keystore         *keystore     // nil if the registry is not encrypted
+	keystoreUnlocked chan struct{} // closed when the private records can be accessed
 }
 
 // New creates new instance of the registry implementation.
+// If the registry is encrypted, it is locked until UnlockKeystore is called.
 func NewRegistry(log *logger.Logger, store kvstore.KVStore) *Impl {
-	return &Impl{
-		log:   log.Named("registry"),
-		store: store,
+	ret := &Impl{
+		log:              log.Named("registry"),
+		store:            store,
+		keystoreUnlocked: make(chan struct{}),
+	}
+	data, err := store.Get(dbKeyForKeystore())
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		ret.log.Panicf("cannot read the keystore header: %v", err)
+	}
+	if err == nil {
+		header, err := keystoreHeaderFromBytes(data)
+		if err != nil {
+			ret.log.Panicf("invalid keystore header: %v", err)
+		}
+		ret.keystore = &keystore{header: header}
+	} else {
+		close(ret.keystoreUnlocked)
 	}
+	return ret
 }
 
 // endregion ////////////////////////////////////////////////////////
@@ -126,25 +148,85 @@ func (r *Impl) SaveDKShare(dkShare *tcrypto.DKShare) error {
 	if exists {
 		return fmt.Errorf("attempt to overwrite existing DK key share")
 	}
-	return r.store.Set(dbKey, dkShare.Bytes())
+	data, err := r.sealRecord(dbKey, dkShare.Bytes())
+	if err != nil {
+		return err
+	}
+	return r.store.Set(dbKey, data)
 }
 
 // LoadDKShare implements dkg.DKShareRegistryProvider.
 func (r *Impl) LoadDKShare(sharedAddress ledgerstate.Address) (*tcrypto.DKShare, error) {
-	data, err := r.store.Get(dbKeyForDKShare(sharedAddress))
+	dbKey := dbKeyForDKShare(sharedAddress)
+	data, err := r.store.Get(dbKey)
 	if err != nil {
 		if errors.Is(err, kvstore.ErrKeyNotFound) {
 			return nil, ErrDKShareNotFound
 		}
 		return nil, err
 	}
+	if data, err = r.openRecord(dbKey, data); err != nil {
+		return nil, err
+	}
 	return tcrypto.DKShareFromBytes(data, tcrypto.DefaultSuite())
 }
 
+// SavePendingDKShare implements dkg.DKShareRegistryProvider.
+// It stores a new share of the address, e.g. after resharing the key, next to the existing one.
+// The share is not used until it is committed with CommitPendingDKShare.
+func (r *Impl) SavePendingDKShare(dkShare *tcrypto.DKShare) error {
+	dbKey := dbKeyForPendingDKShare(dkShare.Address)
+	data, err := r.sealRecord(dbKey, dkShare.Bytes())
+	if err != nil {
+		return err
+	}
+	return r.store.Set(dbKey, data)
+}
+
+// CommitPendingDKShare implements dkg.DKShareRegistryProvider.
+// It replaces the share of the address with the pending one in one batch.
+func (r *Impl) CommitPendingDKShare(sharedAddress ledgerstate.Address) error {
+	pendingKey := dbKeyForPendingDKShare(sharedAddress)
+	data, err := r.store.Get(pendingKey)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return ErrDKShareNotFound
+		}
+		return err
+	}
+	if data, err = r.openRecord(pendingKey, data); err != nil {
+		return err
+	}
+	dbKey := dbKeyForDKShare(sharedAddress)
+	if data, err = r.sealRecord(dbKey, data); err != nil {
+		return err
+	}
+	batch := r.store.Batched()
+	if err := batch.Set(dbKey, data); err != nil {
+		batch.Cancel()
+		return err
+	}
+	if err := batch.Delete(pendingKey); err != nil {
+		batch.Cancel()
+		return err
+	}
+	return batch.Commit()
+}
+
+// DeleteDKShare implements dkg.DKShareRegistryProvider.
+func (r *Impl) DeleteDKShare(sharedAddress ledgerstate.Address) error {
+	return r.store.Delete(dbKeyForDKShare(sharedAddress))
+}
+
 func dbKeyForDKShare(sharedAddress ledgerstate.Address) []byte {
 	return dbkeys.MakeKey(dbkeys.ObjectTypeDistributedKeyData, sharedAddress.Bytes())
 }
 
+// dbKeyForPendingDKShare is in the same partition as the shares, so the pending shares are encrypted with the keystore too
+func dbKeyForPendingDKShare(sharedAddress ledgerstate.Address) []byte {
+	return dbkeys.MakeKey(dbkeys.ObjectTypeDistributedKeyData, sharedAddress.Bytes(), []byte("pending"))
+}
+
 // endregion //////////////////////////////////////////////////////////////
 
 // region TrustedNetworkManager ////////////////////////////////////////////////////
@@ -290,7 +372,9 @@ func (r *Impl) GetNodeIdentity() (*ed25519.KeyPair, error) {
 	exists, _ = r.store.Has(dbKey)
 	if !exists {
 		pair = ed25519.GenerateKeyPair()
-		data = pair.PrivateKey.Bytes()
+		if data, err = r.sealRecord(dbKey, pair.PrivateKey.Bytes()); err != nil {
+			return nil, err
+		}
 		if err := r.store.Set(dbKey, data); err != nil {
 			return nil, err
 		}
@@ -300,6 +384,9 @@ func (r *Impl) GetNodeIdentity() (*ed25519.KeyPair, error) {
 	if data, err = r.store.Get(dbKey); err != nil {
 		return nil, err
 	}
+	if data, err = r.openRecord(dbKey, data); err != nil {
+		return nil, err
+	}
 	if pair.PrivateKey, err, _ = ed25519.PrivateKeyFromBytes(data); err != nil {
 		return nil, err
 	}
@@ -322,3 +409,124 @@ func dbKeyForNodeIdentity() []byte {
 }
 
 // endregion ///////////////////////////////////////////////////
+
+// region Keystore ///////////////////////////////////////////////////
+
+// IsKeystoreEncrypted returns true if the private records of the registry are encrypted.
+func (r *Impl) IsKeystoreEncrypted() bool {
+	r.keystoreMutex.RLock()
+	defer r.keystoreMutex.RUnlock()
+	return r.keystore != nil
+}
+
+// IsKeystoreLocked returns true if the registry is encrypted and has not been unlocked yet.
+// The node identity and the DKShares cannot be accessed while the keystore is locked.
+func (r *Impl) IsKeystoreLocked() bool {
+	r.keystoreMutex.RLock()
+	defer r.keystoreMutex.RUnlock()
+	return r.keystore != nil && r.keystore.locked()
+}
+
+// KeystoreUnlocked returns a channel which is closed when the node identity and the DKShares can be accessed:
+// from the start if the registry is not encrypted, otherwise when the keystore is unlocked.
+func (r *Impl) KeystoreUnlocked() <-chan struct{} {
+	return r.keystoreUnlocked
+}
+
+// UnlockKeystore unlocks the encrypted registry with the secret of the operator.
+func (r *Impl) UnlockKeystore(secret []byte) error {
+	r.keystoreMutex.Lock()
+	defer r.keystoreMutex.Unlock()
+	if r.keystore == nil {
+		return xerrors.New("registry is not encrypted")
+	}
+	if !r.keystore.locked() {
+		return nil
+	}
+	if err := r.keystore.unlock(secret); err != nil {
+		return err
+	}
+	close(r.keystoreUnlocked)
+	r.log.Info("keystore unlocked")
+	return nil
+}
+
+// EncryptKeystore migrates a plain registry to an encrypted one: the existing node identity
+// and DKShares are encrypted with a key derived from the secret, in a single batch.
+// The registry stays unlocked.
+// The plain records are overwritten, but the database may keep the old values in its files until they
+// are compacted, so the keys that existed before the migration may still be recovered from the files.
+func (r *Impl) EncryptKeystore(secret []byte) error {
+	r.keystoreMutex.Lock()
+	defer r.keystoreMutex.Unlock()
+	if r.keystore != nil {
+		return xerrors.New("registry is already encrypted")
+	}
+	ks, err := newKeystore(secret)
+	if err != nil {
+		return err
+	}
+	batch := r.store.Batched()
+	count := 0
+	var innerErr error
+	encryptPrefix := func(prefix byte) error {
+		err := r.store.Iterate([]byte{prefix}, func(key kvstore.Key, value kvstore.Value) bool {
+			data, err := ks.encrypt(key, value)
+			if err != nil {
+				innerErr = err
+				return false
+			}
+			if err := batch.Set(key, data); err != nil {
+				innerErr = err
+				return false
+			}
+			count++
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return innerErr
+	}
+	for _, prefix := range []byte{dbkeys.ObjectTypeNodeIdentity, dbkeys.ObjectTypeDistributedKeyData} {
+		if err := encryptPrefix(prefix); err != nil {
+			batch.Cancel()
+			return err
+		}
+	}
+	if err := batch.Set(dbKeyForKeystore(), ks.header.Bytes()); err != nil {
+		batch.Cancel()
+		return err
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	if err := r.store.Flush(); err != nil {
+		return err
+	}
+	r.keystore = ks
+	r.log.Infof("keystore encrypted: %d records", count)
+	return nil
+}
+
+// sealRecord encrypts a private record, if the registry is encrypted.
+func (r *Impl) sealRecord(dbKey, data []byte) ([]byte, error) {
+	r.keystoreMutex.RLock()
+	defer r.keystoreMutex.RUnlock()
+	if r.keystore == nil {
+		return data, nil
+	}
+	return r.keystore.encrypt(dbKey, data)
+}
+
+// openRecord decrypts a private record, if the registry is encrypted.
+func (r *Impl) openRecord(dbKey, data []byte) ([]byte, error) {
+	r.keystoreMutex.RLock()
+	defer r.keystoreMutex.RUnlock()
+	if r.keystore == nil {
+		return data, nil
+	}
+	return r.keystore.decrypt(dbKey, data)
+}
+
+// endregion ///////////////////////////////////////////////////
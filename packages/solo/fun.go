@@ -84,6 +84,17 @@ func (ch *Chain) FindContract(scName string) (*root.ContractRecord, error) {
 	return record, err
 }
 
+// GetProgramHistory returns the programs the contract ran before it was upgraded, oldest first
+func (ch *Chain) GetProgramHistory(scName string) ([]*root.ProgramHistoryEntry, error) {
+	ret, err := ch.CallView(root.Contract.Name, root.FuncGetProgramHistory.Name,
+		root.ParamHname, iscp.Hn(scName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return root.DecodeProgramHistory(ret)
+}
+
 // GetBlobInfo return info about blob with the given hash with existence flag
 // The blob information is returned as a map of pairs 'blobFieldName': 'fieldDataLength'
 func (ch *Chain) GetBlobInfo(blobHash hashing.HashValue) (map[string]uint32, bool) {
@@ -110,11 +121,10 @@ func (ch *Chain) UploadBlob(keyPair *ed25519.KeyPair, params ...interface{}) (re
 	}
 
 	req := NewCallParams(blob.Contract.Name, blob.FuncStoreBlob.Name, params...)
-	feeColor, ownerFee, validatorFee := ch.GetFeeInfo(blob.Contract.Name)
+	feeColor, fee := ch.EstimateFee(0)
 	require.EqualValues(ch.Env.T, feeColor, colored.IOTA)
-	totalFee := ownerFee + validatorFee
-	if totalFee > 0 {
-		req.WithIotas(totalFee)
+	if fee > 0 {
+		req.WithIotas(fee)
 	} else {
 		req.WithIotas(1)
 	}
@@ -155,11 +165,10 @@ func (ch *Chain) UploadBlobOptimized(optimalSize int, keyPair *ed25519.KeyPair,
 	for _, v := range toUpload {
 		ch.Env.PutBlobDataIntoRegistry(v)
 	}
-	feeColor, ownerFee, validatorFee := ch.GetFeeInfo(blob.Contract.Name)
+	feeColor, fee := ch.EstimateFee(0)
 	require.EqualValues(ch.Env.T, feeColor, colored.IOTA)
-	totalFee := ownerFee + validatorFee
-	if totalFee > 0 {
-		req.WithIotas(totalFee)
+	if fee > 0 {
+		req.WithIotas(fee)
 	}
 	res, err := ch.PostRequestSync(req, keyPair)
 	if err != nil {
@@ -253,6 +262,21 @@ func (ch *Chain) DeployContract(keyPair *ed25519.KeyPair, name string, programHa
 	return err
 }
 
+// UpgradeContract posts a request to the 'root' contract to replace the program of the contract with the given name.
+// The params are passed to the 'migrate' entry point of the new program
+func (ch *Chain) UpgradeContract(keyPair *ed25519.KeyPair, name string, programHash hashing.HashValue, params ...interface{}) error {
+	par := codec.MakeDict(map[string]interface{}{
+		root.ParamHname:       iscp.Hn(name),
+		root.ParamProgramHash: programHash,
+	})
+	for k, v := range parseParams(params) {
+		par[k] = v
+	}
+	req := NewCallParams(root.Contract.Name, root.FuncUpgradeContract.Name, par).WithIotas(1)
+	_, err := ch.PostRequestSync(req, keyPair)
+	return err
+}
+
 // DeployWasmContract is syntactic sugar for uploading Wasm binary from file and
 // deploying the smart contract in one call
 func (ch *Chain) DeployWasmContract(keyPair *ed25519.KeyPair, name, fname string, params ...interface{}) error {
@@ -384,28 +408,29 @@ func (ch *Chain) GetTotalIotas() uint64 {
 	return ret
 }
 
-// GetFeeInfo returns the fee info for the specific chain and smart contract
-//  - color of the fee tokens in the chain
-//  - chain owner part of the fee (number of tokens)
-//  - validator part of the fee (number of tokens)
-// Total fee is sum of owner fee and validator fee
-func (ch *Chain) GetFeeInfo(contractName string) (colored.Color, uint64, uint64) {
-	hname := iscp.Hn(contractName)
-	ret, err := ch.CallView(governance.Contract.Name, governance.FuncGetFeeInfo.Name, governance.ParamHname, hname)
+// GetFeePolicy returns the gas-based fee policy of the chain
+func (ch *Chain) GetFeePolicy() *governance.FeePolicy {
+	ret, err := ch.CallView(governance.Contract.Name, governance.FuncGetFeePolicy.Name)
 	require.NoError(ch.Env.T, err)
-	require.NotEqualValues(ch.Env.T, 0, len(ret))
 
-	feeColor, err := codec.DecodeColor(ret.MustGet(governance.ParamFeeColor))
+	policy, err := governance.DecodeFeePolicy(ret)
+	require.NoError(ch.Env.T, err)
+	return policy
+}
+
+// EstimateFee returns the color of the fee tokens and the maximum fee charged for the request
+// with the given gas budget. Zero means the default gas budget
+func (ch *Chain) EstimateFee(gasBudget uint64) (colored.Color, uint64) {
+	ret, err := ch.CallView(governance.Contract.Name, governance.FuncEstimateFee.Name, governance.ParamGas, gasBudget)
 	require.NoError(ch.Env.T, err)
-	require.NotNil(ch.Env.T, feeColor)
 
-	validatorFee, err := codec.DecodeUint64(ret.MustGet(governance.ParamValidatorFee))
+	feeColor, err := codec.DecodeColor(ret.MustGet(governance.VarFeeColor))
 	require.NoError(ch.Env.T, err)
 
-	ownerFee, err := codec.DecodeUint64(ret.MustGet(governance.ParamOwnerFee))
+	fee, err := codec.DecodeUint64(ret.MustGet(governance.ParamFee))
 	require.NoError(ch.Env.T, err)
 
-	return feeColor, ownerFee, validatorFee
+	return feeColor, fee
 }
 
 func eventsFromViewResult(t TestContext, viewResult dict.Dict) []string {
@@ -495,7 +520,7 @@ func (ch *Chain) IsRequestProcessed(reqID iscp.RequestID) bool {
 		blocklog.ParamRequestID, reqID)
 	require.NoError(ch.Env.T, err)
 	resultDecoder := kvdecoder.New(ret, ch.Log)
-	bin, err := resultDecoder.GetBytes(blocklog.ParamRequestProcessed)
+	bin, err := resultDecoder.GetBytes(blocklog.ParamRequestProcessed, nil)
 	require.NoError(ch.Env.T, err)
 	return bin != nil
 }
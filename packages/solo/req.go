@@ -13,12 +13,16 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate/utxoutil"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/iscp/requestargs"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/runvm"
 	"github.com/iotaledger/wasp/packages/vm/viewcontext"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/xerrors"
@@ -32,6 +36,7 @@ type CallParams struct {
 	transfer    colored.Balances
 	mintAmount  uint64
 	mintAddress ledgerstate.Address
+	gasBudget   uint64
 	args        requestargs.RequestArgs
 }
 
@@ -100,9 +105,17 @@ func (r *CallParams) WithMint(targetAddress ledgerstate.Address, amount uint64)
 	return r
 }
 
+// WithGasBudget sets the gas budget of the request. By default, the request is run with gas.DefaultGasBudget
+func (r *CallParams) WithGasBudget(gasBudget uint64) *CallParams {
+	r.gasBudget = gasBudget
+	return r
+}
+
 // NewRequestOffLedger creates off-ledger request from parameters
 func (r *CallParams) NewRequestOffLedger(chainID *iscp.ChainID, keyPair *ed25519.KeyPair) *request.OffLedger {
-	ret := request.NewOffLedger(chainID, r.target, r.entryPoint, r.args).WithTransfer(r.transfer)
+	ret := request.NewOffLedger(chainID, r.target, r.entryPoint, r.args).
+		WithTransfer(r.transfer).
+		WithGasBudget(r.gasBudget)
 	ret.Sign(keyPair)
 	return ret
 }
@@ -144,6 +157,21 @@ func (ch *Chain) RequestFromParamsToLedger(req *CallParams, keyPair *ed25519.Key
 	ch.Env.ledgerMutex.Lock()
 	defer ch.Env.ledgerMutex.Unlock()
 
+	tx := ch.newRequestTransaction(req, keyPair)
+	err := ch.Env.AddToLedger(tx)
+	require.NoError(ch.Env.T, err)
+
+	for _, out := range tx.Essence().Outputs() {
+		if out.Address().Equals(ch.ChainID.AsAddress()) {
+			return tx, iscp.RequestID(out.ID()), nil
+		}
+	}
+	ch.Log.Panicf("solo::inconsistency: can't find output in tx")
+	return nil, iscp.RequestID{}, nil
+}
+
+// newRequestTransaction builds the transaction with the request without adding it to the ledger
+func (ch *Chain) newRequestTransaction(req *CallParams, keyPair *ed25519.KeyPair) *ledgerstate.Transaction {
 	if keyPair == nil {
 		keyPair = ch.OriginatorKeyPair
 	}
@@ -153,6 +181,7 @@ func (ch *Chain) RequestFromParamsToLedger(req *CallParams, keyPair *ed25519.Key
 	metadata := request.NewMetadata().
 		WithTarget(req.target).
 		WithEntryPoint(req.entryPoint).
+		WithGasBudget(req.gasBudget).
 		WithArgs(req.args)
 
 	mdata := metadata.Bytes()
@@ -173,17 +202,7 @@ func (ch *Chain) RequestFromParamsToLedger(req *CallParams, keyPair *ed25519.Key
 
 	tx, err := txb.BuildWithED25519(keyPair)
 	require.NoError(ch.Env.T, err)
-
-	err = ch.Env.AddToLedger(tx)
-	require.NoError(ch.Env.T, err)
-
-	for _, out := range tx.Essence().Outputs() {
-		if out.Address().Equals(ch.ChainID.AsAddress()) {
-			return tx, iscp.RequestID(out.ID()), nil
-		}
-	}
-	ch.Log.Panicf("solo::inconsistency: can't find output in tx")
-	return nil, iscp.RequestID{}, nil
+	return tx
 }
 
 // PostRequestSync posts a request synchronously  sent by the test program to the smart contract on the same or another chain:
@@ -220,6 +239,49 @@ func (ch *Chain) PostRequestOffLedger(req *CallParams, keyPair *ed25519.KeyPair)
 	return res, ch.mustGetErrorFromReceipt(r.ID())
 }
 
+// DryRunRequestOffLedger runs the off-ledger request on a copy of the current chain state in the same way
+// as the dry-run endpoint of the web API does. The state of the chain is not changed
+func (ch *Chain) DryRunRequestOffLedger(req *CallParams, keyPair *ed25519.KeyPair) (*vm.DryRunResult, error) {
+	if keyPair == nil {
+		keyPair = ch.OriginatorKeyPair
+	}
+	return ch.dryRun(req.NewRequestOffLedger(ch.ChainID, keyPair))
+}
+
+// DryRunRequestOnLedger runs the on-ledger request on a copy of the current chain state in the same way
+// as the dry-run endpoint of the web API does. The request transaction is not added to the ledger
+// and the state of the chain is not changed
+func (ch *Chain) DryRunRequestOnLedger(req *CallParams, keyPair *ed25519.KeyPair) (*vm.DryRunResult, error) {
+	ch.Env.ledgerMutex.Lock()
+	tx := ch.newRequestTransaction(req, keyPair)
+	ch.Env.ledgerMutex.Unlock()
+
+	requests, err := request.OnLedgerFromTransaction(tx, ch.ChainID.AsAddress())
+	require.NoError(ch.Env.T, err)
+	require.Len(ch.Env.T, requests, 1)
+	return ch.dryRun(requests[0])
+}
+
+func (ch *Chain) dryRun(r iscp.Request) (*vm.DryRunResult, error) {
+	_, err := request.SolidifyArgs(r, ch.Env.blobCache)
+	require.NoError(ch.Env.T, err)
+
+	ch.runVMMutex.Lock()
+	defer ch.runVMMutex.Unlock()
+
+	return runvm.DryRun(&vm.VMTask{
+		Processors:         ch.proc,
+		ChainInput:         ch.GetChainOutput(),
+		Requests:           []iscp.Request{r},
+		Timestamp:          ch.Env.LogicalTime(),
+		VirtualStateAccess: ch.State.Copy(),
+		SolidStateBaseline: ch.GlobalSync.GetSolidIndexBaseline(),
+		Entropy:            hashing.RandomHash(nil),
+		ValidatorFeeTarget: ch.ValidatorFeeTarget,
+		Log:                ch.Log,
+	})
+}
+
 func (ch *Chain) PostRequestSyncTx(req *CallParams, keyPair *ed25519.KeyPair) (*ledgerstate.Transaction, dict.Dict, error) {
 	defer ch.logRequestLastBlock()
 
@@ -278,6 +340,21 @@ func (ch *Chain) CallView(scName, funName string, params ...interface{}) (dict.D
 	return vctx.CallView(iscp.Hn(scName), iscp.Hn(funName), p)
 }
 
+// CallViewAtBlockIndex calls the view entry point of the smart contract on the state of the chain
+// as it was after the block with the given index. The call params are the same as in CallView
+func (ch *Chain) CallViewAtBlockIndex(blockIndex uint32, scName, funName string, params ...interface{}) (dict.Dict, error) {
+	ch.Log.Infof("callView at block #%d: %s::%s", blockIndex, scName, funName)
+
+	p := parseParams(params)
+
+	stateReader, err := state.NewStateReaderAtIndex(ch.Env.dbmanager.GetKVStore(ch.ChainID), ch.ChainID, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	vctx := viewcontext.New(ch.ChainID, stateReader, ch.proc, ch.Log)
+	return vctx.CallView(iscp.Hn(scName), iscp.Hn(funName), p)
+}
+
 // WaitUntil waits until the condition specified by the given predicate yields true
 func (ch *Chain) WaitUntil(p func(chain.MempoolInfo) bool, maxWait ...time.Duration) bool {
 	maxw := 10 * time.Second
@@ -307,6 +384,11 @@ func (ch *Chain) WaitForRequestsThrough(numReq int, maxWait ...time.Duration) bo
 	}, maxWait...)
 }
 
+// GetPendingRequests returns the requests in the mempool of the chain which are not processed yet
+func (ch *Chain) GetPendingRequests() []iscp.Request {
+	return ch.mempool.GetPendingRequests()
+}
+
 // MempoolInfo returns stats about the chain mempool
 func (ch *Chain) MempoolInfo() chain.MempoolInfo {
 	return ch.mempool.Info()
@@ -123,6 +123,8 @@ func (ch *Chain) settleStateTransition(stateTx *ledgerstate.Transaction, stateOu
 
 	chain.PublishStateTransition(ch.ChainID, stateOutput, len(reqids))
 	chain.PublishRequestsSettled(ch.ChainID, stateOutput.GetStateIndex(), reqids)
+	err = chain.PublishBlockEvents(ch.ChainID, ch.State.KVStoreReader(), ch.State.BlockIndex())
+	require.NoError(ch.Env.T, err)
 
 	ch.Log.Infof("state transition --> #%d. Requests in the block: %d. Outputs: %d",
 		ch.State.BlockIndex(), len(reqids), len(stateTx.Essence().Outputs()))
@@ -22,6 +22,8 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/iscp/coreutil"
 	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/publisher"
 	"github.com/iotaledger/wasp/packages/registry"
@@ -29,6 +31,7 @@ import (
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 	"github.com/iotaledger/wasp/packages/vm/runvm"
 	_ "github.com/iotaledger/wasp/packages/vm/sandbox"
@@ -271,7 +274,7 @@ func (env *Solo) NewChain(chainOriginator *ed25519.KeyPair, name string, validat
 		proc:                   processors.MustNew(env.processorConfig),
 		Log:                    chainlog,
 	}
-	ret.mempool = mempool.New(ret.StateReader, env.blobCache, chainlog, metrics.DefaultChainMetrics())
+	ret.mempool = mempool.New(ret.StateReader, env.blobCache, chainlog, metrics.DefaultChainMetrics(), nil)
 	require.NoError(env.T, err)
 	require.NoError(env.T, err)
 
@@ -454,7 +457,8 @@ func (ch *Chain) collateAndRunBatch() bool {
 	defer ch.runVMMutex.Unlock()
 
 	batch := ch.collateBatch()
-	if len(batch) > 0 {
+	// like the committee, a block is run for the scheduled calls even if there are no requests
+	if len(batch) > 0 || ch.hasDueScheduledCalls() {
 		_, err := ch.runRequestsNolock(batch, "batchLoop")
 		if err != nil {
 			ch.Log.Errorf("runRequestsSync: %v", err)
@@ -464,6 +468,12 @@ func (ch *Chain) collateAndRunBatch() bool {
 	return false
 }
 
+// hasDueScheduledCalls is true if calls of the scheduler contract are due at the logical time
+func (ch *Chain) hasDueScheduledCalls() bool {
+	schedulerState := subrealm.NewReadOnly(ch.State.KVStoreReader(), kv.Key(scheduler.Contract.Hname().Bytes()))
+	return scheduler.HasDueCalls(schedulerState, ch.Env.LogicalTime())
+}
+
 // BacklogLen is a thread-safe function to return size of the current backlog
 func (ch *Chain) BacklogLen() int {
 	mstats := ch.mempool.Info()
@@ -4,8 +4,12 @@ import (
 	"errors"
 
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/buffered"
 	"github.com/iotaledger/wasp/packages/util"
 	"golang.org/x/xerrors"
 )
@@ -14,16 +18,34 @@ import (
 // Mutations must be non-empty otherwise it is NOP
 // It the log of updates is not taken into account
 func (vs *virtualStateAccess) Commit(blocks ...Block) error {
+	return vs.commitWith(func(kvstore.BatchedMutations) error { return nil }, blocks...)
+}
+
+// commitWith commits the state like Commit. The additional records written by 'also' are committed
+// in the same batch, so either all of them are in DB together with the state or none
+func (vs *virtualStateAccess) commitWith(also func(batch kvstore.BatchedMutations) error, blocks ...Block) error {
 	if vs.kvs.Mutations().IsEmpty() {
 		// nothing to commit
 		return nil
 	}
 	batch := vs.db.Batched()
 
-	stateCommitment := vs.StateCommitment()
+	tr := vs.updatedTrie()
+	stateCommitment := tr.RootCommitment()
 	if err := batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeStateHash), stateCommitment.Bytes()); err != nil {
 		return err
 	}
+	trieMutations := tr.Mutations()
+	for k, v := range trieMutations.Sets {
+		if err := batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeTrieNode, []byte(k)), v); err != nil {
+			return err
+		}
+	}
+	for k := range trieMutations.Dels {
+		if err := batch.Delete(dbkeys.MakeKey(dbkeys.ObjectTypeTrieNode, []byte(k))); err != nil {
+			return err
+		}
+	}
 
 	for _, blk := range blocks {
 		key := dbkeys.MakeKey(dbkeys.ObjectTypeBlock, util.Uint32To4Bytes(blk.BlockIndex()))
@@ -43,6 +65,9 @@ func (vs *virtualStateAccess) Commit(blocks ...Block) error {
 			return err
 		}
 	}
+	if err := also(batch); err != nil {
+		return err
+	}
 
 	if err := batch.Commit(); err != nil {
 		return err
@@ -55,7 +80,6 @@ func (vs *virtualStateAccess) Commit(blocks ...Block) error {
 
 	vs.kvs.ClearMutations()
 	vs.kvs.Mutations().ResetModified()
-	vs.appliedBlockHashes = vs.appliedBlockHashes[:0]
 	vs.committedHash = stateCommitment
 	return nil
 }
@@ -106,3 +130,223 @@ func LoadBlock(store kvstore.KVStore, stateIndex uint32) (Block, error) {
 	}
 	return BlockFromBytes(data)
 }
+
+// MaxReplayedBlocks is the maximum number of blocks applied to rebuild a past state. It bounds the cost of a query
+// of a past state: the states after blocks further than that from the first block in DB are not available
+const MaxReplayedBlocks = 1000
+
+// LoadVirtualStateAtIndex rebuilds in memory the virtual state as it was after the block with the given index,
+// by applying the blocks stored in DB to the base state. The base state is the empty state before the origin block
+// if no blocks were pruned, otherwise the state after the first block in DB, which is kept when blocks are pruned
+// or a snapshot is imported. The resulting state commitment is checked against the previous state hash of the
+// next block, or against the committed state hash if the block is the latest one
+func LoadVirtualStateAtIndex(store kvstore.KVStore, chainID *iscp.ChainID, blockIndex uint32) (VirtualStateAccess, error) {
+	firstBlockIndex, hasBaseState, err := loadFirstBlockIndex(store)
+	if err != nil {
+		return nil, xerrors.Errorf("LoadVirtualStateAtIndex: %w", err)
+	}
+	if blockIndex < firstBlockIndex {
+		return nil, xerrors.Errorf("LoadVirtualStateAtIndex: blocks before #%d have been pruned", firstBlockIndex)
+	}
+	nextIndex := uint32(0)
+	if hasBaseState {
+		nextIndex = firstBlockIndex + 1
+	}
+	if blockIndex-firstBlockIndex >= MaxReplayedBlocks {
+		return nil, xerrors.Errorf("LoadVirtualStateAtIndex: block #%d is more than %d blocks after the first block #%d in DB",
+			blockIndex, MaxReplayedBlocks, firstBlockIndex)
+	}
+	expectedHash, err := loadStateHashAtIndex(store, blockIndex)
+	if err != nil {
+		return nil, xerrors.Errorf("LoadVirtualStateAtIndex: %w", err)
+	}
+	vs := newVirtualState(mapdb.NewMapDB(), chainID)
+	if hasBaseState {
+		if err := loadBaseState(store, vs, firstBlockIndex); err != nil {
+			return nil, xerrors.Errorf("LoadVirtualStateAtIndex: %w", err)
+		}
+	}
+	for i := nextIndex; i <= blockIndex; i++ {
+		block, err := loadExistingBlock(store, i)
+		if err != nil {
+			return nil, xerrors.Errorf("LoadVirtualStateAtIndex: %w", err)
+		}
+		if i == 0 {
+			vs.applyBlockNoCheck(block)
+			continue
+		}
+		if err := vs.ApplyBlock(block); err != nil {
+			return nil, xerrors.Errorf("LoadVirtualStateAtIndex: %w", err)
+		}
+	}
+	if vs.StateCommitment() != expectedHash {
+		return nil, xerrors.Errorf("LoadVirtualStateAtIndex: state hash mismatch at block #%d", blockIndex)
+	}
+	return vs, nil
+}
+
+// loadStateHashAtIndex returns the hash of the state after the block with the given index
+func loadStateHashAtIndex(store kvstore.KVStore, blockIndex uint32) (hashing.HashValue, error) {
+	// the committed hash is read before the next block: if the next block is not found after that,
+	// the committed hash is the one of the requested block
+	committedHash, exists, err := loadStateHashFromDb(store)
+	if err != nil {
+		return hashing.NilHash, err
+	}
+	if !exists {
+		return hashing.NilHash, xerrors.New("state not found")
+	}
+	data, err := LoadBlockBytes(store, blockIndex+1)
+	if err != nil {
+		return hashing.NilHash, err
+	}
+	if data == nil {
+		if _, err := loadExistingBlock(store, blockIndex); err != nil {
+			return hashing.NilHash, err
+		}
+		return committedHash, nil
+	}
+	nextBlock, err := BlockFromBytes(data)
+	if err != nil {
+		return hashing.NilHash, err
+	}
+	return nextBlock.PreviousStateHash(), nil
+}
+
+func loadExistingBlock(store kvstore.KVStore, blockIndex uint32) (Block, error) {
+	data, err := LoadBlockBytes(store, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, xerrors.Errorf("block #%d not found", blockIndex)
+	}
+	return BlockFromBytes(data)
+}
+
+// maxBlocksPrunedPerBatch limits the number of blocks deleted from DB in one transaction
+const maxBlocksPrunedPerBatch = 1000
+
+// LoadFirstBlockIndex returns the index of the oldest block which was not pruned from DB
+func LoadFirstBlockIndex(store kvstore.KVStore) (uint32, error) {
+	ret, _, err := loadFirstBlockIndex(store)
+	return ret, err
+}
+
+// loadFirstBlockIndex also returns if the index was recorded, i.e. blocks were pruned or the state was imported
+// from a snapshot. In that case DB contains the base state, the state after the first block
+func loadFirstBlockIndex(store kvstore.KVStore) (uint32, bool, error) {
+	data, err := store.Get(dbkeys.MakeKey(dbkeys.ObjectTypeFirstBlockIndex))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	ret, err := util.Uint32From4Bytes(data)
+	return ret, err == nil, err
+}
+
+// loadBaseState copies to vs the base state stored in DB
+func loadBaseState(store kvstore.KVStore, vs *virtualStateAccess, firstBlockIndex uint32) error {
+	err := kv.NewHiveKVStoreReader(subRealm(store, []byte{dbkeys.ObjectTypeBaseStateVariable})).Iterate("", func(k kv.Key, v []byte) bool {
+		vs.KVStore().Set(k, v)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if blockIndex, err := loadStateIndexFromState(vs.kvs); err != nil || blockIndex != firstBlockIndex {
+		return xerrors.Errorf("the base state at block #%d is not in DB", firstBlockIndex)
+	}
+	return nil
+}
+
+// writeBaseStateUpdate adds to the batch the updates which move the base state to the state after block toIndex
+func writeBaseStateUpdate(store kvstore.KVStore, batch kvstore.BatchedMutations, nextIndex, toIndex uint32) error {
+	mutations := buffered.NewMutations()
+	for i := nextIndex; i <= toIndex; i++ {
+		block, err := loadExistingBlock(store, i)
+		if err != nil {
+			return err
+		}
+		blockMutations := block.(*blockImpl).stateUpdate.Mutations()
+		for k, v := range blockMutations.Sets {
+			mutations.Set(k, v)
+		}
+		for k := range blockMutations.Dels {
+			mutations.Del(k)
+		}
+	}
+	return writeBaseStateMutations(batch, mutations)
+}
+
+func writeBaseStateMutations(batch kvstore.BatchedMutations, mutations *buffered.Mutations) error {
+	for k, v := range mutations.Sets {
+		if err := batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeBaseStateVariable, []byte(k)), v); err != nil {
+			return err
+		}
+	}
+	for k := range mutations.Dels {
+		if err := batch.Delete(dbkeys.MakeKey(dbkeys.ObjectTypeBaseStateVariable, []byte(k))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneBlocks deletes from DB the blocks with index lower than untilIndex. Blocks pruned by previous calls are not
+// visited again. The base state is moved to the new first block, so that past states can still be rebuilt.
+// Returns the number of deleted blocks and the number of bytes reclaimed in DB
+func PruneBlocks(store kvstore.KVStore, untilIndex uint32) (int, int, error) {
+	fromIndex, hasBaseState, err := loadFirstBlockIndex(store)
+	if err != nil {
+		return 0, 0, xerrors.Errorf("PruneBlocks: %w", err)
+	}
+	numBlocks, numBytes := 0, 0
+	for fromIndex < untilIndex {
+		toIndex := untilIndex
+		if toIndex-fromIndex > maxBlocksPrunedPerBatch {
+			toIndex = fromIndex + maxBlocksPrunedPerBatch
+		}
+		batch := store.Batched()
+		for i := fromIndex; i < toIndex; i++ {
+			key := dbkeys.MakeKey(dbkeys.ObjectTypeBlock, util.Uint32To4Bytes(i))
+			data, err := store.Get(key)
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				batch.Cancel()
+				return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+			}
+			if err := batch.Delete(key); err != nil {
+				batch.Cancel()
+				return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+			}
+			numBlocks++
+			numBytes += len(key) + len(data)
+		}
+		nextIndex := uint32(0)
+		if hasBaseState {
+			nextIndex = fromIndex + 1
+		}
+		if err := writeBaseStateUpdate(store, batch, nextIndex, toIndex); err != nil {
+			batch.Cancel()
+			return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+		}
+		if err := batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeFirstBlockIndex), util.Uint32To4Bytes(toIndex)); err != nil {
+			batch.Cancel()
+			return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+		}
+		if err := batch.Commit(); err != nil {
+			return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+		}
+		fromIndex = toIndex
+		hasBaseState = true
+	}
+	if err := store.Flush(); err != nil {
+		return numBlocks, numBytes, xerrors.Errorf("PruneBlocks: %w", err)
+	}
+	return numBlocks, numBytes, nil
+}
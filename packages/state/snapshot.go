@@ -0,0 +1,244 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/util"
+	"golang.org/x/xerrors"
+)
+
+// snapshotVersion is the version of the format of the snapshot files
+const snapshotVersion = byte(0)
+
+// ErrStateChangedDuringSnapshot is returned by WriteSnapshot when a block is committed while writing the snapshot
+var ErrStateChangedDuringSnapshot = xerrors.New("the state has changed while writing the snapshot")
+
+// SnapshotInfo describes the state contained in a snapshot
+type SnapshotInfo struct {
+	ChainID    *iscp.ChainID
+	BlockIndex uint32
+	StateHash  hashing.HashValue
+	// ApprovingOutputID is the ID of the alias output which anchors the state on the ledger
+	ApprovingOutputID ledgerstate.OutputID
+}
+
+// WriteSnapshot writes to w a snapshot of the chain state after the block with the given index.
+// The snapshot contains the block, the state hash and all the key/value pairs of the state.
+// If the block is not the latest one, the state is rebuilt from the blocks stored in DB.
+// The latest state is read from DB while the chain may be running: if a new block is committed in the meantime
+// the snapshot is inconsistent and ErrStateChangedDuringSnapshot is returned, so that the caller can retry
+func WriteSnapshot(w io.Writer, store kvstore.KVStore, chainID *iscp.ChainID, blockIndex uint32) (*SnapshotInfo, error) {
+	vs, exists, err := LoadSolidState(store, chainID)
+	if err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if !exists {
+		return nil, xerrors.New("WriteSnapshot: state not found")
+	}
+	if vs.BlockIndex() < blockIndex {
+		return nil, xerrors.Errorf("WriteSnapshot: block #%d not found, latest block is #%d", blockIndex, vs.BlockIndex())
+	}
+	latest := vs.BlockIndex() == blockIndex
+	if !latest {
+		if vs, err = LoadVirtualStateAtIndex(store, chainID, blockIndex); err != nil {
+			return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+		}
+	}
+	block, err := loadExistingBlock(store, blockIndex)
+	if err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	info := &SnapshotInfo{
+		ChainID:           chainID,
+		BlockIndex:        blockIndex,
+		StateHash:         vs.StateCommitment(),
+		ApprovingOutputID: block.ApprovingOutputID(),
+	}
+
+	if err := util.WriteByte(w, snapshotVersion); err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if err := chainID.Write(w); err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if _, err := w.Write(info.StateHash[:]); err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if err := util.WriteBytes32(w, block.Bytes()); err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	// each key/value pair is preceded by a flag byte. The flag is 0 after the last pair
+	err = vs.KVStoreReader().Iterate("", func(k kv.Key, v []byte) bool {
+		if err = util.WriteBoolByte(w, true); err != nil {
+			return false
+		}
+		if err = util.WriteBytes16(w, []byte(k)); err != nil {
+			return false
+		}
+		err = util.WriteBytes32(w, v)
+		return err == nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if err := util.WriteBoolByte(w, false); err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+
+	// the state hash in DB changes only when a new block is committed
+	stateHash, _, err := loadStateHashFromDb(store)
+	if err != nil {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", err)
+	}
+	if latest && stateHash != info.StateHash {
+		return nil, xerrors.Errorf("WriteSnapshot: %w", ErrStateChangedDuringSnapshot)
+	}
+	return info, nil
+}
+
+// ReadSnapshot reads a snapshot of the chain from r and stores it in DB as the solid state, together with the block
+// the snapshot was taken at. The DB must not contain a state of the chain. The state commitment calculated from the key/value pairs
+// is checked against the state hash of the snapshot.
+// The imported state is not trusted until the state hash is checked against the alias output which approves the block:
+// until then, the info of the snapshot is returned by LoadUnverifiedSnapshotInfo
+func ReadSnapshot(store kvstore.KVStore, chainID *iscp.ChainID, r io.Reader) (*SnapshotInfo, error) {
+	if _, exists, err := loadStateHashFromDb(store); err != nil || exists {
+		if err != nil {
+			return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+		}
+		return nil, xerrors.New("ReadSnapshot: the chain state already exists in DB")
+	}
+	r = fullReader{r}
+	version, err := util.ReadByte(r)
+	if err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	if version != snapshotVersion {
+		return nil, xerrors.Errorf("ReadSnapshot: unsupported snapshot version %d", version)
+	}
+	info := &SnapshotInfo{ChainID: new(iscp.ChainID)}
+	if err := info.ChainID.Read(r); err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	if !info.ChainID.Equals(chainID) {
+		return nil, xerrors.Errorf("ReadSnapshot: the snapshot belongs to chain %s", info.ChainID.Base58())
+	}
+	if err := util.ReadHashValue(r, &info.StateHash); err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	blockBytes, err := util.ReadBytes32(r)
+	if err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	block, err := BlockFromBytes(blockBytes)
+	if err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	info.BlockIndex = block.BlockIndex()
+	info.ApprovingOutputID = block.ApprovingOutputID()
+
+	vs := newVirtualState(store, info.ChainID)
+	for {
+		var more bool
+		if err := util.ReadBoolByte(r, &more); err != nil {
+			return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+		}
+		if !more {
+			break
+		}
+		k, err := util.ReadBytes16(r)
+		if err != nil {
+			return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+		}
+		v, err := util.ReadBytes32(r)
+		if err != nil {
+			return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+		}
+		vs.KVStore().Set(kv.Key(k), v)
+	}
+	if vs.BlockIndex() != info.BlockIndex {
+		return nil, xerrors.Errorf("ReadSnapshot: inconsistent block index #%d, expected #%d", vs.BlockIndex(), info.BlockIndex)
+	}
+	if vs.StateCommitment() != info.StateHash {
+		return nil, xerrors.New("ReadSnapshot: state hash mismatch")
+	}
+	// the state is committed together with the records of the snapshot: if the node stops in between,
+	// a state which was never verified or lacks the index of its first block must not be left in DB
+	err = vs.commitWith(func(batch kvstore.BatchedMutations) error {
+		// blocks before the snapshot are not in DB
+		if err := batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeFirstBlockIndex), util.Uint32To4Bytes(info.BlockIndex)); err != nil {
+			return err
+		}
+		// the imported state is the base state to rebuild the states after the following blocks
+		if err := writeBaseStateMutations(batch, vs.kvs.Mutations()); err != nil {
+			return err
+		}
+		return batch.Set(dbkeys.MakeKey(dbkeys.ObjectTypeSnapshotInfo), info.Bytes())
+	}, block)
+	if err != nil {
+		return nil, xerrors.Errorf("ReadSnapshot: %w", err)
+	}
+	return info, nil
+}
+
+// LoadUnverifiedSnapshotInfo returns the info of the snapshot imported in DB, if its state hash was not yet checked
+// against the alias output approving the block. Returns nil otherwise
+func LoadUnverifiedSnapshotInfo(store kvstore.KVStore) (*SnapshotInfo, error) {
+	data, err := store.Get(dbkeys.MakeKey(dbkeys.ObjectTypeSnapshotInfo))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return SnapshotInfoFromBytes(data)
+}
+
+// MarkSnapshotVerified records that the state hash of the imported snapshot has been checked against the alias output
+func MarkSnapshotVerified(store kvstore.KVStore) error {
+	return store.Delete(dbkeys.MakeKey(dbkeys.ObjectTypeSnapshotInfo))
+}
+
+func SnapshotInfoFromBytes(data []byte) (*SnapshotInfo, error) {
+	ret := &SnapshotInfo{ChainID: new(iscp.ChainID)}
+	r := bytes.NewReader(data)
+	if err := ret.ChainID.Read(r); err != nil {
+		return nil, err
+	}
+	if err := util.ReadUint32(r, &ret.BlockIndex); err != nil {
+		return nil, err
+	}
+	if err := util.ReadHashValue(r, &ret.StateHash); err != nil {
+		return nil, err
+	}
+	if err := util.ReadOutputID(r, &ret.ApprovingOutputID); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (si *SnapshotInfo) Bytes() []byte {
+	var buf bytes.Buffer
+	_ = si.ChainID.Write(&buf)
+	_ = util.WriteUint32(&buf, si.BlockIndex)
+	_, _ = buf.Write(si.StateHash[:])
+	_, _ = buf.Write(si.ApprovingOutputID[:])
+	return buf.Bytes()
+}
+
+// fullReader reads exactly len(p) bytes, because the readers of the util package expect complete reads
+type fullReader struct {
+	io.Reader
+}
+
+func (r fullReader) Read(p []byte) (int, error) {
+	return io.ReadFull(r.Reader, p)
+}
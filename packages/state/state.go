@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/wasp/packages/database/dbkeys"
@@ -18,6 +19,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/buffered"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/optimism"
+	"github.com/iotaledger/wasp/packages/state/trie"
 	"github.com/iotaledger/wasp/packages/util"
 	"golang.org/x/xerrors"
 )
@@ -25,20 +27,18 @@ import (
 // region VirtualStateAccess /////////////////////////////////////////////////
 
 type virtualStateAccess struct {
-	chainID            *iscp.ChainID
-	db                 kvstore.KVStore
-	kvs                *buffered.BufferedKVStoreAccess
-	committedHash      hashing.HashValue
-	appliedBlockHashes []hashing.HashValue
+	chainID       *iscp.ChainID
+	db            kvstore.KVStore
+	kvs           *buffered.BufferedKVStoreAccess
+	committedHash hashing.HashValue
 }
 
 // newVirtualState creates VirtualStateAccess interface with the partition of KVStore
 func newVirtualState(db kvstore.KVStore, chainID *iscp.ChainID) *virtualStateAccess {
 	sub := subRealm(db, []byte{dbkeys.ObjectTypeStateVariable})
 	ret := &virtualStateAccess{
-		db:                 db,
-		kvs:                buffered.NewBufferedKVStoreAccess(kv.NewHiveKVStoreReader(sub)),
-		appliedBlockHashes: make([]hashing.HashValue, 0),
+		db:  db,
+		kvs: buffered.NewBufferedKVStoreAccess(kv.NewHiveKVStoreReader(sub)),
 	}
 	if chainID != nil {
 		ret.chainID = chainID
@@ -68,28 +68,20 @@ func subRealm(db kvstore.KVStore, realm []byte) kvstore.KVStore {
 }
 
 func (vs *virtualStateAccess) Copy() VirtualStateAccess {
-	ret := &virtualStateAccess{
-		chainID:            vs.chainID.Clone(),
-		db:                 vs.db,
-		committedHash:      vs.committedHash,
-		appliedBlockHashes: make([]hashing.HashValue, len(vs.appliedBlockHashes)),
-		kvs:                vs.kvs.Copy(),
+	return &virtualStateAccess{
+		chainID:       vs.chainID.Clone(),
+		db:            vs.db,
+		committedHash: vs.committedHash,
+		kvs:           vs.kvs.Copy(),
 	}
-	copy(ret.appliedBlockHashes, vs.appliedBlockHashes)
-	return ret
 }
 
 func (vs *virtualStateAccess) DangerouslyConvertToString() string {
-	blockHashes := "["
-	for _, blockHash := range vs.appliedBlockHashes {
-		blockHashes += blockHash.String() + ", "
-	}
-	blockHashes += "]"
-	return fmt.Sprintf("#%d, ts: %v, committed hash: %s, applied block hashes: %s\n%s",
+	return fmt.Sprintf("#%d, ts: %v, committed hash: %s, state commitment: %s\n%s",
 		vs.BlockIndex(),
 		vs.Timestamp(),
 		vs.committedHash.String(),
-		blockHashes,
+		vs.StateCommitment().String(),
 		vs.KVStore().DangerouslyDumpToString(),
 	)
 }
@@ -126,7 +118,7 @@ func (vs *virtualStateAccess) PreviousStateHash() hashing.HashValue {
 	return ph
 }
 
-// ApplyBlock applies a block of state updates. Checks consistency of the block and previous state. Updates state hash
+// ApplyBlock applies a block of state updates. Checks consistency of the block and previous state
 // It is not suitible for applying origin block to empty virtual state. This is done in `newZeroVirtualState`
 func (vs *virtualStateAccess) ApplyBlock(b Block) error {
 	if vs.BlockIndex()+1 != b.BlockIndex() {
@@ -142,10 +134,9 @@ func (vs *virtualStateAccess) ApplyBlock(b Block) error {
 
 func (vs *virtualStateAccess) applyBlockNoCheck(b Block) {
 	vs.ApplyStateUpdates(b.(*blockImpl).stateUpdate)
-	vs.appliedBlockHashes = append(vs.appliedBlockHashes, hashing.HashData(b.EssenceBytes()))
 }
 
-// ApplyStateUpdates applies one state update. Doesn't change the state hash: it can be changed by Apply block
+// ApplyStateUpdates applies one state update
 func (vs *virtualStateAccess) ApplyStateUpdates(stateUpd ...StateUpdate) {
 	for _, upd := range stateUpd {
 		upd.Mutations().ApplyTo(vs.KVStore())
@@ -170,21 +161,28 @@ func (vs *virtualStateAccess) ExtractBlock() (Block, error) {
 	return ret, nil
 }
 
-// StateCommitment returns the hash of the state, calculated as a hashing of the previous (committed) state hash and the block hash.
+// StateCommitment returns the root commitment of the trie over all key/value pairs of the state,
+// including mutations which are not committed yet
 func (vs *virtualStateAccess) StateCommitment() hashing.HashValue {
 	if vs.kvs.Mutations().IsEmpty() {
 		return vs.committedHash
 	}
-	if len(vs.appliedBlockHashes) == 0 {
-		block, err := vs.ExtractBlock()
-		if err != nil {
-			panic(xerrors.Errorf("StateCommitment: %v", err))
-		}
-		vs.appliedBlockHashes = append(vs.appliedBlockHashes, hashing.HashData(block.EssenceBytes()))
+	return vs.updatedTrie().RootCommitment()
+}
+
+// GetProof returns the proof of inclusion of the key into the state (or of its absence) against StateCommitment
+func (vs *virtualStateAccess) GetProof(key kv.Key) *trie.Proof {
+	return vs.updatedTrie().GetProof([]byte(key))
+}
+
+// updatedTrie returns the committed trie with the uncommitted mutations applied to it
+func (vs *virtualStateAccess) updatedTrie() *trie.Trie {
+	ret := trie.New(kv.NewHiveKVStoreReader(subRealm(vs.db, []byte{dbkeys.ObjectTypeTrieNode})))
+	for k, v := range vs.kvs.Mutations().Sets {
+		ret.Update([]byte(k), v)
 	}
-	ret := vs.committedHash
-	for i := range vs.appliedBlockHashes {
-		ret = hashing.HashData(ret[:], vs.appliedBlockHashes[i][:])
+	for k := range vs.kvs.Mutations().Dels {
+		ret.Update([]byte(k), nil)
 	}
 	return ret
 }
@@ -197,14 +195,18 @@ func (vs *virtualStateAccess) StateCommitment() hashing.HashValue {
 type OptimisticStateReaderImpl struct {
 	db         kvstore.KVStore
 	chainState *optimism.OptimisticKVStoreReader
+	trieStore  *optimism.OptimisticKVStoreReader
 }
 
 // NewOptimisticStateReader creates new optimistic read-only access to the database. It contains own read baseline
 func NewOptimisticStateReader(db kvstore.KVStore, glb coreutil.ChainStateSync) *OptimisticStateReaderImpl {
 	chainState := kv.NewHiveKVStoreReader(subRealm(db, []byte{dbkeys.ObjectTypeStateVariable}))
+	trieStore := kv.NewHiveKVStoreReader(subRealm(db, []byte{dbkeys.ObjectTypeTrieNode}))
+	baseline := glb.GetSolidIndexBaseline()
 	return &OptimisticStateReaderImpl{
 		db:         db,
-		chainState: optimism.NewOptimisticKVStoreReader(chainState, glb.GetSolidIndexBaseline()),
+		chainState: optimism.NewOptimisticKVStoreReader(chainState, baseline),
+		trieStore:  optimism.NewOptimisticKVStoreReader(trieStore, baseline),
 	}
 }
 
@@ -242,6 +244,36 @@ func (r *OptimisticStateReaderImpl) Hash() (hashing.HashValue, error) {
 	return ret, nil
 }
 
+// GetProof returns the proof of inclusion of the key into the committed state (or of its absence) against its hash
+func (r *OptimisticStateReaderImpl) GetProof(key kv.Key) (ret *trie.Proof, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			if errRecovered, ok := e.(error); ok {
+				err = errRecovered
+				return
+			}
+			panic(e)
+		}
+	}()
+	return trie.New(r.trieStore).GetProof([]byte(key)), nil
+}
+
+// ApprovingOutputID returns ID of the alias output which anchors the committed state on the ledger
+func (r *OptimisticStateReaderImpl) ApprovingOutputID() (ledgerstate.OutputID, error) {
+	blockIndex, err := r.BlockIndex()
+	if err != nil {
+		return ledgerstate.OutputID{}, err
+	}
+	block, err := LoadBlock(r.db, blockIndex)
+	if err != nil {
+		return ledgerstate.OutputID{}, err
+	}
+	if !r.chainState.IsStateValid() {
+		return ledgerstate.OutputID{}, coreutil.ErrorStateInvalidated
+	}
+	return block.ApprovingOutputID(), nil
+}
+
 func (r *OptimisticStateReaderImpl) KVStoreReader() kv.KVStoreReader {
 	return r.chainState
 }
@@ -252,6 +284,59 @@ func (r *OptimisticStateReaderImpl) SetBaseline() {
 
 // endregion ////////////////////////////////////////////////////////
 
+// region historicalStateReader ///////////////////////////////////////////////////
+
+// historicalStateReader is the read-only access to the state rebuilt at a past block index.
+// The rebuilt state is not affected by the progress of the chain, so it is never invalidated
+type historicalStateReader struct {
+	state             VirtualStateAccess
+	approvingOutputID ledgerstate.OutputID
+}
+
+// NewStateReaderAtIndex rebuilds the state of the chain at the given block index and returns the read-only access to it
+func NewStateReaderAtIndex(store kvstore.KVStore, chainID *iscp.ChainID, blockIndex uint32) (OptimisticStateReader, error) {
+	vs, err := LoadVirtualStateAtIndex(store, chainID, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	block, err := LoadBlock(store, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &historicalStateReader{
+		state:             vs,
+		approvingOutputID: block.ApprovingOutputID(),
+	}, nil
+}
+
+func (r *historicalStateReader) BlockIndex() (uint32, error) {
+	return r.state.BlockIndex(), nil
+}
+
+func (r *historicalStateReader) Timestamp() (time.Time, error) {
+	return r.state.Timestamp(), nil
+}
+
+func (r *historicalStateReader) Hash() (hashing.HashValue, error) {
+	return r.state.StateCommitment(), nil
+}
+
+func (r *historicalStateReader) GetProof(key kv.Key) (*trie.Proof, error) {
+	return r.state.GetProof(key), nil
+}
+
+func (r *historicalStateReader) ApprovingOutputID() (ledgerstate.OutputID, error) {
+	return r.approvingOutputID, nil
+}
+
+func (r *historicalStateReader) KVStoreReader() kv.KVStoreReader {
+	return r.state.KVStoreReader()
+}
+
+func (r *historicalStateReader) SetBaseline() {}
+
+// endregion ////////////////////////////////////////////////////////
+
 // region mustOptimisticVirtualStateAccess ////////////////////////////////
 
 // MustOptimisticVirtualState is a virtual state wrapper with global state baseline
@@ -299,6 +384,13 @@ func (s *mustOptimisticVirtualStateAccess) StateCommitment() hashing.HashValue {
 	return s.state.StateCommitment()
 }
 
+func (s *mustOptimisticVirtualStateAccess) GetProof(key kv.Key) *trie.Proof {
+	s.baseline.MustValidate()
+	defer s.baseline.MustValidate()
+
+	return s.state.GetProof(key)
+}
+
 func (s *mustOptimisticVirtualStateAccess) KVStoreReader() kv.KVStoreReader {
 	s.baseline.MustValidate()
 	defer s.baseline.MustValidate()
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -453,3 +455,243 @@ func TestVirtualStateMustOptimistic2(t *testing.T) {
 	require.EqualValues(t, hash, hashOpt)
 	require.NotEqualValues(t, hashPrev, hashOpt)
 }
+
+func TestStateProof(t *testing.T) {
+	chainID := iscp.RandomChainID([]byte("1"))
+	vs, err := CreateOriginState(mapdb.NewMapDB(), chainID)
+	require.NoError(t, err)
+
+	vs.KVStore().Set("kuku", codec.EncodeString("A"))
+	vs.KVStore().Set("mumu", codec.EncodeString("B"))
+	root := vs.StateCommitment()
+	require.NoError(t, vs.GetProof("kuku").Verify(root, codec.EncodeString("A")))
+	require.NoError(t, vs.GetProof("zuzu").Verify(root, nil))
+
+	upd := NewStateUpdateWithBlocklogValues(1, time.Now(), vs.PreviousStateHash())
+	vs.ApplyStateUpdates(upd)
+	block, err := vs.ExtractBlock()
+	require.NoError(t, err)
+	err = vs.Commit(block)
+	require.NoError(t, err)
+
+	root = vs.StateCommitment()
+	require.NoError(t, vs.GetProof("mumu").Verify(root, codec.EncodeString("B")))
+	require.Error(t, vs.GetProof("mumu").Verify(root, codec.EncodeString("A")))
+	require.NoError(t, vs.GetProof(kv.Key(coreutil.StatePrefixBlockIndex)).Verify(root, codec.EncodeUint64(1)))
+
+	vs.KVStore().Del("mumu")
+	require.NoError(t, vs.GetProof("mumu").Verify(vs.StateCommitment(), nil))
+}
+
+func TestStateAtIndex(t *testing.T) {
+	store := mapdb.NewMapDB()
+	chainID := iscp.RandomChainID([]byte("1"))
+	vs, err := CreateOriginState(store, chainID)
+	require.NoError(t, err)
+
+	hashes := []hashing.HashValue{vs.StateCommitment()}
+	for i := uint32(1); i <= 3; i++ {
+		upd := NewStateUpdateWithBlocklogValues(i, time.Now(), vs.StateCommitment())
+		upd.Mutations().Set("key", codec.EncodeUint32(i))
+		if i == 3 {
+			upd.Mutations().Del("key")
+		}
+		vs.ApplyStateUpdates(upd)
+		block, err := vs.ExtractBlock()
+		require.NoError(t, err)
+		require.NoError(t, vs.Commit(block))
+		hashes = append(hashes, vs.StateCommitment())
+	}
+
+	for i := uint32(0); i <= 3; i++ {
+		rdr, err := NewStateReaderAtIndex(store, chainID, i)
+		require.NoError(t, err)
+		blockIndex, err := rdr.BlockIndex()
+		require.NoError(t, err)
+		require.EqualValues(t, i, blockIndex)
+		h, err := rdr.Hash()
+		require.NoError(t, err)
+		require.EqualValues(t, hashes[i], h)
+
+		v, err := rdr.KVStoreReader().Get("key")
+		require.NoError(t, err)
+		switch i {
+		case 0, 3:
+			require.Nil(t, v)
+		default:
+			require.EqualValues(t, codec.EncodeUint32(i), v)
+		}
+	}
+
+	_, err = NewStateReaderAtIndex(store, chainID, 4)
+	require.Error(t, err)
+}
+
+func TestPruneBlocks(t *testing.T) {
+	store := mapdb.NewMapDB()
+	chainID := iscp.RandomChainID([]byte("1"))
+	vs, err := CreateOriginState(store, chainID)
+	require.NoError(t, err)
+
+	hashes := []hashing.HashValue{vs.StateCommitment()}
+	for i := uint32(1); i <= 5; i++ {
+		upd := NewStateUpdateWithBlocklogValues(i, time.Now(), vs.StateCommitment())
+		upd.Mutations().Set("key", codec.EncodeUint32(i))
+		if i == 4 {
+			upd.Mutations().Del("key")
+		}
+		vs.ApplyStateUpdates(upd)
+		block, err := vs.ExtractBlock()
+		require.NoError(t, err)
+		require.NoError(t, vs.Commit(block))
+		hashes = append(hashes, vs.StateCommitment())
+	}
+
+	numBlocks, numBytes, err := PruneBlocks(store, 3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, numBlocks)
+	require.Greater(t, numBytes, 0)
+
+	firstBlockIndex, err := LoadFirstBlockIndex(store)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, firstBlockIndex)
+	for i := uint32(0); i <= 5; i++ {
+		data, err := LoadBlockBytes(store, i)
+		require.NoError(t, err)
+		require.Equal(t, i >= 3, data != nil)
+	}
+
+	// already pruned blocks are not counted again
+	numBlocks, _, err = PruneBlocks(store, 4)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, numBlocks)
+
+	// the solid state is not affected
+	solid, exists, err := LoadSolidState(store, chainID)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.EqualValues(t, 5, solid.BlockIndex())
+
+	// the past states are rebuilt from the base state after the first block
+	_, err = NewStateReaderAtIndex(store, chainID, 3)
+	require.Error(t, err)
+	for i := uint32(4); i <= 5; i++ {
+		rdr, err := NewStateReaderAtIndex(store, chainID, i)
+		require.NoError(t, err)
+		h, err := rdr.Hash()
+		require.NoError(t, err)
+		require.EqualValues(t, hashes[i], h)
+		v, err := rdr.KVStoreReader().Get("key")
+		require.NoError(t, err)
+		if i == 4 {
+			require.Nil(t, v)
+		} else {
+			require.EqualValues(t, codec.EncodeUint32(i), v)
+		}
+	}
+}
+
+func TestStateAtIndexMaxReplayedBlocks(t *testing.T) {
+	store := mapdb.NewMapDB()
+	chainID := iscp.RandomChainID([]byte("1"))
+	vs, err := CreateOriginState(store, chainID)
+	require.NoError(t, err)
+
+	for i := uint32(1); i <= MaxReplayedBlocks; i++ {
+		upd := NewStateUpdateWithBlocklogValues(i, time.Now(), vs.StateCommitment())
+		vs.ApplyStateUpdates(upd)
+		block, err := vs.ExtractBlock()
+		require.NoError(t, err)
+		require.NoError(t, vs.Commit(block))
+	}
+	_, err = NewStateReaderAtIndex(store, chainID, MaxReplayedBlocks-1)
+	require.NoError(t, err)
+	_, err = NewStateReaderAtIndex(store, chainID, MaxReplayedBlocks)
+	require.Error(t, err)
+
+	// after pruning, the states are rebuilt from the first block in DB
+	_, _, err = PruneBlocks(store, 10)
+	require.NoError(t, err)
+	_, err = NewStateReaderAtIndex(store, chainID, MaxReplayedBlocks)
+	require.NoError(t, err)
+}
+
+func TestSnapshot(t *testing.T) {
+	store := mapdb.NewMapDB()
+	chainID := iscp.RandomChainID([]byte("1"))
+	vs, err := CreateOriginState(store, chainID)
+	require.NoError(t, err)
+
+	for i := uint32(1); i <= 5; i++ {
+		upd := NewStateUpdateWithBlocklogValues(i, time.Now(), vs.StateCommitment())
+		upd.Mutations().Set(kv.Key(fmt.Sprintf("key%d", i)), codec.EncodeUint32(i))
+		vs.ApplyStateUpdates(upd)
+		block, err := vs.ExtractBlock()
+		require.NoError(t, err)
+		require.NoError(t, vs.Commit(block))
+	}
+
+	var buf bytes.Buffer
+	info, err := WriteSnapshot(&buf, store, chainID, 3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, info.BlockIndex)
+
+	_, err = ReadSnapshot(mapdb.NewMapDB(), iscp.RandomChainID([]byte("2")), bytes.NewReader(buf.Bytes()))
+	require.Error(t, err)
+	_, err = ReadSnapshot(store, chainID, bytes.NewReader(buf.Bytes()))
+	require.Error(t, err)
+
+	store2 := mapdb.NewMapDB()
+	info2, err := ReadSnapshot(store2, chainID, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.EqualValues(t, info, info2)
+
+	unverified, err := LoadUnverifiedSnapshotInfo(store2)
+	require.NoError(t, err)
+	require.EqualValues(t, info, unverified)
+	require.NoError(t, MarkSnapshotVerified(store2))
+	unverified, err = LoadUnverifiedSnapshotInfo(store2)
+	require.NoError(t, err)
+	require.Nil(t, unverified)
+
+	// only the blocks after the snapshot are needed to sync
+	vs2, exists, err := LoadSolidState(store2, chainID)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.EqualValues(t, 3, vs2.BlockIndex())
+	require.EqualValues(t, info.StateHash, vs2.StateCommitment())
+	for i := uint32(4); i <= 5; i++ {
+		block, err := LoadBlock(store, i)
+		require.NoError(t, err)
+		require.NoError(t, vs2.ApplyBlock(block))
+		require.NoError(t, vs2.Commit(block))
+	}
+	require.EqualValues(t, vs.StateCommitment(), vs2.StateCommitment())
+
+	firstBlockIndex, err := LoadFirstBlockIndex(store2)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, firstBlockIndex)
+
+	// the past states are rebuilt from the imported state
+	rdr, err := NewStateReaderAtIndex(store2, chainID, 4)
+	require.NoError(t, err)
+	v, err := rdr.KVStoreReader().Get("key4")
+	require.NoError(t, err)
+	require.EqualValues(t, codec.EncodeUint32(4), v)
+	v, err = rdr.KVStoreReader().Get("key5")
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	// a snapshot of the latest state is taken from the solid state
+	buf.Reset()
+	info, err = WriteSnapshot(&buf, store2, chainID, 5)
+	require.NoError(t, err)
+	require.EqualValues(t, vs.StateCommitment(), info.StateHash)
+	// a snapshot of a past state is rebuilt from the imported state
+	buf.Reset()
+	info, err = WriteSnapshot(&buf, store2, chainID, 4)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, info.BlockIndex)
+	_, err = WriteSnapshot(&buf, store2, chainID, 2)
+	require.Error(t, err)
+}
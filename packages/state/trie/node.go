@@ -0,0 +1,179 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package trie
+
+import (
+	"bytes"
+	"io"
+	"sort"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/util"
+	"golang.org/x/xerrors"
+)
+
+// node is a node of the 16-ary Merkle Patricia trie.
+// Nodes are stored by their position in the trie, i.e. by the path (in nibbles) from the root to the node.
+// The full key of the node is position + pathFragment.
+// Children of the node are located at position + pathFragment + childIndex
+type node struct {
+	pathFragment []byte                     // nibbles
+	terminal     *hashing.HashValue         // hash of the value, if the key ends in the node
+	children     map[byte]hashing.HashValue // child index (nibble) -> commitment of the child
+}
+
+func newNode(pathFragment []byte) *node {
+	return &node{
+		pathFragment: pathFragment,
+		children:     make(map[byte]hashing.HashValue),
+	}
+}
+
+func nodeFromBytes(data []byte) (*node, error) {
+	ret := newNode(nil)
+	if err := ret.Read(bytes.NewReader(data)); err != nil {
+		return nil, xerrors.Errorf("nodeFromBytes: %w", err)
+	}
+	return ret, nil
+}
+
+func (n *node) clone() *node {
+	ret := newNode(concat(n.pathFragment))
+	if n.terminal != nil {
+		h := *n.terminal
+		ret.terminal = &h
+	}
+	for i, c := range n.children {
+		ret.children[i] = c
+	}
+	return ret
+}
+
+func (n *node) isEmpty() bool {
+	return n.terminal == nil && len(n.children) == 0
+}
+
+func (n *node) childIndices() []byte {
+	ret := make([]byte, 0, len(n.children))
+	for i := range n.children {
+		ret = append(ret, i)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// commitment is the hash of the serialized node. It commits to the whole subtree,
+// because the node contains commitments to all its children
+func (n *node) commitment() hashing.HashValue {
+	return hashing.HashData(n.Bytes())
+}
+
+func (n *node) Bytes() []byte {
+	var buf bytes.Buffer
+	_ = n.Write(&buf)
+	return buf.Bytes()
+}
+
+func (n *node) Write(w io.Writer) error {
+	if err := util.WriteBytes16(w, n.pathFragment); err != nil {
+		return err
+	}
+	if err := util.WriteBoolByte(w, n.terminal != nil); err != nil {
+		return err
+	}
+	if n.terminal != nil {
+		if _, err := w.Write(n.terminal[:]); err != nil {
+			return err
+		}
+	}
+	var flags uint16
+	for i := range n.children {
+		flags |= 1 << i
+	}
+	if err := util.WriteUint16(w, flags); err != nil {
+		return err
+	}
+	for _, i := range n.childIndices() {
+		c := n.children[i]
+		if _, err := w.Write(c[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *node) Read(r io.Reader) error {
+	var err error
+	if n.pathFragment, err = util.ReadBytes16(r); err != nil {
+		return err
+	}
+	for _, nib := range n.pathFragment {
+		if nib >= radix {
+			return xerrors.New("wrong nibble in the path fragment")
+		}
+	}
+	var hasTerminal bool
+	if err = util.ReadBoolByte(r, &hasTerminal); err != nil {
+		return err
+	}
+	if hasTerminal {
+		n.terminal = new(hashing.HashValue)
+		if err = util.ReadHashValue(r, n.terminal); err != nil {
+			return err
+		}
+	}
+	var flags uint16
+	if err = util.ReadUint16(r, &flags); err != nil {
+		return err
+	}
+	n.children = make(map[byte]hashing.HashValue)
+	for i := byte(0); i < radix; i++ {
+		if flags&(1<<i) == 0 {
+			continue
+		}
+		var c hashing.HashValue
+		if err = util.ReadHashValue(r, &c); err != nil {
+			return err
+		}
+		n.children[i] = c
+	}
+	return nil
+}
+
+// region helpers ////////////////////////////////////////////////////////
+
+const radix = 16
+
+// unpack splits each byte of the key into two nibbles
+func unpack(key []byte) []byte {
+	ret := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		ret = append(ret, b>>4, b&0x0F)
+	}
+	return ret
+}
+
+func commonPrefixLen(a, b []byte) int {
+	i := 0
+	for ; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			break
+		}
+	}
+	return i
+}
+
+func concat(fragments ...[]byte) []byte {
+	size := 0
+	for _, f := range fragments {
+		size += len(f)
+	}
+	ret := make([]byte, 0, size)
+	for _, f := range fragments {
+		ret = append(ret, f...)
+	}
+	return ret
+}
+
+// endregion /////////////////////////////////////////////////////////////
@@ -0,0 +1,186 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package trie
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/util"
+	"golang.org/x/xerrors"
+)
+
+// noChild marks the last element of the path in the serialized proof
+const noChild = 0xFF
+
+// Proof is a path of trie nodes from the root towards the key.
+// It proves inclusion of the key with the value into the trie with the given root commitment,
+// or the absence of the key in the trie
+type Proof struct {
+	Key  []byte
+	Path []*ProofElement
+}
+
+// ProofElement is a node along the path of the proof.
+// ChildIndex is the index of the next element in the path or -1 for the last element
+type ProofElement struct {
+	PathFragment []byte
+	Terminal     *hashing.HashValue
+	Children     map[byte]hashing.HashValue
+	ChildIndex   int
+}
+
+func ProofFromBytes(data []byte) (*Proof, error) {
+	ret := new(Proof)
+	if err := ret.Read(bytes.NewReader(data)); err != nil {
+		return nil, xerrors.Errorf("ProofFromBytes: %w", err)
+	}
+	return ret, nil
+}
+
+// Verify checks the proof against the root commitment.
+// If value is not nil, the proof must prove inclusion of the key with the value.
+// If value is nil, the proof must prove absence of the key
+func (p *Proof) Verify(root hashing.HashValue, value []byte) error {
+	if len(p.Path) == 0 {
+		if root != hashing.NilHash {
+			return xerrors.New("proof is empty but the trie is not")
+		}
+		if value != nil {
+			return xerrors.New("key is not in the empty trie")
+		}
+		return nil
+	}
+	key := unpack(p.Key)
+	pos := 0
+	for i, elem := range p.Path {
+		last := i == len(p.Path)-1
+		if !last {
+			if commonPrefixLen(elem.PathFragment, key[pos:]) != len(elem.PathFragment) {
+				return xerrors.Errorf("path fragment of the proof element #%d does not match the key", i)
+			}
+			pos += len(elem.PathFragment)
+			if pos >= len(key) || elem.ChildIndex != int(key[pos]) {
+				return xerrors.Errorf("wrong child index in the proof element #%d", i)
+			}
+			pos++
+			continue
+		}
+		if elem.ChildIndex != -1 {
+			return xerrors.New("last proof element must not point to a child")
+		}
+		inclusion := commonPrefixLen(elem.PathFragment, key[pos:]) == len(elem.PathFragment) &&
+			pos+len(elem.PathFragment) == len(key) && elem.Terminal != nil
+		if value != nil {
+			if !inclusion {
+				return xerrors.New("key is not included into the trie")
+			}
+			if *elem.Terminal != hashing.HashData(value) {
+				return xerrors.New("value does not match the proof")
+			}
+		} else if inclusion || !p.isAbsenceEnd(elem, key[pos:]) {
+			return xerrors.New("proof does not prove absence of the key")
+		}
+	}
+	// recalculate the commitments from the bottom up
+	c := p.Path[len(p.Path)-1].node().commitment()
+	for i := len(p.Path) - 2; i >= 0; i-- {
+		elem := p.Path[i]
+		if elem.Children[byte(elem.ChildIndex)] != c {
+			return xerrors.Errorf("wrong commitment of the child in the proof element #%d", i)
+		}
+		c = elem.node().commitment()
+	}
+	if c != root {
+		return xerrors.New("root commitment does not match the proof")
+	}
+	return nil
+}
+
+// isAbsenceEnd checks if the path to the key ends in the element: either the path fragment diverges
+// from the key, or the key ends in the node without the terminal, or there is no child for the next nibble
+func (p *Proof) isAbsenceEnd(elem *ProofElement, rest []byte) bool {
+	prefixLen := commonPrefixLen(elem.PathFragment, rest)
+	if prefixLen < len(elem.PathFragment) {
+		return true
+	}
+	if prefixLen == len(rest) {
+		return elem.Terminal == nil
+	}
+	_, ok := elem.Children[rest[prefixLen]]
+	return !ok
+}
+
+func (p *Proof) Bytes() []byte {
+	var buf bytes.Buffer
+	_ = p.Write(&buf)
+	return buf.Bytes()
+}
+
+func (p *Proof) Write(w io.Writer) error {
+	if err := util.WriteBytes16(w, p.Key); err != nil {
+		return err
+	}
+	if err := util.WriteUint16(w, uint16(len(p.Path))); err != nil {
+		return err
+	}
+	for _, elem := range p.Path {
+		if err := util.WriteBytes16(w, elem.node().Bytes()); err != nil {
+			return err
+		}
+		childIndex := byte(noChild)
+		if elem.ChildIndex >= 0 {
+			childIndex = byte(elem.ChildIndex)
+		}
+		if err := util.WriteByte(w, childIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Proof) Read(r io.Reader) error {
+	var err error
+	if p.Key, err = util.ReadBytes16(r); err != nil {
+		return err
+	}
+	var size uint16
+	if err = util.ReadUint16(r, &size); err != nil {
+		return err
+	}
+	p.Path = make([]*ProofElement, size)
+	for i := range p.Path {
+		data, err := util.ReadBytes16(r)
+		if err != nil {
+			return err
+		}
+		n, err := nodeFromBytes(data)
+		if err != nil {
+			return err
+		}
+		childIndex, err := util.ReadByte(r)
+		if err != nil {
+			return err
+		}
+		p.Path[i] = &ProofElement{
+			PathFragment: n.pathFragment,
+			Terminal:     n.terminal,
+			Children:     n.children,
+			ChildIndex:   -1,
+		}
+		if childIndex != noChild {
+			p.Path[i].ChildIndex = int(childIndex)
+		}
+	}
+	return nil
+}
+
+func (e *ProofElement) node() *node {
+	return &node{
+		pathFragment: e.PathFragment,
+		terminal:     e.Terminal,
+		children:     e.Children,
+	}
+}
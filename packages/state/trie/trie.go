@@ -0,0 +1,276 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// Package trie implements authenticated key/value storage in the form of the 16-ary Merkle Patricia trie.
+// The root commitment of the trie commits to the whole set of key/value pairs and does not depend
+// on the order in which the updates were applied.
+package trie
+
+import (
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/buffered"
+	"golang.org/x/xerrors"
+)
+
+type bufferedNode struct {
+	n        *node // nil means the node was deleted
+	modified bool
+}
+
+// Trie is a buffered access to the trie nodes stored in the KV store.
+// Updates are kept in memory. Commitments of the modified nodes are recalculated by RootCommitment
+// and the nodes to be persisted are returned by Mutations
+type Trie struct {
+	store kv.KVStoreReader
+	cache map[kv.Key]*bufferedNode
+}
+
+// New creates the trie on top of the partition of the KV store which contains the trie nodes
+func New(store kv.KVStoreReader) *Trie {
+	return &Trie{
+		store: store,
+		cache: make(map[kv.Key]*bufferedNode),
+	}
+}
+
+// Update sets the value of the key. Nil value deletes the key from the trie
+func (t *Trie) Update(key, value []byte) {
+	if value == nil {
+		t.delete(unpack(key))
+		return
+	}
+	t.insert(unpack(key), hashing.HashData(value))
+}
+
+// RootCommitment recalculates commitments of all modified nodes and returns the commitment to the whole trie.
+// The commitment of the empty trie is hashing.NilHash
+func (t *Trie) RootCommitment() hashing.HashValue {
+	if t.getNode(nil) == nil {
+		return hashing.NilHash
+	}
+	return t.commitNode(nil)
+}
+
+// Mutations returns updates of the trie nodes which must be persisted in the store of the trie.
+// Keys are relative to the trie partition
+func (t *Trie) Mutations() *buffered.Mutations {
+	t.RootCommitment()
+	ret := buffered.NewMutations()
+	for pos, b := range t.cache {
+		if !b.modified {
+			continue
+		}
+		if b.n == nil {
+			ret.Del(pos)
+		} else {
+			ret.Set(pos, b.n.Bytes())
+		}
+	}
+	return ret
+}
+
+// GetProof returns the proof of inclusion of the key into the trie, or the proof of its absence
+func (t *Trie) GetProof(key []byte) *Proof {
+	t.RootCommitment()
+	unpackedKey := unpack(key)
+	ret := &Proof{
+		Key:  key,
+		Path: make([]*ProofElement, 0),
+	}
+	var pos []byte
+	for n := t.getNode(pos); n != nil; {
+		c := n.clone()
+		elem := &ProofElement{
+			PathFragment: c.pathFragment,
+			Terminal:     c.terminal,
+			Children:     c.children,
+			ChildIndex:   -1,
+		}
+		ret.Path = append(ret.Path, elem)
+		rest := unpackedKey[len(pos):]
+		if commonPrefixLen(n.pathFragment, rest) != len(n.pathFragment) || len(rest) == len(n.pathFragment) {
+			break
+		}
+		childIndex := rest[len(n.pathFragment)]
+		if _, ok := n.children[childIndex]; !ok {
+			break
+		}
+		elem.ChildIndex = int(childIndex)
+		pos = concat(pos, n.pathFragment, []byte{childIndex})
+		n = t.mustGetNode(pos)
+	}
+	return ret
+}
+
+func (t *Trie) insert(key []byte, valueHash hashing.HashValue) {
+	var pos []byte
+	n := t.getNode(pos)
+	if n == nil {
+		n = newNode(key)
+		n.terminal = &valueHash
+		t.putNode(pos, n)
+		return
+	}
+	for {
+		// each node along the path will get a new commitment
+		t.putNode(pos, n)
+		rest := key[len(pos):]
+		prefixLen := commonPrefixLen(n.pathFragment, rest)
+		if prefixLen < len(n.pathFragment) {
+			t.split(pos, n, prefixLen, rest, valueHash)
+			return
+		}
+		if prefixLen == len(rest) {
+			n.terminal = &valueHash
+			return
+		}
+		childIndex := rest[prefixLen]
+		childPos := concat(pos, n.pathFragment, []byte{childIndex})
+		if _, ok := n.children[childIndex]; !ok {
+			child := newNode(concat(rest[prefixLen+1:]))
+			child.terminal = &valueHash
+			n.children[childIndex] = hashing.NilHash // to be calculated on commit
+			t.putNode(childPos, child)
+			return
+		}
+		pos = childPos
+		n = t.mustGetNode(pos)
+	}
+}
+
+// split replaces node n at position pos with the new node with the path fragment which is common with the key.
+// The old node becomes the child of the new one. The full key of the old node remains the same, so the
+// positions of its descendants do not change
+func (t *Trie) split(pos []byte, n *node, prefixLen int, rest []byte, valueHash hashing.HashValue) {
+	oldChildIndex := n.pathFragment[prefixLen]
+	oldPos := concat(pos, n.pathFragment[:prefixLen+1])
+	moved := n.clone()
+	moved.pathFragment = concat(n.pathFragment[prefixLen+1:])
+	t.putNode(oldPos, moved)
+
+	fork := newNode(concat(n.pathFragment[:prefixLen]))
+	fork.children[oldChildIndex] = hashing.NilHash
+	if prefixLen == len(rest) {
+		fork.terminal = &valueHash
+	} else {
+		childIndex := rest[prefixLen]
+		child := newNode(concat(rest[prefixLen+1:]))
+		child.terminal = &valueHash
+		fork.children[childIndex] = hashing.NilHash
+		t.putNode(concat(pos, rest[:prefixLen+1]), child)
+	}
+	t.putNode(pos, fork)
+}
+
+func (t *Trie) delete(key []byte) {
+	// collect positions of the nodes along the path to the key
+	positions := make([][]byte, 0)
+	var pos []byte
+	n := t.getNode(pos)
+	for {
+		if n == nil {
+			return
+		}
+		positions = append(positions, pos)
+		rest := key[len(pos):]
+		prefixLen := commonPrefixLen(n.pathFragment, rest)
+		if prefixLen < len(n.pathFragment) {
+			return
+		}
+		if prefixLen == len(rest) {
+			break
+		}
+		childIndex := rest[prefixLen]
+		if _, ok := n.children[childIndex]; !ok {
+			return
+		}
+		pos = concat(pos, n.pathFragment, []byte{childIndex})
+		n = t.mustGetNode(pos)
+	}
+	if n.terminal == nil {
+		return
+	}
+	for _, p := range positions {
+		t.putNode(p, t.mustGetNode(p))
+	}
+	n.terminal = nil
+	// normalize the trie from the bottom up: remove empty nodes and merge nodes with single child
+	for i := len(positions) - 1; i >= 0; i-- {
+		p := positions[i]
+		n = t.mustGetNode(p)
+		switch {
+		case n.isEmpty():
+			t.deleteNode(p)
+			if i > 0 {
+				// the last nibble of the position is the child index in the parent node
+				delete(t.mustGetNode(positions[i-1]).children, p[len(p)-1])
+			}
+		case n.terminal == nil && len(n.children) == 1:
+			t.mergeWithChild(p, n)
+		}
+	}
+}
+
+// mergeWithChild replaces the node by its only child. The full key of the child remains the same,
+// so the positions of its descendants do not change
+func (t *Trie) mergeWithChild(pos []byte, n *node) {
+	var childIndex byte
+	for i := range n.children {
+		childIndex = i
+	}
+	childPos := concat(pos, n.pathFragment, []byte{childIndex})
+	child := t.mustGetNode(childPos).clone()
+	child.pathFragment = concat(n.pathFragment, []byte{childIndex}, child.pathFragment)
+	t.deleteNode(childPos)
+	t.putNode(pos, child)
+}
+
+func (t *Trie) commitNode(pos []byte) hashing.HashValue {
+	n := t.mustGetNode(pos)
+	if b, ok := t.cache[kv.Key(pos)]; ok && b.modified {
+		for childIndex := range n.children {
+			childPos := concat(pos, n.pathFragment, []byte{childIndex})
+			if cb, ok := t.cache[kv.Key(childPos)]; ok && cb.modified {
+				n.children[childIndex] = t.commitNode(childPos)
+			}
+		}
+	}
+	return n.commitment()
+}
+
+func (t *Trie) getNode(pos []byte) *node {
+	if b, ok := t.cache[kv.Key(pos)]; ok {
+		return b.n
+	}
+	data, err := t.store.Get(kv.Key(pos))
+	if err != nil {
+		panic(xerrors.Errorf("trie.getNode: %w", err))
+	}
+	if data == nil {
+		t.cache[kv.Key(pos)] = &bufferedNode{}
+		return nil
+	}
+	n, err := nodeFromBytes(data)
+	if err != nil {
+		panic(xerrors.Errorf("trie.getNode: %w", err))
+	}
+	t.cache[kv.Key(pos)] = &bufferedNode{n: n}
+	return n
+}
+
+func (t *Trie) mustGetNode(pos []byte) *node {
+	n := t.getNode(pos)
+	if n == nil {
+		panic(xerrors.Errorf("trie: inconsistency: node not found at position %x", pos))
+	}
+	return n
+}
+
+func (t *Trie) putNode(pos []byte, n *node) {
+	t.cache[kv.Key(pos)] = &bufferedNode{n: n, modified: true}
+}
+
+func (t *Trie) deleteNode(pos []byte) {
+	t.cache[kv.Key(pos)] = &bufferedNode{modified: true}
+}
@@ -0,0 +1,121 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/stretchr/testify/require"
+)
+
+func randomKVs(n int, seed int64) map[string][]byte {
+	rnd := rand.New(rand.NewSource(seed))
+	ret := make(map[string][]byte)
+	for i := 0; i < n; i++ {
+		key := make([]byte, 1+rnd.Intn(5))
+		rnd.Read(key)
+		ret[string(key)] = []byte(fmt.Sprintf("value%d", i))
+	}
+	return ret
+}
+
+func commitTo(store dict.Dict, tr *Trie) hashing.HashValue {
+	root := tr.RootCommitment()
+	tr.Mutations().ApplyTo(store)
+	return root
+}
+
+func TestTrieBasic(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		tr := New(dict.New())
+		require.EqualValues(t, hashing.NilHash, tr.RootCommitment())
+	})
+	t.Run("insert delete", func(t *testing.T) {
+		tr := New(dict.New())
+		tr.Update([]byte("a"), []byte("1"))
+		c1 := tr.RootCommitment()
+		require.NotEqualValues(t, hashing.NilHash, c1)
+		tr.Update([]byte("ab"), []byte("2"))
+		require.NotEqualValues(t, c1, tr.RootCommitment())
+		tr.Update([]byte("ab"), nil)
+		require.EqualValues(t, c1, tr.RootCommitment())
+		tr.Update([]byte("a"), nil)
+		require.EqualValues(t, hashing.NilHash, tr.RootCommitment())
+	})
+	t.Run("delete absent", func(t *testing.T) {
+		tr := New(dict.New())
+		tr.Update([]byte("abc"), []byte("1"))
+		c1 := tr.RootCommitment()
+		tr.Update([]byte("ab"), nil)
+		tr.Update([]byte("abcd"), nil)
+		tr.Update([]byte("x"), nil)
+		require.EqualValues(t, c1, tr.RootCommitment())
+	})
+}
+
+func TestTrieDeterminism(t *testing.T) {
+	kvs := randomKVs(1000, 1)
+
+	tr1 := New(dict.New())
+	for k, v := range kvs {
+		tr1.Update([]byte(k), v)
+	}
+	// same set of keys, different order, with some keys added and removed in between
+	store := dict.New()
+	tr2 := New(store)
+	extra := randomKVs(300, 2)
+	for k, v := range extra {
+		tr2.Update([]byte(k), v)
+	}
+	commitTo(store, tr2)
+	tr2 = New(store)
+	for k := range extra {
+		tr2.Update([]byte(k), nil)
+	}
+	for k, v := range kvs {
+		tr2.Update([]byte(k), v)
+	}
+	require.EqualValues(t, tr1.RootCommitment(), commitTo(store, tr2))
+	require.EqualValues(t, tr1.RootCommitment(), New(store).RootCommitment())
+
+	// removing all keys leaves no nodes in the store
+	tr3 := New(store)
+	for k := range kvs {
+		tr3.Update([]byte(k), nil)
+	}
+	require.EqualValues(t, hashing.NilHash, commitTo(store, tr3))
+	require.EqualValues(t, 0, len(store))
+}
+
+func TestTrieProof(t *testing.T) {
+	kvs := randomKVs(500, 3)
+	store := dict.New()
+	tr := New(store)
+	for k, v := range kvs {
+		tr.Update([]byte(k), v)
+	}
+	root := commitTo(store, tr)
+	tr = New(store)
+
+	for k, v := range kvs {
+		proof := tr.GetProof([]byte(k))
+		require.NoError(t, proof.Verify(root, v))
+		require.Error(t, proof.Verify(root, []byte("wrong")))
+		require.Error(t, proof.Verify(root, nil))
+
+		proofBack, err := ProofFromBytes(proof.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, proofBack.Verify(root, v))
+		require.Error(t, proofBack.Verify(hashing.RandomHash(nil), v))
+	}
+	for k := range randomKVs(100, 4) {
+		if _, ok := kvs[k]; ok {
+			continue
+		}
+		proof := tr.GetProof([]byte(k))
+		require.NoError(t, proof.Verify(root, nil))
+		require.Error(t, proof.Verify(root, []byte("any")))
+	}
+}
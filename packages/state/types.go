@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/buffered"
+	"github.com/iotaledger/wasp/packages/state/trie"
 )
 
 // VirtualStateAccess is a virtualized access interface to the chain's database
@@ -16,6 +17,7 @@ type VirtualStateAccess interface {
 	Timestamp() time.Time
 	PreviousStateHash() hashing.HashValue
 	StateCommitment() hashing.HashValue
+	GetProof(key kv.Key) *trie.Proof
 	KVStoreReader() kv.KVStoreReader
 	ApplyStateUpdates(...StateUpdate)
 	ApplyBlock(Block) error
@@ -30,6 +32,8 @@ type OptimisticStateReader interface {
 	BlockIndex() (uint32, error)
 	Timestamp() (time.Time, error)
 	Hash() (hashing.HashValue, error)
+	GetProof(key kv.Key) (*trie.Proof, error)
+	ApprovingOutputID() (ledgerstate.OutputID, error)
 	KVStoreReader() kv.KVStoreReader
 	SetBaseline()
 }
@@ -53,7 +57,7 @@ type Block interface {
 	Bytes() []byte
 }
 
-const OriginStateHashBase58 = "96yCdioNdifMb8xTeHQVQ8BzDnXDbRBoYzTq7iVaymvV"
+const OriginStateHashBase58 = "CaXUQwJg7ikdmHsanPbzd3tmpC7k93fVCBAMW9nczuPR"
 
 func OriginStateHash() hashing.HashValue {
 	ret, err := hashing.HashValueFromBase58(OriginStateHashBase58)
@@ -11,6 +11,8 @@ import (
 	"github.com/iotaledger/wasp/packages/tcrypto"
 )
 
+const pendingSuffix = "/pending"
+
 // DkgRegistryProvider stands for a mock for dkg.DKShareRegistryProvider.
 type DkgRegistryProvider struct {
 	DB    map[string][]byte
@@ -39,3 +41,26 @@ func (p *DkgRegistryProvider) LoadDKShare(sharedAddress ledgerstate.Address) (*t
 	}
 	return tcrypto.DKShareFromBytes(dkShareBytes, p.Suite)
 }
+
+// SavePendingDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) SavePendingDKShare(dkShare *tcrypto.DKShare) error {
+	p.DB[dkShare.Address.String()+pendingSuffix] = dkShare.Bytes()
+	return nil
+}
+
+// CommitPendingDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) CommitPendingDKShare(sharedAddress ledgerstate.Address) error {
+	dkShareBytes := p.DB[sharedAddress.String()+pendingSuffix]
+	if dkShareBytes == nil {
+		return fmt.Errorf("pending DKShare not found for %v", sharedAddress.Base58())
+	}
+	p.DB[sharedAddress.String()] = dkShareBytes
+	delete(p.DB, sharedAddress.String()+pendingSuffix)
+	return nil
+}
+
+// DeleteDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) DeleteDKShare(sharedAddress ledgerstate.Address) error {
+	delete(p.DB, sharedAddress.String())
+	return nil
+}
@@ -192,11 +192,11 @@ func (p *peeringNetworkProvider) Self() peering.PeerSender {
 func (p *peeringNetworkProvider) PeerGroup(peeringID peering.PeeringID, peerPubKeys []*ed25519.PublicKey) (peering.GroupProvider, error) {
 	peers := make([]peering.PeerSender, len(peerPubKeys))
 	for i := range peerPubKeys {
-		n := p.network.nodeByPubKey(peerPubKeys[i])
-		if n == nil {
+		sender, err := p.PeerByPubKey(peerPubKeys[i])
+		if err != nil {
 			return nil, errors.New("unknown node location")
 		}
-		peers[i] = p.senders[i]
+		peers[i] = sender
 	}
 	return group.NewPeeringGroupProvider(p, peeringID, peers, p.network.log)
 }
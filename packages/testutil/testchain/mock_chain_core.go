@@ -115,6 +115,10 @@ func (m *MockedChainCore) GetStateReader() state.OptimisticStateReader {
 	return m.onGetStateReader()
 }
 
+func (m *MockedChainCore) GetStateReaderAtIndex(blockIndex uint32) (state.OptimisticStateReader, error) {
+	panic("implement me")
+}
+
 func (m *MockedChainCore) GetCommitteeInfo() *chain.CommitteeInfo {
 	panic("implement me")
 }
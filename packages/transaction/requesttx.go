@@ -16,6 +16,7 @@ type RequestParams struct {
 	EntryPoint iscp.Hname
 	Transfer   colored.Balances
 	Args       requestargs.RequestArgs
+	GasBudget  uint64
 }
 
 type NewRequestTransactionParams struct {
@@ -32,6 +33,7 @@ func NewRequestTransaction(par NewRequestTransactionParams) (*ledgerstate.Transa
 		metadata := request.NewMetadata().
 			WithTarget(req.Contract).
 			WithEntryPoint(req.EntryPoint).
+			WithGasBudget(req.GasBudget).
 			WithArgs(req.Args).
 			Bytes()
 		var transfer colored.Balances
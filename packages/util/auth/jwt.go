@@ -0,0 +1,178 @@
+package auth
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
+)
+
+const SchemeJWT = "jwt"
+
+// Scopes that can be granted to a JWT token. Each route group of the web API requires one of them.
+// The admin scope implies the other scopes.
+const (
+	ScopeRead          = "read"
+	ScopeWriteRequests = "write-requests"
+	ScopeAdmin         = "admin"
+)
+
+// AllScopes is the list of all known scopes
+var AllScopes = []string{ScopeRead, ScopeWriteRequests, ScopeAdmin}
+
+// User is an account allowed to request a JWT token from the login route
+type User struct {
+	Password string
+	Scopes   []string
+}
+
+// Claims is the payload of the JWT tokens issued by the node
+type Claims struct {
+	jwt.RegisteredClaims
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope returns true if the claims grant the given scope
+func (c *Claims) HasScope(scope string) bool {
+	return grantsScope(c.Scopes, scope)
+}
+
+// JWTAuth issues and verifies JWT tokens signed with the node key
+type JWTAuth struct {
+	keyPair      *ed25519.KeyPair
+	duration     time.Duration
+	users        map[string]*User
+	publicScopes []string
+}
+
+// NewJWTAuth creates a JWTAuth issuing tokens valid for the given duration to the given users.
+// Requests without a token are granted the publicScopes.
+func NewJWTAuth(keyPair *ed25519.KeyPair, duration time.Duration, users map[string]*User, publicScopes []string) (*JWTAuth, error) {
+	for name, user := range users {
+		for _, scope := range user.Scopes {
+			if !hasScope(AllScopes, scope) {
+				return nil, xerrors.Errorf("user %s: unknown scope %q", name, scope)
+			}
+		}
+	}
+	for _, scope := range publicScopes {
+		if !hasScope(AllScopes, scope) {
+			return nil, xerrors.Errorf("unknown public scope %q", scope)
+		}
+	}
+	return &JWTAuth{
+		keyPair:      keyPair,
+		duration:     duration,
+		users:        users,
+		publicScopes: publicScopes,
+	}, nil
+}
+
+// UsersFromConfig builds the list of users from the username -> password and
+// username -> comma-separated scopes maps of the node configuration
+func UsersFromConfig(passwords, scopes map[string]string) map[string]*User {
+	ret := make(map[string]*User)
+	for name, password := range passwords {
+		user := &User{Password: password, Scopes: make([]string, 0)}
+		for _, scope := range strings.Split(scopes[name], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				user.Scopes = append(user.Scopes, scope)
+			}
+		}
+		ret[name] = user
+	}
+	return ret
+}
+
+// Login checks the credentials of the user and issues a token with the user's scopes
+func (a *JWTAuth) Login(username, password string) (string, time.Time, error) {
+	user, ok := a.users[username]
+	if !ok || subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) != 1 {
+		return "", time.Time{}, xerrors.New("invalid credentials")
+	}
+	return a.IssueToken(username, user.Scopes)
+}
+
+// IssueToken issues a token for the given subject, granting the given scopes
+func (a *JWTAuth) IssueToken(subject string, scopes []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(a.duration)
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    a.keyPair.PublicKey.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Scopes: scopes,
+	})
+	signed, err := token.SignedString(stded25519.PrivateKey(a.keyPair.PrivateKey[:]))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseToken verifies the signature and the validity period of the token and returns its claims
+func (a *JWTAuth) ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodEdDSA {
+			return nil, xerrors.Errorf("unexpected signing method %s", token.Header["alg"])
+		}
+		return stded25519.PublicKey(a.keyPair.PublicKey[:]), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RequireScope returns a middleware that rejects the requests not granted the given scope,
+// either by the bearer token in the Authorization header or by the public scopes
+func (a *JWTAuth) RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				if grantsScope(a.publicScopes, scope) {
+					return next(c)
+				}
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing token")
+			}
+			const prefix = "Bearer "
+			if !strings.HasPrefix(header, prefix) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header")
+			}
+			claims, err := a.ParseToken(strings.TrimPrefix(header, prefix))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+			}
+			if !claims.HasScope(scope) && !grantsScope(a.publicScopes, scope) {
+				return echo.NewHTTPError(http.StatusForbidden, "token does not grant the "+scope+" scope")
+			}
+			return next(c)
+		}
+	}
+}
+
+// grantsScope returns true if the scopes contain the given scope or the admin scope
+func grantsScope(scopes []string, scope string) bool {
+	return hasScope(scopes, scope) || hasScope(scopes, ScopeAdmin)
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJWTAuth(t *testing.T, duration time.Duration, publicScopes ...string) *JWTAuth {
+	keyPair := ed25519.GenerateKeyPair()
+	a, err := NewJWTAuth(&keyPair, duration, map[string]*User{
+		"reader": {Password: "r", Scopes: []string{ScopeRead}},
+		"admin":  {Password: "a", Scopes: []string{ScopeRead, ScopeWriteRequests, ScopeAdmin}},
+	}, publicScopes)
+	require.NoError(t, err)
+	return a
+}
+
+func TestJWTLogin(t *testing.T) {
+	a := newTestJWTAuth(t, time.Hour)
+
+	_, _, err := a.Login("reader", "wrong")
+	require.Error(t, err)
+	_, _, err = a.Login("nobody", "r")
+	require.Error(t, err)
+
+	token, expiresAt, err := a.Login("reader", "r")
+	require.NoError(t, err)
+	require.True(t, expiresAt.After(time.Now()))
+
+	claims, err := a.ParseToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "reader", claims.Subject)
+	require.True(t, claims.HasScope(ScopeRead))
+	require.False(t, claims.HasScope(ScopeAdmin))
+}
+
+func TestJWTInvalidToken(t *testing.T) {
+	a := newTestJWTAuth(t, time.Hour)
+	other := newTestJWTAuth(t, time.Hour)
+
+	token, _, err := other.IssueToken("admin", []string{ScopeAdmin})
+	require.NoError(t, err)
+	_, err = a.ParseToken(token)
+	require.Error(t, err)
+
+	expired := newTestJWTAuth(t, -time.Minute)
+	token, _, err = expired.IssueToken("admin", []string{ScopeAdmin})
+	require.NoError(t, err)
+	_, err = expired.ParseToken(token)
+	require.Error(t, err)
+}
+
+func TestJWTUnknownScope(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	_, err := NewJWTAuth(&keyPair, time.Hour, map[string]*User{
+		"user": {Password: "p", Scopes: []string{"superuser"}},
+	}, nil)
+	require.Error(t, err)
+
+	_, err = NewJWTAuth(&keyPair, time.Hour, nil, []string{"superuser"})
+	require.Error(t, err)
+}
+
+func TestUsersFromConfig(t *testing.T) {
+	users := UsersFromConfig(
+		map[string]string{"alice": "pw", "bob": "pw2"},
+		map[string]string{"alice": "read, write-requests"},
+	)
+	require.Len(t, users, 2)
+	require.Equal(t, []string{ScopeRead, ScopeWriteRequests}, users["alice"].Scopes)
+	require.Empty(t, users["bob"].Scopes)
+}
+
+func TestJWTRequireScope(t *testing.T) {
+	a := newTestJWTAuth(t, time.Hour, ScopeRead)
+
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/read", ok, a.RequireScope(ScopeRead))
+	e.GET("/admin", ok, a.RequireScope(ScopeAdmin))
+
+	readerToken, _, err := a.Login("reader", "r")
+	require.NoError(t, err)
+	adminToken, _, err := a.Login("admin", "a")
+	require.NoError(t, err)
+
+	check := func(path, token string, expectedStatus int) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, expectedStatus, rec.Code, "%s with token %q", path, token)
+	}
+
+	check("/read", "", http.StatusOK)
+	check("/admin", "", http.StatusUnauthorized)
+	check("/admin", "garbage", http.StatusUnauthorized)
+	check("/admin", readerToken, http.StatusForbidden)
+	check("/admin", adminToken, http.StatusOK)
+	check("/read", readerToken, http.StatusOK)
+
+	// the admin scope implies the other scopes
+	adminOnlyToken, _, err := a.IssueToken("admin", []string{ScopeAdmin})
+	require.NoError(t, err)
+	check("/read", adminOnlyToken, http.StatusOK)
+	check("/admin", adminOnlyToken, http.StatusOK)
+}
@@ -48,7 +48,7 @@ func TestCreditDebit1(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp1")
 
 	require.NotNil(t, total)
@@ -56,7 +56,7 @@ func TestCreditDebit1(t *testing.T) {
 	require.True(t, total.Equals(transfer))
 
 	transfer = colored.NewBalancesForIotas(1).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp2")
 
 	expected := colored.NewBalancesForIotas(43).Add(dummyColor, 4)
@@ -66,7 +66,7 @@ func TestCreditDebit1(t *testing.T) {
 	require.EqualValues(t, 4, GetBalance(state, agentID1, dummyColor))
 	checkLedger(t, state, "cp2")
 
-	DebitFromAccount(state, agentID1, expected)
+	DebitFromAccount(state, agentID1, expected, nil)
 	total = checkLedger(t, state, "cp3")
 	expected = colored.NewBalances()
 	require.True(t, expected.Equals(total))
@@ -80,7 +80,7 @@ func TestCreditDebit2(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp1")
 
 	expected := transfer
@@ -88,7 +88,7 @@ func TestCreditDebit2(t *testing.T) {
 	require.True(t, expected.Equals(total))
 
 	transfer = colored.NewBalancesForColor(dummyColor, 2)
-	DebitFromAccount(state, agentID1, transfer)
+	DebitFromAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp2")
 	require.EqualValues(t, 1, len(total))
 	expected = colored.NewBalancesForIotas(42)
@@ -108,7 +108,7 @@ func TestCreditDebit3(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp1")
 
 	expected := transfer
@@ -116,7 +116,7 @@ func TestCreditDebit3(t *testing.T) {
 	require.True(t, expected.Equals(total))
 
 	transfer = colored.NewBalancesForColor(dummyColor, 100)
-	ok := DebitFromAccount(state, agentID1, transfer)
+	ok := DebitFromAccount(state, agentID1, transfer, nil)
 	require.False(t, ok)
 	total = checkLedger(t, state, "cp2")
 
@@ -133,7 +133,7 @@ func TestCreditDebit4(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp1")
 
 	expected := transfer
@@ -147,7 +147,7 @@ func TestCreditDebit4(t *testing.T) {
 	require.NotEqualValues(t, agentID1, agentID2)
 
 	transfer = colored.NewBalancesForIotas(20)
-	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer)
+	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer, nil)
 	require.True(t, ok)
 	total = checkLedger(t, state, "cp2")
 
@@ -176,7 +176,7 @@ func TestCreditDebit5(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	total = checkLedger(t, state, "cp1")
 
 	expected := transfer
@@ -190,7 +190,7 @@ func TestCreditDebit5(t *testing.T) {
 	require.NotEqualValues(t, agentID1, agentID2)
 
 	transfer = colored.NewBalancesForIotas(50)
-	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer)
+	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer, nil)
 	require.False(t, ok)
 	total = checkLedger(t, state, "cp2")
 
@@ -216,13 +216,13 @@ func TestCreditDebit6(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForIotas(42).Add(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	checkLedger(t, state, "cp1")
 
 	agentID2 := iscp.NewRandomAgentID()
 	require.NotEqualValues(t, agentID1, agentID2)
 
-	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer)
+	ok := MoveBetweenAccounts(state, agentID1, agentID2, transfer, nil)
 	require.True(t, ok)
 	total = checkLedger(t, state, "cp2")
 
@@ -245,14 +245,121 @@ func TestCreditDebit7(t *testing.T) {
 
 	agentID1 := iscp.NewRandomAgentID()
 	transfer := colored.NewBalancesForColor(dummyColor, 2)
-	CreditToAccount(state, agentID1, transfer)
+	CreditToAccount(state, agentID1, transfer, nil)
 	checkLedger(t, state, "cp1")
 
 	debitTransfer := colored.NewBalancesForIotas(1)
 	// debit must fail
-	ok := DebitFromAccount(state, agentID1, debitTransfer)
+	ok := DebitFromAccount(state, agentID1, debitTransfer, nil)
 	require.False(t, ok)
 
 	total = checkLedger(t, state, "cp1")
 	require.True(t, transfer.Equals(total))
 }
+
+func TestHistory(t *testing.T) {
+	curTest = "TestHistory"
+	state := dict.New()
+	agentID1 := iscp.NewRandomAgentID()
+	agentID2 := iscp.NewRandomAgentID()
+
+	// without the context nothing is recorded
+	CreditToAccount(state, agentID1, colored.NewBalancesForIotas(42), nil)
+	require.Zero(t, GetHistoryLength(state, agentID1))
+
+	hctx := &HistoryContext{BlockIndex: 5, RequestID: iscp.RequestID{1, 2, 3}}
+	require.True(t, MoveBetweenAccounts(state, agentID1, agentID2, colored.NewBalancesForIotas(10), hctx))
+	require.True(t, DebitFromAccount(state, agentID2, colored.NewBalancesForIotas(3), hctx))
+	require.False(t, DebitFromAccount(state, agentID2, colored.NewBalancesForIotas(100), hctx))
+	checkLedger(t, state, "cp1")
+
+	require.EqualValues(t, 1, GetHistoryLength(state, agentID1))
+	require.EqualValues(t, 2, GetHistoryLength(state, agentID2))
+
+	entries, err := GetHistory(state, agentID2, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.False(t, entries[0].Credit)
+	require.Nil(t, entries[0].Counterparty)
+	require.EqualValues(t, 3, entries[0].Balances.Get(colored.IOTA))
+	require.True(t, entries[1].Credit)
+	require.True(t, entries[1].Counterparty.Equals(agentID1))
+	require.EqualValues(t, hctx.BlockIndex, entries[1].BlockIndex)
+	require.Equal(t, hctx.RequestID, entries[1].RequestID)
+
+	back, err := HistoryEntryFromBytes(entries[1].Bytes())
+	require.NoError(t, err)
+	require.Equal(t, entries[1].Bytes(), back.Bytes())
+
+	entries, err = GetHistory(state, agentID2, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.True(t, entries[0].Credit)
+
+	entries, err = GetHistory(state, agentID2, 2, 10)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	CreditToAccount(state, agentID1, colored.NewBalancesForIotas(1), nil)
+	require.EqualValues(t, 1, GetHistoryLength(state, agentID1))
+}
+
+func TestHistoryLimit(t *testing.T) {
+	curTest = "TestHistoryLimit"
+	state := dict.New()
+	agentID := iscp.NewRandomAgentID()
+
+	// only the latest entries are kept
+	extra := uint32(5)
+	for i := uint32(0); i < MaxHistoryEntriesPerAccount+extra; i++ {
+		CreditToAccount(state, agentID, colored.NewBalancesForIotas(1), &HistoryContext{BlockIndex: i})
+	}
+	require.EqualValues(t, MaxHistoryEntriesPerAccount, GetHistoryLength(state, agentID))
+	require.EqualValues(t, MaxHistoryEntriesPerAccount, getHistoryR(state, agentID).MustLen())
+
+	entries, err := GetHistory(state, agentID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.EqualValues(t, MaxHistoryEntriesPerAccount+extra-1, entries[0].BlockIndex)
+	require.EqualValues(t, MaxHistoryEntriesPerAccount+extra-2, entries[1].BlockIndex)
+
+	entries, err = GetHistory(state, agentID, MaxHistoryEntriesPerAccount-1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.EqualValues(t, extra, entries[0].BlockIndex)
+}
+
+func TestAllowance(t *testing.T) {
+	curTest = "TestAllowance"
+	state := dict.New()
+	owner := iscp.NewRandomAgentID()
+	spender := iscp.NewRandomAgentID()
+	target := iscp.NewRandomAgentID()
+
+	CreditToAccount(state, owner, colored.Balances{colored.IOTA: 42, dummyColor: 2}, nil)
+	require.True(t, GetAllowance(state, owner, spender).IsEmpty())
+	require.False(t, MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForIotas(1), nil))
+
+	SetAllowance(state, owner, spender, colored.IOTA, 10)
+	SetAllowance(state, owner, spender, dummyColor, 5)
+	require.True(t, GetAllowance(state, owner, spender).Equals(colored.Balances{colored.IOTA: 10, dummyColor: 5}))
+
+	require.True(t, MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForIotas(7), nil))
+	require.EqualValues(t, 3, GetAllowance(state, owner, spender).Get(colored.IOTA))
+	require.EqualValues(t, 35, GetBalance(state, owner, colored.IOTA))
+	require.EqualValues(t, 7, GetBalance(state, target, colored.IOTA))
+
+	// over the allowance
+	require.False(t, MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForIotas(4), nil))
+	// within the allowance, but the owner doesn't have enough tokens
+	require.False(t, MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForColor(dummyColor, 3), nil))
+	require.EqualValues(t, 5, GetAllowance(state, owner, spender).Get(dummyColor))
+
+	// the allowance for the color is removed when it is spent
+	require.True(t, MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForIotas(3), nil))
+	require.True(t, GetAllowance(state, owner, spender).Equals(colored.NewBalancesForColor(dummyColor, 5)))
+	checkLedger(t, state, "cp1")
+
+	RevokeAllowance(state, owner, spender)
+	require.True(t, GetAllowance(state, owner, spender).IsEmpty())
+}
@@ -0,0 +1,70 @@
+package accounts
+
+import (
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+// The owner of an account can approve a spender to move tokens from the account.
+// The allowance is a limit per color, it is decreased by each move made by the spender
+
+const prefixAllowance = "w"
+
+func getAllowance(state kv.KVStore, owner, spender *iscp.AgentID) *collections.Map {
+	return collections.NewMap(state, prefixAllowance+string(owner.Bytes())+string(spender.Bytes()))
+}
+
+func getAllowanceR(state kv.KVStoreReader, owner, spender *iscp.AgentID) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixAllowance+string(owner.Bytes())+string(spender.Bytes()))
+}
+
+// SetAllowance sets the amount of tokens of the color the spender can move from the account of the owner.
+// Zero amount removes the allowance for the color
+func SetAllowance(state kv.KVStore, owner, spender *iscp.AgentID, col colored.Color, amount uint64) {
+	allowance := getAllowance(state, owner, spender)
+	if amount == 0 {
+		allowance.MustDelAt(col[:])
+		return
+	}
+	allowance.MustSetAt(col[:], util.Uint64To8Bytes(amount))
+}
+
+// RevokeAllowance removes the allowance of the spender for all colors
+func RevokeAllowance(state kv.KVStore, owner, spender *iscp.AgentID) {
+	getAllowance(state, owner, spender).Erase()
+}
+
+// GetAllowance returns the amounts of tokens the spender can move from the account of the owner
+func GetAllowance(state kv.KVStoreReader, owner, spender *iscp.AgentID) colored.Balances {
+	return getAccountBalances(getAllowanceR(state, owner, spender))
+}
+
+// MoveWithAllowance moves tokens from the account of the owner to the target account on behalf of the spender.
+// The move is only made if it is within the allowance of the spender and the owner has enough tokens.
+// The allowance is decreased by the moved tokens
+func MoveWithAllowance(state kv.KVStore, owner, spender, target *iscp.AgentID, transfer colored.Balances, hctx *HistoryContext) bool {
+	allowance := GetAllowance(state, owner, spender)
+	ok := true
+	transfer.ForEachSorted(func(col colored.Color, bal uint64) bool {
+		if allowance.Get(col) < bal {
+			ok = false
+			return false
+		}
+		allowance.SubNoOverflow(col, bal)
+		return true
+	})
+	if !ok {
+		return false
+	}
+	if !MoveBetweenAccounts(state, owner, target, transfer, hctx) {
+		return false
+	}
+	transfer.ForEachSorted(func(col colored.Color, _ uint64) bool {
+		SetAllowance(state, owner, spender, col, allowance.Get(col))
+		return true
+	})
+	return true
+}
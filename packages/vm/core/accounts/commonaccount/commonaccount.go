@@ -4,7 +4,10 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp"
 )
 
-var coreHnames = make(map[iscp.Hname]struct{})
+var (
+	coreHnames       = make(map[iscp.Hname]struct{})
+	ownAccountHnames = make(map[iscp.Hname]struct{})
+)
 
 func SetCoreHname(hname iscp.Hname) {
 	coreHnames[hname] = struct{}{}
@@ -15,13 +18,25 @@ func IsCoreHname(hname iscp.Hname) bool {
 	return ret
 }
 
-// AdjustIfNeeded makes account of the chain owner and all core contracts equal to (chainID, 0)
+// SetOwnAccount makes the core contract keep its tokens in its own account instead of the common account
+func SetOwnAccount(hname iscp.Hname) {
+	ownAccountHnames[hname] = struct{}{}
+}
+
+// HasOwnAccount is true if the core contract keeps its tokens in its own account
+func HasOwnAccount(hname iscp.Hname) bool {
+	_, ret := ownAccountHnames[hname]
+	return ret
+}
+
+// AdjustIfNeeded makes account of the chain owner and all core contracts equal to (chainID, 0),
+// except for the core contracts which have their own account
 func AdjustIfNeeded(agentID *iscp.AgentID, chainID *iscp.ChainID) *iscp.AgentID {
 	if !agentID.Address().Equals(chainID.AsAddress()) {
 		// from another chain
 		return agentID
 	}
-	if IsCoreHname(agentID.Hname()) {
+	if IsCoreHname(agentID.Hname()) && !HasOwnAccount(agentID.Hname()) {
 		// one of core contracts
 		return Get(chainID)
 	}
@@ -0,0 +1,184 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"golang.org/x/xerrors"
+)
+
+// The history of the accounts is kept only when it is enabled in the governance contract.
+// The VM passes the block index and the ID of the current request as the history context: while it is set,
+// each credit and debit of an account is appended to the history of the account.
+// The history of each account is a ring buffer of the latest MaxHistoryEntriesPerAccount entries
+
+const (
+	prefixHistory      = "h"
+	prefixHistoryCount = "g"
+
+	// MaxHistoryEntriesPerAccount is the number of the latest history entries kept for each account
+	MaxHistoryEntriesPerAccount = uint32(1000)
+	// MaxHistoryEntriesPerView is the maximum number of history entries returned by the getAccountHistory view
+	MaxHistoryEntriesPerView = uint32(100)
+)
+
+// HistoryContext identifies the request which changes the balances of the accounts.
+// A nil context means the history is disabled
+type HistoryContext struct {
+	BlockIndex uint32
+	RequestID  iscp.RequestID
+}
+
+// historySandbox is implemented by the sandbox of the VM, which passes the history context to the accounts contract
+type historySandbox interface {
+	AccountHistoryContext() *HistoryContext
+}
+
+func getHistoryContext(ctx iscp.Sandbox) *HistoryContext {
+	if hs, ok := ctx.(historySandbox); ok {
+		return hs.AccountHistoryContext()
+	}
+	return nil
+}
+
+// HistoryEntry is a record of a credit to or a debit from an account
+type HistoryEntry struct {
+	BlockIndex uint32
+	RequestID  iscp.RequestID
+	Credit     bool
+	// Counterparty is the account the tokens were moved from or to.
+	// It is nil if the tokens came to the chain with the request or were sent out of the chain
+	Counterparty *iscp.AgentID
+	Balances     colored.Balances
+}
+
+func (e *HistoryEntry) Bytes() []byte {
+	mu := marshalutil.New()
+	mu.WriteUint32(e.BlockIndex).
+		Write(e.RequestID).
+		WriteBool(e.Credit).
+		WriteBool(e.Counterparty != nil)
+	if e.Counterparty != nil {
+		mu.Write(e.Counterparty)
+	}
+	mu.Write(e.Balances)
+	return mu.Bytes()
+}
+
+func HistoryEntryFromBytes(data []byte) (*HistoryEntry, error) {
+	mu := marshalutil.New(data)
+	ret := &HistoryEntry{}
+	var err error
+	if ret.BlockIndex, err = mu.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if ret.RequestID, err = iscp.RequestIDFromMarshalUtil(mu); err != nil {
+		return nil, err
+	}
+	if ret.Credit, err = mu.ReadBool(); err != nil {
+		return nil, err
+	}
+	hasCounterparty, err := mu.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+	if hasCounterparty {
+		if ret.Counterparty, err = iscp.AgentIDFromMarshalUtil(mu); err != nil {
+			return nil, err
+		}
+	}
+	if ret.Balances, err = colored.BalancesFromMarshalUtil(mu); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *HistoryEntry) String() string {
+	kind := "debit"
+	if e.Credit {
+		kind = "credit"
+	}
+	counterparty := "L1"
+	if e.Counterparty != nil {
+		counterparty = e.Counterparty.String()
+	}
+	return fmt.Sprintf("%s block: %d, request: %s, counterparty: %s, balances: %s",
+		kind, e.BlockIndex, e.RequestID.Base58(), counterparty, e.Balances.String())
+}
+
+func historyKey(agentID *iscp.AgentID) string {
+	return string(agentID.Bytes())
+}
+
+func getHistory(state kv.KVStore, agentID *iscp.AgentID) *collections.Map {
+	return collections.NewMap(state, prefixHistory+historyKey(agentID))
+}
+
+func getHistoryR(state kv.KVStoreReader, agentID *iscp.AgentID) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixHistory+historyKey(agentID))
+}
+
+// getHistoryCount returns the number of entries ever recorded in the history of the account
+func getHistoryCount(state kv.KVStoreReader, agentID *iscp.AgentID) uint32 {
+	ret, err := codec.DecodeUint32(state.MustGet(kv.Key(prefixHistoryCount+historyKey(agentID))), 0)
+	if err != nil {
+		panic(xerrors.Errorf("getHistoryCount: %w", err))
+	}
+	return ret
+}
+
+// recordHistory appends the entry to the history of the account, if the history is enabled.
+// When the history is full, the oldest entry is overwritten
+func recordHistory(state kv.KVStore, hctx *HistoryContext, agentID *iscp.AgentID, credit bool, counterparty *iscp.AgentID, balances colored.Balances) {
+	if hctx == nil || balances.IsEmpty() {
+		return
+	}
+	entry := &HistoryEntry{
+		BlockIndex:   hctx.BlockIndex,
+		RequestID:    hctx.RequestID,
+		Credit:       credit,
+		Counterparty: counterparty,
+		Balances:     balances.Clone(),
+	}
+	count := getHistoryCount(state, agentID)
+	getHistory(state, agentID).MustSetAt(codec.EncodeUint32(count%MaxHistoryEntriesPerAccount), entry.Bytes())
+	state.Set(kv.Key(prefixHistoryCount+historyKey(agentID)), codec.EncodeUint32(count+1))
+}
+
+// GetHistoryLength returns the number of entries in the history of the account
+func GetHistoryLength(state kv.KVStoreReader, agentID *iscp.AgentID) uint32 {
+	count := getHistoryCount(state, agentID)
+	if count > MaxHistoryEntriesPerAccount {
+		return MaxHistoryEntriesPerAccount
+	}
+	return count
+}
+
+// GetHistory returns at most 'limit' entries of the history of the account, the newest first.
+// The 'offset' newest entries are skipped
+func GetHistory(state kv.KVStoreReader, agentID *iscp.AgentID, offset, limit uint32) ([]*HistoryEntry, error) {
+	history := getHistoryR(state, agentID)
+	count := getHistoryCount(state, agentID)
+	length := GetHistoryLength(state, agentID)
+	if offset >= length {
+		return nil, nil
+	}
+	if limit > length-offset {
+		limit = length - offset
+	}
+	ret := make([]*HistoryEntry, limit)
+	for i := range ret {
+		seq := count - offset - uint32(i) - 1
+		entry, err := HistoryEntryFromBytes(history.MustGetAt(codec.EncodeUint32(seq % MaxHistoryEntriesPerAccount)))
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = entry
+	}
+	return ret, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/assert"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts/commonaccount"
@@ -20,6 +21,11 @@ var Processor = Contract.Processor(initialize,
 	FuncWithdraw.WithHandler(withdraw),
 	FuncHarvest.WithHandler(harvest),
 	FuncGetAccountNonce.WithHandler(getAccountNonce),
+	FuncGetAccountHistory.WithHandler(getAccountHistory),
+	FuncApprove.WithHandler(approve),
+	FuncRevoke.WithHandler(revoke),
+	FuncTransferFrom.WithHandler(transferFrom),
+	FuncGetAllowance.WithHandler(viewAllowance),
 )
 
 // initialize the init call
@@ -69,7 +75,7 @@ func deposit(ctx iscp.Sandbox) (dict.Dict, error) {
 	targetAccount = commonaccount.AdjustIfNeeded(targetAccount, ctx.ChainID())
 
 	// funds currently are in the common account (because call is to 'accounts'), they must be moved to the target
-	succ := MoveBetweenAccounts(ctx.State(), commonaccount.Get(ctx.ChainID()), targetAccount, ctx.IncomingTransfer())
+	succ := MoveBetweenAccounts(ctx.State(), commonaccount.Get(ctx.ChainID()), targetAccount, ctx.IncomingTransfer(), getHistoryContext(ctx))
 	assert.NewAssert(ctx.Log()).Require(succ, "internal error: failed to deposit to %s", targetAccount.String())
 
 	ctx.Log().Debugf("accounts.deposit.success: target: %s\n%s",
@@ -96,7 +102,7 @@ func withdraw(ctx iscp.Sandbox) (dict.Dict, error) {
 	// will be sending back to default entry point
 	a := assert.NewAssert(ctx.Log())
 	// bring balances to the current account (owner's account). It is needed for subsequent Send call
-	a.Require(MoveBetweenAccounts(state, ctx.Caller(), commonaccount.Get(ctx.ChainID()), tokensToWithdraw),
+	a.Require(MoveBetweenAccounts(state, ctx.Caller(), commonaccount.Get(ctx.ChainID()), tokensToWithdraw, getHistoryContext(ctx)),
 		"accounts.withdraw.inconsistency. failed to move tokens to owner's account")
 
 	// add incoming tokens (after fees) to the balances to be withdrawn. Otherwise they would end up in the common account
@@ -147,7 +153,7 @@ func harvest(ctx iscp.Sandbox) (dict.Dict, error) {
 		a.Require(balCol >= amount, "accounts.harvest.error: not enough tokens")
 		tokensToSend = colored.NewBalancesForColor(col, amount)
 	}
-	a.Require(MoveBetweenAccounts(state, sourceAccount, ctx.Caller(), tokensToSend),
+	a.Require(MoveBetweenAccounts(state, sourceAccount, ctx.Caller(), tokensToSend, getHistoryContext(ctx)),
 		"accounts.harvest.inconsistency. failed to move tokens to owner's account")
 	return nil, nil
 }
@@ -160,3 +166,122 @@ func getAccountNonce(ctx iscp.SandboxView) (dict.Dict, error) {
 	ret.Set(ParamAccountNonce, codec.EncodeUint64(nonce))
 	return ret, nil
 }
+
+// getAccountHistory returns a page of the history of the account, the newest entries first.
+// The history is only kept while it is enabled in the governance contract
+// Params:
+// - ParamAgentID
+// - ParamHistoryOffset uint32 number of newest entries to skip, default 0
+// - ParamHistoryLimit uint32 maximum number of entries to return, default and maximum MaxHistoryEntriesPerView
+// Returns:
+// - ParamHistoryLength uint32 total number of entries in the history of the account
+// - ParamHistoryEntries array of HistoryEntry bytes
+func getAccountHistory(ctx iscp.SandboxView) (dict.Dict, error) {
+	par := kvdecoder.New(ctx.Params(), ctx.Log())
+	account := par.MustGetAgentID(ParamAgentID)
+	offset := par.MustGetUint32(ParamHistoryOffset, 0)
+	limit := par.MustGetUint32(ParamHistoryLimit, MaxHistoryEntriesPerView)
+	if limit == 0 || limit > MaxHistoryEntriesPerView {
+		limit = MaxHistoryEntriesPerView
+	}
+	entries, err := GetHistory(ctx.State(), account, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	ret := dict.New()
+	ret.Set(ParamHistoryLength, codec.EncodeUint32(GetHistoryLength(ctx.State(), account)))
+	arr := collections.NewArray16(ret, ParamHistoryEntries)
+	for _, entry := range entries {
+		arr.MustPush(entry.Bytes())
+	}
+	return ret, nil
+}
+
+// depositIncomingToCaller moves the tokens sent with the call from the common account to the account of the caller
+func depositIncomingToCaller(ctx iscp.Sandbox) {
+	caller := commonaccount.AdjustIfNeeded(ctx.Caller(), ctx.ChainID())
+	assert.NewAssert(ctx.Log()).Require(
+		MoveBetweenAccounts(ctx.State(), commonaccount.Get(ctx.ChainID()), caller, ctx.IncomingTransfer(), getHistoryContext(ctx)),
+		"internal error: failed to deposit to %s", caller.String())
+}
+
+// approve sets the amount of tokens of the color the spender can move from the account of the caller.
+// It replaces the previous allowance for the color. The tokens sent with the call are deposited to the caller
+// Params:
+// - ParamSpender AgentID
+// - ParamColor color of the allowance, default colored.IOTA
+// - ParamAmount uint64 allowance, 0 removes the allowance for the color
+func approve(ctx iscp.Sandbox) (dict.Dict, error) {
+	par := kvdecoder.New(ctx.Params(), ctx.Log())
+	spender := commonaccount.AdjustIfNeeded(par.MustGetAgentID(ParamSpender), ctx.ChainID())
+	col := par.MustGetColor(ParamColor, colored.IOTA)
+	amount := par.MustGetUint64(ParamAmount)
+
+	depositIncomingToCaller(ctx)
+	owner := commonaccount.AdjustIfNeeded(ctx.Caller(), ctx.ChainID())
+	SetAllowance(ctx.State(), owner, spender, col, amount)
+	ctx.Event(fmt.Sprintf("[approve] owner: %s, spender: %s, color: %s, amount: %d", owner, spender, col, amount))
+	return nil, nil
+}
+
+// revoke removes the allowance of the spender to move tokens from the account of the caller.
+// The tokens sent with the call are deposited to the caller
+// Params:
+// - ParamSpender AgentID
+// - ParamColor color of the allowance to remove, default is all colors
+func revoke(ctx iscp.Sandbox) (dict.Dict, error) {
+	par := kvdecoder.New(ctx.Params(), ctx.Log())
+	spender := commonaccount.AdjustIfNeeded(par.MustGetAgentID(ParamSpender), ctx.ChainID())
+
+	depositIncomingToCaller(ctx)
+	owner := commonaccount.AdjustIfNeeded(ctx.Caller(), ctx.ChainID())
+	if ctx.Params().MustHas(ParamColor) {
+		col := par.MustGetColor(ParamColor)
+		SetAllowance(ctx.State(), owner, spender, col, 0)
+		ctx.Event(fmt.Sprintf("[revoke] owner: %s, spender: %s, color: %s", owner, spender, col))
+		return nil, nil
+	}
+	RevokeAllowance(ctx.State(), owner, spender)
+	ctx.Event(fmt.Sprintf("[revoke] owner: %s, spender: %s", owner, spender))
+	return nil, nil
+}
+
+// transferFrom moves tokens from the account of the owner to the target account.
+// The caller is the spender, the tokens are taken from its allowance.
+// The tokens sent with the call are deposited to the caller
+// Params:
+// - ParamOwner AgentID
+// - ParamTarget AgentID, default is the caller
+// - ParamColor color of the tokens, default colored.IOTA
+// - ParamAmount uint64 amount of the tokens
+func transferFrom(ctx iscp.Sandbox) (dict.Dict, error) {
+	state := ctx.State()
+	mustCheckLedger(state, "accounts.transferFrom.begin")
+	defer mustCheckLedger(state, "accounts.transferFrom.exit")
+
+	par := kvdecoder.New(ctx.Params(), ctx.Log())
+	owner := commonaccount.AdjustIfNeeded(par.MustGetAgentID(ParamOwner), ctx.ChainID())
+	target := par.MustGetAgentID(ParamTarget, ctx.Caller())
+	target = commonaccount.AdjustIfNeeded(target, ctx.ChainID())
+	col := par.MustGetColor(ParamColor, colored.IOTA)
+	amount := par.MustGetUint64(ParamAmount)
+
+	a := assert.NewAssert(ctx.Log())
+	a.Require(amount > 0, "accounts.transferFrom.error: amount must be positive")
+	depositIncomingToCaller(ctx)
+	spender := commonaccount.AdjustIfNeeded(ctx.Caller(), ctx.ChainID())
+	a.Require(MoveWithAllowance(state, owner, spender, target, colored.NewBalancesForColor(col, amount), getHistoryContext(ctx)),
+		"accounts.transferFrom.error: allowance exceeded or not enough tokens")
+	return nil, nil
+}
+
+// viewAllowance returns the amounts of tokens the spender can move from the account of the owner
+// Params:
+// - ParamOwner AgentID
+// - ParamSpender AgentID
+func viewAllowance(ctx iscp.SandboxView) (dict.Dict, error) {
+	par := kvdecoder.New(ctx.Params(), ctx.Log())
+	owner := commonaccount.AdjustIfNeeded(par.MustGetAgentID(ParamOwner), ctx.ChainID())
+	spender := commonaccount.AdjustIfNeeded(par.MustGetAgentID(ParamSpender), ctx.ChainID())
+	return EncodeBalances(GetAllowance(ctx.State(), owner, spender)), nil
+}
@@ -7,13 +7,18 @@ import (
 var Contract = coreutil.NewContract(coreutil.CoreContractAccounts, "Chain account ledger contract")
 
 var (
-	FuncViewBalance     = coreutil.ViewFunc("balance")
-	FuncViewTotalAssets = coreutil.ViewFunc("totalAssets")
-	FuncViewAccounts    = coreutil.ViewFunc("accounts")
-	FuncDeposit         = coreutil.Func("deposit")
-	FuncWithdraw        = coreutil.Func("withdraw")
-	FuncHarvest         = coreutil.Func("harvest")
-	FuncGetAccountNonce = coreutil.ViewFunc("getAccountNonce")
+	FuncViewBalance       = coreutil.ViewFunc("balance")
+	FuncViewTotalAssets   = coreutil.ViewFunc("totalAssets")
+	FuncViewAccounts      = coreutil.ViewFunc("accounts")
+	FuncDeposit           = coreutil.Func("deposit")
+	FuncWithdraw          = coreutil.Func("withdraw")
+	FuncHarvest           = coreutil.Func("harvest")
+	FuncGetAccountNonce   = coreutil.ViewFunc("getAccountNonce")
+	FuncGetAccountHistory = coreutil.ViewFunc("getAccountHistory")
+	FuncApprove           = coreutil.Func("approve")
+	FuncRevoke            = coreutil.Func("revoke")
+	FuncTransferFrom      = coreutil.Func("transferFrom")
+	FuncGetAllowance      = coreutil.ViewFunc("getAllowance")
 )
 
 const (
@@ -21,4 +26,13 @@ const (
 	ParamWithdrawColor  = "c"
 	ParamWithdrawAmount = "m"
 	ParamAccountNonce   = "n"
+	ParamHistoryOffset  = "o"
+	ParamHistoryLimit   = "l"
+	ParamHistoryLength  = "hl"
+	ParamHistoryEntries = "he"
+	ParamOwner          = "ow"
+	ParamSpender        = "sp"
+	ParamTarget         = "t"
+	ParamColor          = "c"
+	ParamAmount         = "m"
 )
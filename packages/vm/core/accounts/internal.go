@@ -43,12 +43,14 @@ func getTotalAssetsAccountR(state kv.KVStoreReader) *collections.ImmutableMap {
 }
 
 // CreditToAccount brings new funds to the on chain ledger.
-func CreditToAccount(state kv.KVStore, agentID *iscp.AgentID, transfer colored.Balances) {
+// The credit is recorded in the history of the account if the history context is not nil
+func CreditToAccount(state kv.KVStore, agentID *iscp.AgentID, transfer colored.Balances, hctx *HistoryContext) {
 	mustCheckLedger(state, "CreditToAccount IN")
 	defer mustCheckLedger(state, "CreditToAccount OUT")
 
 	creditToAccount(state, getAccount(state, agentID), transfer)
 	creditToAccount(state, getTotalAssetsAccount(state), transfer)
+	recordHistory(state, hctx, agentID, true, nil, transfer)
 }
 
 // creditToAccount internal
@@ -68,7 +70,8 @@ func creditToAccount(state kv.KVStore, account *collections.Map, transfer colore
 }
 
 // DebitFromAccount removes funds from the chain ledger.
-func DebitFromAccount(state kv.KVStore, agentID *iscp.AgentID, transfer colored.Balances) bool {
+// The debit is recorded in the history of the account if the history context is not nil
+func DebitFromAccount(state kv.KVStore, agentID *iscp.AgentID, transfer colored.Balances, hctx *HistoryContext) bool {
 	mustCheckLedger(state, "DebitFromAccount IN")
 	defer mustCheckLedger(state, "DebitFromAccount OUT")
 
@@ -78,6 +81,7 @@ func DebitFromAccount(state kv.KVStore, agentID *iscp.AgentID, transfer colored.
 	if !debitFromAccount(state, getTotalAssetsAccount(state), transfer) {
 		panic("debitFromAccount: inconsistent accounts ledger state")
 	}
+	recordHistory(state, hctx, agentID, false, nil, transfer)
 	return true
 }
 
@@ -111,7 +115,7 @@ func debitFromAccount(state kv.KVStore, account *collections.Map, transfer color
 	return true
 }
 
-func MoveBetweenAccounts(state kv.KVStore, fromAgentID, toAgentID *iscp.AgentID, transfer colored.Balances) bool {
+func MoveBetweenAccounts(state kv.KVStore, fromAgentID, toAgentID *iscp.AgentID, transfer colored.Balances, hctx *HistoryContext) bool {
 	mustCheckLedger(state, "MoveBetweenAccounts.IN")
 	defer mustCheckLedger(state, "MoveBetweenAccounts.OUT")
 	if fromAgentID.Equals(toAgentID) {
@@ -123,6 +127,8 @@ func MoveBetweenAccounts(state kv.KVStore, fromAgentID, toAgentID *iscp.AgentID,
 		return false
 	}
 	creditToAccount(state, getAccount(state, toAgentID), transfer)
+	recordHistory(state, hctx, fromAgentID, false, toAgentID, transfer)
+	recordHistory(state, hctx, toAgentID, true, fromAgentID, transfer)
 	return true
 }
 
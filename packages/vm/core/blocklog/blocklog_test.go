@@ -15,11 +15,15 @@ func TestSerdeRequestLogRecord(t *testing.T) {
 	rand.Read(txid[:])
 	req := request.NewOffLedger(iscp.RandomChainID(), iscp.Hn("0"), iscp.Hn("0"), nil)
 	rec := &RequestReceipt{
-		Request: req,
-		Error:   "some log data",
+		Request:   req,
+		Error:     "some log data",
+		GasBudget: 1000,
+		GasBurned: 500,
 	}
 	forward := rec.Bytes()
 	back, err := RequestReceiptFromBytes(forward)
 	require.NoError(t, err)
 	require.EqualValues(t, forward, back.Bytes())
+	require.EqualValues(t, rec.GasBudget, back.GasBudget)
+	require.EqualValues(t, rec.GasBurned, back.GasBurned)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/state"
 )
@@ -39,3 +40,94 @@ func IsRequestProcessed(stateReader kv.KVStoreReader, reqid *iscp.RequestID) (bo
 	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
 	return isRequestProcessedInternal(partition, reqid)
 }
+
+// GetBlockInfo reads blocklog from chain state and returns the info of the block. Returns false if the block does not exist
+func GetBlockInfo(stateReader kv.KVStoreReader, blockIndex uint32) (*BlockInfo, bool, error) {
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	numBlocks, err := collections.NewArray32ReadOnly(partition, StateVarBlockRegistry).Len()
+	if err != nil || blockIndex >= numBlocks {
+		return nil, false, err
+	}
+	blockInfo, err := getRequestLogRecordsForBlock(partition, blockIndex)
+	if err != nil || blockInfo == nil {
+		return nil, false, err
+	}
+	return blockInfo, true, nil
+}
+
+// GetRequestReceiptsForBlock reads blocklog from chain state and returns the receipts of the requests settled in the block.
+// Returns an error if the receipts have been pruned
+func GetRequestReceiptsForBlock(stateReader kv.KVStoreReader, blockIndex uint32) ([]*RequestReceipt, error) {
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	recsBin, exist, err := getRequestLogRecordsForBlockBin(partition, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("block index %v does not exist", blockIndex)
+	}
+	ret := make([]*RequestReceipt, len(recsBin))
+	for i, d := range recsBin {
+		if ret[i], err = RequestReceiptFromBytes(d); err != nil {
+			return nil, err
+		}
+		ret[i].WithBlockData(blockIndex, uint16(i))
+	}
+	return ret, nil
+}
+
+// GetRequestEvents reads blocklog from chain state and returns the events emitted by the request
+// with the given index in the block, in the order they were emitted
+func GetRequestEvents(stateReader kv.KVStoreReader, blockIndex uint32, requestIndex uint16) ([]*Event, error) {
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	events := collections.NewMapReadOnly(partition, StateVarRequestEvents)
+	ret := make([]*Event, 0)
+	for eventIndex := uint16(0); ; eventIndex++ {
+		msg, err := events.GetAt(NewEventLookupKey(blockIndex, requestIndex, eventIndex).Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if msg == nil {
+			return ret, nil
+		}
+		evt, err := EventFromText(string(msg))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, evt)
+	}
+}
+
+// GetControlAddressesChangedInBlock reads blocklog from chain state and returns the new control addresses of the chain,
+// if they changed in the block, i.e. if the block is the first one produced after a rotation of the committee.
+// Returns nil otherwise
+func GetControlAddressesChangedInBlock(stateReader kv.KVStoreReader, blockIndex uint32) (*ControlAddresses, error) {
+	if blockIndex == 0 {
+		return nil, nil
+	}
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	registry := collections.NewArray32ReadOnly(partition, StateVarControlAddresses)
+	l, err := registry.Len()
+	if err != nil {
+		return nil, err
+	}
+	// the first record contains the addresses of the origin, not a rotation.
+	// The block saves the addresses of the output it consumes, which is the output of the previous block
+	for i := l; i > 1; i-- {
+		data, err := registry.GetAt(i - 1)
+		if err != nil {
+			return nil, err
+		}
+		rec, err := ControlAddressesFromBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		if rec.SinceBlockIndex == blockIndex-1 {
+			return rec, nil
+		}
+		if rec.SinceBlockIndex < blockIndex-1 {
+			break
+		}
+	}
+	return nil, nil
+}
@@ -24,6 +24,7 @@ var Processor = Contract.Processor(initialize,
 	FuncGetEventsForRequest.WithHandler(viewGetEventsForRequest),
 	FuncGetEventsForBlock.WithHandler(viewGetEventsForBlock),
 	FuncGetEventsForContract.WithHandler(viewGetEventsForContract),
+	FuncGetPruningInfo.WithHandler(viewGetPruningInfo),
 )
 
 func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
@@ -234,3 +235,14 @@ func viewGetEventsForContract(ctx iscp.SandboxView) (dict.Dict, error) {
 	}
 	return ret, nil
 }
+
+// viewGetPruningInfo returns the state of the pruning of request receipts and events.
+// results:
+// ParamPrunedUntil - index of the first block whose receipts and events were not pruned
+// ParamPrunedBytes - total number of bytes deleted from the state by the pruning
+func viewGetPruningInfo(ctx iscp.SandboxView) (dict.Dict, error) {
+	ret := dict.New()
+	ret.Set(ParamPrunedUntil, codec.EncodeUint32(getPrunedUntil(ctx.State())))
+	ret.Set(ParamPrunedBytes, codec.EncodeUint64(getPrunedBytes(ctx.State())))
+	return ret, nil
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -15,6 +16,7 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/coreutil"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/util"
+	"golang.org/x/xerrors"
 )
 
 var Contract = coreutil.NewContract(coreutil.CoreContractBlocklog, "Block log contract")
@@ -27,6 +29,10 @@ const (
 	StateVarRequestReceipts           = "r"
 	StateVarRequestEvents             = "e"
 	StateVarSmartContractEventsLookup = "e"
+	StateVarPrunedUntil               = "p"
+	StateVarPrunedBytes               = "z"
+	StateVarKeptReceipts              = "k"
+	StateVarKeptReceiptsHead          = "kh"
 )
 
 var (
@@ -40,6 +46,7 @@ var (
 	FuncGetEventsForRequest        = coreutil.ViewFunc("getEventsForRequest")
 	FuncGetEventsForBlock          = coreutil.ViewFunc("getEventsForBlock")
 	FuncGetEventsForContract       = coreutil.ViewFunc("getEventsForContract")
+	FuncGetPruningInfo             = coreutil.ViewFunc("getPruningInfo")
 )
 
 const (
@@ -56,6 +63,8 @@ const (
 	ParamRequestRecord          = "d"
 	ParamEvent                  = "e"
 	ParamStateControllerAddress = "s"
+	ParamPrunedUntil            = "pu"
+	ParamPrunedBytes            = "pb"
 )
 
 // region BlockInfo //////////////////////////////////////////////////////////////
@@ -66,6 +75,7 @@ type BlockInfo struct {
 	TotalRequests         uint16
 	NumSuccessfulRequests uint16
 	NumOffLedgerRequests  uint16
+	NumScheduledCalls     uint16 // the scheduled calls are counted in TotalRequests too
 	PreviousStateHash     hashing.HashValue
 }
 
@@ -96,6 +106,7 @@ func (bi *BlockInfo) String() string {
 	ret += fmt.Sprintf("Total requests: %d\n", bi.TotalRequests)
 	ret += fmt.Sprintf("Number of succesfull requests: %d\n", bi.NumSuccessfulRequests)
 	ret += fmt.Sprintf("Number of off-ledger requests: %d\n", bi.NumOffLedgerRequests)
+	ret += fmt.Sprintf("Number of scheduled calls: %d\n", bi.NumScheduledCalls)
 	return ret
 }
 
@@ -112,6 +123,9 @@ func (bi *BlockInfo) Write(w io.Writer) error {
 	if err := util.WriteUint16(w, bi.NumOffLedgerRequests); err != nil {
 		return err
 	}
+	if err := util.WriteUint16(w, bi.NumScheduledCalls); err != nil {
+		return err
+	}
 	if _, err := w.Write(bi.PreviousStateHash.Bytes()); err != nil {
 		return err
 	}
@@ -131,6 +145,9 @@ func (bi *BlockInfo) Read(r io.Reader) error {
 	if err := util.ReadUint16(r, &bi.NumOffLedgerRequests); err != nil {
 		return err
 	}
+	if err := util.ReadUint16(r, &bi.NumScheduledCalls); err != nil {
+		return err
+	}
 	if err := util.ReadHashValue(r, &bi.PreviousStateHash); err != nil { // nolint:nolint
 		return err
 	}
@@ -262,8 +279,10 @@ func EventLookupKeyFromBytes(r io.Reader) (*EventLookupKey, error) {
 
 // RequestReceipt represents log record of processed request on the chain
 type RequestReceipt struct {
-	Request iscp.Request
-	Error   string
+	Request   iscp.Request
+	Error     string
+	GasBudget uint64
+	GasBurned uint64
 	// not persistent
 	BlockIndex   uint32
 	RequestIndex uint16
@@ -288,6 +307,12 @@ func RequestReceiptFromMarshalutil(mu *marshalutil.MarshalUtil) (*RequestReceipt
 		return nil, err
 	}
 	ret.Error = string(strBytes)
+	if ret.GasBudget, err = mu.ReadUint64(); err != nil {
+		return nil, err
+	}
+	if ret.GasBurned, err = mu.ReadUint64(); err != nil {
+		return nil, err
+	}
 	return ret, nil
 }
 
@@ -295,7 +320,9 @@ func (r *RequestReceipt) Bytes() []byte {
 	mu := marshalutil.New()
 	mu.WriteBytes(r.Request.Bytes()).
 		WriteUint16(uint16(len(r.Error))).
-		WriteBytes([]byte(r.Error))
+		WriteBytes([]byte(r.Error)).
+		WriteUint64(r.GasBudget).
+		WriteUint64(r.GasBurned)
 	return mu.Bytes()
 }
 
@@ -306,15 +333,18 @@ func (r *RequestReceipt) WithBlockData(blockIndex uint32, requestIndex uint16) *
 }
 
 func (r *RequestReceipt) String() string {
+	ret := fmt.Sprintf("%s\n Gas: %d/%d", r.Request.String(), r.GasBurned, r.GasBudget)
 	if len(r.Error) > 0 {
-		return fmt.Sprintf("%s\n Error: '%s'", r.Request.String(), r.Error)
+		ret += fmt.Sprintf("\n Error: '%s'", r.Error)
 	}
-	return r.Request.String()
+	return ret
 }
 
 func (r *RequestReceipt) Short() string {
 	prefix := "tx"
-	if r.Request.IsOffLedger() {
+	if _, ok := r.Request.(*request.Scheduled); ok {
+		prefix = "sched"
+	} else if r.Request.IsOffLedger() {
 		prefix = "api"
 	}
 	ret := fmt.Sprintf("%s/%s", prefix, r.Request.ID())
@@ -326,6 +356,33 @@ func (r *RequestReceipt) Short() string {
 
 // endregion  /////////////////////////////////////////////////////////////
 
+// region Event ////////////////////////////////////////////////////////
+
+// Event is an event emitted by a smart contract while processing a request
+type Event struct {
+	Contract iscp.Hname
+	Message  string
+}
+
+// EventFromText parses the event as it is stored in the blocklog, in the form '<contract hname>: <message>'
+func EventFromText(text string) (*Event, error) {
+	parts := strings.SplitN(text, ": ", 2)
+	if len(parts) != 2 {
+		return nil, xerrors.Errorf("EventFromText: wrong format '%s'", text)
+	}
+	hn, err := iscp.HnameFromString(parts[0])
+	if err != nil {
+		return nil, xerrors.Errorf("EventFromText: %w", err)
+	}
+	return &Event{Contract: hn, Message: parts[1]}, nil
+}
+
+func (e *Event) String() string {
+	return fmt.Sprintf("%s: %s", e.Contract.String(), e.Message)
+}
+
+// endregion /////////////////////////////////////////////////////////////
+
 // region ControlAddresses ///////////////////////////////////////////////
 
 type ControlAddresses struct {
@@ -85,7 +85,7 @@ func SaveRequestLogRecord(partition kv.KVStore, rec *RequestReceipt, key Request
 }
 
 func SaveEvent(partition kv.KVStore, msg string, key EventLookupKey, contract iscp.Hname) error {
-	text := fmt.Sprintf("%s: %s", contract.String(), msg)
+	text := (&Event{Contract: contract, Message: msg}).String()
 	if err := collections.NewMap(partition, StateVarRequestEvents).SetAt(key.Bytes(), []byte(text)); err != nil {
 		return xerrors.Errorf("SaveRequestLogRecord: %w", err)
 	}
@@ -261,6 +261,9 @@ func getRequestLogRecordsForBlockBin(partition kv.KVStoreReader, blockIndex uint
 	if err != nil || blockInfo == nil {
 		return nil, false, err
 	}
+	if blockIndex < getPrunedUntil(partition) {
+		return nil, false, xerrors.Errorf("request receipts of block #%d have been pruned", blockIndex)
+	}
 	ret := make([][]byte, blockInfo.TotalRequests)
 	found := false
 	for reqIdx := uint16(0); reqIdx < blockInfo.TotalRequests; reqIdx++ {
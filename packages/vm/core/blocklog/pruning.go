@@ -0,0 +1,216 @@
+package blocklog
+
+import (
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/util"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// MaxBlocksPrunedPerBlock limits the number of blocks which are pruned when closing a block, so that
+	// lowering the retention of a long-running chain doesn't produce a huge state update
+	MaxBlocksPrunedPerBlock = 10
+	// maxKeptReceiptsCheckedPerBlock limits the number of kept receipts which are checked again when closing a block
+	maxKeptReceiptsCheckedPerBlock = 100
+)
+
+// PruneRequestLogs deletes the request receipts and the events of the blocks which are older than the latest
+// blockKeepAmount blocks. 0 means nothing is pruned.
+// The receipts for which keepReceipt returns true (i.e. off-ledger requests still needed for the replay protection)
+// are not deleted: they are queued and checked again when the next blocks are closed.
+// Block infos are never pruned. Returns the number of bytes deleted from the state
+func PruneRequestLogs(partition kv.KVStore, blockIndex, blockKeepAmount uint32, keepReceipt func(*RequestReceipt) bool) int {
+	size := pruneKeptReceipts(partition, keepReceipt)
+	if blockKeepAmount > 0 && blockIndex >= blockKeepAmount {
+		untilIndex := blockIndex - blockKeepAmount + 1
+		prunedUntil := getPrunedUntil(partition)
+		contracts := make([]iscp.Hname, 0)
+		for n := 0; prunedUntil < untilIndex && n < MaxBlocksPrunedPerBlock; n++ {
+			size += pruneBlock(partition, prunedUntil, keepReceipt, &contracts)
+			prunedUntil++
+		}
+		for _, contract := range contracts {
+			size += trimSmartContractEventsLookup(partition, contract, prunedUntil)
+		}
+		partition.Set(StateVarPrunedUntil, codec.EncodeUint32(prunedUntil))
+	}
+	if size > 0 {
+		partition.Set(StateVarPrunedBytes, codec.EncodeUint64(getPrunedBytes(partition)+uint64(size)))
+	}
+	return size
+}
+
+// getPrunedUntil returns the index of the first block whose receipts and events were not pruned
+func getPrunedUntil(partition kv.KVStoreReader) uint32 {
+	// block 0 has no receipts
+	ret, err := codec.DecodeUint32(partition.MustGet(StateVarPrunedUntil), 1)
+	if err != nil {
+		panic(xerrors.Errorf("getPrunedUntil: %w", err))
+	}
+	return ret
+}
+
+// getPrunedBytes returns the total number of bytes deleted from the state by the pruning
+func getPrunedBytes(partition kv.KVStoreReader) uint64 {
+	ret, err := codec.DecodeUint64(partition.MustGet(StateVarPrunedBytes), 0)
+	if err != nil {
+		panic(xerrors.Errorf("getPrunedBytes: %w", err))
+	}
+	return ret
+}
+
+func pruneBlock(partition kv.KVStore, blockIndex uint32, keepReceipt func(*RequestReceipt) bool, contracts *[]iscp.Hname) int {
+	blockInfo, err := getRequestLogRecordsForBlock(partition, blockIndex)
+	if err != nil {
+		panic(xerrors.Errorf("pruneBlock: %w", err))
+	}
+	if blockInfo == nil {
+		return 0
+	}
+	size := 0
+	receipts := collections.NewMapReadOnly(partition, StateVarRequestReceipts)
+	events := collections.NewMap(partition, StateVarRequestEvents)
+	for reqIdx := uint16(0); reqIdx < blockInfo.TotalRequests; reqIdx++ {
+		key := NewRequestLookupKey(blockIndex, reqIdx)
+		if recBin := receipts.MustGetAt(key.Bytes()); recBin != nil {
+			rec, err := RequestReceiptFromBytes(recBin)
+			if err != nil {
+				panic(xerrors.Errorf("pruneBlock: %w", err))
+			}
+			if keepReceipt(rec) {
+				pushKeptReceipt(partition, key)
+			} else {
+				size += deleteRequestReceipt(partition, key, rec, len(recBin))
+			}
+		}
+		for eventIndex := uint16(0); ; eventIndex++ {
+			eventKey := NewEventLookupKey(blockIndex, reqIdx, eventIndex)
+			event := events.MustGetAt(eventKey.Bytes())
+			if event == nil {
+				break
+			}
+			events.MustDelAt(eventKey.Bytes())
+			size += len(eventKey) + len(event)
+			// events are saved as '<contract hname>: <message>'
+			contract, err := iscp.HnameFromString(strings.SplitN(string(event), ":", 2)[0])
+			if err == nil && !containsHname(*contracts, contract) {
+				*contracts = append(*contracts, contract)
+			}
+		}
+	}
+	return size
+}
+
+// deleteRequestReceipt deletes the receipt and its reference from the lookup table. Returns the number of bytes deleted
+func deleteRequestReceipt(partition kv.KVStore, key RequestLookupKey, rec *RequestReceipt, recSize int) int {
+	collections.NewMap(partition, StateVarRequestReceipts).MustDelAt(key.Bytes())
+	size := len(key) + recSize
+
+	lookupTable := collections.NewMap(partition, StateVarRequestLookupIndex)
+	digest := rec.Request.ID().LookupDigest()
+	lst, err := RequestLookupKeyListFromBytes(lookupTable.MustGetAt(digest[:]))
+	if err != nil {
+		panic(xerrors.Errorf("deleteRequestReceipt: %w", err))
+	}
+	remaining := make(RequestLookupKeyList, 0, len(lst))
+	for i := range lst {
+		if lst[i] != key {
+			remaining = append(remaining, lst[i])
+		}
+	}
+	if len(remaining) == 0 {
+		lookupTable.MustDelAt(digest[:])
+		return size + len(digest) + len(lst.Bytes())
+	}
+	lookupTable.MustSetAt(digest[:], remaining.Bytes())
+	return size + len(key)
+}
+
+// trimSmartContractEventsLookup deletes from the lookup of the contract the references to the events of the blocks
+// before untilIndex. References are stored in the order of the blocks
+func trimSmartContractEventsLookup(partition kv.KVStore, contract iscp.Hname, untilIndex uint32) int {
+	scLut := collections.NewMap(partition, StateVarSmartContractEventsLookup)
+	entries := scLut.MustGetAt(contract.Bytes())
+	keyLen := len(EventLookupKey{})
+	pos := 0
+	for ; pos+keyLen <= len(entries); pos += keyLen {
+		if util.MustUint32From4Bytes(entries[pos:pos+4]) >= untilIndex {
+			break
+		}
+	}
+	if pos == 0 {
+		return 0
+	}
+	if pos >= len(entries) {
+		scLut.MustDelAt(contract.Bytes())
+		return len(contract.Bytes()) + len(entries)
+	}
+	scLut.MustSetAt(contract.Bytes(), entries[pos:])
+	return pos
+}
+
+// the receipts kept by the pruning are stored in a FIFO queue. Queue elements are indexed by a sequence number:
+// the head is stored in StateVarKeptReceiptsHead, the number of elements is the length of the map
+
+func pushKeptReceipt(partition kv.KVStore, key RequestLookupKey) {
+	queue := collections.NewMap(partition, StateVarKeptReceipts)
+	head, err := codec.DecodeUint32(partition.MustGet(StateVarKeptReceiptsHead), 0)
+	if err != nil {
+		panic(xerrors.Errorf("pushKeptReceipt: %w", err))
+	}
+	queue.MustSetAt(util.Uint32To4Bytes(head+queue.MustLen()), key.Bytes())
+}
+
+func popKeptReceipt(partition kv.KVStore) RequestLookupKey {
+	queue := collections.NewMap(partition, StateVarKeptReceipts)
+	head, err := codec.DecodeUint32(partition.MustGet(StateVarKeptReceiptsHead), 0)
+	if err != nil {
+		panic(xerrors.Errorf("popKeptReceipt: %w", err))
+	}
+	var ret RequestLookupKey
+	copy(ret[:], queue.MustGetAt(util.Uint32To4Bytes(head)))
+	queue.MustDelAt(util.Uint32To4Bytes(head))
+	partition.Set(StateVarKeptReceiptsHead, codec.EncodeUint32(head+1))
+	return ret
+}
+
+// pruneKeptReceipts checks again the oldest kept receipts and deletes those which are not needed anymore
+func pruneKeptReceipts(partition kv.KVStore, keepReceipt func(*RequestReceipt) bool) int {
+	n := collections.NewMapReadOnly(partition, StateVarKeptReceipts).MustLen()
+	if n > maxKeptReceiptsCheckedPerBlock {
+		n = maxKeptReceiptsCheckedPerBlock
+	}
+	size := 0
+	receipts := collections.NewMapReadOnly(partition, StateVarRequestReceipts)
+	for i := uint32(0); i < n; i++ {
+		key := popKeptReceipt(partition)
+		recBin := receipts.MustGetAt(key.Bytes())
+		if recBin == nil {
+			continue
+		}
+		rec, err := RequestReceiptFromBytes(recBin)
+		if err != nil {
+			panic(xerrors.Errorf("pruneKeptReceipts: %w", err))
+		}
+		if keepReceipt(rec) {
+			pushKeptReceipt(partition, key)
+			continue
+		}
+		size += deleteRequestReceipt(partition, key, rec, len(recBin))
+	}
+	return size
+}
+
+func containsHname(lst []iscp.Hname, hn iscp.Hname) bool {
+	for _, h := range lst {
+		if h == hn {
+			return true
+		}
+	}
+	return false
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/governance/governanceimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/core/root/rootimpl"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 )
 
 var AllCoreContractsByHash = map[hashing.HashValue]*coreutil.ContractProcessor{
@@ -24,12 +25,15 @@ var AllCoreContractsByHash = map[hashing.HashValue]*coreutil.ContractProcessor{
 	blob.Contract.ProgramHash:       blob.Processor,
 	blocklog.Contract.ProgramHash:   blocklog.Processor,
 	governance.Contract.ProgramHash: governanceimpl.Processor,
+	scheduler.Contract.ProgramHash:  scheduler.Processor,
 }
 
 func init() {
 	for _, rec := range AllCoreContractsByHash {
 		commonaccount.SetCoreHname(rec.Contract.Hname())
 	}
+	// the deposits of the scheduled calls must not be mixed with the tokens of the chain owner
+	commonaccount.SetOwnAccount(scheduler.Contract.Hname())
 }
 
 func GetProcessor(programHash hashing.HashValue) (iscp.VMProcessor, error) {
@@ -10,13 +10,17 @@ import (
 
 // ChainInfo is an API structure which contains main properties of the chain in on place
 type ChainInfo struct {
-	ChainID             *iscp.ChainID
-	ChainOwnerID        *iscp.AgentID
-	Description         string
-	FeeColor            colored.Color
-	DefaultOwnerFee     int64
-	DefaultValidatorFee int64
-	MaxBlobSize         uint32
-	MaxEventSize        uint16
-	MaxEventsPerReq     uint16
+	ChainID         *iscp.ChainID
+	ChainOwnerID    *iscp.AgentID
+	Description     string
+	FeeColor        colored.Color
+	MaxBlobSize     uint32
+	MaxEventSize    uint16
+	MaxEventsPerReq uint16
+	// BlockKeepAmount is the number of latest blocks kept by the chain. 0 means all blocks are kept
+	BlockKeepAmount uint32
+	// AccountHistory is true if the accounts contract keeps the history of credits and debits of each account
+	AccountHistory bool
+	// WasmLimits are the limits of the execution of Wasm smart contracts
+	WasmLimits iscp.WasmLimits
 }
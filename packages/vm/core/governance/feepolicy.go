@@ -0,0 +1,103 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governance
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
+)
+
+// GasPriceUnit is the amount of gas the gas price is set for
+const GasPriceUnit = uint64(1000)
+
+// MaxValidatorFeeShare is the maximum share of the fee (in percent) which goes to the validator
+const MaxValidatorFeeShare = uint16(100)
+
+// FeePolicy is a structure which contains the gas-based fee policy of the chain
+// The fee of the request is proportional to the gas burned by the request, but never less than the MinFee
+type FeePolicy struct {
+	// FeeColor is the color of tokens accepted for fees
+	FeeColor colored.Color
+	// GasPrice is the number of fee tokens charged for GasPriceUnit units of gas
+	GasPrice uint64
+	// MinFee is the minimum fee charged for any request
+	MinFee uint64
+	// ValidatorFeeShare is the part of the fee in percent which goes to the validator. The rest goes to the chain owner
+	ValidatorFeeShare uint16
+}
+
+// Enabled returns false if the policy does not charge any fees
+func (p *FeePolicy) Enabled() bool {
+	return p.GasPrice > 0 || p.MinFee > 0
+}
+
+// FeeForGas returns the fee for the amount of gas burned by the request
+func (p *FeePolicy) FeeForGas(gas uint64) uint64 {
+	hi, lo := bits.Mul64(gas, p.GasPrice)
+	if hi >= GasPriceUnit {
+		// the fee does not fit into uint64
+		return math.MaxUint64
+	}
+	fee, rem := bits.Div64(hi, lo, GasPriceUnit)
+	if rem > 0 {
+		fee++
+	}
+	if fee < p.MinFee {
+		return p.MinFee
+	}
+	return fee
+}
+
+// AffordableGas returns the maximum amount of gas the given number of fee tokens can pay for
+func (p *FeePolicy) AffordableGas(tokens uint64) uint64 {
+	if tokens < p.MinFee {
+		return 0
+	}
+	if p.GasPrice == 0 {
+		return math.MaxUint64
+	}
+	hi, lo := bits.Mul64(tokens, GasPriceUnit)
+	if hi >= p.GasPrice {
+		return math.MaxUint64
+	}
+	ret, _ := bits.Div64(hi, lo, p.GasPrice)
+	return ret
+}
+
+// SplitFee splits the fee into the chain owner part and the validator part
+func (p *FeePolicy) SplitFee(fee uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(fee, uint64(p.ValidatorFeeShare))
+	validatorFee, _ := bits.Div64(hi, lo, uint64(MaxValidatorFeeShare))
+	return fee - validatorFee, validatorFee
+}
+
+func (p *FeePolicy) String() string {
+	return fmt.Sprintf("FeePolicy(color: %s, gas price: %d per %d gas, min fee: %d, validator share: %d%%)",
+		p.FeeColor, p.GasPrice, GasPriceUnit, p.MinFee, p.ValidatorFeeShare)
+}
+
+// DecodeFeePolicy decodes the result of the getFeePolicy view
+func DecodeFeePolicy(ret dict.Dict) (*FeePolicy, error) {
+	d := kvdecoder.New(ret)
+	var err error
+	p := &FeePolicy{}
+	if p.FeeColor, err = d.GetColor(VarFeeColor); err != nil {
+		return nil, err
+	}
+	if p.GasPrice, err = d.GetUint64(ParamGasPrice, 0); err != nil {
+		return nil, err
+	}
+	if p.MinFee, err = d.GetUint64(ParamMinFee, 0); err != nil {
+		return nil, err
+	}
+	if p.ValidatorFeeShare, err = d.GetUint16(ParamValidatorFeeShare, 0); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
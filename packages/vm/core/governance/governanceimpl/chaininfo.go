@@ -14,7 +14,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
 
-// getChainInfo view returns general info about the chain: chain ID, chain owner ID, limits and default fees
+// getChainInfo view returns general info about the chain: chain ID, chain owner ID, limits and fee color
 func getChainInfo(ctx iscp.SandboxView) (dict.Dict, error) {
 	info := governance.MustGetChainInfo(ctx.State())
 	ret := dict.New()
@@ -22,11 +22,14 @@ func getChainInfo(ctx iscp.SandboxView) (dict.Dict, error) {
 	ret.Set(governance.VarChainOwnerID, codec.EncodeAgentID(info.ChainOwnerID))
 	ret.Set(governance.VarDescription, codec.EncodeString(info.Description))
 	ret.Set(governance.VarFeeColor, codec.EncodeColor(info.FeeColor))
-	ret.Set(governance.VarDefaultOwnerFee, codec.EncodeInt64(info.DefaultOwnerFee))
-	ret.Set(governance.VarDefaultValidatorFee, codec.EncodeInt64(info.DefaultValidatorFee))
 	ret.Set(governance.VarMaxBlobSize, codec.EncodeUint32(info.MaxBlobSize))
 	ret.Set(governance.VarMaxEventSize, codec.EncodeUint16(info.MaxEventSize))
 	ret.Set(governance.VarMaxEventsPerReq, codec.EncodeUint16(info.MaxEventsPerReq))
+	ret.Set(governance.VarBlockKeepAmount, codec.EncodeUint32(info.BlockKeepAmount))
+	ret.Set(governance.VarAccountHistory, codec.EncodeBool(info.AccountHistory))
+	ret.Set(governance.VarWasmMaxMemoryPages, codec.EncodeUint32(info.WasmLimits.MaxMemoryPages))
+	ret.Set(governance.VarWasmMaxTableSize, codec.EncodeUint32(info.WasmLimits.MaxTableSize))
+	ret.Set(governance.VarWasmMaxCallDepth, codec.EncodeUint32(info.WasmLimits.MaxCallDepth))
 
 	return ret, nil
 }
@@ -36,8 +39,12 @@ func getChainInfo(ctx iscp.SandboxView) (dict.Dict, error) {
 // - ParamMaxBlobSize         - uint32 maximum size of a blob to be saved in the blob contract.
 // - ParamMaxEventSize        - uint16 maximum size of a single event.
 // - ParamMaxEventsPerRequest - uint16 maximum number of events per request.
-// - ParamOwnerFee            - int64 non-negative value of the owner fee.
-// - ParamValidatorFee        - int64 non-negative value of the contract fee.
+// - ParamBlockKeepAmount     - uint32 number of latest blocks to keep. 0 means all blocks are kept.
+// - ParamAccountHistory      - bool enables or disables the history of the accounts in the accounts contract.
+// - ParamWasmMaxMemoryPages  - uint32 maximum size of the memory of Wasm code, in pages of 64Kb.
+// - ParamWasmMaxTableSize    - uint32 maximum number of elements of the tables of Wasm code.
+// - ParamWasmMaxCallDepth    - uint32 maximum depth of nested calls in Wasm code.
+// The fee policy is set by setFeePolicy
 func setChainInfo(ctx iscp.Sandbox) (dict.Dict, error) {
 	a := assert.NewAssert(ctx.Log())
 	a.Require(governance.CheckAuthorizationByChainOwner(ctx.State(), ctx.Caller()), "governance.setChainInfo: not authorized")
@@ -72,18 +79,48 @@ func setChainInfo(ctx iscp.Sandbox) (dict.Dict, error) {
 		ctx.Event(fmt.Sprintf("[updated chain config] max eventsPerRequest: %d", maxEventsPerReq))
 	}
 
-	// default owner fee
-	ownerFee := params.MustGetInt64(governance.ParamOwnerFee, -1)
-	if ownerFee >= 0 {
-		ctx.State().Set(governance.VarDefaultOwnerFee, codec.EncodeInt64(ownerFee))
-		ctx.Event(fmt.Sprintf("[updated chain config] default owner fee: %d", ownerFee))
+	// block retention. 0 disables pruning, so the presence of the parameter is checked
+	if ctx.Params().MustHas(governance.ParamBlockKeepAmount) {
+		blockKeepAmount := params.MustGetUint32(governance.ParamBlockKeepAmount)
+		if blockKeepAmount > 0 && blockKeepAmount < governance.MinBlockKeepAmount {
+			// the chain must keep enough blocks for the nodes that are syncing
+			blockKeepAmount = governance.MinBlockKeepAmount
+		}
+		ctx.State().Set(governance.VarBlockKeepAmount, codec.Encode(blockKeepAmount))
+		ctx.Event(fmt.Sprintf("[updated chain config] block keep amount: %d", blockKeepAmount))
+	}
+
+	// account history. Disabling it keeps the recorded entries
+	if ctx.Params().MustHas(governance.ParamAccountHistory) {
+		accountHistory := params.MustGetBool(governance.ParamAccountHistory)
+		ctx.State().Set(governance.VarAccountHistory, codec.EncodeBool(accountHistory))
+		ctx.Event(fmt.Sprintf("[updated chain config] account history: %v", accountHistory))
 	}
 
-	// default validator fee
-	validatorFee := params.MustGetInt64(governance.ParamValidatorFee, -1)
-	if validatorFee >= 0 {
-		ctx.State().Set(governance.VarDefaultValidatorFee, codec.EncodeInt64(validatorFee))
-		ctx.Event(fmt.Sprintf("[updated chain config] default validator fee: %d", validatorFee))
+	// Wasm limits. Lower limits than the minimums would prevent any Wasm contract from running
+	wasmMaxMemoryPages := params.MustGetUint32(governance.ParamWasmMaxMemoryPages, 0)
+	if wasmMaxMemoryPages > 0 {
+		if wasmMaxMemoryPages < governance.MinWasmMaxMemoryPages {
+			wasmMaxMemoryPages = governance.MinWasmMaxMemoryPages
+		}
+		ctx.State().Set(governance.VarWasmMaxMemoryPages, codec.Encode(wasmMaxMemoryPages))
+		ctx.Event(fmt.Sprintf("[updated chain config] wasm max memory pages: %d", wasmMaxMemoryPages))
+	}
+	wasmMaxTableSize := params.MustGetUint32(governance.ParamWasmMaxTableSize, 0)
+	if wasmMaxTableSize > 0 {
+		if wasmMaxTableSize < governance.MinWasmMaxTableSize {
+			wasmMaxTableSize = governance.MinWasmMaxTableSize
+		}
+		ctx.State().Set(governance.VarWasmMaxTableSize, codec.Encode(wasmMaxTableSize))
+		ctx.Event(fmt.Sprintf("[updated chain config] wasm max table size: %d", wasmMaxTableSize))
+	}
+	wasmMaxCallDepth := params.MustGetUint32(governance.ParamWasmMaxCallDepth, 0)
+	if wasmMaxCallDepth > 0 {
+		if wasmMaxCallDepth < governance.MinWasmMaxCallDepth {
+			wasmMaxCallDepth = governance.MinWasmMaxCallDepth
+		}
+		ctx.State().Set(governance.VarWasmMaxCallDepth, codec.Encode(wasmMaxCallDepth))
+		ctx.Event(fmt.Sprintf("[updated chain config] wasm max call depth: %d", wasmMaxCallDepth))
 	}
 	return nil, nil
 }
@@ -4,6 +4,8 @@
 package governanceimpl
 
 import (
+	"fmt"
+
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/assert"
 	"github.com/iotaledger/wasp/packages/kv/codec"
@@ -11,58 +13,85 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-// setContractFee sets fee for the particular smart contract
-// Input:
-// - ParamHname iscp.Hname smart contract ID
-// - ParamOwnerFee int64 non-negative value of the owner fee. May be skipped, then it is not set
-// - ParamValidatorFee int64 non-negative value of the contract fee. May be skipped, then it is not set
-func setContractFee(ctx iscp.Sandbox) (dict.Dict, error) {
+// setFeePolicy sets the gas-based fee policy of the chain
+// Input (all optional):
+// - ParamFeeColor colored.Color color of tokens accepted for fees. If skipped, the current fee color is kept
+// - ParamGasPrice int64 non-negative number of fee tokens per governance.GasPriceUnit of gas.
+//   The gas price is set for the fee color, the given one or the current one
+// - ParamMinFee int64 non-negative minimum fee charged for any request
+// - ParamValidatorFeeShare int64 part of the fee in percent which goes to the validator
+func setFeePolicy(ctx iscp.Sandbox) (dict.Dict, error) {
 	a := assert.NewAssert(ctx.Log())
-	a.Require(governance.CheckAuthorizationByChainOwner(ctx.State(), ctx.Caller()), "governance.setContractFee: not authorized")
+	a.Require(governance.CheckAuthorizationByChainOwner(ctx.State(), ctx.Caller()), "governance.setFeePolicy: not authorized")
 
 	params := kvdecoder.New(ctx.Params(), ctx.Log())
 
-	hname := params.MustGetHname(governance.ParamHname)
-	rec := governance.FindContractFees(ctx.State(), hname)
-	if rec == nil {
-		rec = governance.NewContractFeesRecord(0, 0)
+	feeColor := governance.MustGetFeePolicy(ctx.State()).FeeColor
+	if ctx.Params().MustHas(governance.ParamFeeColor) {
+		feeColor = params.MustGetColor(governance.ParamFeeColor)
+		ctx.State().Set(governance.VarFeeColor, codec.EncodeColor(feeColor))
+		ctx.Event(fmt.Sprintf("[updated fee policy] fee color: %s", feeColor))
 	}
 
-	ownerFee := params.MustGetInt64(governance.ParamOwnerFee, -1)
-	ownerFeeSet := ownerFee >= 0
-	validatorFee := params.MustGetInt64(governance.ParamValidatorFee, -1)
-	validatorFeeSet := validatorFee >= 0
+	gasPrice := params.MustGetInt64(governance.ParamGasPrice, -1)
+	if gasPrice >= 0 {
+		collections.NewMap(ctx.State(), governance.VarGasPrices).MustSetAt(feeColor.Bytes(), codec.EncodeUint64(uint64(gasPrice)))
+		ctx.Event(fmt.Sprintf("[updated fee policy] gas price: %d per %d gas in %s", gasPrice, governance.GasPriceUnit, feeColor))
+	}
 
-	a.Require(ownerFeeSet || validatorFeeSet, "governance.setContractFee: wrong parameters")
-	if ownerFeeSet {
-		rec.OwnerFee = uint64(ownerFee)
+	minFee := params.MustGetInt64(governance.ParamMinFee, -1)
+	if minFee >= 0 {
+		ctx.State().Set(governance.VarMinFee, codec.EncodeUint64(uint64(minFee)))
+		ctx.Event(fmt.Sprintf("[updated fee policy] min fee: %d", minFee))
 	}
-	if validatorFeeSet {
-		rec.ValidatorFee = uint64(validatorFee)
+
+	validatorFeeShare := params.MustGetInt64(governance.ParamValidatorFeeShare, -1)
+	if validatorFeeShare >= 0 {
+		a.Require(validatorFeeShare <= int64(governance.MaxValidatorFeeShare), "governance.setFeePolicy: validator fee share must not exceed %d%%",
+			governance.MaxValidatorFeeShare)
+		ctx.State().Set(governance.VarValidatorFeeShare, codec.EncodeUint16(uint16(validatorFeeShare)))
+		ctx.Event(fmt.Sprintf("[updated fee policy] validator fee share: %d%%", validatorFeeShare))
 	}
-	collections.NewMap(ctx.State(), governance.VarContractFeesRegistry).MustSetAt(hname.Bytes(), rec.Bytes())
 	return nil, nil
 }
 
-// getFeeInfo returns fee information for the contract.
+// getFeePolicy returns the fee policy of the chain
+// Output:
+// - VarFeeColor colored.Color color of tokens accepted for fees
+// - ParamGasPrice uint64 number of fee tokens per governance.GasPriceUnit of gas
+// - ParamMinFee uint64 minimum fee charged for any request
+// - ParamValidatorFeeShare uint16 part of the fee in percent which goes to the validator
+func getFeePolicy(ctx iscp.SandboxView) (dict.Dict, error) {
+	policy := governance.MustGetFeePolicy(ctx.State())
+	ret := dict.New()
+	ret.Set(governance.VarFeeColor, codec.EncodeColor(policy.FeeColor))
+	ret.Set(governance.ParamGasPrice, codec.EncodeUint64(policy.GasPrice))
+	ret.Set(governance.ParamMinFee, codec.EncodeUint64(policy.MinFee))
+	ret.Set(governance.ParamValidatorFeeShare, codec.EncodeUint16(policy.ValidatorFeeShare))
+	return ret, nil
+}
+
+// estimateFee returns the maximum fee the request with the given gas budget will be charged
 // Input:
-// - ParamHname iscp.Hname contract id
+// - ParamGas uint64 gas budget of the request. May be skipped, then the default budget is assumed
 // Output:
-// - ParamFeeColor ledgerstate.Color color of tokens accepted for fees
-// - ParamValidatorFee int64 minimum fee for contract
-// Note: return default chain values if contract doesn't exist
-func getFeeInfo(ctx iscp.SandboxView) (dict.Dict, error) {
+// - VarFeeColor colored.Color color of tokens accepted for fees
+// - ParamFee uint64 the fee charged if the whole gas budget is burned
+// - ParamGas uint64 the effective gas budget of the request
+func estimateFee(ctx iscp.SandboxView) (dict.Dict, error) {
 	params := kvdecoder.New(ctx.Params())
-	hname, err := params.GetHname(governance.ParamHname)
+	requested, err := params.GetUint64(governance.ParamGas, 0)
 	if err != nil {
 		return nil, err
 	}
-	feeColor, ownerFee, validatorFee := governance.GetFeeInfo(ctx, hname)
+	budget := gas.Budget(requested)
+	policy := governance.MustGetFeePolicy(ctx.State())
 	ret := dict.New()
-	ret.Set(governance.ParamFeeColor, codec.EncodeColor(feeColor))
-	ret.Set(governance.ParamOwnerFee, codec.EncodeUint64(ownerFee))
-	ret.Set(governance.ParamValidatorFee, codec.EncodeUint64(validatorFee))
+	ret.Set(governance.VarFeeColor, codec.EncodeColor(policy.FeeColor))
+	ret.Set(governance.ParamFee, codec.EncodeUint64(policy.FeeForGas(budget)))
+	ret.Set(governance.ParamGas, codec.EncodeUint64(budget))
 	return ret, nil
 }
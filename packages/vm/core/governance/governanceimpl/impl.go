@@ -25,8 +25,9 @@ var Processor = governance.Contract.Processor(initialize,
 	governance.FuncGetChainOwner.WithHandler(getChainOwner),
 
 	// fees
-	governance.FuncSetContractFee.WithHandler(setContractFee),
-	governance.FuncGetFeeInfo.WithHandler(getFeeInfo),
+	governance.FuncSetFeePolicy.WithHandler(setFeePolicy),
+	governance.FuncGetFeePolicy.WithHandler(getFeePolicy),
+	governance.FuncEstimateFee.WithHandler(estimateFee),
 
 	// chain info
 	governance.FuncGetChainInfo.WithHandler(getChainInfo),
@@ -16,6 +16,13 @@ const (
 	DefaultMaxEventsPerRequest = uint16(50)
 	DefaultMaxEventSize        = uint16(2000)    // 2Kb
 	DefaultMaxBlobSize         = uint32(1000000) // 1Mb
+	MinBlockKeepAmount         = uint32(100)
+	DefaultWasmMaxMemoryPages  = uint32(256) // 16Mb
+	DefaultWasmMaxTableSize    = uint32(10000)
+	DefaultWasmMaxCallDepth    = uint32(1000)
+	MinWasmMaxMemoryPages      = uint32(32) // 2Mb
+	MinWasmMaxTableSize        = uint32(100)
+	MinWasmMaxCallDepth        = uint32(100)
 )
 
 var Contract = coreutil.NewContract(coreutil.CoreContractGovernance, "Governance contract")
@@ -33,8 +40,9 @@ var (
 	FuncGetChainOwner          = coreutil.ViewFunc("getChainOwner")
 
 	// fees
-	FuncSetContractFee = coreutil.Func("setContractFee")
-	FuncGetFeeInfo     = coreutil.ViewFunc("getFeeInfo")
+	FuncSetFeePolicy = coreutil.Func("setFeePolicy")
+	FuncGetFeePolicy = coreutil.ViewFunc("getFeePolicy")
+	FuncEstimateFee  = coreutil.ViewFunc("estimateFee")
 
 	// chain info
 	FuncSetChainInfo   = coreutil.Func("setChainInfo")
@@ -57,14 +65,12 @@ const (
 	// chain owner
 	VarChainOwnerID          = "o"
 	VarChainOwnerIDDelegated = "n"
-	VarDefaultOwnerFee       = "do"
-	VarOwnerFee              = "of"
 
 	// fees
-	VarDefaultValidatorFee  = "dv"
-	VarValidatorFee         = "vf"
-	VarFeeColor             = "f"
-	VarContractFeesRegistry = "fr"
+	VarFeeColor          = "f"
+	VarGasPrices         = "gp"
+	VarMinFee            = "mf"
+	VarValidatorFeeShare = "vs"
 
 	// chain info
 	VarChainID         = "c"
@@ -72,6 +78,12 @@ const (
 	VarMaxBlobSize     = "mb"
 	VarMaxEventSize    = "me"
 	VarMaxEventsPerReq = "mr"
+	VarBlockKeepAmount = "bk"
+	VarAccountHistory  = "ah"
+	// Wasm limits
+	VarWasmMaxMemoryPages = "wm"
+	VarWasmMaxTableSize   = "wt"
+	VarWasmMaxCallDepth   = "wd"
 
 	// access nodes
 	VarAccessNodes          = "an"
@@ -87,12 +99,14 @@ const (
 
 	// chain owner
 	ParamChainOwner = "oi"
-	ParamOwnerFee   = "of"
 
 	// fees
-	ParamFeeColor     = "fc"
-	ParamValidatorFee = "vf"
-	ParamHname        = "hn"
+	ParamFeeColor          = "fc"
+	ParamGasPrice          = "gp"
+	ParamMinFee            = "mf"
+	ParamValidatorFeeShare = "vs"
+	ParamGas               = "g"
+	ParamFee               = "fe"
 
 	// chain info
 	ParamChainID             = "ci"
@@ -100,6 +114,11 @@ const (
 	ParamMaxBlobSize         = "bs"
 	ParamMaxEventSize        = "es"
 	ParamMaxEventsPerRequest = "ne"
+	ParamBlockKeepAmount     = "bk"
+	ParamAccountHistory      = "ah"
+	ParamWasmMaxMemoryPages  = "wm"
+	ParamWasmMaxTableSize    = "wt"
+	ParamWasmMaxCallDepth    = "wd"
 
 	// access nodes: getChainNodes
 	ParamGetChainNodesAccessNodeCandidates = "c"
@@ -29,84 +29,62 @@ func GetRotationAddress(state kv.KVStoreReader) ledgerstate.Address {
 func MustGetChainInfo(state kv.KVStoreReader) ChainInfo {
 	d := kvdecoder.New(state)
 	ret := ChainInfo{
-		ChainID:             d.MustGetChainID(VarChainID),
-		ChainOwnerID:        d.MustGetAgentID(VarChainOwnerID),
-		Description:         d.MustGetString(VarDescription, ""),
-		FeeColor:            d.MustGetColor(VarFeeColor, colored.IOTA),
-		DefaultOwnerFee:     d.MustGetInt64(VarDefaultOwnerFee, 0),
-		DefaultValidatorFee: d.MustGetInt64(VarDefaultValidatorFee, 0),
-		MaxBlobSize:         d.MustGetUint32(VarMaxBlobSize, 0),
-		MaxEventSize:        d.MustGetUint16(VarMaxEventSize, 0),
-		MaxEventsPerReq:     d.MustGetUint16(VarMaxEventsPerReq, 0),
+		ChainID:         d.MustGetChainID(VarChainID),
+		ChainOwnerID:    d.MustGetAgentID(VarChainOwnerID),
+		Description:     d.MustGetString(VarDescription, ""),
+		FeeColor:        d.MustGetColor(VarFeeColor, colored.IOTA),
+		MaxBlobSize:     d.MustGetUint32(VarMaxBlobSize, 0),
+		MaxEventSize:    d.MustGetUint16(VarMaxEventSize, 0),
+		MaxEventsPerReq: d.MustGetUint16(VarMaxEventsPerReq, 0),
+		BlockKeepAmount: d.MustGetUint32(VarBlockKeepAmount, 0),
+		AccountHistory:  d.MustGetBool(VarAccountHistory, false),
+		WasmLimits:      MustGetWasmLimits(state),
 	}
 	return ret
 }
 
-func MustGetChainOwnerID(state kv.KVStoreReader) *iscp.AgentID {
+// MustGetWasmLimits returns the limits of the execution of Wasm smart contracts on the chain
+func MustGetWasmLimits(state kv.KVStoreReader) iscp.WasmLimits {
 	d := kvdecoder.New(state)
-	return d.MustGetAgentID(VarChainOwnerID)
+	return iscp.WasmLimits{
+		MaxMemoryPages: d.MustGetUint32(VarWasmMaxMemoryPages, DefaultWasmMaxMemoryPages),
+		MaxTableSize:   d.MustGetUint32(VarWasmMaxTableSize, DefaultWasmMaxTableSize),
+		MaxCallDepth:   d.MustGetUint32(VarWasmMaxCallDepth, DefaultWasmMaxCallDepth),
+	}
 }
 
-// GetFeeInfo is an internal utility function which returns fee info for the contract
-// It is called from VMContext and viewcontext objects
-// It is not exposed to the sandbox
-func GetFeeInfo(ctx iscp.SandboxView, hname iscp.Hname) (colored.Color, uint64, uint64) {
-	state := ctx.State()
-	rec := FindContractFees(state, hname)
-	return GetFeeInfoFromContractFeesRecord(state, rec)
+// MustGetBlockKeepAmount returns the number of latest blocks kept by the chain. 0 means all blocks are kept
+func MustGetBlockKeepAmount(state kv.KVStoreReader) uint32 {
+	d := kvdecoder.New(state)
+	return d.MustGetUint32(VarBlockKeepAmount, 0)
 }
 
-// GetFeeInfoByHname is an internal utility function which returns fee info for the contract
-// It is called from VMContext and viewcontext objects
-// It is not exposed to the sandbox
-func GetFeeInfoByHname(state kv.KVStoreReader, hname iscp.Hname) (colored.Color, uint64, uint64) {
-	rec := FindContractFees(state, hname)
-	return GetFeeInfoFromContractFeesRecord(state, rec)
+func MustGetChainOwnerID(state kv.KVStoreReader) *iscp.AgentID {
+	d := kvdecoder.New(state)
+	return d.MustGetAgentID(VarChainOwnerID)
 }
 
-// FindContractFees is an internal utility function which finds a contract in the KVStore
-// It is called from within the 'governance' contract as well as VMContext and viewcontext objects
-// It is not directly exposed to the sandbox
-// If contract fees are not found by the given hname, nil is returned
-// the bool flag indicates if a contract-fees record was found or not
-func FindContractFees(state kv.KVStoreReader, hname iscp.Hname) *ContractFeesRecord {
-	contractRegistry := collections.NewMapReadOnly(state, VarContractFeesRegistry)
-	retBin := contractRegistry.MustGetAt(hname.Bytes())
-	if retBin == nil {
-		return nil
-	}
-	ret, err := ContractFeesRecordFromBytes(retBin)
-	if err != nil {
-		panic(xerrors.Errorf("FindContractFees: %w", err))
+// MustGetFeePolicy is an internal utility function which returns the fee policy of the chain
+// It is called from VMContext and from the 'governance' contract
+// It is not exposed to the sandbox
+func MustGetFeePolicy(state kv.KVStoreReader) *FeePolicy {
+	d := kvdecoder.New(state)
+	ret := &FeePolicy{
+		FeeColor:          d.MustGetColor(VarFeeColor, colored.IOTA),
+		MinFee:            d.MustGetUint64(VarMinFee, 0),
+		ValidatorFeeShare: d.MustGetUint16(VarValidatorFeeShare, 0),
 	}
+	ret.GasPrice = MustGetGasPrice(state, ret.FeeColor)
 	return ret
 }
 
-func GetFeeInfoFromContractFeesRecord(state kv.KVStoreReader, rec *ContractFeesRecord) (colored.Color, uint64, uint64) {
-	var ownerFee, validatorFee uint64
-	if rec != nil {
-		ownerFee = rec.OwnerFee
-		validatorFee = rec.ValidatorFee
-	}
-	feeColor, defaultOwnerFee, defaultValidatorFee, err := GetDefaultFeeInfo(state)
+// MustGetGasPrice returns the gas price set for the fee color. 0 means gas is not charged
+func MustGetGasPrice(state kv.KVStoreReader, col colored.Color) uint64 {
+	ret, err := codec.DecodeUint64(collections.NewMapReadOnly(state, VarGasPrices).MustGetAt(col.Bytes()), 0)
 	if err != nil {
-		panic(err)
+		panic(xerrors.Errorf("MustGetGasPrice: %w", err))
 	}
-	if ownerFee == 0 {
-		ownerFee = defaultOwnerFee
-	}
-	if validatorFee == 0 {
-		validatorFee = defaultValidatorFee
-	}
-	return feeColor, ownerFee, validatorFee
-}
-
-func GetDefaultFeeInfo(state kv.KVStoreReader) (colored.Color, uint64, uint64, error) {
-	deco := kvdecoder.New(state)
-	feeColor := deco.MustGetColor(VarFeeColor, colored.IOTA)
-	defaultOwnerFee := deco.MustGetUint64(VarDefaultOwnerFee, 0)
-	defaultValidatorFee := deco.MustGetUint64(VarDefaultValidatorFee, 0)
-	return feeColor, defaultOwnerFee, defaultValidatorFee, nil
+	return ret
 }
 
 func CheckAuthorizationByChainOwner(state kv.KVStore, agentID *iscp.AgentID) bool {
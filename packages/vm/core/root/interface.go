@@ -17,6 +17,7 @@ const (
 	VarDeployPermissionsEnabled = "a"
 	VarDeployPermissions        = "p"
 	VarStateInitialized         = "i"
+	VarProgramHistory           = "h" // programs replaced by upgrades, per contract
 )
 
 // param variables
@@ -29,14 +30,17 @@ const (
 	ParamContractFound            = "cf"
 	ParamDescription              = "ds"
 	ParamDeployPermissionsEnabled = "de"
+	ParamProgramHistory           = "hs"
 )
 
 // function names
 var (
 	FuncDeployContract           = coreutil.Func("deployContract")
+	FuncUpgradeContract          = coreutil.Func("upgradeContract")
 	FuncGrantDeployPermission    = coreutil.Func("grantDeployPermission")
 	FuncRevokeDeployPermission   = coreutil.Func("revokeDeployPermission")
 	FuncRequireDeployPermissions = coreutil.Func("requireDeployPermissions")
 	FuncFindContract             = coreutil.ViewFunc("findContract")
 	FuncGetContractRecords       = coreutil.ViewFunc("getContractRecords")
+	FuncGetProgramHistory        = coreutil.ViewFunc("getProgramHistory")
 )
@@ -0,0 +1,75 @@
+package root
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"golang.org/x/xerrors"
+)
+
+// ProgramHistoryEntry is a program a contract ran before it was upgraded
+type ProgramHistoryEntry struct {
+	ProgramHash hashing.HashValue
+	// ReplacedAt is the timestamp of the block in which the program was replaced
+	ReplacedAt time.Time
+}
+
+func ProgramHistoryEntryFromBytes(data []byte) (*ProgramHistoryEntry, error) {
+	mu := marshalutil.New(data)
+	ret := &ProgramHistoryEntry{}
+	buf, err := mu.ReadBytes(len(ret.ProgramHash))
+	if err != nil {
+		return nil, err
+	}
+	copy(ret.ProgramHash[:], buf)
+	ts, err := mu.ReadInt64()
+	if err != nil {
+		return nil, err
+	}
+	ret.ReplacedAt = time.Unix(0, ts)
+	return ret, nil
+}
+
+func (e *ProgramHistoryEntry) Bytes() []byte {
+	return marshalutil.New().
+		WriteBytes(e.ProgramHash[:]).
+		WriteInt64(e.ReplacedAt.UnixNano()).
+		Bytes()
+}
+
+func programHistoryName(hname iscp.Hname) string {
+	return VarProgramHistory + string(hname.Bytes())
+}
+
+// AddProgramHistory appends the program replaced by an upgrade to the history of the contract
+func AddProgramHistory(state kv.KVStore, hname iscp.Hname, entry *ProgramHistoryEntry) {
+	collections.NewArray32(state, programHistoryName(hname)).MustPush(entry.Bytes())
+}
+
+// GetProgramHistory returns the programs the contract ran before the current one, oldest first
+func GetProgramHistory(state kv.KVStoreReader, hname iscp.Hname) ([]*ProgramHistoryEntry, error) {
+	return decodeProgramHistory(collections.NewArray32ReadOnly(state, programHistoryName(hname)))
+}
+
+// DecodeProgramHistory decodes the result of the getProgramHistory view
+func DecodeProgramHistory(ret dict.Dict) ([]*ProgramHistoryEntry, error) {
+	return decodeProgramHistory(collections.NewArray32ReadOnly(ret, ParamProgramHistory))
+}
+
+func decodeProgramHistory(arr *collections.ImmutableArray32) ([]*ProgramHistoryEntry, error) {
+	n := arr.MustLen()
+	ret := make([]*ProgramHistoryEntry, n)
+	for i := uint32(0); i < n; i++ {
+		entry, err := ProgramHistoryEntryFromBytes(arr.MustGetAt(i))
+		if err != nil {
+			return nil, xerrors.Errorf("decodeProgramHistory: %w", err)
+		}
+		ret[i] = entry
+	}
+	return ret, nil
+}
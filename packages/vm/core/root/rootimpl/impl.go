@@ -8,6 +8,7 @@ package rootimpl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/assert"
@@ -17,18 +18,22 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
 	"github.com/iotaledger/wasp/packages/vm/core/_default"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts/commonaccount"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 )
 
 var Processor = root.Contract.Processor(initialize,
 	root.FuncDeployContract.WithHandler(deployContract),
+	root.FuncUpgradeContract.WithHandler(upgradeContract),
 	root.FuncGrantDeployPermission.WithHandler(grantDeployPermission),
 	root.FuncRevokeDeployPermission.WithHandler(revokeDeployPermission),
 	root.FuncFindContract.WithHandler(findContract),
 	root.FuncGetContractRecords.WithHandler(getContractRecords),
+	root.FuncGetProgramHistory.WithHandler(getProgramHistory),
 	root.FuncRequireDeployPermissions.WithHandler(requireDeployPermissions),
 )
 
@@ -62,6 +67,7 @@ func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
 	govParams := ctx.Params().Clone()
 	govParams.Set(governance.ParamChainOwner, ctx.Caller().Bytes()) // chain owner is whoever sends init request
 	mustStoreAndInitCoreContract(ctx, governance.Contract, a, govParams)
+	mustStoreAndInitCoreContract(ctx, scheduler.Contract, a)
 
 	state.Set(root.VarDeployPermissionsEnabled, codec.EncodeBool(true))
 	state.Set(root.VarStateInitialized, []byte{0xFF})
@@ -117,6 +123,58 @@ func deployContract(ctx iscp.Sandbox) (dict.Dict, error) {
 	return nil, nil
 }
 
+// upgradeContract replaces the program of a deployed contract and calls the 'migrate' entry point
+// of the new program, if it exists. The state partition and the hname of the contract don't change.
+// Only the creator of the contract or the chain owner can upgrade it. Core contracts can't be upgraded
+// Inputs:
+// - ParamHname Hname of the contract
+// - ParamProgramHash HashValue is a hash of the blob of the new program binary, or the hash of a builtin program
+// - ParamDescription string is the new description. Defaults to the current one
+// All other params are passed to the 'migrate' entry point
+func upgradeContract(ctx iscp.Sandbox) (dict.Dict, error) {
+	ctx.Log().Debugf("root.upgradeContract.begin")
+	params := kvdecoder.New(ctx.Params(), ctx.Log())
+	a := assert.NewAssert(ctx.Log())
+
+	hname := params.MustGetHname(root.ParamHname)
+	progHash := params.MustGetHashValue(root.ParamProgramHash)
+
+	rec, found := root.FindContract(ctx.State(), hname)
+	a.Require(found, "root.upgradeContract: contract %s not found", hname)
+	a.Require(!commonaccount.IsCoreHname(hname), "root.upgradeContract: core contracts can't be upgraded")
+	a.Require(rec.Creator.Equals(ctx.Caller()) || isChainOwner(a, ctx),
+		"root.upgradeContract: upgrade not permitted for: %s", ctx.Caller())
+	a.Require(rec.ProgramHash != progHash, "root.upgradeContract: the contract already runs program %s", progHash)
+
+	// pass to migrate function all params not consumed so far
+	migrateParams := dict.New()
+	for key, value := range ctx.Params() {
+		if key != root.ParamHname && key != root.ParamProgramHash && key != root.ParamDescription {
+			migrateParams.Set(key, value)
+		}
+	}
+	// call to load VM from binary to check if it loads successfully
+	err := ctx.DeployContract(progHash, "", "", nil)
+	a.Require(err == nil, "root.upgradeContract.fail 1: %v", err)
+
+	oldProgHash := rec.ProgramHash
+	rec.ProgramHash = progHash
+	rec.Description = params.MustGetString(root.ParamDescription, rec.Description)
+	collections.NewMap(ctx.State(), root.VarContractRegistry).MustSetAt(hname.Bytes(), rec.Bytes())
+	// the history is kept in the state, the events of the blocklog can be pruned
+	root.AddProgramHistory(ctx.State(), hname, &root.ProgramHistoryEntry{
+		ProgramHash: oldProgHash,
+		ReplacedAt:  time.Unix(0, ctx.GetTimestamp()),
+	})
+
+	_, err = ctx.Call(hname, iscp.EntryPointMigrate, migrateParams, nil)
+	a.RequireNoError(err)
+
+	ctx.Event(fmt.Sprintf("[upgrade] name: %s hname: %s, progHash: %s -> %s",
+		rec.Name, hname, oldProgHash.String(), progHash.String()))
+	return nil, nil
+}
+
 // findContract view finds and returns encoded record of the contract
 // Input:
 // - ParamHname
@@ -182,6 +240,29 @@ func getContractRecords(ctx iscp.SandboxView) (dict.Dict, error) {
 	return ret, nil
 }
 
+// getProgramHistory returns the programs the contract ran before it was upgraded, oldest first
+// Input:
+// - ParamHname
+// Output:
+// - ParamProgramHistory Array32 of encoded root.ProgramHistoryEntry
+func getProgramHistory(ctx iscp.SandboxView) (dict.Dict, error) {
+	params := kvdecoder.New(ctx.Params())
+	hname, err := params.GetHname(root.ParamHname)
+	if err != nil {
+		return nil, err
+	}
+	history, err := root.GetProgramHistory(ctx.State(), hname)
+	if err != nil {
+		return nil, err
+	}
+	ret := dict.New()
+	arr := collections.NewArray32(ret, root.ParamProgramHistory)
+	for _, entry := range history {
+		arr.MustPush(entry.Bytes())
+	}
+	return ret, nil
+}
+
 func requireDeployPermissions(ctx iscp.Sandbox) (dict.Dict, error) {
 	a := assert.NewAssert(ctx.Log())
 	a.Require(isChainOwner(a, ctx), "root.revokeDeployPermissions: not authorized")
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/assert"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+)
+
+var Processor = Contract.Processor(initialize,
+	FuncSchedule.WithHandler(schedule),
+	FuncCancel.WithHandler(cancel),
+	FuncGetScheduledCall.WithHandler(viewGetScheduledCall),
+	FuncGetScheduledCalls.WithHandler(viewGetScheduledCalls),
+)
+
+func initialize(ctx iscp.Sandbox) (dict.Dict, error) {
+	ctx.Log().Debugf("scheduler.initialize.success hname = %s", Contract.Hname().String())
+	return nil, nil
+}
+
+// schedule registers a call to an entry point of the calling contract.
+// The call is due 'delay' seconds after the timestamp of the current block.
+// Recurring calls are repeated every 'interval' seconds, 'count' times or forever if count is 0.
+// The tokens sent with the request are kept as a deposit to pay for the fees of the executions.
+// A contract can have at most MaxScheduledCallsPerContract calls scheduled at the same time
+// Params:
+// - ParamEntryPoint Hname
+// - ParamDelay uint32 seconds, default 0
+// - ParamInterval uint32 seconds, default 0 (one-shot call)
+// - ParamCount uint32, default 0 (unlimited)
+// - ParamGasBudget uint64, default 0 (default gas budget)
+// - ParamArgs bytes of the encoded dict.Dict of the call parameters, optional
+func schedule(ctx iscp.Sandbox) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	caller := ctx.Caller()
+	a.Require(caller.Address().Equals(ctx.ChainID().AsAddress()) && caller.Hname() != 0,
+		"scheduler.schedule.fail: only contracts on the chain can schedule calls")
+	a.Require(GetCallCount(ctx.State(), caller.Hname()) < MaxScheduledCallsPerContract,
+		"scheduler.schedule.fail: the contract has already %d calls scheduled", MaxScheduledCallsPerContract)
+
+	params := kvdecoder.New(ctx.Params(), ctx.Log())
+	args := dict.New()
+	if data := params.MustGetBytes(ParamArgs, nil); len(data) > 0 {
+		var err error
+		args, err = dict.FromBytes(data)
+		a.RequireNoError(err, "scheduler.schedule.fail: wrong args")
+	}
+	interval := params.MustGetUint32(ParamInterval, 0)
+	count := params.MustGetUint32(ParamCount, 0)
+	if interval == 0 {
+		count = 1
+	}
+	delay := time.Duration(params.MustGetUint32(ParamDelay, 0)) * time.Second
+
+	call := &ScheduledCall{
+		ID:         mustNextCallID(ctx.State()),
+		Contract:   caller.Hname(),
+		EntryPoint: params.MustGetHname(ParamEntryPoint),
+		Params:     args,
+		Time:       time.Unix(0, ctx.GetTimestamp()).Add(delay),
+		Interval:   interval,
+		Remaining:  count,
+		GasBudget:  params.MustGetUint64(ParamGasBudget, 0),
+		Deposit:    colored.NewBalances(),
+	}
+	if transfer := ctx.IncomingTransfer(); transfer != nil {
+		call.Deposit = transfer.Clone()
+	}
+	SaveCall(ctx.State(), call)
+	ctx.Log().Debugf("scheduler.schedule.success: %s", call.String())
+
+	ret := dict.New()
+	ret.Set(ParamCallID, codec.EncodeUint32(call.ID))
+	return ret, nil
+}
+
+// cancel removes a call scheduled by the calling contract and returns the remaining deposit to it
+// Params:
+// - ParamCallID uint32
+func cancel(ctx iscp.Sandbox) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	params := kvdecoder.New(ctx.Params(), ctx.Log())
+	callID := params.MustGetUint32(ParamCallID)
+
+	call, ok := GetCall(ctx.State(), callID)
+	a.Require(ok, "scheduler.cancel.fail: call %d not found", callID)
+	a.Require(call.AgentID(ctx.ChainID()).Equals(ctx.Caller()),
+		"scheduler.cancel.fail: call %d was not scheduled by the caller", callID)
+
+	DeleteCall(ctx.State(), callID)
+	if !call.Deposit.IsEmpty() {
+		_, err := ctx.Call(accounts.Contract.Hname(), accounts.FuncDeposit.Hname(), dict.Dict{
+			accounts.ParamAgentID: codec.EncodeAgentID(ctx.Caller()),
+		}, call.Deposit)
+		a.RequireNoError(err, "scheduler.cancel.fail: can't return the deposit")
+	}
+	ctx.Log().Debugf("scheduler.cancel.success: %s", call.String())
+	return nil, nil
+}
+
+// viewGetScheduledCall returns the scheduled call with the given id
+// Params:
+// - ParamCallID uint32
+// Returns ParamScheduledCall, the ScheduledCall bytes
+func viewGetScheduledCall(ctx iscp.SandboxView) (dict.Dict, error) {
+	a := assert.NewAssert(ctx.Log())
+	params := kvdecoder.New(ctx.Params(), ctx.Log())
+	callID := params.MustGetUint32(ParamCallID)
+
+	call, ok := GetCall(ctx.State(), callID)
+	a.Require(ok, "scheduler.getScheduledCall.fail: call %d not found", callID)
+
+	ret := dict.New()
+	ret.Set(ParamScheduledCall, call.Bytes())
+	return ret, nil
+}
+
+// viewGetScheduledCalls returns all scheduled calls, or only the ones of the given contract.
+// Params:
+// - ParamContract Hname, optional
+// Returns a map of the encoded call ids to the ScheduledCall bytes
+func viewGetScheduledCalls(ctx iscp.SandboxView) (dict.Dict, error) {
+	params := kvdecoder.New(ctx.Params(), ctx.Log())
+	contract := params.MustGetHname(ParamContract, 0)
+
+	ret := dict.New()
+	for _, call := range filterCalls(ctx.State(), func(call *ScheduledCall) bool {
+		return contract == 0 || call.Contract == contract
+	}) {
+		ret.Set(kv.Key(codec.EncodeUint32(call.ID)), call.Bytes())
+	}
+	return ret, nil
+}
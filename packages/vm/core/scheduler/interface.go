@@ -0,0 +1,178 @@
+// in the scheduler core contract smart contracts register calls to their own entry points,
+// which the VM runs at the start of the first block with a timestamp at or after the due time.
+// When calls are due and there are no requests, the committee produces a block for the calls alone.
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"golang.org/x/xerrors"
+)
+
+var Contract = coreutil.NewContract(coreutil.CoreContractScheduler, "Scheduler contract")
+
+// Account returns the account of the scheduler on the chain, it holds the deposits of all scheduled calls
+func Account(chainID *iscp.ChainID) *iscp.AgentID {
+	return iscp.NewAgentID(chainID.AsAddress(), Contract.Hname())
+}
+
+// MaxScheduledCallsPerBlock is the maximum number of due calls run at the start of one block.
+// The remaining due calls are run in the following blocks
+const MaxScheduledCallsPerBlock = 32
+
+// MaxScheduledCallsPerContract is the maximum number of calls one contract can have scheduled at the same time
+const MaxScheduledCallsPerContract = 16
+
+const (
+	// state variables
+	VarNextCallID = "n"
+	VarCalls      = "c"
+	// VarCallCounts is the map of contract hnames to the number of calls they have scheduled
+	VarCallCounts = "p"
+	// VarDueIndex is the prefix of the keys of the index of the calls by due time.
+	// The keys are the big-endian due time in nanoseconds followed by the big-endian call id
+	VarDueIndex = "t"
+)
+
+var (
+	FuncSchedule          = coreutil.Func("schedule")
+	FuncCancel            = coreutil.Func("cancel")
+	FuncGetScheduledCall  = coreutil.ViewFunc("getScheduledCall")
+	FuncGetScheduledCalls = coreutil.ViewFunc("getScheduledCalls")
+)
+
+const (
+	// parameters
+	ParamCallID        = "i"
+	ParamContract      = "h"
+	ParamEntryPoint    = "e"
+	ParamDelay         = "d"
+	ParamInterval      = "v"
+	ParamCount         = "c"
+	ParamGasBudget     = "g"
+	ParamArgs          = "a"
+	ParamScheduledCall = "s"
+)
+
+// region ScheduledCall //////////////////////////////////////////////////////////////
+
+// ScheduledCall is a call to an entry point of a contract registered in the scheduler
+type ScheduledCall struct {
+	ID         uint32 // not persistent. Set from key
+	Contract   iscp.Hname
+	EntryPoint iscp.Hname
+	Params     dict.Dict
+	// Time is when the call is due next
+	Time time.Time
+	// Interval in seconds between executions. 0 means a one-shot call
+	Interval uint32
+	// Remaining number of executions. 0 means unlimited for recurring calls
+	Remaining uint32
+	GasBudget uint64
+	// Deposit is what is left of the tokens sent with the schedule request to pay for the fees
+	Deposit    colored.Balances
+	Executions uint32
+}
+
+func ScheduledCallFromBytes(data []byte) (*ScheduledCall, error) {
+	return ScheduledCallFromMarshalUtil(marshalutil.New(data))
+}
+
+func ScheduledCallFromMarshalUtil(mu *marshalutil.MarshalUtil) (*ScheduledCall, error) {
+	ret := &ScheduledCall{}
+	if err := ret.readFromMarshalUtil(mu); err != nil {
+		return nil, xerrors.Errorf("ScheduledCallFromMarshalUtil: %w", err)
+	}
+	return ret, nil
+}
+
+func (c *ScheduledCall) Bytes() []byte {
+	mu := marshalutil.New()
+	mu.Write(c.Contract).
+		Write(c.EntryPoint).
+		Write(c.Params).
+		WriteInt64(c.Time.UnixNano()).
+		WriteUint32(c.Interval).
+		WriteUint32(c.Remaining).
+		WriteUint64(c.GasBudget).
+		Write(c.Deposit).
+		WriteUint32(c.Executions)
+	return mu.Bytes()
+}
+
+func (c *ScheduledCall) readFromMarshalUtil(mu *marshalutil.MarshalUtil) error {
+	var err error
+	if err = c.Contract.ReadFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if err = c.EntryPoint.ReadFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if c.Params, err = dict.FromMarshalUtil(mu); err != nil {
+		return err
+	}
+	ts, err := mu.ReadInt64()
+	if err != nil {
+		return err
+	}
+	c.Time = time.Unix(0, ts)
+	if c.Interval, err = mu.ReadUint32(); err != nil {
+		return err
+	}
+	if c.Remaining, err = mu.ReadUint32(); err != nil {
+		return err
+	}
+	if c.GasBudget, err = mu.ReadUint64(); err != nil {
+		return err
+	}
+	if c.Deposit, err = colored.BalancesFromMarshalUtil(mu); err != nil {
+		return err
+	}
+	if c.Executions, err = mu.ReadUint32(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AgentID is the account of the contract which scheduled the call
+func (c *ScheduledCall) AgentID(chainID *iscp.ChainID) *iscp.AgentID {
+	return iscp.NewAgentID(chainID.AsAddress(), c.Contract)
+}
+
+// IsDue returns true if the call must be run in a block with the given timestamp
+func (c *ScheduledCall) IsDue(now time.Time) bool {
+	return !c.Time.After(now)
+}
+
+// Advance records one execution of the call due at c.Time, which was run at the given time.
+// For recurring calls the next due time is moved past now, skipping the missed intervals.
+// Returns false if there are no executions left
+func (c *ScheduledCall) Advance(now time.Time) bool {
+	c.Executions++
+	if c.Remaining > 0 {
+		c.Remaining--
+		if c.Remaining == 0 {
+			return false
+		}
+	}
+	if c.Interval == 0 {
+		return false
+	}
+	interval := time.Duration(c.Interval) * time.Second
+	missed := now.Sub(c.Time) / interval
+	c.Time = c.Time.Add((missed + 1) * interval)
+	return true
+}
+
+func (c *ScheduledCall) String() string {
+	return fmt.Sprintf("ScheduledCall::{ ID: %d, contract: %s, entrypoint: %s, time: %v, interval: %ds, remaining: %d, executions: %d, deposit: %s }",
+		c.ID, c.Contract, c.EntryPoint, c.Time, c.Interval, c.Remaining, c.Executions, c.Deposit)
+}
+
+// endregion  /////////////////////////////////////////////////////////////
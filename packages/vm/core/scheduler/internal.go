@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"golang.org/x/xerrors"
+)
+
+func getCallsMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarCalls)
+}
+
+func getCallsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarCalls)
+}
+
+func mustNextCallID(state kv.KVStore) uint32 {
+	id, err := codec.DecodeUint32(state.MustGet(VarNextCallID), 1)
+	if err != nil {
+		panic(xerrors.Errorf("mustNextCallID: %w", err))
+	}
+	state.Set(VarNextCallID, codec.EncodeUint32(id+1))
+	return id
+}
+
+func callFromBytes(id, data []byte) *ScheduledCall {
+	ret, err := ScheduledCallFromBytes(data)
+	if err != nil {
+		panic(xerrors.Errorf("callFromBytes: %w", err))
+	}
+	if ret.ID, err = codec.DecodeUint32(id); err != nil {
+		panic(xerrors.Errorf("callFromBytes: %w", err))
+	}
+	return ret
+}
+
+// GetCall returns the scheduled call with the given id, if it exists
+func GetCall(state kv.KVStoreReader, id uint32) (*ScheduledCall, bool) {
+	key := codec.EncodeUint32(id)
+	data := getCallsMapR(state).MustGetAt(key)
+	if data == nil {
+		return nil, false
+	}
+	return callFromBytes(key, data), true
+}
+
+// SaveCall stores the scheduled call under its id and indexes it by its due time
+func SaveCall(state kv.KVStore, call *ScheduledCall) {
+	key := codec.EncodeUint32(call.ID)
+	calls := getCallsMap(state)
+	if data := calls.MustGetAt(key); data != nil {
+		state.Del(dueIndexKey(callFromBytes(key, data)))
+	} else {
+		mustAddCallCount(state, call.Contract, 1)
+	}
+	calls.MustSetAt(key, call.Bytes())
+	state.Set(dueIndexKey(call), []byte{1})
+}
+
+// DeleteCall removes the scheduled call with the given id
+func DeleteCall(state kv.KVStore, id uint32) {
+	key := codec.EncodeUint32(id)
+	calls := getCallsMap(state)
+	data := calls.MustGetAt(key)
+	if data == nil {
+		return
+	}
+	call := callFromBytes(key, data)
+	state.Del(dueIndexKey(call))
+	mustAddCallCount(state, call.Contract, -1)
+	calls.MustDelAt(key)
+}
+
+// GetCallCount returns the number of calls scheduled by the contract
+func GetCallCount(state kv.KVStoreReader, contract iscp.Hname) uint32 {
+	ret, err := codec.DecodeUint32(collections.NewMapReadOnly(state, VarCallCounts).MustGetAt(contract.Bytes()), 0)
+	if err != nil {
+		panic(xerrors.Errorf("GetCallCount: %w", err))
+	}
+	return ret
+}
+
+func mustAddCallCount(state kv.KVStore, contract iscp.Hname, delta int) {
+	counts := collections.NewMap(state, VarCallCounts)
+	count := int(GetCallCount(state, contract)) + delta
+	if count <= 0 {
+		counts.MustDelAt(contract.Bytes())
+		return
+	}
+	counts.MustSetAt(contract.Bytes(), codec.EncodeUint32(uint32(count)))
+}
+
+// dueIndexKey is the key of the call in the index by due time. The keys sort in the order the calls are due
+func dueIndexKey(call *ScheduledCall) kv.Key {
+	var buf [12]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(call.Time.UnixNano()))
+	binary.BigEndian.PutUint32(buf[8:], call.ID)
+	return kv.Key(VarDueIndex) + kv.Key(buf[:])
+}
+
+// iterateCallsByDueTime calls f for the scheduled calls, the earliest due first, until it returns false.
+// Calls due at the same time are ordered by id
+func iterateCallsByDueTime(state kv.KVStoreReader, f func(*ScheduledCall) bool) {
+	calls := getCallsMapR(state)
+	state.MustIterateKeysSorted(kv.Key(VarDueIndex), func(key kv.Key) bool {
+		id := codec.EncodeUint32(binary.BigEndian.Uint32([]byte(key[len(VarDueIndex)+8:])))
+		data := calls.MustGetAt(id)
+		if data == nil {
+			panic(xerrors.Errorf("iterateCallsByDueTime: inconsistency: indexed call %x not found", id))
+		}
+		return f(callFromBytes(id, data))
+	})
+}
+
+// GetDueCalls returns at most maxCalls calls which are due at the given time,
+// the earliest first. Calls due at the same time are ordered by id
+func GetDueCalls(state kv.KVStoreReader, now time.Time, maxCalls int) []*ScheduledCall {
+	ret := make([]*ScheduledCall, 0)
+	iterateCallsByDueTime(state, func(call *ScheduledCall) bool {
+		if !call.IsDue(now) || len(ret) >= maxCalls {
+			return false
+		}
+		ret = append(ret, call)
+		return true
+	})
+	return ret
+}
+
+// HasDueCalls is true if at least one scheduled call is due at the given time
+func HasDueCalls(state kv.KVStoreReader, now time.Time) bool {
+	ret := false
+	iterateCallsByDueTime(state, func(call *ScheduledCall) bool {
+		ret = call.IsDue(now)
+		return false
+	})
+	return ret
+}
+
+// filterCalls returns the scheduled calls accepted by the filter, ordered by due time and id
+func filterCalls(state kv.KVStoreReader, filter func(*ScheduledCall) bool) []*ScheduledCall {
+	ret := make([]*ScheduledCall, 0)
+	iterateCallsByDueTime(state, func(call *ScheduledCall) bool {
+		if filter(call) {
+			ret = append(ret, call)
+		}
+		return true
+	})
+	return ret
+}
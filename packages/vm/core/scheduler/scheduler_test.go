@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledCallBytes(t *testing.T) {
+	call := &ScheduledCall{
+		Contract:   iscp.Hn("contract"),
+		EntryPoint: iscp.Hn("entrypoint"),
+		Params:     dict.Dict{"a": []byte{1}},
+		Time:       time.Unix(0, 12345),
+		Interval:   10,
+		Remaining:  3,
+		GasBudget:  1000,
+		Deposit:    colored.NewBalancesForIotas(5),
+		Executions: 2,
+	}
+	back, err := ScheduledCallFromBytes(call.Bytes())
+	require.NoError(t, err)
+	require.EqualValues(t, call.Bytes(), back.Bytes())
+	require.True(t, call.Time.Equal(back.Time))
+}
+
+func TestScheduledCallAdvance(t *testing.T) {
+	start := time.Unix(1000, 0)
+
+	oneShot := &ScheduledCall{Time: start, Remaining: 1}
+	require.False(t, oneShot.Advance(start))
+	require.EqualValues(t, 1, oneShot.Executions)
+
+	recurring := &ScheduledCall{Time: start, Interval: 10}
+	require.True(t, recurring.Advance(start.Add(5*time.Second)))
+	require.True(t, recurring.Time.Equal(start.Add(10*time.Second)))
+	// missed executions are skipped
+	require.True(t, recurring.Advance(start.Add(35*time.Second)))
+	require.True(t, recurring.Time.Equal(start.Add(40*time.Second)))
+	require.EqualValues(t, 2, recurring.Executions)
+
+	limited := &ScheduledCall{Time: start, Interval: 10, Remaining: 2}
+	require.True(t, limited.Advance(start))
+	require.False(t, limited.Advance(start.Add(10*time.Second)))
+}
+
+func TestDueCallsIndex(t *testing.T) {
+	state := dict.New()
+	start := time.Unix(1000, 0)
+	contract := iscp.Hn("contract")
+	for i, d := range []time.Duration{30, 10, 20, 10} {
+		SaveCall(state, &ScheduledCall{
+			ID:       uint32(i + 1),
+			Contract: contract,
+			Time:     start.Add(d * time.Second),
+			Deposit:  colored.NewBalances(),
+		})
+	}
+	require.EqualValues(t, 4, GetCallCount(state, contract))
+
+	ids := func(calls []*ScheduledCall) []uint32 {
+		ret := make([]uint32, len(calls))
+		for i, call := range calls {
+			ret[i] = call.ID
+		}
+		return ret
+	}
+	require.Empty(t, GetDueCalls(state, start, 10))
+	require.EqualValues(t, []uint32{2, 4, 3}, ids(GetDueCalls(state, start.Add(20*time.Second), 10)))
+	require.EqualValues(t, []uint32{2, 4}, ids(GetDueCalls(state, start.Add(30*time.Second), 2)))
+
+	// moving a call moves its index entry
+	call, ok := GetCall(state, 2)
+	require.True(t, ok)
+	call.Time = start.Add(40 * time.Second)
+	SaveCall(state, call)
+	require.EqualValues(t, 4, GetCallCount(state, contract))
+	require.EqualValues(t, []uint32{4, 3, 1}, ids(GetDueCalls(state, start.Add(30*time.Second), 10)))
+
+	DeleteCall(state, 4)
+	DeleteCall(state, 4)
+	require.EqualValues(t, 3, GetCallCount(state, contract))
+	require.EqualValues(t, []uint32{3, 1, 2}, ids(GetDueCalls(state, start.Add(40*time.Second), 10)))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
@@ -209,3 +210,237 @@ func TestGetAccountNonce(t *testing.T) {
 
 	require.GreaterOrEqual(t, getAccountNonce(t, chain, userAddress), nowNanoTs)
 }
+
+func TestAccountsBalanceAtBlockIndex(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+
+	userWallet, userAddress := env.NewKeyPairWithFunds()
+	userAgentID := iscp.NewAgentID(userAddress, 0)
+
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(42)
+	_, err := chain.PostRequestSync(req, userWallet)
+	require.NoError(t, err)
+	blockIndex := chain.State.BlockIndex()
+	balance := chain.GetAccountBalance(userAgentID).Get(colored.IOTA)
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(100)
+	_, err = chain.PostRequestSync(req, userWallet)
+	require.NoError(t, err)
+	require.EqualValues(t, balance+100, chain.GetAccountBalance(userAgentID).Get(colored.IOTA))
+
+	ret, err := chain.CallViewAtBlockIndex(blockIndex, accounts.Contract.Name, accounts.FuncViewBalance.Name,
+		accounts.ParamAgentID, userAgentID)
+	require.NoError(t, err)
+	bals, err := accounts.DecodeBalances(ret)
+	require.NoError(t, err)
+	require.EqualValues(t, balance, bals.Get(colored.IOTA))
+
+	ret, err = chain.CallViewAtBlockIndex(0, accounts.Contract.Name, accounts.FuncViewBalance.Name,
+		accounts.ParamAgentID, userAgentID)
+	require.Error(t, err)
+	require.Nil(t, ret)
+
+	_, err = chain.CallViewAtBlockIndex(chain.State.BlockIndex()+1, accounts.Contract.Name, accounts.FuncViewBalance.Name,
+		accounts.ParamAgentID, userAgentID)
+	require.Error(t, err)
+}
+
+func getAccountHistory(t *testing.T, chain *solo.Chain, agentID *iscp.AgentID, params ...interface{}) (uint32, []*accounts.HistoryEntry) {
+	ret, err := chain.CallView(accounts.Contract.Name, accounts.FuncGetAccountHistory.Name,
+		append([]interface{}{accounts.ParamAgentID, agentID}, params...)...,
+	)
+	require.NoError(t, err)
+	length, err := codec.DecodeUint32(ret.MustGet(accounts.ParamHistoryLength), 0)
+	require.NoError(t, err)
+	arr := collections.NewArray16ReadOnly(ret, accounts.ParamHistoryEntries)
+	entries := make([]*accounts.HistoryEntry, arr.MustLen())
+	for i := range entries {
+		entries[i], err = accounts.HistoryEntryFromBytes(arr.MustGetAt(uint16(i)))
+		require.NoError(t, err)
+	}
+	return length, entries
+}
+
+func TestAccountHistory(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+
+	userWallet, userAddress := env.NewKeyPairWithFunds()
+	userAgentID := iscp.NewAgentID(userAddress, 0)
+
+	// the history is disabled by default
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(42)
+	_, err := chain.PostRequestSync(req, userWallet)
+	require.NoError(t, err)
+	length, entries := getAccountHistory(t, chain, userAgentID)
+	require.Zero(t, length)
+	require.Empty(t, entries)
+
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamAccountHistory, true,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+	ret, err := chain.CallView(governance.Contract.Name, governance.FuncGetChainInfo.Name)
+	require.NoError(t, err)
+	accountHistory, err := codec.DecodeBool(ret.MustGet(governance.VarAccountHistory), false)
+	require.NoError(t, err)
+	require.True(t, accountHistory)
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(100)
+	tx, _, err := chain.PostRequestSyncTx(req, userWallet)
+	require.NoError(t, err)
+	depositID := iscp.NewRequestID(tx.ID(), 0)
+	depositBlock := chain.State.BlockIndex()
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncWithdraw.Name).WithIotas(1)
+	_, err = chain.PostRequestSync(req, userWallet)
+	require.NoError(t, err)
+	chain.AssertAccountBalance(userAgentID, colored.IOTA, 0)
+
+	// deposit: credit from the common account. withdraw: credit of the tokens of the request,
+	// debit of all tokens to the common account, which sends them back to the address
+	length, entries = getAccountHistory(t, chain, userAgentID)
+	require.EqualValues(t, 2, length)
+	require.Len(t, entries, 2)
+	require.False(t, entries[0].Credit)
+	require.True(t, entries[0].Counterparty.Equals(chain.CommonAccount()))
+	require.EqualValues(t, 142, entries[0].Balances.Get(colored.IOTA))
+	require.True(t, entries[1].Credit)
+	require.EqualValues(t, depositBlock, entries[1].BlockIndex)
+	require.Equal(t, depositID, entries[1].RequestID)
+	require.True(t, entries[1].Counterparty.Equals(chain.CommonAccount()))
+	require.EqualValues(t, 100, entries[1].Balances.Get(colored.IOTA))
+
+	// paging, the newest entries first
+	length, entries = getAccountHistory(t, chain, userAgentID,
+		accounts.ParamHistoryOffset, uint32(1),
+		accounts.ParamHistoryLimit, uint32(5),
+	)
+	require.EqualValues(t, 2, length)
+	require.Len(t, entries, 1)
+	require.Equal(t, depositID, entries[0].RequestID)
+
+	// disabling the history keeps the recorded entries
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamAccountHistory, false,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(10)
+	_, err = chain.PostRequestSync(req, userWallet)
+	require.NoError(t, err)
+	length, _ = getAccountHistory(t, chain, userAgentID)
+	require.EqualValues(t, 2, length)
+	chain.CheckAccountLedger()
+}
+
+func getAllowance(t *testing.T, chain *solo.Chain, owner, spender *iscp.AgentID) colored.Balances {
+	ret, err := chain.CallView(accounts.Contract.Name, accounts.FuncGetAllowance.Name,
+		accounts.ParamOwner, owner,
+		accounts.ParamSpender, spender,
+	)
+	require.NoError(t, err)
+	allowance, err := accounts.DecodeBalances(ret)
+	require.NoError(t, err)
+	return allowance
+}
+
+func TestAccountsAllowance(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+
+	ownerWallet, ownerAddress := env.NewKeyPairWithFunds()
+	ownerAgentID := iscp.NewAgentID(ownerAddress, 0)
+	spenderWallet, spenderAddress := env.NewKeyPairWithFunds()
+	spenderAgentID := iscp.NewAgentID(spenderAddress, 0)
+	targetAgentID := iscp.NewRandomAgentID()
+
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(100)
+	_, err := chain.PostRequestSync(req, ownerWallet)
+	require.NoError(t, err)
+
+	// the tokens sent with the call are deposited to the owner
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncApprove.Name,
+		accounts.ParamSpender, spenderAgentID,
+		accounts.ParamAmount, 30,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, ownerWallet)
+	require.NoError(t, err)
+	chain.AssertAccountBalance(ownerAgentID, colored.IOTA, 101)
+	require.True(t, getAllowance(t, chain, ownerAgentID, spenderAgentID).Equals(colored.NewBalancesForIotas(30)))
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncTransferFrom.Name,
+		accounts.ParamOwner, ownerAgentID,
+		accounts.ParamTarget, targetAgentID,
+		accounts.ParamAmount, 20,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, spenderWallet)
+	require.NoError(t, err)
+	chain.AssertAccountBalance(ownerAgentID, colored.IOTA, 81)
+	chain.AssertAccountBalance(targetAgentID, colored.IOTA, 20)
+	chain.AssertAccountBalance(spenderAgentID, colored.IOTA, 1)
+	require.True(t, getAllowance(t, chain, ownerAgentID, spenderAgentID).Equals(colored.NewBalancesForIotas(10)))
+
+	// over the allowance
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncTransferFrom.Name,
+		accounts.ParamOwner, ownerAgentID,
+		accounts.ParamAmount, 11,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, spenderWallet)
+	require.Error(t, err)
+	chain.AssertAccountBalance(ownerAgentID, colored.IOTA, 81)
+
+	// only the owner can approve for its account
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncApprove.Name,
+		accounts.ParamSpender, spenderAgentID,
+		accounts.ParamAmount, 1000,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, spenderWallet)
+	require.NoError(t, err)
+	require.True(t, getAllowance(t, chain, ownerAgentID, spenderAgentID).Equals(colored.NewBalancesForIotas(10)))
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncRevoke.Name,
+		accounts.ParamSpender, spenderAgentID,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, ownerWallet)
+	require.NoError(t, err)
+	require.True(t, getAllowance(t, chain, ownerAgentID, spenderAgentID).IsEmpty())
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncTransferFrom.Name,
+		accounts.ParamOwner, ownerAgentID,
+		accounts.ParamAmount, 1,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, spenderWallet)
+	require.Error(t, err)
+	chain.AssertAccountBalance(ownerAgentID, colored.IOTA, 82)
+	chain.CheckAccountLedger()
+}
+
+func TestAccountsAllowanceCoreContract(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+
+	ownerWallet, ownerAddress := env.NewKeyPairWithFunds()
+	ownerAgentID := iscp.NewAgentID(ownerAddress, 0)
+	rootAgentID := iscp.NewAgentID(chain.ChainID.AsAddress(), root.Contract.Hname())
+
+	// core contracts share the common account, as for the balances
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncApprove.Name,
+		accounts.ParamSpender, rootAgentID,
+		accounts.ParamAmount, 30,
+	).WithIotas(1)
+	_, err := chain.PostRequestSync(req, ownerWallet)
+	require.NoError(t, err)
+	require.True(t, getAllowance(t, chain, ownerAgentID, rootAgentID).Equals(colored.NewBalancesForIotas(30)))
+	require.True(t, getAllowance(t, chain, ownerAgentID, chain.CommonAccount()).Equals(colored.NewBalancesForIotas(30)))
+
+	req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncRevoke.Name,
+		accounts.ParamSpender, chain.CommonAccount(),
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, ownerWallet)
+	require.NoError(t, err)
+	require.True(t, getAllowance(t, chain, ownerAgentID, rootAgentID).IsEmpty())
+	chain.CheckAccountLedger()
+}
@@ -79,8 +79,8 @@ func TestOkCall(t *testing.T) {
 	env.EnablePublisher(true)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0)
 	req.WithIotas(2)
 	_, err := chain.PostRequestSync(req, nil)
 	require.NoError(t, err)
@@ -95,8 +95,8 @@ func TestNoTokens(t *testing.T) {
 	chain := env.NewChain(nil, "chain1")
 	chain.CheckControlAddresses()
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0)
 	_, err := chain.PostRequestSync(req, nil)
 	require.Error(t, err)
 	chain.CheckControlAddresses()
@@ -4,10 +4,14 @@ import (
 	"testing"
 
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
+	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
+	"github.com/iotaledger/wasp/packages/publisher"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/vm/core"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/stretchr/testify/require"
@@ -94,8 +98,8 @@ func TestRequestIsProcessed(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0).WithIotas(1)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0).WithIotas(1)
 	tx, _, err := chain.PostRequestSyncTx(req, nil)
 	require.NoError(t, err)
 
@@ -110,8 +114,8 @@ func TestRequestLogRecord(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0).WithIotas(1)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0).WithIotas(1)
 	tx, _, err := chain.PostRequestSyncTx(req, nil)
 	require.NoError(t, err)
 
@@ -135,8 +139,8 @@ func TestRequestLogRecordsForBlocks(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0).WithIotas(1)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0).WithIotas(1)
 	tx, _, err := chain.PostRequestSyncTx(req, nil)
 	require.NoError(t, err)
 
@@ -155,8 +159,8 @@ func TestRequestIDsForBlocks(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 0, governance.ParamValidatorFee, 0).WithIotas(1)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 0, governance.ParamGasPrice, 0).WithIotas(1)
 	tx, _, err := chain.PostRequestSyncTx(req, nil)
 	require.NoError(t, err)
 
@@ -270,4 +274,97 @@ func TestGetEvents(t *testing.T) {
 	require.Contains(t, events[1], "counter = 1")
 }
 
+func TestBlockEvents(t *testing.T) {
+	env := solo.New(t, false, false).WithNativeContract(inccounter.Processor)
+	ch := env.NewChain(nil, "chain1")
+
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+	reqID := incrementSCCounter(t, ch)
+	blockIndex := ch.State.BlockIndex()
+
+	evts, ok, err := chain.BlockEvents(ch.ChainID, ch.State.KVStoreReader(), blockIndex)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, evts, 3)
+	for i, evt := range evts {
+		require.EqualValues(t, ch.ChainID.Base58(), evt.ChainID)
+		require.EqualValues(t, publisher.Cursor{BlockIndex: blockIndex, EventIndex: uint32(i)}, evt.Cursor)
+	}
+	require.EqualValues(t, publisher.ChainEventRequestReceipt, evts[0].Type)
+	receipt := evts[0].Payload.(*publisher.RequestReceipt)
+	require.EqualValues(t, reqID.String(), receipt.RequestID)
+	require.EqualValues(t, inccounter.Contract.Hname().String(), receipt.Contract)
+	require.Empty(t, receipt.Error)
+	require.EqualValues(t, publisher.ChainEventContractEvent, evts[1].Type)
+	contractEvent := evts[1].Payload.(*publisher.ContractEvent)
+	require.EqualValues(t, inccounter.Contract.Hname().String(), contractEvent.Contract)
+	require.Contains(t, contractEvent.Message, "counter = 1")
+	require.EqualValues(t, publisher.ChainEventBlockCommitted, evts[2].Type)
+	require.EqualValues(t, 1, evts[2].Payload.(*publisher.BlockCommitted).TotalRequests)
+
+	_, ok, err = chain.BlockEvents(ch.ChainID, ch.State.KVStoreReader(), blockIndex+1)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 /// end region ----------------------------------------------------------------
+
+func TestPruneRequestLogs(t *testing.T) {
+	env := solo.New(t, false, false).WithNativeContract(inccounter.Processor)
+	chain := env.NewChain(nil, "chain1")
+
+	err := chain.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+
+	// the retention is raised to the minimum
+	_, err = chain.PostRequestSync(solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamBlockKeepAmount, uint32(1),
+	).WithIotas(1), nil)
+	require.NoError(t, err)
+	res, err := chain.CallView(governance.Contract.Name, governance.FuncGetChainInfo.Name)
+	require.NoError(t, err)
+	d := kvdecoder.New(res)
+	require.EqualValues(t, governance.MinBlockKeepAmount, d.MustGetUint32(governance.VarBlockKeepAmount))
+
+	userWallet, _ := env.NewKeyPairWithFunds()
+	_, err = chain.PostRequestSync(solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(1000), userWallet)
+	require.NoError(t, err)
+	_, err = chain.PostRequestOffLedger(solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name), userWallet)
+	require.NoError(t, err)
+	offLedgerBlockIndex := chain.State.BlockIndex()
+	offLedgerReqIDs := chain.GetRequestIDsForBlock(offLedgerBlockIndex)
+	require.Len(t, offLedgerReqIDs, 1)
+
+	firstReqID := incrementSCCounter(t, chain)
+	firstBlockIndex := chain.State.BlockIndex()
+	for i := uint32(0); i < governance.MinBlockKeepAmount; i++ {
+		incrementSCCounter(t, chain)
+	}
+
+	res, err = chain.CallView(blocklog.Contract.Name, blocklog.FuncGetPruningInfo.Name)
+	require.NoError(t, err)
+	d = kvdecoder.New(res)
+	require.Greater(t, d.MustGetUint32(blocklog.ParamPrunedUntil), firstBlockIndex)
+	require.Greater(t, d.MustGetUint64(blocklog.ParamPrunedBytes), uint64(0))
+
+	// receipts and events of old blocks are pruned
+	require.False(t, chain.IsRequestProcessed(firstReqID))
+	require.Nil(t, chain.GetRequestReceiptsForBlock(firstBlockIndex))
+	require.Empty(t, getEventsForRequest(t, chain, firstReqID))
+	require.Len(t, getEventsForSC(t, chain, 0, int32(firstBlockIndex)), 0)
+
+	// the off-ledger request is still needed by the replay protection
+	require.True(t, chain.IsRequestProcessed(offLedgerReqIDs[0]))
+
+	// the latest blocks are kept
+	latest := chain.State.BlockIndex()
+	require.Len(t, chain.GetRequestReceiptsForBlock(latest), 1)
+	require.Len(t, getEventsForBlock(t, chain), 1)
+	require.NotEmpty(t, getEventsForSC(t, chain, int32(latest-10), int32(latest)))
+
+	// the block infos are never pruned
+	bi, err := chain.GetBlockInfo(firstBlockIndex)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, bi.TotalRequests)
+}
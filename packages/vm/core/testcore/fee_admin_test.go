@@ -9,229 +9,189 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/solo"
-	"github.com/iotaledger/wasp/packages/vm/core/accounts"
-	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
-	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/stretchr/testify/require"
 )
 
-func checkFees(chain *solo.Chain, contract string, expectedOf, expectedVf uint64) {
-	col, ownerFee, validatorFee := chain.GetFeeInfo(contract)
-	require.EqualValues(chain.Env.T, colored.IOTA, col)
-	require.EqualValues(chain.Env.T, int(expectedOf), int(ownerFee))
-	require.EqualValues(chain.Env.T, int(expectedVf), int(validatorFee))
+func checkFees(chain *solo.Chain, expectedGasPrice, expectedMinFee uint64, expectedShare uint16) {
+	policy := chain.GetFeePolicy()
+	require.EqualValues(chain.Env.T, colored.IOTA, policy.FeeColor)
+	require.EqualValues(chain.Env.T, int(expectedGasPrice), int(policy.GasPrice))
+	require.EqualValues(chain.Env.T, int(expectedMinFee), int(policy.MinFee))
+	require.EqualValues(chain.Env.T, int(expectedShare), int(policy.ValidatorFeeShare))
 }
 
 func TestFeeBasic(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	checkFees(chain, 0, 0, 0)
 
 	chain.AssertCommonAccountIotas(1)
 	chain.AssertTotalIotas(1)
 }
 
-func TestSetDefaultFeeNotAuthorized(t *testing.T) {
+func TestSetFeePolicyNotAuthorized(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
 	user, _ := env.NewKeyPairWithFunds()
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name, governance.ParamOwnerFee, 1000)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name, governance.ParamMinFee, 1000)
 	_, err := chain.PostRequestSync(req, user)
 	require.Error(t, err)
 
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	checkFees(chain, 0, 0, 0)
 
 	chain.AssertCommonAccountIotas(1)
 	chain.AssertTotalIotas(1)
 }
 
-func TestSetContractFeeNotAuthorized(t *testing.T) {
+func TestSetMinFeeOk(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	user, _ := env.NewKeyPairWithFunds()
-
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name, governance.ParamOwnerFee, 1000)
-	_, err := chain.PostRequestSync(req, user)
-	require.Error(t, err)
-
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
-
-	chain.AssertCommonAccountIotas(1)
-	chain.AssertTotalIotas(1)
-}
-
-func TestSetDefaultOwnerFeeOk(t *testing.T) {
-	env := solo.New(t, false, false)
-	chain := env.NewChain(nil, "chain1")
-
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 1000,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 1000,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
-	checkFees(chain, root.Contract.Name, 1000, 0)
-	checkFees(chain, accounts.Contract.Name, 1000, 0)
-	checkFees(chain, blob.Contract.Name, 1000, 0)
+	checkFees(chain, 0, 1000, 0)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(2)
 }
 
-func TestSetDefaultValidatorFeeOk(t *testing.T) {
+func TestSetGasPriceOk(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamValidatorFee, 499,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 3,
+		governance.ParamValidatorFeeShare, 20,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
-	checkFees(chain, root.Contract.Name, 0, 499)
-	checkFees(chain, accounts.Contract.Name, 0, 499)
-	checkFees(chain, blob.Contract.Name, 0, 499)
+	checkFees(chain, 3, 0, 20)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(2)
 }
 
-func TestSetDefaultFeeOk(t *testing.T) {
+func TestSetGasPricePerColor(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 1000,
-		governance.ParamValidatorFee, 499,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 3,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
-	checkFees(chain, root.Contract.Name, 1000, 499)
-	checkFees(chain, accounts.Contract.Name, 1000, 499)
-	checkFees(chain, blob.Contract.Name, 1000, 499)
-
-	chain.AssertCommonAccountIotas(2)
-	chain.AssertTotalIotas(2)
-}
+	checkFees(chain, 3, 0, 0)
 
-func TestSetDefaultFeeFailNegative1(t *testing.T) {
-	env := solo.New(t, false, false)
-	chain := env.NewChain(nil, "chain1")
-
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name, governance.ParamOwnerFee, -2)
-	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
+	col := colored.ColorRandom()
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamFeeColor, col,
+		governance.ParamGasPrice, 7,
+	)
+	_, err = chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	policy := chain.GetFeePolicy()
+	require.EqualValues(t, col, policy.FeeColor)
+	require.EqualValues(t, 7, policy.GasPrice)
 
-	chain.AssertCommonAccountIotas(2)
-	chain.AssertTotalIotas(2)
+	// switching back to IOTA restores the gas price set for IOTA
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamFeeColor, colored.IOTA,
+	)
+	_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+	checkFees(chain, 3, 0, 0)
 }
 
-func TestSetDefaultFeeFailNegative2(t *testing.T) {
+func TestSetFeePolicyNegative(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name, governance.ParamValidatorFee, -100)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, -2,
+		governance.ParamGasPrice, -100,
+	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	checkFees(chain, 0, 0, 0)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(2)
 }
 
-func TestSetContractValidatorFeeOk(t *testing.T) {
+func TestSetValidatorFeeShareTooBig(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamValidatorFee, 1000,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamValidatorFeeShare, 101,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-	require.NoError(t, err)
-
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 1000)
+	require.Error(t, err)
 
-	chain.AssertCommonAccountIotas(2)
-	chain.AssertTotalIotas(2)
+	checkFees(chain, 0, 0, 0)
 }
 
-func TestSetContractOwnerFeeOk(t *testing.T) {
+func TestRevertFeePolicyToZero(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, accounts.Contract.Hname(),
-		governance.ParamOwnerFee, 499,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 1000,
+		governance.ParamMinFee, 1000,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
+	checkFees(chain, 1000, 1000, 0)
 
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 499, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 0,
+		governance.ParamMinFee, 0,
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+	checkFees(chain, 0, 0, 0)
 
-	chain.AssertCommonAccountIotas(2)
-	chain.AssertTotalIotas(2)
+	chain.AssertCommonAccountIotas(3)
+	chain.AssertTotalIotas(3)
 }
 
-func TestSetContractFeeWithDefault(t *testing.T) {
+func TestEstimateFee(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamValidatorFee, 1000,
+	col, fee := chain.EstimateFee(0)
+	require.EqualValues(t, colored.IOTA, col)
+	require.EqualValues(t, 0, fee)
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 2,
+		governance.ParamMinFee, 10,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 1000)
-
-	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 499,
-	).WithIotas(1)
-	_, err = chain.PostRequestSync(req, nil)
-	require.NoError(t, err)
-
-	checkFees(chain, root.Contract.Name, 499, 0)
-	checkFees(chain, accounts.Contract.Name, 499, 0)
-	checkFees(chain, blob.Contract.Name, 499, 1000)
+	_, fee = chain.EstimateFee(0)
+	require.EqualValues(t, gas.DefaultGasBudget*2/governance.GasPriceUnit, fee)
 
-	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name, governance.ParamValidatorFee, 1999).WithIotas(1)
-	//.WithTransfers(
-	//		map[ledgerstate.Color]uint64{
-	//			ledgerstate.ColorIOTA: 800,
-	//		},
-	//	)
-	_, err = chain.PostRequestSync(req, nil)
-	require.NoError(t, err)
+	_, fee = chain.EstimateFee(100_000)
+	require.EqualValues(t, 200, fee)
 
-	checkFees(chain, root.Contract.Name, 499, 1999)
-	checkFees(chain, accounts.Contract.Name, 499, 1999)
-	checkFees(chain, blob.Contract.Name, 499, 1000)
+	// never less than the minimum fee
+	_, fee = chain.EstimateFee(1)
+	require.EqualValues(t, 10, fee)
 
-	chain.AssertCommonAccountIotas(4)
-	chain.AssertTotalIotas(4)
+	// the budget is capped
+	_, fee = chain.EstimateFee(gas.MaxGasPerRequest * 2)
+	require.EqualValues(t, gas.MaxGasPerRequest*2/governance.GasPriceUnit, fee)
 }
 
 func TestFeeNotEnough(t *testing.T) {
@@ -240,30 +200,26 @@ func TestFeeNotEnough(t *testing.T) {
 	validatorFeeTargetAgentID := iscp.NewAgentID(validatorFeeTargetAddr, 0)
 
 	chain := env.NewChain(nil, "chain1", validatorFeeTargetAgentID)
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, governance.Contract.Hname(),
-		governance.ParamValidatorFee, 499,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 499,
+		governance.ParamValidatorFeeShare, 100,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, governance.Contract.Name, 0, 499)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	checkFees(chain, 0, 499, 100)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(2)
 
 	user, _ := env.NewKeyPairWithFunds()
-	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 1000,
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 1000,
 	).WithIotas(99)
 	_, err = chain.PostRequestSync(req, user)
 	require.Error(t, err)
 
-	checkFees(chain, governance.Contract.Name, 0, 499)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
+	checkFees(chain, 0, 499, 100)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(101)
@@ -274,57 +230,20 @@ func TestFeeOwnerDontNeed(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, governance.Contract.Hname(),
-		governance.ParamValidatorFee, 499,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 499,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, governance.Contract.Name, 0, 499)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 0, 0)
-
-	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
-		governance.ParamOwnerFee, 1000,
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 1000,
 	).WithIotas(99)
 	_, err = chain.PostRequestSync(req, nil)
 	require.NoError(t, err)
 
-	checkFees(chain, governance.Contract.Name, 1000, 499)
-	checkFees(chain, accounts.Contract.Name, 1000, 0)
-	checkFees(chain, blob.Contract.Name, 1000, 0)
+	checkFees(chain, 0, 1000, 0)
 
 	chain.AssertCommonAccountIotas(101)
 	chain.AssertTotalIotas(101)
 }
-
-func TestRevertContractFeeToZero(t *testing.T) {
-	env := solo.New(t, false, false)
-	chain := env.NewChain(nil, "chain1")
-
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamValidatorFee, 1000,
-		governance.ParamOwnerFee, 1000,
-	)
-	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-	require.NoError(t, err)
-
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
-	checkFees(chain, blob.Contract.Name, 1000, 1000)
-
-	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamValidatorFee, 0,
-		governance.ParamOwnerFee, 0,
-	).WithIotas(1)
-	_, err = chain.PostRequestSync(req, nil)
-	require.NoError(t, err)
-
-	checkFees(chain, blob.Contract.Name, 0, 0)
-
-	chain.AssertCommonAccountIotas(3)
-	chain.AssertTotalIotas(3)
-}
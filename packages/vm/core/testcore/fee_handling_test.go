@@ -10,10 +10,8 @@ import (
 
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/solo"
-	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
-	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,18 +25,15 @@ func TestInit(t *testing.T) {
 	chain.AssertTotalIotas(1)
 	chain.AssertCommonAccountIotas(1)
 
-	checkFees(chain, blob.Contract.Name, 0, 0)
-	checkFees(chain, root.Contract.Name, 0, 0)
-	checkFees(chain, accounts.Contract.Name, 0, 0)
+	checkFees(chain, 0, 0, 0)
 }
 
 func TestBase(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamOwnerFee, 5,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 5,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
@@ -47,7 +42,7 @@ func TestBase(t *testing.T) {
 	chain.AssertTotalIotas(2)
 	env.AssertAddressBalance(chain.OriginatorAddress, colored.IOTA, solo.Saldo-solo.ChainDustThreshold-2)
 
-	checkFees(chain, blob.Contract.Name, 5, 0)
+	checkFees(chain, 0, 5, 0)
 }
 
 //nolint:dupl
@@ -55,9 +50,8 @@ func TestFeeIsEnough1(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamOwnerFee, 1,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 1,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
@@ -66,7 +60,7 @@ func TestFeeIsEnough1(t *testing.T) {
 	chain.AssertTotalIotas(2)
 	env.AssertAddressBalance(chain.OriginatorAddress, colored.IOTA, solo.Saldo-solo.ChainDustThreshold-2)
 
-	checkFees(chain, blob.Contract.Name, 1, 0)
+	checkFees(chain, 0, 1, 0)
 
 	// the upload blob takes fees itself
 	_, err = chain.UploadBlob(nil,
@@ -86,9 +80,8 @@ func TestFeeIsEnough2(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamOwnerFee, 10,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 10,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
@@ -97,7 +90,7 @@ func TestFeeIsEnough2(t *testing.T) {
 	chain.AssertTotalIotas(2)
 	env.AssertAddressBalance(chain.OriginatorAddress, colored.IOTA, solo.Saldo-solo.ChainDustThreshold-2)
 
-	checkFees(chain, blob.Contract.Name, 10, 0)
+	checkFees(chain, 0, 10, 0)
 
 	// the upload blob takes fees itself
 	_, err = chain.UploadBlob(nil,
@@ -116,9 +109,8 @@ func TestFeesNoNeed(t *testing.T) {
 	env := solo.New(t, false, false)
 	chain := env.NewChain(nil, "chain1")
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamOwnerFee, 10,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 10,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
@@ -127,7 +119,7 @@ func TestFeesNoNeed(t *testing.T) {
 	chain.AssertTotalIotas(2)
 	env.AssertAddressBalance(chain.OriginatorAddress, colored.IOTA, solo.Saldo-solo.ChainDustThreshold-2)
 
-	checkFees(chain, blob.Contract.Name, 10, 0)
+	checkFees(chain, 0, 10, 0)
 
 	req = solo.NewCallParams(blob.Contract.Name, blob.FuncStoreBlob.Name, "par1", []byte("data1"))
 	req.WithIotas(7)
@@ -147,14 +139,13 @@ func TestFeesNotEnough(t *testing.T) {
 	user, userAddr := env.NewKeyPairWithFunds()
 	userAgentID := iscp.NewAgentID(userAddr, 0)
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, blob.Contract.Hname(),
-		governance.ParamOwnerFee, 10,
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 10,
 	)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
-	checkFees(chain, blob.Contract.Name, 10, 0)
+	checkFees(chain, 0, 10, 0)
 
 	chain.AssertCommonAccountIotas(2)
 	chain.AssertTotalIotas(2)
@@ -171,3 +162,36 @@ func TestFeesNotEnough(t *testing.T) {
 	chain.AssertIotas(userAgentID, 0)
 	env.AssertAddressIotas(userAddr, solo.Saldo-7)
 }
+
+func TestFeesGasPrice(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := iscp.NewAgentID(userAddr, 0)
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamGasPrice, 1,
+	)
+	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+
+	checkFees(chain, 1, 0, 0)
+
+	chain.AssertCommonAccountIotas(2)
+	chain.AssertTotalIotas(2)
+
+	const transfer = 100_000
+	req = solo.NewCallParams(blob.Contract.Name, blob.FuncStoreBlob.Name, "par1", []byte("data1"))
+	req.WithIotas(transfer)
+	_, err = chain.PostRequestSync(req, user)
+	require.NoError(t, err)
+
+	// the fee is proportional to the gas burned, the rest of the reserve is returned to the sender account
+	fee := chain.GetAccountBalance(chain.CommonAccount()).Get(colored.IOTA) - 2
+	require.Greater(t, fee, uint64(0))
+	require.Less(t, fee, uint64(transfer))
+	chain.AssertIotas(userAgentID, transfer-fee)
+	chain.AssertTotalIotas(2 + transfer)
+	env.AssertAddressIotas(userAddr, solo.Saldo-transfer)
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/vm/core"
@@ -205,3 +206,49 @@ func TestAccessNodes(t *testing.T) {
 	require.Empty(t, getChainNodesResponse.AccessNodeCandidates)
 	require.Empty(t, getChainNodesResponse.AccessNodes)
 }
+
+func TestWasmLimits(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+	defer chain.Log.Sync()
+
+	getWasmLimits := func() (memoryPages, tableSize, callDepth uint32) {
+		ret, err := chain.CallView(governance.Contract.Name, governance.FuncGetChainInfo.Name)
+		require.NoError(t, err)
+		memoryPages, err = codec.DecodeUint32(ret.MustGet(governance.VarWasmMaxMemoryPages))
+		require.NoError(t, err)
+		tableSize, err = codec.DecodeUint32(ret.MustGet(governance.VarWasmMaxTableSize))
+		require.NoError(t, err)
+		callDepth, err = codec.DecodeUint32(ret.MustGet(governance.VarWasmMaxCallDepth))
+		require.NoError(t, err)
+		return memoryPages, tableSize, callDepth
+	}
+
+	memoryPages, tableSize, callDepth := getWasmLimits()
+	require.EqualValues(t, governance.DefaultWasmMaxMemoryPages, memoryPages)
+	require.EqualValues(t, governance.DefaultWasmMaxTableSize, tableSize)
+	require.EqualValues(t, governance.DefaultWasmMaxCallDepth, callDepth)
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamWasmMaxMemoryPages, uint32(512),
+		governance.ParamWasmMaxCallDepth, uint32(1),
+	).WithIotas(1)
+	_, err := chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+
+	// limits below the minimums are raised to the minimums
+	memoryPages, tableSize, callDepth = getWasmLimits()
+	require.EqualValues(t, 512, memoryPages)
+	require.EqualValues(t, governance.DefaultWasmMaxTableSize, tableSize)
+	require.EqualValues(t, governance.MinWasmMaxCallDepth, callDepth)
+
+	// only the chain owner can change the limits
+	userWallet, _ := env.NewKeyPairWithFunds()
+	req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetChainInfo.Name,
+		governance.ParamWasmMaxTableSize, uint32(1000),
+	).WithIotas(1)
+	_, err = chain.PostRequestSync(req, userWallet)
+	require.Error(t, err)
+	_, tableSize, _ = getWasmLimits()
+	require.EqualValues(t, governance.DefaultWasmMaxTableSize, tableSize)
+}
@@ -4,9 +4,13 @@
 package testcore
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/vm/core"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
@@ -160,3 +164,112 @@ func TestChangeOwnerUnauthorized(t *testing.T) {
 	_, ownerAgentID, _ := chain.GetInfo()
 	require.EqualValues(t, chain.OriginatorAgentID, ownerAgentID)
 }
+
+const (
+	varVersion = "v"
+	varValue   = "n"
+)
+
+var (
+	upgradeTestV1     = coreutil.NewContract("upgradetest_v1", "Upgrade test, version 1")
+	upgradeTestV2     = coreutil.NewContract("upgradetest_v2", "Upgrade test, version 2")
+	funcMigrate       = coreutil.Func(iscp.FuncMigrate)
+	funcGetVersion    = coreutil.ViewFunc("getVersion")
+	upgradeTestProcV1 = upgradeTestV1.Processor(func(ctx iscp.Sandbox) (dict.Dict, error) {
+		ctx.State().Set(varVersion, codec.EncodeInt64(1))
+		ctx.State().Set(varValue, ctx.Params().MustGet(varValue))
+		return nil, nil
+	},
+		funcGetVersion.WithHandler(getVersion),
+	)
+	upgradeTestProcV2 = upgradeTestV2.Processor(nil,
+		funcMigrate.WithHandler(func(ctx iscp.Sandbox) (dict.Dict, error) {
+			ctx.State().Set(varVersion, codec.EncodeInt64(2))
+			return nil, nil
+		}),
+		funcGetVersion.WithHandler(getVersion),
+	)
+)
+
+func getVersion(ctx iscp.SandboxView) (dict.Dict, error) {
+	return dict.Dict{
+		varVersion: ctx.State().MustGet(varVersion),
+		varValue:   ctx.State().MustGet(varValue),
+	}, nil
+}
+
+func TestUpgradeContract(t *testing.T) {
+	env := solo.New(t, false, false).
+		WithNativeContract(upgradeTestProcV1).
+		WithNativeContract(upgradeTestProcV2).
+		WithNativeContract(sbtestsc.Processor)
+	chain := env.NewChain(nil, "chain1")
+
+	name := "upgradable"
+	err := chain.DeployContract(nil, name, upgradeTestV1.ProgramHash, varValue, 42)
+	require.NoError(t, err)
+
+	checkVersion := func(version int64) {
+		res, err := chain.CallView(name, funcGetVersion.Name)
+		require.NoError(t, err)
+		v, err := codec.DecodeInt64(res.MustGet(varVersion))
+		require.NoError(t, err)
+		require.EqualValues(t, version, v)
+		n, err := codec.DecodeInt64(res.MustGet(varValue))
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+	}
+	checkVersion(1)
+
+	// only the creator of the contract or the chain owner can upgrade it
+	user, _ := env.NewKeyPairWithFunds()
+	err = chain.UpgradeContract(user, name, upgradeTestV2.ProgramHash)
+	require.Error(t, err)
+	checkVersion(1)
+
+	// 'migrate' can only be called by the root contract
+	req := solo.NewCallParams(name, iscp.FuncMigrate).WithIotas(1)
+	_, err = chain.PostRequestSync(req, nil)
+	require.Error(t, err)
+
+	err = chain.UpgradeContract(nil, name, upgradeTestV2.ProgramHash)
+	require.NoError(t, err)
+	checkVersion(2)
+
+	rec, err := chain.FindContract(name)
+	require.NoError(t, err)
+	require.EqualValues(t, upgradeTestV2.ProgramHash, rec.ProgramHash)
+	require.True(t, chain.OriginatorAgentID.Equals(rec.Creator))
+
+	// the 'migrate' entry point is optional
+	err = chain.UpgradeContract(nil, name, sbtestsc.Contract.ProgramHash)
+	require.NoError(t, err)
+	rec, err = chain.FindContract(name)
+	require.NoError(t, err)
+	require.EqualValues(t, sbtestsc.Contract.ProgramHash, rec.ProgramHash)
+
+	// core contracts can't be upgraded
+	err = chain.UpgradeContract(nil, accounts.Contract.Name, sbtestsc.Contract.ProgramHash)
+	require.Error(t, err)
+
+	events, err := chain.GetEventsForContract(root.Contract.Name)
+	require.NoError(t, err)
+	upgrades := 0
+	for _, e := range events {
+		if strings.Contains(e, "[upgrade]") {
+			upgrades++
+		}
+	}
+	require.EqualValues(t, 2, upgrades)
+
+	// the replaced programs are kept in the state of the root contract
+	history, err := chain.GetProgramHistory(name)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.EqualValues(t, upgradeTestV1.ProgramHash, history[0].ProgramHash)
+	require.EqualValues(t, upgradeTestV2.ProgramHash, history[1].ProgramHash)
+	require.False(t, history[1].ReplacedAt.Before(history[0].ReplacedAt))
+	history, err = chain.GetProgramHistory(accounts.Contract.Name)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -0,0 +1,73 @@
+package sbtests
+
+import (
+	"testing"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/testcore/sbtests/sbtestsc"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) { run2(t, testDryRun) }
+func testDryRun(t *testing.T, w bool) {
+	env, chain := setupChain(t, nil)
+	setupTestSandboxSC(t, chain, nil, w)
+
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := iscp.NewAgentID(userAddr, 0)
+	_, err := chain.PostRequestSync(solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).WithIotas(100), user)
+	require.NoError(t, err)
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 5,
+	)
+	_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+
+	blockIndex := chain.GetLatestBlockInfo().BlockIndex
+	balance := chain.GetAccountBalance(userAgentID).Get(colored.IOTA)
+
+	req = solo.NewCallParams(ScName, sbtestsc.FuncEventLogEventData.Name)
+	res, err := chain.DryRunRequestOffLedger(req.WithIotas(10), user)
+	require.NoError(t, err)
+	require.NoError(t, res.Error)
+	require.Len(t, res.Events, 1)
+	require.EqualValues(t, HScName, res.Events[0].Contract)
+	require.Contains(t, res.Events[0].Message, "Testing Event")
+	require.Greater(t, res.GasBurned, uint64(0))
+	require.EqualValues(t, gas.DefaultGasBudget, res.GasBudget)
+	require.EqualValues(t, colored.IOTA, res.FeeColor)
+	require.EqualValues(t, 5, res.Fee)
+	require.Empty(t, res.Outputs)
+
+	// nothing has been committed
+	require.EqualValues(t, blockIndex, chain.GetLatestBlockInfo().BlockIndex)
+	require.EqualValues(t, balance, chain.GetAccountBalance(userAgentID).Get(colored.IOTA))
+
+	// on-ledger requests are run without posting them to the ledger
+	l1Balance := env.GetAddressBalance(userAddr, colored.IOTA)
+	res, err = chain.DryRunRequestOnLedger(req.WithIotas(10), user)
+	require.NoError(t, err)
+	require.NoError(t, res.Error)
+	require.Len(t, res.Events, 1)
+	require.Contains(t, res.Events[0].Message, "Testing Event")
+	require.EqualValues(t, 5, res.Fee)
+	require.EqualValues(t, blockIndex, chain.GetLatestBlockInfo().BlockIndex)
+	require.EqualValues(t, l1Balance, env.GetAddressBalance(userAddr, colored.IOTA))
+
+	// the dry run reports the error the request would fail with
+	req = solo.NewCallParams(ScName, sbtestsc.FuncSetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp",
+		sbtestsc.ParamIntParamValue, 314)
+	res, err = chain.DryRunRequestOffLedger(req.WithIotas(10).WithGasBudget(1), user)
+	require.NoError(t, err)
+	require.Error(t, res.Error)
+	require.Contains(t, res.Error.Error(), gas.ErrNotEnoughGas.Error())
+	require.Empty(t, res.Events)
+	require.EqualValues(t, 1, res.GasBurned)
+}
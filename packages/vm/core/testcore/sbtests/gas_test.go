@@ -0,0 +1,82 @@
+package sbtests
+
+import (
+	"testing"
+
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/testcore/sbtests/sbtestsc"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasBurned(t *testing.T) { run2(t, testGasBurned) }
+func testGasBurned(t *testing.T, w bool) {
+	_, chain := setupChain(t, nil)
+	setupTestSandboxSC(t, chain, nil, w)
+
+	req := solo.NewCallParams(ScName, sbtestsc.FuncSetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp",
+		sbtestsc.ParamIntParamValue, 314)
+	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+
+	recs := chain.GetRequestReceiptsForBlock(chain.GetLatestBlockInfo().BlockIndex)
+	require.Len(t, recs, 1)
+	require.EqualValues(t, gas.DefaultGasBudget, recs[0].GasBudget)
+	require.Greater(t, recs[0].GasBurned, gas.StorageWrite(len("ppp")))
+	require.Less(t, recs[0].GasBurned, recs[0].GasBudget)
+}
+
+func TestGasBudgetExceeded(t *testing.T) { run2(t, testGasBudgetExceeded) }
+func testGasBudgetExceeded(t *testing.T, w bool) {
+	_, chain := setupChain(t, nil)
+	setupTestSandboxSC(t, chain, nil, w)
+
+	req := solo.NewCallParams(ScName, sbtestsc.FuncSetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp",
+		sbtestsc.ParamIntParamValue, 1)
+	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+
+	req = solo.NewCallParams(ScName, sbtestsc.FuncSetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp",
+		sbtestsc.ParamIntParamValue, 314)
+	_, err = chain.PostRequestSync(req.WithIotas(1).WithGasBudget(gas.StorageWriteBase), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), gas.ErrNotEnoughGas.Error())
+
+	recs := chain.GetRequestReceiptsForBlock(chain.GetLatestBlockInfo().BlockIndex)
+	require.Len(t, recs, 1)
+	require.EqualValues(t, gas.StorageWriteBase, recs[0].GasBudget)
+	require.EqualValues(t, gas.StorageWriteBase, recs[0].GasBurned)
+
+	// state update of the request was rolled back
+	ret, err := chain.CallView(ScName, sbtestsc.FuncGetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp")
+	require.NoError(t, err)
+	retInt, err := codec.DecodeInt64(ret.MustGet("ppp"))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, retInt)
+}
+
+func TestGasBudgetExceededOffLedger(t *testing.T) {
+	env, chain := setupChain(t, nil)
+	setupTestSandboxSC(t, chain, nil, false)
+
+	user, _ := env.NewKeyPairWithFunds()
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+	_, err := chain.PostRequestSync(req.WithIotas(10), user)
+	require.NoError(t, err)
+
+	req = solo.NewCallParams(ScName, sbtestsc.FuncSetInt.Name,
+		sbtestsc.ParamIntParamName, "ppp",
+		sbtestsc.ParamIntParamValue, 314)
+	_, err = chain.PostRequestOffLedger(req.WithGasBudget(1), user)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), gas.ErrNotEnoughGas.Error())
+
+	_, err = chain.PostRequestOffLedger(req.WithGasBudget(0), user)
+	require.NoError(t, err)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
-	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/core/testcore/sbtests/sbtestsc"
 	"github.com/stretchr/testify/require"
 )
@@ -93,22 +92,21 @@ func TestOffLedgerFeesEnough(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(10), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(10), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 10)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10), user)
 		require.NoError(t, err)
@@ -129,22 +127,21 @@ func TestOffLedgerFeesNotEnough(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(9), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(9), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 9)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10), user)
 		require.Error(t, err)
@@ -166,22 +163,21 @@ func TestOffLedgerFeesExtra(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(11), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(11), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 11)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10), user)
 		require.NoError(t, err)
@@ -202,22 +198,21 @@ func TestOffLedgerTransferWithFeesEnough(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(10+42), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(10+42), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 10+42)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10+42), user)
 		require.NoError(t, err)
@@ -238,22 +233,21 @@ func TestOffLedgerTransferWithFeesNotEnough(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(10+41), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(10+41), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 10+41)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10+42), user)
 		require.NoError(t, err)
@@ -274,22 +268,21 @@ func TestOffLedgerTransferWithFeesExtra(t *testing.T) {
 		cAID, extraToken := setupTestSandboxSC(t, chain, nil, w)
 		user, userAddr, userAgentID := setupDeployer(t, chain)
 
-		req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-			root.ParamHname, HScName,
-			governance.ParamOwnerFee, 10)
-		_, err := chain.PostRequestSync(req.WithIotas(1), nil)
-		require.NoError(t, err)
-
 		chain.AssertIotas(userAgentID, 0)
 		chain.AssertIotas(cAID, 1)
 
-		req = solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
-		_, err = chain.PostRequestSync(req.WithIotas(10+43), user)
+		req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name)
+		_, err := chain.PostRequestSync(req.WithIotas(10+43), user)
 		require.NoError(t, err)
 
 		chain.AssertIotas(userAgentID, 10+43)
 		chain.AssertIotas(cAID, 1)
 
+		req = solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+			governance.ParamMinFee, 10)
+		_, err = chain.PostRequestSync(req.WithIotas(1), nil)
+		require.NoError(t, err)
+
 		req = solo.NewCallParams(ScName, sbtestsc.FuncDoNothing.Name)
 		_, err = chain.PostRequestOffLedger(req.WithIotas(10+42), user)
 		require.NoError(t, err)
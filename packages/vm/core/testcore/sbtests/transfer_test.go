@@ -166,9 +166,8 @@ func testDoPanicUserFee(t *testing.T, w bool) {
 	env.AssertAddressIotas(chain.OriginatorAddress, solo.Saldo-solo.ChainDustThreshold-4-extraToken)
 	env.AssertAddressIotas(userAddress, solo.Saldo)
 
-	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFee.Name,
-		governance.ParamHname, cAID.Hname(),
-		governance.ParamOwnerFee, 10)
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name,
+		governance.ParamMinFee, 10)
 	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
 	require.NoError(t, err)
 
@@ -0,0 +1,274 @@
+package testcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/assert"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+// ticker is a test contract which schedules calls to its 'tick' entry point
+var tickerContract = coreutil.NewContract("ticker", "Scheduler test contract")
+
+var (
+	funcTickerStart   = coreutil.Func("start")
+	funcTickerStop    = coreutil.Func("stop")
+	funcTickerTick    = coreutil.Func("tick")
+	funcTickerCounter = coreutil.ViewFunc("counter")
+)
+
+const (
+	varTickerCounter = "n"
+	paramTickerInc   = "inc"
+)
+
+var tickerProcessor = tickerContract.Processor(nil,
+	funcTickerStart.WithHandler(func(ctx iscp.Sandbox) (dict.Dict, error) {
+		// forward the parameters and the tokens to the scheduler
+		params := ctx.Params().Clone()
+		params.Set(scheduler.ParamEntryPoint, codec.EncodeHname(funcTickerTick.Hname()))
+		params.Set(scheduler.ParamArgs, dict.Dict{paramTickerInc: codec.EncodeInt64(10)}.Bytes())
+		return ctx.Call(scheduler.Contract.Hname(), scheduler.FuncSchedule.Hname(), params, ctx.IncomingTransfer())
+	}),
+	funcTickerStop.WithHandler(func(ctx iscp.Sandbox) (dict.Dict, error) {
+		return ctx.Call(scheduler.Contract.Hname(), scheduler.FuncCancel.Hname(), ctx.Params(), nil)
+	}),
+	funcTickerTick.WithHandler(func(ctx iscp.Sandbox) (dict.Dict, error) {
+		assert.NewAssert(ctx.Log()).Require(ctx.Caller().Equals(iscp.NewAgentID(ctx.ChainID().AsAddress(), ctx.Contract())),
+			"tick: only the contract itself can tick")
+		params := kvdecoder.New(ctx.Params(), ctx.Log())
+		state := kvdecoder.New(ctx.State(), ctx.Log())
+		inc := params.MustGetInt64(paramTickerInc)
+		counter := state.MustGetInt64(varTickerCounter, 0)
+		ctx.State().Set(varTickerCounter, codec.EncodeInt64(counter+inc))
+		return nil, nil
+	}),
+	funcTickerCounter.WithHandler(func(ctx iscp.SandboxView) (dict.Dict, error) {
+		return dict.Dict{varTickerCounter: ctx.State().MustGet(varTickerCounter)}, nil
+	}),
+)
+
+func setupScheduler(t *testing.T) (*solo.Solo, *solo.Chain) {
+	env := solo.New(t, false, false).WithNativeContract(tickerProcessor)
+	chain := env.NewChain(nil, "chain1")
+	err := chain.DeployContract(nil, tickerContract.Name, tickerContract.ProgramHash)
+	require.NoError(t, err)
+	return env, chain
+}
+
+func startTicker(t *testing.T, chain *solo.Chain, keyPair *ed25519.KeyPair, deposit uint64, params ...interface{}) uint32 {
+	req := solo.NewCallParams(tickerContract.Name, funcTickerStart.Name, params...).WithIotas(deposit)
+	ret, err := chain.PostRequestSync(req, keyPair)
+	require.NoError(t, err)
+	callID, err := codec.DecodeUint32(ret.MustGet(scheduler.ParamCallID))
+	require.NoError(t, err)
+	return callID
+}
+
+// nextBlock advances the clock and waits for the block of the calls which became due, if any
+func nextBlock(t *testing.T, env *solo.Solo, chain *solo.Chain, d time.Duration) {
+	env.AdvanceClockBy(d)
+	chain.Sync()
+}
+
+func tickerCounter(t *testing.T, chain *solo.Chain) int64 {
+	ret, err := chain.CallView(tickerContract.Name, funcTickerCounter.Name)
+	require.NoError(t, err)
+	counter, err := codec.DecodeInt64(ret.MustGet(varTickerCounter), 0)
+	require.NoError(t, err)
+	return counter
+}
+
+func scheduledCalls(t *testing.T, chain *solo.Chain) int {
+	ret, err := chain.CallView(scheduler.Contract.Name, scheduler.FuncGetScheduledCalls.Name)
+	require.NoError(t, err)
+	return len(ret)
+}
+
+func TestSchedulerDeployed(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+	chain.CheckChain()
+	_, err := chain.FindContract(scheduler.Contract.Name)
+	require.NoError(t, err)
+	require.Zero(t, scheduledCalls(t, chain))
+}
+
+func TestSchedulerOnlyContracts(t *testing.T) {
+	env := solo.New(t, false, false)
+	chain := env.NewChain(nil, "chain1")
+	req := solo.NewCallParams(scheduler.Contract.Name, scheduler.FuncSchedule.Name,
+		scheduler.ParamEntryPoint, iscp.Hn("tick"),
+	).WithIotas(1)
+	_, err := chain.PostRequestSync(req, nil)
+	require.Error(t, err)
+	require.Zero(t, scheduledCalls(t, chain))
+}
+
+func TestSchedulerMaxCallsPerContract(t *testing.T) {
+	_, chain := setupScheduler(t)
+	for i := 0; i < scheduler.MaxScheduledCallsPerContract; i++ {
+		startTicker(t, chain, nil, 1, scheduler.ParamInterval, uint32(10))
+	}
+	require.EqualValues(t, scheduler.MaxScheduledCallsPerContract, scheduledCalls(t, chain))
+
+	req := solo.NewCallParams(tickerContract.Name, funcTickerStart.Name, scheduler.ParamInterval, uint32(10)).WithIotas(1)
+	_, err := chain.PostRequestSync(req, nil)
+	require.Error(t, err)
+	require.EqualValues(t, scheduler.MaxScheduledCallsPerContract, scheduledCalls(t, chain))
+}
+
+func TestSchedulerOneShot(t *testing.T) {
+	env, chain := setupScheduler(t)
+	tickerAgentID := iscp.NewAgentID(chain.ChainID.AsAddress(), tickerContract.Hname())
+
+	callID := startTicker(t, chain, nil, 5, scheduler.ParamDelay, uint32(10))
+	require.EqualValues(t, 1, scheduledCalls(t, chain))
+
+	ret, err := chain.CallView(scheduler.Contract.Name, scheduler.FuncGetScheduledCall.Name, scheduler.ParamCallID, callID)
+	require.NoError(t, err)
+	call, err := scheduler.ScheduledCallFromBytes(ret.MustGet(scheduler.ParamScheduledCall))
+	require.NoError(t, err)
+	require.Equal(t, tickerContract.Hname(), call.Contract)
+	require.Equal(t, funcTickerTick.Hname(), call.EntryPoint)
+	require.EqualValues(t, 5, call.Deposit.Get(colored.IOTA))
+
+	nextBlock(t, env, chain, 5*time.Second)
+	require.EqualValues(t, 0, tickerCounter(t, chain))
+
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+	require.Zero(t, scheduledCalls(t, chain))
+	// fees are disabled, the whole deposit is returned to the contract
+	chain.AssertAccountBalance(tickerAgentID, colored.IOTA, 5)
+
+	// the execution is recorded in the blocklog like a normal request, in a block without other requests
+	blockInfo := chain.GetLatestBlockInfo()
+	require.EqualValues(t, 1, blockInfo.TotalRequests)
+	require.EqualValues(t, 1, blockInfo.NumScheduledCalls)
+	require.Zero(t, blockInfo.NumOffLedgerRequests)
+	receipts := chain.GetRequestReceiptsForBlock(blockInfo.BlockIndex)
+	require.Len(t, receipts, 1)
+	sreq, ok := receipts[0].Request.(*request.Scheduled)
+	require.True(t, ok)
+	require.Equal(t, callID, sreq.CallID())
+	require.Empty(t, receipts[0].Error)
+	require.True(t, tickerAgentID.Equals(sreq.SenderAccount()))
+
+	nextBlock(t, env, chain, time.Minute)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+}
+
+func TestSchedulerRecurring(t *testing.T) {
+	env, chain := setupScheduler(t)
+
+	startTicker(t, chain, nil, 1,
+		scheduler.ParamDelay, uint32(10),
+		scheduler.ParamInterval, uint32(10),
+		scheduler.ParamCount, uint32(3),
+	)
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+
+	// missed executions are skipped
+	nextBlock(t, env, chain, 25*time.Second)
+	require.EqualValues(t, 20, tickerCounter(t, chain))
+	require.EqualValues(t, 1, scheduledCalls(t, chain))
+
+	nextBlock(t, env, chain, 5*time.Second)
+	require.EqualValues(t, 30, tickerCounter(t, chain))
+	require.Zero(t, scheduledCalls(t, chain))
+
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 30, tickerCounter(t, chain))
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	env, chain := setupScheduler(t)
+	tickerAgentID := iscp.NewAgentID(chain.ChainID.AsAddress(), tickerContract.Hname())
+
+	callID := startTicker(t, chain, nil, 3, scheduler.ParamInterval, uint32(10))
+	chain.AssertAccountBalance(tickerAgentID, colored.IOTA, 0)
+
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+
+	// only the contract which scheduled the call can cancel it
+	req := solo.NewCallParams(scheduler.Contract.Name, scheduler.FuncCancel.Name, scheduler.ParamCallID, callID).WithIotas(1)
+	_, err := chain.PostRequestSync(req, nil)
+	require.Error(t, err)
+
+	req = solo.NewCallParams(tickerContract.Name, funcTickerStop.Name, scheduler.ParamCallID, callID).WithIotas(1)
+	_, err = chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+	require.Zero(t, scheduledCalls(t, chain))
+	// the deposit and the tokens sent with the stop request
+	chain.AssertAccountBalance(tickerAgentID, colored.IOTA, 3+1)
+
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+}
+
+func TestSchedulerFees(t *testing.T) {
+	env, chain := setupScheduler(t)
+	tickerAgentID := iscp.NewAgentID(chain.ChainID.AsAddress(), tickerContract.Hname())
+
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetFeePolicy.Name, governance.ParamMinFee, 1)
+	_, err := chain.PostRequestSync(req.WithIotas(1), nil)
+	require.NoError(t, err)
+
+	// the schedule request pays 1 iota of fees, 2 are left for the deposit
+	user, _ := env.NewKeyPairWithFunds()
+	callID := startTicker(t, chain, user, 3, scheduler.ParamInterval, uint32(10))
+	ret, err := chain.CallView(scheduler.Contract.Name, scheduler.FuncGetScheduledCall.Name, scheduler.ParamCallID, callID)
+	require.NoError(t, err)
+	call, err := scheduler.ScheduledCallFromBytes(ret.MustGet(scheduler.ParamScheduledCall))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, call.Deposit.Get(colored.IOTA))
+	chain.AssertAccountBalance(scheduler.Account(chain.ChainID), colored.IOTA, 2)
+
+	// the fees of the execution are paid from the deposit to the chain owner
+	common := chain.GetCommonAccountIotas()
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 10, tickerCounter(t, chain))
+	require.EqualValues(t, 1, scheduledCalls(t, chain))
+	chain.AssertAccountBalance(scheduler.Account(chain.ChainID), colored.IOTA, 1)
+	chain.AssertCommonAccountIotas(common + 1)
+
+	// the deposit is exhausted by the second execution, the call is removed
+	nextBlock(t, env, chain, 10*time.Second)
+	require.EqualValues(t, 20, tickerCounter(t, chain))
+	require.Zero(t, scheduledCalls(t, chain))
+	chain.AssertAccountBalance(tickerAgentID, colored.IOTA, 0)
+	chain.AssertAccountBalance(scheduler.Account(chain.ChainID), colored.IOTA, 0)
+	chain.AssertCommonAccountIotas(common + 2)
+}
+
+func TestSchedulerDepositNotHarvested(t *testing.T) {
+	_, chain := setupScheduler(t)
+
+	common := chain.GetCommonAccountIotas()
+	startTicker(t, chain, nil, 5, scheduler.ParamInterval, uint32(10))
+	chain.AssertAccountBalance(scheduler.Account(chain.ChainID), colored.IOTA, 5)
+	chain.AssertCommonAccountIotas(common)
+
+	// the chain owner harvests the common account, the deposit stays with the scheduler
+	req := solo.NewCallParams(accounts.Contract.Name, accounts.FuncHarvest.Name).WithIotas(1)
+	_, err := chain.PostRequestSync(req, nil)
+	require.NoError(t, err)
+	chain.AssertAccountBalance(scheduler.Account(chain.ChainID), colored.IOTA, 5)
+	require.EqualValues(t, 1, scheduledCalls(t, chain))
+}
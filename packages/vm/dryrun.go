@@ -0,0 +1,22 @@
+package vm
+
+import (
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+)
+
+// DryRunResult is the outcome of running a single request on the current state of the chain
+// without committing anything. It tells what the request would do if it was processed in the next block
+type DryRunResult struct {
+	Result    dict.Dict
+	Error     error
+	Events    []*blocklog.Event
+	GasBudget uint64
+	GasBurned uint64
+	FeeColor  colored.Color
+	Fee       uint64
+	// Outputs are the outputs the request would add to the anchor transaction, without the chain output
+	Outputs []ledgerstate.Output
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gas defines the deterministic gas schedule of the ISCP VM.
+// Each request runs with a gas budget. Sandbox calls, state access, events and
+// the instructions executed by Wasm and EVM code burn gas from the budget.
+// When the budget is exhausted, the request is aborted and its state changes are rolled back
+package gas
+
+import (
+	"math"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// MaxGasPerRequest is the upper limit of the gas budget of one request
+	MaxGasPerRequest = uint64(100_000_000)
+	// DefaultGasBudget is the budget of the request which does not specify it
+	DefaultGasBudget = uint64(20_000_000)
+	// MaxGasExternalViewCall is the budget of the view call from outside of the chain (web API, solo)
+	MaxGasExternalViewCall = uint64(20_000_000)
+)
+
+// gas schedule
+const (
+	StorageReadBase     = uint64(10)
+	StorageReadPerByte  = uint64(1)
+	StorageWriteBase    = uint64(100)
+	StorageWritePerByte = uint64(10)
+	StorageDelete       = uint64(50)
+	CallContract        = uint64(100)
+	DeployContract      = uint64(10_000)
+	EventBase           = uint64(100)
+	EventPerByte        = uint64(1)
+	SendOutput          = uint64(1_000)
+
+	// the utilities of the sandbox burn gas according to the work they do
+	UtilsBase58Base         = uint64(50)
+	UtilsBase58PerByte      = uint64(1)
+	UtilsHashingBase        = uint64(50)
+	UtilsHashingPerByte     = uint64(1)
+	UtilsED25519Verify      = uint64(1_000)
+	UtilsED25519Address     = uint64(100)
+	UtilsBLSVerify          = uint64(20_000)
+	UtilsBLSAddress         = uint64(500)
+	UtilsBLSAggregateBase   = uint64(1_000)
+	UtilsBLSAggregatePerSig = uint64(5_000)
+
+	// WasmFuelPerGas is the amount of Wasm fuel (roughly, number of executed instructions)
+	// which is worth one unit of gas. The Wasm processor multiplies it by the language specific factor
+	WasmFuelPerGas = uint64(1)
+	// EVMGasPerGas is the amount of EVM gas which is worth one unit of gas
+	EVMGasPerGas = uint64(1)
+)
+
+var ErrNotEnoughGas = xerrors.New("gas budget exceeded")
+
+// Budget returns the effective gas budget for the budget requested by the sender.
+// Zero means the default budget
+func Budget(requested uint64) uint64 {
+	if requested == 0 {
+		return DefaultGasBudget
+	}
+	if requested > MaxGasPerRequest {
+		return MaxGasPerRequest
+	}
+	return requested
+}
+
+func StorageRead(size int) uint64 {
+	return StorageReadBase + StorageReadPerByte*uint64(size)
+}
+
+func StorageWrite(size int) uint64 {
+	return StorageWriteBase + StorageWritePerByte*uint64(size)
+}
+
+func Event(size int) uint64 {
+	return EventBase + EventPerByte*uint64(size)
+}
+
+func UtilsBase58(size int) uint64 {
+	return UtilsBase58Base + UtilsBase58PerByte*uint64(size)
+}
+
+func UtilsHashing(size int) uint64 {
+	return UtilsHashingBase + UtilsHashingPerByte*uint64(size)
+}
+
+func UtilsBLSAggregate(count int) uint64 {
+	return UtilsBLSAggregateBase + UtilsBLSAggregatePerSig*uint64(count)
+}
+
+func FromEVM(evmGas uint64) uint64 {
+	return evmGas / EVMGasPerGas
+}
+
+func ToEVM(g uint64) uint64 {
+	if g > math.MaxUint64/EVMGasPerGas {
+		return math.MaxUint64
+	}
+	return g * EVMGasPerGas
+}
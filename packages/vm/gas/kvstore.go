@@ -0,0 +1,105 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package gas
+
+import (
+	"github.com/iotaledger/wasp/packages/kv"
+)
+
+// BurnFunc burns the gas. It panics with ErrNotEnoughGas if the budget is exceeded
+type BurnFunc func(gas uint64)
+
+type kvStoreReader struct {
+	kv   kv.KVStoreReader
+	burn BurnFunc
+}
+
+type kvStore struct {
+	kvStoreReader
+	w kv.KVWriter
+}
+
+// NewKVStore wraps the state of the contract. Each access to the state burns gas
+func NewKVStore(store kv.KVStore, burn BurnFunc) kv.KVStore {
+	return &kvStore{kvStoreReader{store, burn}, store}
+}
+
+// NewKVStoreReader wraps the read-only state of the contract. Each access to the state burns gas
+func NewKVStoreReader(kvReader kv.KVStoreReader, burn BurnFunc) kv.KVStoreReader {
+	return &kvStoreReader{kvReader, burn}
+}
+
+func (s *kvStore) Set(key kv.Key, value []byte) {
+	s.burn(StorageWrite(len(key) + len(value)))
+	s.w.Set(key, value)
+}
+
+func (s *kvStore) Del(key kv.Key) {
+	s.burn(StorageDelete)
+	s.w.Del(key)
+}
+
+// Get returns the value, or nil if not found
+func (s *kvStoreReader) Get(key kv.Key) ([]byte, error) {
+	v, err := s.kv.Get(key)
+	s.burn(StorageRead(len(key) + len(v)))
+	return v, err
+}
+
+func (s *kvStoreReader) Has(key kv.Key) (bool, error) {
+	s.burn(StorageRead(len(key)))
+	return s.kv.Has(key)
+}
+
+func (s *kvStoreReader) Iterate(prefix kv.Key, f func(key kv.Key, value []byte) bool) error {
+	return s.kv.Iterate(prefix, func(key kv.Key, value []byte) bool {
+		s.burn(StorageRead(len(key) + len(value)))
+		return f(key, value)
+	})
+}
+
+func (s *kvStoreReader) IterateKeys(prefix kv.Key, f func(key kv.Key) bool) error {
+	return s.kv.IterateKeys(prefix, func(key kv.Key) bool {
+		s.burn(StorageRead(len(key)))
+		return f(key)
+	})
+}
+
+func (s *kvStoreReader) IterateSorted(prefix kv.Key, f func(key kv.Key, value []byte) bool) error {
+	return s.kv.IterateSorted(prefix, func(key kv.Key, value []byte) bool {
+		s.burn(StorageRead(len(key) + len(value)))
+		return f(key, value)
+	})
+}
+
+func (s *kvStoreReader) IterateKeysSorted(prefix kv.Key, f func(key kv.Key) bool) error {
+	return s.kv.IterateKeysSorted(prefix, func(key kv.Key) bool {
+		s.burn(StorageRead(len(key)))
+		return f(key)
+	})
+}
+
+func (s *kvStoreReader) MustGet(key kv.Key) []byte {
+	return kv.MustGet(s, key)
+}
+
+func (s *kvStoreReader) MustHas(key kv.Key) bool {
+	return kv.MustHas(s, key)
+}
+
+func (s *kvStoreReader) MustIterate(prefix kv.Key, f func(key kv.Key, value []byte) bool) {
+	kv.MustIterate(s, prefix, f)
+}
+
+func (s *kvStoreReader) MustIterateKeys(prefix kv.Key, f func(key kv.Key) bool) {
+	kv.MustIterateKeys(s, prefix, f)
+}
+
+func (s *kvStoreReader) MustIterateSorted(prefix kv.Key, f func(key kv.Key, value []byte) bool) {
+	kv.MustIterateSorted(s, prefix, f)
+}
+
+func (s *kvStoreReader) MustIterateKeysSorted(prefix kv.Key, f func(key kv.Key) bool) {
+	kv.MustIterateKeysSorted(s, prefix, f)
+}
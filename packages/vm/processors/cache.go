@@ -11,11 +11,15 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/vmtypes"
 )
 
-// Cache stores all initialized VMProcessor instances used by a single chain
+// Cache stores the VMProcessor instances used by a single chain.
+// Core and native processors are kept by the chain, the processors created from binaries
+// are kept in the node-wide cache of the Config, which is shared by all chains
 type Cache struct {
 	mutex      *sync.Mutex
 	Config     *Config
 	processors map[hashing.HashValue]iscp.VMProcessor
+	// vmtypes is the VM type of each program run from a binary, the key of the program in the node-wide cache
+	vmtypes map[hashing.HashValue]string
 }
 
 func MustNew(config *Config) *Cache {
@@ -23,6 +27,7 @@ func MustNew(config *Config) *Cache {
 		mutex:      &sync.Mutex{},
 		Config:     config,
 		processors: make(map[hashing.HashValue]iscp.VMProcessor),
+		vmtypes:    make(map[hashing.HashValue]string),
 	}
 	// default builtin processor has root contract hash
 	err := ret.NewProcessor(root.Contract.ProgramHash, nil, vmtypes.Core)
@@ -61,18 +66,25 @@ func (cps *Cache) newProcessor(programHash hashing.HashValue, programCode []byte
 		}
 
 	default:
-		proc, err = cps.Config.NewProcessorFromBinary(vmtype, programCode)
+		_, err = cps.Config.getOrCreateProcessorFromBinary(programHash, vmtype, func() ([]byte, error) {
+			return programCode, nil
+		})
 		if err != nil {
 			return err
 		}
+		cps.vmtypes[programHash] = vmtype
+		return nil
 	}
 	cps.processors[programHash] = proc
 	return nil
 }
 
 func (cps *Cache) ExistsProcessor(h hashing.HashValue) bool {
-	_, ok := cps.processors[h]
-	return ok
+	if _, ok := cps.processors[h]; ok {
+		return true
+	}
+	vmtype, ok := cps.vmtypes[h]
+	return ok && cps.Config.cache.contains(cacheKey{programHash: h, vmtype: vmtype})
 }
 
 func (cps *Cache) GetOrCreateProcessor(rec *root.ContractRecord, getBinary func(hashing.HashValue) (string, []byte, error)) (iscp.VMProcessor, error) {
@@ -86,22 +98,45 @@ func (cps *Cache) GetOrCreateProcessorByProgramHash(progHash hashing.HashValue,
 	if proc, ok := cps.processors[progHash]; ok {
 		return proc, nil
 	}
+	if vmtype, ok := cps.vmtypes[progHash]; ok {
+		// the processor may have been evicted from the node-wide cache, then it is created again
+		return cps.Config.getOrCreateProcessorFromBinary(progHash, vmtype, func() ([]byte, error) {
+			_, binary, err := getBinary(progHash)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: can't get the binary for the program: %v", err)
+			}
+			return binary, nil
+		})
+	}
 	vmtype, binary, err := getBinary(progHash)
 	if err != nil {
 		return nil, fmt.Errorf("internal error: can't get the binary for the program: %v", err)
 	}
-	if err := cps.newProcessor(progHash, binary, vmtype); err != nil {
-		return nil, err
+	switch vmtype {
+	case vmtypes.Core, vmtypes.Native:
+		if err := cps.newProcessor(progHash, binary, vmtype); err != nil {
+			return nil, err
+		}
+		if proc, ok := cps.processors[progHash]; ok {
+			return proc, nil
+		}
+		return nil, fmt.Errorf("internal error: can't get the deployed processor")
 	}
-	if proc, ok := cps.processors[progHash]; ok {
-		return proc, nil
+	proc, err := cps.Config.getOrCreateProcessorFromBinary(progHash, vmtype, func() ([]byte, error) {
+		return binary, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("internal error: can't get the deployed processor")
+	cps.vmtypes[progHash] = vmtype
+	return proc, nil
 }
 
-// RemoveProcessor deletes processor from cache
+// RemoveProcessor deletes processor from cache.
+// A processor created from a binary stays in the node-wide cache, it may be used by other chains
 func (cps *Cache) RemoveProcessor(h hashing.HashValue) {
 	cps.mutex.Lock()
 	defer cps.mutex.Unlock()
 	delete(cps.processors, h)
+	delete(cps.vmtypes, h)
 }
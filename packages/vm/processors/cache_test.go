@@ -30,3 +30,101 @@ func TestBasic(t *testing.T) {
 	assert.True(t, exists)
 	assert.Same(t, ep.(*coreutil.EntryPointHandler).Info, &root.FuncDeployContract)
 }
+
+func newBinaryConfig(t *testing.T, budget uint64) *Config {
+	config := NewConfig()
+	config.SetCacheBudget(budget)
+	err := config.RegisterVMType("test", func(binaryCode []byte) (iscp.VMProcessor, error) {
+		return coreutil.NewContract(string(binaryCode), "test contract").Processor(nil), nil
+	})
+	assert.NoError(t, err)
+	return config
+}
+
+func getTestBinary(binaryCode []byte) func(hashing.HashValue) (string, []byte, error) {
+	return func(hashing.HashValue) (string, []byte, error) { return "test", binaryCode, nil }
+}
+
+func TestSharedBetweenChains(t *testing.T) {
+	config := newBinaryConfig(t, 0)
+	chain1 := MustNew(config)
+	chain2 := MustNew(config)
+
+	h := hashing.HashStrings("program")
+	proc1, err := chain1.GetOrCreateProcessorByProgramHash(h, getTestBinary([]byte("program")))
+	assert.NoError(t, err)
+	// the binary is read by the second chain, but it is not compiled again
+	proc2, err := chain2.GetOrCreateProcessorByProgramHash(h, getTestBinary([]byte("program")))
+	assert.NoError(t, err)
+	assert.Same(t, proc1, proc2)
+
+	stats := config.CacheStats()
+	assert.EqualValues(t, 1, stats.Entries)
+	assert.EqualValues(t, len("program"), stats.Size)
+	assert.EqualValues(t, 1, stats.Hits)
+	assert.EqualValues(t, 1, stats.Misses)
+}
+
+func TestEviction(t *testing.T) {
+	config := newBinaryConfig(t, 10)
+	p := MustNew(config)
+
+	h1 := hashing.HashStrings("1")
+	h2 := hashing.HashStrings("2")
+	h3 := hashing.HashStrings("3")
+	assert.NoError(t, p.NewProcessor(h1, []byte("aaaa"), "test"))
+	assert.NoError(t, p.NewProcessor(h2, []byte("bbbb"), "test"))
+	// h1 is used, so h2 is the least recently used one
+	_, err := p.GetOrCreateProcessorByProgramHash(h1, getTestBinary([]byte("aaaa")))
+	assert.NoError(t, err)
+	assert.NoError(t, p.NewProcessor(h3, []byte("cccc"), "test"))
+
+	assert.True(t, p.ExistsProcessor(h1))
+	assert.False(t, p.ExistsProcessor(h2))
+	assert.True(t, p.ExistsProcessor(h3))
+	stats := config.CacheStats()
+	assert.EqualValues(t, 2, stats.Entries)
+	assert.EqualValues(t, 8, stats.Size)
+	assert.EqualValues(t, 1, stats.Evictions)
+
+	// the evicted processor is created again from the binary
+	loaded := false
+	_, err = p.GetOrCreateProcessorByProgramHash(h2, func(hashing.HashValue) (string, []byte, error) {
+		loaded = true
+		return "test", []byte("bbbb"), nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, loaded)
+	assert.True(t, p.ExistsProcessor(h2))
+	assert.False(t, p.ExistsProcessor(h1))
+
+	config.SetCacheBudget(4)
+	assert.EqualValues(t, 1, config.CacheStats().Entries)
+	assert.True(t, p.ExistsProcessor(h2))
+}
+
+type sizedProcessor struct {
+	iscp.VMProcessor
+	size uint64
+}
+
+func (p *sizedProcessor) MemorySize() uint64 {
+	return p.size
+}
+
+func TestProcessorSize(t *testing.T) {
+	config := NewConfig()
+	err := config.RegisterVMType("sized", func(binaryCode []byte) (iscp.VMProcessor, error) {
+		proc := coreutil.NewContract(string(binaryCode), "test contract").Processor(nil)
+		return &sizedProcessor{VMProcessor: proc, size: 1000}, nil
+	})
+	assert.NoError(t, err)
+	p := MustNew(config)
+
+	// the size of the processor is its own estimate, not the size of the binary
+	_, err = p.GetOrCreateProcessorByProgramHash(hashing.HashStrings("1"), func(hashing.HashValue) (string, []byte, error) {
+		return "sized", []byte("aaaa"), nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1000, config.CacheStats().Size)
+}
@@ -12,18 +12,27 @@ import (
 
 type VMConstructor func(binaryCode []byte) (iscp.VMProcessor, error)
 
+// memorySizer is implemented by the processors which can estimate the memory they hold
+type memorySizer interface {
+	MemorySize() uint64
+}
+
 type Config struct {
 	// vmConstructors is the collection of registered non-native VM types
 	vmConstructors map[string]VMConstructor
 
 	// nativeContracts is the collection of registered native contracts
 	nativeContracts map[hashing.HashValue]iscp.VMProcessor
+
+	// cache is the node-wide cache of the processors created from binaries, shared by all chains
+	cache *lruCache
 }
 
 func NewConfig(nativeContracts ...*coreutil.ContractProcessor) *Config {
 	p := &Config{
 		vmConstructors:  make(map[string]VMConstructor),
 		nativeContracts: make(map[hashing.HashValue]iscp.VMProcessor),
+		cache:           newLRUCache(DefaultCacheBudget),
 	}
 	for _, c := range nativeContracts {
 		p.RegisterNativeContract(c)
@@ -52,6 +61,42 @@ func (p *Config) NewProcessorFromBinary(vmtype string, binaryCode []byte) (iscp.
 	return constructor(binaryCode)
 }
 
+// getOrCreateProcessorFromBinary returns the processor of the program from the node-wide cache.
+// If it is not cached, the processor is created from the binary code
+func (p *Config) getOrCreateProcessorFromBinary(programHash hashing.HashValue, vmtype string, getBinary func() ([]byte, error)) (iscp.VMProcessor, error) {
+	key := cacheKey{programHash: programHash, vmtype: vmtype}
+	if proc, ok := p.cache.get(key); ok {
+		return proc, nil
+	}
+	binaryCode, err := getBinary()
+	if err != nil {
+		return nil, err
+	}
+	proc, err := p.NewProcessorFromBinary(vmtype, binaryCode)
+	if err != nil {
+		return nil, err
+	}
+	return p.cache.add(key, proc, processorSize(proc, binaryCode)), nil
+}
+
+// processorSize estimates the memory held by the processor, by the size of its binary if the processor can't tell
+func processorSize(proc iscp.VMProcessor, binaryCode []byte) uint64 {
+	if sizer, ok := proc.(memorySizer); ok {
+		return sizer.MemorySize()
+	}
+	return uint64(len(binaryCode))
+}
+
+// SetCacheBudget sets the memory budget of the processors created from binaries, in bytes. 0 means no limit
+func (p *Config) SetCacheBudget(budget uint64) {
+	p.cache.setBudget(budget)
+}
+
+// CacheStats returns the state of the node-wide processor cache
+func (p *Config) CacheStats() CacheStats {
+	return p.cache.stats()
+}
+
 // GetNativeProcessorType returns the type of the native processor
 func (p *Config) GetNativeProcessorType(programHash hashing.HashValue) (string, bool) {
 	if _, err := core.GetProcessor(programHash); err == nil {
@@ -0,0 +1,126 @@
+package processors
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+)
+
+// DefaultCacheBudget is the default memory budget of the processors created from binaries, in bytes
+const DefaultCacheBudget = 512 * 1024 * 1024
+
+// CacheStats is a snapshot of the state of the node-wide processor cache
+type CacheStats struct {
+	Entries   int
+	Size      uint64
+	Budget    uint64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+type cacheKey struct {
+	programHash hashing.HashValue
+	vmtype      string
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	proc iscp.VMProcessor
+	size uint64
+}
+
+// lruCache keeps the processors created from binaries, shared by all chains of the node.
+// The size of a processor is its own estimate of the memory it holds when it is cached, or else the size of its binary.
+// When the total size exceeds the budget, the least recently used processors are evicted.
+// The most recently used one is kept even if it alone exceeds the budget
+type lruCache struct {
+	mutex     sync.Mutex
+	budget    uint64
+	size      uint64
+	order     *list.List // front is the most recently used
+	entries   map[cacheKey]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func newLRUCache(budget uint64) *lruCache {
+	return &lruCache{
+		budget:  budget,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key cacheKey) (iscp.VMProcessor, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).proc, true
+}
+
+// add puts the processor in the cache and returns the cached one.
+// If another processor was added for the key in the meantime, it is kept and returned
+func (c *lruCache) add(key cacheKey, proc iscp.VMProcessor, size uint64) iscp.VMProcessor {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).proc
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, proc: proc, size: size})
+	c.size += size
+	c.evict()
+	return proc
+}
+
+func (c *lruCache) contains(key cacheKey) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.entries[key]
+	return ok
+}
+
+func (c *lruCache) setBudget(budget uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.budget = budget
+	c.evict()
+}
+
+// evict removes the least recently used processors until the cache fits in the budget. Budget 0 means no limit
+func (c *lruCache) evict() {
+	for c.budget > 0 && c.size > c.budget && c.order.Len() > 1 {
+		entry := c.order.Remove(c.order.Back()).(*cacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+		c.evictions++
+	}
+}
+
+func (c *lruCache) stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return CacheStats{
+		Entries:   c.order.Len(),
+		Size:      c.size,
+		Budget:    c.budget,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
@@ -0,0 +1,72 @@
+package runvm
+
+import (
+	"errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/vmcontext"
+	"golang.org/x/xerrors"
+)
+
+// DryRun runs the only request of the task on the VM in the same way as it would be run in a block,
+// but does not produce the block. The virtual state of the task is mutated, so it must be a throwaway copy.
+// Returns coreutil.ErrorStateInvalidated if the state changed during the run, so the caller can retry
+func DryRun(task *vm.VMTask) (ret *vm.DryRunResult, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		ret = nil
+		if e, ok := r.(error); ok && errors.Is(e, coreutil.ErrorStateInvalidated) {
+			err = coreutil.ErrorStateInvalidated
+			return
+		}
+		err = xerrors.Errorf("DryRun: %v", r)
+	}()
+	if len(task.Requests) != 1 {
+		return nil, xerrors.Errorf("DryRun: expected exactly 1 request, got %d", len(task.Requests))
+	}
+	vmctx := vmcontext.CreateVMContext(task)
+	req := task.Requests[0]
+
+	vmctx.RunTheRequest(req, 0)
+	ret = &vm.DryRunResult{}
+	var exceededBlockOutputLimit bool
+	ret.Result, _, ret.Error, exceededBlockOutputLimit = vmctx.GetResult()
+	if exceededBlockOutputLimit {
+		return nil, xerrors.New("DryRun: the request produces more outputs than fit in a block")
+	}
+	ret.GasBudget, ret.GasBurned, ret.FeeColor, ret.Fee = vmctx.GetGasAndFees()
+
+	var numSuccess, numOffLedger uint16
+	if ret.Error == nil {
+		numSuccess = 1
+	}
+	if req.IsOffLedger() {
+		numOffLedger = 1
+	}
+	blockIndex, stateCommitment, timestamp, rotationAddr := vmctx.CloseVMContext(1, numSuccess, numOffLedger, 0)
+	if ret.Events, err = blocklog.GetRequestEvents(task.VirtualStateAccess.KVStoreReader(), blockIndex, 0); err != nil {
+		return nil, xerrors.Errorf("DryRun: %w", err)
+	}
+	ret.Outputs = make([]ledgerstate.Output, 0)
+	if rotationAddr != nil {
+		// the request rotates the committee, no outputs are produced in the rotation block
+		return ret, nil
+	}
+	essence, err := vmctx.BuildTransactionEssence(stateCommitment, timestamp)
+	if err != nil {
+		return nil, xerrors.Errorf("DryRun: %w", err)
+	}
+	for _, o := range essence.Outputs() {
+		if _, ok := o.(*ledgerstate.AliasOutput); ok {
+			continue
+		}
+		ret.Outputs = append(ret.Outputs, o)
+	}
+	return ret, nil
+}
@@ -5,10 +5,12 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/iscp/coreutil"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/vmcontext"
 	"golang.org/x/xerrors"
 )
@@ -46,9 +48,16 @@ func runTask(task *vm.VMTask) {
 	var lastTotalAssets colored.Balances
 	var exceededBlockOutputLimit bool
 
+	// the calls of the scheduler contract which are due are run first
+	var numScheduled, numSuccess uint16
+	if !isRotationBatch(task.Requests) {
+		numScheduled, numSuccess = vmctx.RunScheduledCalls()
+		_, lastTotalAssets, _, _ = vmctx.GetResult()
+	}
+
 	// loop over the batch of requests and run each request on the VM.
 	// the result accumulates in the VMContext and in the list of stateUpdates
-	var numOffLedger, numSuccess uint16
+	var numOffLedger uint16
 	var numOnLedger uint8
 	for i, req := range task.Requests {
 		if req.IsOffLedger() {
@@ -60,7 +69,7 @@ func runTask(task *vm.VMTask) {
 			numOnLedger++
 		}
 
-		vmctx.RunTheRequest(req, uint16(i))
+		vmctx.RunTheRequest(req, numScheduled+uint16(i))
 		lastResult, lastTotalAssets, lastErr, exceededBlockOutputLimit = vmctx.GetResult()
 
 		if exceededBlockOutputLimit {
@@ -80,9 +89,10 @@ func runTask(task *vm.VMTask) {
 		}
 	}
 
-	task.Log.Debugf("runTask, ran %d requests. success: %d, offledger: %d", task.ProcessedRequestsCount, numSuccess, numOffLedger)
+	task.Log.Debugf("runTask, ran %d requests and %d scheduled calls. success: %d, offledger: %d",
+		task.ProcessedRequestsCount, numScheduled, numSuccess, numOffLedger)
 
-	blockIndex, stateCommitment, timestamp, rotationAddr := vmctx.CloseVMContext(task.ProcessedRequestsCount, numSuccess, numOffLedger)
+	blockIndex, stateCommitment, timestamp, rotationAddr := vmctx.CloseVMContext(task.ProcessedRequestsCount+numScheduled, numSuccess, numOffLedger, numScheduled)
 
 	task.Log.Debugf("closed VMContext: block index: %d, state hash: %s timestamp: %v, rotationAddr: %v",
 		blockIndex, stateCommitment, timestamp, rotationAddr)
@@ -114,6 +124,18 @@ func runTask(task *vm.VMTask) {
 	task.OnFinish(lastResult, lastErr, nil)
 }
 
+// isRotationBatch returns true if the batch contains the request rotating the state controller.
+// The rotation block is not a normal state transition, so scheduled calls are not run in it
+func isRotationBatch(reqs []iscp.Request) bool {
+	for _, req := range reqs {
+		target := req.Target()
+		if target.Contract == governance.Contract.Hname() && target.EntryPoint == coreutil.CoreEPRotateStateControllerHname {
+			return true
+		}
+	}
+	return false
+}
+
 func checkTotalAssets(essence *ledgerstate.TransactionEssence, lastTotalOnChainAssets colored.Balances) error {
 	var chainOutput *ledgerstate.AliasOutput
 	for _, o := range essence.Outputs() {
@@ -10,6 +10,8 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/sandbox/sandbox_utils"
 	"github.com/iotaledger/wasp/packages/vm/vmcontext"
 )
@@ -30,6 +32,11 @@ func (s *sandbox) AccountID() *iscp.AgentID {
 	return s.vmctx.AccountID()
 }
 
+// AccountHistoryContext passes the history context of the current request to the accounts contract
+func (s *sandbox) AccountHistoryContext() *accounts.HistoryContext {
+	return s.vmctx.AccountHistoryContext()
+}
+
 func (s *sandbox) Balance(col colored.Color) uint64 {
 	return s.vmctx.GetBalance(col)
 }
@@ -40,6 +47,7 @@ func (s *sandbox) Balances() colored.Balances {
 
 // Call calls an entry point of contract, passes parameters and funds
 func (s *sandbox) Call(target, entryPoint iscp.Hname, params dict.Dict, transfer colored.Balances) (dict.Dict, error) {
+	s.vmctx.GasBurn(gas.CallContract)
 	return s.vmctx.Call(target, entryPoint, params, transfer)
 }
 
@@ -66,10 +74,12 @@ func (s *sandbox) ContractCreator() *iscp.AgentID {
 // DeployContract deploys contract by the binary hash
 // and calls "init" endpoint (constructor) with provided parameters
 func (s *sandbox) DeployContract(programHash hashing.HashValue, name, description string, initParams dict.Dict) error {
+	s.vmctx.GasBurn(gas.DeployContract)
 	return s.vmctx.DeployContract(programHash, name, description, initParams)
 }
 
 func (s *sandbox) Event(msg string) {
+	s.vmctx.GasBurn(gas.Event(len(msg)))
 	s.Log().Infof("event::%s -> '%s'", s.vmctx.CurrentContractHname(), msg)
 	s.vmctx.MustSaveEvent(s.vmctx.CurrentContractHname(), msg)
 }
@@ -103,15 +113,24 @@ func (s *sandbox) Request() iscp.Request {
 }
 
 func (s *sandbox) Send(target ledgerstate.Address, tokens colored.Balances, metadata *iscp.SendMetadata, options ...iscp.SendOptions) bool {
+	s.vmctx.GasBurn(gas.SendOutput)
 	return s.vmctx.Send(target, tokens, metadata, options...)
 }
 
 func (s *sandbox) State() kv.KVStore {
-	return s.vmctx.State()
+	return gas.NewKVStore(s.vmctx.State(), s.vmctx.GasBurn)
 }
 
 func (s *sandbox) Utils() iscp.Utils {
-	return sandbox_utils.NewUtils()
+	return sandbox_utils.NewUtils(s.vmctx.GasBurn)
+}
+
+func (s *sandbox) Gas() iscp.Gas {
+	return s.vmctx.Gas()
+}
+
+func (s *sandbox) WasmLimits() iscp.WasmLimits {
+	return s.vmctx.WasmLimits()
 }
 
 func (s *sandbox) BlockContext(construct func(ctx iscp.Sandbox) interface{}, onClose func(interface{})) interface{} {
@@ -3,14 +3,21 @@
 
 package sandbox_utils //nolint:revive // TODO refactor to remove `_` from package name
 
-import "github.com/mr-tron/base58"
+import (
+	"github.com/iotaledger/wasp/packages/vm/gas"
+	"github.com/mr-tron/base58"
+)
 
-type base58Util struct{}
+type base58Util struct {
+	burn gas.BurnFunc
+}
 
 func (u base58Util) Decode(s string) ([]byte, error) {
+	u.burn(gas.UtilsBase58(len(s)))
 	return base58.Decode(s)
 }
 
 func (u base58Util) Encode(data []byte) string {
+	u.burn(gas.UtilsBase58(len(data)))
 	return base58.Encode(data)
 }
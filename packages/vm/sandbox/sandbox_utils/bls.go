@@ -8,15 +8,19 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/sign/bdn"
 )
 
-type blsUtil struct{}
+type blsUtil struct {
+	burn gas.BurnFunc
+}
 
 var suite = bn256.NewSuite()
 
 func (u blsUtil) ValidSignature(data, pubKeyBin, signature []byte) bool {
+	u.burn(gas.UtilsBLSVerify + gas.UtilsHashingPerByte*uint64(len(data)))
 	pubKey := suite.G2().Point()
 	var err error
 	if err = pubKey.UnmarshalBinary(pubKeyBin); err != nil {
@@ -26,6 +30,7 @@ func (u blsUtil) ValidSignature(data, pubKeyBin, signature []byte) bool {
 }
 
 func (u blsUtil) AddressFromPublicKey(pubKeyBin []byte) (ledgerstate.Address, error) {
+	u.burn(gas.UtilsBLSAddress)
 	pubKey := suite.G2().Point()
 	if err := pubKey.UnmarshalBinary(pubKeyBin); err != nil {
 		return nil, fmt.Errorf("BLSUtil: wrong public key bytes")
@@ -37,6 +42,7 @@ func (u blsUtil) AddressFromPublicKey(pubKeyBin []byte) (ledgerstate.Address, er
 // TODO: optimize redundant binary manipulation.
 //   Implement more flexible access to parts of SignatureShort
 func (u blsUtil) AggregateBLSSignatures(pubKeysBin, sigsBin [][]byte) ([]byte, []byte, error) {
+	u.burn(gas.UtilsBLSAggregate(len(sigsBin)))
 	if len(sigsBin) == 0 || len(pubKeysBin) != len(sigsBin) {
 		return nil, nil, fmt.Errorf("BLSUtil: number of public keys must be equal to the number of signatures and not empty")
 	}
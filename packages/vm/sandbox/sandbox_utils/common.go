@@ -6,26 +6,34 @@ package sandbox_utils //nolint:revive // TODO refactor to remove `_` from packag
 
 import (
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-type utilImpl struct{}
+type utilImpl struct {
+	burn gas.BurnFunc
+}
 
-func NewUtils() iscp.Utils {
-	return utilImpl{}
+// NewUtils returns the utilities of the sandbox. Each call of a utility burns gas according to the work it does.
+// A nil burn function burns no gas
+func NewUtils(burn gas.BurnFunc) iscp.Utils {
+	if burn == nil {
+		burn = func(uint64) {}
+	}
+	return utilImpl{burn}
 }
 
 func (u utilImpl) Base58() iscp.Base58 {
-	return base58Util{}
+	return base58Util{u.burn}
 }
 
 func (u utilImpl) Hashing() iscp.Hashing {
-	return hashUtil{}
+	return hashUtil{u.burn}
 }
 
 func (u utilImpl) ED25519() iscp.ED25519 {
-	return ed25519Util{}
+	return ed25519Util{u.burn}
 }
 
 func (u utilImpl) BLS() iscp.BLS {
-	return blsUtil{}
+	return blsUtil{u.burn}
 }
@@ -8,11 +8,15 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-type ed25519Util struct{}
+type ed25519Util struct {
+	burn gas.BurnFunc
+}
 
 func (u ed25519Util) ValidSignature(data, pubKey, signature []byte) bool {
+	u.burn(gas.UtilsED25519Verify + gas.UtilsHashingPerByte*uint64(len(data)))
 	pk, _, err := ed25519.PublicKeyFromBytes(pubKey)
 	if err != nil {
 		return false
@@ -25,6 +29,7 @@ func (u ed25519Util) ValidSignature(data, pubKey, signature []byte) bool {
 }
 
 func (u ed25519Util) AddressFromPublicKey(pubKey []byte) (ledgerstate.Address, error) {
+	u.burn(gas.UtilsED25519Address)
 	pk, _, err := ed25519.PublicKeyFromBytes(pubKey)
 	if err != nil {
 		return nil, fmt.Errorf("ED255519Util: wrong public key bytes. Err: %v", err)
@@ -6,18 +6,24 @@ package sandbox_utils //nolint:revive // TODO refactor to remove `_` from packag
 import (
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-type hashUtil struct{}
+type hashUtil struct {
+	burn gas.BurnFunc
+}
 
 func (u hashUtil) Blake2b(data []byte) hashing.HashValue {
+	u.burn(gas.UtilsHashing(len(data)))
 	return hashing.HashDataBlake2b(data)
 }
 
 func (u hashUtil) Sha3(data []byte) hashing.HashValue {
+	u.burn(gas.UtilsHashing(len(data)))
 	return hashing.HashSha3(data)
 }
 
 func (u hashUtil) Hname(s string) iscp.Hname {
+	u.burn(gas.UtilsHashing(len(s)))
 	return iscp.Hn(s)
 }
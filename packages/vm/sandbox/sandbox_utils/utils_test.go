@@ -0,0 +1,35 @@
+package sandbox_utils
+
+import (
+	"testing"
+
+	"github.com/iotaledger/wasp/packages/vm/gas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUtilsBurnGas(t *testing.T) {
+	burned := uint64(0)
+	u := NewUtils(func(g uint64) { burned += g })
+	require.Zero(t, burned)
+
+	data := []byte("some data to hash")
+	u.Hashing().Blake2b(data)
+	require.EqualValues(t, gas.UtilsHashing(len(data)), burned)
+
+	burned = 0
+	s := u.Base58().Encode(data)
+	_, err := u.Base58().Decode(s)
+	require.NoError(t, err)
+	require.EqualValues(t, gas.UtilsBase58(len(data))+gas.UtilsBase58(len(s)), burned)
+
+	burned = 0
+	require.False(t, u.ED25519().ValidSignature(data, nil, nil))
+	require.Greater(t, burned, gas.UtilsED25519Verify)
+}
+
+func TestUtilsNoBurn(t *testing.T) {
+	u := NewUtils(nil)
+	require.NotPanics(t, func() {
+		u.Hashing().Sha3([]byte("data"))
+	})
+}
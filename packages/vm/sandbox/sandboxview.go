@@ -8,6 +8,7 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/sandbox/sandbox_utils"
 	"github.com/iotaledger/wasp/packages/vm/vmcontext"
 )
@@ -33,6 +34,7 @@ func (s sandboxView) Balances() colored.Balances {
 }
 
 func (s sandboxView) Call(contractHname, entryPoint iscp.Hname, params dict.Dict) (dict.Dict, error) {
+	s.vmctx.GasBurn(gas.CallContract)
 	return s.vmctx.Call(contractHname, entryPoint, params, nil)
 }
 
@@ -65,9 +67,17 @@ func (s sandboxView) Params() dict.Dict {
 }
 
 func (s sandboxView) State() kv.KVStoreReader {
-	return s.vmctx.State()
+	return gas.NewKVStoreReader(s.vmctx.State(), s.vmctx.GasBurn)
 }
 
 func (s sandboxView) Utils() iscp.Utils {
-	return sandbox_utils.NewUtils()
+	return sandbox_utils.NewUtils(s.vmctx.GasBurn)
+}
+
+func (s sandboxView) Gas() iscp.Gas {
+	return s.vmctx.Gas()
+}
+
+func (s sandboxView) WasmLimits() iscp.WasmLimits {
+	return s.vmctx.WasmLimits()
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/sandbox/sandbox_utils"
 )
 
@@ -31,7 +32,7 @@ func newSandboxView(vctx *Viewcontext, contractHname iscp.Hname, params dict.Dic
 		vctx:          vctx,
 		contractHname: contractHname,
 		params:        params,
-		state:         contractStateSubpartition(vctx.stateReader.KVStoreReader(), contractHname),
+		state:         gas.NewKVStoreReader(contractStateSubpartition(vctx.stateReader.KVStoreReader(), contractHname), vctx.GasBurn),
 	}
 }
 
@@ -56,6 +57,7 @@ func (s *sandboxview) Balances() colored.Balances {
 }
 
 func (s *sandboxview) Call(contractHname, entryPoint iscp.Hname, params dict.Dict) (dict.Dict, error) {
+	s.vctx.GasBurn(gas.CallContract)
 	return s.vctx.CallView(contractHname, entryPoint, params)
 }
 
@@ -102,5 +104,21 @@ func (s *sandboxview) State() kv.KVStoreReader {
 }
 
 func (s *sandboxview) Utils() iscp.Utils {
-	return sandbox_utils.NewUtils()
+	return sandbox_utils.NewUtils(s.vctx.GasBurn)
+}
+
+func (s *sandboxview) Gas() iscp.Gas {
+	return s
+}
+
+func (s *sandboxview) WasmLimits() iscp.WasmLimits {
+	return governance.MustGetWasmLimits(contractStateSubpartition(s.vctx.stateReader.KVStoreReader(), governance.Contract.Hname()))
+}
+
+func (s *sandboxview) Burn(g uint64) {
+	s.vctx.GasBurn(g)
+}
+
+func (s *sandboxview) Budget() uint64 {
+	return s.vctx.gasBudget - s.vctx.gasBurned
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 	"golang.org/x/xerrors"
 )
@@ -23,18 +24,30 @@ type Viewcontext struct {
 	stateReader state.OptimisticStateReader
 	chainID     *iscp.ChainID
 	log         *logger.Logger
+	gasBudget   uint64
+	gasBurned   uint64
 }
 
 func NewFromChain(ch chain.ChainCore) *Viewcontext {
 	return New(ch.ID(), ch.GetStateReader(), ch.Processors(), ch.Log().Named("view"))
 }
 
+// NewFromChainAtIndex creates the view context on the state of the chain as it was after the block with the given index
+func NewFromChainAtIndex(ch chain.ChainCore, blockIndex uint32) (*Viewcontext, error) {
+	stateReader, err := ch.GetStateReaderAtIndex(blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	return New(ch.ID(), stateReader, ch.Processors(), ch.Log().Named("view")), nil
+}
+
 func New(chainID *iscp.ChainID, stateReader state.OptimisticStateReader, proc *processors.Cache, log *logger.Logger) *Viewcontext {
 	return &Viewcontext{
 		processors:  proc,
 		stateReader: stateReader,
 		chainID:     chainID,
 		log:         log,
+		gasBudget:   gas.MaxGasExternalViewCall,
 	}
 }
 
@@ -92,6 +105,20 @@ func (v *Viewcontext) callView(contractHname, epCode iscp.Hname, params dict.Dic
 	return ep.Call(newSandboxView(v, contractHname, params))
 }
 
+// GasBurn burns gas from the budget of the view call, including all nested calls.
+// It panics with gas.ErrNotEnoughGas when the budget is exceeded
+func (v *Viewcontext) GasBurn(g uint64) {
+	if g > v.gasBudget-v.gasBurned {
+		v.gasBurned = v.gasBudget
+		panic(gas.ErrNotEnoughGas)
+	}
+	v.gasBurned += g
+}
+
+func (v *Viewcontext) GasBurned() uint64 {
+	return v.gasBurned
+}
+
 func contractStateSubpartition(stateKvReader kv.KVStoreReader, contractHname iscp.Hname) kv.KVStoreReader {
 	return subrealm.NewReadOnly(stateKvReader, kv.Key(contractHname.Bytes()))
 }
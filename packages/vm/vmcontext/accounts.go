@@ -8,6 +8,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 )
 
 func (vmctx *VMContext) AccountID() *iscp.AgentID {
@@ -27,6 +28,10 @@ func (vmctx *VMContext) commonAccount() *iscp.AgentID {
 	return commonaccount.Get(vmctx.chainID)
 }
 
+func (vmctx *VMContext) schedulerAccount() *iscp.AgentID {
+	return scheduler.Account(vmctx.chainID)
+}
+
 func (vmctx *VMContext) GetBalance(col colored.Color) uint64 {
 	return vmctx.getBalance(col)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 )
@@ -31,12 +32,16 @@ func (vmctx *VMContext) callByProgramHash(targetContract, epCode iscp.Hname, par
 	}
 	ep, ok := proc.GetEntryPoint(epCode)
 	if !ok {
+		if epCode == iscp.EntryPointMigrate {
+			// the migration entry point is optional
+			return nil, nil
+		}
 		ep = proc.GetDefaultEntryPoint()
 	}
 	// distinguishing between two types of entry points. Passing different types of sandboxes
 	if ep.IsView() {
-		if epCode == iscp.EntryPointInit {
-			return nil, fmt.Errorf("'init' entry point can't be a view")
+		if epCode == iscp.EntryPointInit || epCode == iscp.EntryPointMigrate {
+			return nil, fmt.Errorf("'init' and 'migrate' entry points can't be views")
 		}
 		// passing nil as transfer: calling the view should not have effect on chain ledger
 		if err := vmctx.pushCallContextWithTransfer(targetContract, params, nil); err != nil {
@@ -51,10 +56,10 @@ func (vmctx *VMContext) callByProgramHash(targetContract, epCode iscp.Hname, par
 	}
 	defer vmctx.popCallContext()
 
-	// prevent calling 'init' not from root contract or not while initializing root
-	if epCode == iscp.EntryPointInit && targetContract != root.Contract.Hname() {
+	// prevent calling 'init' or 'migrate' not from root contract or not while initializing root
+	if (epCode == iscp.EntryPointInit || epCode == iscp.EntryPointMigrate) && targetContract != root.Contract.Hname() {
 		if !vmctx.callerIsRoot() {
-			return nil, fmt.Errorf("attempt to callByProgramHash init not from the root contract")
+			return nil, fmt.Errorf("attempt to callByProgramHash init or migrate not from the root contract")
 		}
 	}
 	return ep.Call(NewSandbox(vmctx))
@@ -78,10 +83,10 @@ func (vmctx *VMContext) callNonViewByProgramHash(targetContract, epCode iscp.Hna
 	}
 	defer vmctx.popCallContext()
 
-	// prevent calling 'init' not from root contract or not while initializing root
-	if epCode == iscp.EntryPointInit && targetContract != root.Contract.Hname() {
+	// prevent calling 'init' or 'migrate' not from root contract or not while initializing root
+	if (epCode == iscp.EntryPointInit || epCode == iscp.EntryPointMigrate) && targetContract != root.Contract.Hname() {
 		if !vmctx.callerIsRoot() {
-			return nil, fmt.Errorf("attempt to callByProgramHash init not from the root contract")
+			return nil, fmt.Errorf("attempt to callByProgramHash init or migrate not from the root contract")
 		}
 	}
 	return ep.Call(NewSandbox(vmctx))
@@ -96,6 +101,10 @@ func (vmctx *VMContext) callerIsRoot() bool {
 }
 
 func (vmctx *VMContext) requesterIsLocal() bool {
+	if _, ok := vmctx.req.(*request.Scheduled); ok {
+		// scheduled calls pay the fees from their deposit
+		return false
+	}
 	return vmctx.chainOwnerID.Equals(vmctx.req.SenderAccount()) ||
 		vmctx.chainID.AsAddress().Equals(vmctx.req.SenderAccount().Address())
 }
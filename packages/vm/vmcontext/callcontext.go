@@ -45,8 +45,11 @@ func (vmctx *VMContext) pushCallContext(contract iscp.Hname, params dict.Dict, t
 	var caller *iscp.AgentID
 	isRequestContext := len(vmctx.callStack) == 0
 	if isRequestContext {
-		// request context
-		caller = vmctx.req.SenderAccount()
+		// request context. Before the first request of the block, the VM itself is the caller
+		caller = vmctx.commonAccount()
+		if vmctx.req != nil {
+			caller = vmctx.req.SenderAccount()
+		}
 	} else {
 		caller = vmctx.MyAgentID()
 	}
@@ -0,0 +1,45 @@
+package vmcontext
+
+import (
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+type gasContext struct {
+	vmctx *VMContext
+}
+
+var _ iscp.Gas = gasContext{}
+
+// Gas gives access to the gas budget of the current request
+func (vmctx *VMContext) Gas() iscp.Gas {
+	return gasContext{vmctx}
+}
+
+// GasBurn burns gas from the budget of the current request.
+// It panics with gas.ErrNotEnoughGas when the budget is exceeded.
+// The panic is caught in RunTheRequest and all state updates of the request are rolled back
+func (vmctx *VMContext) GasBurn(g uint64) {
+	if g > vmctx.gasBudget-vmctx.gasBurned {
+		vmctx.gasBurned = vmctx.gasBudget
+		panic(gas.ErrNotEnoughGas)
+	}
+	vmctx.gasBurned += g
+}
+
+func (vmctx *VMContext) GasBudgetLeft() uint64 {
+	return vmctx.gasBudget - vmctx.gasBurned
+}
+
+func (g gasContext) Burn(gas uint64) {
+	g.vmctx.GasBurn(gas)
+}
+
+func (g gasContext) Budget() uint64 {
+	return g.vmctx.GasBudgetLeft()
+}
+
+// WasmLimits returns the limits of the execution of Wasm code set in the governance contract
+func (vmctx *VMContext) WasmLimits() iscp.WasmLimits {
+	return vmctx.wasmLimits
+}
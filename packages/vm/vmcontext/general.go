@@ -89,6 +89,7 @@ func (vmctx *VMContext) Send(target ledgerstate.Address, tokens colored.Balances
 		}
 		data.WithTarget(metadata.TargetContract).
 			WithEntryPoint(metadata.EntryPoint).
+			WithGasBudget(metadata.GasBudget).
 			WithArgs(args)
 	}
 	sourceAccount := vmctx.adjustAccount(vmctx.MyAgentID())
@@ -21,7 +21,7 @@ func (vmctx *VMContext) creditToAccount(agentID *iscp.AgentID, transfer colored.
 	vmctx.pushCallContext(accounts.Contract.Hname(), nil, nil) // create local context for the state
 	defer vmctx.popCallContext()
 
-	accounts.CreditToAccount(vmctx.State(), agentID, transfer)
+	accounts.CreditToAccount(vmctx.State(), agentID, transfer, vmctx.AccountHistoryContext())
 }
 
 // debitFromAccount subtracts tokens from account if it is enough of it.
@@ -30,14 +30,26 @@ func (vmctx *VMContext) debitFromAccount(agentID *iscp.AgentID, transfer colored
 	vmctx.pushCallContext(accounts.Contract.Hname(), nil, nil) // create local context for the state
 	defer vmctx.popCallContext()
 
-	return accounts.DebitFromAccount(vmctx.State(), agentID, transfer)
+	return accounts.DebitFromAccount(vmctx.State(), agentID, transfer, vmctx.AccountHistoryContext())
 }
 
 func (vmctx *VMContext) moveBetweenAccounts(fromAgentID, toAgentID *iscp.AgentID, transfer colored.Balances) bool {
 	vmctx.pushCallContext(accounts.Contract.Hname(), nil, nil) // create local context for the state
 	defer vmctx.popCallContext()
 
-	return accounts.MoveBetweenAccounts(vmctx.State(), fromAgentID, toAgentID, transfer)
+	return accounts.MoveBetweenAccounts(vmctx.State(), fromAgentID, toAgentID, transfer, vmctx.AccountHistoryContext())
+}
+
+// AccountHistoryContext returns the context of the current request for the history of the accounts,
+// or nil if the history is disabled
+func (vmctx *VMContext) AccountHistoryContext() *accounts.HistoryContext {
+	if !vmctx.accountHistory {
+		return nil
+	}
+	return &accounts.HistoryContext{
+		BlockIndex: vmctx.virtualState.BlockIndex(),
+		RequestID:  vmctx.req.ID(),
+	}
 }
 
 func (vmctx *VMContext) totalAssets() colored.Balances {
@@ -61,11 +73,11 @@ func (vmctx *VMContext) getChainInfo() governance.ChainInfo {
 	return governance.MustGetChainInfo(vmctx.State())
 }
 
-func (vmctx *VMContext) getFeeInfo() (colored.Color, uint64, uint64) {
+func (vmctx *VMContext) getFeePolicy() *governance.FeePolicy {
 	vmctx.pushCallContext(governance.Contract.Hname(), nil, nil)
 	defer vmctx.popCallContext()
 
-	return governance.GetFeeInfoByHname(vmctx.State(), vmctx.contractRecord.Hname())
+	return governance.MustGetFeePolicy(vmctx.State())
 }
 
 func (vmctx *VMContext) getBinary(programHash hashing.HashValue) (string, []byte, error) {
@@ -110,6 +122,7 @@ func (vmctx *VMContext) moveBalance(target *iscp.AgentID, col colored.Color, amo
 		vmctx.MyAgentID(),
 		target,
 		colored.NewBalancesForColor(col, amount),
+		vmctx.AccountHistoryContext(),
 	)
 }
 
@@ -130,8 +143,10 @@ func (vmctx *VMContext) mustLogRequestToBlockLog(errProvided error) {
 		errStr = errProvided.Error()
 	}
 	err := blocklog.SaveRequestLogRecord(vmctx.State(), &blocklog.RequestReceipt{
-		Request: vmctx.req,
-		Error:   errStr,
+		Request:   vmctx.req,
+		Error:     errStr,
+		GasBudget: vmctx.gasBudget,
+		GasBurned: vmctx.gasBurned,
 	}, vmctx.requestLookupKey())
 	if err != nil {
 		vmctx.Panicf("logRequestToBlockLog: %v", err)
@@ -15,14 +15,15 @@ import (
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"golang.org/x/xerrors"
 )
 
-// TODO temporary place for the constant. In the future must be shared with pruning module
-//  the number is approximate assumed maximum number of requests in the batch
-//  The node must guarantee at least this number of last requests processed recorded in the state
-//  for each address
+// OffLedgerNonceStrictOrderTolerance is the approximate assumed maximum number of requests in the batch.
+// The node must guarantee at least this number of last requests processed recorded in the state
+// for each address: the pruning of the blocklog keeps the receipts of those requests
 const OffLedgerNonceStrictOrderTolerance = 10000
 
 // RunTheRequest processes any request based on the Extended output, even if it
@@ -45,7 +46,7 @@ func (vmctx *VMContext) RunTheRequest(req iscp.Request, requestIndex uint16) {
 		vmctx.chainOwnerID = vmctx.req.SenderAccount().Clone()
 	} else {
 		vmctx.getChainConfigFromState()
-		enoughFees := vmctx.mustHandleFees()
+		enoughFees := vmctx.mustReserveFees()
 		if !enoughFees {
 			return
 		}
@@ -89,6 +90,12 @@ func (vmctx *VMContext) RunTheRequest(req iscp.Request, requestIndex uint16) {
 		// rollback request processing, don't consume output or send funds back as this request should be processed in a following batch
 		vmctx.txBuilder = snapshotTxBuilderWithoutInput
 		vmctx.currentStateUpdate = state.NewStateUpdate()
+		if vmctx.req.IsFeePrepaid() {
+			// fee tokens were taken from the sender account before the run. Return them, the request is not charged
+			vmctx.mustReturnReservedFees()
+			vmctx.virtualState.ApplyStateUpdates(vmctx.currentStateUpdate)
+		}
+		return
 	}
 
 	if vmctx.lastError != nil {
@@ -96,7 +103,9 @@ func (vmctx *VMContext) RunTheRequest(req iscp.Request, requestIndex uint16) {
 		// restore the txbuilder and dispose mutations in the last state update
 		vmctx.txBuilder = snapshotTxBuilder
 		vmctx.currentStateUpdate = state.NewStateUpdate()
-
+	}
+	vmctx.mustChargeFees()
+	if vmctx.lastError != nil {
 		vmctx.mustSendBack(vmctx.remainingAfterFees)
 	}
 }
@@ -111,6 +120,10 @@ func (vmctx *VMContext) mustSetUpRequestContext(req iscp.Request, requestIndex u
 	vmctx.requestEventIndex = 0
 	vmctx.requestOutputCount = 0
 	vmctx.exceededBlockOutputLimit = false
+	vmctx.gasBudget = gas.Budget(req.GasBudget())
+	vmctx.gasBurned = 0
+	vmctx.feeReserved = 0
+	vmctx.feeCharged = 0
 
 	if !req.IsOffLedger() {
 		vmctx.txBuilder.AddConsumable(vmctx.req.(*request.OnLedger).Output())
@@ -140,6 +153,9 @@ func (vmctx *VMContext) mustSetUpRequestContext(req iscp.Request, requestIndex u
 			vmctx.log.Panicf("mustSetUpRequestContext.inconsistency: unexpected UTXO type")
 		}
 		vmctx.remainingAfterFees = colored.BalancesFromL1Balances(reqt.Output().Balances())
+	} else if sreq, ok := req.(*request.Scheduled); ok {
+		// scheduled call. The deposit can only be used to pay the fees
+		vmctx.remainingAfterFees = sreq.Deposit().Clone()
 	} else {
 		// off-ledger request
 		vmctx.remainingAfterFees = vmctx.adjustOffLedgerTransfer()
@@ -212,66 +228,129 @@ func (vmctx *VMContext) validateRequest() bool {
 	vmctx.log.Debugf("vmctx.validateRequest - nonce check - maxAssumed: %d, tolerance: %d, request nonce: %d ",
 		maxAssumed, OffLedgerNonceStrictOrderTolerance, req.Nonce())
 
+	return isNonceWithinTolerance(maxAssumed, req.Nonce())
+}
+
+// isNonceWithinTolerance returns true if a request with the nonce is accepted by the nonce check
+func isNonceWithinTolerance(maxAssumed, nonce uint64) bool {
 	if maxAssumed < OffLedgerNonceStrictOrderTolerance {
 		return true
 	}
-	return req.Nonce() > maxAssumed-OffLedgerNonceStrictOrderTolerance
+	return nonce > maxAssumed-OffLedgerNonceStrictOrderTolerance
+}
+
+// isNeededForReplayProtection returns true if the receipt of the processed request must be kept in the blocklog,
+// because a replay of the request would pass the nonce check
+func (vmctx *VMContext) isNeededForReplayProtection(rec *blocklog.RequestReceipt) bool {
+	req, ok := rec.Request.(*request.OffLedger)
+	if !ok {
+		// on-ledger requests can't be replayed, the output is consumed
+		return false
+	}
+	vmctx.pushCallContext(accounts.Contract.Hname(), nil, nil)
+	defer vmctx.popCallContext()
+
+	return isNonceWithinTolerance(accounts.GetMaxAssumedNonce(vmctx.State(), req.SenderAddress()), req.Nonce())
 }
 
-// mustHandleFees handles node fees. If not enough, takes as much as it can, the rest sends back
+// mustReserveFees reserves fee tokens for the gas budget of the request. The gas budget is reduced
+// to the amount of gas the available fee tokens can pay for. The actual fee is charged after the request is run.
+// If there are not enough tokens for the minimum fee, takes as much as it can, the rest sends back
 // Return false if not enough fees
-func (vmctx *VMContext) mustHandleFees() bool {
-	totalFee := vmctx.ownerFee + vmctx.validatorFee
-	if totalFee == 0 || vmctx.requesterIsLocal() {
+func (vmctx *VMContext) mustReserveFees() bool {
+	if !vmctx.feePolicy.Enabled() || vmctx.requesterIsLocal() {
 		// no fees enabled or the caller is the chain owner
-		vmctx.log.Debugf("mustHandleFees: no fees charged")
+		vmctx.log.Debugf("mustReserveFees: no fees charged")
 		return true
 	}
 
-	// process fees for owner and validator
-	if vmctx.grabFee(vmctx.commonAccount(), vmctx.ownerFee) &&
-		vmctx.grabFee(vmctx.validatorFeeTarget, vmctx.validatorFee) {
-		// there were enough fees for both
-		return true
+	// fees are taken from the tokens transferred with the request
+	available := vmctx.remainingAfterFees.Get(vmctx.feePolicy.FeeColor)
+	if affordable := vmctx.feePolicy.AffordableGas(available); affordable < vmctx.gasBudget {
+		vmctx.gasBudget = affordable
 	}
-
-	// not enough fees available
-	vmctx.mustSendBack(vmctx.remainingAfterFees)
-	vmctx.remainingAfterFees = nil
-	vmctx.lastError = fmt.Errorf("mustHandleFees: not enough fees for request %s. Remaining tokens were sent back to %s",
-		vmctx.req.ID(), vmctx.req.SenderAddress().Base58())
-	return false
+	if vmctx.gasBudget == 0 {
+		// not enough fees available
+		vmctx.mustTakeFeeTokens(available)
+		vmctx.mustCreditFees(available)
+		vmctx.feeCharged = available
+		vmctx.mustSendBack(vmctx.remainingAfterFees)
+		vmctx.remainingAfterFees = nil
+		vmctx.lastError = fmt.Errorf("mustReserveFees: not enough fees for request %s. Remaining tokens were sent back to %s",
+			vmctx.req.ID(), vmctx.req.SenderAddress().Base58())
+		return false
+	}
+	vmctx.feeReserved = vmctx.feePolicy.FeeForGas(vmctx.gasBudget)
+	vmctx.mustTakeFeeTokens(vmctx.feeReserved)
+	return true
 }
 
-// Return false if not enough fees
-func (vmctx *VMContext) grabFee(account *iscp.AgentID, amount uint64) bool {
+// mustTakeFeeTokens takes fee tokens from the tokens transferred with the request.
+// The tokens are kept by the VM until they are credited to fee accounts or returned
+func (vmctx *VMContext) mustTakeFeeTokens(amount uint64) {
 	if amount == 0 {
-		return true
+		return
+	}
+	vmctx.remainingAfterFees.SubNoOverflow(vmctx.feePolicy.FeeColor, amount)
+	if !vmctx.req.IsFeePrepaid() {
+		return
+	}
+	// fees should have been deposited in sender account on chain
+	if !vmctx.debitFromAccount(vmctx.feeAccount(), colored.NewBalancesForColor(vmctx.feePolicy.FeeColor, amount)) {
+		vmctx.log.Panicf("mustTakeFeeTokens.inconsistency: can't debit %d fee tokens from %s", amount, vmctx.feeAccount())
 	}
+}
 
-	// determine how much fees we can actually take
-	available := vmctx.remainingAfterFees.Get(vmctx.feeColor)
-	if available == 0 {
-		return false
+// mustCreditFees splits the fee between the chain owner and the validator according to the fee policy
+func (vmctx *VMContext) mustCreditFees(fee uint64) {
+	ownerFee, validatorFee := vmctx.feePolicy.SplitFee(fee)
+	if ownerFee > 0 {
+		vmctx.creditToAccount(vmctx.commonAccount(), colored.NewBalancesForColor(vmctx.feePolicy.FeeColor, ownerFee))
 	}
-	enoughFees := available >= amount
-	if !enoughFees {
-		// just take whatever is there
-		amount = available
+	if validatorFee > 0 {
+		vmctx.creditToAccount(vmctx.validatorFeeTarget, colored.NewBalancesForColor(vmctx.feePolicy.FeeColor, validatorFee))
 	}
-	vmctx.remainingAfterFees.SubNoOverflow(vmctx.feeColor, amount)
+}
 
-	// get ready to transfer the fees
-	transfer := colored.NewBalancesForColor(vmctx.feeColor, amount)
+// mustChargeFees charges the fee for the gas burned by the request from the reserved fee tokens.
+// The rest of the reserve is returned to the sender: sent back together with other tokens if the request failed,
+// otherwise credited to the sender account on the chain
+func (vmctx *VMContext) mustChargeFees() {
+	if vmctx.feeReserved == 0 {
+		return
+	}
+	fee := vmctx.feePolicy.FeeForGas(vmctx.gasBurned)
+	if fee > vmctx.feeReserved {
+		// can't happen, gas burned never exceeds the budget
+		fee = vmctx.feeReserved
+	}
+	vmctx.mustCreditFees(fee)
+	vmctx.feeCharged = fee
+	vmctx.feeReserved -= fee
+	if vmctx.lastError != nil && !vmctx.req.IsFeePrepaid() {
+		vmctx.remainingAfterFees.Add(vmctx.feePolicy.FeeColor, vmctx.feeReserved)
+		vmctx.feeReserved = 0
+		return
+	}
+	vmctx.mustReturnReservedFees()
+}
 
-	if !vmctx.req.IsFeePrepaid() {
-		vmctx.creditToAccount(account, transfer)
-		return enoughFees
+// mustReturnReservedFees credits the fee tokens reserved but not charged back to the sender account
+func (vmctx *VMContext) mustReturnReservedFees() {
+	if vmctx.feeReserved == 0 {
+		return
 	}
+	vmctx.creditToAccount(vmctx.feeAccount(), colored.NewBalancesForColor(vmctx.feePolicy.FeeColor, vmctx.feeReserved))
+	vmctx.feeReserved = 0
+}
 
-	// fees should have been deposited in sender account on chain
-	sender := vmctx.req.SenderAccount()
-	return vmctx.moveBetweenAccounts(sender, account, transfer) && enoughFees
+// feeAccount is the on-chain account prepaid fees are taken from.
+// Fees of scheduled calls are paid from the deposit, which is held in the account of the scheduler
+func (vmctx *VMContext) feeAccount() *iscp.AgentID {
+	if _, ok := vmctx.req.(*request.Scheduled); ok {
+		return vmctx.schedulerAccount()
+	}
+	return vmctx.adjustAccount(vmctx.req.SenderAccount())
 }
 
 func (vmctx *VMContext) mustSendBack(tokens colored.Balances) {
@@ -307,8 +386,13 @@ func (vmctx *VMContext) mustCallFromRequest() {
 	entryPoint := vmctx.req.Target().EntryPoint
 	targetContract := vmctx.contractRecord.Hname()
 	params, _ := vmctx.req.Params()
+	transfer := vmctx.remainingAfterFees
+	if _, ok := vmctx.req.(*request.Scheduled); ok {
+		// the rest of the deposit stays with the scheduler
+		transfer = nil
+	}
 	vmctx.lastResult, vmctx.lastError = vmctx.callNonViewByProgramHash(
-		targetContract, entryPoint, params, vmctx.remainingAfterFees, vmctx.contractRecord.ProgramHash)
+		targetContract, entryPoint, params, transfer, vmctx.contractRecord.ProgramHash)
 }
 
 func (vmctx *VMContext) mustUpdateOffledgerRequestMaxAssumedNonce() {
@@ -345,7 +429,9 @@ func (vmctx *VMContext) getChainConfigFromState() {
 	vmctx.chainOwnerID = cfg.ChainOwnerID
 	vmctx.maxEventSize = cfg.MaxEventSize
 	vmctx.maxEventsPerReq = cfg.MaxEventsPerReq
-	vmctx.feeColor, vmctx.ownerFee, vmctx.validatorFee = vmctx.getFeeInfo()
+	vmctx.accountHistory = cfg.AccountHistory
+	vmctx.wasmLimits = cfg.WasmLimits
+	vmctx.feePolicy = vmctx.getFeePolicy()
 }
 
 func (vmctx *VMContext) isInitChainRequest() bool {
@@ -0,0 +1,138 @@
+package vmcontext
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+)
+
+// RunScheduledCalls runs the calls registered in the scheduler contract which are due at the timestamp of the block.
+// It must be called before the requests of the batch are run, the calls take the first request indices of the block.
+// Returns the number of calls run and the number of successful ones
+func (vmctx *VMContext) RunScheduledCalls() (uint16, uint16) {
+	// no request is run yet, the state update is only needed to read the state
+	vmctx.currentStateUpdate = state.NewStateUpdate()
+	defer func() {
+		// the block may consist of the scheduled calls only, so the total assets are checked after them
+		vmctx.currentStateUpdate = state.NewStateUpdate()
+		vmctx.lastTotalAssets = vmctx.totalAssets()
+		vmctx.currentStateUpdate = nil
+	}()
+
+	if _, ok := vmctx.findContractByHname(scheduler.Contract.Hname()); !ok {
+		// the chain is not initialized yet or was deployed without the scheduler
+		return 0, 0
+	}
+	now := vmctx.virtualState.Timestamp()
+	var numRun, numSuccess uint16
+	for _, call := range vmctx.getDueScheduledCalls(now) {
+		req := vmctx.mustTakeScheduledCall(call, now)
+		vmctx.RunTheRequest(req, numRun)
+		if vmctx.exceededBlockOutputLimit {
+			if numRun == 0 {
+				// the outputs of the call don't fit even in an empty block, so this execution is dropped
+				vmctx.log.Warnf("RunScheduledCalls: execution of scheduled call %d dropped, its outputs exceed the block limit", call.ID)
+				vmctx.mustSettleScheduledCall(req)
+				continue
+			}
+			// the call and the following ones will be run first in the next block
+			vmctx.mustRestoreScheduledCall(call)
+			break
+		}
+		vmctx.mustSettleScheduledCall(req)
+		numRun++
+		if vmctx.lastError == nil {
+			numSuccess++
+		}
+	}
+	vmctx.log.Debugf("RunScheduledCalls: ran %d scheduled calls, success: %d", numRun, numSuccess)
+	return numRun, numSuccess
+}
+
+func (vmctx *VMContext) getDueScheduledCalls(now time.Time) []*scheduler.ScheduledCall {
+	vmctx.pushCallContext(scheduler.Contract.Hname(), nil, nil)
+	defer vmctx.popCallContext()
+
+	return scheduler.GetDueCalls(vmctx.State(), now, scheduler.MaxScheduledCallsPerBlock)
+}
+
+// mustTakeScheduledCall advances the schedule of the call and creates the request for its execution.
+// The deposit is moved from the call to the request, so the fees of the execution are paid from it
+func (vmctx *VMContext) mustTakeScheduledCall(call *scheduler.ScheduledCall, now time.Time) *request.Scheduled {
+	vmctx.currentStateUpdate = state.NewStateUpdate()
+
+	// the account of the scheduler holds the deposits of all calls
+	deposit := call.Deposit.Clone()
+	deposit.ForEachSorted(func(col colored.Color, bal uint64) bool {
+		if available := vmctx.getBalanceOfAccount(vmctx.schedulerAccount(), col); bal > available {
+			vmctx.log.Panicf("mustTakeScheduledCall.inconsistency: deposit %d of color %s of call %d exceeds the balance %d of the scheduler",
+				bal, col.String(), call.ID, available)
+		}
+		return true
+	})
+	req := request.NewScheduled(vmctx.chainID, call.Contract, call.EntryPoint, call.ID, call.Executions,
+		call.Params, call.GasBudget, call.Time, deposit)
+
+	vmctx.pushCallContext(scheduler.Contract.Hname(), nil, nil)
+	next, err := scheduler.ScheduledCallFromBytes(call.Bytes())
+	if err != nil {
+		vmctx.log.Panicf("mustTakeScheduledCall: %v", err)
+	}
+	next.ID = call.ID
+	if next.Advance(now) {
+		next.Deposit = colored.NewBalances()
+		scheduler.SaveCall(vmctx.State(), next)
+	} else {
+		scheduler.DeleteCall(vmctx.State(), call.ID)
+	}
+	vmctx.popCallContext()
+	vmctx.virtualState.ApplyStateUpdates(vmctx.currentStateUpdate)
+	vmctx.currentStateUpdate = nil
+	return req
+}
+
+// mustRestoreScheduledCall puts back the call as it was before it was taken
+func (vmctx *VMContext) mustRestoreScheduledCall(call *scheduler.ScheduledCall) {
+	vmctx.currentStateUpdate = state.NewStateUpdate()
+	vmctx.pushCallContext(scheduler.Contract.Hname(), nil, nil)
+	scheduler.SaveCall(vmctx.State(), call)
+	vmctx.popCallContext()
+	vmctx.virtualState.ApplyStateUpdates(vmctx.currentStateUpdate)
+	vmctx.currentStateUpdate = nil
+}
+
+// mustSettleScheduledCall returns the part of the deposit not charged as fees to the call.
+// If the call has no executions left, was canceled or the deposit can't pay the fees of the next execution,
+// the call is removed and the deposit is credited to the contract which scheduled it
+func (vmctx *VMContext) mustSettleScheduledCall(req *request.Scheduled) {
+	remainder := req.Deposit().Clone()
+	if vmctx.feeCharged > 0 {
+		remainder.SubNoOverflow(vmctx.feePolicy.FeeColor, vmctx.feeCharged)
+	}
+	vmctx.currentStateUpdate = state.NewStateUpdate()
+	vmctx.pushCallContext(scheduler.Contract.Hname(), nil, nil)
+	call, ok := scheduler.GetCall(vmctx.State(), req.CallID())
+	if ok && vmctx.feePolicy.Enabled() && remainder.Get(vmctx.feePolicy.FeeColor) == 0 {
+		vmctx.log.Debugf("mustSettleScheduledCall: deposit of scheduled call %d is exhausted", call.ID)
+		scheduler.DeleteCall(vmctx.State(), call.ID)
+		ok = false
+	}
+	if ok {
+		call.Deposit.AddAll(remainder)
+		scheduler.SaveCall(vmctx.State(), call)
+	}
+	vmctx.popCallContext()
+	if !ok && !remainder.IsEmpty() {
+		vmctx.pushCallContext(accounts.Contract.Hname(), nil, nil)
+		if !accounts.MoveBetweenAccounts(vmctx.State(), vmctx.schedulerAccount(), vmctx.adjustAccount(req.SenderAccount()), remainder, vmctx.AccountHistoryContext()) {
+			vmctx.log.Panicf("mustSettleScheduledCall.inconsistency: can't return deposit %s to %s", remainder, req.SenderAccount())
+		}
+		vmctx.popCallContext()
+	}
+	vmctx.virtualState.ApplyStateUpdates(vmctx.currentStateUpdate)
+	vmctx.currentStateUpdate = nil
+}
@@ -22,8 +22,8 @@ func (vmctx *VMContext) chainState() chainStateWrapper {
 func (s chainStateWrapper) Has(name kv.Key) (bool, error) {
 	s.vmctx.solidStateBaseline.MustValidate()
 
-	if _, ok := s.vmctx.currentStateUpdate.Mutations().Sets[name]; ok {
-		return true, nil
+	if v, ok := s.vmctx.currentStateUpdate.Mutations().Get(name); ok {
+		return v != nil, nil
 	}
 	return s.vmctx.virtualState.KVStore().Has(name)
 }
@@ -112,8 +112,7 @@ func (s chainStateWrapper) IterateKeysSorted(prefix kv.Key, f func(key kv.Key) b
 func (s chainStateWrapper) Get(name kv.Key) ([]byte, error) {
 	s.vmctx.solidStateBaseline.MustValidate()
 
-	v, ok := s.vmctx.currentStateUpdate.Mutations().Sets[name]
-	if ok {
+	if v, ok := s.vmctx.currentStateUpdate.Mutations().Get(name); ok {
 		return v, nil
 	}
 	return s.vmctx.virtualState.KVStore().Get(name)
@@ -101,3 +101,35 @@ func TestIterate(t *testing.T) {
 	require.Equal(t, []byte{42 * 2}, arr[1])
 	assert.NoError(t, err)
 }
+
+func TestDelCommittedThenGet(t *testing.T) {
+	chainID := iscp.RandomChainID([]byte("hmm"))
+	virtualState, _ := state.CreateOriginState(mapdb.NewMapDB(), chainID)
+	hname := iscp.Hn("test")
+
+	// variable x is committed in a previous state update
+	su := state.NewStateUpdate()
+	su.Mutations().Set(kv.Key(hname.Bytes())+"x", []byte{42})
+	virtualState.ApplyStateUpdates(su)
+
+	vmctx := &VMContext{
+		virtualState:       virtualState,
+		currentStateUpdate: state.NewStateUpdate(),
+		solidStateBaseline: coreutil.NewChainStateSync().SetSolidIndex(0).GetSolidIndexBaseline(),
+		callStack:          []*callContext{{contract: hname}},
+	}
+	s := vmctx.State()
+
+	v, err := s.Get("x")
+	require.NoError(t, err)
+	require.Equal(t, []byte{42}, v)
+
+	// contract deletes variable x and does not see the committed value anymore
+	s.Del("x")
+	v, err = s.Get("x")
+	require.NoError(t, err)
+	require.Nil(t, v)
+	ok, err := s.Has("x")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package vmcontext
 
 import (
+	"math"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -45,17 +46,23 @@ type VMContext struct {
 	blockOutputCount     uint8
 	// fee related
 	validatorFeeTarget *iscp.AgentID // provided by validator
-	feeColor           colored.Color
-	ownerFee           uint64
-	validatorFee       uint64
+	feePolicy          *governance.FeePolicy
 	// events related
 	maxEventSize    uint16
 	maxEventsPerReq uint16
+	// accounts contract records the history of the accounts
+	accountHistory bool
+	// limits of the execution of Wasm code
+	wasmLimits iscp.WasmLimits
 	// request context
 	req                      iscp.Request
 	requestIndex             uint16
 	requestEventIndex        uint16
 	requestOutputCount       uint8
+	gasBudget                uint64
+	gasBurned                uint64
+	feeReserved              uint64
+	feeCharged               uint64
 	currentStateUpdate       state.StateUpdate
 	entropy                  hashing.HashValue // mutates with each request
 	contractRecord           *root.ContractRecord
@@ -81,12 +88,7 @@ type blockContext struct {
 
 // CreateVMContext creates a context for the whole batch run
 func CreateVMContext(task *vm.VMTask) *VMContext {
-	// assert consistency. It is a bit redundant double check
-
-	if len(task.Requests) == 0 {
-		// should never happen
-		task.Log.Panicf("CreateVMContext.invalid params: must be at least 1 request")
-	}
+	// the batch may be empty if the block only runs the due calls of the scheduler
 	txb := utxoutil.NewBuilder(task.ChainInput)
 
 	chainID, err := iscp.ChainIDFromAddress(task.ChainInput.Address())
@@ -143,6 +145,16 @@ func (vmctx *VMContext) GetResult() (dict.Dict, colored.Balances, error, bool) {
 	return vmctx.lastResult, vmctx.lastTotalAssets, vmctx.lastError, vmctx.exceededBlockOutputLimit
 }
 
+// GetGasAndFees returns the gas budget and the gas burned by the last request,
+// together with the color and the amount of the fee charged for it
+func (vmctx *VMContext) GetGasAndFees() (uint64, uint64, colored.Color, uint64) {
+	feeColor := colored.IOTA
+	if vmctx.feePolicy != nil {
+		feeColor = vmctx.feePolicy.FeeColor
+	}
+	return vmctx.gasBudget, vmctx.gasBurned, feeColor, vmctx.feeCharged
+}
+
 func (vmctx *VMContext) BuildTransactionEssence(stateHash hashing.HashValue, timestamp time.Time) (*ledgerstate.TransactionEssence, error) {
 	if err := vmctx.txBuilder.AddAliasOutputAsRemainder(vmctx.chainID.AsAddress(), stateHash[:]); err != nil {
 		return nil, xerrors.Errorf("mustFinalizeRequestCall: %v", err)
@@ -156,8 +168,8 @@ func (vmctx *VMContext) BuildTransactionEssence(stateHash hashing.HashValue, tim
 
 // CloseVMContext does the closing actions on the block
 // return nil for normal block and rotation address for rotation block
-func (vmctx *VMContext) CloseVMContext(numRequests, numSuccess, numOffLedger uint16) (uint32, hashing.HashValue, time.Time, ledgerstate.Address) {
-	rotationAddr := vmctx.mustSaveBlockInfo(numRequests, numSuccess, numOffLedger)
+func (vmctx *VMContext) CloseVMContext(numRequests, numSuccess, numOffLedger, numScheduled uint16) (uint32, hashing.HashValue, time.Time, ledgerstate.Address) {
+	rotationAddr := vmctx.mustSaveBlockInfo(numRequests, numSuccess, numOffLedger, numScheduled)
 	vmctx.closeBlockContexts()
 
 	blockIndex := vmctx.virtualState.BlockIndex()
@@ -176,7 +188,7 @@ func (vmctx *VMContext) checkRotationAddress() ledgerstate.Address {
 
 // mustSaveBlockInfo is in the blocklog partition context
 // returns rotation address if this block is a rotation block
-func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger uint16) ledgerstate.Address {
+func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger, numScheduled uint16) ledgerstate.Address {
 	vmctx.currentStateUpdate = state.NewStateUpdate() // need this before to make state valid
 
 	if rotationAddress := vmctx.checkRotationAddress(); rotationAddress != nil {
@@ -185,6 +197,7 @@ func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger
 		// We skip saving block information in order to avoid inconsistencies
 		return rotationAddress
 	}
+	blockKeepAmount := vmctx.getChainInfo().BlockKeepAmount
 	// block info will be stored into the separate state update
 	vmctx.pushCallContext(blocklog.Contract.Hname(), nil, nil)
 	defer vmctx.popCallContext()
@@ -195,6 +208,7 @@ func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger
 		TotalRequests:         numRequests,
 		NumSuccessfulRequests: numSuccess,
 		NumOffLedgerRequests:  numOffLedger,
+		NumScheduledCalls:     numScheduled,
 		PreviousStateHash:     vmctx.StateHash(),
 	}
 
@@ -211,6 +225,9 @@ func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger
 		vmctx.GoverningAddress(),
 		vmctx.chainInput.GetStateIndex(),
 	)
+	if pruned := blocklog.PruneRequestLogs(vmctx.State(), idx, blockKeepAmount, vmctx.isNeededForReplayProtection); pruned > 0 {
+		vmctx.log.Debugf("CloseVMContext: pruned %d bytes of request receipts and events", pruned)
+	}
 	vmctx.virtualState.ApplyStateUpdates(vmctx.currentStateUpdate)
 	vmctx.currentStateUpdate = nil // invalidate
 
@@ -219,6 +236,8 @@ func (vmctx *VMContext) mustSaveBlockInfo(numRequests, numSuccess, numOffLedger
 
 // closeBlockContexts closing block contexts in deterministic FIFO sequence
 func (vmctx *VMContext) closeBlockContexts() {
+	// closing the block contexts is not charged to the gas budget of the last request
+	vmctx.gasBudget, vmctx.gasBurned = math.MaxUint64, 0
 	vmctx.currentStateUpdate = state.NewStateUpdate()
 	for _, hname := range vmctx.blockContextCloseSeq {
 		b := vmctx.blockContext[hname]
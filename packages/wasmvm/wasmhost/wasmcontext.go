@@ -4,6 +4,8 @@
 package wasmhost
 
 import (
+	"errors"
+
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -92,7 +94,18 @@ func (wc *WasmContext) callFunction() error {
 
 	saveID := wc.proc.currentContextID
 	wc.proc.currentContextID = wc.id
-	err := wc.proc.RunScFunction(wc.funcName)
+	wc.proc.vm.GasDisable(false)
+	wc.proc.vm.GasBudget(wc.GasBudget() * wc.proc.gasFactor())
+	err := wc.proc.vm.SetLimits(wc.wcSandbox.common.WasmLimits())
+	if err != nil {
+		wc.proc.currentContextID = saveID
+		return err
+	}
+	err = wc.proc.RunScFunction(wc.funcName)
+	if err == nil || errors.Is(err, ErrGasBudgetExceeded) {
+		// burn the gas consumed after the last host call
+		wc.GasBurned(wc.proc.vm.GasBurned() / wc.proc.gasFactor())
+	}
 	wc.proc.currentContextID = saveID
 	return err
 }
@@ -121,6 +134,16 @@ func (wc *WasmContext) FunctionFromCode(code uint32) string {
 	return wc.funcTable.FunctionFromCode(code)
 }
 
+// GasBudget returns the remaining gas budget of the call
+func (wc *WasmContext) GasBudget() uint64 {
+	return wc.wcSandbox.common.Gas().Budget()
+}
+
+// GasBurned burns the gas consumed by the Wasm code
+func (wc *WasmContext) GasBurned(burned uint64) {
+	wc.wcSandbox.common.Gas().Burn(burned)
+}
+
 func (wc *WasmContext) IsView() bool {
 	return wc.proc.IsView(wc.funcName)
 }
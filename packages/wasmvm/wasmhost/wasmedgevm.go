@@ -9,12 +9,15 @@ package wasmhost
 import (
 	"errors"
 
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/second-state/WasmEdge-go/wasmedge"
 )
 
 type WasmEdgeVM struct {
 	WasmVMBase
 	edge      *wasmedge.VM
+	info      *wasmModuleInfo
+	lastFuel  int64
 	memory    *wasmedge.Memory
 	module    *wasmedge.ImportObject
 	store     *wasmedge.Store
@@ -32,14 +35,26 @@ func NewWasmEdgeVM() WasmVM {
 	wasmedge.SetLogErrorLevel()
 
 	vm.edge = wasmedge.NewVM()
-
-	//config := wasmedge.NewConfig()
-	//config.SetInterruptable(true)
-	//vm.store = wasmedge.NewStore(wasmedge.NewEngineWithConfig(config))
-	//vm.interrupt, _ = vm.store.InterruptHandle()
 	return vm
 }
 
+// GasBudget sets the gas budget for the VM.
+// WasmEdge has no fuel metering, the instrumented code burns the fuel in the exported fuel global
+func (vm *WasmEdgeVM) GasBudget(budget uint64) {
+	vm.setGlobal(exportFuel, int64(budget))
+	vm.lastFuel = int64(budget)
+}
+
+// GasBurned will return the gas burned since the last time GasBudget() was called
+func (vm *WasmEdgeVM) GasBurned() uint64 {
+	fuel := vm.getGlobal(exportFuel)
+	if fuel < 0 {
+		// the budget was exceeded
+		fuel = 0
+	}
+	return uint64(vm.lastFuel - fuel)
+}
+
 func (vm *WasmEdgeVM) Instantiate() error {
 	err := vm.edge.Instantiate()
 	if err != nil {
@@ -49,14 +64,12 @@ func (vm *WasmEdgeVM) Instantiate() error {
 	if vm.memory == nil {
 		return errors.New("no memory export")
 	}
+	// instantiation and initialization of the module are not charged to the request,
+	// the budget will be set before calling the smart contract function
+	vm.GasBudget(unmeteredFuel)
 	return nil
 }
 
-//TODO
-func (vm *WasmEdgeVM) Interrupt() {
-	panic("implement me")
-}
-
 func (vm *WasmEdgeVM) LinkHost(proc *WasmProcessor) error {
 	_ = vm.WasmVMBase.LinkHost(proc)
 
@@ -88,8 +101,13 @@ func (vm *WasmEdgeVM) LinkHost(proc *WasmProcessor) error {
 	return vm.edge.RegisterImport(vm.module)
 }
 
-func (vm *WasmEdgeVM) LoadWasm(wasmData []byte) error {
-	err := vm.edge.LoadWasmBuffer(wasmData)
+func (vm *WasmEdgeVM) LoadWasm(wasmData []byte) (err error) {
+	// WasmEdge does not meter fuel, so the instrumented code meters it
+	wasmData, vm.info, err = instrumentWasm(wasmData, true)
+	if err != nil {
+		return err
+	}
+	err = vm.edge.LoadWasmBuffer(wasmData)
 	if err != nil {
 		return err
 	}
@@ -101,21 +119,64 @@ func (vm *WasmEdgeVM) LoadWasm(wasmData []byte) error {
 }
 
 func (vm *WasmEdgeVM) NewInstance() WasmVM {
-	return NewWasmEdgeVM()
+	instance := NewWasmEdgeVM().(*WasmEdgeVM)
+	instance.info = vm.info
+	return instance
 }
 
 func (vm *WasmEdgeVM) RunFunction(functionName string, args ...interface{}) error {
-	return vm.Run(func() (err error) {
+	err := vm.Run(func() (err error) {
 		_, err = vm.edge.Execute(functionName, args...)
 		return err
 	})
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
 }
 
 func (vm *WasmEdgeVM) RunScFunction(index int32) error {
-	return vm.Run(func() (err error) {
+	err := vm.Run(func() (err error) {
 		_, err = vm.edge.Execute("on_call", index)
 		return err
 	})
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
+}
+
+// SetLimits checks the module against the limits and sets the limits enforced by the instrumented code
+func (vm *WasmEdgeVM) SetLimits(limits iscp.WasmLimits) error {
+	if err := vm.info.checkLimits(limits); err != nil {
+		return err
+	}
+	vm.setGlobal(exportCallDepthLimit, int64(limits.MaxCallDepth))
+	vm.setGlobal(exportMemoryLimit, int64(limits.MaxMemoryPages))
+	vm.setGlobal(exportCallDepth, 0)
+	vm.setGlobal(exportTrap, 0)
+	return nil
+}
+
+func (vm *WasmEdgeVM) getGlobal(name string) int64 {
+	global := vm.edge.GetStore().FindGlobal(name)
+	if global == nil {
+		panic("wasmedge.getGlobal: unknown global " + name)
+	}
+	switch value := global.GetValue().(type) {
+	case int32:
+		return int64(value)
+	case int64:
+		return value
+	}
+	panic("wasmedge.getGlobal: invalid type of global " + name)
+}
+
+// setGlobal sets the global with the value converted to the type of the global
+func (vm *WasmEdgeVM) setGlobal(name string, value int64) {
+	global := vm.edge.GetStore().FindGlobal(name)
+	if global == nil {
+		panic("wasmedge.setGlobal: unknown global " + name)
+	}
+	if name == exportFuel {
+		global.SetValue(value)
+		return
+	}
+	global.SetValue(int32(value))
 }
 
 func (vm *WasmEdgeVM) UnsafeMemory() []byte {
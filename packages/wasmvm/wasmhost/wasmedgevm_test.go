@@ -0,0 +1,8 @@
+//go:build wasmedge
+// +build wasmedge
+
+package wasmhost
+
+func init() {
+	gasTestVMs["wasmedge"] = NewWasmEdgeVM
+}
@@ -7,12 +7,15 @@
 package wasmhost
 
 import (
+	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/wasmerio/wasmer-go/wasmer"
 )
 
 type WasmerVM struct {
 	WasmVMBase
+	info     *wasmModuleInfo
 	instance *wasmer.Instance
+	lastFuel int64
 	linker   *wasmer.ImportObject
 	memory   *wasmer.Memory
 	module   *wasmer.Module
@@ -27,9 +30,21 @@ func NewWasmerVM() WasmVM {
 	return vm
 }
 
-//TODO
-func (vm *WasmerVM) Interrupt() {
-	panic("implement me")
+// GasBudget sets the gas budget for the VM.
+// Wasmer has no fuel metering, the instrumented code burns the fuel in the exported fuel global
+func (vm *WasmerVM) GasBudget(budget uint64) {
+	vm.setGlobal(exportFuel, int64(budget))
+	vm.lastFuel = int64(budget)
+}
+
+// GasBurned will return the gas burned since the last time GasBudget() was called
+func (vm *WasmerVM) GasBurned() uint64 {
+	fuel := vm.getGlobal(exportFuel)
+	if fuel < 0 {
+		// the budget was exceeded
+		fuel = 0
+	}
+	return uint64(vm.lastFuel - fuel)
 }
 
 func (vm *WasmerVM) LinkHost(proc *WasmProcessor) error {
@@ -57,8 +72,12 @@ func (vm *WasmerVM) LinkHost(proc *WasmProcessor) error {
 	return nil
 }
 
-func (vm *WasmerVM) LoadWasm(wasmData []byte) error {
-	var err error
+func (vm *WasmerVM) LoadWasm(wasmData []byte) (err error) {
+	// Wasmer does not meter fuel, so the instrumented code meters it
+	wasmData, vm.info, err = instrumentWasm(wasmData, true)
+	if err != nil {
+		return err
+	}
 	vm.module, err = wasmer.NewModule(vm.store, wasmData)
 	if err != nil {
 		return err
@@ -68,11 +87,17 @@ func (vm *WasmerVM) LoadWasm(wasmData []byte) error {
 		return err
 	}
 	vm.memory, err = vm.instance.Exports.GetMemory("memory")
-	return err
+	if err != nil {
+		return err
+	}
+	// instantiation and initialization of the module are not charged to the request,
+	// the budget will be set before calling the smart contract function
+	vm.GasBudget(unmeteredFuel)
+	return nil
 }
 
 func (vm *WasmerVM) NewInstance() WasmVM {
-	return &WasmerVM{store: vm.store}
+	return &WasmerVM{info: vm.info, store: vm.store}
 }
 
 func (vm *WasmerVM) RunFunction(functionName string, args ...interface{}) error {
@@ -80,10 +105,11 @@ func (vm *WasmerVM) RunFunction(functionName string, args ...interface{}) error
 	if err != nil {
 		return err
 	}
-	return vm.Run(func() error {
+	err = vm.Run(func() error {
 		_, err = export(args...)
 		return err
 	})
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
 }
 
 func (vm *WasmerVM) RunScFunction(index int32) error {
@@ -95,7 +121,53 @@ func (vm *WasmerVM) RunScFunction(index int32) error {
 		_, err = export(index)
 		return err
 	})
-	return err
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
+}
+
+// SetLimits checks the module against the limits and sets the limits enforced by the instrumented code
+func (vm *WasmerVM) SetLimits(limits iscp.WasmLimits) error {
+	if err := vm.info.checkLimits(limits); err != nil {
+		return err
+	}
+	vm.setGlobal(exportCallDepthLimit, int64(limits.MaxCallDepth))
+	vm.setGlobal(exportMemoryLimit, int64(limits.MaxMemoryPages))
+	vm.setGlobal(exportCallDepth, 0)
+	vm.setGlobal(exportTrap, 0)
+	return nil
+}
+
+func (vm *WasmerVM) getGlobal(name string) int64 {
+	global, err := vm.instance.Exports.GetGlobal(name)
+	if err != nil {
+		panic("wasmer.getGlobal: " + err.Error())
+	}
+	value, err := global.Get()
+	if err != nil {
+		panic("wasmer.getGlobal: " + err.Error())
+	}
+	switch value := value.(type) {
+	case int32:
+		return int64(value)
+	case int64:
+		return value
+	}
+	panic("wasmer.getGlobal: invalid type of global " + name)
+}
+
+// setGlobal sets the global with the value converted to the type of the global
+func (vm *WasmerVM) setGlobal(name string, value int64) {
+	global, err := vm.instance.Exports.GetGlobal(name)
+	if err != nil {
+		panic("wasmer.setGlobal: " + err.Error())
+	}
+	if name == exportFuel {
+		err = global.Set(value, wasmer.I64)
+	} else {
+		err = global.Set(int32(value), wasmer.I32)
+	}
+	if err != nil {
+		panic("wasmer.setGlobal: " + err.Error())
+	}
 }
 
 func (vm *WasmerVM) UnsafeMemory() []byte {
@@ -0,0 +1,8 @@
+//go:build wasmer
+// +build wasmer
+
+package wasmhost
+
+func init() {
+	gasTestVMs["wasmer"] = NewWasmerVM
+}
@@ -0,0 +1,46 @@
+package wasmhost
+
+import (
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/stretchr/testify/require"
+)
+
+// gasTestVMs are the Wasm runtimes built into the test binary, the other ones are added by their build tags
+var gasTestVMs = map[string]func() WasmVM{
+	"wasmtime": NewWasmTimeVM,
+}
+
+// TestGasParity checks that every Wasm runtime burns exactly the same fuel for the same code
+func TestGasParity(t *testing.T) {
+	wasmData, err := wasmtime.Wat2Wasm(limitsTestModule)
+	require.NoError(t, err)
+
+	// the fuel burned by the instrumented code itself is the reference
+	inst, _ := newLimitsTestInstance(t, true)
+	inst.setGlobal(exportFuel, wasmtime.ValI64(1000))
+	_, err = inst.call("loop", int32(10))
+	require.NoError(t, err)
+	expected := uint64(1000 - inst.global(exportFuel).(int64))
+
+	for name, newVM := range gasTestVMs {
+		t.Run(name, func(t *testing.T) {
+			vm := newVM()
+			require.NoError(t, vm.LinkHost(nil))
+			require.NoError(t, vm.LoadWasm(wasmData))
+			require.NoError(t, vm.SetLimits(iscp.WasmLimits{MaxCallDepth: 100, MaxMemoryPages: 1, MaxTableSize: 2}))
+
+			for i := 0; i < 2; i++ {
+				vm.GasBudget(1000)
+				require.NoError(t, vm.RunFunction("loop", int32(10)))
+				require.Equal(t, expected, vm.GasBurned())
+			}
+
+			vm.GasBudget(1000)
+			require.ErrorIs(t, vm.RunFunction("loop", int32(1000)), ErrGasBudgetExceeded)
+			require.EqualValues(t, 1000, vm.GasBurned())
+		})
+	}
+}
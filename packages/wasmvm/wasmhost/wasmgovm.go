@@ -31,11 +31,6 @@ func (vm *WasmGoVM) Instantiate() error {
 	return nil
 }
 
-func (vm *WasmGoVM) Interrupt() {
-	// disabled for now
-	// panic("implement me")
-}
-
 func (vm *WasmGoVM) LoadWasm(wasmData []byte) error {
 	scName := string(wasmData)
 	if !strings.HasPrefix(scName, "go:") {
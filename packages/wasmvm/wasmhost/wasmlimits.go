@@ -0,0 +1,793 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasmhost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+)
+
+// The limits of the execution of Wasm code must not depend on the machine of the validator,
+// otherwise validators can reach different results for the same request.
+// Instead of relying on the features of each Wasm runtime, the Wasm code is instrumented before it is loaded,
+// so that WasmTime, WasmEdge and Wasmer enforce the limits in exactly the same way:
+// - every call between functions of the module increases a call depth counter, which traps above the limit
+// - memory.grow is replaced by a function which fails (returns -1) when memory would exceed the limit
+// - the tables can't grow beyond their initial size, imported tables are not allowed because they can't be clamped
+// - the code can't access the added globals, they are out of range of the original module
+// - fuel is charged at the start of each linear block of instructions, with the same cost on every runtime,
+//   so the native fuel metering of WasmTime is not used
+// The limits are exported as mutable globals and set by the host before each call of a smart contract function.
+
+const (
+	exportCallDepth      = "__wasp_call_depth"
+	exportCallDepthLimit = "__wasp_call_depth_limit"
+	exportMemoryLimit    = "__wasp_memory_limit"
+	exportTrap           = "__wasp_trap"
+	exportFuel           = "__wasp_fuel"
+
+	// exportPrefix is reserved for the exports added by the instrumentation
+	exportPrefix = "__wasp_"
+
+	// trap codes stored in the exported trap global before the instrumented code traps
+	trapFuel      = 1
+	trapCallDepth = 2
+)
+
+var (
+	ErrCallDepthExceeded = errors.New("call depth limit exceeded in Wasm VM")
+	ErrMemoryExceeded    = errors.New("memory limit exceeded in Wasm VM")
+	ErrTableExceeded     = errors.New("table size limit exceeded in Wasm VM")
+)
+
+// DefaultWasmLimits are the limits used before the limits of the chain are known, e.g. while the module is loaded
+var DefaultWasmLimits = iscp.WasmLimits{
+	MaxMemoryPages: governance.DefaultWasmMaxMemoryPages,
+	MaxTableSize:   governance.DefaultWasmMaxTableSize,
+	MaxCallDepth:   governance.DefaultWasmMaxCallDepth,
+}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+
+	valI32 = 0x7f
+	valI64 = 0x7e
+
+	opUnreachable = 0x00
+	opBlock       = 0x02
+	opLoop        = 0x03
+	opIf          = 0x04
+	opElse        = 0x05
+	opEnd         = 0x0b
+	opBrIf        = 0x0d
+	opCall        = 0x10
+	opCallInd     = 0x11
+	opLocalGet    = 0x20
+	opGlobalGet   = 0x23
+	opGlobalSet   = 0x24
+	opMemorySize  = 0x3f
+	opMemoryGrow  = 0x40
+	opI32Const    = 0x41
+	opI64Const    = 0x42
+	opI64LtS      = 0x53
+	opI64GtU      = 0x56
+	opI32GtU      = 0x4b
+	opI32Add      = 0x6a
+	opI32Sub      = 0x6b
+	opI64Add      = 0x7c
+	opI64Sub      = 0x7d
+	opI64ExtendU  = 0xad
+	opPrefixFC    = 0xfc
+)
+
+// sectionOrder is the order of the sections in the module, the data count section comes before the code section
+var sectionOrder = map[byte]int{
+	sectionType: 1, sectionImport: 2, sectionFunction: 3, sectionTable: 4, sectionMemory: 5, sectionGlobal: 6,
+	sectionExport: 7, sectionStart: 8, sectionElement: 9, sectionDataCount: 10, sectionCode: 11, sectionData: 12,
+}
+
+// wasmModuleInfo is what the host needs to know about the instrumented module
+type wasmModuleInfo struct {
+	memoryPages uint32
+	tableSize   uint32
+	meterFuel   bool
+}
+
+// checkLimits checks the static properties of the module against the limits
+func (info *wasmModuleInfo) checkLimits(limits iscp.WasmLimits) error {
+	if info.memoryPages > limits.MaxMemoryPages {
+		return ErrMemoryExceeded
+	}
+	if info.tableSize > limits.MaxTableSize {
+		return ErrTableExceeded
+	}
+	return nil
+}
+
+// trapError replaces the error of a trap caused by the instrumented code with the reason of the trap
+func trapError(err error, trap int32) error {
+	switch {
+	case err == nil:
+		return nil
+	case trap == trapFuel:
+		return ErrGasBudgetExceeded
+	case trap == trapCallDepth:
+		return ErrCallDepthExceeded
+	}
+	return err
+}
+
+type wasmSection struct {
+	id   byte
+	data []byte
+}
+
+type wasmInstrumenter struct {
+	info          wasmModuleInfo
+	sections      []*wasmSection
+	importedFuncs uint32
+	importedGlobs uint32
+	definedFuncs  uint32
+	definedGlobs  uint32
+	hasMemory     bool
+
+	// indices of the globals and of the memory.grow function added to the module
+	globalDepth      uint32
+	globalDepthLimit uint32
+	globalMemLimit   uint32
+	globalTrap       uint32
+	globalFuel       uint32
+	funcGrow         uint32
+	growType         uint32
+}
+
+// instrumentWasm adds the enforcement of the limits to the Wasm code.
+// With meterFuel the code also burns fuel from the exported fuel global
+func instrumentWasm(wasmData []byte, meterFuel bool) ([]byte, *wasmModuleInfo, error) {
+	in := &wasmInstrumenter{info: wasmModuleInfo{meterFuel: meterFuel}}
+	if err := in.parse(wasmData); err != nil {
+		return nil, nil, fmt.Errorf("instrumentWasm: %w", err)
+	}
+	ret, err := in.rewrite()
+	if err != nil {
+		return nil, nil, fmt.Errorf("instrumentWasm: %w", err)
+	}
+	return ret, &in.info, nil
+}
+
+func (in *wasmInstrumenter) parse(wasmData []byte) error {
+	if len(wasmData) < 8 || !bytes.Equal(wasmData[:8], []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}) {
+		return errors.New("not a Wasm module")
+	}
+	r := &wasmReader{data: wasmData, pos: 8}
+	for !r.eof() {
+		id := r.byte()
+		size := r.u32()
+		sec := &wasmSection{id: id, data: r.bytes(size)}
+		if r.err != nil {
+			return r.err
+		}
+		in.sections = append(in.sections, sec)
+		if err := in.scan(sec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scan collects the counts of the index spaces and the initial sizes of memory and tables
+func (in *wasmInstrumenter) scan(sec *wasmSection) error {
+	r := &wasmReader{data: sec.data}
+	switch sec.id {
+	case sectionImport:
+		for n := r.u32(); n > 0 && r.err == nil; n-- {
+			r.name()
+			r.name()
+			switch kind := r.byte(); kind {
+			case 0x00:
+				r.u32()
+				in.importedFuncs++
+			case 0x01:
+				// the maximum size of an imported table is set by the host, so it can't be clamped
+				return errors.New("imported tables are not supported")
+			case 0x02:
+				_, initial, _ := r.limits()
+				in.info.memoryPages = initial
+				in.hasMemory = true
+			case 0x03:
+				r.byte()
+				r.byte()
+				in.importedGlobs++
+			default:
+				return fmt.Errorf("unknown import kind %d", kind)
+			}
+		}
+	case sectionFunction:
+		in.definedFuncs = r.u32()
+	case sectionTable:
+		for n := r.u32(); n > 0 && r.err == nil; n-- {
+			r.byte()
+			_, initial, _ := r.limits()
+			in.info.tableSize += initial
+		}
+	case sectionMemory:
+		if r.u32() > 0 {
+			_, initial, _ := r.limits()
+			in.info.memoryPages = initial
+			in.hasMemory = true
+		}
+	case sectionGlobal:
+		in.definedGlobs = r.u32()
+	}
+	return r.err
+}
+
+func (in *wasmInstrumenter) rewrite() ([]byte, error) {
+	nextGlobal := in.importedGlobs + in.definedGlobs
+	in.globalDepth = nextGlobal
+	in.globalDepthLimit = nextGlobal + 1
+	in.globalMemLimit = nextGlobal + 2
+	in.globalTrap = nextGlobal + 3
+	in.globalFuel = nextGlobal + 4
+	in.funcGrow = in.importedFuncs + in.definedFuncs
+
+	for _, id := range []byte{sectionGlobal, sectionExport} {
+		if in.section(id) == nil {
+			in.insertSection(&wasmSection{id: id, data: []byte{0x00}})
+		}
+	}
+	var err error
+	for _, sec := range in.sections {
+		switch sec.id {
+		case sectionType:
+			sec.data, err = in.rewriteTypes(sec.data)
+		case sectionFunction:
+			sec.data, err = in.rewriteFunctions(sec.data)
+		case sectionTable:
+			sec.data, err = in.rewriteTables(sec.data)
+		case sectionGlobal:
+			sec.data, err = in.rewriteGlobals(sec.data)
+		case sectionExport:
+			sec.data, err = in.rewriteExports(sec.data)
+		case sectionCode:
+			sec.data, err = in.rewriteCode(sec.data)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w := &bytes.Buffer{}
+	w.Write([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00})
+	for _, sec := range in.sections {
+		w.WriteByte(sec.id)
+		writeU32(w, uint32(len(sec.data)))
+		w.Write(sec.data)
+	}
+	return w.Bytes(), nil
+}
+
+func (in *wasmInstrumenter) section(id byte) *wasmSection {
+	for _, sec := range in.sections {
+		if sec.id == id {
+			return sec
+		}
+	}
+	return nil
+}
+
+// insertSection inserts the section before the first section which must follow it
+func (in *wasmInstrumenter) insertSection(sec *wasmSection) {
+	for i, s := range in.sections {
+		if s.id != sectionCustom && sectionOrder[s.id] > sectionOrder[sec.id] {
+			in.sections = append(in.sections[:i], append([]*wasmSection{sec}, in.sections[i:]...)...)
+			return
+		}
+	}
+	in.sections = append(in.sections, sec)
+}
+
+// needsGrow is true if the module has memory, then memory.grow is replaced by the added function
+func (in *wasmInstrumenter) needsGrow() bool {
+	return in.hasMemory && in.section(sectionFunction) != nil
+}
+
+// rewriteTypes adds the type (i32) -> (i32) of the memory.grow function as the last type
+func (in *wasmInstrumenter) rewriteTypes(data []byte) ([]byte, error) {
+	if !in.needsGrow() {
+		return data, nil
+	}
+	r := &wasmReader{data: data}
+	n := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	w := &bytes.Buffer{}
+	writeU32(w, n+1)
+	w.Write(data[r.pos:])
+	w.Write([]byte{0x60, 0x01, valI32, 0x01, valI32})
+	in.growType = n
+	return w.Bytes(), nil
+}
+
+func (in *wasmInstrumenter) rewriteFunctions(data []byte) ([]byte, error) {
+	if !in.needsGrow() {
+		return data, nil
+	}
+	r := &wasmReader{data: data}
+	n := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	w := &bytes.Buffer{}
+	writeU32(w, n+1)
+	w.Write(data[r.pos:])
+	writeU32(w, in.growType)
+	return w.Bytes(), nil
+}
+
+// rewriteTables sets the maximum size of each table to its initial size, so table.grow always fails
+func (in *wasmInstrumenter) rewriteTables(data []byte) ([]byte, error) {
+	r := &wasmReader{data: data}
+	w := &bytes.Buffer{}
+	n := r.u32()
+	writeU32(w, n)
+	for ; n > 0 && r.err == nil; n-- {
+		w.WriteByte(r.byte())
+		_, initial, _ := r.limits()
+		w.WriteByte(0x01)
+		writeU32(w, initial)
+		writeU32(w, initial)
+	}
+	return w.Bytes(), r.err
+}
+
+func (in *wasmInstrumenter) rewriteGlobals(data []byte) ([]byte, error) {
+	r := &wasmReader{data: data}
+	n := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	added := uint32(4)
+	if in.info.meterFuel {
+		added++
+	}
+	w := &bytes.Buffer{}
+	writeU32(w, n+added)
+	w.Write(data[r.pos:])
+	writeGlobalI32(w, 0)
+	writeGlobalI32(w, int64(DefaultWasmLimits.MaxCallDepth))
+	writeGlobalI32(w, int64(DefaultWasmLimits.MaxMemoryPages))
+	writeGlobalI32(w, 0)
+	if in.info.meterFuel {
+		// the fuel for the start function, the host sets the budget before each call
+		w.Write([]byte{valI64, 0x01, opI64Const})
+		writeS64(w, unmeteredFuel)
+		w.WriteByte(opEnd)
+	}
+	return w.Bytes(), nil
+}
+
+func (in *wasmInstrumenter) rewriteExports(data []byte) ([]byte, error) {
+	r := &wasmReader{data: data}
+	n := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	exports := map[string]uint32{
+		exportCallDepth:      in.globalDepth,
+		exportCallDepthLimit: in.globalDepthLimit,
+		exportMemoryLimit:    in.globalMemLimit,
+		exportTrap:           in.globalTrap,
+	}
+	names := []string{exportCallDepth, exportCallDepthLimit, exportMemoryLimit, exportTrap}
+	if in.info.meterFuel {
+		exports[exportFuel] = in.globalFuel
+		names = append(names, exportFuel)
+	}
+	start := r.pos
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		name := string(r.bytes(r.u32()))
+		r.byte()
+		r.u32()
+		if strings.HasPrefix(name, exportPrefix) {
+			return nil, fmt.Errorf("export name %q is reserved", name)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	w := &bytes.Buffer{}
+	writeU32(w, n+uint32(len(names)))
+	w.Write(data[start:])
+	for _, name := range names {
+		writeU32(w, uint32(len(name)))
+		w.WriteString(name)
+		w.WriteByte(0x03)
+		writeU32(w, exports[name])
+	}
+	return w.Bytes(), nil
+}
+
+func (in *wasmInstrumenter) rewriteCode(data []byte) ([]byte, error) {
+	r := &wasmReader{data: data}
+	n := r.u32()
+	w := &bytes.Buffer{}
+	if in.needsGrow() {
+		writeU32(w, n+1)
+	} else {
+		writeU32(w, n)
+	}
+	for ; n > 0; n-- {
+		size := r.u32()
+		body := r.bytes(size)
+		if r.err != nil {
+			return nil, r.err
+		}
+		newBody, err := in.rewriteBody(body)
+		if err != nil {
+			return nil, err
+		}
+		writeU32(w, uint32(len(newBody)))
+		w.Write(newBody)
+	}
+	if in.needsGrow() {
+		grow := in.growBody()
+		writeU32(w, uint32(len(grow)))
+		w.Write(grow)
+	}
+	return w.Bytes(), nil
+}
+
+// growBody is the function which replaces memory.grow:
+// if memory.size + delta > limit { return -1 } else { return memory.grow(delta) }
+func (in *wasmInstrumenter) growBody() []byte {
+	w := &bytes.Buffer{}
+	w.WriteByte(0x00) // no locals
+	w.Write([]byte{opMemorySize, 0x00, opI64ExtendU, opLocalGet, 0x00, opI64ExtendU, opI64Add})
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalMemLimit)
+	w.Write([]byte{opI64ExtendU, opI64GtU})
+	w.Write([]byte{opIf, valI32, opI32Const, 0x7f, opElse, opLocalGet, 0x00, opMemoryGrow, 0x00, opEnd})
+	w.WriteByte(opEnd)
+	return w.Bytes()
+}
+
+type wasmInstr struct {
+	op   byte
+	code []byte
+}
+
+func (in *wasmInstrumenter) rewriteBody(body []byte) ([]byte, error) {
+	r := &wasmReader{data: body}
+	for n := r.u32(); n > 0 && r.err == nil; n-- {
+		r.u32()
+		r.byte()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	locals := body[:r.pos]
+	instrs, err := decodeInstrs(r)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &bytes.Buffer{}
+	w.Write(locals)
+	segmentStart := true
+	for i, instr := range instrs {
+		if segmentStart && in.info.meterFuel {
+			in.writeFuelCharge(w, segmentCost(instrs[i:]))
+		}
+		segmentStart = false
+		switch {
+		case instr.op == opCall && in.isInternalCall(instr.code):
+			in.writeDepthEnter(w)
+			w.Write(instr.code)
+			in.writeDepthExit(w)
+		case instr.op == opCallInd:
+			in.writeDepthEnter(w)
+			w.Write(instr.code)
+			in.writeDepthExit(w)
+		case instr.op == opMemoryGrow:
+			w.WriteByte(opCall)
+			writeU32(w, in.funcGrow)
+		case (instr.op == opGlobalGet || instr.op == opGlobalSet) && !in.isModuleGlobal(instr.code):
+			// the index is valid only because of the added globals, the code must not reach them
+			return nil, errors.New("global index out of range")
+		default:
+			w.Write(instr.code)
+		}
+		segmentStart = startsSegment(instr.op) && i < len(instrs)-1
+	}
+	return w.Bytes(), nil
+}
+
+// isInternalCall is true if the call is to a function of the module, not to a host function
+func (in *wasmInstrumenter) isInternalCall(code []byte) bool {
+	r := &wasmReader{data: code, pos: 1}
+	return r.u32() >= in.importedFuncs
+}
+
+// isModuleGlobal is true if global.get or global.set accesses a global of the original module
+func (in *wasmInstrumenter) isModuleGlobal(code []byte) bool {
+	r := &wasmReader{data: code, pos: 1}
+	return r.u32() < in.importedGlobs+in.definedGlobs
+}
+
+// startsSegment is true if the next instruction starts a new linear block of instructions
+func startsSegment(op byte) bool {
+	switch op {
+	case opBlock, opLoop, opIf, opElse, opEnd, opBrIf:
+		return true
+	}
+	return false
+}
+
+// segmentCost is the number of instructions up to and including the next instruction which ends the linear block
+func segmentCost(instrs []wasmInstr) int64 {
+	for i, instr := range instrs {
+		if startsSegment(instr.op) {
+			return int64(i + 1)
+		}
+	}
+	return int64(len(instrs))
+}
+
+// writeFuelCharge writes: fuel -= cost; if fuel < 0 { trap = trapFuel; unreachable }
+func (in *wasmInstrumenter) writeFuelCharge(w *bytes.Buffer, cost int64) {
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalFuel)
+	w.WriteByte(opI64Const)
+	writeS64(w, cost)
+	w.WriteByte(opI64Sub)
+	w.WriteByte(opGlobalSet)
+	writeU32(w, in.globalFuel)
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalFuel)
+	w.Write([]byte{opI64Const, 0x00, opI64LtS})
+	in.writeTrapIf(w, trapFuel)
+}
+
+// writeDepthEnter writes: depth++; if depth > limit { trap = trapCallDepth; unreachable }
+func (in *wasmInstrumenter) writeDepthEnter(w *bytes.Buffer) {
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalDepth)
+	w.Write([]byte{opI32Const, 0x01, opI32Add})
+	w.WriteByte(opGlobalSet)
+	writeU32(w, in.globalDepth)
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalDepth)
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalDepthLimit)
+	w.WriteByte(opI32GtU)
+	in.writeTrapIf(w, trapCallDepth)
+}
+
+// writeDepthExit writes: depth--
+func (in *wasmInstrumenter) writeDepthExit(w *bytes.Buffer) {
+	w.WriteByte(opGlobalGet)
+	writeU32(w, in.globalDepth)
+	w.Write([]byte{opI32Const, 0x01, opI32Sub})
+	w.WriteByte(opGlobalSet)
+	writeU32(w, in.globalDepth)
+}
+
+func (in *wasmInstrumenter) writeTrapIf(w *bytes.Buffer, trap byte) {
+	w.Write([]byte{opIf, 0x40, opI32Const, trap})
+	w.WriteByte(opGlobalSet)
+	writeU32(w, in.globalTrap)
+	w.Write([]byte{opUnreachable, opEnd})
+}
+
+// decodeInstrs splits the expression of the function body into instructions.
+// Only the instructions of Wasm 1.0 and of the widely supported extensions are known
+func decodeInstrs(r *wasmReader) ([]wasmInstr, error) {
+	instrs := make([]wasmInstr, 0, len(r.data)/2)
+	for !r.eof() {
+		start := r.pos
+		op := r.byte()
+		switch {
+		case op <= 0x01 || op == opElse || op == opEnd || op == 0x0f || op == 0x1a || op == 0x1b || op == 0xd1:
+			// no immediates
+		case op >= 0x45 && op <= 0xc4:
+			// numeric instructions have no immediates
+		case op == opBlock || op == opLoop || op == opIf:
+			r.blockType()
+		case op == 0x0c || op == opBrIf || op == opCall || op == 0xd2 || (op >= 0x20 && op <= 0x26):
+			r.u32()
+		case op == 0x0e:
+			for n := r.u32(); n > 0 && r.err == nil; n-- {
+				r.u32()
+			}
+			r.u32()
+		case op == opCallInd:
+			r.u32()
+			r.u32()
+		case op == 0x1c:
+			for n := r.u32(); n > 0 && r.err == nil; n-- {
+				r.byte()
+			}
+		case op >= 0x28 && op <= 0x3e:
+			r.u32()
+			r.u32()
+		case op == opMemorySize || op == opMemoryGrow || op == 0xd0:
+			r.byte()
+		case op == opI32Const || op == opI64Const:
+			r.s64()
+		case op == 0x43:
+			r.bytes(4)
+		case op == 0x44:
+			r.bytes(8)
+		case op == opPrefixFC:
+			decodePrefixFC(r)
+		default:
+			return nil, fmt.Errorf("unsupported Wasm instruction 0x%02x", op)
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+		instrs = append(instrs, wasmInstr{op: op, code: r.data[start:r.pos]})
+	}
+	return instrs, nil
+}
+
+func decodePrefixFC(r *wasmReader) {
+	switch sub := r.u32(); {
+	case sub <= 7:
+		// saturating truncation
+	case sub == 8:
+		r.u32()
+		r.byte()
+	case sub == 9 || sub == 13 || (sub >= 15 && sub <= 17):
+		r.u32()
+	case sub == 10:
+		r.byte()
+		r.byte()
+	case sub == 11:
+		r.byte()
+	case sub == 12 || sub == 14:
+		r.u32()
+		r.u32()
+	default:
+		r.err = fmt.Errorf("unsupported Wasm instruction 0xfc %d", sub)
+	}
+}
+
+type wasmReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *wasmReader) eof() bool {
+	return r.err != nil || r.pos >= len(r.data)
+}
+
+func (r *wasmReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos >= len(r.data) {
+		r.err = errors.New("unexpected end of Wasm data")
+		return 0
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *wasmReader) bytes(n uint32) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if uint64(r.pos)+uint64(n) > uint64(len(r.data)) {
+		r.err = errors.New("unexpected end of Wasm data")
+		return nil
+	}
+	ret := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return ret
+}
+
+func (r *wasmReader) u32() uint32 {
+	var ret uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b := r.byte()
+		ret |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return ret
+		}
+	}
+	if r.err == nil {
+		r.err = errors.New("invalid LEB128 number in Wasm data")
+	}
+	return 0
+}
+
+// s64 skips a signed LEB128 number of at most 64 bits
+func (r *wasmReader) s64() {
+	for i := 0; i < 10; i++ {
+		if r.byte()&0x80 == 0 {
+			return
+		}
+	}
+	if r.err == nil {
+		r.err = errors.New("invalid LEB128 number in Wasm data")
+	}
+}
+
+func (r *wasmReader) name() {
+	r.bytes(r.u32())
+}
+
+func (r *wasmReader) limits() (hasMax bool, initial, maximum uint32) {
+	flags := r.byte()
+	initial = r.u32()
+	if flags&0x01 != 0 {
+		return true, initial, r.u32()
+	}
+	return false, initial, 0
+}
+
+// blockType skips the type of a block: empty, a value type or a type index
+func (r *wasmReader) blockType() {
+	if r.pos < len(r.data) {
+		switch r.data[r.pos] {
+		case 0x40, valI32, valI64, 0x7d, 0x7c, 0x7b, 0x70, 0x6f:
+			r.pos++
+			return
+		}
+	}
+	r.s64()
+}
+
+func writeU32(w *bytes.Buffer, v uint32) {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			w.WriteByte(b)
+			return
+		}
+		w.WriteByte(b | 0x80)
+	}
+}
+
+func writeS64(w *bytes.Buffer, v int64) {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			w.WriteByte(b)
+			return
+		}
+		w.WriteByte(b | 0x80)
+	}
+}
+
+// writeGlobalI32 writes a mutable i32 global with the initial value
+func writeGlobalI32(w *bytes.Buffer, v int64) {
+	w.Write([]byte{valI32, 0x01, opI32Const})
+	writeS64(w, v)
+	w.WriteByte(opEnd)
+}
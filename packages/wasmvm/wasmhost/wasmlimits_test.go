@@ -0,0 +1,171 @@
+package wasmhost
+
+import (
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/stretchr/testify/require"
+)
+
+const limitsTestModule = `(module
+	(memory (export "memory") 1)
+	(table 2 funcref)
+	(func $rec (export "rec") (param i32) (result i32)
+		local.get 0
+		i32.eqz
+		if (result i32)
+			i32.const 0
+		else
+			local.get 0
+			i32.const 1
+			i32.sub
+			call $rec
+		end)
+	(func (export "grow") (param i32) (result i32)
+		local.get 0
+		memory.grow)
+	(func (export "tgrow") (result i32)
+		ref.null func
+		i32.const 1
+		table.grow 0)
+	(func (export "loop") (param i32)
+		loop
+			local.get 0
+			i32.const 1
+			i32.sub
+			local.tee 0
+			br_if 0
+		end))`
+
+type limitsTestInstance struct {
+	t        *testing.T
+	store    *wasmtime.Store
+	instance *wasmtime.Instance
+}
+
+func newLimitsTestInstance(t *testing.T, meterFuel bool) (*limitsTestInstance, *wasmModuleInfo) {
+	wasmData, err := wasmtime.Wat2Wasm(limitsTestModule)
+	require.NoError(t, err)
+	wasmData, info, err := instrumentWasm(wasmData, meterFuel)
+	require.NoError(t, err)
+
+	engine := wasmtime.NewEngine()
+	module, err := wasmtime.NewModule(engine, wasmData)
+	require.NoError(t, err)
+	store := wasmtime.NewStore(engine)
+	instance, err := wasmtime.NewInstance(store, module, nil)
+	require.NoError(t, err)
+	return &limitsTestInstance{t: t, store: store, instance: instance}, info
+}
+
+func (inst *limitsTestInstance) call(name string, args ...interface{}) (interface{}, error) {
+	ret, err := inst.instance.GetExport(inst.store, name).Func().Call(inst.store, args...)
+	return ret, trapError(err, inst.global(exportTrap).(int32))
+}
+
+func (inst *limitsTestInstance) global(name string) interface{} {
+	return inst.instance.GetExport(inst.store, name).Global().Get(inst.store).Get()
+}
+
+func (inst *limitsTestInstance) setGlobal(name string, value wasmtime.Val) {
+	require.NoError(inst.t, inst.instance.GetExport(inst.store, name).Global().Set(inst.store, value))
+}
+
+func TestInstrumentCallDepth(t *testing.T) {
+	inst, _ := newLimitsTestInstance(t, false)
+	inst.setGlobal(exportCallDepthLimit, wasmtime.ValI32(100))
+
+	ret, err := inst.call("rec", int32(100))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, ret)
+	require.EqualValues(t, 0, inst.global(exportCallDepth))
+
+	_, err = inst.call("rec", int32(101))
+	require.ErrorIs(t, err, ErrCallDepthExceeded)
+}
+
+func TestInstrumentMemory(t *testing.T) {
+	inst, info := newLimitsTestInstance(t, false)
+	require.EqualValues(t, 1, info.memoryPages)
+	inst.setGlobal(exportMemoryLimit, wasmtime.ValI32(4))
+
+	ret, err := inst.call("grow", int32(2))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, ret)
+	ret, err = inst.call("grow", int32(2))
+	require.NoError(t, err)
+	require.EqualValues(t, -1, ret)
+	ret, err = inst.call("grow", int32(1))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, ret)
+}
+
+func TestInstrumentTable(t *testing.T) {
+	inst, info := newLimitsTestInstance(t, false)
+	require.EqualValues(t, 2, info.tableSize)
+
+	ret, err := inst.call("tgrow")
+	require.NoError(t, err)
+	require.EqualValues(t, -1, ret)
+
+	require.NoError(t, info.checkLimits(iscp.WasmLimits{MaxMemoryPages: 1, MaxTableSize: 2}))
+	require.ErrorIs(t, info.checkLimits(iscp.WasmLimits{MaxMemoryPages: 0, MaxTableSize: 2}), ErrMemoryExceeded)
+	require.ErrorIs(t, info.checkLimits(iscp.WasmLimits{MaxMemoryPages: 1, MaxTableSize: 1}), ErrTableExceeded)
+}
+
+func TestInstrumentFuel(t *testing.T) {
+	inst, _ := newLimitsTestInstance(t, true)
+
+	// the same code always burns the same amount of fuel
+	burned := make([]int64, 2)
+	for i := range burned {
+		inst.setGlobal(exportFuel, wasmtime.ValI64(1000))
+		_, err := inst.call("loop", int32(10))
+		require.NoError(t, err)
+		burned[i] = 1000 - inst.global(exportFuel).(int64)
+	}
+	require.Positive(t, burned[0])
+	require.Equal(t, burned[0], burned[1])
+
+	inst.setGlobal(exportFuel, wasmtime.ValI64(1000))
+	_, err := inst.call("loop", int32(1000))
+	require.ErrorIs(t, err, ErrGasBudgetExceeded)
+}
+
+func TestInstrumentRejects(t *testing.T) {
+	modules := map[string]string{
+		// the index of the fuel global after the instrumentation, out of range in the original module
+		"set fuel": `(module
+			(global (mut i32) (i32.const 0))
+			(func (export "f")
+				i64.const 0x7fffffffffffffff
+				global.set 5))`,
+		"get depth": `(module
+			(func (export "f") (result i32)
+				global.get 0))`,
+		"imported table": `(module
+			(import "env" "table" (table 1 funcref)))`,
+		"reserved export": `(module
+			(global (mut i64) (i64.const 0))
+			(export "__wasp_fuel" (global 0)))`,
+	}
+	for name, wat := range modules {
+		t.Run(name, func(t *testing.T) {
+			wasmData, err := wasmtime.Wat2Wasm(wat)
+			require.NoError(t, err)
+			_, _, err = instrumentWasm(wasmData, true)
+			require.Error(t, err)
+		})
+	}
+
+	// the globals of the module itself are still accessible
+	wasmData, err := wasmtime.Wat2Wasm(`(module
+		(global (mut i32) (i32.const 0))
+		(func (export "f")
+			i32.const 1
+			global.set 0))`)
+	require.NoError(t, err)
+	_, _, err = instrumentWasm(wasmData, true)
+	require.NoError(t, err)
+}
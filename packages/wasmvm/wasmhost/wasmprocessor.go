@@ -9,9 +9,11 @@ import (
 
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 type WasmProcessor struct {
+	codeSize         int
 	contextLock      sync.Mutex
 	contexts         map[int32]*WasmContext
 	currentContextID int32
@@ -33,6 +35,7 @@ var GoWasmVM func() WasmVM
 // GetProcessor creates a new Wasm VM processor.
 func GetProcessor(wasmBytes []byte, log *logger.Logger) (iscp.VMProcessor, error) {
 	proc := &WasmProcessor{
+		codeSize:   len(wasmBytes),
 		contexts:   make(map[int32]*WasmContext),
 		funcTable:  NewWasmFuncTable(),
 		gasFactorX: 1,
@@ -60,12 +63,7 @@ func GetProcessor(wasmBytes []byte, log *logger.Logger) (iscp.VMProcessor, error
 	if err != nil {
 		return nil, err
 	}
-	// proc.vm.GasBudget(1_000_000)
-	// proc.vm.GasDisable(true)
 	err = proc.vm.RunFunction("on_load")
-	// proc.vm.GasDisable(false)
-	// burned := proc.vm.GasBurned()
-	// _ = burned
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +133,11 @@ func (proc *WasmProcessor) KillContext(id int32) {
 	delete(proc.contexts, id)
 }
 
+// MemorySize estimates the memory held by the processor: the Wasm code and the linear memory of its instance
+func (proc *WasmProcessor) MemorySize() uint64 {
+	return uint64(proc.codeSize) + uint64(len(proc.vm.UnsafeMemory()))
+}
+
 func (proc *WasmProcessor) RunScFunction(functionName string) (err error) {
 	index, ok := proc.mainProc().funcTable.funcToIndex[functionName]
 	if !ok {
@@ -143,9 +146,9 @@ func (proc *WasmProcessor) RunScFunction(functionName string) (err error) {
 	return proc.vm.RunScFunction(index)
 }
 
-//nolint:unused
+// gasFactor is the amount of Wasm fuel which is worth one unit of gas
 func (proc *WasmProcessor) gasFactor() uint64 {
-	return proc.mainProc().gasFactorX
+	return gas.WasmFuelPerGas * proc.mainProc().gasFactorX
 }
 
 func (proc *WasmProcessor) mainProc() *WasmProcessor {
@@ -5,76 +5,53 @@ package wasmhost
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
 )
 
 type WasmTimeVM struct {
 	WasmVMBase
-	engine     *wasmtime.Engine
-	instance   *wasmtime.Instance
-	interrupt  *wasmtime.InterruptHandle
-	linker     *wasmtime.Linker
-	memory     *wasmtime.Memory
-	module     *wasmtime.Module
-	store      *wasmtime.Store
-	lastBudget uint64
+	engine   *wasmtime.Engine
+	info     *wasmModuleInfo
+	instance *wasmtime.Instance
+	lastFuel int64
+	linker   *wasmtime.Linker
+	memory   *wasmtime.Memory
+	module   *wasmtime.Module
+	store    *wasmtime.Store
 }
 
 func NewWasmTimeVM() WasmVM {
 	vm := &WasmTimeVM{}
-	config := wasmtime.NewConfig()
-	config.SetInterruptable(true)
-	// config.SetConsumeFuel(true)
-	vm.engine = wasmtime.NewEngineWithConfig(config)
+	vm.engine = wasmtime.NewEngine()
 	return vm
 }
 
 // GasBudget sets the gas budget for the VM.
+// The native fuel metering of WasmTime is not used, so that the same code burns the same fuel
+// on every Wasm runtime: the instrumented code burns the fuel in the exported fuel global
 func (vm *WasmTimeVM) GasBudget(budget uint64) {
-	// save budget so we can later determine how much the VM burned
-	vm.lastBudget = budget
-
-	// new budget for VM, top up to desired budget
-	err := vm.store.AddFuel(budget)
-	if err != nil {
-		panic("GasBudget.set: " + err.Error())
-	}
-
-	// consume 0 fuel to determine remaining budget
-	remainingBudget, err := vm.store.ConsumeFuel(0)
-	if err != nil {
-		panic("GasBudget.determine: " + err.Error())
-	}
-
-	if remainingBudget > budget {
-		// burn excess budget
-		_, err = vm.store.ConsumeFuel(remainingBudget - budget)
-		if err != nil {
-			panic("GasBudget.burn: " + err.Error())
-		}
-	}
+	vm.setGlobal(exportFuel, int64(budget))
+	vm.lastFuel = int64(budget)
 }
 
 // GasBurned will return the gas burned since the last time GasBudget() was called
 func (vm *WasmTimeVM) GasBurned() uint64 {
-	// consume 0 fuel to determine remaining budget
-	remainingBudget, err := vm.store.ConsumeFuel(0)
-	if err != nil {
-		panic("GasBurned.determine: " + err.Error())
+	fuel := vm.getGlobal(exportFuel)
+	if fuel < 0 {
+		// the budget was exceeded
+		fuel = 0
 	}
-
-	burned := vm.lastBudget - remainingBudget
-	return burned
+	return uint64(vm.lastFuel - fuel)
 }
 
 func (vm *WasmTimeVM) Instantiate() (err error) {
-	// vm.GasBudget(1_000_000)
-	// vm.GasDisable(true)
 	vm.instance, err = vm.linker.Instantiate(vm.store, vm.module)
-	// vm.GasDisable(false)
-	// burned := vm.GasBurned()
-	// _ = burned
 	if err != nil {
 		return err
 	}
@@ -86,22 +63,16 @@ func (vm *WasmTimeVM) Instantiate() (err error) {
 	if vm.memory == nil {
 		return errors.New("not a memory type")
 	}
+	// initialization of the module is not charged to the request,
+	// the budget will be set before calling the smart contract function
+	vm.GasBudget(unmeteredFuel)
 	return nil
 }
 
-func (vm *WasmTimeVM) Interrupt() {
-	vm.interrupt.Interrupt()
-}
-
 func (vm *WasmTimeVM) LinkHost(proc *WasmProcessor) (err error) {
 	_ = vm.WasmVMBase.LinkHost(proc)
 
 	vm.store = wasmtime.NewStore(vm.engine)
-	vm.interrupt, err = vm.store.InterruptHandle()
-	if err != nil {
-		return err
-	}
-
 	vm.linker = wasmtime.NewLinker(vm.engine)
 
 	// new Wasm VM interface
@@ -129,15 +100,87 @@ func (vm *WasmTimeVM) LinkHost(proc *WasmProcessor) (err error) {
 }
 
 func (vm *WasmTimeVM) LoadWasm(wasmData []byte) (err error) {
-	vm.module, err = wasmtime.NewModule(vm.engine, wasmData)
+	wasmData, vm.info, err = instrumentWasm(wasmData, true)
+	if err != nil {
+		return err
+	}
+	vm.module, err = vm.loadModule(wasmData)
 	if err != nil {
 		return err
 	}
 	return vm.Instantiate()
 }
 
+// InitModuleCacheDir creates the directory of the compiled modules, accessible by the node only.
+// It fails if an existing directory can be written by other users, because the compiled modules
+// loaded from it are run as native code without any check
+func InitModuleCacheDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("module cache %s is not a directory", dir)
+	}
+	if info.Mode().Perm()&0o022 != 0 {
+		return fmt.Errorf("module cache directory %s can be written by other users (mode %v)", dir, info.Mode().Perm())
+	}
+	return nil
+}
+
+// loadModule takes the compiled module from the ModuleCacheDir, if it is there.
+// Otherwise it compiles the Wasm code and stores the result in the ModuleCacheDir.
+// The stored modules are deserialized without any validation, the ModuleCacheDir must be trusted
+func (vm *WasmTimeVM) loadModule(wasmData []byte) (*wasmtime.Module, error) {
+	if ModuleCacheDir == "" {
+		return wasmtime.NewModule(vm.engine, wasmData)
+	}
+	path := filepath.Join(ModuleCacheDir, hashing.HashData(wasmData).String()+".cwasm")
+	if _, err := os.Stat(path); err == nil {
+		module, err := wasmtime.NewModuleDeserializeFile(vm.engine, path)
+		if err == nil {
+			return module, nil
+		}
+		// the file is damaged or was compiled by another version of WasmTime, compile again
+	}
+	module, err := wasmtime.NewModule(vm.engine, wasmData)
+	if err != nil {
+		return nil, err
+	}
+	// the disk cache is only an optimization, the module is still usable if it can't be stored
+	_ = storeModule(path, module)
+	return module, nil
+}
+
+// storeModule writes the compiled module to a temporary file first, so that a partially written file is never loaded
+func storeModule(path string, module *wasmtime.Module) error {
+	data, err := module.Serialize()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "module-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 func (vm *WasmTimeVM) NewInstance() WasmVM {
-	return &WasmTimeVM{engine: vm.engine, module: vm.module}
+	return &WasmTimeVM{engine: vm.engine, info: vm.info, module: vm.module}
 }
 
 func (vm *WasmTimeVM) RunFunction(functionName string, args ...interface{}) error {
@@ -145,10 +188,11 @@ func (vm *WasmTimeVM) RunFunction(functionName string, args ...interface{}) erro
 	if export == nil {
 		return errors.New("unknown export function: '" + functionName + "'")
 	}
-	return vm.Run(func() (err error) {
+	err := vm.Run(func() (err error) {
 		_, err = export.Func().Call(vm.store, args...)
 		return err
 	})
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
 }
 
 func (vm *WasmTimeVM) RunScFunction(index int32) error {
@@ -157,10 +201,43 @@ func (vm *WasmTimeVM) RunScFunction(index int32) error {
 		return errors.New("unknown export function: 'on_call'")
 	}
 
-	return vm.Run(func() (err error) {
+	err := vm.Run(func() (err error) {
 		_, err = export.Func().Call(vm.store, index)
 		return err
 	})
+	return trapError(err, int32(vm.getGlobal(exportTrap)))
+}
+
+// SetLimits checks the module against the limits and sets the limits enforced by the instrumented code
+func (vm *WasmTimeVM) SetLimits(limits iscp.WasmLimits) error {
+	if err := vm.info.checkLimits(limits); err != nil {
+		return err
+	}
+	vm.setGlobal(exportCallDepthLimit, int64(limits.MaxCallDepth))
+	vm.setGlobal(exportMemoryLimit, int64(limits.MaxMemoryPages))
+	vm.setGlobal(exportCallDepth, 0)
+	vm.setGlobal(exportTrap, 0)
+	return nil
+}
+
+func (vm *WasmTimeVM) getGlobal(name string) int64 {
+	value := vm.instance.GetExport(vm.store, name).Global().Get(vm.store)
+	if value.Kind() == wasmtime.KindI64 {
+		return value.I64()
+	}
+	return int64(value.I32())
+}
+
+// setGlobal sets the global with the value converted to the type of the global
+func (vm *WasmTimeVM) setGlobal(name string, value int64) {
+	val := wasmtime.ValI32(int32(value))
+	if name == exportFuel {
+		val = wasmtime.ValI64(value)
+	}
+	err := vm.instance.GetExport(vm.store, name).Global().Set(vm.store, val)
+	if err != nil {
+		panic("setGlobal: " + err.Error())
+	}
 }
 
 func (vm *WasmTimeVM) UnsafeMemory() []byte {
@@ -0,0 +1,51 @@
+package wasmhost
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleCache(t *testing.T) {
+	wasmData, err := wasmtime.Wat2Wasm(`(module (memory (export "memory") 1))`)
+	require.NoError(t, err)
+
+	ModuleCacheDir = filepath.Join(t.TempDir(), "modules")
+	defer func() { ModuleCacheDir = "" }()
+
+	vm := NewWasmTimeVM().(*WasmTimeVM)
+	_, err = vm.loadModule(wasmData)
+	require.NoError(t, err)
+	files, err := os.ReadDir(ModuleCacheDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	path := filepath.Join(ModuleCacheDir, files[0].Name())
+
+	// the stored module is loaded by another engine
+	vm = NewWasmTimeVM().(*WasmTimeVM)
+	module, err := vm.loadModule(wasmData)
+	require.NoError(t, err)
+	require.Len(t, module.Type().Exports(), 1)
+
+	// a damaged file is replaced
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	_, err = vm.loadModule(wasmData)
+	require.NoError(t, err)
+	_, err = wasmtime.NewModuleDeserializeFile(vm.engine, path)
+	require.NoError(t, err)
+}
+
+func TestModuleCacheDirTrusted(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "modules")
+	require.NoError(t, InitModuleCacheDir(dir))
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	require.EqualValues(t, 0o700, info.Mode().Perm())
+
+	// a directory which other users can write to is refused
+	require.NoError(t, os.Chmod(dir, 0o777))
+	require.Error(t, InitModuleCacheDir(dir))
+}
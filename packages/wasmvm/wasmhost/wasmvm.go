@@ -8,13 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib"
 )
 
 const (
-	defaultTimeout   = 5 * time.Second
+	unmeteredFuel    = 1_000_000_000
 	FuncAbort        = "abort"
 	FuncFdWrite      = "fd_write"
 	FuncHostStateGet = "hostStateGet"
@@ -26,14 +27,16 @@ const (
 )
 
 var (
-	// DisableWasmTimeout can be used to disable the annoying timeout during debugging
-	DisableWasmTimeout = false
-
 	// HostTracing turns on debug tracing for ScHost calls
 	HostTracing = false
 
-	// WasmTimeout set this to non-zero for a one-time override of the defaultTimeout
-	WasmTimeout = 0 * time.Second
+	// ModuleCacheDir is the directory where WasmTimeVM stores the compiled Wasm modules,
+	// so that they are not compiled again when the node restarts. Empty disables the disk cache.
+	// The compiled modules are native code which is run without any check, so the directory must be
+	// trusted: only the node may write to it. See InitModuleCacheDir
+	ModuleCacheDir = ""
+
+	ErrGasBudgetExceeded = fmt.Errorf("%w in Wasm VM", gas.ErrNotEnoughGas)
 )
 
 type WasmVM interface {
@@ -41,12 +44,12 @@ type WasmVM interface {
 	GasBurned() uint64
 	GasDisable(disable bool)
 	Instantiate() error
-	Interrupt()
 	LinkHost(proc *WasmProcessor) error
 	LoadWasm(wasmData []byte) error
 	NewInstance() WasmVM
 	RunFunction(functionName string, args ...interface{}) error
 	RunScFunction(index int32) error
+	SetLimits(limits iscp.WasmLimits) error
 	UnsafeMemory() []byte
 	VMGetBytes(offset int32, size int32) []byte
 	VMGetSize() int32
@@ -54,11 +57,10 @@ type WasmVM interface {
 }
 
 type WasmVMBase struct {
-	cachedResult   []byte
-	gasDisabled    bool
-	panicErr       error
-	proc           *WasmProcessor
-	timeoutStarted bool
+	cachedResult []byte
+	gasDisabled  bool
+	panicErr     error
+	proc         *WasmProcessor
 }
 
 func (vm *WasmVMBase) GasBudget(budget uint64) {
@@ -203,9 +205,8 @@ func (vm *WasmVMBase) Instantiate() error {
 }
 
 func (vm *WasmVMBase) LinkHost(proc *WasmProcessor) error {
-	// trick vm into thinking it doesn't have to start the timeout timer
-	// useful when debugging to prevent timing out on breakpoints
-	vm.timeoutStarted = DisableWasmTimeout
+	// gas is metered only while running smart contract functions
+	vm.gasDisabled = true
 
 	vm.proc = proc
 	return nil
@@ -213,10 +214,10 @@ func (vm *WasmVMBase) LinkHost(proc *WasmProcessor) error {
 
 // reportGasBurned updates the sandbox gas budget with the amount burned by the VM
 func (vm *WasmVMBase) reportGasBurned() {
-	// if !vm.gasDisabled {
-	// 	ctx := vm.proc.GetContext(0)
-	// 	ctx.GasBurned(vm.proc.vm.GasBurned() / vm.proc.gasFactor())
-	// }
+	if !vm.gasDisabled {
+		ctx := vm.proc.GetContext(0)
+		ctx.GasBurned(vm.proc.vm.GasBurned() / vm.proc.gasFactor())
+	}
 }
 
 func (vm *WasmVMBase) Run(runner func() error) (err error) {
@@ -233,53 +234,22 @@ func (vm *WasmVMBase) Run(runner func() error) (err error) {
 		panic(r)
 	}()
 
-	if vm.timeoutStarted {
-		// no need to wrap nested calls in timeout code
-		err = runner()
-		if vm.panicErr != nil {
-			err = vm.panicErr
-			vm.panicErr = nil
-		}
-		if err != nil && strings.Contains(err.Error(), "all fuel consumed") {
-			err = errors.New("gas budget exceeded in Wasm VM")
-		}
-		return err
-	}
-
-	timeout := defaultTimeout
-	if WasmTimeout != 0 {
-		timeout = WasmTimeout
-		WasmTimeout = 0
-	}
-
-	done := make(chan bool, 2)
-
-	// start timeout handler
-	go func() {
-		select {
-		case <-done: // runner was done before timeout
-		case <-time.After(timeout):
-			// timeout: interrupt Wasm
-			vm.proc.vm.Interrupt()
-			// wait for runner to finish
-			<-done
-		}
-	}()
-
-	vm.timeoutStarted = true
+	// there is no timeout, the execution is only bounded by the gas budget and the limits of the chain,
+	// so that all nodes stop the Wasm code at exactly the same point
 	err = runner()
-	done <- true
-	vm.timeoutStarted = false
 	if vm.panicErr != nil {
 		err = vm.panicErr
 		vm.panicErr = nil
 	}
-	if err != nil && strings.Contains(err.Error(), "all fuel consumed") {
-		err = errors.New("gas budget exceeded in Wasm VM")
-	}
 	return err
 }
 
+// SetLimits sets the limits of the execution of the Wasm code
+func (vm *WasmVMBase) SetLimits(limits iscp.WasmLimits) error {
+	// no limits to enforce
+	return nil
+}
+
 func (vm *WasmVMBase) VMGetBytes(offset, size int32) []byte {
 	ptr := vm.proc.vm.UnsafeMemory()
 	bytes := make([]byte, size)
@@ -310,11 +280,11 @@ func (vm *WasmVMBase) VMSetBytes(offset, size int32, bytes []byte) int32 {
 func (vm *WasmVMBase) wrapUp() {
 	panicMsg := recover()
 	if panicMsg == nil {
-		// if !vm.gasDisabled {
-		// 	// update VM gas budget to reflect what sandbox burned
-		// 	ctx := vm.getContext(0)
-		// 	vm.proc.vm.GasBudget(ctx.GasBudget() * vm.proc.gasFactor())
-		// }
+		if !vm.gasDisabled {
+			// update VM gas budget to reflect what sandbox burned
+			ctx := vm.getContext(0)
+			vm.proc.vm.GasBudget(ctx.GasBudget() * vm.proc.gasFactor())
+		}
 		return
 	}
 
@@ -11,14 +11,49 @@ import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmclient"
 
 const (
 	ArgAgentID        = "a"
+	ArgAmount         = "m"
+	ArgColor          = "c"
+	ArgLimit          = "l"
+	ArgOffset         = "o"
+	ArgOwner          = "ow"
+	ArgSpender        = "sp"
+	ArgTarget         = "t"
 	ArgWithdrawAmount = "m"
 	ArgWithdrawColor  = "c"
 
 	ResAccountNonce = "n"
 	ResAgents       = "this"
+	ResAllowance    = "this"
 	ResBalances     = "this"
+	ResEntries      = "he"
+	ResLength       = "hl"
 )
 
+///////////////////////////// approve /////////////////////////////
+
+type ApproveFunc struct {
+	wasmclient.ClientFunc
+	args wasmclient.Arguments
+}
+
+func (f *ApproveFunc) Amount(v int64) {
+	f.args.Set(ArgAmount, f.args.FromInt64(v))
+}
+
+func (f *ApproveFunc) Color(v wasmclient.Color) {
+	f.args.Set(ArgColor, f.args.FromColor(v))
+}
+
+func (f *ApproveFunc) Spender(v wasmclient.AgentID) {
+	f.args.Set(ArgSpender, f.args.FromAgentID(v))
+}
+
+func (f *ApproveFunc) Post() wasmclient.Request {
+	f.args.Mandatory(ArgAmount)
+	f.args.Mandatory(ArgSpender)
+	return f.ClientFunc.Post(0xa0661268, &f.args)
+}
+
 ///////////////////////////// deposit /////////////////////////////
 
 type DepositFunc struct {
@@ -53,6 +88,55 @@ func (f *HarvestFunc) Post() wasmclient.Request {
 	return f.ClientFunc.Post(0x7b40efbd, &f.args)
 }
 
+///////////////////////////// revoke /////////////////////////////
+
+type RevokeFunc struct {
+	wasmclient.ClientFunc
+	args wasmclient.Arguments
+}
+
+func (f *RevokeFunc) Color(v wasmclient.Color) {
+	f.args.Set(ArgColor, f.args.FromColor(v))
+}
+
+func (f *RevokeFunc) Spender(v wasmclient.AgentID) {
+	f.args.Set(ArgSpender, f.args.FromAgentID(v))
+}
+
+func (f *RevokeFunc) Post() wasmclient.Request {
+	f.args.Mandatory(ArgSpender)
+	return f.ClientFunc.Post(0x128d530a, &f.args)
+}
+
+///////////////////////////// transferFrom /////////////////////////////
+
+type TransferFromFunc struct {
+	wasmclient.ClientFunc
+	args wasmclient.Arguments
+}
+
+func (f *TransferFromFunc) Amount(v int64) {
+	f.args.Set(ArgAmount, f.args.FromInt64(v))
+}
+
+func (f *TransferFromFunc) Color(v wasmclient.Color) {
+	f.args.Set(ArgColor, f.args.FromColor(v))
+}
+
+func (f *TransferFromFunc) Owner(v wasmclient.AgentID) {
+	f.args.Set(ArgOwner, f.args.FromAgentID(v))
+}
+
+func (f *TransferFromFunc) Target(v wasmclient.AgentID) {
+	f.args.Set(ArgTarget, f.args.FromAgentID(v))
+}
+
+func (f *TransferFromFunc) Post() wasmclient.Request {
+	f.args.Mandatory(ArgAmount)
+	f.args.Mandatory(ArgOwner)
+	return f.ClientFunc.Post(0xd5e0a602, &f.args)
+}
+
 ///////////////////////////// withdraw /////////////////////////////
 
 type WithdrawFunc struct {
@@ -115,6 +199,43 @@ func (r *BalanceResults) Balances() map[wasmclient.Color]int64 {
 	return res
 }
 
+///////////////////////////// getAccountHistory /////////////////////////////
+
+type GetAccountHistoryView struct {
+	wasmclient.ClientView
+	args wasmclient.Arguments
+}
+
+func (f *GetAccountHistoryView) AgentID(v wasmclient.AgentID) {
+	f.args.Set(ArgAgentID, f.args.FromAgentID(v))
+}
+
+func (f *GetAccountHistoryView) Limit(v uint32) {
+	f.args.Set(ArgLimit, f.args.FromUint32(v))
+}
+
+func (f *GetAccountHistoryView) Offset(v uint32) {
+	f.args.Set(ArgOffset, f.args.FromUint32(v))
+}
+
+func (f *GetAccountHistoryView) Call() GetAccountHistoryResults {
+	f.args.Mandatory(ArgAgentID)
+	f.ClientView.Call("getAccountHistory", &f.args)
+	return GetAccountHistoryResults{res: f.Results()}
+}
+
+type GetAccountHistoryResults struct {
+	res wasmclient.Results
+}
+
+func (r *GetAccountHistoryResults) Entries() []byte {
+	return r.res.ToBytes(r.res.Get(ResEntries))
+}
+
+func (r *GetAccountHistoryResults) Length() uint32 {
+	return r.res.ToUint32(r.res.Get(ResLength))
+}
+
 ///////////////////////////// getAccountNonce /////////////////////////////
 
 type GetAccountNonceView struct {
@@ -140,6 +261,40 @@ func (r *GetAccountNonceResults) AccountNonce() int64 {
 	return r.res.ToInt64(r.res.Get(ResAccountNonce))
 }
 
+///////////////////////////// getAllowance /////////////////////////////
+
+type GetAllowanceView struct {
+	wasmclient.ClientView
+	args wasmclient.Arguments
+}
+
+func (f *GetAllowanceView) Owner(v wasmclient.AgentID) {
+	f.args.Set(ArgOwner, f.args.FromAgentID(v))
+}
+
+func (f *GetAllowanceView) Spender(v wasmclient.AgentID) {
+	f.args.Set(ArgSpender, f.args.FromAgentID(v))
+}
+
+func (f *GetAllowanceView) Call() GetAllowanceResults {
+	f.args.Mandatory(ArgOwner)
+	f.args.Mandatory(ArgSpender)
+	f.ClientView.Call("getAllowance", &f.args)
+	return GetAllowanceResults{res: f.Results()}
+}
+
+type GetAllowanceResults struct {
+	res wasmclient.Results
+}
+
+func (r *GetAllowanceResults) Allowance() map[wasmclient.Color]int64 {
+	res := make(map[wasmclient.Color]int64)
+	r.res.ForEach(func(key []byte, val []byte) {
+		res[r.res.ToColor(key)] = r.res.ToInt64(val)
+	})
+	return res
+}
+
 ///////////////////////////// totalAssets /////////////////////////////
 
 type TotalAssetsView struct {
@@ -175,6 +330,10 @@ func NewCoreAccountsService(cl *wasmclient.ServiceClient, chainID string) (*Core
 	return s, err
 }
 
+func (s *CoreAccountsService) Approve() ApproveFunc {
+	return ApproveFunc{ClientFunc: s.AsClientFunc()}
+}
+
 func (s *CoreAccountsService) Deposit() DepositFunc {
 	return DepositFunc{ClientFunc: s.AsClientFunc()}
 }
@@ -183,6 +342,14 @@ func (s *CoreAccountsService) Harvest() HarvestFunc {
 	return HarvestFunc{ClientFunc: s.AsClientFunc()}
 }
 
+func (s *CoreAccountsService) Revoke() RevokeFunc {
+	return RevokeFunc{ClientFunc: s.AsClientFunc()}
+}
+
+func (s *CoreAccountsService) TransferFrom() TransferFromFunc {
+	return TransferFromFunc{ClientFunc: s.AsClientFunc()}
+}
+
 func (s *CoreAccountsService) Withdraw() WithdrawFunc {
 	return WithdrawFunc{ClientFunc: s.AsClientFunc()}
 }
@@ -195,10 +362,18 @@ func (s *CoreAccountsService) Balance() BalanceView {
 	return BalanceView{ClientView: s.AsClientView()}
 }
 
+func (s *CoreAccountsService) GetAccountHistory() GetAccountHistoryView {
+	return GetAccountHistoryView{ClientView: s.AsClientView()}
+}
+
 func (s *CoreAccountsService) GetAccountNonce() GetAccountNonceView {
 	return GetAccountNonceView{ClientView: s.AsClientView()}
 }
 
+func (s *CoreAccountsService) GetAllowance() GetAllowanceView {
+	return GetAllowanceView{ClientView: s.AsClientView()}
+}
+
 func (s *CoreAccountsService) TotalAssets() TotalAssetsView {
 	return TotalAssetsView{ClientView: s.AsClientView()}
 }
@@ -20,6 +20,8 @@ const (
 	ResBlockInfo              = "i"
 	ResEvent                  = "e"
 	ResGoverningAddress       = "g"
+	ResPrunedBytes            = "pb"
+	ResPrunedUntil            = "pu"
 	ResRequestID              = "u"
 	ResRequestIndex           = "r"
 	ResRequestProcessed       = "p"
@@ -185,6 +187,29 @@ func (r *GetLatestBlockInfoResults) BlockInfo() []byte {
 	return r.res.ToBytes(r.res.Get(ResBlockInfo))
 }
 
+///////////////////////////// getPruningInfo /////////////////////////////
+
+type GetPruningInfoView struct {
+	wasmclient.ClientView
+}
+
+func (f *GetPruningInfoView) Call() GetPruningInfoResults {
+	f.ClientView.Call("getPruningInfo", nil)
+	return GetPruningInfoResults{res: f.Results()}
+}
+
+type GetPruningInfoResults struct {
+	res wasmclient.Results
+}
+
+func (r *GetPruningInfoResults) PrunedBytes() uint64 {
+	return r.res.ToUint64(r.res.Get(ResPrunedBytes))
+}
+
+func (r *GetPruningInfoResults) PrunedUntil() uint32 {
+	return r.res.ToUint32(r.res.Get(ResPrunedUntil))
+}
+
 ///////////////////////////// getRequestIDsForBlock /////////////////////////////
 
 type GetRequestIDsForBlockView struct {
@@ -329,6 +354,10 @@ func (s *CoreBlockLogService) GetLatestBlockInfo() GetLatestBlockInfoView {
 	return GetLatestBlockInfoView{ClientView: s.AsClientView()}
 }
 
+func (s *CoreBlockLogService) GetPruningInfo() GetPruningInfoView {
+	return GetPruningInfoView{ClientView: s.AsClientView()}
+}
+
 func (s *CoreBlockLogService) GetRequestIDsForBlock() GetRequestIDsForBlockView {
 	return GetRequestIDsForBlockView{ClientView: s.AsClientView()}
 }
@@ -10,28 +10,40 @@ package coregovernanceclient
 import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmclient"
 
 const (
+	ArgAccountHistory         = "ah"
+	ArgBlockKeepAmount        = "bk"
 	ArgChainOwner             = "oi"
 	ArgFeeColor               = "fc"
-	ArgHname                  = "hn"
+	ArgGas                    = "g"
+	ArgGasPrice               = "gp"
 	ArgMaxBlobSize            = "bs"
 	ArgMaxEventSize           = "es"
 	ArgMaxEventsPerReq        = "ne"
-	ArgOwnerFee               = "of"
+	ArgMinFee                 = "mf"
 	ArgStateControllerAddress = "S"
-	ArgValidatorFee           = "vf"
+	ArgValidatorFeeShare      = "vs"
+	ArgWasmMaxCallDepth       = "wd"
+	ArgWasmMaxMemoryPages     = "wm"
+	ArgWasmMaxTableSize       = "wt"
 
+	ResAccountHistory                  = "ah"
 	ResAllowedStateControllerAddresses = "a"
+	ResBlockKeepAmount                 = "bk"
 	ResChainID                         = "c"
 	ResChainOwnerID                    = "o"
-	ResDefaultOwnerFee                 = "do"
-	ResDefaultValidatorFee             = "dv"
 	ResDescription                     = "d"
+	ResFee                             = "fe"
 	ResFeeColor                        = "f"
+	ResGas                             = "g"
+	ResGasPrice                        = "gp"
 	ResMaxBlobSize                     = "mb"
 	ResMaxEventSize                    = "me"
 	ResMaxEventsPerReq                 = "mr"
-	ResOwnerFee                        = "of"
-	ResValidatorFee                    = "vf"
+	ResMinFee                          = "mf"
+	ResValidatorFeeShare               = "vs"
+	ResWasmMaxCallDepth                = "wd"
+	ResWasmMaxMemoryPages              = "wm"
+	ResWasmMaxTableSize                = "wt"
 )
 
 ///////////////////////////// addAllowedStateControllerAddress /////////////////////////////
@@ -124,6 +136,14 @@ type SetChainInfoFunc struct {
 	args wasmclient.Arguments
 }
 
+func (f *SetChainInfoFunc) AccountHistory(v bool) {
+	f.args.Set(ArgAccountHistory, f.args.FromBool(v))
+}
+
+func (f *SetChainInfoFunc) BlockKeepAmount(v uint32) {
+	f.args.Set(ArgBlockKeepAmount, f.args.FromUint32(v))
+}
+
 func (f *SetChainInfoFunc) MaxBlobSize(v int32) {
 	f.args.Set(ArgMaxBlobSize, f.args.FromInt32(v))
 }
@@ -136,59 +156,79 @@ func (f *SetChainInfoFunc) MaxEventsPerReq(v int16) {
 	f.args.Set(ArgMaxEventsPerReq, f.args.FromInt16(v))
 }
 
-func (f *SetChainInfoFunc) OwnerFee(v int64) {
-	f.args.Set(ArgOwnerFee, f.args.FromInt64(v))
+func (f *SetChainInfoFunc) WasmMaxCallDepth(v uint32) {
+	f.args.Set(ArgWasmMaxCallDepth, f.args.FromUint32(v))
 }
 
-func (f *SetChainInfoFunc) ValidatorFee(v int64) {
-	f.args.Set(ArgValidatorFee, f.args.FromInt64(v))
+func (f *SetChainInfoFunc) WasmMaxMemoryPages(v uint32) {
+	f.args.Set(ArgWasmMaxMemoryPages, f.args.FromUint32(v))
+}
+
+func (f *SetChainInfoFunc) WasmMaxTableSize(v uint32) {
+	f.args.Set(ArgWasmMaxTableSize, f.args.FromUint32(v))
 }
 
 func (f *SetChainInfoFunc) Post() wasmclient.Request {
 	return f.ClientFunc.Post(0x702f5d2b, &f.args)
 }
 
-///////////////////////////// setContractFee /////////////////////////////
+///////////////////////////// setFeePolicy /////////////////////////////
 
-type SetContractFeeFunc struct {
+type SetFeePolicyFunc struct {
 	wasmclient.ClientFunc
 	args wasmclient.Arguments
 }
 
-func (f *SetContractFeeFunc) Hname(v wasmclient.Hname) {
-	f.args.Set(ArgHname, f.args.FromHname(v))
+func (f *SetFeePolicyFunc) FeeColor(v wasmclient.Color) {
+	f.args.Set(ArgFeeColor, f.args.FromColor(v))
 }
 
-func (f *SetContractFeeFunc) OwnerFee(v int64) {
-	f.args.Set(ArgOwnerFee, f.args.FromInt64(v))
+func (f *SetFeePolicyFunc) GasPrice(v int64) {
+	f.args.Set(ArgGasPrice, f.args.FromInt64(v))
 }
 
-func (f *SetContractFeeFunc) ValidatorFee(v int64) {
-	f.args.Set(ArgValidatorFee, f.args.FromInt64(v))
+func (f *SetFeePolicyFunc) MinFee(v int64) {
+	f.args.Set(ArgMinFee, f.args.FromInt64(v))
 }
 
-func (f *SetContractFeeFunc) Post() wasmclient.Request {
-	f.args.Mandatory(ArgHname)
-	return f.ClientFunc.Post(0x8421a42b, &f.args)
+func (f *SetFeePolicyFunc) ValidatorFeeShare(v int64) {
+	f.args.Set(ArgValidatorFeeShare, f.args.FromInt64(v))
 }
 
-///////////////////////////// setDefaultFee /////////////////////////////
+func (f *SetFeePolicyFunc) Post() wasmclient.Request {
+	return f.ClientFunc.Post(0x5b791c9f, &f.args)
+}
 
-type SetDefaultFeeFunc struct {
-	wasmclient.ClientFunc
+///////////////////////////// estimateFee /////////////////////////////
+
+type EstimateFeeView struct {
+	wasmclient.ClientView
 	args wasmclient.Arguments
 }
 
-func (f *SetDefaultFeeFunc) OwnerFee(v int64) {
-	f.args.Set(ArgOwnerFee, f.args.FromInt64(v))
+func (f *EstimateFeeView) Gas(v uint64) {
+	f.args.Set(ArgGas, f.args.FromUint64(v))
+}
+
+func (f *EstimateFeeView) Call() EstimateFeeResults {
+	f.ClientView.Call("estimateFee", &f.args)
+	return EstimateFeeResults{res: f.Results()}
+}
+
+type EstimateFeeResults struct {
+	res wasmclient.Results
+}
+
+func (r *EstimateFeeResults) Fee() uint64 {
+	return r.res.ToUint64(r.res.Get(ResFee))
 }
 
-func (f *SetDefaultFeeFunc) ValidatorFee(v int64) {
-	f.args.Set(ArgValidatorFee, f.args.FromInt64(v))
+func (r *EstimateFeeResults) FeeColor() wasmclient.Color {
+	return r.res.ToColor(r.res.Get(ResFeeColor))
 }
 
-func (f *SetDefaultFeeFunc) Post() wasmclient.Request {
-	return f.ClientFunc.Post(0x3310ecd0, &f.args)
+func (r *EstimateFeeResults) Gas() uint64 {
+	return r.res.ToUint64(r.res.Get(ResGas))
 }
 
 ///////////////////////////// getAllowedStateControllerAddresses /////////////////////////////
@@ -225,20 +265,20 @@ type GetChainInfoResults struct {
 	res wasmclient.Results
 }
 
-func (r *GetChainInfoResults) ChainID() wasmclient.ChainID {
-	return r.res.ToChainID(r.res.Get(ResChainID))
+func (r *GetChainInfoResults) AccountHistory() bool {
+	return r.res.ToBool(r.res.Get(ResAccountHistory))
 }
 
-func (r *GetChainInfoResults) ChainOwnerID() wasmclient.AgentID {
-	return r.res.ToAgentID(r.res.Get(ResChainOwnerID))
+func (r *GetChainInfoResults) BlockKeepAmount() uint32 {
+	return r.res.ToUint32(r.res.Get(ResBlockKeepAmount))
 }
 
-func (r *GetChainInfoResults) DefaultOwnerFee() int64 {
-	return r.res.ToInt64(r.res.Get(ResDefaultOwnerFee))
+func (r *GetChainInfoResults) ChainID() wasmclient.ChainID {
+	return r.res.ToChainID(r.res.Get(ResChainID))
 }
 
-func (r *GetChainInfoResults) DefaultValidatorFee() int64 {
-	return r.res.ToInt64(r.res.Get(ResDefaultValidatorFee))
+func (r *GetChainInfoResults) ChainOwnerID() wasmclient.AgentID {
+	return r.res.ToAgentID(r.res.Get(ResChainOwnerID))
 }
 
 func (r *GetChainInfoResults) Description() string {
@@ -261,37 +301,47 @@ func (r *GetChainInfoResults) MaxEventsPerReq() int16 {
 	return r.res.ToInt16(r.res.Get(ResMaxEventsPerReq))
 }
 
-///////////////////////////// getFeeInfo /////////////////////////////
+func (r *GetChainInfoResults) WasmMaxCallDepth() uint32 {
+	return r.res.ToUint32(r.res.Get(ResWasmMaxCallDepth))
+}
 
-type GetFeeInfoView struct {
-	wasmclient.ClientView
-	args wasmclient.Arguments
+func (r *GetChainInfoResults) WasmMaxMemoryPages() uint32 {
+	return r.res.ToUint32(r.res.Get(ResWasmMaxMemoryPages))
 }
 
-func (f *GetFeeInfoView) Hname(v wasmclient.Hname) {
-	f.args.Set(ArgHname, f.args.FromHname(v))
+func (r *GetChainInfoResults) WasmMaxTableSize() uint32 {
+	return r.res.ToUint32(r.res.Get(ResWasmMaxTableSize))
 }
 
-func (f *GetFeeInfoView) Call() GetFeeInfoResults {
-	f.args.Mandatory(ArgHname)
-	f.ClientView.Call("getFeeInfo", &f.args)
-	return GetFeeInfoResults{res: f.Results()}
+///////////////////////////// getFeePolicy /////////////////////////////
+
+type GetFeePolicyView struct {
+	wasmclient.ClientView
 }
 
-type GetFeeInfoResults struct {
+func (f *GetFeePolicyView) Call() GetFeePolicyResults {
+	f.ClientView.Call("getFeePolicy", nil)
+	return GetFeePolicyResults{res: f.Results()}
+}
+
+type GetFeePolicyResults struct {
 	res wasmclient.Results
 }
 
-func (r *GetFeeInfoResults) FeeColor() wasmclient.Color {
+func (r *GetFeePolicyResults) FeeColor() wasmclient.Color {
 	return r.res.ToColor(r.res.Get(ResFeeColor))
 }
 
-func (r *GetFeeInfoResults) OwnerFee() int64 {
-	return r.res.ToInt64(r.res.Get(ResOwnerFee))
+func (r *GetFeePolicyResults) GasPrice() uint64 {
+	return r.res.ToUint64(r.res.Get(ResGasPrice))
+}
+
+func (r *GetFeePolicyResults) MinFee() uint64 {
+	return r.res.ToUint64(r.res.Get(ResMinFee))
 }
 
-func (r *GetFeeInfoResults) ValidatorFee() int64 {
-	return r.res.ToInt64(r.res.Get(ResValidatorFee))
+func (r *GetFeePolicyResults) ValidatorFeeShare() uint16 {
+	return r.res.ToUint16(r.res.Get(ResValidatorFeeShare))
 }
 
 ///////////////////////////// getMaxBlobSize /////////////////////////////
@@ -349,12 +399,12 @@ func (s *CoreGovernanceService) SetChainInfo() SetChainInfoFunc {
 	return SetChainInfoFunc{ClientFunc: s.AsClientFunc()}
 }
 
-func (s *CoreGovernanceService) SetContractFee() SetContractFeeFunc {
-	return SetContractFeeFunc{ClientFunc: s.AsClientFunc()}
+func (s *CoreGovernanceService) SetFeePolicy() SetFeePolicyFunc {
+	return SetFeePolicyFunc{ClientFunc: s.AsClientFunc()}
 }
 
-func (s *CoreGovernanceService) SetDefaultFee() SetDefaultFeeFunc {
-	return SetDefaultFeeFunc{ClientFunc: s.AsClientFunc()}
+func (s *CoreGovernanceService) EstimateFee() EstimateFeeView {
+	return EstimateFeeView{ClientView: s.AsClientView()}
 }
 
 func (s *CoreGovernanceService) GetAllowedStateControllerAddresses() GetAllowedStateControllerAddressesView {
@@ -365,8 +415,8 @@ func (s *CoreGovernanceService) GetChainInfo() GetChainInfoView {
 	return GetChainInfoView{ClientView: s.AsClientView()}
 }
 
-func (s *CoreGovernanceService) GetFeeInfo() GetFeeInfoView {
-	return GetFeeInfoView{ClientView: s.AsClientView()}
+func (s *CoreGovernanceService) GetFeePolicy() GetFeePolicyView {
+	return GetFeePolicyView{ClientView: s.AsClientView()}
 }
 
 func (s *CoreGovernanceService) GetMaxBlobSize() GetMaxBlobSizeView {
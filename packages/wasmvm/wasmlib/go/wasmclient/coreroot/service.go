@@ -78,6 +78,31 @@ func (f *RevokeDeployPermissionFunc) Post() wasmclient.Request {
 	return f.ClientFunc.Post(0x850744f1, &f.args)
 }
 
+///////////////////////////// upgradeContract /////////////////////////////
+
+type UpgradeContractFunc struct {
+	wasmclient.ClientFunc
+	args wasmclient.Arguments
+}
+
+func (f *UpgradeContractFunc) Description(v string) {
+	f.args.Set(ArgDescription, f.args.FromString(v))
+}
+
+func (f *UpgradeContractFunc) Hname(v wasmclient.Hname) {
+	f.args.Set(ArgHname, f.args.FromHname(v))
+}
+
+func (f *UpgradeContractFunc) ProgramHash(v wasmclient.Hash) {
+	f.args.Set(ArgProgramHash, f.args.FromHash(v))
+}
+
+func (f *UpgradeContractFunc) Post() wasmclient.Request {
+	f.args.Mandatory(ArgHname)
+	f.args.Mandatory(ArgProgramHash)
+	return f.ClientFunc.Post(0x00d30d5c, &f.args)
+}
+
 ///////////////////////////// findContract /////////////////////////////
 
 type FindContractView struct {
@@ -154,6 +179,10 @@ func (s *CoreRootService) RevokeDeployPermission() RevokeDeployPermissionFunc {
 	return RevokeDeployPermissionFunc{ClientFunc: s.AsClientFunc()}
 }
 
+func (s *CoreRootService) UpgradeContract() UpgradeContractFunc {
+	return UpgradeContractFunc{ClientFunc: s.AsClientFunc()}
+}
+
 func (s *CoreRootService) FindContract() FindContractView {
 	return FindContractView{ClientView: s.AsClientView()}
 }
@@ -17,6 +17,13 @@ const (
 
 const (
 	ParamAgentID        = "a"
+	ParamAmount         = "m"
+	ParamColor          = "c"
+	ParamLimit          = "l"
+	ParamOffset         = "o"
+	ParamOwner          = "ow"
+	ParamSpender        = "sp"
+	ParamTarget         = "t"
 	ParamWithdrawAmount = "m"
 	ParamWithdrawColor  = "c"
 )
@@ -24,25 +31,38 @@ const (
 const (
 	ResultAccountNonce = "n"
 	ResultAgents       = "this"
+	ResultAllowance    = "this"
 	ResultBalances     = "this"
+	ResultEntries      = "he"
+	ResultLength       = "hl"
 )
 
 const (
-	FuncDeposit         = "deposit"
-	FuncHarvest         = "harvest"
-	FuncWithdraw        = "withdraw"
-	ViewAccounts        = "accounts"
-	ViewBalance         = "balance"
-	ViewGetAccountNonce = "getAccountNonce"
-	ViewTotalAssets     = "totalAssets"
+	FuncApprove           = "approve"
+	FuncDeposit           = "deposit"
+	FuncHarvest           = "harvest"
+	FuncRevoke            = "revoke"
+	FuncTransferFrom      = "transferFrom"
+	FuncWithdraw          = "withdraw"
+	ViewAccounts          = "accounts"
+	ViewBalance           = "balance"
+	ViewGetAccountHistory = "getAccountHistory"
+	ViewGetAccountNonce   = "getAccountNonce"
+	ViewGetAllowance      = "getAllowance"
+	ViewTotalAssets       = "totalAssets"
 )
 
 const (
-	HFuncDeposit         = wasmtypes.ScHname(0xbdc9102d)
-	HFuncHarvest         = wasmtypes.ScHname(0x7b40efbd)
-	HFuncWithdraw        = wasmtypes.ScHname(0x9dcc0f41)
-	HViewAccounts        = wasmtypes.ScHname(0x3c4b5e02)
-	HViewBalance         = wasmtypes.ScHname(0x84168cb4)
-	HViewGetAccountNonce = wasmtypes.ScHname(0x529d7df9)
-	HViewTotalAssets     = wasmtypes.ScHname(0xfab0f8d2)
+	HFuncApprove           = wasmtypes.ScHname(0xa0661268)
+	HFuncDeposit           = wasmtypes.ScHname(0xbdc9102d)
+	HFuncHarvest           = wasmtypes.ScHname(0x7b40efbd)
+	HFuncRevoke            = wasmtypes.ScHname(0x128d530a)
+	HFuncTransferFrom      = wasmtypes.ScHname(0xd5e0a602)
+	HFuncWithdraw          = wasmtypes.ScHname(0x9dcc0f41)
+	HViewAccounts          = wasmtypes.ScHname(0x3c4b5e02)
+	HViewBalance           = wasmtypes.ScHname(0x84168cb4)
+	HViewGetAccountHistory = wasmtypes.ScHname(0x289be591)
+	HViewGetAccountNonce   = wasmtypes.ScHname(0x529d7df9)
+	HViewGetAllowance      = wasmtypes.ScHname(0x329aa88f)
+	HViewTotalAssets       = wasmtypes.ScHname(0xfab0f8d2)
 )
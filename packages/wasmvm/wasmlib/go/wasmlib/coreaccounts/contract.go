@@ -9,6 +9,11 @@ package coreaccounts
 
 import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib"
 
+type ApproveCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableApproveParams
+}
+
 type DepositCall struct {
 	Func   *wasmlib.ScFunc
 	Params MutableDepositParams
@@ -19,6 +24,16 @@ type HarvestCall struct {
 	Params MutableHarvestParams
 }
 
+type RevokeCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableRevokeParams
+}
+
+type TransferFromCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableTransferFromParams
+}
+
 type WithdrawCall struct {
 	Func *wasmlib.ScFunc
 }
@@ -34,12 +49,24 @@ type BalanceCall struct {
 	Results ImmutableBalanceResults
 }
 
+type GetAccountHistoryCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetAccountHistoryParams
+	Results ImmutableGetAccountHistoryResults
+}
+
 type GetAccountNonceCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableGetAccountNonceParams
 	Results ImmutableGetAccountNonceResults
 }
 
+type GetAllowanceCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetAllowanceParams
+	Results ImmutableGetAllowanceResults
+}
+
 type TotalAssetsCall struct {
 	Func    *wasmlib.ScView
 	Results ImmutableTotalAssetsResults
@@ -49,6 +76,12 @@ type Funcs struct{}
 
 var ScFuncs Funcs
 
+func (sc Funcs) Approve(ctx wasmlib.ScFuncCallContext) *ApproveCall {
+	f := &ApproveCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncApprove)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 func (sc Funcs) Deposit(ctx wasmlib.ScFuncCallContext) *DepositCall {
 	f := &DepositCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncDeposit)}
 	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
@@ -61,6 +94,18 @@ func (sc Funcs) Harvest(ctx wasmlib.ScFuncCallContext) *HarvestCall {
 	return f
 }
 
+func (sc Funcs) Revoke(ctx wasmlib.ScFuncCallContext) *RevokeCall {
+	f := &RevokeCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncRevoke)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
+func (sc Funcs) TransferFrom(ctx wasmlib.ScFuncCallContext) *TransferFromCall {
+	f := &TransferFromCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncTransferFrom)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 func (sc Funcs) Withdraw(ctx wasmlib.ScFuncCallContext) *WithdrawCall {
 	return &WithdrawCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncWithdraw)}
 }
@@ -78,6 +123,13 @@ func (sc Funcs) Balance(ctx wasmlib.ScViewCallContext) *BalanceCall {
 	return f
 }
 
+func (sc Funcs) GetAccountHistory(ctx wasmlib.ScViewCallContext) *GetAccountHistoryCall {
+	f := &GetAccountHistoryCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetAccountHistory)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
+	return f
+}
+
 func (sc Funcs) GetAccountNonce(ctx wasmlib.ScViewCallContext) *GetAccountNonceCall {
 	f := &GetAccountNonceCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetAccountNonce)}
 	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
@@ -85,6 +137,13 @@ func (sc Funcs) GetAccountNonce(ctx wasmlib.ScViewCallContext) *GetAccountNonceC
 	return f
 }
 
+func (sc Funcs) GetAllowance(ctx wasmlib.ScViewCallContext) *GetAllowanceCall {
+	f := &GetAllowanceCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetAllowance)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
+	return f
+}
+
 func (sc Funcs) TotalAssets(ctx wasmlib.ScViewCallContext) *TotalAssetsCall {
 	f := &TotalAssetsCall{Func: wasmlib.NewScView(ctx, HScName, HViewTotalAssets)}
 	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
@@ -93,24 +152,34 @@ func (sc Funcs) TotalAssets(ctx wasmlib.ScViewCallContext) *TotalAssetsCall {
 
 var exportMap = wasmlib.ScExportMap{
 	Names: []string{
+		FuncApprove,
 		FuncDeposit,
 		FuncHarvest,
+		FuncRevoke,
+		FuncTransferFrom,
 		FuncWithdraw,
 		ViewAccounts,
 		ViewBalance,
+		ViewGetAccountHistory,
 		ViewGetAccountNonce,
+		ViewGetAllowance,
 		ViewTotalAssets,
 	},
 	Funcs: []wasmlib.ScFuncContextFunction{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
+		wasmlib.FuncError,
+		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
@@ -9,6 +9,38 @@ package coreaccounts
 
 import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
 
+type ImmutableApproveParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableApproveParams) Amount() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamAmount))
+}
+
+func (s ImmutableApproveParams) Color() wasmtypes.ScImmutableColor {
+	return wasmtypes.NewScImmutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s ImmutableApproveParams) Spender() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamSpender))
+}
+
+type MutableApproveParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableApproveParams) Amount() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamAmount))
+}
+
+func (s MutableApproveParams) Color() wasmtypes.ScMutableColor {
+	return wasmtypes.NewScMutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s MutableApproveParams) Spender() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamSpender))
+}
+
 type ImmutableDepositParams struct {
 	proxy wasmtypes.Proxy
 }
@@ -49,6 +81,70 @@ func (s MutableHarvestParams) WithdrawColor() wasmtypes.ScMutableColor {
 	return wasmtypes.NewScMutableColor(s.proxy.Root(ParamWithdrawColor))
 }
 
+type ImmutableRevokeParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableRevokeParams) Color() wasmtypes.ScImmutableColor {
+	return wasmtypes.NewScImmutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s ImmutableRevokeParams) Spender() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamSpender))
+}
+
+type MutableRevokeParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableRevokeParams) Color() wasmtypes.ScMutableColor {
+	return wasmtypes.NewScMutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s MutableRevokeParams) Spender() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamSpender))
+}
+
+type ImmutableTransferFromParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableTransferFromParams) Amount() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamAmount))
+}
+
+func (s ImmutableTransferFromParams) Color() wasmtypes.ScImmutableColor {
+	return wasmtypes.NewScImmutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s ImmutableTransferFromParams) Owner() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamOwner))
+}
+
+func (s ImmutableTransferFromParams) Target() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamTarget))
+}
+
+type MutableTransferFromParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableTransferFromParams) Amount() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamAmount))
+}
+
+func (s MutableTransferFromParams) Color() wasmtypes.ScMutableColor {
+	return wasmtypes.NewScMutableColor(s.proxy.Root(ParamColor))
+}
+
+func (s MutableTransferFromParams) Owner() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamOwner))
+}
+
+func (s MutableTransferFromParams) Target() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamTarget))
+}
+
 type ImmutableBalanceParams struct {
 	proxy wasmtypes.Proxy
 }
@@ -65,6 +161,38 @@ func (s MutableBalanceParams) AgentID() wasmtypes.ScMutableAgentID {
 	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamAgentID))
 }
 
+type ImmutableGetAccountHistoryParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetAccountHistoryParams) AgentID() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamAgentID))
+}
+
+func (s ImmutableGetAccountHistoryParams) Limit() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamLimit))
+}
+
+func (s ImmutableGetAccountHistoryParams) Offset() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamOffset))
+}
+
+type MutableGetAccountHistoryParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetAccountHistoryParams) AgentID() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamAgentID))
+}
+
+func (s MutableGetAccountHistoryParams) Limit() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamLimit))
+}
+
+func (s MutableGetAccountHistoryParams) Offset() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamOffset))
+}
+
 type ImmutableGetAccountNonceParams struct {
 	proxy wasmtypes.Proxy
 }
@@ -80,3 +208,27 @@ type MutableGetAccountNonceParams struct {
 func (s MutableGetAccountNonceParams) AgentID() wasmtypes.ScMutableAgentID {
 	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamAgentID))
 }
+
+type ImmutableGetAllowanceParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetAllowanceParams) Owner() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamOwner))
+}
+
+func (s ImmutableGetAllowanceParams) Spender() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ParamSpender))
+}
+
+type MutableGetAllowanceParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetAllowanceParams) Owner() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamOwner))
+}
+
+func (s MutableGetAllowanceParams) Spender() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamSpender))
+}
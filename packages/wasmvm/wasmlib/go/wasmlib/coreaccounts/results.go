@@ -85,6 +85,62 @@ func (s MutableBalanceResults) Balances() MapColorToMutableInt64 {
 	return MapColorToMutableInt64{proxy: s.proxy}
 }
 
+type ArrayOfImmutableBytes struct {
+	proxy wasmtypes.Proxy
+}
+
+func (a ArrayOfImmutableBytes) Length() uint32 {
+	return a.proxy.Length()
+}
+
+func (a ArrayOfImmutableBytes) GetBytes(index uint32) wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(a.proxy.Index(index))
+}
+
+type ImmutableGetAccountHistoryResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetAccountHistoryResults) Entries() ArrayOfImmutableBytes {
+	return ArrayOfImmutableBytes{proxy: s.proxy.Root(ResultEntries)}
+}
+
+func (s ImmutableGetAccountHistoryResults) Length() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultLength))
+}
+
+type ArrayOfMutableBytes struct {
+	proxy wasmtypes.Proxy
+}
+
+func (a ArrayOfMutableBytes) AppendBytes() wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(a.proxy.Append())
+}
+
+func (a ArrayOfMutableBytes) Clear() {
+	a.proxy.ClearArray()
+}
+
+func (a ArrayOfMutableBytes) Length() uint32 {
+	return a.proxy.Length()
+}
+
+func (a ArrayOfMutableBytes) GetBytes(index uint32) wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(a.proxy.Index(index))
+}
+
+type MutableGetAccountHistoryResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetAccountHistoryResults) Entries() ArrayOfMutableBytes {
+	return ArrayOfMutableBytes{proxy: s.proxy.Root(ResultEntries)}
+}
+
+func (s MutableGetAccountHistoryResults) Length() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultLength))
+}
+
 type ImmutableGetAccountNonceResults struct {
 	proxy wasmtypes.Proxy
 }
@@ -101,6 +157,24 @@ func (s MutableGetAccountNonceResults) AccountNonce() wasmtypes.ScMutableInt64 {
 	return wasmtypes.NewScMutableInt64(s.proxy.Root(ResultAccountNonce))
 }
 
+type ImmutableGetAllowanceResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetAllowanceResults) Allowance() MapColorToImmutableInt64 {
+	//nolint:gosimple
+	return MapColorToImmutableInt64{proxy: s.proxy}
+}
+
+type MutableGetAllowanceResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetAllowanceResults) Allowance() MapColorToMutableInt64 {
+	//nolint:gosimple
+	return MapColorToMutableInt64{proxy: s.proxy}
+}
+
 type ImmutableTotalAssetsResults struct {
 	proxy wasmtypes.Proxy
 }
@@ -28,6 +28,8 @@ const (
 	ResultBlockInfo              = "i"
 	ResultEvent                  = "e"
 	ResultGoverningAddress       = "g"
+	ResultPrunedBytes            = "pb"
+	ResultPrunedUntil            = "pu"
 	ResultRequestID              = "u"
 	ResultRequestIndex           = "r"
 	ResultRequestProcessed       = "p"
@@ -42,6 +44,7 @@ const (
 	ViewGetEventsForContract       = "getEventsForContract"
 	ViewGetEventsForRequest        = "getEventsForRequest"
 	ViewGetLatestBlockInfo         = "getLatestBlockInfo"
+	ViewGetPruningInfo             = "getPruningInfo"
 	ViewGetRequestIDsForBlock      = "getRequestIDsForBlock"
 	ViewGetRequestReceipt          = "getRequestReceipt"
 	ViewGetRequestReceiptsForBlock = "getRequestReceiptsForBlock"
@@ -55,6 +58,7 @@ const (
 	HViewGetEventsForContract       = wasmtypes.ScHname(0x682a1922)
 	HViewGetEventsForRequest        = wasmtypes.ScHname(0x4f8d68e4)
 	HViewGetLatestBlockInfo         = wasmtypes.ScHname(0x084a1760)
+	HViewGetPruningInfo             = wasmtypes.ScHname(0x1ac4ecd4)
 	HViewGetRequestIDsForBlock      = wasmtypes.ScHname(0x5a20327a)
 	HViewGetRequestReceipt          = wasmtypes.ScHname(0xb7f9534f)
 	HViewGetRequestReceiptsForBlock = wasmtypes.ScHname(0x77e3beef)
@@ -43,6 +43,11 @@ type GetLatestBlockInfoCall struct {
 	Results ImmutableGetLatestBlockInfoResults
 }
 
+type GetPruningInfoCall struct {
+	Func    *wasmlib.ScView
+	Results ImmutableGetPruningInfoResults
+}
+
 type GetRequestIDsForBlockCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableGetRequestIDsForBlockParams
@@ -111,6 +116,12 @@ func (sc Funcs) GetLatestBlockInfo(ctx wasmlib.ScViewCallContext) *GetLatestBloc
 	return f
 }
 
+func (sc Funcs) GetPruningInfo(ctx wasmlib.ScViewCallContext) *GetPruningInfoCall {
+	f := &GetPruningInfoCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetPruningInfo)}
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
+	return f
+}
+
 func (sc Funcs) GetRequestIDsForBlock(ctx wasmlib.ScViewCallContext) *GetRequestIDsForBlockCall {
 	f := &GetRequestIDsForBlockCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetRequestIDsForBlock)}
 	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
@@ -147,6 +158,7 @@ var exportMap = wasmlib.ScExportMap{
 		ViewGetEventsForContract,
 		ViewGetEventsForRequest,
 		ViewGetLatestBlockInfo,
+		ViewGetPruningInfo,
 		ViewGetRequestIDsForBlock,
 		ViewGetRequestReceipt,
 		ViewGetRequestReceiptsForBlock,
@@ -164,6 +176,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
@@ -161,6 +161,30 @@ func (s MutableGetLatestBlockInfoResults) BlockInfo() wasmtypes.ScMutableBytes {
 	return wasmtypes.NewScMutableBytes(s.proxy.Root(ResultBlockInfo))
 }
 
+type ImmutableGetPruningInfoResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetPruningInfoResults) PrunedBytes() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ResultPrunedBytes))
+}
+
+func (s ImmutableGetPruningInfoResults) PrunedUntil() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultPrunedUntil))
+}
+
+type MutableGetPruningInfoResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetPruningInfoResults) PrunedBytes() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ResultPrunedBytes))
+}
+
+func (s MutableGetPruningInfoResults) PrunedUntil() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultPrunedUntil))
+}
+
 type ArrayOfImmutableRequestID struct {
 	proxy wasmtypes.Proxy
 }
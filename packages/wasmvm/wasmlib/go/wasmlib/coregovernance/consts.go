@@ -16,30 +16,42 @@ const (
 )
 
 const (
+	ParamAccountHistory         = "ah"
+	ParamBlockKeepAmount        = "bk"
 	ParamChainOwner             = "oi"
 	ParamFeeColor               = "fc"
-	ParamHname                  = "hn"
+	ParamGas                    = "g"
+	ParamGasPrice               = "gp"
 	ParamMaxBlobSize            = "bs"
 	ParamMaxEventSize           = "es"
 	ParamMaxEventsPerReq        = "ne"
-	ParamOwnerFee               = "of"
+	ParamMinFee                 = "mf"
 	ParamStateControllerAddress = "S"
-	ParamValidatorFee           = "vf"
+	ParamValidatorFeeShare      = "vs"
+	ParamWasmMaxCallDepth       = "wd"
+	ParamWasmMaxMemoryPages     = "wm"
+	ParamWasmMaxTableSize       = "wt"
 )
 
 const (
+	ResultAccountHistory                  = "ah"
 	ResultAllowedStateControllerAddresses = "a"
+	ResultBlockKeepAmount                 = "bk"
 	ResultChainID                         = "c"
 	ResultChainOwnerID                    = "o"
-	ResultDefaultOwnerFee                 = "do"
-	ResultDefaultValidatorFee             = "dv"
 	ResultDescription                     = "d"
+	ResultFee                             = "fe"
 	ResultFeeColor                        = "f"
+	ResultGas                             = "g"
+	ResultGasPrice                        = "gp"
 	ResultMaxBlobSize                     = "mb"
 	ResultMaxEventSize                    = "me"
 	ResultMaxEventsPerReq                 = "mr"
-	ResultOwnerFee                        = "of"
-	ResultValidatorFee                    = "vf"
+	ResultMinFee                          = "mf"
+	ResultValidatorFeeShare               = "vs"
+	ResultWasmMaxCallDepth                = "wd"
+	ResultWasmMaxMemoryPages              = "wm"
+	ResultWasmMaxTableSize                = "wt"
 )
 
 const (
@@ -49,11 +61,11 @@ const (
 	FuncRemoveAllowedStateControllerAddress = "removeAllowedStateControllerAddress"
 	FuncRotateStateController               = "rotateStateController"
 	FuncSetChainInfo                        = "setChainInfo"
-	FuncSetContractFee                      = "setContractFee"
-	FuncSetDefaultFee                       = "setDefaultFee"
+	FuncSetFeePolicy                        = "setFeePolicy"
+	ViewEstimateFee                         = "estimateFee"
 	ViewGetAllowedStateControllerAddresses  = "getAllowedStateControllerAddresses"
 	ViewGetChainInfo                        = "getChainInfo"
-	ViewGetFeeInfo                          = "getFeeInfo"
+	ViewGetFeePolicy                        = "getFeePolicy"
 	ViewGetMaxBlobSize                      = "getMaxBlobSize"
 )
 
@@ -64,10 +76,10 @@ const (
 	HFuncRemoveAllowedStateControllerAddress = wasmtypes.ScHname(0x31f69447)
 	HFuncRotateStateController               = wasmtypes.ScHname(0x244d1038)
 	HFuncSetChainInfo                        = wasmtypes.ScHname(0x702f5d2b)
-	HFuncSetContractFee                      = wasmtypes.ScHname(0x8421a42b)
-	HFuncSetDefaultFee                       = wasmtypes.ScHname(0x3310ecd0)
+	HFuncSetFeePolicy                        = wasmtypes.ScHname(0x5b791c9f)
+	HViewEstimateFee                         = wasmtypes.ScHname(0xe1b674ad)
 	HViewGetAllowedStateControllerAddresses  = wasmtypes.ScHname(0xf3505183)
 	HViewGetChainInfo                        = wasmtypes.ScHname(0x434477e2)
-	HViewGetFeeInfo                          = wasmtypes.ScHname(0x9fe54b48)
+	HViewGetFeePolicy                        = wasmtypes.ScHname(0xf8c89790)
 	HViewGetMaxBlobSize                      = wasmtypes.ScHname(0xe1db3d28)
 )
@@ -38,14 +38,15 @@ type SetChainInfoCall struct {
 	Params MutableSetChainInfoParams
 }
 
-type SetContractFeeCall struct {
+type SetFeePolicyCall struct {
 	Func   *wasmlib.ScFunc
-	Params MutableSetContractFeeParams
+	Params MutableSetFeePolicyParams
 }
 
-type SetDefaultFeeCall struct {
-	Func   *wasmlib.ScFunc
-	Params MutableSetDefaultFeeParams
+type EstimateFeeCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableEstimateFeeParams
+	Results ImmutableEstimateFeeResults
 }
 
 type GetAllowedStateControllerAddressesCall struct {
@@ -58,10 +59,9 @@ type GetChainInfoCall struct {
 	Results ImmutableGetChainInfoResults
 }
 
-type GetFeeInfoCall struct {
+type GetFeePolicyCall struct {
 	Func    *wasmlib.ScView
-	Params  MutableGetFeeInfoParams
-	Results ImmutableGetFeeInfoResults
+	Results ImmutableGetFeePolicyResults
 }
 
 type GetMaxBlobSizeCall struct {
@@ -107,15 +107,16 @@ func (sc Funcs) SetChainInfo(ctx wasmlib.ScFuncCallContext) *SetChainInfoCall {
 	return f
 }
 
-func (sc Funcs) SetContractFee(ctx wasmlib.ScFuncCallContext) *SetContractFeeCall {
-	f := &SetContractFeeCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetContractFee)}
+func (sc Funcs) SetFeePolicy(ctx wasmlib.ScFuncCallContext) *SetFeePolicyCall {
+	f := &SetFeePolicyCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetFeePolicy)}
 	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
 	return f
 }
 
-func (sc Funcs) SetDefaultFee(ctx wasmlib.ScFuncCallContext) *SetDefaultFeeCall {
-	f := &SetDefaultFeeCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetDefaultFee)}
-	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+func (sc Funcs) EstimateFee(ctx wasmlib.ScViewCallContext) *EstimateFeeCall {
+	f := &EstimateFeeCall{Func: wasmlib.NewScView(ctx, HScName, HViewEstimateFee)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
 	return f
 }
 
@@ -131,9 +132,8 @@ func (sc Funcs) GetChainInfo(ctx wasmlib.ScViewCallContext) *GetChainInfoCall {
 	return f
 }
 
-func (sc Funcs) GetFeeInfo(ctx wasmlib.ScViewCallContext) *GetFeeInfoCall {
-	f := &GetFeeInfoCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetFeeInfo)}
-	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+func (sc Funcs) GetFeePolicy(ctx wasmlib.ScViewCallContext) *GetFeePolicyCall {
+	f := &GetFeePolicyCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetFeePolicy)}
 	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
 	return f
 }
@@ -152,11 +152,11 @@ var exportMap = wasmlib.ScExportMap{
 		FuncRemoveAllowedStateControllerAddress,
 		FuncRotateStateController,
 		FuncSetChainInfo,
-		FuncSetContractFee,
-		FuncSetDefaultFee,
+		FuncSetFeePolicy,
+		ViewEstimateFee,
 		ViewGetAllowedStateControllerAddresses,
 		ViewGetChainInfo,
-		ViewGetFeeInfo,
+		ViewGetFeePolicy,
 		ViewGetMaxBlobSize,
 	},
 	Funcs: []wasmlib.ScFuncContextFunction{
@@ -167,13 +167,13 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
-		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
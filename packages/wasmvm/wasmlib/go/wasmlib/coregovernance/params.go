@@ -93,6 +93,14 @@ type ImmutableSetChainInfoParams struct {
 	proxy wasmtypes.Proxy
 }
 
+func (s ImmutableSetChainInfoParams) AccountHistory() wasmtypes.ScImmutableBool {
+	return wasmtypes.NewScImmutableBool(s.proxy.Root(ParamAccountHistory))
+}
+
+func (s ImmutableSetChainInfoParams) BlockKeepAmount() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamBlockKeepAmount))
+}
+
 func (s ImmutableSetChainInfoParams) MaxBlobSize() wasmtypes.ScImmutableInt32 {
 	return wasmtypes.NewScImmutableInt32(s.proxy.Root(ParamMaxBlobSize))
 }
@@ -105,18 +113,30 @@ func (s ImmutableSetChainInfoParams) MaxEventsPerReq() wasmtypes.ScImmutableInt1
 	return wasmtypes.NewScImmutableInt16(s.proxy.Root(ParamMaxEventsPerReq))
 }
 
-func (s ImmutableSetChainInfoParams) OwnerFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamOwnerFee))
+func (s ImmutableSetChainInfoParams) WasmMaxCallDepth() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamWasmMaxCallDepth))
+}
+
+func (s ImmutableSetChainInfoParams) WasmMaxMemoryPages() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamWasmMaxMemoryPages))
 }
 
-func (s ImmutableSetChainInfoParams) ValidatorFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamValidatorFee))
+func (s ImmutableSetChainInfoParams) WasmMaxTableSize() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamWasmMaxTableSize))
 }
 
 type MutableSetChainInfoParams struct {
 	proxy wasmtypes.Proxy
 }
 
+func (s MutableSetChainInfoParams) AccountHistory() wasmtypes.ScMutableBool {
+	return wasmtypes.NewScMutableBool(s.proxy.Root(ParamAccountHistory))
+}
+
+func (s MutableSetChainInfoParams) BlockKeepAmount() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamBlockKeepAmount))
+}
+
 func (s MutableSetChainInfoParams) MaxBlobSize() wasmtypes.ScMutableInt32 {
 	return wasmtypes.NewScMutableInt32(s.proxy.Root(ParamMaxBlobSize))
 }
@@ -129,82 +149,70 @@ func (s MutableSetChainInfoParams) MaxEventsPerReq() wasmtypes.ScMutableInt16 {
 	return wasmtypes.NewScMutableInt16(s.proxy.Root(ParamMaxEventsPerReq))
 }
 
-func (s MutableSetChainInfoParams) OwnerFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamOwnerFee))
-}
-
-func (s MutableSetChainInfoParams) ValidatorFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamValidatorFee))
-}
-
-type ImmutableSetContractFeeParams struct {
-	proxy wasmtypes.Proxy
-}
-
-func (s ImmutableSetContractFeeParams) Hname() wasmtypes.ScImmutableHname {
-	return wasmtypes.NewScImmutableHname(s.proxy.Root(ParamHname))
+func (s MutableSetChainInfoParams) WasmMaxCallDepth() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamWasmMaxCallDepth))
 }
 
-func (s ImmutableSetContractFeeParams) OwnerFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamOwnerFee))
+func (s MutableSetChainInfoParams) WasmMaxMemoryPages() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamWasmMaxMemoryPages))
 }
 
-func (s ImmutableSetContractFeeParams) ValidatorFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamValidatorFee))
+func (s MutableSetChainInfoParams) WasmMaxTableSize() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamWasmMaxTableSize))
 }
 
-type MutableSetContractFeeParams struct {
+type ImmutableSetFeePolicyParams struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s MutableSetContractFeeParams) Hname() wasmtypes.ScMutableHname {
-	return wasmtypes.NewScMutableHname(s.proxy.Root(ParamHname))
+func (s ImmutableSetFeePolicyParams) FeeColor() wasmtypes.ScImmutableColor {
+	return wasmtypes.NewScImmutableColor(s.proxy.Root(ParamFeeColor))
 }
 
-func (s MutableSetContractFeeParams) OwnerFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamOwnerFee))
+func (s ImmutableSetFeePolicyParams) GasPrice() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamGasPrice))
 }
 
-func (s MutableSetContractFeeParams) ValidatorFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamValidatorFee))
+func (s ImmutableSetFeePolicyParams) MinFee() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamMinFee))
 }
 
-type ImmutableSetDefaultFeeParams struct {
-	proxy wasmtypes.Proxy
+func (s ImmutableSetFeePolicyParams) ValidatorFeeShare() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamValidatorFeeShare))
 }
 
-func (s ImmutableSetDefaultFeeParams) OwnerFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamOwnerFee))
+type MutableSetFeePolicyParams struct {
+	proxy wasmtypes.Proxy
 }
 
-func (s ImmutableSetDefaultFeeParams) ValidatorFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ParamValidatorFee))
+func (s MutableSetFeePolicyParams) FeeColor() wasmtypes.ScMutableColor {
+	return wasmtypes.NewScMutableColor(s.proxy.Root(ParamFeeColor))
 }
 
-type MutableSetDefaultFeeParams struct {
-	proxy wasmtypes.Proxy
+func (s MutableSetFeePolicyParams) GasPrice() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamGasPrice))
 }
 
-func (s MutableSetDefaultFeeParams) OwnerFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamOwnerFee))
+func (s MutableSetFeePolicyParams) MinFee() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamMinFee))
 }
 
-func (s MutableSetDefaultFeeParams) ValidatorFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamValidatorFee))
+func (s MutableSetFeePolicyParams) ValidatorFeeShare() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.proxy.Root(ParamValidatorFeeShare))
 }
 
-type ImmutableGetFeeInfoParams struct {
+type ImmutableEstimateFeeParams struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s ImmutableGetFeeInfoParams) Hname() wasmtypes.ScImmutableHname {
-	return wasmtypes.NewScImmutableHname(s.proxy.Root(ParamHname))
+func (s ImmutableEstimateFeeParams) Gas() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ParamGas))
 }
 
-type MutableGetFeeInfoParams struct {
+type MutableEstimateFeeParams struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s MutableGetFeeInfoParams) Hname() wasmtypes.ScMutableHname {
-	return wasmtypes.NewScMutableHname(s.proxy.Root(ParamHname))
+func (s MutableEstimateFeeParams) Gas() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ParamGas))
 }
@@ -9,6 +9,38 @@ package coregovernance
 
 import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
 
+type ImmutableEstimateFeeResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableEstimateFeeResults) Fee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ResultFee))
+}
+
+func (s ImmutableEstimateFeeResults) FeeColor() wasmtypes.ScImmutableColor {
+	return wasmtypes.NewScImmutableColor(s.proxy.Root(ResultFeeColor))
+}
+
+func (s ImmutableEstimateFeeResults) Gas() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ResultGas))
+}
+
+type MutableEstimateFeeResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableEstimateFeeResults) Fee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ResultFee))
+}
+
+func (s MutableEstimateFeeResults) FeeColor() wasmtypes.ScMutableColor {
+	return wasmtypes.NewScMutableColor(s.proxy.Root(ResultFeeColor))
+}
+
+func (s MutableEstimateFeeResults) Gas() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ResultGas))
+}
+
 type ArrayOfImmutableBytes struct {
 	proxy wasmtypes.Proxy
 }
@@ -61,20 +93,20 @@ type ImmutableGetChainInfoResults struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s ImmutableGetChainInfoResults) ChainID() wasmtypes.ScImmutableChainID {
-	return wasmtypes.NewScImmutableChainID(s.proxy.Root(ResultChainID))
+func (s ImmutableGetChainInfoResults) AccountHistory() wasmtypes.ScImmutableBool {
+	return wasmtypes.NewScImmutableBool(s.proxy.Root(ResultAccountHistory))
 }
 
-func (s ImmutableGetChainInfoResults) ChainOwnerID() wasmtypes.ScImmutableAgentID {
-	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ResultChainOwnerID))
+func (s ImmutableGetChainInfoResults) BlockKeepAmount() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultBlockKeepAmount))
 }
 
-func (s ImmutableGetChainInfoResults) DefaultOwnerFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ResultDefaultOwnerFee))
+func (s ImmutableGetChainInfoResults) ChainID() wasmtypes.ScImmutableChainID {
+	return wasmtypes.NewScImmutableChainID(s.proxy.Root(ResultChainID))
 }
 
-func (s ImmutableGetChainInfoResults) DefaultValidatorFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ResultDefaultValidatorFee))
+func (s ImmutableGetChainInfoResults) ChainOwnerID() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.proxy.Root(ResultChainOwnerID))
 }
 
 func (s ImmutableGetChainInfoResults) Description() wasmtypes.ScImmutableString {
@@ -97,24 +129,36 @@ func (s ImmutableGetChainInfoResults) MaxEventsPerReq() wasmtypes.ScImmutableInt
 	return wasmtypes.NewScImmutableInt16(s.proxy.Root(ResultMaxEventsPerReq))
 }
 
+func (s ImmutableGetChainInfoResults) WasmMaxCallDepth() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultWasmMaxCallDepth))
+}
+
+func (s ImmutableGetChainInfoResults) WasmMaxMemoryPages() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultWasmMaxMemoryPages))
+}
+
+func (s ImmutableGetChainInfoResults) WasmMaxTableSize() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultWasmMaxTableSize))
+}
+
 type MutableGetChainInfoResults struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s MutableGetChainInfoResults) ChainID() wasmtypes.ScMutableChainID {
-	return wasmtypes.NewScMutableChainID(s.proxy.Root(ResultChainID))
+func (s MutableGetChainInfoResults) AccountHistory() wasmtypes.ScMutableBool {
+	return wasmtypes.NewScMutableBool(s.proxy.Root(ResultAccountHistory))
 }
 
-func (s MutableGetChainInfoResults) ChainOwnerID() wasmtypes.ScMutableAgentID {
-	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ResultChainOwnerID))
+func (s MutableGetChainInfoResults) BlockKeepAmount() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultBlockKeepAmount))
 }
 
-func (s MutableGetChainInfoResults) DefaultOwnerFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ResultDefaultOwnerFee))
+func (s MutableGetChainInfoResults) ChainID() wasmtypes.ScMutableChainID {
+	return wasmtypes.NewScMutableChainID(s.proxy.Root(ResultChainID))
 }
 
-func (s MutableGetChainInfoResults) DefaultValidatorFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ResultDefaultValidatorFee))
+func (s MutableGetChainInfoResults) ChainOwnerID() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ResultChainOwnerID))
 }
 
 func (s MutableGetChainInfoResults) Description() wasmtypes.ScMutableString {
@@ -137,36 +181,56 @@ func (s MutableGetChainInfoResults) MaxEventsPerReq() wasmtypes.ScMutableInt16 {
 	return wasmtypes.NewScMutableInt16(s.proxy.Root(ResultMaxEventsPerReq))
 }
 
-type ImmutableGetFeeInfoResults struct {
+func (s MutableGetChainInfoResults) WasmMaxCallDepth() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultWasmMaxCallDepth))
+}
+
+func (s MutableGetChainInfoResults) WasmMaxMemoryPages() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultWasmMaxMemoryPages))
+}
+
+func (s MutableGetChainInfoResults) WasmMaxTableSize() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultWasmMaxTableSize))
+}
+
+type ImmutableGetFeePolicyResults struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s ImmutableGetFeeInfoResults) FeeColor() wasmtypes.ScImmutableColor {
+func (s ImmutableGetFeePolicyResults) FeeColor() wasmtypes.ScImmutableColor {
 	return wasmtypes.NewScImmutableColor(s.proxy.Root(ResultFeeColor))
 }
 
-func (s ImmutableGetFeeInfoResults) OwnerFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ResultOwnerFee))
+func (s ImmutableGetFeePolicyResults) GasPrice() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ResultGasPrice))
+}
+
+func (s ImmutableGetFeePolicyResults) MinFee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ResultMinFee))
 }
 
-func (s ImmutableGetFeeInfoResults) ValidatorFee() wasmtypes.ScImmutableInt64 {
-	return wasmtypes.NewScImmutableInt64(s.proxy.Root(ResultValidatorFee))
+func (s ImmutableGetFeePolicyResults) ValidatorFeeShare() wasmtypes.ScImmutableUint16 {
+	return wasmtypes.NewScImmutableUint16(s.proxy.Root(ResultValidatorFeeShare))
 }
 
-type MutableGetFeeInfoResults struct {
+type MutableGetFeePolicyResults struct {
 	proxy wasmtypes.Proxy
 }
 
-func (s MutableGetFeeInfoResults) FeeColor() wasmtypes.ScMutableColor {
+func (s MutableGetFeePolicyResults) FeeColor() wasmtypes.ScMutableColor {
 	return wasmtypes.NewScMutableColor(s.proxy.Root(ResultFeeColor))
 }
 
-func (s MutableGetFeeInfoResults) OwnerFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ResultOwnerFee))
+func (s MutableGetFeePolicyResults) GasPrice() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ResultGasPrice))
+}
+
+func (s MutableGetFeePolicyResults) MinFee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ResultMinFee))
 }
 
-func (s MutableGetFeeInfoResults) ValidatorFee() wasmtypes.ScMutableInt64 {
-	return wasmtypes.NewScMutableInt64(s.proxy.Root(ResultValidatorFee))
+func (s MutableGetFeePolicyResults) ValidatorFeeShare() wasmtypes.ScMutableUint16 {
+	return wasmtypes.NewScMutableUint16(s.proxy.Root(ResultValidatorFeeShare))
 }
 
 type ImmutableGetMaxBlobSizeResults struct {
@@ -33,6 +33,7 @@ const (
 	FuncDeployContract         = "deployContract"
 	FuncGrantDeployPermission  = "grantDeployPermission"
 	FuncRevokeDeployPermission = "revokeDeployPermission"
+	FuncUpgradeContract        = "upgradeContract"
 	ViewFindContract           = "findContract"
 	ViewGetContractRecords     = "getContractRecords"
 )
@@ -41,6 +42,7 @@ const (
 	HFuncDeployContract         = wasmtypes.ScHname(0x28232c27)
 	HFuncGrantDeployPermission  = wasmtypes.ScHname(0xf440263a)
 	HFuncRevokeDeployPermission = wasmtypes.ScHname(0x850744f1)
+	HFuncUpgradeContract        = wasmtypes.ScHname(0x00d30d5c)
 	HViewFindContract           = wasmtypes.ScHname(0xc145ca00)
 	HViewGetContractRecords     = wasmtypes.ScHname(0x078b3ef3)
 )
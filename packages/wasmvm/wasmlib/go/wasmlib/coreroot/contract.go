@@ -24,6 +24,11 @@ type RevokeDeployPermissionCall struct {
 	Params MutableRevokeDeployPermissionParams
 }
 
+type UpgradeContractCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableUpgradeContractParams
+}
+
 type FindContractCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableFindContractParams
@@ -57,6 +62,12 @@ func (sc Funcs) RevokeDeployPermission(ctx wasmlib.ScFuncCallContext) *RevokeDep
 	return f
 }
 
+func (sc Funcs) UpgradeContract(ctx wasmlib.ScFuncCallContext) *UpgradeContractCall {
+	f := &UpgradeContractCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncUpgradeContract)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 func (sc Funcs) FindContract(ctx wasmlib.ScViewCallContext) *FindContractCall {
 	f := &FindContractCall{Func: wasmlib.NewScView(ctx, HScName, HViewFindContract)}
 	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
@@ -75,6 +86,7 @@ var exportMap = wasmlib.ScExportMap{
 		FuncDeployContract,
 		FuncGrantDeployPermission,
 		FuncRevokeDeployPermission,
+		FuncUpgradeContract,
 		ViewFindContract,
 		ViewGetContractRecords,
 	},
@@ -82,6 +94,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
@@ -73,6 +73,38 @@ func (s MutableRevokeDeployPermissionParams) Deployer() wasmtypes.ScMutableAgent
 	return wasmtypes.NewScMutableAgentID(s.proxy.Root(ParamDeployer))
 }
 
+type ImmutableUpgradeContractParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableUpgradeContractParams) Description() wasmtypes.ScImmutableString {
+	return wasmtypes.NewScImmutableString(s.proxy.Root(ParamDescription))
+}
+
+func (s ImmutableUpgradeContractParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.proxy.Root(ParamHname))
+}
+
+func (s ImmutableUpgradeContractParams) ProgramHash() wasmtypes.ScImmutableHash {
+	return wasmtypes.NewScImmutableHash(s.proxy.Root(ParamProgramHash))
+}
+
+type MutableUpgradeContractParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableUpgradeContractParams) Description() wasmtypes.ScMutableString {
+	return wasmtypes.NewScMutableString(s.proxy.Root(ParamDescription))
+}
+
+func (s MutableUpgradeContractParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.proxy.Root(ParamHname))
+}
+
+func (s MutableUpgradeContractParams) ProgramHash() wasmtypes.ScMutableHash {
+	return wasmtypes.NewScMutableHash(s.proxy.Root(ParamProgramHash))
+}
+
 type ImmutableFindContractParams struct {
 	proxy wasmtypes.Proxy
 }
@@ -0,0 +1,47 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+package corescheduler
+
+import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
+
+const (
+	ScName        = "scheduler"
+	ScDescription = "Core scheduler contract"
+	HScName       = wasmtypes.ScHname(0x9c305966)
+)
+
+const (
+	ParamArgs       = "a"
+	ParamCallID     = "i"
+	ParamContract   = "h"
+	ParamCount      = "c"
+	ParamDelay      = "d"
+	ParamEntryPoint = "e"
+	ParamGasBudget  = "g"
+	ParamInterval   = "v"
+)
+
+const (
+	ResultCallID         = "i"
+	ResultScheduledCall  = "s"
+	ResultScheduledCalls = "this"
+)
+
+const (
+	FuncCancel            = "cancel"
+	FuncSchedule          = "schedule"
+	ViewGetScheduledCall  = "getScheduledCall"
+	ViewGetScheduledCalls = "getScheduledCalls"
+)
+
+const (
+	HFuncCancel            = wasmtypes.ScHname(0xa7e99697)
+	HFuncSchedule          = wasmtypes.ScHname(0x9631b89d)
+	HViewGetScheduledCall  = wasmtypes.ScHname(0x2328db9b)
+	HViewGetScheduledCalls = wasmtypes.ScHname(0x0e844101)
+)
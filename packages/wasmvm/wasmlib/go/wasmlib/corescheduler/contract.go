@@ -0,0 +1,89 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+package corescheduler
+
+import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib"
+
+type CancelCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableCancelParams
+}
+
+type ScheduleCall struct {
+	Func    *wasmlib.ScFunc
+	Params  MutableScheduleParams
+	Results ImmutableScheduleResults
+}
+
+type GetScheduledCallCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetScheduledCallParams
+	Results ImmutableGetScheduledCallResults
+}
+
+type GetScheduledCallsCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetScheduledCallsParams
+	Results ImmutableGetScheduledCallsResults
+}
+
+type Funcs struct{}
+
+var ScFuncs Funcs
+
+func (sc Funcs) Cancel(ctx wasmlib.ScFuncCallContext) *CancelCall {
+	f := &CancelCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncCancel)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
+func (sc Funcs) Schedule(ctx wasmlib.ScFuncCallContext) *ScheduleCall {
+	f := &ScheduleCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSchedule)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	wasmlib.NewCallResultsProxy(&f.Func.ScView, &f.Results.proxy)
+	return f
+}
+
+func (sc Funcs) GetScheduledCall(ctx wasmlib.ScViewCallContext) *GetScheduledCallCall {
+	f := &GetScheduledCallCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetScheduledCall)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
+	return f
+}
+
+func (sc Funcs) GetScheduledCalls(ctx wasmlib.ScViewCallContext) *GetScheduledCallsCall {
+	f := &GetScheduledCallsCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetScheduledCalls)}
+	f.Params.proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.proxy)
+	return f
+}
+
+var exportMap = wasmlib.ScExportMap{
+	Names: []string{
+		FuncCancel,
+		FuncSchedule,
+		ViewGetScheduledCall,
+		ViewGetScheduledCalls,
+	},
+	Funcs: []wasmlib.ScFuncContextFunction{
+		wasmlib.FuncError,
+		wasmlib.FuncError,
+	},
+	Views: []wasmlib.ScViewContextFunction{
+		wasmlib.ViewError,
+		wasmlib.ViewError,
+	},
+}
+
+func OnLoad(index int32) {
+	if index >= 0 {
+		panic("Calling core contract?")
+	}
+
+	wasmlib.ScExportsExport(&exportMap)
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+package corescheduler
+
+import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
+
+type ImmutableCancelParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableCancelParams) CallID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamCallID))
+}
+
+type MutableCancelParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableCancelParams) CallID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamCallID))
+}
+
+type ImmutableScheduleParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableScheduleParams) Args() wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(s.proxy.Root(ParamArgs))
+}
+
+func (s ImmutableScheduleParams) Count() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamCount))
+}
+
+func (s ImmutableScheduleParams) Delay() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamDelay))
+}
+
+func (s ImmutableScheduleParams) EntryPoint() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.proxy.Root(ParamEntryPoint))
+}
+
+func (s ImmutableScheduleParams) GasBudget() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.proxy.Root(ParamGasBudget))
+}
+
+func (s ImmutableScheduleParams) Interval() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamInterval))
+}
+
+type MutableScheduleParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableScheduleParams) Args() wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(s.proxy.Root(ParamArgs))
+}
+
+func (s MutableScheduleParams) Count() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamCount))
+}
+
+func (s MutableScheduleParams) Delay() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamDelay))
+}
+
+func (s MutableScheduleParams) EntryPoint() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.proxy.Root(ParamEntryPoint))
+}
+
+func (s MutableScheduleParams) GasBudget() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.proxy.Root(ParamGasBudget))
+}
+
+func (s MutableScheduleParams) Interval() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamInterval))
+}
+
+type ImmutableGetScheduledCallParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetScheduledCallParams) CallID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ParamCallID))
+}
+
+type MutableGetScheduledCallParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetScheduledCallParams) CallID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ParamCallID))
+}
+
+type ImmutableGetScheduledCallsParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetScheduledCallsParams) Contract() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.proxy.Root(ParamContract))
+}
+
+type MutableGetScheduledCallsParams struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetScheduledCallsParams) Contract() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.proxy.Root(ParamContract))
+}
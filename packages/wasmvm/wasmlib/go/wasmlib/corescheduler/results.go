@@ -0,0 +1,80 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+package corescheduler
+
+import "github.com/iotaledger/wasp/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
+
+type ImmutableScheduleResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableScheduleResults) CallID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.proxy.Root(ResultCallID))
+}
+
+type MutableScheduleResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableScheduleResults) CallID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.proxy.Root(ResultCallID))
+}
+
+type ImmutableGetScheduledCallResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetScheduledCallResults) ScheduledCall() wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(s.proxy.Root(ResultScheduledCall))
+}
+
+type MutableGetScheduledCallResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetScheduledCallResults) ScheduledCall() wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(s.proxy.Root(ResultScheduledCall))
+}
+
+type MapUint32ToImmutableBytes struct {
+	proxy wasmtypes.Proxy
+}
+
+func (m MapUint32ToImmutableBytes) GetBytes(key uint32) wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(m.proxy.Key(wasmtypes.Uint32ToBytes(key)))
+}
+
+type ImmutableGetScheduledCallsResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s ImmutableGetScheduledCallsResults) ScheduledCalls() MapUint32ToImmutableBytes {
+	//nolint:gosimple
+	return MapUint32ToImmutableBytes{proxy: s.proxy}
+}
+
+type MapUint32ToMutableBytes struct {
+	proxy wasmtypes.Proxy
+}
+
+func (m MapUint32ToMutableBytes) Clear() {
+	m.proxy.ClearMap()
+}
+
+func (m MapUint32ToMutableBytes) GetBytes(key uint32) wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(m.proxy.Key(wasmtypes.Uint32ToBytes(key)))
+}
+
+type MutableGetScheduledCallsResults struct {
+	proxy wasmtypes.Proxy
+}
+
+func (s MutableGetScheduledCallsResults) ScheduledCalls() MapUint32ToMutableBytes {
+	//nolint:gosimple
+	return MapUint32ToMutableBytes{proxy: s.proxy}
+}
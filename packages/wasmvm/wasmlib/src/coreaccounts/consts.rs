@@ -14,25 +14,45 @@ pub const SC_DESCRIPTION : &str = "Core chain account ledger contract";
 pub const HSC_NAME       : ScHname = ScHname(0x3c4b5e02);
 
 pub(crate) const PARAM_AGENT_ID        : &str = "a";
+pub(crate) const PARAM_AMOUNT          : &str = "m";
+pub(crate) const PARAM_COLOR           : &str = "c";
+pub(crate) const PARAM_LIMIT           : &str = "l";
+pub(crate) const PARAM_OFFSET          : &str = "o";
+pub(crate) const PARAM_OWNER           : &str = "ow";
+pub(crate) const PARAM_SPENDER         : &str = "sp";
+pub(crate) const PARAM_TARGET          : &str = "t";
 pub(crate) const PARAM_WITHDRAW_AMOUNT : &str = "m";
 pub(crate) const PARAM_WITHDRAW_COLOR  : &str = "c";
 
 pub(crate) const RESULT_ACCOUNT_NONCE : &str = "n";
 pub(crate) const RESULT_AGENTS        : &str = "this";
+pub(crate) const RESULT_ALLOWANCE     : &str = "this";
 pub(crate) const RESULT_BALANCES      : &str = "this";
-
-pub(crate) const FUNC_DEPOSIT           : &str = "deposit";
-pub(crate) const FUNC_HARVEST           : &str = "harvest";
-pub(crate) const FUNC_WITHDRAW          : &str = "withdraw";
-pub(crate) const VIEW_ACCOUNTS          : &str = "accounts";
-pub(crate) const VIEW_BALANCE           : &str = "balance";
-pub(crate) const VIEW_GET_ACCOUNT_NONCE : &str = "getAccountNonce";
-pub(crate) const VIEW_TOTAL_ASSETS      : &str = "totalAssets";
-
-pub(crate) const HFUNC_DEPOSIT           : ScHname = ScHname(0xbdc9102d);
-pub(crate) const HFUNC_HARVEST           : ScHname = ScHname(0x7b40efbd);
-pub(crate) const HFUNC_WITHDRAW          : ScHname = ScHname(0x9dcc0f41);
-pub(crate) const HVIEW_ACCOUNTS          : ScHname = ScHname(0x3c4b5e02);
-pub(crate) const HVIEW_BALANCE           : ScHname = ScHname(0x84168cb4);
-pub(crate) const HVIEW_GET_ACCOUNT_NONCE : ScHname = ScHname(0x529d7df9);
-pub(crate) const HVIEW_TOTAL_ASSETS      : ScHname = ScHname(0xfab0f8d2);
+pub(crate) const RESULT_ENTRIES       : &str = "he";
+pub(crate) const RESULT_LENGTH        : &str = "hl";
+
+pub(crate) const FUNC_APPROVE             : &str = "approve";
+pub(crate) const FUNC_DEPOSIT             : &str = "deposit";
+pub(crate) const FUNC_HARVEST             : &str = "harvest";
+pub(crate) const FUNC_REVOKE              : &str = "revoke";
+pub(crate) const FUNC_TRANSFER_FROM       : &str = "transferFrom";
+pub(crate) const FUNC_WITHDRAW            : &str = "withdraw";
+pub(crate) const VIEW_ACCOUNTS            : &str = "accounts";
+pub(crate) const VIEW_BALANCE             : &str = "balance";
+pub(crate) const VIEW_GET_ACCOUNT_HISTORY : &str = "getAccountHistory";
+pub(crate) const VIEW_GET_ACCOUNT_NONCE   : &str = "getAccountNonce";
+pub(crate) const VIEW_GET_ALLOWANCE       : &str = "getAllowance";
+pub(crate) const VIEW_TOTAL_ASSETS        : &str = "totalAssets";
+
+pub(crate) const HFUNC_APPROVE             : ScHname = ScHname(0xa0661268);
+pub(crate) const HFUNC_DEPOSIT             : ScHname = ScHname(0xbdc9102d);
+pub(crate) const HFUNC_HARVEST             : ScHname = ScHname(0x7b40efbd);
+pub(crate) const HFUNC_REVOKE              : ScHname = ScHname(0x128d530a);
+pub(crate) const HFUNC_TRANSFER_FROM       : ScHname = ScHname(0xd5e0a602);
+pub(crate) const HFUNC_WITHDRAW            : ScHname = ScHname(0x9dcc0f41);
+pub(crate) const HVIEW_ACCOUNTS            : ScHname = ScHname(0x3c4b5e02);
+pub(crate) const HVIEW_BALANCE             : ScHname = ScHname(0x84168cb4);
+pub(crate) const HVIEW_GET_ACCOUNT_HISTORY : ScHname = ScHname(0x289be591);
+pub(crate) const HVIEW_GET_ACCOUNT_NONCE   : ScHname = ScHname(0x529d7df9);
+pub(crate) const HVIEW_GET_ALLOWANCE       : ScHname = ScHname(0x329aa88f);
+pub(crate) const HVIEW_TOTAL_ASSETS        : ScHname = ScHname(0xfab0f8d2);
@@ -10,6 +10,11 @@
 use crate::coreaccounts::*;
 use crate::*;
 
+pub struct ApproveCall {
+	pub func: ScFunc,
+	pub params: MutableApproveParams,
+}
+
 pub struct DepositCall {
 	pub func: ScFunc,
 	pub params: MutableDepositParams,
@@ -20,6 +25,16 @@ pub struct HarvestCall {
 	pub params: MutableHarvestParams,
 }
 
+pub struct RevokeCall {
+	pub func: ScFunc,
+	pub params: MutableRevokeParams,
+}
+
+pub struct TransferFromCall {
+	pub func: ScFunc,
+	pub params: MutableTransferFromParams,
+}
+
 pub struct WithdrawCall {
 	pub func: ScFunc,
 }
@@ -35,12 +50,24 @@ pub struct BalanceCall {
 	pub results: ImmutableBalanceResults,
 }
 
+pub struct GetAccountHistoryCall {
+	pub func: ScView,
+	pub params: MutableGetAccountHistoryParams,
+	pub results: ImmutableGetAccountHistoryResults,
+}
+
 pub struct GetAccountNonceCall {
 	pub func: ScView,
 	pub params: MutableGetAccountNonceParams,
 	pub results: ImmutableGetAccountNonceResults,
 }
 
+pub struct GetAllowanceCall {
+	pub func: ScView,
+	pub params: MutableGetAllowanceParams,
+	pub results: ImmutableGetAllowanceResults,
+}
+
 pub struct TotalAssetsCall {
 	pub func: ScView,
 	pub results: ImmutableTotalAssetsResults,
@@ -50,6 +77,15 @@ pub struct ScFuncs {
 }
 
 impl ScFuncs {
+    pub fn approve(_ctx: &dyn ScFuncCallContext) -> ApproveCall {
+        let mut f = ApproveCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_APPROVE),
+            params: MutableApproveParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     pub fn deposit(_ctx: &dyn ScFuncCallContext) -> DepositCall {
         let mut f = DepositCall {
             func: ScFunc::new(HSC_NAME, HFUNC_DEPOSIT),
@@ -68,6 +104,24 @@ impl ScFuncs {
         f
     }
 
+    pub fn revoke(_ctx: &dyn ScFuncCallContext) -> RevokeCall {
+        let mut f = RevokeCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_REVOKE),
+            params: MutableRevokeParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
+    pub fn transfer_from(_ctx: &dyn ScFuncCallContext) -> TransferFromCall {
+        let mut f = TransferFromCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_TRANSFER_FROM),
+            params: MutableTransferFromParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     pub fn withdraw(_ctx: &dyn ScFuncCallContext) -> WithdrawCall {
         WithdrawCall {
             func: ScFunc::new(HSC_NAME, HFUNC_WITHDRAW),
@@ -94,6 +148,17 @@ impl ScFuncs {
         f
     }
 
+    pub fn get_account_history(_ctx: &dyn ScViewCallContext) -> GetAccountHistoryCall {
+        let mut f = GetAccountHistoryCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_ACCOUNT_HISTORY),
+            params: MutableGetAccountHistoryParams { proxy: Proxy::nil() },
+            results: ImmutableGetAccountHistoryResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     pub fn get_account_nonce(_ctx: &dyn ScViewCallContext) -> GetAccountNonceCall {
         let mut f = GetAccountNonceCall {
             func: ScView::new(HSC_NAME, HVIEW_GET_ACCOUNT_NONCE),
@@ -105,6 +170,17 @@ impl ScFuncs {
         f
     }
 
+    pub fn get_allowance(_ctx: &dyn ScViewCallContext) -> GetAllowanceCall {
+        let mut f = GetAllowanceCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_ALLOWANCE),
+            params: MutableGetAllowanceParams { proxy: Proxy::nil() },
+            results: ImmutableGetAllowanceResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     pub fn total_assets(_ctx: &dyn ScViewCallContext) -> TotalAssetsCall {
         let mut f = TotalAssetsCall {
             func: ScView::new(HSC_NAME, HVIEW_TOTAL_ASSETS),
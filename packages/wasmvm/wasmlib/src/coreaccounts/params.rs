@@ -11,6 +11,44 @@
 use crate::coreaccounts::*;
 use crate::*;
 
+#[derive(Clone)]
+pub struct ImmutableApproveParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableApproveParams {
+    pub fn amount(&self) -> ScImmutableInt64 {
+		ScImmutableInt64::new(self.proxy.root(PARAM_AMOUNT))
+	}
+
+    pub fn color(&self) -> ScImmutableColor {
+		ScImmutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn spender(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableApproveParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableApproveParams {
+    pub fn amount(&self) -> ScMutableInt64 {
+		ScMutableInt64::new(self.proxy.root(PARAM_AMOUNT))
+	}
+
+    pub fn color(&self) -> ScMutableColor {
+		ScMutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn spender(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableDepositParams {
 	pub(crate) proxy: Proxy,
@@ -63,6 +101,82 @@ impl MutableHarvestParams {
 	}
 }
 
+#[derive(Clone)]
+pub struct ImmutableRevokeParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableRevokeParams {
+    pub fn color(&self) -> ScImmutableColor {
+		ScImmutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn spender(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableRevokeParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableRevokeParams {
+    pub fn color(&self) -> ScMutableColor {
+		ScMutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn spender(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
+
+#[derive(Clone)]
+pub struct ImmutableTransferFromParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableTransferFromParams {
+    pub fn amount(&self) -> ScImmutableInt64 {
+		ScImmutableInt64::new(self.proxy.root(PARAM_AMOUNT))
+	}
+
+    pub fn color(&self) -> ScImmutableColor {
+		ScImmutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn owner(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_OWNER))
+	}
+
+    pub fn target(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_TARGET))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableTransferFromParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableTransferFromParams {
+    pub fn amount(&self) -> ScMutableInt64 {
+		ScMutableInt64::new(self.proxy.root(PARAM_AMOUNT))
+	}
+
+    pub fn color(&self) -> ScMutableColor {
+		ScMutableColor::new(self.proxy.root(PARAM_COLOR))
+	}
+
+    pub fn owner(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_OWNER))
+	}
+
+    pub fn target(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_TARGET))
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableBalanceParams {
 	pub(crate) proxy: Proxy,
@@ -85,6 +199,44 @@ impl MutableBalanceParams {
 	}
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetAccountHistoryParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetAccountHistoryParams {
+    pub fn agent_id(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+	}
+
+    pub fn limit(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_LIMIT))
+	}
+
+    pub fn offset(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_OFFSET))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetAccountHistoryParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetAccountHistoryParams {
+    pub fn agent_id(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+	}
+
+    pub fn limit(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_LIMIT))
+	}
+
+    pub fn offset(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_OFFSET))
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetAccountNonceParams {
 	pub(crate) proxy: Proxy,
@@ -106,3 +258,33 @@ impl MutableGetAccountNonceParams {
 		ScMutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
 	}
 }
+
+#[derive(Clone)]
+pub struct ImmutableGetAllowanceParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetAllowanceParams {
+    pub fn owner(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_OWNER))
+	}
+
+    pub fn spender(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetAllowanceParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetAllowanceParams {
+    pub fn owner(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_OWNER))
+	}
+
+    pub fn spender(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(PARAM_SPENDER))
+	}
+}
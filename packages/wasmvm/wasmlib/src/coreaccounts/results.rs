@@ -107,6 +107,74 @@ impl MutableBalanceResults {
 	}
 }
 
+#[derive(Clone)]
+pub struct ArrayOfImmutableBytes {
+	pub(crate) proxy: Proxy,
+}
+
+impl ArrayOfImmutableBytes {
+    pub fn length(&self) -> u32 {
+        self.proxy.length()
+    }
+
+    pub fn get_bytes(&self, index: u32) -> ScImmutableBytes {
+        ScImmutableBytes::new(self.proxy.index(index))
+    }
+}
+
+#[derive(Clone)]
+pub struct ImmutableGetAccountHistoryResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetAccountHistoryResults {
+    pub fn entries(&self) -> ArrayOfImmutableBytes {
+		ArrayOfImmutableBytes { proxy: self.proxy.root(RESULT_ENTRIES) }
+	}
+
+    pub fn length(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_LENGTH))
+	}
+}
+
+#[derive(Clone)]
+pub struct ArrayOfMutableBytes {
+	pub(crate) proxy: Proxy,
+}
+
+impl ArrayOfMutableBytes {
+	pub fn append_bytes(&self) -> ScMutableBytes {
+		ScMutableBytes::new(self.proxy.append())
+	}
+
+	pub fn clear(&self) {
+        self.proxy.clear_array();
+    }
+
+    pub fn length(&self) -> u32 {
+        self.proxy.length()
+    }
+
+    pub fn get_bytes(&self, index: u32) -> ScMutableBytes {
+        ScMutableBytes::new(self.proxy.index(index))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetAccountHistoryResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetAccountHistoryResults {
+    pub fn entries(&self) -> ArrayOfMutableBytes {
+		ArrayOfMutableBytes { proxy: self.proxy.root(RESULT_ENTRIES) }
+	}
+
+    pub fn length(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_LENGTH))
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetAccountNonceResults {
 	pub(crate) proxy: Proxy,
@@ -129,6 +197,28 @@ impl MutableGetAccountNonceResults {
 	}
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetAllowanceResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetAllowanceResults {
+    pub fn allowance(&self) -> MapColorToImmutableInt64 {
+		MapColorToImmutableInt64 { proxy: self.proxy.clone() }
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetAllowanceResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetAllowanceResults {
+    pub fn allowance(&self) -> MapColorToMutableInt64 {
+		MapColorToMutableInt64 { proxy: self.proxy.clone() }
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableTotalAssetsResults {
 	pub(crate) proxy: Proxy,
@@ -23,6 +23,8 @@ pub(crate) const RESULT_BLOCK_INDEX              : &str = "n";
 pub(crate) const RESULT_BLOCK_INFO               : &str = "i";
 pub(crate) const RESULT_EVENT                    : &str = "e";
 pub(crate) const RESULT_GOVERNING_ADDRESS        : &str = "g";
+pub(crate) const RESULT_PRUNED_BYTES             : &str = "pb";
+pub(crate) const RESULT_PRUNED_UNTIL             : &str = "pu";
 pub(crate) const RESULT_REQUEST_ID               : &str = "u";
 pub(crate) const RESULT_REQUEST_INDEX            : &str = "r";
 pub(crate) const RESULT_REQUEST_PROCESSED        : &str = "p";
@@ -35,6 +37,7 @@ pub(crate) const VIEW_GET_EVENTS_FOR_BLOCK           : &str = "getEventsForBlock
 pub(crate) const VIEW_GET_EVENTS_FOR_CONTRACT        : &str = "getEventsForContract";
 pub(crate) const VIEW_GET_EVENTS_FOR_REQUEST         : &str = "getEventsForRequest";
 pub(crate) const VIEW_GET_LATEST_BLOCK_INFO          : &str = "getLatestBlockInfo";
+pub(crate) const VIEW_GET_PRUNING_INFO               : &str = "getPruningInfo";
 pub(crate) const VIEW_GET_REQUEST_I_DS_FOR_BLOCK     : &str = "getRequestIDsForBlock";
 pub(crate) const VIEW_GET_REQUEST_RECEIPT            : &str = "getRequestReceipt";
 pub(crate) const VIEW_GET_REQUEST_RECEIPTS_FOR_BLOCK : &str = "getRequestReceiptsForBlock";
@@ -46,6 +49,7 @@ pub(crate) const HVIEW_GET_EVENTS_FOR_BLOCK           : ScHname = ScHname(0x3623
 pub(crate) const HVIEW_GET_EVENTS_FOR_CONTRACT        : ScHname = ScHname(0x682a1922);
 pub(crate) const HVIEW_GET_EVENTS_FOR_REQUEST         : ScHname = ScHname(0x4f8d68e4);
 pub(crate) const HVIEW_GET_LATEST_BLOCK_INFO          : ScHname = ScHname(0x084a1760);
+pub(crate) const HVIEW_GET_PRUNING_INFO               : ScHname = ScHname(0x1ac4ecd4);
 pub(crate) const HVIEW_GET_REQUEST_I_DS_FOR_BLOCK     : ScHname = ScHname(0x5a20327a);
 pub(crate) const HVIEW_GET_REQUEST_RECEIPT            : ScHname = ScHname(0xb7f9534f);
 pub(crate) const HVIEW_GET_REQUEST_RECEIPTS_FOR_BLOCK : ScHname = ScHname(0x77e3beef);
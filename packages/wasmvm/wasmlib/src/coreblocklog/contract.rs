@@ -44,6 +44,11 @@ pub struct GetLatestBlockInfoCall {
 	pub results: ImmutableGetLatestBlockInfoResults,
 }
 
+pub struct GetPruningInfoCall {
+	pub func: ScView,
+	pub results: ImmutableGetPruningInfoResults,
+}
+
 pub struct GetRequestIDsForBlockCall {
 	pub func: ScView,
 	pub params: MutableGetRequestIDsForBlockParams,
@@ -134,6 +139,15 @@ impl ScFuncs {
         f
     }
 
+    pub fn get_pruning_info(_ctx: &dyn ScViewCallContext) -> GetPruningInfoCall {
+        let mut f = GetPruningInfoCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_PRUNING_INFO),
+            results: ImmutableGetPruningInfoResults { proxy: Proxy::nil() },
+        };
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     pub fn get_request_i_ds_for_block(_ctx: &dyn ScViewCallContext) -> GetRequestIDsForBlockCall {
         let mut f = GetRequestIDsForBlockCall {
             func: ScView::new(HSC_NAME, HVIEW_GET_REQUEST_I_DS_FOR_BLOCK),
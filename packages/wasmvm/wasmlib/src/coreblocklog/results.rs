@@ -205,6 +205,36 @@ impl MutableGetLatestBlockInfoResults {
 	}
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetPruningInfoResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetPruningInfoResults {
+    pub fn pruned_bytes(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(RESULT_PRUNED_BYTES))
+	}
+
+    pub fn pruned_until(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_PRUNED_UNTIL))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetPruningInfoResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetPruningInfoResults {
+    pub fn pruned_bytes(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(RESULT_PRUNED_BYTES))
+	}
+
+    pub fn pruned_until(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_PRUNED_UNTIL))
+	}
+}
+
 #[derive(Clone)]
 pub struct ArrayOfImmutableRequestID {
 	pub(crate) proxy: Proxy,
@@ -13,28 +13,40 @@ pub const SC_NAME        : &str = "governance";
 pub const SC_DESCRIPTION : &str = "Core governance contract";
 pub const HSC_NAME       : ScHname = ScHname(0x17cf909f);
 
+pub(crate) const PARAM_ACCOUNT_HISTORY          : &str = "ah";
+pub(crate) const PARAM_BLOCK_KEEP_AMOUNT        : &str = "bk";
 pub(crate) const PARAM_CHAIN_OWNER              : &str = "oi";
 pub(crate) const PARAM_FEE_COLOR                : &str = "fc";
-pub(crate) const PARAM_HNAME                    : &str = "hn";
+pub(crate) const PARAM_GAS                      : &str = "g";
+pub(crate) const PARAM_GAS_PRICE                : &str = "gp";
 pub(crate) const PARAM_MAX_BLOB_SIZE            : &str = "bs";
 pub(crate) const PARAM_MAX_EVENT_SIZE           : &str = "es";
 pub(crate) const PARAM_MAX_EVENTS_PER_REQ       : &str = "ne";
-pub(crate) const PARAM_OWNER_FEE                : &str = "of";
+pub(crate) const PARAM_MIN_FEE                  : &str = "mf";
 pub(crate) const PARAM_STATE_CONTROLLER_ADDRESS : &str = "S";
-pub(crate) const PARAM_VALIDATOR_FEE            : &str = "vf";
+pub(crate) const PARAM_VALIDATOR_FEE_SHARE      : &str = "vs";
+pub(crate) const PARAM_WASM_MAX_CALL_DEPTH      : &str = "wd";
+pub(crate) const PARAM_WASM_MAX_MEMORY_PAGES    : &str = "wm";
+pub(crate) const PARAM_WASM_MAX_TABLE_SIZE      : &str = "wt";
 
+pub(crate) const RESULT_ACCOUNT_HISTORY                    : &str = "ah";
 pub(crate) const RESULT_ALLOWED_STATE_CONTROLLER_ADDRESSES : &str = "a";
+pub(crate) const RESULT_BLOCK_KEEP_AMOUNT                  : &str = "bk";
 pub(crate) const RESULT_CHAIN_ID                           : &str = "c";
 pub(crate) const RESULT_CHAIN_OWNER_ID                     : &str = "o";
-pub(crate) const RESULT_DEFAULT_OWNER_FEE                  : &str = "do";
-pub(crate) const RESULT_DEFAULT_VALIDATOR_FEE              : &str = "dv";
 pub(crate) const RESULT_DESCRIPTION                        : &str = "d";
+pub(crate) const RESULT_FEE                                : &str = "fe";
 pub(crate) const RESULT_FEE_COLOR                          : &str = "f";
+pub(crate) const RESULT_GAS                                : &str = "g";
+pub(crate) const RESULT_GAS_PRICE                          : &str = "gp";
 pub(crate) const RESULT_MAX_BLOB_SIZE                      : &str = "mb";
 pub(crate) const RESULT_MAX_EVENT_SIZE                     : &str = "me";
 pub(crate) const RESULT_MAX_EVENTS_PER_REQ                 : &str = "mr";
-pub(crate) const RESULT_OWNER_FEE                          : &str = "of";
-pub(crate) const RESULT_VALIDATOR_FEE                      : &str = "vf";
+pub(crate) const RESULT_MIN_FEE                            : &str = "mf";
+pub(crate) const RESULT_VALIDATOR_FEE_SHARE                : &str = "vs";
+pub(crate) const RESULT_WASM_MAX_CALL_DEPTH                : &str = "wd";
+pub(crate) const RESULT_WASM_MAX_MEMORY_PAGES              : &str = "wm";
+pub(crate) const RESULT_WASM_MAX_TABLE_SIZE                : &str = "wt";
 
 pub(crate) const FUNC_ADD_ALLOWED_STATE_CONTROLLER_ADDRESS    : &str = "addAllowedStateControllerAddress";
 pub(crate) const FUNC_CLAIM_CHAIN_OWNERSHIP                   : &str = "claimChainOwnership";
@@ -42,11 +54,11 @@ pub(crate) const FUNC_DELEGATE_CHAIN_OWNERSHIP                : &str = "delegate
 pub(crate) const FUNC_REMOVE_ALLOWED_STATE_CONTROLLER_ADDRESS : &str = "removeAllowedStateControllerAddress";
 pub(crate) const FUNC_ROTATE_STATE_CONTROLLER                 : &str = "rotateStateController";
 pub(crate) const FUNC_SET_CHAIN_INFO                          : &str = "setChainInfo";
-pub(crate) const FUNC_SET_CONTRACT_FEE                        : &str = "setContractFee";
-pub(crate) const FUNC_SET_DEFAULT_FEE                         : &str = "setDefaultFee";
+pub(crate) const FUNC_SET_FEE_POLICY                          : &str = "setFeePolicy";
+pub(crate) const VIEW_ESTIMATE_FEE                            : &str = "estimateFee";
 pub(crate) const VIEW_GET_ALLOWED_STATE_CONTROLLER_ADDRESSES  : &str = "getAllowedStateControllerAddresses";
 pub(crate) const VIEW_GET_CHAIN_INFO                          : &str = "getChainInfo";
-pub(crate) const VIEW_GET_FEE_INFO                            : &str = "getFeeInfo";
+pub(crate) const VIEW_GET_FEE_POLICY                          : &str = "getFeePolicy";
 pub(crate) const VIEW_GET_MAX_BLOB_SIZE                       : &str = "getMaxBlobSize";
 
 pub(crate) const HFUNC_ADD_ALLOWED_STATE_CONTROLLER_ADDRESS    : ScHname = ScHname(0x9469d567);
@@ -55,9 +67,9 @@ pub(crate) const HFUNC_DELEGATE_CHAIN_OWNERSHIP                : ScHname = ScHna
 pub(crate) const HFUNC_REMOVE_ALLOWED_STATE_CONTROLLER_ADDRESS : ScHname = ScHname(0x31f69447);
 pub(crate) const HFUNC_ROTATE_STATE_CONTROLLER                 : ScHname = ScHname(0x244d1038);
 pub(crate) const HFUNC_SET_CHAIN_INFO                          : ScHname = ScHname(0x702f5d2b);
-pub(crate) const HFUNC_SET_CONTRACT_FEE                        : ScHname = ScHname(0x8421a42b);
-pub(crate) const HFUNC_SET_DEFAULT_FEE                         : ScHname = ScHname(0x3310ecd0);
+pub(crate) const HFUNC_SET_FEE_POLICY                          : ScHname = ScHname(0x5b791c9f);
+pub(crate) const HVIEW_ESTIMATE_FEE                            : ScHname = ScHname(0xe1b674ad);
 pub(crate) const HVIEW_GET_ALLOWED_STATE_CONTROLLER_ADDRESSES  : ScHname = ScHname(0xf3505183);
 pub(crate) const HVIEW_GET_CHAIN_INFO                          : ScHname = ScHname(0x434477e2);
-pub(crate) const HVIEW_GET_FEE_INFO                            : ScHname = ScHname(0x9fe54b48);
+pub(crate) const HVIEW_GET_FEE_POLICY                          : ScHname = ScHname(0xf8c89790);
 pub(crate) const HVIEW_GET_MAX_BLOB_SIZE                       : ScHname = ScHname(0xe1db3d28);
@@ -39,14 +39,15 @@ pub struct SetChainInfoCall {
 	pub params: MutableSetChainInfoParams,
 }
 
-pub struct SetContractFeeCall {
+pub struct SetFeePolicyCall {
 	pub func: ScFunc,
-	pub params: MutableSetContractFeeParams,
+	pub params: MutableSetFeePolicyParams,
 }
 
-pub struct SetDefaultFeeCall {
-	pub func: ScFunc,
-	pub params: MutableSetDefaultFeeParams,
+pub struct EstimateFeeCall {
+	pub func: ScView,
+	pub params: MutableEstimateFeeParams,
+	pub results: ImmutableEstimateFeeResults,
 }
 
 pub struct GetAllowedStateControllerAddressesCall {
@@ -59,10 +60,9 @@ pub struct GetChainInfoCall {
 	pub results: ImmutableGetChainInfoResults,
 }
 
-pub struct GetFeeInfoCall {
+pub struct GetFeePolicyCall {
 	pub func: ScView,
-	pub params: MutableGetFeeInfoParams,
-	pub results: ImmutableGetFeeInfoResults,
+	pub results: ImmutableGetFeePolicyResults,
 }
 
 pub struct GetMaxBlobSizeCall {
@@ -125,21 +125,23 @@ impl ScFuncs {
         f
     }
 
-    pub fn set_contract_fee(_ctx: &dyn ScFuncCallContext) -> SetContractFeeCall {
-        let mut f = SetContractFeeCall {
-            func: ScFunc::new(HSC_NAME, HFUNC_SET_CONTRACT_FEE),
-            params: MutableSetContractFeeParams { proxy: Proxy::nil() },
+    pub fn set_fee_policy(_ctx: &dyn ScFuncCallContext) -> SetFeePolicyCall {
+        let mut f = SetFeePolicyCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_SET_FEE_POLICY),
+            params: MutableSetFeePolicyParams { proxy: Proxy::nil() },
         };
         ScFunc::link_params(&mut f.params.proxy, &f.func);
         f
     }
 
-    pub fn set_default_fee(_ctx: &dyn ScFuncCallContext) -> SetDefaultFeeCall {
-        let mut f = SetDefaultFeeCall {
-            func: ScFunc::new(HSC_NAME, HFUNC_SET_DEFAULT_FEE),
-            params: MutableSetDefaultFeeParams { proxy: Proxy::nil() },
+    pub fn estimate_fee(_ctx: &dyn ScViewCallContext) -> EstimateFeeCall {
+        let mut f = EstimateFeeCall {
+            func: ScView::new(HSC_NAME, HVIEW_ESTIMATE_FEE),
+            params: MutableEstimateFeeParams { proxy: Proxy::nil() },
+            results: ImmutableEstimateFeeResults { proxy: Proxy::nil() },
         };
-        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
         f
     }
 
@@ -161,13 +163,11 @@ impl ScFuncs {
         f
     }
 
-    pub fn get_fee_info(_ctx: &dyn ScViewCallContext) -> GetFeeInfoCall {
-        let mut f = GetFeeInfoCall {
-            func: ScView::new(HSC_NAME, HVIEW_GET_FEE_INFO),
-            params: MutableGetFeeInfoParams { proxy: Proxy::nil() },
-            results: ImmutableGetFeeInfoResults { proxy: Proxy::nil() },
+    pub fn get_fee_policy(_ctx: &dyn ScViewCallContext) -> GetFeePolicyCall {
+        let mut f = GetFeePolicyCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_FEE_POLICY),
+            results: ImmutableGetFeePolicyResults { proxy: Proxy::nil() },
         };
-        ScView::link_params(&mut f.params.proxy, &f.func);
         ScView::link_results(&mut f.results.proxy, &f.func);
         f
     }
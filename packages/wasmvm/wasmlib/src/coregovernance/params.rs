@@ -121,6 +121,14 @@ pub struct ImmutableSetChainInfoParams {
 }
 
 impl ImmutableSetChainInfoParams {
+    pub fn account_history(&self) -> ScImmutableBool {
+		ScImmutableBool::new(self.proxy.root(PARAM_ACCOUNT_HISTORY))
+	}
+
+    pub fn block_keep_amount(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_BLOCK_KEEP_AMOUNT))
+	}
+
     pub fn max_blob_size(&self) -> ScImmutableInt32 {
 		ScImmutableInt32::new(self.proxy.root(PARAM_MAX_BLOB_SIZE))
 	}
@@ -133,12 +141,16 @@ impl ImmutableSetChainInfoParams {
 		ScImmutableInt16::new(self.proxy.root(PARAM_MAX_EVENTS_PER_REQ))
 	}
 
-    pub fn owner_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
+    pub fn wasm_max_call_depth(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_WASM_MAX_CALL_DEPTH))
 	}
 
-    pub fn validator_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    pub fn wasm_max_memory_pages(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_WASM_MAX_MEMORY_PAGES))
+	}
+
+    pub fn wasm_max_table_size(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_WASM_MAX_TABLE_SIZE))
 	}
 }
 
@@ -148,6 +160,14 @@ pub struct MutableSetChainInfoParams {
 }
 
 impl MutableSetChainInfoParams {
+    pub fn account_history(&self) -> ScMutableBool {
+		ScMutableBool::new(self.proxy.root(PARAM_ACCOUNT_HISTORY))
+	}
+
+    pub fn block_keep_amount(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_BLOCK_KEEP_AMOUNT))
+	}
+
     pub fn max_blob_size(&self) -> ScMutableInt32 {
 		ScMutableInt32::new(self.proxy.root(PARAM_MAX_BLOB_SIZE))
 	}
@@ -160,101 +180,83 @@ impl MutableSetChainInfoParams {
 		ScMutableInt16::new(self.proxy.root(PARAM_MAX_EVENTS_PER_REQ))
 	}
 
-    pub fn owner_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
-	}
-
-    pub fn validator_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
-	}
-}
-
-#[derive(Clone)]
-pub struct ImmutableSetContractFeeParams {
-	pub(crate) proxy: Proxy,
-}
-
-impl ImmutableSetContractFeeParams {
-    pub fn hname(&self) -> ScImmutableHname {
-		ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    pub fn wasm_max_call_depth(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_WASM_MAX_CALL_DEPTH))
 	}
 
-    pub fn owner_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
+    pub fn wasm_max_memory_pages(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_WASM_MAX_MEMORY_PAGES))
 	}
 
-    pub fn validator_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    pub fn wasm_max_table_size(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_WASM_MAX_TABLE_SIZE))
 	}
 }
 
 #[derive(Clone)]
-pub struct MutableSetContractFeeParams {
+pub struct ImmutableSetFeePolicyParams {
 	pub(crate) proxy: Proxy,
 }
 
-impl MutableSetContractFeeParams {
-    pub fn hname(&self) -> ScMutableHname {
-		ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+impl ImmutableSetFeePolicyParams {
+    pub fn fee_color(&self) -> ScImmutableColor {
+		ScImmutableColor::new(self.proxy.root(PARAM_FEE_COLOR))
+	}
+
+    pub fn gas_price(&self) -> ScImmutableInt64 {
+		ScImmutableInt64::new(self.proxy.root(PARAM_GAS_PRICE))
 	}
 
-    pub fn owner_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
+    pub fn min_fee(&self) -> ScImmutableInt64 {
+		ScImmutableInt64::new(self.proxy.root(PARAM_MIN_FEE))
 	}
 
-    pub fn validator_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    pub fn validator_fee_share(&self) -> ScImmutableInt64 {
+		ScImmutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE_SHARE))
 	}
 }
 
 #[derive(Clone)]
-pub struct ImmutableSetDefaultFeeParams {
+pub struct MutableSetFeePolicyParams {
 	pub(crate) proxy: Proxy,
 }
 
-impl ImmutableSetDefaultFeeParams {
-    pub fn owner_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
+impl MutableSetFeePolicyParams {
+    pub fn fee_color(&self) -> ScMutableColor {
+		ScMutableColor::new(self.proxy.root(PARAM_FEE_COLOR))
 	}
 
-    pub fn validator_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    pub fn gas_price(&self) -> ScMutableInt64 {
+		ScMutableInt64::new(self.proxy.root(PARAM_GAS_PRICE))
 	}
-}
-
-#[derive(Clone)]
-pub struct MutableSetDefaultFeeParams {
-	pub(crate) proxy: Proxy,
-}
 
-impl MutableSetDefaultFeeParams {
-    pub fn owner_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_OWNER_FEE))
+    pub fn min_fee(&self) -> ScMutableInt64 {
+		ScMutableInt64::new(self.proxy.root(PARAM_MIN_FEE))
 	}
 
-    pub fn validator_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    pub fn validator_fee_share(&self) -> ScMutableInt64 {
+		ScMutableInt64::new(self.proxy.root(PARAM_VALIDATOR_FEE_SHARE))
 	}
 }
 
 #[derive(Clone)]
-pub struct ImmutableGetFeeInfoParams {
+pub struct ImmutableEstimateFeeParams {
 	pub(crate) proxy: Proxy,
 }
 
-impl ImmutableGetFeeInfoParams {
-    pub fn hname(&self) -> ScImmutableHname {
-		ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+impl ImmutableEstimateFeeParams {
+    pub fn gas(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(PARAM_GAS))
 	}
 }
 
 #[derive(Clone)]
-pub struct MutableGetFeeInfoParams {
+pub struct MutableEstimateFeeParams {
 	pub(crate) proxy: Proxy,
 }
 
-impl MutableGetFeeInfoParams {
-    pub fn hname(&self) -> ScMutableHname {
-		ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+impl MutableEstimateFeeParams {
+    pub fn gas(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(PARAM_GAS))
 	}
 }
@@ -11,6 +11,44 @@
 use crate::coregovernance::*;
 use crate::*;
 
+#[derive(Clone)]
+pub struct ImmutableEstimateFeeResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableEstimateFeeResults {
+    pub fn fee(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(RESULT_FEE))
+	}
+
+    pub fn fee_color(&self) -> ScImmutableColor {
+		ScImmutableColor::new(self.proxy.root(RESULT_FEE_COLOR))
+	}
+
+    pub fn gas(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(RESULT_GAS))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableEstimateFeeResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableEstimateFeeResults {
+    pub fn fee(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(RESULT_FEE))
+	}
+
+    pub fn fee_color(&self) -> ScMutableColor {
+		ScMutableColor::new(self.proxy.root(RESULT_FEE_COLOR))
+	}
+
+    pub fn gas(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(RESULT_GAS))
+	}
+}
+
 #[derive(Clone)]
 pub struct ArrayOfImmutableBytes {
 	pub(crate) proxy: Proxy,
@@ -77,20 +115,20 @@ pub struct ImmutableGetChainInfoResults {
 }
 
 impl ImmutableGetChainInfoResults {
-    pub fn chain_id(&self) -> ScImmutableChainID {
-		ScImmutableChainID::new(self.proxy.root(RESULT_CHAIN_ID))
+    pub fn account_history(&self) -> ScImmutableBool {
+		ScImmutableBool::new(self.proxy.root(RESULT_ACCOUNT_HISTORY))
 	}
 
-    pub fn chain_owner_id(&self) -> ScImmutableAgentID {
-		ScImmutableAgentID::new(self.proxy.root(RESULT_CHAIN_OWNER_ID))
+    pub fn block_keep_amount(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_BLOCK_KEEP_AMOUNT))
 	}
 
-    pub fn default_owner_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(RESULT_DEFAULT_OWNER_FEE))
+    pub fn chain_id(&self) -> ScImmutableChainID {
+		ScImmutableChainID::new(self.proxy.root(RESULT_CHAIN_ID))
 	}
 
-    pub fn default_validator_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(RESULT_DEFAULT_VALIDATOR_FEE))
+    pub fn chain_owner_id(&self) -> ScImmutableAgentID {
+		ScImmutableAgentID::new(self.proxy.root(RESULT_CHAIN_OWNER_ID))
 	}
 
     pub fn description(&self) -> ScImmutableString {
@@ -112,6 +150,18 @@ impl ImmutableGetChainInfoResults {
     pub fn max_events_per_req(&self) -> ScImmutableInt16 {
 		ScImmutableInt16::new(self.proxy.root(RESULT_MAX_EVENTS_PER_REQ))
 	}
+
+    pub fn wasm_max_call_depth(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_WASM_MAX_CALL_DEPTH))
+	}
+
+    pub fn wasm_max_memory_pages(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_WASM_MAX_MEMORY_PAGES))
+	}
+
+    pub fn wasm_max_table_size(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_WASM_MAX_TABLE_SIZE))
+	}
 }
 
 #[derive(Clone)]
@@ -120,20 +170,20 @@ pub struct MutableGetChainInfoResults {
 }
 
 impl MutableGetChainInfoResults {
-    pub fn chain_id(&self) -> ScMutableChainID {
-		ScMutableChainID::new(self.proxy.root(RESULT_CHAIN_ID))
+    pub fn account_history(&self) -> ScMutableBool {
+		ScMutableBool::new(self.proxy.root(RESULT_ACCOUNT_HISTORY))
 	}
 
-    pub fn chain_owner_id(&self) -> ScMutableAgentID {
-		ScMutableAgentID::new(self.proxy.root(RESULT_CHAIN_OWNER_ID))
+    pub fn block_keep_amount(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_BLOCK_KEEP_AMOUNT))
 	}
 
-    pub fn default_owner_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(RESULT_DEFAULT_OWNER_FEE))
+    pub fn chain_id(&self) -> ScMutableChainID {
+		ScMutableChainID::new(self.proxy.root(RESULT_CHAIN_ID))
 	}
 
-    pub fn default_validator_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(RESULT_DEFAULT_VALIDATOR_FEE))
+    pub fn chain_owner_id(&self) -> ScMutableAgentID {
+		ScMutableAgentID::new(self.proxy.root(RESULT_CHAIN_OWNER_ID))
 	}
 
     pub fn description(&self) -> ScMutableString {
@@ -155,43 +205,63 @@ impl MutableGetChainInfoResults {
     pub fn max_events_per_req(&self) -> ScMutableInt16 {
 		ScMutableInt16::new(self.proxy.root(RESULT_MAX_EVENTS_PER_REQ))
 	}
+
+    pub fn wasm_max_call_depth(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_WASM_MAX_CALL_DEPTH))
+	}
+
+    pub fn wasm_max_memory_pages(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_WASM_MAX_MEMORY_PAGES))
+	}
+
+    pub fn wasm_max_table_size(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_WASM_MAX_TABLE_SIZE))
+	}
 }
 
 #[derive(Clone)]
-pub struct ImmutableGetFeeInfoResults {
+pub struct ImmutableGetFeePolicyResults {
 	pub(crate) proxy: Proxy,
 }
 
-impl ImmutableGetFeeInfoResults {
+impl ImmutableGetFeePolicyResults {
     pub fn fee_color(&self) -> ScImmutableColor {
 		ScImmutableColor::new(self.proxy.root(RESULT_FEE_COLOR))
 	}
 
-    pub fn owner_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(RESULT_OWNER_FEE))
+    pub fn gas_price(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(RESULT_GAS_PRICE))
 	}
 
-    pub fn validator_fee(&self) -> ScImmutableInt64 {
-		ScImmutableInt64::new(self.proxy.root(RESULT_VALIDATOR_FEE))
+    pub fn min_fee(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(RESULT_MIN_FEE))
+	}
+
+    pub fn validator_fee_share(&self) -> ScImmutableUint16 {
+		ScImmutableUint16::new(self.proxy.root(RESULT_VALIDATOR_FEE_SHARE))
 	}
 }
 
 #[derive(Clone)]
-pub struct MutableGetFeeInfoResults {
+pub struct MutableGetFeePolicyResults {
 	pub(crate) proxy: Proxy,
 }
 
-impl MutableGetFeeInfoResults {
+impl MutableGetFeePolicyResults {
     pub fn fee_color(&self) -> ScMutableColor {
 		ScMutableColor::new(self.proxy.root(RESULT_FEE_COLOR))
 	}
 
-    pub fn owner_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(RESULT_OWNER_FEE))
+    pub fn gas_price(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(RESULT_GAS_PRICE))
+	}
+
+    pub fn min_fee(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(RESULT_MIN_FEE))
 	}
 
-    pub fn validator_fee(&self) -> ScMutableInt64 {
-		ScMutableInt64::new(self.proxy.root(RESULT_VALIDATOR_FEE))
+    pub fn validator_fee_share(&self) -> ScMutableUint16 {
+		ScMutableUint16::new(self.proxy.root(RESULT_VALIDATOR_FEE_SHARE))
 	}
 }
 
@@ -26,11 +26,13 @@ pub(crate) const RESULT_CONTRACT_REGISTRY : &str = "r";
 pub(crate) const FUNC_DEPLOY_CONTRACT          : &str = "deployContract";
 pub(crate) const FUNC_GRANT_DEPLOY_PERMISSION  : &str = "grantDeployPermission";
 pub(crate) const FUNC_REVOKE_DEPLOY_PERMISSION : &str = "revokeDeployPermission";
+pub(crate) const FUNC_UPGRADE_CONTRACT         : &str = "upgradeContract";
 pub(crate) const VIEW_FIND_CONTRACT            : &str = "findContract";
 pub(crate) const VIEW_GET_CONTRACT_RECORDS     : &str = "getContractRecords";
 
 pub(crate) const HFUNC_DEPLOY_CONTRACT          : ScHname = ScHname(0x28232c27);
 pub(crate) const HFUNC_GRANT_DEPLOY_PERMISSION  : ScHname = ScHname(0xf440263a);
 pub(crate) const HFUNC_REVOKE_DEPLOY_PERMISSION : ScHname = ScHname(0x850744f1);
+pub(crate) const HFUNC_UPGRADE_CONTRACT         : ScHname = ScHname(0x00d30d5c);
 pub(crate) const HVIEW_FIND_CONTRACT            : ScHname = ScHname(0xc145ca00);
 pub(crate) const HVIEW_GET_CONTRACT_RECORDS     : ScHname = ScHname(0x078b3ef3);
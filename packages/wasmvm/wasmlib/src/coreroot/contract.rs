@@ -25,6 +25,11 @@ pub struct RevokeDeployPermissionCall {
 	pub params: MutableRevokeDeployPermissionParams,
 }
 
+pub struct UpgradeContractCall {
+	pub func: ScFunc,
+	pub params: MutableUpgradeContractParams,
+}
+
 pub struct FindContractCall {
 	pub func: ScView,
 	pub params: MutableFindContractParams,
@@ -67,6 +72,15 @@ impl ScFuncs {
         f
     }
 
+    pub fn upgrade_contract(_ctx: &dyn ScFuncCallContext) -> UpgradeContractCall {
+        let mut f = UpgradeContractCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_UPGRADE_CONTRACT),
+            params: MutableUpgradeContractParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     pub fn find_contract(_ctx: &dyn ScViewCallContext) -> FindContractCall {
         let mut f = FindContractCall {
             func: ScView::new(HSC_NAME, HVIEW_FIND_CONTRACT),
@@ -93,6 +93,44 @@ impl MutableRevokeDeployPermissionParams {
 	}
 }
 
+#[derive(Clone)]
+pub struct ImmutableUpgradeContractParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableUpgradeContractParams {
+    pub fn description(&self) -> ScImmutableString {
+		ScImmutableString::new(self.proxy.root(PARAM_DESCRIPTION))
+	}
+
+    pub fn hname(&self) -> ScImmutableHname {
+		ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+	}
+
+    pub fn program_hash(&self) -> ScImmutableHash {
+		ScImmutableHash::new(self.proxy.root(PARAM_PROGRAM_HASH))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableUpgradeContractParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableUpgradeContractParams {
+    pub fn description(&self) -> ScMutableString {
+		ScMutableString::new(self.proxy.root(PARAM_DESCRIPTION))
+	}
+
+    pub fn hname(&self) -> ScMutableHname {
+		ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+	}
+
+    pub fn program_hash(&self) -> ScMutableHash {
+		ScMutableHash::new(self.proxy.root(PARAM_PROGRAM_HASH))
+	}
+}
+
 #[derive(Clone)]
 pub struct ImmutableFindContractParams {
 	pub(crate) proxy: Proxy,
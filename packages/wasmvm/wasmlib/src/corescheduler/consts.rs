@@ -0,0 +1,37 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+#![allow(dead_code)]
+
+use crate::*;
+
+pub const SC_NAME        : &str = "scheduler";
+pub const SC_DESCRIPTION : &str = "Core scheduler contract";
+pub const HSC_NAME       : ScHname = ScHname(0x9c305966);
+
+pub(crate) const PARAM_ARGS        : &str = "a";
+pub(crate) const PARAM_CALL_ID     : &str = "i";
+pub(crate) const PARAM_CONTRACT    : &str = "h";
+pub(crate) const PARAM_COUNT       : &str = "c";
+pub(crate) const PARAM_DELAY       : &str = "d";
+pub(crate) const PARAM_ENTRY_POINT : &str = "e";
+pub(crate) const PARAM_GAS_BUDGET  : &str = "g";
+pub(crate) const PARAM_INTERVAL    : &str = "v";
+
+pub(crate) const RESULT_CALL_ID         : &str = "i";
+pub(crate) const RESULT_SCHEDULED_CALL  : &str = "s";
+pub(crate) const RESULT_SCHEDULED_CALLS : &str = "this";
+
+pub(crate) const FUNC_CANCEL              : &str = "cancel";
+pub(crate) const FUNC_SCHEDULE            : &str = "schedule";
+pub(crate) const VIEW_GET_SCHEDULED_CALL  : &str = "getScheduledCall";
+pub(crate) const VIEW_GET_SCHEDULED_CALLS : &str = "getScheduledCalls";
+
+pub(crate) const HFUNC_CANCEL              : ScHname = ScHname(0xa7e99697);
+pub(crate) const HFUNC_SCHEDULE            : ScHname = ScHname(0x9631b89d);
+pub(crate) const HVIEW_GET_SCHEDULED_CALL  : ScHname = ScHname(0x2328db9b);
+pub(crate) const HVIEW_GET_SCHEDULED_CALLS : ScHname = ScHname(0x0e844101);
@@ -0,0 +1,81 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+#![allow(dead_code)]
+
+use crate::corescheduler::*;
+use crate::*;
+
+pub struct CancelCall {
+	pub func: ScFunc,
+	pub params: MutableCancelParams,
+}
+
+pub struct ScheduleCall {
+	pub func: ScFunc,
+	pub params: MutableScheduleParams,
+	pub results: ImmutableScheduleResults,
+}
+
+pub struct GetScheduledCallCall {
+	pub func: ScView,
+	pub params: MutableGetScheduledCallParams,
+	pub results: ImmutableGetScheduledCallResults,
+}
+
+pub struct GetScheduledCallsCall {
+	pub func: ScView,
+	pub params: MutableGetScheduledCallsParams,
+	pub results: ImmutableGetScheduledCallsResults,
+}
+
+pub struct ScFuncs {
+}
+
+impl ScFuncs {
+    pub fn cancel(_ctx: &dyn ScFuncCallContext) -> CancelCall {
+        let mut f = CancelCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_CANCEL),
+            params: MutableCancelParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
+    pub fn schedule(_ctx: &dyn ScFuncCallContext) -> ScheduleCall {
+        let mut f = ScheduleCall {
+            func: ScFunc::new(HSC_NAME, HFUNC_SCHEDULE),
+            params: MutableScheduleParams { proxy: Proxy::nil() },
+            results: ImmutableScheduleResults { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        ScFunc::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
+    pub fn get_scheduled_call(_ctx: &dyn ScViewCallContext) -> GetScheduledCallCall {
+        let mut f = GetScheduledCallCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_SCHEDULED_CALL),
+            params: MutableGetScheduledCallParams { proxy: Proxy::nil() },
+            results: ImmutableGetScheduledCallResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
+    pub fn get_scheduled_calls(_ctx: &dyn ScViewCallContext) -> GetScheduledCallsCall {
+        let mut f = GetScheduledCallsCall {
+            func: ScView::new(HSC_NAME, HVIEW_GET_SCHEDULED_CALLS),
+            params: MutableGetScheduledCallsParams { proxy: Proxy::nil() },
+            results: ImmutableGetScheduledCallsResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+}
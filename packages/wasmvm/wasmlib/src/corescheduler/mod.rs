@@ -0,0 +1,18 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+#![allow(unused_imports)]
+
+pub use consts::*;
+pub use contract::*;
+pub use params::*;
+pub use results::*;
+
+pub mod consts;
+pub mod contract;
+pub mod params;
+pub mod results;
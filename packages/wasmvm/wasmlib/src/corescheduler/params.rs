@@ -0,0 +1,140 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+#![allow(dead_code)]
+#![allow(unused_imports)]
+
+use crate::corescheduler::*;
+use crate::*;
+
+#[derive(Clone)]
+pub struct ImmutableCancelParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableCancelParams {
+    pub fn call_id(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableCancelParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableCancelParams {
+    pub fn call_id(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct ImmutableScheduleParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableScheduleParams {
+    pub fn args(&self) -> ScImmutableBytes {
+		ScImmutableBytes::new(self.proxy.root(PARAM_ARGS))
+	}
+
+    pub fn count(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_COUNT))
+	}
+
+    pub fn delay(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_DELAY))
+	}
+
+    pub fn entry_point(&self) -> ScImmutableHname {
+		ScImmutableHname::new(self.proxy.root(PARAM_ENTRY_POINT))
+	}
+
+    pub fn gas_budget(&self) -> ScImmutableUint64 {
+		ScImmutableUint64::new(self.proxy.root(PARAM_GAS_BUDGET))
+	}
+
+    pub fn interval(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_INTERVAL))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableScheduleParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableScheduleParams {
+    pub fn args(&self) -> ScMutableBytes {
+		ScMutableBytes::new(self.proxy.root(PARAM_ARGS))
+	}
+
+    pub fn count(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_COUNT))
+	}
+
+    pub fn delay(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_DELAY))
+	}
+
+    pub fn entry_point(&self) -> ScMutableHname {
+		ScMutableHname::new(self.proxy.root(PARAM_ENTRY_POINT))
+	}
+
+    pub fn gas_budget(&self) -> ScMutableUint64 {
+		ScMutableUint64::new(self.proxy.root(PARAM_GAS_BUDGET))
+	}
+
+    pub fn interval(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_INTERVAL))
+	}
+}
+
+#[derive(Clone)]
+pub struct ImmutableGetScheduledCallParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetScheduledCallParams {
+    pub fn call_id(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(PARAM_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledCallParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetScheduledCallParams {
+    pub fn call_id(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(PARAM_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct ImmutableGetScheduledCallsParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetScheduledCallsParams {
+    pub fn contract(&self) -> ScImmutableHname {
+		ScImmutableHname::new(self.proxy.root(PARAM_CONTRACT))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledCallsParams {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetScheduledCallsParams {
+    pub fn contract(&self) -> ScMutableHname {
+		ScMutableHname::new(self.proxy.root(PARAM_CONTRACT))
+	}
+}
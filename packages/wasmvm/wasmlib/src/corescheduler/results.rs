@@ -0,0 +1,104 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+#![allow(dead_code)]
+#![allow(unused_imports)]
+
+use crate::corescheduler::*;
+use crate::*;
+
+#[derive(Clone)]
+pub struct ImmutableScheduleResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableScheduleResults {
+    pub fn call_id(&self) -> ScImmutableUint32 {
+		ScImmutableUint32::new(self.proxy.root(RESULT_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableScheduleResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableScheduleResults {
+    pub fn call_id(&self) -> ScMutableUint32 {
+		ScMutableUint32::new(self.proxy.root(RESULT_CALL_ID))
+	}
+}
+
+#[derive(Clone)]
+pub struct ImmutableGetScheduledCallResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetScheduledCallResults {
+    pub fn scheduled_call(&self) -> ScImmutableBytes {
+		ScImmutableBytes::new(self.proxy.root(RESULT_SCHEDULED_CALL))
+	}
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledCallResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetScheduledCallResults {
+    pub fn scheduled_call(&self) -> ScMutableBytes {
+		ScMutableBytes::new(self.proxy.root(RESULT_SCHEDULED_CALL))
+	}
+}
+
+#[derive(Clone)]
+pub struct MapUint32ToImmutableBytes {
+	pub(crate) proxy: Proxy,
+}
+
+impl MapUint32ToImmutableBytes {
+    pub fn get_bytes(&self, key: u32) -> ScImmutableBytes {
+        ScImmutableBytes::new(self.proxy.key(&uint32_to_bytes(key)))
+    }
+}
+
+#[derive(Clone)]
+pub struct ImmutableGetScheduledCallsResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetScheduledCallsResults {
+    pub fn scheduled_calls(&self) -> MapUint32ToImmutableBytes {
+		MapUint32ToImmutableBytes { proxy: self.proxy.clone() }
+	}
+}
+
+#[derive(Clone)]
+pub struct MapUint32ToMutableBytes {
+	pub(crate) proxy: Proxy,
+}
+
+impl MapUint32ToMutableBytes {
+    pub fn clear(&self) {
+        self.proxy.clear_map();
+    }
+
+    pub fn get_bytes(&self, key: u32) -> ScMutableBytes {
+        ScMutableBytes::new(self.proxy.key(&uint32_to_bytes(key)))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledCallsResults {
+	pub(crate) proxy: Proxy,
+}
+
+impl MutableGetScheduledCallsResults {
+    pub fn scheduled_calls(&self) -> MapUint32ToMutableBytes {
+		MapUint32ToMutableBytes { proxy: self.proxy.clone() }
+	}
+}
@@ -21,6 +21,7 @@ pub mod coreblob;
 pub mod coreblocklog;
 pub mod coregovernance;
 pub mod coreroot;
+pub mod corescheduler;
 pub mod dict;
 pub mod events;
 pub mod exports;
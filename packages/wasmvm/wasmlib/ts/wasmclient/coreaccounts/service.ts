@@ -8,12 +8,46 @@
 import * as wasmclient from "wasmclient"
 
 const ArgAgentID = "a";
+const ArgAmount = "m";
+const ArgColor = "c";
+const ArgLimit = "l";
+const ArgOffset = "o";
+const ArgOwner = "ow";
+const ArgSpender = "sp";
+const ArgTarget = "t";
 const ArgWithdrawAmount = "m";
 const ArgWithdrawColor = "c";
 
 const ResAccountNonce = "n";
 const ResAgents = "this";
+const ResAllowance = "this";
 const ResBalances = "this";
+const ResEntries = "he";
+const ResLength = "hl";
+
+///////////////////////////// approve /////////////////////////////
+
+export class ApproveFunc extends wasmclient.ClientFunc {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public amount(v: wasmclient.Int64): void {
+		this.args.set(ArgAmount, this.args.fromInt64(v));
+	}
+	
+	public color(v: wasmclient.Color): void {
+		this.args.set(ArgColor, this.args.fromColor(v));
+	}
+	
+	public spender(v: wasmclient.AgentID): void {
+		this.args.set(ArgSpender, this.args.fromAgentID(v));
+	}
+	
+	public async post(): Promise<wasmclient.RequestID> {
+		this.args.mandatory(ArgAmount);
+		this.args.mandatory(ArgSpender);
+		return await super.post(0xa0661268, this.args);
+	}
+}
 
 ///////////////////////////// deposit /////////////////////////////
 
@@ -47,6 +81,53 @@ export class HarvestFunc extends wasmclient.ClientFunc {
 	}
 }
 
+///////////////////////////// revoke /////////////////////////////
+
+export class RevokeFunc extends wasmclient.ClientFunc {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public color(v: wasmclient.Color): void {
+		this.args.set(ArgColor, this.args.fromColor(v));
+	}
+	
+	public spender(v: wasmclient.AgentID): void {
+		this.args.set(ArgSpender, this.args.fromAgentID(v));
+	}
+	
+	public async post(): Promise<wasmclient.RequestID> {
+		this.args.mandatory(ArgSpender);
+		return await super.post(0x128d530a, this.args);
+	}
+}
+
+///////////////////////////// transferFrom /////////////////////////////
+
+export class TransferFromFunc extends wasmclient.ClientFunc {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public amount(v: wasmclient.Int64): void {
+		this.args.set(ArgAmount, this.args.fromInt64(v));
+	}
+	
+	public color(v: wasmclient.Color): void {
+		this.args.set(ArgColor, this.args.fromColor(v));
+	}
+	
+	public owner(v: wasmclient.AgentID): void {
+		this.args.set(ArgOwner, this.args.fromAgentID(v));
+	}
+	
+	public target(v: wasmclient.AgentID): void {
+		this.args.set(ArgTarget, this.args.fromAgentID(v));
+	}
+	
+	public async post(): Promise<wasmclient.RequestID> {
+		this.args.mandatory(ArgAmount);
+		this.args.mandatory(ArgOwner);
+		return await super.post(0xd5e0a602, this.args);
+	}
+}
+
 ///////////////////////////// withdraw /////////////////////////////
 
 export class WithdrawFunc extends wasmclient.ClientFunc {
@@ -106,6 +187,42 @@ export class BalanceResults extends wasmclient.Results {
 	}
 }
 
+///////////////////////////// getAccountHistory /////////////////////////////
+
+export class GetAccountHistoryView extends wasmclient.ClientView {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public agentID(v: wasmclient.AgentID): void {
+		this.args.set(ArgAgentID, this.args.fromAgentID(v));
+	}
+	
+	public limit(v: wasmclient.Uint32): void {
+		this.args.set(ArgLimit, this.args.fromUint32(v));
+	}
+	
+	public offset(v: wasmclient.Uint32): void {
+		this.args.set(ArgOffset, this.args.fromUint32(v));
+	}
+
+	public async call(): Promise<GetAccountHistoryResults> {
+		this.args.mandatory(ArgAgentID);
+		const res = new GetAccountHistoryResults();
+		await this.callView("getAccountHistory", this.args, res);
+		return res;
+	}
+}
+
+export class GetAccountHistoryResults extends wasmclient.Results {
+
+	entries(): wasmclient.Bytes {
+		return this.toBytes(this.get(ResEntries));
+	}
+
+	length(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResLength));
+	}
+}
+
 ///////////////////////////// getAccountNonce /////////////////////////////
 
 export class GetAccountNonceView extends wasmclient.ClientView {
@@ -130,6 +247,39 @@ export class GetAccountNonceResults extends wasmclient.Results {
 	}
 }
 
+///////////////////////////// getAllowance /////////////////////////////
+
+export class GetAllowanceView extends wasmclient.ClientView {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public owner(v: wasmclient.AgentID): void {
+		this.args.set(ArgOwner, this.args.fromAgentID(v));
+	}
+	
+	public spender(v: wasmclient.AgentID): void {
+		this.args.set(ArgSpender, this.args.fromAgentID(v));
+	}
+
+	public async call(): Promise<GetAllowanceResults> {
+		this.args.mandatory(ArgOwner);
+		this.args.mandatory(ArgSpender);
+		const res = new GetAllowanceResults();
+		await this.callView("getAllowance", this.args, res);
+		return res;
+	}
+}
+
+export class GetAllowanceResults extends wasmclient.Results {
+
+	allowance(): Map<wasmclient.Color, wasmclient.Int64> {
+		const res = new Map<wasmclient.Color, wasmclient.Int64>();
+		this.forEach((key, val) => {
+			res.set(this.toColor(key), this.toInt64(val));
+		});
+		return res;
+	}
+}
+
 ///////////////////////////// totalAssets /////////////////////////////
 
 export class TotalAssetsView extends wasmclient.ClientView {
@@ -160,6 +310,10 @@ export class CoreAccountsService extends wasmclient.Service {
 		super(cl, 0x3c4b5e02);
 	}
 
+	public approve(): ApproveFunc {
+		return new ApproveFunc(this);
+	}
+
 	public deposit(): DepositFunc {
 		return new DepositFunc(this);
 	}
@@ -168,6 +322,14 @@ export class CoreAccountsService extends wasmclient.Service {
 		return new HarvestFunc(this);
 	}
 
+	public revoke(): RevokeFunc {
+		return new RevokeFunc(this);
+	}
+
+	public transferFrom(): TransferFromFunc {
+		return new TransferFromFunc(this);
+	}
+
 	public withdraw(): WithdrawFunc {
 		return new WithdrawFunc(this);
 	}
@@ -180,10 +342,18 @@ export class CoreAccountsService extends wasmclient.Service {
 		return new BalanceView(this);
 	}
 
+	public getAccountHistory(): GetAccountHistoryView {
+		return new GetAccountHistoryView(this);
+	}
+
 	public getAccountNonce(): GetAccountNonceView {
 		return new GetAccountNonceView(this);
 	}
 
+	public getAllowance(): GetAllowanceView {
+		return new GetAllowanceView(this);
+	}
+
 	public totalAssets(): TotalAssetsView {
 		return new TotalAssetsView(this);
 	}
@@ -17,6 +17,8 @@ const ResBlockIndex = "n";
 const ResBlockInfo = "i";
 const ResEvent = "e";
 const ResGoverningAddress = "g";
+const ResPrunedBytes = "pb";
+const ResPrunedUntil = "pu";
 const ResRequestID = "u";
 const ResRequestIndex = "r";
 const ResRequestProcessed = "p";
@@ -175,6 +177,28 @@ export class GetLatestBlockInfoResults extends wasmclient.Results {
 	}
 }
 
+///////////////////////////// getPruningInfo /////////////////////////////
+
+export class GetPruningInfoView extends wasmclient.ClientView {
+
+	public async call(): Promise<GetPruningInfoResults> {
+		const res = new GetPruningInfoResults();
+		await this.callView("getPruningInfo", null, res);
+		return res;
+	}
+}
+
+export class GetPruningInfoResults extends wasmclient.Results {
+
+	prunedBytes(): wasmclient.Uint64 {
+		return this.toUint64(this.get(ResPrunedBytes));
+	}
+
+	prunedUntil(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResPrunedUntil));
+	}
+}
+
 ///////////////////////////// getRequestIDsForBlock /////////////////////////////
 
 export class GetRequestIDsForBlockView extends wasmclient.ClientView {
@@ -311,6 +335,10 @@ export class CoreBlockLogService extends wasmclient.Service {
 		return new GetLatestBlockInfoView(this);
 	}
 
+	public getPruningInfo(): GetPruningInfoView {
+		return new GetPruningInfoView(this);
+	}
+
 	public getRequestIDsForBlock(): GetRequestIDsForBlockView {
 		return new GetRequestIDsForBlockView(this);
 	}
@@ -7,28 +7,40 @@
 
 import * as wasmclient from "wasmclient"
 
+const ArgAccountHistory = "ah";
+const ArgBlockKeepAmount = "bk";
 const ArgChainOwner = "oi";
 const ArgFeeColor = "fc";
-const ArgHname = "hn";
+const ArgGas = "g";
+const ArgGasPrice = "gp";
 const ArgMaxBlobSize = "bs";
 const ArgMaxEventSize = "es";
 const ArgMaxEventsPerReq = "ne";
-const ArgOwnerFee = "of";
+const ArgMinFee = "mf";
 const ArgStateControllerAddress = "S";
-const ArgValidatorFee = "vf";
+const ArgValidatorFeeShare = "vs";
+const ArgWasmMaxCallDepth = "wd";
+const ArgWasmMaxMemoryPages = "wm";
+const ArgWasmMaxTableSize = "wt";
 
+const ResAccountHistory = "ah";
 const ResAllowedStateControllerAddresses = "a";
+const ResBlockKeepAmount = "bk";
 const ResChainID = "c";
 const ResChainOwnerID = "o";
-const ResDefaultOwnerFee = "do";
-const ResDefaultValidatorFee = "dv";
 const ResDescription = "d";
+const ResFee = "fe";
 const ResFeeColor = "f";
+const ResGas = "g";
+const ResGasPrice = "gp";
 const ResMaxBlobSize = "mb";
 const ResMaxEventSize = "me";
 const ResMaxEventsPerReq = "mr";
-const ResOwnerFee = "of";
-const ResValidatorFee = "vf";
+const ResMinFee = "mf";
+const ResValidatorFeeShare = "vs";
+const ResWasmMaxCallDepth = "wd";
+const ResWasmMaxMemoryPages = "wm";
+const ResWasmMaxTableSize = "wt";
 
 ///////////////////////////// addAllowedStateControllerAddress /////////////////////////////
 
@@ -113,6 +125,14 @@ export class RotateStateControllerFunc extends wasmclient.ClientFunc {
 export class SetChainInfoFunc extends wasmclient.ClientFunc {
 	private args: wasmclient.Arguments = new wasmclient.Arguments();
 	
+	public accountHistory(v: boolean): void {
+		this.args.set(ArgAccountHistory, this.args.fromBool(v));
+	}
+	
+	public blockKeepAmount(v: wasmclient.Uint32): void {
+		this.args.set(ArgBlockKeepAmount, this.args.fromUint32(v));
+	}
+	
 	public maxBlobSize(v: wasmclient.Int32): void {
 		this.args.set(ArgMaxBlobSize, this.args.fromInt32(v));
 	}
@@ -125,12 +145,16 @@ export class SetChainInfoFunc extends wasmclient.ClientFunc {
 		this.args.set(ArgMaxEventsPerReq, this.args.fromInt16(v));
 	}
 	
-	public ownerFee(v: wasmclient.Int64): void {
-		this.args.set(ArgOwnerFee, this.args.fromInt64(v));
+	public wasmMaxCallDepth(v: wasmclient.Uint32): void {
+		this.args.set(ArgWasmMaxCallDepth, this.args.fromUint32(v));
+	}
+	
+	public wasmMaxMemoryPages(v: wasmclient.Uint32): void {
+		this.args.set(ArgWasmMaxMemoryPages, this.args.fromUint32(v));
 	}
 	
-	public validatorFee(v: wasmclient.Int64): void {
-		this.args.set(ArgValidatorFee, this.args.fromInt64(v));
+	public wasmMaxTableSize(v: wasmclient.Uint32): void {
+		this.args.set(ArgWasmMaxTableSize, this.args.fromUint32(v));
 	}
 	
 	public async post(): Promise<wasmclient.RequestID> {
@@ -138,44 +162,60 @@ export class SetChainInfoFunc extends wasmclient.ClientFunc {
 	}
 }
 
-///////////////////////////// setContractFee /////////////////////////////
+///////////////////////////// setFeePolicy /////////////////////////////
 
-export class SetContractFeeFunc extends wasmclient.ClientFunc {
+export class SetFeePolicyFunc extends wasmclient.ClientFunc {
 	private args: wasmclient.Arguments = new wasmclient.Arguments();
 	
-	public hname(v: wasmclient.Hname): void {
-		this.args.set(ArgHname, this.args.fromHname(v));
+	public feeColor(v: wasmclient.Color): void {
+		this.args.set(ArgFeeColor, this.args.fromColor(v));
 	}
 	
-	public ownerFee(v: wasmclient.Int64): void {
-		this.args.set(ArgOwnerFee, this.args.fromInt64(v));
+	public gasPrice(v: wasmclient.Int64): void {
+		this.args.set(ArgGasPrice, this.args.fromInt64(v));
 	}
 	
-	public validatorFee(v: wasmclient.Int64): void {
-		this.args.set(ArgValidatorFee, this.args.fromInt64(v));
+	public minFee(v: wasmclient.Int64): void {
+		this.args.set(ArgMinFee, this.args.fromInt64(v));
+	}
+	
+	public validatorFeeShare(v: wasmclient.Int64): void {
+		this.args.set(ArgValidatorFeeShare, this.args.fromInt64(v));
 	}
 	
 	public async post(): Promise<wasmclient.RequestID> {
-		this.args.mandatory(ArgHname);
-		return await super.post(0x8421a42b, this.args);
+		return await super.post(0x5b791c9f, this.args);
 	}
 }
 
-///////////////////////////// setDefaultFee /////////////////////////////
+///////////////////////////// estimateFee /////////////////////////////
 
-export class SetDefaultFeeFunc extends wasmclient.ClientFunc {
+export class EstimateFeeView extends wasmclient.ClientView {
 	private args: wasmclient.Arguments = new wasmclient.Arguments();
 	
-	public ownerFee(v: wasmclient.Int64): void {
-		this.args.set(ArgOwnerFee, this.args.fromInt64(v));
+	public gas(v: wasmclient.Uint64): void {
+		this.args.set(ArgGas, this.args.fromUint64(v));
 	}
-	
-	public validatorFee(v: wasmclient.Int64): void {
-		this.args.set(ArgValidatorFee, this.args.fromInt64(v));
+
+	public async call(): Promise<EstimateFeeResults> {
+		const res = new EstimateFeeResults();
+		await this.callView("estimateFee", this.args, res);
+		return res;
 	}
-	
-	public async post(): Promise<wasmclient.RequestID> {
-		return await super.post(0x3310ecd0, this.args);
+}
+
+export class EstimateFeeResults extends wasmclient.Results {
+
+	fee(): wasmclient.Uint64 {
+		return this.toUint64(this.get(ResFee));
+	}
+
+	feeColor(): wasmclient.Color {
+		return this.toColor(this.get(ResFeeColor));
+	}
+
+	gas(): wasmclient.Uint64 {
+		return this.toUint64(this.get(ResGas));
 	}
 }
 
@@ -210,20 +250,20 @@ export class GetChainInfoView extends wasmclient.ClientView {
 
 export class GetChainInfoResults extends wasmclient.Results {
 
-	chainID(): wasmclient.ChainID {
-		return this.toChainID(this.get(ResChainID));
+	accountHistory(): boolean {
+		return this.toBool(this.get(ResAccountHistory));
 	}
 
-	chainOwnerID(): wasmclient.AgentID {
-		return this.toAgentID(this.get(ResChainOwnerID));
+	blockKeepAmount(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResBlockKeepAmount));
 	}
 
-	defaultOwnerFee(): wasmclient.Int64 {
-		return this.toInt64(this.get(ResDefaultOwnerFee));
+	chainID(): wasmclient.ChainID {
+		return this.toChainID(this.get(ResChainID));
 	}
 
-	defaultValidatorFee(): wasmclient.Int64 {
-		return this.toInt64(this.get(ResDefaultValidatorFee));
+	chainOwnerID(): wasmclient.AgentID {
+		return this.toAgentID(this.get(ResChainOwnerID));
 	}
 
 	description(): string {
@@ -245,37 +285,47 @@ export class GetChainInfoResults extends wasmclient.Results {
 	maxEventsPerReq(): wasmclient.Int16 {
 		return this.toInt16(this.get(ResMaxEventsPerReq));
 	}
-}
 
-///////////////////////////// getFeeInfo /////////////////////////////
+	wasmMaxCallDepth(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResWasmMaxCallDepth));
+	}
 
-export class GetFeeInfoView extends wasmclient.ClientView {
-	private args: wasmclient.Arguments = new wasmclient.Arguments();
-	
-	public hname(v: wasmclient.Hname): void {
-		this.args.set(ArgHname, this.args.fromHname(v));
+	wasmMaxMemoryPages(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResWasmMaxMemoryPages));
+	}
+
+	wasmMaxTableSize(): wasmclient.Uint32 {
+		return this.toUint32(this.get(ResWasmMaxTableSize));
 	}
+}
+
+///////////////////////////// getFeePolicy /////////////////////////////
+
+export class GetFeePolicyView extends wasmclient.ClientView {
 
-	public async call(): Promise<GetFeeInfoResults> {
-		this.args.mandatory(ArgHname);
-		const res = new GetFeeInfoResults();
-		await this.callView("getFeeInfo", this.args, res);
+	public async call(): Promise<GetFeePolicyResults> {
+		const res = new GetFeePolicyResults();
+		await this.callView("getFeePolicy", null, res);
 		return res;
 	}
 }
 
-export class GetFeeInfoResults extends wasmclient.Results {
+export class GetFeePolicyResults extends wasmclient.Results {
 
 	feeColor(): wasmclient.Color {
 		return this.toColor(this.get(ResFeeColor));
 	}
 
-	ownerFee(): wasmclient.Int64 {
-		return this.toInt64(this.get(ResOwnerFee));
+	gasPrice(): wasmclient.Uint64 {
+		return this.toUint64(this.get(ResGasPrice));
+	}
+
+	minFee(): wasmclient.Uint64 {
+		return this.toUint64(this.get(ResMinFee));
 	}
 
-	validatorFee(): wasmclient.Int64 {
-		return this.toInt64(this.get(ResValidatorFee));
+	validatorFeeShare(): wasmclient.Uint16 {
+		return this.toUint16(this.get(ResValidatorFeeShare));
 	}
 }
 
@@ -329,12 +379,12 @@ export class CoreGovernanceService extends wasmclient.Service {
 		return new SetChainInfoFunc(this);
 	}
 
-	public setContractFee(): SetContractFeeFunc {
-		return new SetContractFeeFunc(this);
+	public setFeePolicy(): SetFeePolicyFunc {
+		return new SetFeePolicyFunc(this);
 	}
 
-	public setDefaultFee(): SetDefaultFeeFunc {
-		return new SetDefaultFeeFunc(this);
+	public estimateFee(): EstimateFeeView {
+		return new EstimateFeeView(this);
 	}
 
 	public getAllowedStateControllerAddresses(): GetAllowedStateControllerAddressesView {
@@ -345,8 +395,8 @@ export class CoreGovernanceService extends wasmclient.Service {
 		return new GetChainInfoView(this);
 	}
 
-	public getFeeInfo(): GetFeeInfoView {
-		return new GetFeeInfoView(this);
+	public getFeePolicy(): GetFeePolicyView {
+		return new GetFeePolicyView(this);
 	}
 
 	public getMaxBlobSize(): GetMaxBlobSizeView {
@@ -71,6 +71,30 @@ export class RevokeDeployPermissionFunc extends wasmclient.ClientFunc {
 	}
 }
 
+///////////////////////////// upgradeContract /////////////////////////////
+
+export class UpgradeContractFunc extends wasmclient.ClientFunc {
+	private args: wasmclient.Arguments = new wasmclient.Arguments();
+	
+	public description(v: string): void {
+		this.args.set(ArgDescription, this.args.fromString(v));
+	}
+	
+	public hname(v: wasmclient.Hname): void {
+		this.args.set(ArgHname, this.args.fromHname(v));
+	}
+	
+	public programHash(v: wasmclient.Hash): void {
+		this.args.set(ArgProgramHash, this.args.fromHash(v));
+	}
+	
+	public async post(): Promise<wasmclient.RequestID> {
+		this.args.mandatory(ArgHname);
+		this.args.mandatory(ArgProgramHash);
+		return await super.post(0x00d30d5c, this.args);
+	}
+}
+
 ///////////////////////////// findContract /////////////////////////////
 
 export class FindContractView extends wasmclient.ClientView {
@@ -141,6 +165,10 @@ export class CoreRootService extends wasmclient.Service {
 		return new RevokeDeployPermissionFunc(this);
 	}
 
+	public upgradeContract(): UpgradeContractFunc {
+		return new UpgradeContractFunc(this);
+	}
+
 	public findContract(): FindContractView {
 		return new FindContractView(this);
 	}
@@ -12,25 +12,45 @@ export const ScDescription = "Core chain account ledger contract";
 export const HScName       = new wasmtypes.ScHname(0x3c4b5e02);
 
 export const ParamAgentID        = "a";
+export const ParamAmount         = "m";
+export const ParamColor          = "c";
+export const ParamLimit          = "l";
+export const ParamOffset         = "o";
+export const ParamOwner          = "ow";
+export const ParamSpender        = "sp";
+export const ParamTarget         = "t";
 export const ParamWithdrawAmount = "m";
 export const ParamWithdrawColor  = "c";
 
 export const ResultAccountNonce = "n";
 export const ResultAgents       = "this";
+export const ResultAllowance    = "this";
 export const ResultBalances     = "this";
+export const ResultEntries      = "he";
+export const ResultLength       = "hl";
 
-export const FuncDeposit         = "deposit";
-export const FuncHarvest         = "harvest";
-export const FuncWithdraw        = "withdraw";
-export const ViewAccounts        = "accounts";
-export const ViewBalance         = "balance";
-export const ViewGetAccountNonce = "getAccountNonce";
-export const ViewTotalAssets     = "totalAssets";
+export const FuncApprove           = "approve";
+export const FuncDeposit           = "deposit";
+export const FuncHarvest           = "harvest";
+export const FuncRevoke            = "revoke";
+export const FuncTransferFrom      = "transferFrom";
+export const FuncWithdraw          = "withdraw";
+export const ViewAccounts          = "accounts";
+export const ViewBalance           = "balance";
+export const ViewGetAccountHistory = "getAccountHistory";
+export const ViewGetAccountNonce   = "getAccountNonce";
+export const ViewGetAllowance      = "getAllowance";
+export const ViewTotalAssets       = "totalAssets";
 
-export const HFuncDeposit         = new wasmtypes.ScHname(0xbdc9102d);
-export const HFuncHarvest         = new wasmtypes.ScHname(0x7b40efbd);
-export const HFuncWithdraw        = new wasmtypes.ScHname(0x9dcc0f41);
-export const HViewAccounts        = new wasmtypes.ScHname(0x3c4b5e02);
-export const HViewBalance         = new wasmtypes.ScHname(0x84168cb4);
-export const HViewGetAccountNonce = new wasmtypes.ScHname(0x529d7df9);
-export const HViewTotalAssets     = new wasmtypes.ScHname(0xfab0f8d2);
+export const HFuncApprove           = new wasmtypes.ScHname(0xa0661268);
+export const HFuncDeposit           = new wasmtypes.ScHname(0xbdc9102d);
+export const HFuncHarvest           = new wasmtypes.ScHname(0x7b40efbd);
+export const HFuncRevoke            = new wasmtypes.ScHname(0x128d530a);
+export const HFuncTransferFrom      = new wasmtypes.ScHname(0xd5e0a602);
+export const HFuncWithdraw          = new wasmtypes.ScHname(0x9dcc0f41);
+export const HViewAccounts          = new wasmtypes.ScHname(0x3c4b5e02);
+export const HViewBalance           = new wasmtypes.ScHname(0x84168cb4);
+export const HViewGetAccountHistory = new wasmtypes.ScHname(0x289be591);
+export const HViewGetAccountNonce   = new wasmtypes.ScHname(0x529d7df9);
+export const HViewGetAllowance      = new wasmtypes.ScHname(0x329aa88f);
+export const HViewTotalAssets       = new wasmtypes.ScHname(0xfab0f8d2);
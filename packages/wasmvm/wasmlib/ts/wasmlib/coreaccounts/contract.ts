@@ -8,6 +8,11 @@
 import * as wasmlib from "wasmlib";
 import * as sc from "./index";
 
+export class ApproveCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncApprove);
+	params: sc.MutableApproveParams = new sc.MutableApproveParams(wasmlib.ScView.nilProxy);
+}
+
 export class DepositCall {
 	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncDeposit);
 	params: sc.MutableDepositParams = new sc.MutableDepositParams(wasmlib.ScView.nilProxy);
@@ -18,6 +23,16 @@ export class HarvestCall {
 	params: sc.MutableHarvestParams = new sc.MutableHarvestParams(wasmlib.ScView.nilProxy);
 }
 
+export class RevokeCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncRevoke);
+	params: sc.MutableRevokeParams = new sc.MutableRevokeParams(wasmlib.ScView.nilProxy);
+}
+
+export class TransferFromCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncTransferFrom);
+	params: sc.MutableTransferFromParams = new sc.MutableTransferFromParams(wasmlib.ScView.nilProxy);
+}
+
 export class WithdrawCall {
 	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncWithdraw);
 }
@@ -33,18 +48,36 @@ export class BalanceCall {
 	results: sc.ImmutableBalanceResults = new sc.ImmutableBalanceResults(wasmlib.ScView.nilProxy);
 }
 
+export class GetAccountHistoryCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetAccountHistory);
+	params: sc.MutableGetAccountHistoryParams = new sc.MutableGetAccountHistoryParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableGetAccountHistoryResults = new sc.ImmutableGetAccountHistoryResults(wasmlib.ScView.nilProxy);
+}
+
 export class GetAccountNonceCall {
 	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetAccountNonce);
 	params: sc.MutableGetAccountNonceParams = new sc.MutableGetAccountNonceParams(wasmlib.ScView.nilProxy);
 	results: sc.ImmutableGetAccountNonceResults = new sc.ImmutableGetAccountNonceResults(wasmlib.ScView.nilProxy);
 }
 
+export class GetAllowanceCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetAllowance);
+	params: sc.MutableGetAllowanceParams = new sc.MutableGetAllowanceParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableGetAllowanceResults = new sc.ImmutableGetAllowanceResults(wasmlib.ScView.nilProxy);
+}
+
 export class TotalAssetsCall {
 	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewTotalAssets);
 	results: sc.ImmutableTotalAssetsResults = new sc.ImmutableTotalAssetsResults(wasmlib.ScView.nilProxy);
 }
 
 export class ScFuncs {
+	static approve(_ctx: wasmlib.ScFuncCallContext): ApproveCall {
+		const f = new ApproveCall();
+		f.params = new sc.MutableApproveParams(wasmlib.newCallParamsProxy(f.func));
+		return f;
+	}
+
 	static deposit(_ctx: wasmlib.ScFuncCallContext): DepositCall {
 		const f = new DepositCall();
 		f.params = new sc.MutableDepositParams(wasmlib.newCallParamsProxy(f.func));
@@ -57,6 +90,18 @@ export class ScFuncs {
 		return f;
 	}
 
+	static revoke(_ctx: wasmlib.ScFuncCallContext): RevokeCall {
+		const f = new RevokeCall();
+		f.params = new sc.MutableRevokeParams(wasmlib.newCallParamsProxy(f.func));
+		return f;
+	}
+
+	static transferFrom(_ctx: wasmlib.ScFuncCallContext): TransferFromCall {
+		const f = new TransferFromCall();
+		f.params = new sc.MutableTransferFromParams(wasmlib.newCallParamsProxy(f.func));
+		return f;
+	}
+
 	static withdraw(_ctx: wasmlib.ScFuncCallContext): WithdrawCall {
 		return new WithdrawCall();
 	}
@@ -74,6 +119,13 @@ export class ScFuncs {
 		return f;
 	}
 
+	static getAccountHistory(_ctx: wasmlib.ScViewCallContext): GetAccountHistoryCall {
+		const f = new GetAccountHistoryCall();
+		f.params = new sc.MutableGetAccountHistoryParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableGetAccountHistoryResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+
 	static getAccountNonce(_ctx: wasmlib.ScViewCallContext): GetAccountNonceCall {
 		const f = new GetAccountNonceCall();
 		f.params = new sc.MutableGetAccountNonceParams(wasmlib.newCallParamsProxy(f.func));
@@ -81,6 +133,13 @@ export class ScFuncs {
 		return f;
 	}
 
+	static getAllowance(_ctx: wasmlib.ScViewCallContext): GetAllowanceCall {
+		const f = new GetAllowanceCall();
+		f.params = new sc.MutableGetAllowanceParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableGetAllowanceResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+
 	static totalAssets(_ctx: wasmlib.ScViewCallContext): TotalAssetsCall {
 		const f = new TotalAssetsCall();
 		f.results = new sc.ImmutableTotalAssetsResults(wasmlib.newCallResultsProxy(f.func));
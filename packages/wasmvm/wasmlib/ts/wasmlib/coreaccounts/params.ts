@@ -8,6 +8,34 @@
 import * as wasmtypes from "wasmlib/wasmtypes";
 import * as sc from "./index";
 
+export class ImmutableApproveParams extends wasmtypes.ScProxy {
+	amount(): wasmtypes.ScImmutableInt64 {
+		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamAmount));
+	}
+
+	color(): wasmtypes.ScImmutableColor {
+		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	spender(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
+
+export class MutableApproveParams extends wasmtypes.ScProxy {
+	amount(): wasmtypes.ScMutableInt64 {
+		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamAmount));
+	}
+
+	color(): wasmtypes.ScMutableColor {
+		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	spender(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
+
 export class ImmutableDepositParams extends wasmtypes.ScProxy {
 	agentID(): wasmtypes.ScImmutableAgentID {
 		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
@@ -40,6 +68,62 @@ export class MutableHarvestParams extends wasmtypes.ScProxy {
 	}
 }
 
+export class ImmutableRevokeParams extends wasmtypes.ScProxy {
+	color(): wasmtypes.ScImmutableColor {
+		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	spender(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
+
+export class MutableRevokeParams extends wasmtypes.ScProxy {
+	color(): wasmtypes.ScMutableColor {
+		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	spender(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
+
+export class ImmutableTransferFromParams extends wasmtypes.ScProxy {
+	amount(): wasmtypes.ScImmutableInt64 {
+		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamAmount));
+	}
+
+	color(): wasmtypes.ScImmutableColor {
+		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	owner(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamOwner));
+	}
+
+	target(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamTarget));
+	}
+}
+
+export class MutableTransferFromParams extends wasmtypes.ScProxy {
+	amount(): wasmtypes.ScMutableInt64 {
+		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamAmount));
+	}
+
+	color(): wasmtypes.ScMutableColor {
+		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ParamColor));
+	}
+
+	owner(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamOwner));
+	}
+
+	target(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamTarget));
+	}
+}
+
 export class ImmutableBalanceParams extends wasmtypes.ScProxy {
 	agentID(): wasmtypes.ScImmutableAgentID {
 		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
@@ -52,6 +136,34 @@ export class MutableBalanceParams extends wasmtypes.ScProxy {
 	}
 }
 
+export class ImmutableGetAccountHistoryParams extends wasmtypes.ScProxy {
+	agentID(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
+	}
+
+	limit(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamLimit));
+	}
+
+	offset(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamOffset));
+	}
+}
+
+export class MutableGetAccountHistoryParams extends wasmtypes.ScProxy {
+	agentID(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamAgentID));
+	}
+
+	limit(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamLimit));
+	}
+
+	offset(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamOffset));
+	}
+}
+
 export class ImmutableGetAccountNonceParams extends wasmtypes.ScProxy {
 	agentID(): wasmtypes.ScImmutableAgentID {
 		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
@@ -63,3 +175,23 @@ export class MutableGetAccountNonceParams extends wasmtypes.ScProxy {
 		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamAgentID));
 	}
 }
+
+export class ImmutableGetAllowanceParams extends wasmtypes.ScProxy {
+	owner(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamOwner));
+	}
+
+	spender(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
+
+export class MutableGetAllowanceParams extends wasmtypes.ScProxy {
+	owner(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamOwner));
+	}
+
+	spender(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamSpender));
+	}
+}
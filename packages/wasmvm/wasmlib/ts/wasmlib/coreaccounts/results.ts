@@ -68,6 +68,56 @@ export class MutableBalanceResults extends wasmtypes.ScProxy {
 	}
 }
 
+export class ArrayOfImmutableBytes extends wasmtypes.ScProxy {
+
+	length(): u32 {
+		return this.proxy.length();
+	}
+
+	getBytes(index: u32): wasmtypes.ScImmutableBytes {
+		return new wasmtypes.ScImmutableBytes(this.proxy.index(index));
+	}
+}
+
+export class ImmutableGetAccountHistoryResults extends wasmtypes.ScProxy {
+	entries(): sc.ArrayOfImmutableBytes {
+		return new sc.ArrayOfImmutableBytes(this.proxy.root(sc.ResultEntries));
+	}
+
+	length(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultLength));
+	}
+}
+
+export class ArrayOfMutableBytes extends wasmtypes.ScProxy {
+
+	appendBytes(): wasmtypes.ScMutableBytes {
+		return new wasmtypes.ScMutableBytes(this.proxy.append());
+	}
+
+	clear(): void {
+		this.proxy.clearArray();
+	}
+
+	length(): u32 {
+		return this.proxy.length();
+	}
+
+	getBytes(index: u32): wasmtypes.ScMutableBytes {
+		return new wasmtypes.ScMutableBytes(this.proxy.index(index));
+	}
+}
+
+export class MutableGetAccountHistoryResults extends wasmtypes.ScProxy {
+	entries(): sc.ArrayOfMutableBytes {
+		return new sc.ArrayOfMutableBytes(this.proxy.root(sc.ResultEntries));
+	}
+
+	length(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultLength));
+	}
+}
+
 export class ImmutableGetAccountNonceResults extends wasmtypes.ScProxy {
 	accountNonce(): wasmtypes.ScImmutableInt64 {
 		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ResultAccountNonce));
@@ -80,6 +130,18 @@ export class MutableGetAccountNonceResults extends wasmtypes.ScProxy {
 	}
 }
 
+export class ImmutableGetAllowanceResults extends wasmtypes.ScProxy {
+	allowance(): sc.MapColorToImmutableInt64 {
+		return new sc.MapColorToImmutableInt64(this.proxy);
+	}
+}
+
+export class MutableGetAllowanceResults extends wasmtypes.ScProxy {
+	allowance(): sc.MapColorToMutableInt64 {
+		return new sc.MapColorToMutableInt64(this.proxy);
+	}
+}
+
 export class ImmutableTotalAssetsResults extends wasmtypes.ScProxy {
 	balances(): sc.MapColorToImmutableInt64 {
 		return new sc.MapColorToImmutableInt64(this.proxy);
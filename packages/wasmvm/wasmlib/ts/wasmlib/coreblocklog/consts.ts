@@ -21,6 +21,8 @@ export const ResultBlockIndex             = "n";
 export const ResultBlockInfo              = "i";
 export const ResultEvent                  = "e";
 export const ResultGoverningAddress       = "g";
+export const ResultPrunedBytes            = "pb";
+export const ResultPrunedUntil            = "pu";
 export const ResultRequestID              = "u";
 export const ResultRequestIndex           = "r";
 export const ResultRequestProcessed       = "p";
@@ -33,6 +35,7 @@ export const ViewGetEventsForBlock          = "getEventsForBlock";
 export const ViewGetEventsForContract       = "getEventsForContract";
 export const ViewGetEventsForRequest        = "getEventsForRequest";
 export const ViewGetLatestBlockInfo         = "getLatestBlockInfo";
+export const ViewGetPruningInfo             = "getPruningInfo";
 export const ViewGetRequestIDsForBlock      = "getRequestIDsForBlock";
 export const ViewGetRequestReceipt          = "getRequestReceipt";
 export const ViewGetRequestReceiptsForBlock = "getRequestReceiptsForBlock";
@@ -44,6 +47,7 @@ export const HViewGetEventsForBlock          = new wasmtypes.ScHname(0x36232798)
 export const HViewGetEventsForContract       = new wasmtypes.ScHname(0x682a1922);
 export const HViewGetEventsForRequest        = new wasmtypes.ScHname(0x4f8d68e4);
 export const HViewGetLatestBlockInfo         = new wasmtypes.ScHname(0x084a1760);
+export const HViewGetPruningInfo             = new wasmtypes.ScHname(0x1ac4ecd4);
 export const HViewGetRequestIDsForBlock      = new wasmtypes.ScHname(0x5a20327a);
 export const HViewGetRequestReceipt          = new wasmtypes.ScHname(0xb7f9534f);
 export const HViewGetRequestReceiptsForBlock = new wasmtypes.ScHname(0x77e3beef);
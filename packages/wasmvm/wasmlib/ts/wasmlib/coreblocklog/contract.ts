@@ -42,6 +42,11 @@ export class GetLatestBlockInfoCall {
 	results: sc.ImmutableGetLatestBlockInfoResults = new sc.ImmutableGetLatestBlockInfoResults(wasmlib.ScView.nilProxy);
 }
 
+export class GetPruningInfoCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetPruningInfo);
+	results: sc.ImmutableGetPruningInfoResults = new sc.ImmutableGetPruningInfoResults(wasmlib.ScView.nilProxy);
+}
+
 export class GetRequestIDsForBlockCall {
 	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetRequestIDsForBlock);
 	params: sc.MutableGetRequestIDsForBlockParams = new sc.MutableGetRequestIDsForBlockParams(wasmlib.ScView.nilProxy);
@@ -107,6 +112,12 @@ export class ScFuncs {
 		return f;
 	}
 
+	static getPruningInfo(_ctx: wasmlib.ScViewCallContext): GetPruningInfoCall {
+		const f = new GetPruningInfoCall();
+		f.results = new sc.ImmutableGetPruningInfoResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+
 	static getRequestIDsForBlock(_ctx: wasmlib.ScViewCallContext): GetRequestIDsForBlockCall {
 		const f = new GetRequestIDsForBlockCall();
 		f.params = new sc.MutableGetRequestIDsForBlockParams(wasmlib.newCallParamsProxy(f.func));
@@ -134,6 +134,26 @@ export class MutableGetLatestBlockInfoResults extends wasmtypes.ScProxy {
 	}
 }
 
+export class ImmutableGetPruningInfoResults extends wasmtypes.ScProxy {
+	prunedBytes(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultPrunedBytes));
+	}
+
+	prunedUntil(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultPrunedUntil));
+	}
+}
+
+export class MutableGetPruningInfoResults extends wasmtypes.ScProxy {
+	prunedBytes(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultPrunedBytes));
+	}
+
+	prunedUntil(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultPrunedUntil));
+	}
+}
+
 export class ArrayOfImmutableRequestID extends wasmtypes.ScProxy {
 
 	length(): u32 {
@@ -11,28 +11,40 @@ export const ScName        = "governance";
 export const ScDescription = "Core governance contract";
 export const HScName       = new wasmtypes.ScHname(0x17cf909f);
 
+export const ParamAccountHistory         = "ah";
+export const ParamBlockKeepAmount        = "bk";
 export const ParamChainOwner             = "oi";
 export const ParamFeeColor               = "fc";
-export const ParamHname                  = "hn";
+export const ParamGas                    = "g";
+export const ParamGasPrice               = "gp";
 export const ParamMaxBlobSize            = "bs";
 export const ParamMaxEventSize           = "es";
 export const ParamMaxEventsPerReq        = "ne";
-export const ParamOwnerFee               = "of";
+export const ParamMinFee                 = "mf";
 export const ParamStateControllerAddress = "S";
-export const ParamValidatorFee           = "vf";
+export const ParamValidatorFeeShare      = "vs";
+export const ParamWasmMaxCallDepth       = "wd";
+export const ParamWasmMaxMemoryPages     = "wm";
+export const ParamWasmMaxTableSize       = "wt";
 
+export const ResultAccountHistory                  = "ah";
 export const ResultAllowedStateControllerAddresses = "a";
+export const ResultBlockKeepAmount                 = "bk";
 export const ResultChainID                         = "c";
 export const ResultChainOwnerID                    = "o";
-export const ResultDefaultOwnerFee                 = "do";
-export const ResultDefaultValidatorFee             = "dv";
 export const ResultDescription                     = "d";
+export const ResultFee                             = "fe";
 export const ResultFeeColor                        = "f";
+export const ResultGas                             = "g";
+export const ResultGasPrice                        = "gp";
 export const ResultMaxBlobSize                     = "mb";
 export const ResultMaxEventSize                    = "me";
 export const ResultMaxEventsPerReq                 = "mr";
-export const ResultOwnerFee                        = "of";
-export const ResultValidatorFee                    = "vf";
+export const ResultMinFee                          = "mf";
+export const ResultValidatorFeeShare               = "vs";
+export const ResultWasmMaxCallDepth                = "wd";
+export const ResultWasmMaxMemoryPages              = "wm";
+export const ResultWasmMaxTableSize                = "wt";
 
 export const FuncAddAllowedStateControllerAddress    = "addAllowedStateControllerAddress";
 export const FuncClaimChainOwnership                 = "claimChainOwnership";
@@ -40,11 +52,11 @@ export const FuncDelegateChainOwnership              = "delegateChainOwnership";
 export const FuncRemoveAllowedStateControllerAddress = "removeAllowedStateControllerAddress";
 export const FuncRotateStateController               = "rotateStateController";
 export const FuncSetChainInfo                        = "setChainInfo";
-export const FuncSetContractFee                      = "setContractFee";
-export const FuncSetDefaultFee                       = "setDefaultFee";
+export const FuncSetFeePolicy                        = "setFeePolicy";
+export const ViewEstimateFee                         = "estimateFee";
 export const ViewGetAllowedStateControllerAddresses  = "getAllowedStateControllerAddresses";
 export const ViewGetChainInfo                        = "getChainInfo";
-export const ViewGetFeeInfo                          = "getFeeInfo";
+export const ViewGetFeePolicy                        = "getFeePolicy";
 export const ViewGetMaxBlobSize                      = "getMaxBlobSize";
 
 export const HFuncAddAllowedStateControllerAddress    = new wasmtypes.ScHname(0x9469d567);
@@ -53,9 +65,9 @@ export const HFuncDelegateChainOwnership              = new wasmtypes.ScHname(0x
 export const HFuncRemoveAllowedStateControllerAddress = new wasmtypes.ScHname(0x31f69447);
 export const HFuncRotateStateController               = new wasmtypes.ScHname(0x244d1038);
 export const HFuncSetChainInfo                        = new wasmtypes.ScHname(0x702f5d2b);
-export const HFuncSetContractFee                      = new wasmtypes.ScHname(0x8421a42b);
-export const HFuncSetDefaultFee                       = new wasmtypes.ScHname(0x3310ecd0);
+export const HFuncSetFeePolicy                        = new wasmtypes.ScHname(0x5b791c9f);
+export const HViewEstimateFee                         = new wasmtypes.ScHname(0xe1b674ad);
 export const HViewGetAllowedStateControllerAddresses  = new wasmtypes.ScHname(0xf3505183);
 export const HViewGetChainInfo                        = new wasmtypes.ScHname(0x434477e2);
-export const HViewGetFeeInfo                          = new wasmtypes.ScHname(0x9fe54b48);
+export const HViewGetFeePolicy                        = new wasmtypes.ScHname(0xf8c89790);
 export const HViewGetMaxBlobSize                      = new wasmtypes.ScHname(0xe1db3d28);
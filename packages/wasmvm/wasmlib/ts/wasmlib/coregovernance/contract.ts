@@ -37,14 +37,15 @@ export class SetChainInfoCall {
 	params: sc.MutableSetChainInfoParams = new sc.MutableSetChainInfoParams(wasmlib.ScView.nilProxy);
 }
 
-export class SetContractFeeCall {
-	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncSetContractFee);
-	params: sc.MutableSetContractFeeParams = new sc.MutableSetContractFeeParams(wasmlib.ScView.nilProxy);
+export class SetFeePolicyCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncSetFeePolicy);
+	params: sc.MutableSetFeePolicyParams = new sc.MutableSetFeePolicyParams(wasmlib.ScView.nilProxy);
 }
 
-export class SetDefaultFeeCall {
-	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncSetDefaultFee);
-	params: sc.MutableSetDefaultFeeParams = new sc.MutableSetDefaultFeeParams(wasmlib.ScView.nilProxy);
+export class EstimateFeeCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewEstimateFee);
+	params: sc.MutableEstimateFeeParams = new sc.MutableEstimateFeeParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableEstimateFeeResults = new sc.ImmutableEstimateFeeResults(wasmlib.ScView.nilProxy);
 }
 
 export class GetAllowedStateControllerAddressesCall {
@@ -57,10 +58,9 @@ export class GetChainInfoCall {
 	results: sc.ImmutableGetChainInfoResults = new sc.ImmutableGetChainInfoResults(wasmlib.ScView.nilProxy);
 }
 
-export class GetFeeInfoCall {
-	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetFeeInfo);
-	params: sc.MutableGetFeeInfoParams = new sc.MutableGetFeeInfoParams(wasmlib.ScView.nilProxy);
-	results: sc.ImmutableGetFeeInfoResults = new sc.ImmutableGetFeeInfoResults(wasmlib.ScView.nilProxy);
+export class GetFeePolicyCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetFeePolicy);
+	results: sc.ImmutableGetFeePolicyResults = new sc.ImmutableGetFeePolicyResults(wasmlib.ScView.nilProxy);
 }
 
 export class GetMaxBlobSizeCall {
@@ -103,15 +103,16 @@ export class ScFuncs {
 		return f;
 	}
 
-	static setContractFee(_ctx: wasmlib.ScFuncCallContext): SetContractFeeCall {
-		const f = new SetContractFeeCall();
-		f.params = new sc.MutableSetContractFeeParams(wasmlib.newCallParamsProxy(f.func));
+	static setFeePolicy(_ctx: wasmlib.ScFuncCallContext): SetFeePolicyCall {
+		const f = new SetFeePolicyCall();
+		f.params = new sc.MutableSetFeePolicyParams(wasmlib.newCallParamsProxy(f.func));
 		return f;
 	}
 
-	static setDefaultFee(_ctx: wasmlib.ScFuncCallContext): SetDefaultFeeCall {
-		const f = new SetDefaultFeeCall();
-		f.params = new sc.MutableSetDefaultFeeParams(wasmlib.newCallParamsProxy(f.func));
+	static estimateFee(_ctx: wasmlib.ScViewCallContext): EstimateFeeCall {
+		const f = new EstimateFeeCall();
+		f.params = new sc.MutableEstimateFeeParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableEstimateFeeResults(wasmlib.newCallResultsProxy(f.func));
 		return f;
 	}
 
@@ -127,10 +128,9 @@ export class ScFuncs {
 		return f;
 	}
 
-	static getFeeInfo(_ctx: wasmlib.ScViewCallContext): GetFeeInfoCall {
-		const f = new GetFeeInfoCall();
-		f.params = new sc.MutableGetFeeInfoParams(wasmlib.newCallParamsProxy(f.func));
-		f.results = new sc.ImmutableGetFeeInfoResults(wasmlib.newCallResultsProxy(f.func));
+	static getFeePolicy(_ctx: wasmlib.ScViewCallContext): GetFeePolicyCall {
+		const f = new GetFeePolicyCall();
+		f.results = new sc.ImmutableGetFeePolicyResults(wasmlib.newCallResultsProxy(f.func));
 		return f;
 	}
 
@@ -73,6 +73,14 @@ export class MutableRotateStateControllerParams extends wasmtypes.ScProxy {
 }
 
 export class ImmutableSetChainInfoParams extends wasmtypes.ScProxy {
+	accountHistory(): wasmtypes.ScImmutableBool {
+		return new wasmtypes.ScImmutableBool(this.proxy.root(sc.ParamAccountHistory));
+	}
+
+	blockKeepAmount(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamBlockKeepAmount));
+	}
+
 	maxBlobSize(): wasmtypes.ScImmutableInt32 {
 		return new wasmtypes.ScImmutableInt32(this.proxy.root(sc.ParamMaxBlobSize));
 	}
@@ -85,16 +93,28 @@ export class ImmutableSetChainInfoParams extends wasmtypes.ScProxy {
 		return new wasmtypes.ScImmutableInt16(this.proxy.root(sc.ParamMaxEventsPerReq));
 	}
 
-	ownerFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamOwnerFee));
+	wasmMaxCallDepth(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamWasmMaxCallDepth));
+	}
+
+	wasmMaxMemoryPages(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamWasmMaxMemoryPages));
 	}
 
-	validatorFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamValidatorFee));
+	wasmMaxTableSize(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamWasmMaxTableSize));
 	}
 }
 
 export class MutableSetChainInfoParams extends wasmtypes.ScProxy {
+	accountHistory(): wasmtypes.ScMutableBool {
+		return new wasmtypes.ScMutableBool(this.proxy.root(sc.ParamAccountHistory));
+	}
+
+	blockKeepAmount(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamBlockKeepAmount));
+	}
+
 	maxBlobSize(): wasmtypes.ScMutableInt32 {
 		return new wasmtypes.ScMutableInt32(this.proxy.root(sc.ParamMaxBlobSize));
 	}
@@ -107,71 +127,63 @@ export class MutableSetChainInfoParams extends wasmtypes.ScProxy {
 		return new wasmtypes.ScMutableInt16(this.proxy.root(sc.ParamMaxEventsPerReq));
 	}
 
-	ownerFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamOwnerFee));
+	wasmMaxCallDepth(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamWasmMaxCallDepth));
 	}
 
-	validatorFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamValidatorFee));
-	}
-}
-
-export class ImmutableSetContractFeeParams extends wasmtypes.ScProxy {
-	hname(): wasmtypes.ScImmutableHname {
-		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+	wasmMaxMemoryPages(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamWasmMaxMemoryPages));
 	}
 
-	ownerFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamOwnerFee));
+	wasmMaxTableSize(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamWasmMaxTableSize));
 	}
+}
 
-	validatorFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamValidatorFee));
+export class ImmutableSetFeePolicyParams extends wasmtypes.ScProxy {
+	feeColor(): wasmtypes.ScImmutableColor {
+		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ParamFeeColor));
 	}
-}
 
-export class MutableSetContractFeeParams extends wasmtypes.ScProxy {
-	hname(): wasmtypes.ScMutableHname {
-		return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+	gasPrice(): wasmtypes.ScImmutableInt64 {
+		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamGasPrice));
 	}
 
-	ownerFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamOwnerFee));
+	minFee(): wasmtypes.ScImmutableInt64 {
+		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamMinFee));
 	}
 
-	validatorFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamValidatorFee));
+	validatorFeeShare(): wasmtypes.ScImmutableInt64 {
+		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamValidatorFeeShare));
 	}
 }
 
-export class ImmutableSetDefaultFeeParams extends wasmtypes.ScProxy {
-	ownerFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamOwnerFee));
+export class MutableSetFeePolicyParams extends wasmtypes.ScProxy {
+	feeColor(): wasmtypes.ScMutableColor {
+		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ParamFeeColor));
 	}
 
-	validatorFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamValidatorFee));
+	gasPrice(): wasmtypes.ScMutableInt64 {
+		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamGasPrice));
 	}
-}
 
-export class MutableSetDefaultFeeParams extends wasmtypes.ScProxy {
-	ownerFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamOwnerFee));
+	minFee(): wasmtypes.ScMutableInt64 {
+		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamMinFee));
 	}
 
-	validatorFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamValidatorFee));
+	validatorFeeShare(): wasmtypes.ScMutableInt64 {
+		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamValidatorFeeShare));
 	}
 }
 
-export class ImmutableGetFeeInfoParams extends wasmtypes.ScProxy {
-	hname(): wasmtypes.ScImmutableHname {
-		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+export class ImmutableEstimateFeeParams extends wasmtypes.ScProxy {
+	gas(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamGas));
 	}
 }
 
-export class MutableGetFeeInfoParams extends wasmtypes.ScProxy {
-	hname(): wasmtypes.ScMutableHname {
-		return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+export class MutableEstimateFeeParams extends wasmtypes.ScProxy {
+	gas(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamGas));
 	}
 }
@@ -8,6 +8,34 @@
 import * as wasmtypes from "wasmlib/wasmtypes";
 import * as sc from "./index";
 
+export class ImmutableEstimateFeeResults extends wasmtypes.ScProxy {
+	fee(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultFee));
+	}
+
+	feeColor(): wasmtypes.ScImmutableColor {
+		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ResultFeeColor));
+	}
+
+	gas(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultGas));
+	}
+}
+
+export class MutableEstimateFeeResults extends wasmtypes.ScProxy {
+	fee(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultFee));
+	}
+
+	feeColor(): wasmtypes.ScMutableColor {
+		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ResultFeeColor));
+	}
+
+	gas(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultGas));
+	}
+}
+
 export class ArrayOfImmutableBytes extends wasmtypes.ScProxy {
 
 	length(): u32 {
@@ -51,20 +79,20 @@ export class MutableGetAllowedStateControllerAddressesResults extends wasmtypes.
 }
 
 export class ImmutableGetChainInfoResults extends wasmtypes.ScProxy {
-	chainID(): wasmtypes.ScImmutableChainID {
-		return new wasmtypes.ScImmutableChainID(this.proxy.root(sc.ResultChainID));
+	accountHistory(): wasmtypes.ScImmutableBool {
+		return new wasmtypes.ScImmutableBool(this.proxy.root(sc.ResultAccountHistory));
 	}
 
-	chainOwnerID(): wasmtypes.ScImmutableAgentID {
-		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ResultChainOwnerID));
+	blockKeepAmount(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultBlockKeepAmount));
 	}
 
-	defaultOwnerFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ResultDefaultOwnerFee));
+	chainID(): wasmtypes.ScImmutableChainID {
+		return new wasmtypes.ScImmutableChainID(this.proxy.root(sc.ResultChainID));
 	}
 
-	defaultValidatorFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ResultDefaultValidatorFee));
+	chainOwnerID(): wasmtypes.ScImmutableAgentID {
+		return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ResultChainOwnerID));
 	}
 
 	description(): wasmtypes.ScImmutableString {
@@ -86,23 +114,35 @@ export class ImmutableGetChainInfoResults extends wasmtypes.ScProxy {
 	maxEventsPerReq(): wasmtypes.ScImmutableInt16 {
 		return new wasmtypes.ScImmutableInt16(this.proxy.root(sc.ResultMaxEventsPerReq));
 	}
+
+	wasmMaxCallDepth(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultWasmMaxCallDepth));
+	}
+
+	wasmMaxMemoryPages(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultWasmMaxMemoryPages));
+	}
+
+	wasmMaxTableSize(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultWasmMaxTableSize));
+	}
 }
 
 export class MutableGetChainInfoResults extends wasmtypes.ScProxy {
-	chainID(): wasmtypes.ScMutableChainID {
-		return new wasmtypes.ScMutableChainID(this.proxy.root(sc.ResultChainID));
+	accountHistory(): wasmtypes.ScMutableBool {
+		return new wasmtypes.ScMutableBool(this.proxy.root(sc.ResultAccountHistory));
 	}
 
-	chainOwnerID(): wasmtypes.ScMutableAgentID {
-		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ResultChainOwnerID));
+	blockKeepAmount(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultBlockKeepAmount));
 	}
 
-	defaultOwnerFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ResultDefaultOwnerFee));
+	chainID(): wasmtypes.ScMutableChainID {
+		return new wasmtypes.ScMutableChainID(this.proxy.root(sc.ResultChainID));
 	}
 
-	defaultValidatorFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ResultDefaultValidatorFee));
+	chainOwnerID(): wasmtypes.ScMutableAgentID {
+		return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ResultChainOwnerID));
 	}
 
 	description(): wasmtypes.ScMutableString {
@@ -124,33 +164,53 @@ export class MutableGetChainInfoResults extends wasmtypes.ScProxy {
 	maxEventsPerReq(): wasmtypes.ScMutableInt16 {
 		return new wasmtypes.ScMutableInt16(this.proxy.root(sc.ResultMaxEventsPerReq));
 	}
+
+	wasmMaxCallDepth(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultWasmMaxCallDepth));
+	}
+
+	wasmMaxMemoryPages(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultWasmMaxMemoryPages));
+	}
+
+	wasmMaxTableSize(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultWasmMaxTableSize));
+	}
 }
 
-export class ImmutableGetFeeInfoResults extends wasmtypes.ScProxy {
+export class ImmutableGetFeePolicyResults extends wasmtypes.ScProxy {
 	feeColor(): wasmtypes.ScImmutableColor {
 		return new wasmtypes.ScImmutableColor(this.proxy.root(sc.ResultFeeColor));
 	}
 
-	ownerFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ResultOwnerFee));
+	gasPrice(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultGasPrice));
+	}
+
+	minFee(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultMinFee));
 	}
 
-	validatorFee(): wasmtypes.ScImmutableInt64 {
-		return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ResultValidatorFee));
+	validatorFeeShare(): wasmtypes.ScImmutableUint16 {
+		return new wasmtypes.ScImmutableUint16(this.proxy.root(sc.ResultValidatorFeeShare));
 	}
 }
 
-export class MutableGetFeeInfoResults extends wasmtypes.ScProxy {
+export class MutableGetFeePolicyResults extends wasmtypes.ScProxy {
 	feeColor(): wasmtypes.ScMutableColor {
 		return new wasmtypes.ScMutableColor(this.proxy.root(sc.ResultFeeColor));
 	}
 
-	ownerFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ResultOwnerFee));
+	gasPrice(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultGasPrice));
+	}
+
+	minFee(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultMinFee));
 	}
 
-	validatorFee(): wasmtypes.ScMutableInt64 {
-		return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ResultValidatorFee));
+	validatorFeeShare(): wasmtypes.ScMutableUint16 {
+		return new wasmtypes.ScMutableUint16(this.proxy.root(sc.ResultValidatorFeeShare));
 	}
 }
 
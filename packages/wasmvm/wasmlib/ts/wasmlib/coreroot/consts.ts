@@ -24,11 +24,13 @@ export const ResultContractRegistry = "r";
 export const FuncDeployContract         = "deployContract";
 export const FuncGrantDeployPermission  = "grantDeployPermission";
 export const FuncRevokeDeployPermission = "revokeDeployPermission";
+export const FuncUpgradeContract        = "upgradeContract";
 export const ViewFindContract           = "findContract";
 export const ViewGetContractRecords     = "getContractRecords";
 
 export const HFuncDeployContract         = new wasmtypes.ScHname(0x28232c27);
 export const HFuncGrantDeployPermission  = new wasmtypes.ScHname(0xf440263a);
 export const HFuncRevokeDeployPermission = new wasmtypes.ScHname(0x850744f1);
+export const HFuncUpgradeContract        = new wasmtypes.ScHname(0x00d30d5c);
 export const HViewFindContract           = new wasmtypes.ScHname(0xc145ca00);
 export const HViewGetContractRecords     = new wasmtypes.ScHname(0x078b3ef3);
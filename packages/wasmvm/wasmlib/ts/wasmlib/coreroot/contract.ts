@@ -23,6 +23,11 @@ export class RevokeDeployPermissionCall {
 	params: sc.MutableRevokeDeployPermissionParams = new sc.MutableRevokeDeployPermissionParams(wasmlib.ScView.nilProxy);
 }
 
+export class UpgradeContractCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncUpgradeContract);
+	params: sc.MutableUpgradeContractParams = new sc.MutableUpgradeContractParams(wasmlib.ScView.nilProxy);
+}
+
 export class FindContractCall {
 	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewFindContract);
 	params: sc.MutableFindContractParams = new sc.MutableFindContractParams(wasmlib.ScView.nilProxy);
@@ -53,6 +58,12 @@ export class ScFuncs {
 		return f;
 	}
 
+	static upgradeContract(_ctx: wasmlib.ScFuncCallContext): UpgradeContractCall {
+		const f = new UpgradeContractCall();
+		f.params = new sc.MutableUpgradeContractParams(wasmlib.newCallParamsProxy(f.func));
+		return f;
+	}
+
 	static findContract(_ctx: wasmlib.ScViewCallContext): FindContractCall {
 		const f = new FindContractCall();
 		f.params = new sc.MutableFindContractParams(wasmlib.newCallParamsProxy(f.func));
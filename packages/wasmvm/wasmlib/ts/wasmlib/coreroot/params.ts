@@ -60,6 +60,34 @@ export class MutableRevokeDeployPermissionParams extends wasmtypes.ScProxy {
 	}
 }
 
+export class ImmutableUpgradeContractParams extends wasmtypes.ScProxy {
+	description(): wasmtypes.ScImmutableString {
+		return new wasmtypes.ScImmutableString(this.proxy.root(sc.ParamDescription));
+	}
+
+	hname(): wasmtypes.ScImmutableHname {
+		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+	}
+
+	programHash(): wasmtypes.ScImmutableHash {
+		return new wasmtypes.ScImmutableHash(this.proxy.root(sc.ParamProgramHash));
+	}
+}
+
+export class MutableUpgradeContractParams extends wasmtypes.ScProxy {
+	description(): wasmtypes.ScMutableString {
+		return new wasmtypes.ScMutableString(this.proxy.root(sc.ParamDescription));
+	}
+
+	hname(): wasmtypes.ScMutableHname {
+		return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+	}
+
+	programHash(): wasmtypes.ScMutableHash {
+		return new wasmtypes.ScMutableHash(this.proxy.root(sc.ParamProgramHash));
+	}
+}
+
 export class ImmutableFindContractParams extends wasmtypes.ScProxy {
 	hname(): wasmtypes.ScImmutableHname {
 		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
@@ -0,0 +1,35 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+import * as wasmtypes from "wasmlib/wasmtypes";
+
+export const ScName        = "scheduler";
+export const ScDescription = "Core scheduler contract";
+export const HScName       = new wasmtypes.ScHname(0x9c305966);
+
+export const ParamArgs       = "a";
+export const ParamCallID     = "i";
+export const ParamContract   = "h";
+export const ParamCount      = "c";
+export const ParamDelay      = "d";
+export const ParamEntryPoint = "e";
+export const ParamGasBudget  = "g";
+export const ParamInterval   = "v";
+
+export const ResultCallID         = "i";
+export const ResultScheduledCall  = "s";
+export const ResultScheduledCalls = "this";
+
+export const FuncCancel            = "cancel";
+export const FuncSchedule          = "schedule";
+export const ViewGetScheduledCall  = "getScheduledCall";
+export const ViewGetScheduledCalls = "getScheduledCalls";
+
+export const HFuncCancel            = new wasmtypes.ScHname(0xa7e99697);
+export const HFuncSchedule          = new wasmtypes.ScHname(0x9631b89d);
+export const HViewGetScheduledCall  = new wasmtypes.ScHname(0x2328db9b);
+export const HViewGetScheduledCalls = new wasmtypes.ScHname(0x0e844101);
@@ -0,0 +1,61 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+import * as wasmlib from "wasmlib";
+import * as sc from "./index";
+
+export class CancelCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncCancel);
+	params: sc.MutableCancelParams = new sc.MutableCancelParams(wasmlib.ScView.nilProxy);
+}
+
+export class ScheduleCall {
+	func: wasmlib.ScFunc = new wasmlib.ScFunc(sc.HScName, sc.HFuncSchedule);
+	params: sc.MutableScheduleParams = new sc.MutableScheduleParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableScheduleResults = new sc.ImmutableScheduleResults(wasmlib.ScView.nilProxy);
+}
+
+export class GetScheduledCallCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetScheduledCall);
+	params: sc.MutableGetScheduledCallParams = new sc.MutableGetScheduledCallParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableGetScheduledCallResults = new sc.ImmutableGetScheduledCallResults(wasmlib.ScView.nilProxy);
+}
+
+export class GetScheduledCallsCall {
+	func: wasmlib.ScView = new wasmlib.ScView(sc.HScName, sc.HViewGetScheduledCalls);
+	params: sc.MutableGetScheduledCallsParams = new sc.MutableGetScheduledCallsParams(wasmlib.ScView.nilProxy);
+	results: sc.ImmutableGetScheduledCallsResults = new sc.ImmutableGetScheduledCallsResults(wasmlib.ScView.nilProxy);
+}
+
+export class ScFuncs {
+	static cancel(_ctx: wasmlib.ScFuncCallContext): CancelCall {
+		const f = new CancelCall();
+		f.params = new sc.MutableCancelParams(wasmlib.newCallParamsProxy(f.func));
+		return f;
+	}
+
+	static schedule(_ctx: wasmlib.ScFuncCallContext): ScheduleCall {
+		const f = new ScheduleCall();
+		f.params = new sc.MutableScheduleParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableScheduleResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+
+	static getScheduledCall(_ctx: wasmlib.ScViewCallContext): GetScheduledCallCall {
+		const f = new GetScheduledCallCall();
+		f.params = new sc.MutableGetScheduledCallParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableGetScheduledCallResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+
+	static getScheduledCalls(_ctx: wasmlib.ScViewCallContext): GetScheduledCallsCall {
+		const f = new GetScheduledCallsCall();
+		f.params = new sc.MutableGetScheduledCallsParams(wasmlib.newCallParamsProxy(f.func));
+		f.results = new sc.ImmutableGetScheduledCallsResults(wasmlib.newCallResultsProxy(f.func));
+		return f;
+	}
+}
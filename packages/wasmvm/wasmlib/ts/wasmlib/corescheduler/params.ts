@@ -0,0 +1,97 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+import * as wasmtypes from "wasmlib/wasmtypes";
+import * as sc from "./index";
+
+export class ImmutableCancelParams extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamCallID));
+	}
+}
+
+export class MutableCancelParams extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamCallID));
+	}
+}
+
+export class ImmutableScheduleParams extends wasmtypes.ScProxy {
+	args(): wasmtypes.ScImmutableBytes {
+		return new wasmtypes.ScImmutableBytes(this.proxy.root(sc.ParamArgs));
+	}
+
+	count(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamCount));
+	}
+
+	delay(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamDelay));
+	}
+
+	entryPoint(): wasmtypes.ScImmutableHname {
+		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamEntryPoint));
+	}
+
+	gasBudget(): wasmtypes.ScImmutableUint64 {
+		return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamGasBudget));
+	}
+
+	interval(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamInterval));
+	}
+}
+
+export class MutableScheduleParams extends wasmtypes.ScProxy {
+	args(): wasmtypes.ScMutableBytes {
+		return new wasmtypes.ScMutableBytes(this.proxy.root(sc.ParamArgs));
+	}
+
+	count(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamCount));
+	}
+
+	delay(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamDelay));
+	}
+
+	entryPoint(): wasmtypes.ScMutableHname {
+		return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamEntryPoint));
+	}
+
+	gasBudget(): wasmtypes.ScMutableUint64 {
+		return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamGasBudget));
+	}
+
+	interval(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamInterval));
+	}
+}
+
+export class ImmutableGetScheduledCallParams extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamCallID));
+	}
+}
+
+export class MutableGetScheduledCallParams extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamCallID));
+	}
+}
+
+export class ImmutableGetScheduledCallsParams extends wasmtypes.ScProxy {
+	contract(): wasmtypes.ScImmutableHname {
+		return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamContract));
+	}
+}
+
+export class MutableGetScheduledCallsParams extends wasmtypes.ScProxy {
+	contract(): wasmtypes.ScMutableHname {
+		return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamContract));
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// (Re-)generated by schema tool
+// >>>> DO NOT CHANGE THIS FILE! <<<<
+// Change the json schema instead
+
+import * as wasmtypes from "wasmlib/wasmtypes";
+import * as sc from "./index";
+
+export class ImmutableScheduleResults extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScImmutableUint32 {
+		return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultCallID));
+	}
+}
+
+export class MutableScheduleResults extends wasmtypes.ScProxy {
+	callID(): wasmtypes.ScMutableUint32 {
+		return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultCallID));
+	}
+}
+
+export class ImmutableGetScheduledCallResults extends wasmtypes.ScProxy {
+	scheduledCall(): wasmtypes.ScImmutableBytes {
+		return new wasmtypes.ScImmutableBytes(this.proxy.root(sc.ResultScheduledCall));
+	}
+}
+
+export class MutableGetScheduledCallResults extends wasmtypes.ScProxy {
+	scheduledCall(): wasmtypes.ScMutableBytes {
+		return new wasmtypes.ScMutableBytes(this.proxy.root(sc.ResultScheduledCall));
+	}
+}
+
+export class MapUint32ToImmutableBytes extends wasmtypes.ScProxy {
+
+	getBytes(key: u32): wasmtypes.ScImmutableBytes {
+		return new wasmtypes.ScImmutableBytes(this.proxy.key(wasmtypes.uint32ToBytes(key)));
+	}
+}
+
+export class ImmutableGetScheduledCallsResults extends wasmtypes.ScProxy {
+	scheduledCalls(): sc.MapUint32ToImmutableBytes {
+		return new sc.MapUint32ToImmutableBytes(this.proxy);
+	}
+}
+
+export class MapUint32ToMutableBytes extends wasmtypes.ScProxy {
+
+	clear(): void {
+		this.proxy.clearMap();
+	}
+
+	getBytes(key: u32): wasmtypes.ScMutableBytes {
+		return new wasmtypes.ScMutableBytes(this.proxy.key(wasmtypes.uint32ToBytes(key)));
+	}
+}
+
+export class MutableGetScheduledCallsResults extends wasmtypes.ScProxy {
+	scheduledCalls(): sc.MapUint32ToMutableBytes {
+		return new sc.MapUint32ToMutableBytes(this.proxy);
+	}
+}
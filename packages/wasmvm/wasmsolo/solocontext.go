@@ -203,10 +203,6 @@ func soloContext(t *testing.T, chain *solo.Chain, scName string, creator *SoloAg
 
 // StartChain starts a new chain named chainName.
 func StartChain(t *testing.T, chainName string, env ...*solo.Solo) *solo.Chain {
-	if SoloDebug {
-		// avoid pesky timeouts during debugging
-		wasmhost.DisableWasmTimeout = true
-	}
 	wasmhost.HostTracing = SoloHostTracing
 
 	var soloEnv *solo.Solo
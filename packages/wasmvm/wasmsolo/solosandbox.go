@@ -77,7 +77,7 @@ type SoloSandbox struct {
 var _ wasmhost.ISandbox = new(SoloSandbox)
 
 func NewSoloSandbox(ctx *SoloContext) *SoloSandbox {
-	return &SoloSandbox{ctx: ctx, utils: sandbox_utils.NewUtils()}
+	return &SoloSandbox{ctx: ctx, utils: sandbox_utils.NewUtils(nil)}
 }
 
 func (s *SoloSandbox) Call(funcNr int32, params []byte) []byte {
@@ -52,6 +52,16 @@ func addChainEndpoints(adm echoswagger.ApiGroup, registryProvider registry.Provi
 	adm.GET(routes.GetChainInfo(":chainID"), c.handleGetChainInfo).
 		AddParamPath("", "chainID", "ChainID (base58)").
 		SetSummary("Get basic chain info.")
+
+	adm.GET(routes.ChainSnapshot(":chainID"), c.handleExportSnapshot).
+		AddParamPath("", "chainID", "ChainID (base58)").
+		AddParamQuery(uint32(0), "blockIndex", "Index of the block the snapshot is taken at (optional, default: latest block)", false).
+		SetSummary("Export a snapshot of the chain state")
+
+	adm.POST(routes.ChainSnapshot(":chainID"), c.handleImportSnapshot).
+		AddParamPath("", "chainID", "ChainID (base58)").
+		AddResponse(http.StatusOK, "Info of the imported snapshot", model.SnapshotInfo{}, nil).
+		SetSummary("Import a snapshot of the chain state. The chain must not be active")
 }
 
 func (w *chainWebAPI) handleActivateChain(c echo.Context) error {
@@ -30,6 +30,11 @@ func addDKSharesEndpoints(adm echoswagger.ApiGroup, registryProvider registry.Pr
 		Threshold:   3,
 		TimeoutMS:   10000,
 	}
+	reshareExample := model.DKSharesReshareRequest{
+		PeerPubKeys: []string{base64.StdEncoding.EncodeToString([]byte("key"))},
+		Threshold:   3,
+		TimeoutMS:   10000,
+	}
 	addr1 := iscp.RandomChainID().AsAddress()
 	infoExample := model.DKSharesInfo{
 		Address:      addr1.Base58(),
@@ -51,6 +56,12 @@ func addDKSharesEndpoints(adm echoswagger.ApiGroup, registryProvider registry.Pr
 		AddParamPath("", "sharedAddress", "Address of the DK share (base58)").
 		AddResponse(http.StatusOK, "DK shares info", infoExample, nil).
 		SetSummary("Get distributed key properties")
+
+	adm.POST(routes.DKSharesReshare(":sharedAddress"), s.handleDKSharesReshare).
+		AddParamPath("", "sharedAddress", "Address of the DK share (base58)").
+		AddParamBody(reshareExample, "DKSharesReshareRequest", "Request parameters", true).
+		AddResponse(http.StatusOK, "DK shares info", infoExample, nil).
+		SetSummary("Reshare the distributed key to a new committee, keeping its address")
 }
 
 type dkSharesService struct {
@@ -71,15 +82,8 @@ func (s *dkSharesService) handleDKSharesPost(c echo.Context) error {
 	}
 
 	var peerPubKeys []*ed25519.PublicKey
-	if req.PeerPubKeys != nil {
-		peerPubKeys = make([]*ed25519.PublicKey, len(req.PeerPubKeys))
-		for i := range req.PeerPubKeys {
-			peerPubKey, err := ed25519.PublicKeyFromString(req.PeerPubKeys[i])
-			if err != nil {
-				return httperrors.BadRequest(fmt.Sprintf("Invalid PeerPubKeys[%v]=%v", i, req.PeerPubKeys[i]))
-			}
-			peerPubKeys[i] = &peerPubKey
-		}
+	if peerPubKeys, err = parsePeerPubKeys(req.PeerPubKeys); err != nil {
+		return err
 	}
 
 	var dkShare *tcrypto.DKShare
@@ -121,6 +125,64 @@ func (s *dkSharesService) handleDKSharesGet(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+func (s *dkSharesService) handleDKSharesReshare(c echo.Context) error {
+	var req model.DKSharesReshareRequest
+	var err error
+
+	var sharedAddress ledgerstate.Address
+	if sharedAddress, err = ledgerstate.AddressFromBase58EncodedString(c.Param("sharedAddress")); err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Invalid shared address: %v", c.Param("sharedAddress")))
+	}
+	if err = c.Bind(&req); err != nil {
+		return httperrors.BadRequest("Invalid request body.")
+	}
+	if len(req.PeerPubKeys) < 1 {
+		return httperrors.BadRequest("PeerPubKeys are mandatory")
+	}
+
+	var peerPubKeys []*ed25519.PublicKey
+	if peerPubKeys, err = parsePeerPubKeys(req.PeerPubKeys); err != nil {
+		return err
+	}
+
+	var dkShare *tcrypto.DKShare
+	dkShare, err = s.dkgNode().ReshareDistributedKey(
+		sharedAddress,
+		peerPubKeys,
+		req.Threshold,
+		1*time.Second,
+		3*time.Second,
+		time.Duration(req.TimeoutMS)*time.Millisecond,
+	)
+	if err != nil {
+		if _, ok := err.(dkg.InvalidParamsError); ok {
+			return httperrors.BadRequest(err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	var response *model.DKSharesInfo
+	if response, err = makeDKSharesInfo(dkShare); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func parsePeerPubKeys(peerPubKeysStr []string) ([]*ed25519.PublicKey, error) {
+	if peerPubKeysStr == nil {
+		return nil, nil
+	}
+	peerPubKeys := make([]*ed25519.PublicKey, len(peerPubKeysStr))
+	for i := range peerPubKeysStr {
+		peerPubKey, err := ed25519.PublicKeyFromString(peerPubKeysStr[i])
+		if err != nil {
+			return nil, httperrors.BadRequest(fmt.Sprintf("Invalid PeerPubKeys[%v]=%v", i, peerPubKeysStr[i]))
+		}
+		peerPubKeys[i] = &peerPubKey
+	}
+	return peerPubKeys, nil
+}
+
 func makeDKSharesInfo(dkShare *tcrypto.DKShare) (*model.DKSharesInfo, error) {
 	var err error
 
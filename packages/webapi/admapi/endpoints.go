@@ -15,6 +15,8 @@ import (
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/wal"
+	"github.com/iotaledger/wasp/packages/webapi/webapiutil"
+	"github.com/iotaledger/wasp/packages/webhook"
 	"github.com/labstack/echo/v4"
 	"github.com/pangpanglabs/echoswagger/v2"
 )
@@ -36,24 +38,29 @@ func AddEndpoints(
 	shutdown ShutdownFunc,
 	metrics *metricspkg.Metrics,
 	w *wal.WAL,
+	webhooks *webhook.Dispatcher,
 ) {
 	initLogger()
 
-	isWhitelistEnabled := !parameters.GetBool(parameters.WebAPIAdminWhitelistDisabled)
-
-	echoGroup := adm.EchoGroup()
-
-	if isWhitelistEnabled {
-		echoGroup.Use(protected(adminWhitelist))
-	}
+	adm = protectedGroup(adm, adminWhitelist)
 
 	addShutdownEndpoint(adm, shutdown)
 	addNodeOwnerEndpoints(adm, registryProvider)
+	addKeystoreEndpoints(adm, registryProvider)
 	addChainRecordEndpoints(adm, registryProvider)
 	addChainMetricsEndpoints(adm, chainsProvider)
 	addChainEndpoints(adm, registryProvider, chainsProvider, network, metrics, w)
 	addDKSharesEndpoints(adm, registryProvider, nodeProvider)
 	addPeeringEndpoints(adm, network, tnm)
+	addWebhookEndpoints(adm, webhooks)
+}
+
+// protectedGroup adds the whitelist to the routes of the group, unless it is disabled
+func protectedGroup(adm echoswagger.ApiGroup, adminWhitelist []net.IP) echoswagger.ApiGroup {
+	if parameters.GetBool(parameters.WebAPIAdminWhitelistDisabled) {
+		return adm
+	}
+	return webapiutil.GroupWithMiddleware(adm, protected(adminWhitelist))
 }
 
 // allow only if the remote address is private or in whitelist
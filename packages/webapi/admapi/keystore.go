@@ -0,0 +1,71 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package admapi
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+)
+
+// AddKeystoreEndpoints adds only the keystore endpoints. They are served alone while the keystore is locked,
+// because the other endpoints need the node identity.
+func AddKeystoreEndpoints(adm echoswagger.ApiGroup, adminWhitelist []net.IP, registryProvider registry.Provider) {
+	initLogger()
+
+	addKeystoreEndpoints(protectedGroup(adm, adminWhitelist), registryProvider)
+}
+
+func addKeystoreEndpoints(adm echoswagger.ApiGroup, registryProvider registry.Provider) {
+	addCtx := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("reg", registryProvider)
+			return next(c)
+		}
+	}
+
+	adm.GET(routes.KeystoreStatus(), handleKeystoreStatus, addCtx).
+		AddResponse(http.StatusOK, "Keystore status", model.KeystoreStatus{Encrypted: true, Locked: true}, nil).
+		SetSummary("Get the status of the keystore")
+
+	adm.POST(routes.KeystoreUnlock(), handleKeystoreUnlock, addCtx).
+		AddParamBody(model.KeystoreUnlockRequest{Secret: model.NewBytes([]byte("passphrase"))}, "Request", "Keystore secret", true).
+		AddResponse(http.StatusOK, "Keystore unlocked", nil, nil).
+		SetSummary("Unlock the keystore, then start peering, DKG and the chains")
+}
+
+func handleKeystoreStatus(c echo.Context) error {
+	reg := c.Get("reg").(registry.Provider)()
+	return c.JSON(http.StatusOK, model.KeystoreStatus{
+		Encrypted: reg.IsKeystoreEncrypted(),
+		Locked:    reg.IsKeystoreLocked(),
+	})
+}
+
+func handleKeystoreUnlock(c echo.Context) error {
+	reg := c.Get("reg").(registry.Provider)()
+
+	var req model.KeystoreUnlockRequest
+	if err := c.Bind(&req); err != nil {
+		return httperrors.BadRequest("Invalid request body")
+	}
+	if !reg.IsKeystoreLocked() {
+		return httperrors.BadRequest("The keystore is not locked")
+	}
+	if err := reg.UnlockKeystore(req.Secret.Bytes()); err != nil {
+		if errors.Is(err, registry.ErrKeystoreWrongSecret) {
+			return httperrors.Unauthorized("Wrong keystore secret")
+		}
+		return httperrors.BadRequest(err.Error())
+	}
+	log.Info("keystore unlocked through the admin API")
+	return c.NoContent(http.StatusOK)
+}
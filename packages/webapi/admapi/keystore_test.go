@@ -0,0 +1,52 @@
+package admapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/iotaledger/wasp/packages/webapi/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystoreUnlock(t *testing.T) {
+	log = testlogger.NewLogger(t)
+	store := mapdb.NewMapDB()
+	secret := []byte("correct horse battery staple")
+	require.NoError(t, registry.NewRegistry(log, store).EncryptKeystore(secret))
+	reg := registry.NewRegistry(log, store)
+
+	withReg := func(handler echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("reg", registry.Provider(func() *registry.Impl { return reg }))
+			return handler(c)
+		}
+	}
+	status := func() model.KeystoreStatus {
+		var res model.KeystoreStatus
+		testutil.CallWebAPIRequestHandler(t, withReg(handleKeystoreStatus),
+			http.MethodGet, routes.KeystoreStatus(), nil, nil, &res, http.StatusOK)
+		return res
+	}
+	unlock := func(secret []byte, expectedStatus int) {
+		testutil.CallWebAPIRequestHandler(t, withReg(handleKeystoreUnlock),
+			http.MethodPost, routes.KeystoreUnlock(), nil,
+			model.KeystoreUnlockRequest{Secret: model.NewBytes(secret)}, nil, expectedStatus)
+	}
+
+	require.Equal(t, model.KeystoreStatus{Encrypted: true, Locked: true}, status())
+	unlock([]byte("wrong"), http.StatusUnauthorized)
+	require.True(t, reg.IsKeystoreLocked())
+
+	unlock(secret, http.StatusOK)
+	require.Equal(t, model.KeystoreStatus{Encrypted: true, Locked: false}, status())
+	_, err := reg.GetNodeIdentity()
+	require.NoError(t, err)
+
+	unlock(secret, http.StatusBadRequest)
+}
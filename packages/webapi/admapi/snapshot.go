@@ -0,0 +1,96 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package admapi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
+)
+
+// maxSnapshotAttempts is the number of times the snapshot of the latest state is taken again
+// when a block is committed while writing it
+const maxSnapshotAttempts = 3
+
+func (w *chainWebAPI) handleExportSnapshot(c echo.Context) error {
+	chainID, err := iscp.ChainIDFromBase58(c.Param("chainID"))
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Invalid chain id: %s", c.Param("chainID")))
+	}
+	ch := w.chains().Get(chainID, true)
+	if ch == nil {
+		return httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID))
+	}
+	var blockIndex uint32
+	if s := c.QueryParam("blockIndex"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return httperrors.BadRequest(fmt.Sprintf("Invalid block index: %s", s))
+		}
+		blockIndex = uint32(n)
+	} else if blockIndex, err = ch.GetStateReader().BlockIndex(); err != nil {
+		return err
+	}
+
+	// the snapshot is written to a temporary file rather than held in memory, because it contains
+	// the whole state. It can't be streamed to the client directly: the attempt may have to be repeated
+	f, err := os.CreateTemp("", "snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	for i := 0; ; i++ {
+		if err = resetFile(f); err != nil {
+			return err
+		}
+		buf := bufio.NewWriter(f)
+		if _, err = w.chains().ExportSnapshot(buf, chainID, blockIndex); err == nil {
+			err = buf.Flush()
+		}
+		if err == nil || i+1 >= maxSnapshotAttempts || !xerrors.Is(err, state.ErrStateChangedDuringSnapshot) {
+			break
+		}
+	}
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Cannot export snapshot: %v", err))
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return c.Stream(http.StatusOK, echo.MIMEOctetStream, f)
+}
+
+func resetFile(f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.Seek(0, io.SeekStart)
+	return err
+}
+
+func (w *chainWebAPI) handleImportSnapshot(c echo.Context) error {
+	chainID, err := iscp.ChainIDFromBase58(c.Param("chainID"))
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Invalid chain id: %s", c.Param("chainID")))
+	}
+	info, err := w.chains().ImportSnapshot(chainID, c.Request().Body)
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Cannot import snapshot: %v", err))
+	}
+	log.Infof("imported snapshot of chain %s at block #%d, state hash %s",
+		chainID.Base58(), info.BlockIndex, info.StateHash.String())
+	return c.JSON(http.StatusOK, model.NewSnapshotInfo(info))
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package admapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/iotaledger/wasp/packages/webhook"
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+)
+
+func addWebhookEndpoints(adm echoswagger.ApiGroup, dispatcher *webhook.Dispatcher) {
+	if dispatcher == nil {
+		// webhooks are disabled in the node
+		return
+	}
+	example := &model.WebhookSubscription{
+		ID:         "3f5e9c1a7b2d4e60",
+		URL:        "https://example.com/wasp-events",
+		ChainID:    "jn52vSuUvsJ9TQHnDMnSRKmwZ2qZiSw9bDWcq2AgFrQm",
+		Contract:   "cebf5908",
+		EventTypes: []string{publisher.ChainEventRequestReceipt, publisher.ChainEventContractEvent},
+	}
+
+	addCtx := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("dispatcher", dispatcher)
+			return next(c)
+		}
+	}
+
+	adm.GET(routes.WebhookList(), handleWebhookList, addCtx).
+		AddResponse(http.StatusOK, "A list of webhook subscriptions.", []*model.WebhookSubscription{example}, nil).
+		SetSummary("Get the webhook subscriptions of the node.")
+
+	adm.POST(routes.WebhookPost(), handleWebhookPost, addCtx).
+		AddParamBody(example, "WebhookSubscription", "The subscription to add. The ID is assigned by the node.", true).
+		AddResponse(http.StatusOK, "The added subscription.", example, nil).
+		SetSummary("Subscribe an HTTP endpoint to the chain events.")
+
+	adm.GET(routes.WebhookGet(":id"), handleWebhookGet, addCtx).
+		AddParamPath(example.ID, "id", "ID of the subscription.").
+		AddResponse(http.StatusOK, "The subscription.", example, nil).
+		SetSummary("Get a webhook subscription.")
+
+	adm.DELETE(routes.WebhookDelete(":id"), handleWebhookDelete, addCtx).
+		AddParamPath(example.ID, "id", "ID of the subscription.").
+		SetSummary("Delete a webhook subscription and its pending deliveries.")
+}
+
+func handleWebhookList(c echo.Context) error {
+	dispatcher := c.Get("dispatcher").(*webhook.Dispatcher)
+	subs := dispatcher.GetSubscriptions()
+	response := make([]*model.WebhookSubscription, len(subs))
+	for i, sub := range subs {
+		response[i] = model.NewWebhookSubscription(sub)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func handleWebhookPost(c echo.Context) error {
+	dispatcher := c.Get("dispatcher").(*webhook.Dispatcher)
+	req := model.WebhookSubscription{}
+	if err := c.Bind(&req); err != nil {
+		return httperrors.BadRequest("Invalid request body.")
+	}
+	req.ID = ""
+	sub, err := req.Subscription()
+	if err != nil {
+		return httperrors.BadRequest(err.Error())
+	}
+	sub, err = dispatcher.AddSubscription(sub)
+	if err != nil {
+		return httperrors.BadRequest(err.Error())
+	}
+	return c.JSON(http.StatusOK, model.NewWebhookSubscription(sub))
+}
+
+func handleWebhookGet(c echo.Context) error {
+	dispatcher := c.Get("dispatcher").(*webhook.Dispatcher)
+	sub, err := dispatcher.GetSubscription(c.Param("id"))
+	if err != nil {
+		return httperrors.NotFound(err.Error())
+	}
+	return c.JSON(http.StatusOK, model.NewWebhookSubscription(sub))
+}
+
+func handleWebhookDelete(c echo.Context) error {
+	dispatcher := c.Get("dispatcher").(*webhook.Dispatcher)
+	err := dispatcher.DeleteSubscription(c.Param("id"))
+	if errors.Is(err, webhook.ErrSubscriptionNotFound) {
+		return httperrors.NotFound(err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -14,13 +14,16 @@ import (
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/util/auth"
 	"github.com/iotaledger/wasp/packages/wal"
 	"github.com/iotaledger/wasp/packages/webapi/admapi"
 	"github.com/iotaledger/wasp/packages/webapi/info"
+	"github.com/iotaledger/wasp/packages/webapi/login"
 	"github.com/iotaledger/wasp/packages/webapi/reqstatus"
 	"github.com/iotaledger/wasp/packages/webapi/request"
 	"github.com/iotaledger/wasp/packages/webapi/state"
 	"github.com/iotaledger/wasp/packages/webapi/webapiutil"
+	"github.com/iotaledger/wasp/packages/webhook"
 	"github.com/labstack/echo/v4"
 	"github.com/pangpanglabs/echoswagger/v2"
 )
@@ -38,20 +41,23 @@ func Init(
 	shutdown admapi.ShutdownFunc,
 	metrics *metricspkg.Metrics,
 	w *wal.WAL,
+	webhooks *webhook.Dispatcher,
+	jwtAuth *auth.JWTAuth,
 ) {
 	log = logger.NewLogger("WebAPI")
 
 	server.SetRequestContentType(echo.MIMEApplicationJSON)
 	server.SetResponseContentType(echo.MIMEApplicationJSON)
 
-	pub := server.Group("public", "").SetDescription("Public endpoints")
-	addWebSocketEndpoint(pub, log)
+	pub, reqs, adm := addGroups(server, jwtAuth)
+
+	addWebSocketEndpoint(pub, log, chainsProvider.ChainProvider())
 
 	info.AddEndpoints(pub, network)
 	reqstatus.AddEndpoints(pub, chainsProvider.ChainProvider())
-	state.AddEndpoints(pub, chainsProvider)
+	state.AddEndpoints(pub, chainsProvider.ChainProvider())
 	request.AddEndpoints(
-		pub,
+		reqs,
 		chainsProvider.ChainProvider(),
 		webapiutil.GetAccountBalance,
 		webapiutil.HasRequestBeenProcessed,
@@ -60,7 +66,6 @@ func Init(
 		log,
 	)
 
-	adm := server.Group("admin", "").SetDescription("Admin endpoints")
 	admapi.AddEndpoints(
 		adm,
 		adminWhitelist,
@@ -72,6 +77,42 @@ func Init(
 		shutdown,
 		metrics,
 		w,
+		webhooks,
 	)
 	log.Infof("added web api endpoints")
 }
+
+// InitLocked adds only the endpoints to unlock the keystore, for the node to serve while it is locked.
+func InitLocked(server echoswagger.ApiRoot, adminWhitelist []net.IP, registryProvider registry.Provider) {
+	log = logger.NewLogger("WebAPI")
+
+	server.SetRequestContentType(echo.MIMEApplicationJSON)
+	server.SetResponseContentType(echo.MIMEApplicationJSON)
+
+	adm := server.Group("admin", "").SetDescription("Admin endpoints")
+	admapi.AddKeystoreEndpoints(adm, adminWhitelist, registryProvider)
+	log.Infof("added web api keystore endpoints")
+}
+
+// addGroups adds the route groups of the web API.
+// When JWT authentication is enabled, each group requires its own scope
+func addGroups(server echoswagger.ApiRoot, jwtAuth *auth.JWTAuth) (pub, reqs, adm echoswagger.ApiGroup) {
+	pub = server.Group("public", "").SetDescription("Public endpoints")
+	reqs = server.Group("requests", "").SetDescription("Request submission endpoints")
+	adm = server.Group("admin", "").SetDescription("Admin endpoints")
+
+	if jwtAuth != nil {
+		server.AddSecurityAPIKey("Authorization", "JWT token: 'Bearer <token>'", echoswagger.SecurityInHeader)
+		pub = requireScope(pub, jwtAuth, auth.ScopeRead)
+		reqs = requireScope(reqs, jwtAuth, auth.ScopeWriteRequests)
+		adm = requireScope(adm, jwtAuth, auth.ScopeAdmin)
+
+		login.AddEndpoints(server.Group("auth", "").SetDescription("Authentication endpoints"), jwtAuth)
+	}
+	return pub, reqs, adm
+}
+
+func requireScope(group echoswagger.ApiGroup, jwtAuth *auth.JWTAuth, scope string) echoswagger.ApiGroup {
+	group.SetSecurity("Authorization")
+	return webapiutil.GroupWithMiddleware(group, jwtAuth.RequireScope(scope))
+}
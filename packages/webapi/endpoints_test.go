@@ -0,0 +1,72 @@
+package webapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/util/auth"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteGroupScopes(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	jwtAuth, err := auth.NewJWTAuth(&keyPair, time.Hour, nil, []string{auth.ScopeRead})
+	require.NoError(t, err)
+
+	server := echoswagger.New(echo.New(), "/doc", &echoswagger.Info{Title: "test"})
+	pub, reqs, adm := addGroups(server, jwtAuth)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	pub.GET(routes.Info(), ok)
+	reqs.POST(routes.NewRequest(":chainID"), ok)
+	adm.GET(routes.Shutdown(), ok)
+
+	token := func(scopes ...string) string {
+		ret, _, err := jwtAuth.IssueToken("test", scopes)
+		require.NoError(t, err)
+		return ret
+	}
+	check := func(method, path, token string, expectedStatus int) {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		server.Echo().ServeHTTP(rec, req)
+		require.Equal(t, expectedStatus, rec.Code, "%s %s", method, path)
+	}
+	info := routes.Info()
+	newRequest := routes.NewRequest("chain")
+	shutdown := routes.Shutdown()
+
+	// the public scope
+	check(http.MethodGet, info, "", http.StatusOK)
+	check(http.MethodPost, newRequest, "", http.StatusUnauthorized)
+	check(http.MethodGet, shutdown, "", http.StatusUnauthorized)
+
+	read := token(auth.ScopeRead)
+	check(http.MethodGet, info, read, http.StatusOK)
+	check(http.MethodPost, newRequest, read, http.StatusForbidden)
+	check(http.MethodGet, shutdown, read, http.StatusForbidden)
+
+	write := token(auth.ScopeWriteRequests)
+	check(http.MethodGet, info, write, http.StatusOK)
+	check(http.MethodPost, newRequest, write, http.StatusOK)
+	check(http.MethodGet, shutdown, write, http.StatusForbidden)
+
+	// the admin scope implies the others
+	admin := token(auth.ScopeAdmin)
+	check(http.MethodGet, info, admin, http.StatusOK)
+	check(http.MethodPost, newRequest, admin, http.StatusOK)
+	check(http.MethodGet, shutdown, admin, http.StatusOK)
+
+	// unknown routes are not caught by the groups
+	check(http.MethodGet, "/unknown", "", http.StatusNotFound)
+	check(http.MethodGet, "/adm/unknown", "", http.StatusNotFound)
+	check(http.MethodGet, "/adm/unknown", admin, http.StatusNotFound)
+}
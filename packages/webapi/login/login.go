@@ -0,0 +1,61 @@
+package login
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/util/auth"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pangpanglabs/echoswagger/v2"
+)
+
+// The login attempts are limited per client, to slow down guessing the passwords
+const (
+	loginAttemptsPerSecond = 0.2
+	loginAttemptsBurst     = 5
+)
+
+type loginService struct {
+	jwtAuth   *auth.JWTAuth
+	loginRate *middleware.RateLimiterMemoryStore
+}
+
+func newLoginService(jwtAuth *auth.JWTAuth) *loginService {
+	return &loginService{
+		jwtAuth: jwtAuth,
+		loginRate: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  loginAttemptsPerSecond,
+			Burst: loginAttemptsBurst,
+		}),
+	}
+}
+
+func AddEndpoints(server echoswagger.ApiRouter, jwtAuth *auth.JWTAuth) {
+	s := newLoginService(jwtAuth)
+
+	server.POST(routes.Login(), s.handleLogin).
+		SetSummary("Get a JWT token for the given user").
+		AddParamBody(model.LoginRequest{Username: "wasp", Password: "wasp"}, "Request", "User credentials", true).
+		AddResponse(http.StatusOK, "JWT token", model.LoginResponse{JWT: "token", ExpiresAt: time.Now()}, nil).
+		AddResponse(http.StatusUnauthorized, "Invalid credentials", nil, nil).
+		AddResponse(http.StatusTooManyRequests, "Too many login attempts", nil, nil)
+}
+
+func (s *loginService) handleLogin(c echo.Context) error {
+	if allow, err := s.loginRate.Allow(c.RealIP()); err != nil || !allow {
+		return httperrors.TooManyRequests("Too many login attempts, retry later")
+	}
+	var req model.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return httperrors.BadRequest("Invalid request body")
+	}
+	token, expiresAt, err := s.jwtAuth.Login(req.Username, req.Password)
+	if err != nil {
+		return httperrors.Unauthorized("Invalid credentials")
+	}
+	return c.JSON(http.StatusOK, model.LoginResponse{JWT: token, ExpiresAt: expiresAt})
+}
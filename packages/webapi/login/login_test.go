@@ -0,0 +1,45 @@
+package login
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/packages/util/auth"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/iotaledger/wasp/packages/webapi/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginRateLimit(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	jwtAuth, err := auth.NewJWTAuth(&keyPair, time.Hour, map[string]*auth.User{
+		"reader": {Password: "r", Scopes: []string{auth.ScopeRead}},
+	}, nil)
+	require.NoError(t, err)
+	s := newLoginService(jwtAuth)
+
+	login := func(password string, res interface{}, expectedStatus int) {
+		testutil.CallWebAPIRequestHandler(
+			t,
+			s.handleLogin,
+			http.MethodPost,
+			routes.Login(),
+			nil,
+			model.LoginRequest{Username: "reader", Password: password},
+			res,
+			expectedStatus,
+		)
+	}
+
+	var res model.LoginResponse
+	login("r", &res, http.StatusOK)
+	require.NotEmpty(t, res.JWT)
+	for i := 1; i < loginAttemptsBurst; i++ {
+		login("wrong", nil, http.StatusUnauthorized)
+	}
+	// even the right password is rejected once the client used up its attempts
+	login("r", nil, http.StatusTooManyRequests)
+}
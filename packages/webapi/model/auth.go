@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type LoginRequest struct {
+	Username string `swagger:"desc(Name of the user)"`
+	Password string `swagger:"desc(Password of the user)"`
+}
+
+type LoginResponse struct {
+	JWT       string    `swagger:"desc(JWT token to be sent as 'Authorization: Bearer <token>')"`
+	ExpiresAt time.Time `swagger:"desc(Expiration time of the token)"`
+}
@@ -10,6 +10,13 @@ type DKSharesPostRequest struct {
 	TimeoutMS   uint32   `json:"timeoutMS" swagger:"desc(Timeout in milliseconds.)"`
 }
 
+// DKSharesReshareRequest is a POST request for resharing an existing DKShare to a new set of peers.
+type DKSharesReshareRequest struct {
+	PeerPubKeys []string `json:"peerPubKeys" swagger:"desc(Base64 encoded public keys of the peers of the new committee.)"`
+	Threshold   uint16   `json:"threshold" swagger:"desc(Should be =< len(PeerPubKeys))"`
+	TimeoutMS   uint32   `json:"timeoutMS" swagger:"desc(Timeout in milliseconds.)"`
+}
+
 // DKSharesInfo stands for the DKShare representation, returned by the GET and POST methods.
 type DKSharesInfo struct {
 	Address      string   `json:"address" swagger:"desc(New generated shared address.)"`
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm"
+)
+
+type DryRunEvent struct {
+	Contract string `swagger:"desc(Hname of the contract which emitted the event)"`
+	Message  string `swagger:"desc(Event message)"`
+}
+
+type DryRunResponse struct {
+	Result    dict.Dict     `swagger:"desc(Result returned by the called function)"`
+	Error     string        `swagger:"desc(Error returned by the request. Empty on success)"`
+	Events    []DryRunEvent `swagger:"desc(Events the request would emit)"`
+	GasBudget uint64        `swagger:"desc(Gas budget of the request)"`
+	GasBurned uint64        `swagger:"desc(Gas burned by the request)"`
+	FeeColor  Color         `swagger:"desc(Color of the fee tokens)"`
+	Fee       uint64        `swagger:"desc(Fee charged for the request)"`
+	Outputs   []Bytes       `swagger:"desc(Outputs the request would create on the ledger, not including the chain output (base64))"`
+}
+
+func NewDryRunResponse(res *vm.DryRunResult) *DryRunResponse {
+	ret := &DryRunResponse{
+		Result:    res.Result,
+		Events:    make([]DryRunEvent, len(res.Events)),
+		GasBudget: res.GasBudget,
+		GasBurned: res.GasBurned,
+		FeeColor:  Color(res.FeeColor.Base58()),
+		Fee:       res.Fee,
+		Outputs:   make([]Bytes, len(res.Outputs)),
+	}
+	if res.Error != nil {
+		ret.Error = res.Error.Error()
+	}
+	for i, evt := range res.Events {
+		ret.Events[i] = DryRunEvent{Contract: evt.Contract.String(), Message: evt.Message}
+	}
+	for i, out := range res.Outputs {
+		ret.Outputs[i] = NewBytes(out.Bytes())
+	}
+	return ret
+}
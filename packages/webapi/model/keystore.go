@@ -0,0 +1,13 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+type KeystoreUnlockRequest struct {
+	Secret Bytes `swagger:"desc(Passphrase or key of the keystore. (base64))"`
+}
+
+type KeystoreStatus struct {
+	Encrypted bool `swagger:"desc(Whether the node identity and the DKShares are encrypted)"`
+	Locked    bool `swagger:"desc(Whether the keystore is waiting to be unlocked)"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// OutputID is the base58 representation of an output ID
+type OutputID string
+
+func NewOutputID(id ledgerstate.OutputID) OutputID {
+	return OutputID(id.Base58())
+}
+
+func (id OutputID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(id))
+}
+
+func (id *OutputID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	_, err := ledgerstate.OutputIDFromBase58(s)
+	*id = OutputID(s)
+	return err
+}
+
+func (id OutputID) ID() (ledgerstate.OutputID, error) {
+	return ledgerstate.OutputIDFromBase58(string(id))
+}
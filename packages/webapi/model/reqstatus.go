@@ -10,4 +10,15 @@ type RequestStatusResponse struct {
 	IsProcessed bool `swagger:"desc(True if the request has been processed)"`
 }
 
+type PendingRequestsResponse struct {
+	Total    int     `swagger:"desc(Number of requests waiting in the mempool of the node)"`
+	Requests []Bytes `swagger:"desc(Requests of the page waiting in the mempool of the node (base64))"`
+}
+
+// limits of the pages of PendingRequestsResponse
+const (
+	PendingRequestsDefaultLimit = 100
+	PendingRequestsMaxLimit     = 1000
+)
+
 const WaitRequestProcessedDefaultTimeout = 30 * time.Second
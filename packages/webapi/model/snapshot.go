@@ -0,0 +1,22 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "github.com/iotaledger/wasp/packages/state"
+
+type SnapshotInfo struct {
+	ChainID           ChainID   `swagger:"desc(ChainID (base58-encoded))"`
+	BlockIndex        uint32    `swagger:"desc(Index of the block the snapshot was taken at)"`
+	StateHash         HashValue `swagger:"desc(Hash of the state after the block)"`
+	ApprovingOutputID OutputID  `swagger:"desc(ID of the alias output which approved the block)"`
+}
+
+func NewSnapshotInfo(info *state.SnapshotInfo) *SnapshotInfo {
+	return &SnapshotInfo{
+		ChainID:           NewChainID(info.ChainID),
+		BlockIndex:        info.BlockIndex,
+		StateHash:         NewHashValue(info.StateHash),
+		ApprovingOutputID: NewOutputID(info.ApprovingOutputID),
+	}
+}
@@ -0,0 +1,11 @@
+package model
+
+type StateProof struct {
+	Key           Bytes     `swagger:"desc(Key (base64-encoded))"`
+	Value         Bytes     `swagger:"desc(Value (base64-encoded). Empty if the key is absent from the state)"`
+	Exists        bool      `swagger:"desc(Whether or not the key exists in the state)"`
+	Proof         Bytes     `swagger:"desc(Proof of inclusion or absence of the key (base64-encoded))"`
+	BlockIndex    uint32    `swagger:"desc(Index of the block of the state)"`
+	StateHash     HashValue `swagger:"desc(Hash of the state the proof is valid against)"`
+	StateOutputID OutputID  `swagger:"desc(ID of the alias output which anchors the state hash on the ledger)"`
+}
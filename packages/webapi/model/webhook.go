@@ -0,0 +1,56 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/webhook"
+)
+
+// WebhookSubscription describes an HTTP endpoint the chain events are delivered to.
+type WebhookSubscription struct {
+	ID         string   `json:"id" swagger:"desc(ID of the subscription. Assigned by the node)"`
+	URL        string   `json:"url" swagger:"desc(URL the events are POSTed to)"`
+	ChainID    string   `json:"chainID" swagger:"desc(Selects the events of a chain (base58-encoded). Empty means all chains)"`
+	Contract   string   `json:"contract" swagger:"desc(Selects the receipts and events of a contract (hname). Empty means all)"`
+	EventTypes []string `json:"eventTypes" swagger:"desc(Selects the types of the events. Empty means all types)"`
+}
+
+func NewWebhookSubscription(sub *webhook.Subscription) *WebhookSubscription {
+	ret := &WebhookSubscription{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+	}
+	if sub.ChainID != nil {
+		ret.ChainID = sub.ChainID.Base58()
+	}
+	if sub.Contract != 0 {
+		ret.Contract = sub.Contract.String()
+	}
+	if ret.EventTypes == nil {
+		ret.EventTypes = []string{}
+	}
+	return ret
+}
+
+func (s *WebhookSubscription) Subscription() (*webhook.Subscription, error) {
+	ret := &webhook.Subscription{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+	}
+	var err error
+	if s.ChainID != "" {
+		if ret.ChainID, err = iscp.ChainIDFromBase58(s.ChainID); err != nil {
+			return nil, err
+		}
+	}
+	if s.Contract != "" {
+		if ret.Contract, err = iscp.HnameFromString(s.Contract); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
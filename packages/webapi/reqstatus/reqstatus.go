@@ -1,8 +1,12 @@
 package reqstatus
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/iotaledger/wasp/packages/chain"
@@ -35,6 +39,14 @@ func AddEndpoints(server echoswagger.ApiRouter, getChain chains.ChainProvider) {
 		AddParamPath("", "chainID", "ChainID (base58)").
 		AddParamPath("", "reqID", "Request ID (base58)").
 		AddParamBody(model.WaitRequestProcessedParams{}, "Params", "Optional parameters", false)
+
+	server.GET(routes.PendingRequests(":chainID"), r.handlePendingRequests).
+		SetSummary("Get the requests waiting to be processed in the mempool of the node, ordered by request ID").
+		AddParamPath("", "chainID", "ChainID (base58)").
+		AddParamQuery(0, "offset", "Number of requests to skip", false).
+		AddParamQuery(model.PendingRequestsDefaultLimit, "limit",
+			fmt.Sprintf("Maximum number of requests returned, at most %d", model.PendingRequestsMaxLimit), false).
+		AddResponse(http.StatusOK, "Pending requests", model.PendingRequestsResponse{}, nil)
 }
 
 func (r *reqstatusWebAPI) handleRequestStatus(c echo.Context) error {
@@ -95,14 +107,68 @@ func (r *reqstatusWebAPI) handleWaitRequestProcessed(c echo.Context) error {
 	}
 }
 
-func (r *reqstatusWebAPI) parseParams(c echo.Context) (chain.ChainRequests, iscp.RequestID, error) {
+func (r *reqstatusWebAPI) handlePendingRequests(c echo.Context) error {
+	ch, err := r.parseChainID(c)
+	if err != nil {
+		return err
+	}
+	offset, err := parseIntQueryParam(c, "offset", 0, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	limit, err := parseIntQueryParam(c, "limit", model.PendingRequestsDefaultLimit, model.PendingRequestsMaxLimit)
+	if err != nil {
+		return err
+	}
+	reqs := ch.GetPendingRequests()
+	// the order of the mempool is not stable, the pages are taken in the order of the request IDs
+	sort.Slice(reqs, func(i, j int) bool {
+		return bytes.Compare(reqs[i].ID().Bytes(), reqs[j].ID().Bytes()) < 0
+	})
+	res := model.PendingRequestsResponse{
+		Total:    len(reqs),
+		Requests: make([]model.Bytes, 0),
+	}
+	if offset < len(reqs) {
+		reqs = reqs[offset:]
+		if len(reqs) > limit {
+			reqs = reqs[:limit]
+		}
+		for _, req := range reqs {
+			res.Requests = append(res.Requests, model.NewBytes(req.Bytes()))
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func parseIntQueryParam(c echo.Context, name string, def, max int) (int, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > max {
+		return 0, httperrors.BadRequest(fmt.Sprintf("Invalid %s: %q, expected a number from 0 to %d", name, s, max))
+	}
+	return n, nil
+}
+
+func (r *reqstatusWebAPI) parseChainID(c echo.Context) (chain.ChainRequests, error) {
 	chainID, err := iscp.ChainIDFromBase58(c.Param("chainID"))
 	if err != nil {
-		return nil, iscp.RequestID{}, httperrors.BadRequest(fmt.Sprintf("Invalid Chain ID %+v: %s", c.Param("chainID"), err.Error()))
+		return nil, httperrors.BadRequest(fmt.Sprintf("Invalid Chain ID %+v: %s", c.Param("chainID"), err.Error()))
 	}
 	theChain := r.getChain(chainID)
 	if theChain == nil {
-		return nil, iscp.RequestID{}, httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID.String()))
+		return nil, httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID.String()))
+	}
+	return theChain, nil
+}
+
+func (r *reqstatusWebAPI) parseParams(c echo.Context) (chain.ChainRequests, iscp.RequestID, error) {
+	theChain, err := r.parseChainID(c)
+	if err != nil {
+		return nil, iscp.RequestID{}, err
 	}
 	reqID, err := iscp.RequestIDFromBase58(c.Param("reqID"))
 	if err != nil {
@@ -1,20 +1,28 @@
 package reqstatus
 
 import (
+	"bytes"
 	"net/http"
+	"sort"
 	"testing"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/webapi/model"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
 	"github.com/iotaledger/wasp/packages/webapi/testutil"
 	"github.com/stretchr/testify/require"
 )
 
-type mockChain struct{}
+type mockChain struct {
+	pending []iscp.Request
+}
 
 var _ chain.ChainRequests = &mockChain{}
 
@@ -22,6 +30,10 @@ func (m *mockChain) GetRequestProcessingStatus(id iscp.RequestID) chain.RequestP
 	return chain.RequestProcessingStatusCompleted
 }
 
+func (m *mockChain) GetPendingRequests() []iscp.Request {
+	return m.pending
+}
+
 func (m *mockChain) AttachToRequestProcessed(func(iscp.RequestID)) (attachID *events.Closure) {
 	panic("not implemented")
 }
@@ -30,6 +42,10 @@ func (m *mockChain) DetachFromRequestProcessed(attachID *events.Closure) {
 	panic("not implemented")
 }
 
+func (m *mockChain) DryRunRequest(req iscp.Request) (*vm.DryRunResult, error) {
+	panic("not implemented")
+}
+
 func TestRequestStatus(t *testing.T) {
 	r := &reqstatusWebAPI{func(chainID *iscp.ChainID) chain.ChainRequests {
 		return &mockChain{}
@@ -55,3 +71,69 @@ func TestRequestStatus(t *testing.T) {
 
 	require.True(t, res.IsProcessed)
 }
+
+func TestPendingRequests(t *testing.T) {
+	chainID := iscp.RandomChainID()
+	pending := make([]iscp.Request, 5)
+	for i := range pending {
+		req := request.NewOffLedger(chainID, iscp.Hn("test"), iscp.Hn("test"), nil)
+		req.WithNonce(uint64(i))
+		keyPair := ed25519.GenerateKeyPair()
+		req.Sign(&keyPair)
+		pending[i] = req
+	}
+	sorted := make([]iscp.Request, len(pending))
+	copy(sorted, pending)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].ID().Bytes(), sorted[j].ID().Bytes()) < 0
+	})
+
+	r := &reqstatusWebAPI{func(chainID *iscp.ChainID) chain.ChainRequests {
+		p := make([]iscp.Request, len(pending))
+		copy(p, pending)
+		return &mockChain{pending: p}
+	}}
+
+	getPage := func(query string) *model.PendingRequestsResponse {
+		var res model.PendingRequestsResponse
+		testutil.CallWebAPIRequestHandler(
+			t,
+			r.handlePendingRequests,
+			http.MethodGet,
+			routes.PendingRequests(":chainID")+query,
+			map[string]string{"chainID": chainID.Base58()},
+			nil,
+			&res,
+			http.StatusOK,
+		)
+		return &res
+	}
+
+	res := getPage("")
+	require.EqualValues(t, 5, res.Total)
+	require.Len(t, res.Requests, 5)
+
+	res = getPage("?offset=1&limit=3")
+	require.EqualValues(t, 5, res.Total)
+	require.Len(t, res.Requests, 3)
+	for i, data := range res.Requests {
+		decoded, err := request.FromMarshalUtil(marshalutil.New(data.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, sorted[1+i].ID(), decoded.ID())
+	}
+
+	res = getPage("?offset=10")
+	require.EqualValues(t, 5, res.Total)
+	require.Empty(t, res.Requests)
+
+	testutil.CallWebAPIRequestHandler(
+		t,
+		r.handlePendingRequests,
+		http.MethodGet,
+		routes.PendingRequests(":chainID")+"?limit=100000",
+		map[string]string{"chainID": chainID.Base58()},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,56 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/labstack/echo/v4"
+)
+
+// maxDryRuns is the maximum number of dry runs the node executes at the same time
+const maxDryRuns = 4
+
+// handleDryRun runs the request on the current state of the chain and returns the outcome.
+// The request is not added to the mempool and nothing is committed.
+// The signature of an off-ledger request is not checked, the request is run with the sender it claims.
+// An on-ledger request does not need to be posted to the ledger
+func (o *offLedgerReqAPI) handleDryRun(c echo.Context) error {
+	chainID, req, err := parseRequest(c)
+	if err != nil {
+		return err
+	}
+
+	switch req := req.(type) {
+	case *request.OffLedger:
+		if !req.ChainID().Equals(chainID) {
+			return httperrors.BadRequest("Request is for a different chain")
+		}
+	case *request.OnLedger:
+		if !req.Output().Address().Equals(chainID.AsAddress()) {
+			return httperrors.BadRequest("Request is for a different chain")
+		}
+	default:
+		return httperrors.BadRequest("Error parsing request: off-ledger or on-ledger request expected")
+	}
+
+	ch := o.getChain(chainID)
+	if ch == nil {
+		return httperrors.NotFound(fmt.Sprintf("Unknown chain: %s", chainID.Base58()))
+	}
+
+	select {
+	case o.dryRunning <- struct{}{}:
+		defer func() { <-o.dryRunning }()
+	default:
+		return httperrors.TooManyRequests("Too many dry runs running, retry later")
+	}
+
+	res, err := ch.DryRunRequest(req)
+	if err != nil {
+		return httperrors.ServerError(fmt.Sprintf("Dry run failed: %v", err))
+	}
+	return c.JSON(http.StatusOK, model.NewDryRunResponse(res))
+}
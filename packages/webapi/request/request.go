@@ -45,6 +45,7 @@ func AddEndpoints(
 		requestsCache:           expiringcache.New(cacheTTL),
 		nodePubKey:              nodePubKey,
 		log:                     log,
+		dryRunning:              make(chan struct{}, maxDryRuns),
 	}
 	server.POST(routes.NewRequest(":chainID"), instance.handleNewRequest).
 		SetSummary("New off-ledger request").
@@ -55,6 +56,16 @@ func AddEndpoints(
 			"Offledger Request encoded in base64. Optionally, the body can be the binary representation of the offledger request, but mime-type must be specified to \"application/octet-stream\"",
 			false).
 		AddResponse(http.StatusAccepted, "Request submitted", nil, nil)
+
+	server.POST(routes.DryRunRequest(":chainID"), instance.handleDryRun).
+		SetSummary("Run a request on the current state without committing anything").
+		AddParamPath("", "chainID", "chainID represented in base58").
+		AddParamBody(
+			model.OffLedgerRequestBody{Request: "base64 string"},
+			"Request",
+			"Off-ledger or on-ledger request encoded in base64. The off-ledger request does not need to be signed. Optionally, the body can be the binary representation of the request, but mime-type must be specified to \"application/octet-stream\"",
+			false).
+		AddResponse(http.StatusOK, "Outcome of the request", model.DryRunResponse{}, nil)
 }
 
 type offLedgerReqAPI struct {
@@ -64,6 +75,8 @@ type offLedgerReqAPI struct {
 	requestsCache           *expiringcache.ExpiringCache
 	nodePubKey              *ed25519.PublicKey
 	log                     *logger.Logger
+	// dryRunning limits the number of dry runs executed at the same time
+	dryRunning chan struct{}
 }
 
 func (o *offLedgerReqAPI) handleNewRequest(c echo.Context) error {
@@ -131,6 +144,18 @@ func (o *offLedgerReqAPI) handleNewRequest(c echo.Context) error {
 }
 
 func parseParams(c echo.Context) (chainID *iscp.ChainID, req *request.OffLedger, err error) {
+	chainID, rGeneric, err := parseRequest(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, ok := rGeneric.(*request.OffLedger)
+	if !ok {
+		return nil, nil, httperrors.BadRequest("Error parsing request: off-ledger request expected")
+	}
+	return chainID, req, nil
+}
+
+func parseRequest(c echo.Context) (chainID *iscp.ChainID, req iscp.Request, err error) {
 	chainID, err = iscp.ChainIDFromBase58(c.Param("chainID"))
 	if err != nil {
 		return nil, nil, httperrors.BadRequest(fmt.Sprintf("Invalid Chain ID %+v: %s", c.Param("chainID"), err.Error()))
@@ -142,15 +167,11 @@ func parseParams(c echo.Context) (chainID *iscp.ChainID, req *request.OffLedger,
 		if err = c.Bind(r); err != nil {
 			return nil, nil, httperrors.BadRequest("Error parsing request from payload")
 		}
-		rGeneric, err := request.FromMarshalUtil(marshalutil.New(r.Request.Bytes()))
+		req, err = request.FromMarshalUtil(marshalutil.New(r.Request.Bytes()))
 		if err != nil {
-			return nil, nil, httperrors.BadRequest(fmt.Sprintf("Error constructing off-ledger request from base64 string: %q", r.Request))
-		}
-		var ok bool
-		if req, ok = rGeneric.(*request.OffLedger); !ok {
-			return nil, nil, httperrors.BadRequest("Error parsing request: off-ledger request is expected")
+			return nil, nil, httperrors.BadRequest(fmt.Sprintf("Error constructing request from base64 string: %q", r.Request))
 		}
-		return chainID, req, err
+		return chainID, req, nil
 	}
 
 	// binary format
@@ -158,13 +179,9 @@ func parseParams(c echo.Context) (chainID *iscp.ChainID, req *request.OffLedger,
 	if err != nil {
 		return nil, nil, httperrors.BadRequest("Error parsing request from payload")
 	}
-	rGeneric, err := request.FromMarshalUtil(marshalutil.New(reqBytes))
+	req, err = request.FromMarshalUtil(marshalutil.New(reqBytes))
 	if err != nil {
 		return nil, nil, httperrors.BadRequest("Error parsing request from payload")
 	}
-	req, ok := rGeneric.(*request.OffLedger)
-	if !ok {
-		return nil, nil, httperrors.BadRequest("Error parsing request: off-ledger request expected")
-	}
-	return chainID, req, err
+	return chainID, req, nil
 }
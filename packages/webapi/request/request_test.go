@@ -6,20 +6,28 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate/utxodb"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/messages"
 	"github.com/iotaledger/wasp/packages/chains"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/metrics/nodeconnmetrics"
 	util "github.com/iotaledger/wasp/packages/testutil"
 	"github.com/iotaledger/wasp/packages/testutil/testchain"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/util/expiringcache"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/webapi/model"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
 	"github.com/iotaledger/wasp/packages/webapi/testutil"
+	"github.com/stretchr/testify/require"
 )
 
 type mockedChain struct {
@@ -40,6 +48,10 @@ func (m *mockedChain) GetRequestProcessingStatus(_ iscp.RequestID) chain.Request
 	panic("implement me")
 }
 
+func (m *mockedChain) GetPendingRequests() []iscp.Request {
+	panic("implement me")
+}
+
 func (m *mockedChain) AttachToRequestProcessed(func(iscp.RequestID)) (attachID *events.Closure) {
 	panic("implement me")
 }
@@ -48,6 +60,17 @@ func (m *mockedChain) DetachFromRequestProcessed(attachID *events.Closure) {
 	panic("implement me")
 }
 
+func (m *mockedChain) DryRunRequest(req iscp.Request) (*vm.DryRunResult, error) {
+	return &vm.DryRunResult{
+		Result:    dict.Dict{"a": []byte{1}},
+		Events:    []*blocklog.Event{{Contract: req.Target().Contract, Message: "hello"}},
+		GasBudget: 1000,
+		GasBurned: 100,
+		FeeColor:  colored.IOTA,
+		Fee:       10,
+	}, nil
+}
+
 // chain.ChainEntry implementation
 
 func (m *mockedChain) ReceiveTransaction(_ *ledgerstate.Transaction) {
@@ -108,6 +131,7 @@ func newMockedAPI(t *testing.T) *offLedgerReqAPI {
 		getAccountBalance:       getAccountBalanceMocked,
 		hasRequestBeenProcessed: hasRequestBeenProcessedMocked(false),
 		requestsCache:           expiringcache.New(10 * time.Second),
+		dryRunning:              make(chan struct{}, maxDryRuns),
 	}
 }
 
@@ -154,3 +178,88 @@ func TestWrongChainID(t *testing.T) {
 	body := util.DummyOffledgerRequest(iscp.RandomChainID()).Bytes()
 	testRequest(t, instance, iscp.RandomChainID(), body, http.StatusBadRequest)
 }
+
+func testDryRun(t *testing.T, instance *offLedgerReqAPI, chainID *iscp.ChainID, body interface{}, res interface{}, expectedStatus int) {
+	testutil.CallWebAPIRequestHandler(
+		t,
+		instance.handleDryRun,
+		http.MethodPost,
+		routes.DryRunRequest(":chainID"),
+		map[string]string{"chainID": chainID.Base58()},
+		body,
+		res,
+		expectedStatus,
+	)
+}
+
+func TestDryRun(t *testing.T) {
+	instance := newMockedAPI(t)
+	chainID := iscp.RandomChainID()
+	req := util.DummyOffledgerRequest(chainID)
+	body := model.OffLedgerRequestBody{Request: model.NewBytes(req.Bytes())}
+
+	var res model.DryRunResponse
+	testDryRun(t, instance, chainID, body, &res, http.StatusOK)
+	require.Empty(t, res.Error)
+	require.EqualValues(t, []byte{1}, res.Result["a"])
+	require.Len(t, res.Events, 1)
+	require.Equal(t, req.Target().Contract.String(), res.Events[0].Contract)
+	require.Equal(t, "hello", res.Events[0].Message)
+	require.EqualValues(t, 100, res.GasBurned)
+	require.EqualValues(t, 10, res.Fee)
+	require.Empty(t, res.Outputs)
+}
+
+func TestDryRunUnsigned(t *testing.T) {
+	instance := newMockedAPI(t)
+	chainID := iscp.RandomChainID()
+	req := request.NewOffLedger(chainID, iscp.Hn("somecontract"), iscp.Hn("someentrypoint"), requestargs.New(nil))
+	require.False(t, req.VerifySignature())
+	testDryRun(t, instance, chainID, req.Bytes(), &model.DryRunResponse{}, http.StatusOK)
+}
+
+func TestDryRunOnLedger(t *testing.T) {
+	instance := newMockedAPI(t)
+	chainID := iscp.RandomChainID()
+	utxo := utxodb.New()
+	keyPair, addr := utxo.NewKeyPairByIndex(0)
+	_, err := utxo.RequestFunds(addr)
+	require.NoError(t, err)
+	tx, err := transaction.NewRequestTransaction(transaction.NewRequestTransactionParams{
+		SenderKeyPair:  keyPair,
+		UnspentOutputs: utxo.GetAddressOutputs(addr),
+		Requests: []transaction.RequestParams{{
+			ChainID:    chainID,
+			Contract:   iscp.Hn("somecontract"),
+			EntryPoint: iscp.Hn("someentrypoint"),
+		}},
+	})
+	require.NoError(t, err)
+	requests, err := request.OnLedgerFromTransaction(tx, chainID.AsAddress())
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	body := model.OffLedgerRequestBody{Request: model.NewBytes(requests[0].Bytes())}
+
+	var res model.DryRunResponse
+	testDryRun(t, instance, chainID, body, &res, http.StatusOK)
+	require.Len(t, res.Events, 1)
+	require.Equal(t, iscp.Hn("somecontract").String(), res.Events[0].Contract)
+
+	testDryRun(t, instance, iscp.RandomChainID(), body, nil, http.StatusBadRequest)
+}
+
+func TestDryRunWrongChainID(t *testing.T) {
+	instance := newMockedAPI(t)
+	body := util.DummyOffledgerRequest(iscp.RandomChainID()).Bytes()
+	testDryRun(t, instance, iscp.RandomChainID(), body, nil, http.StatusBadRequest)
+}
+
+func TestDryRunBusy(t *testing.T) {
+	instance := newMockedAPI(t)
+	for i := 0; i < maxDryRuns; i++ {
+		instance.dryRunning <- struct{}{}
+	}
+	chainID := iscp.RandomChainID()
+	body := util.DummyOffledgerRequest(chainID).Bytes()
+	testDryRun(t, instance, chainID, body, nil, http.StatusTooManyRequests)
+}
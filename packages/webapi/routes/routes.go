@@ -3,14 +3,24 @@
 
 package routes
 
+import "strconv"
+
 func Info() string {
 	return "/info"
 }
 
+func Login() string {
+	return "/auth/login"
+}
+
 func NewRequest(chainID string) string {
 	return "/request/" + chainID
 }
 
+func DryRunRequest(chainID string) string {
+	return "/chain/" + chainID + "/request/dryrun"
+}
+
 func CallView(chainID, contractHname, functionName string) string {
 	return "chain/" + chainID + "/contract/" + contractHname + "/callview/" + functionName
 }
@@ -23,10 +33,27 @@ func WaitRequestProcessed(chainID, reqID string) string {
 	return "/chain/" + chainID + "/request/" + reqID + "/wait"
 }
 
+func PendingRequests(chainID string) string {
+	return "/chain/" + chainID + "/mempool"
+}
+
+func ChainEvents(chainID string) string {
+	return "/chain/" + chainID + "/events"
+}
+
 func StateGet(chainID, key string) string {
 	return "/chain/" + chainID + "/state/" + key
 }
 
+func StateGetProof(chainID, key string) string {
+	return "/chain/" + chainID + "/stateproof/" + key
+}
+
+// AtBlockIndex adds to the route the query parameter selecting the state at a past block index
+func AtBlockIndex(route string, blockIndex uint32) string {
+	return route + "?blockIndex=" + strconv.FormatUint(uint64(blockIndex), 10)
+}
+
 func ActivateChain(chainID string) string {
 	return "/adm/chain/" + chainID + "/activate"
 }
@@ -39,6 +66,10 @@ func GetChainInfo(chainID string) string {
 	return "/adm/chain/" + chainID + "/info"
 }
 
+func ChainSnapshot(chainID string) string {
+	return "/adm/chain/" + chainID + "/snapshot"
+}
+
 func ListChainRecords() string {
 	return "/adm/chainrecords"
 }
@@ -75,6 +106,10 @@ func DKSharesGet(sharedAddress string) string {
 	return "/adm/dks/" + sharedAddress
 }
 
+func DKSharesReshare(sharedAddress string) string {
+	return "/adm/dks/" + sharedAddress + "/reshare"
+}
+
 func PeeringSelfGet() string {
 	return "/adm/peering/self"
 }
@@ -110,3 +145,27 @@ func AdmNodeOwnerCertificate() string {
 func Shutdown() string {
 	return "/adm/shutdown"
 }
+
+func KeystoreStatus() string {
+	return "/adm/keystore"
+}
+
+func KeystoreUnlock() string {
+	return "/adm/keystore/unlock"
+}
+
+func WebhookList() string {
+	return "/adm/webhooks"
+}
+
+func WebhookPost() string {
+	return WebhookList()
+}
+
+func WebhookGet(id string) string {
+	return "/adm/webhooks/" + id
+}
+
+func WebhookDelete(id string) string {
+	return WebhookGet(id)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/iotaledger/wasp/packages/chains"
 	"github.com/iotaledger/wasp/packages/iscp"
@@ -13,23 +14,47 @@ import (
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/kv/optimism"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
 	"github.com/iotaledger/wasp/packages/webapi/webapiutil"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/pangpanglabs/echoswagger/v2"
 )
 
+// Each query of a past state rebuilds the state by applying the blocks stored in DB, so the queries are limited
+// both in number per client and in number run at the same time
+const (
+	historicalQueriesPerSecond = 1
+	historicalQueriesBurst     = 5
+	maxHistoricalQueries       = 4
+)
+
 type callViewService struct {
-	chains chains.Provider
+	getChain          chains.ChainProvider
+	historicalRate    *middleware.RateLimiterMemoryStore
+	historicalRunning chan struct{}
+}
+
+func newCallViewService(getChain chains.ChainProvider) *callViewService {
+	return &callViewService{
+		getChain: getChain,
+		historicalRate: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  historicalQueriesPerSecond,
+			Burst: historicalQueriesBurst,
+		}),
+		historicalRunning: make(chan struct{}, maxHistoricalQueries),
+	}
 }
 
-func AddEndpoints(server echoswagger.ApiRouter, allChains chains.Provider) {
+func AddEndpoints(server echoswagger.ApiRouter, getChain chains.ChainProvider) {
 	dictExample := dict.Dict{
 		kv.Key("key1"): []byte("value1"),
 	}.JSONDict()
 
-	s := &callViewService{allChains}
+	s := newCallViewService(getChain)
 
 	server.POST(routes.CallView(":chainID", ":contractHname", ":fname"), s.handleCallView).
 		SetSummary("Call a view function on a contract").
@@ -37,6 +62,7 @@ func AddEndpoints(server echoswagger.ApiRouter, allChains chains.Provider) {
 		AddParamPath("", "contractHname", "Contract Hname").
 		AddParamPath("getInfo", "fname", "Function name").
 		AddParamBody(dictExample, "params", "Parameters", false).
+		AddParamQuery(uint32(0), "blockIndex", "Index of a past block: call the view on the state at that block (optional)", false).
 		AddResponse(http.StatusOK, "Result", dictExample, nil)
 
 	server.GET(routes.CallView(":chainID", ":contractHname", ":fname"), s.handleCallView).
@@ -45,13 +71,49 @@ func AddEndpoints(server echoswagger.ApiRouter, allChains chains.Provider) {
 		AddParamPath("", "contractHname", "Contract Hname").
 		AddParamPath("getInfo", "fname", "Function name").
 		AddParamBody(dictExample, "params", "Parameters", false).
+		AddParamQuery(uint32(0), "blockIndex", "Index of a past block: call the view on the state at that block (optional)", false).
 		AddResponse(http.StatusOK, "Result", dictExample, nil)
 
 	server.GET(routes.StateGet(":chainID", ":key"), s.handleStateGet).
 		SetSummary("Fetch the raw value associated with the given key in the chain state").
 		AddParamPath("", "chainID", "ChainID (base58-encoded)").
 		AddParamPath("", "key", "Key (hex-encoded)").
+		AddParamQuery(uint32(0), "blockIndex", "Index of a past block: fetch the value from the state at that block (optional)", false).
 		AddResponse(http.StatusOK, "Result", []byte("value"), nil)
+
+	server.GET(routes.StateGetProof(":chainID", ":key"), s.handleStateGetProof).
+		SetSummary("Fetch the value associated with the given key together with the proof against the committed state hash").
+		AddParamPath("", "chainID", "ChainID (base58-encoded)").
+		AddParamPath("", "key", "Key (hex-encoded)").
+		AddParamQuery(uint32(0), "blockIndex", "Index of a past block: prove the value in the state at that block (optional)", false).
+		AddResponse(http.StatusOK, "Value with the proof", model.StateProof{}, nil)
+}
+
+// parseBlockIndex parses the optional blockIndex query parameter
+func parseBlockIndex(c echo.Context) (blockIndex uint32, ok bool, err error) {
+	s := c.QueryParam("blockIndex")
+	if s == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false, httperrors.BadRequest(fmt.Sprintf("Invalid block index: %+v", s))
+	}
+	return uint32(n), true, nil
+}
+
+// startHistoricalQuery checks the limits of the queries of past states. The returned function must be called
+// when the query is done
+func (s *callViewService) startHistoricalQuery(c echo.Context) (func(), error) {
+	if allow, err := s.historicalRate.Allow(c.RealIP()); err != nil || !allow {
+		return nil, httperrors.TooManyRequests("Too many queries of past states, retry later")
+	}
+	select {
+	case s.historicalRunning <- struct{}{}:
+		return func() { <-s.historicalRunning }, nil
+	default:
+		return nil, httperrors.TooManyRequests("Too many queries of past states running, retry later")
+	}
 }
 
 func (s *callViewService) handleCallView(c echo.Context) error {
@@ -72,11 +134,27 @@ func (s *callViewService) handleCallView(c echo.Context) error {
 			return httperrors.BadRequest("Invalid request body")
 		}
 	}
-	theChain := s.chains().Get(chainID)
+	blockIndex, atBlockIndex, err := parseBlockIndex(c)
+	if err != nil {
+		return err
+	}
+	theChain := s.getChain(chainID)
 	if theChain == nil {
 		return httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID))
 	}
-	ret, err := webapiutil.CallView(theChain, contractHname, iscp.Hn(fname), params)
+	if atBlockIndex {
+		done, err := s.startHistoricalQuery(c)
+		if err != nil {
+			return err
+		}
+		defer done()
+	}
+	var ret dict.Dict
+	if atBlockIndex {
+		ret, err = webapiutil.CallViewAtIndex(theChain, contractHname, iscp.Hn(fname), params, blockIndex)
+	} else {
+		ret, err = webapiutil.CallView(theChain, contractHname, iscp.Hn(fname), params)
+	}
 	if err != nil {
 		return httperrors.BadRequest(fmt.Sprintf("View call failed: %v", err))
 	}
@@ -95,10 +173,34 @@ func (s *callViewService) handleStateGet(c echo.Context) error {
 		return httperrors.BadRequest(fmt.Sprintf("cannot parse hex-encoded key: %+v", c.Param("key")))
 	}
 
-	theChain := s.chains().Get(chainID)
+	blockIndex, atBlockIndex, err := parseBlockIndex(c)
+	if err != nil {
+		return err
+	}
+
+	theChain := s.getChain(chainID)
 	if theChain == nil {
 		return httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID))
 	}
+	if atBlockIndex {
+		done, err := s.startHistoricalQuery(c)
+		if err != nil {
+			return err
+		}
+		defer done()
+	}
+
+	if atBlockIndex {
+		stateReader, err := theChain.GetStateReaderAtIndex(blockIndex)
+		if err != nil {
+			return httperrors.NotFound(fmt.Sprintf("State at block #%d not available: %v", blockIndex, err))
+		}
+		ret, err := stateReader.KVStoreReader().Get(kv.Key(key))
+		if err != nil {
+			return httperrors.BadRequest(fmt.Sprintf("View call failed: %v", err))
+		}
+		return c.JSON(http.StatusOK, ret)
+	}
 
 	var ret []byte
 	err = optimism.RetryOnStateInvalidated(func() error {
@@ -116,3 +218,88 @@ func (s *callViewService) handleStateGet(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, ret)
 }
+
+func (s *callViewService) handleStateGetProof(c echo.Context) error {
+	chainID, err := iscp.ChainIDFromBase58(c.Param("chainID"))
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("Invalid chain ID: %+v", c.Param("chainID")))
+	}
+
+	key, err := hex.DecodeString(c.Param("key"))
+	if err != nil {
+		return httperrors.BadRequest(fmt.Sprintf("cannot parse hex-encoded key: %+v", c.Param("key")))
+	}
+
+	blockIndex, atBlockIndex, err := parseBlockIndex(c)
+	if err != nil {
+		return err
+	}
+
+	theChain := s.getChain(chainID)
+	if theChain == nil {
+		return httperrors.NotFound(fmt.Sprintf("Chain not found: %s", chainID))
+	}
+	if atBlockIndex {
+		done, err := s.startHistoricalQuery(c)
+		if err != nil {
+			return err
+		}
+		defer done()
+	}
+
+	var historicalStateReader state.OptimisticStateReader
+	if atBlockIndex {
+		historicalStateReader, err = theChain.GetStateReaderAtIndex(blockIndex)
+		if err != nil {
+			return httperrors.NotFound(fmt.Sprintf("State at block #%d not available: %v", blockIndex, err))
+		}
+	}
+
+	var ret *model.StateProof
+	err = optimism.RetryOnStateInvalidated(func() error {
+		stateReader := historicalStateReader
+		if stateReader == nil {
+			stateReader = theChain.GetStateReader()
+		}
+		stateReader.SetBaseline()
+		blockIndex, err := stateReader.BlockIndex()
+		if err != nil {
+			return err
+		}
+		stateHash, err := stateReader.Hash()
+		if err != nil {
+			return err
+		}
+		value, err := stateReader.KVStoreReader().Get(kv.Key(key))
+		if err != nil {
+			return err
+		}
+		proof, err := stateReader.GetProof(kv.Key(key))
+		if err != nil {
+			return err
+		}
+		stateOutputID, err := stateReader.ApprovingOutputID()
+		if err != nil {
+			return err
+		}
+		ret = &model.StateProof{
+			Key:           model.NewBytes(key),
+			Value:         model.NewBytes(value),
+			Exists:        value != nil,
+			Proof:         model.NewBytes(proof.Bytes()),
+			BlockIndex:    blockIndex,
+			StateHash:     model.NewHashValue(stateHash),
+			StateOutputID: model.NewOutputID(stateOutputID),
+		}
+		return nil
+	})
+	if err != nil {
+		reason := fmt.Sprintf("State proof failed: %v", err)
+		if errors.Is(err, coreutil.ErrorStateInvalidated) {
+			return httperrors.Conflict(reason)
+		}
+		return httperrors.BadRequest(reason)
+	}
+
+	return c.JSON(http.StatusOK, ret)
+}
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/trie"
+	"github.com/iotaledger/wasp/packages/webapi/model"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/iotaledger/wasp/packages/webapi/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChain struct {
+	chain.Chain
+	stateReader state.OptimisticStateReader
+}
+
+func (m *mockChain) GetStateReader() state.OptimisticStateReader {
+	return m.stateReader
+}
+
+func TestStateGetProof(t *testing.T) {
+	chainID := iscp.RandomChainID()
+	store := mapdb.NewMapDB()
+	vs, err := state.CreateOriginState(store, chainID)
+	require.NoError(t, err)
+	upd := state.NewStateUpdateWithBlocklogValues(1, time.Now(), vs.StateCommitment())
+	upd.Mutations().Set("key", []byte("value"))
+	vs.ApplyStateUpdates(upd)
+	block, err := vs.ExtractBlock()
+	require.NoError(t, err)
+	outputID := ledgerstate.OutputID{1, 2, 3}
+	block.SetApprovingOutputID(outputID)
+	require.NoError(t, vs.Commit(block))
+
+	glb := coreutil.NewChainStateSync()
+	glb.SetSolidIndex(1)
+	s := newCallViewService(func(*iscp.ChainID) chain.Chain {
+		return &mockChain{stateReader: state.NewOptimisticStateReader(store, glb)}
+	})
+
+	getProof := func(key string) *model.StateProof {
+		var res model.StateProof
+		testutil.CallWebAPIRequestHandler(
+			t,
+			s.handleStateGetProof,
+			http.MethodGet,
+			routes.StateGetProof(":chainID", ":key"),
+			map[string]string{
+				"chainID": chainID.Base58(),
+				"key":     hex.EncodeToString([]byte(key)),
+			},
+			nil,
+			&res,
+			http.StatusOK,
+		)
+		return &res
+	}
+	verify := func(res *model.StateProof, root hashing.HashValue, value []byte) error {
+		proof, err := trie.ProofFromBytes(res.Proof.Bytes())
+		require.NoError(t, err)
+		return proof.Verify(root, value)
+	}
+
+	// the proof of a value is valid against the committed state hash only
+	res := getProof("key")
+	require.True(t, res.Exists)
+	require.EqualValues(t, "value", res.Value.Bytes())
+	require.EqualValues(t, 1, res.BlockIndex)
+	require.Equal(t, vs.StateCommitment(), res.StateHash.HashValue())
+	stateOutputID, err := res.StateOutputID.ID()
+	require.NoError(t, err)
+	require.Equal(t, outputID, stateOutputID)
+	require.NoError(t, verify(res, res.StateHash.HashValue(), res.Value.Bytes()))
+	require.Error(t, verify(res, res.StateHash.HashValue(), []byte("other")))
+	require.Error(t, verify(res, hashing.HashStrings("other"), res.Value.Bytes()))
+
+	// the absence of a key is proven
+	res = getProof("nokey")
+	require.False(t, res.Exists)
+	require.NoError(t, verify(res, res.StateHash.HashValue(), nil))
+	require.Error(t, verify(res, res.StateHash.HashValue(), []byte("value")))
+
+	// wrong parameters
+	testutil.CallWebAPIRequestHandler(
+		t,
+		s.handleStateGetProof,
+		http.MethodGet,
+		routes.StateGetProof(":chainID", ":key"),
+		map[string]string{
+			"chainID": chainID.Base58(),
+			"key":     "not hex",
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	)
+}
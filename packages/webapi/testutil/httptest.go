@@ -56,6 +56,11 @@ func CallWebAPIRequestHandler(
 	e := echo.New()
 
 	req := buildRequest(t, method, body)
+	// the query string of the route, if any, is passed in the URL of the request
+	if i := strings.IndexByte(route, '?'); i >= 0 {
+		req.URL.RawQuery = route[i+1:]
+		route = route[:i]
+	}
 
 	rec := httptest.NewRecorder()
 
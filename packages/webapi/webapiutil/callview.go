@@ -19,3 +19,12 @@ func CallView(ch chain.ChainCore, contractHname, viewHname iscp.Hname, params di
 
 	return ret, err
 }
+
+// CallViewAtIndex calls the view on the state of the chain as it was after the block with the given index
+func CallViewAtIndex(ch chain.ChainCore, contractHname, viewHname iscp.Hname, params dict.Dict, blockIndex uint32) (dict.Dict, error) {
+	vctx, err := viewcontext.NewFromChainAtIndex(ch, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	return vctx.CallView(contractHname, viewHname, params)
+}
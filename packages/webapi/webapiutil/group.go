@@ -0,0 +1,54 @@
+package webapiutil
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+)
+
+type groupWithMiddleware struct {
+	echoswagger.ApiGroup
+	middleware []echo.MiddlewareFunc
+}
+
+// GroupWithMiddleware returns the group adding the middleware to each route of the group.
+// Unlike echo.Group.Use, the middleware is not run for the requests to unknown routes: all the groups of
+// the web API have an empty prefix, so they would also catch the requests of the other groups and the 404s
+func GroupWithMiddleware(group echoswagger.ApiGroup, m ...echo.MiddlewareFunc) echoswagger.ApiGroup {
+	return &groupWithMiddleware{ApiGroup: group, middleware: m}
+}
+
+func (g *groupWithMiddleware) with(m []echo.MiddlewareFunc) []echo.MiddlewareFunc {
+	return append(append([]echo.MiddlewareFunc{}, g.middleware...), m...)
+}
+
+func (g *groupWithMiddleware) Add(method, path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.Add(method, path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.GET(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.POST(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.PUT(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.DELETE(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.OPTIONS(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.HEAD(path, h, g.with(m)...)
+}
+
+func (g *groupWithMiddleware) PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echoswagger.Api {
+	return g.ApiGroup.PATCH(path, h, g.with(m)...)
+}
@@ -2,24 +2,37 @@ package webapi
 
 import (
 	_ "embed"
+	"strconv"
 
 	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chains"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/publisher/publisherws"
+	"github.com/iotaledger/wasp/packages/webapi/httperrors"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
 	"github.com/labstack/echo/v4"
 	"github.com/pangpanglabs/echoswagger/v2"
 )
 
 type webSocketAPI struct {
-	pws *publisherws.PublisherWebSocket
+	pws           *publisherws.PublisherWebSocket
+	log           *logger.Logger
+	chainProvider chains.ChainProvider
 }
 
-func addWebSocketEndpoint(e echoswagger.ApiGroup, log *logger.Logger) *webSocketAPI {
+func addWebSocketEndpoint(e echoswagger.ApiGroup, log *logger.Logger, chainProvider chains.ChainProvider) *webSocketAPI {
 	api := &webSocketAPI{
-		pws: publisherws.New(log, []string{"state", "vmmsg"}),
+		pws:           publisherws.New(log, []string{"state", "vmmsg"}),
+		log:           log,
+		chainProvider: chainProvider,
 	}
 
 	e.GET("/chain/:chainid/ws", api.handleWebSocket)
+	e.GET(routes.ChainEvents(":chainID"), api.handleEventStream).
+		AddParamPath("", "chainID", "ChainID (base58)").
+		AddParamQuery(uint32(0), "since", "Index of the first block to stream the events of. If omitted, only new events are streamed", false).
+		SetSummary("Stream the JSON events of the chain through a websocket, backfilling the past blocks since the given one")
 
 	return api
 }
@@ -31,3 +44,24 @@ func (w *webSocketAPI) handleWebSocket(c echo.Context) error {
 	}
 	return w.pws.ServeHTTP(chainID, c.Response(), c.Request())
 }
+
+func (w *webSocketAPI) handleEventStream(c echo.Context) error {
+	chainID, err := iscp.ChainIDFromBase58(c.Param("chainID"))
+	if err != nil {
+		return httperrors.BadRequest("Invalid chain ID")
+	}
+	var since *uint32
+	if s := c.QueryParam("since"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return httperrors.BadRequest("Invalid block index")
+		}
+		blockIndex := uint32(n)
+		since = &blockIndex
+	}
+	ch := w.chainProvider(chainID)
+	if ch == nil {
+		return httperrors.NotFound("Chain not found")
+	}
+	return publisherws.ServeChainEvents(w.log, chainID, chain.NewBlockEventSource(ch), since, c.Response(), c.Request())
+}
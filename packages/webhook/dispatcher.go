@@ -0,0 +1,573 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+)
+
+// headers of the HTTP requests delivering the events
+const (
+	HeaderDeliveryID   = "X-Wasp-Delivery"
+	HeaderSubscription = "X-Wasp-Subscription"
+	HeaderTimestamp    = "X-Wasp-Timestamp"
+	HeaderPubKey       = "X-Wasp-PubKey"
+	HeaderSignature    = "X-Wasp-Signature"
+)
+
+var ErrSubscriptionNotFound = xerrors.New("webhook subscription not found")
+
+// defaults of the Config
+const (
+	DefaultMaxAttempts = 50
+	DefaultMaxQueued   = 1000
+)
+
+// Config contains the parameters of the delivery
+type Config struct {
+	// MaxAttempts is the number of attempts after which a delivery is dropped. DefaultMaxAttempts if not set
+	MaxAttempts int
+	// MaxQueued is the maximum number of deliveries waiting for each subscription, further events are dropped.
+	// DefaultMaxQueued if not set
+	MaxQueued int
+	// MinBackoff is the delay before the first retry. It is doubled at each further retry
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration
+	// Timeout is the timeout of the HTTP requests
+	Timeout time.Duration
+}
+
+// maxReceivedBlocks is the maximum number of blocks whose published events wait to be queued.
+// When it is exceeded, the events are discarded and the blocks are queued from the EventSource instead
+const maxReceivedBlocks = 1000
+
+// EventSource provides the events of the past blocks of the chains. It is used to queue the events of the blocks
+// which were not received live, for example because they were committed while the node was down
+type EventSource interface {
+	// LatestBlockIndex returns the index of the latest committed block of the chain
+	LatestBlockIndex(chainID *iscp.ChainID) (uint32, error)
+	// BlockEvents returns the events of the block. Returns false if the block does not exist
+	BlockEvents(chainID *iscp.ChainID, blockIndex uint32) ([]*publisher.ChainEvent, bool, error)
+}
+
+// Dispatcher queues the chain events matching the subscriptions and POSTs them to the endpoints.
+// The events of each subscription are delivered in order: while a delivery is failing, the next ones
+// of the same subscription wait in the queue. The subscriptions are delivered concurrently.
+// The published events are only buffered on the publishing path, they are queued by the Run loop.
+// For each subscription and chain the index of the next block to queue is kept in the DB, so the blocks
+// missed meanwhile are queued from the EventSource
+type Dispatcher struct {
+	log     *logger.Logger
+	store   kvstore.KVStore
+	keyPair *ed25519.KeyPair
+	source  EventSource
+	config  Config
+	client  *http.Client
+
+	mutex         sync.Mutex
+	subscriptions map[string]*Subscription
+	queue         *queue
+	delivering    map[string]bool // subscriptions being delivered by a worker
+	workers       sync.WaitGroup
+
+	receivedMutex sync.Mutex
+	received      [][]*publisher.ChainEvent // published events waiting to be queued, one slice per block
+	overflow      bool
+
+	// stalled is set when the events of a block did not fit in the queue of a subscription.
+	// Only accessed by the loop queueing the events
+	stalled bool
+	signal  chan struct{}
+}
+
+// New creates the dispatcher. The source may be nil, then the blocks which are not received live are not queued
+func New(log *logger.Logger, store kvstore.KVStore, keyPair *ed25519.KeyPair, source EventSource, config Config) (*Dispatcher, error) {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.MaxQueued <= 0 {
+		config.MaxQueued = DefaultMaxQueued
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = time.Second
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = config.MinBackoff
+	}
+	q, err := newQueue(store, config.MaxQueued)
+	if err != nil {
+		return nil, err
+	}
+	ret := &Dispatcher{
+		log:           log,
+		store:         store,
+		keyPair:       keyPair,
+		source:        source,
+		config:        config,
+		client:        &http.Client{Timeout: config.Timeout},
+		subscriptions: make(map[string]*Subscription),
+		queue:         q,
+		delivering:    make(map[string]bool),
+		signal:        make(chan struct{}, 1),
+	}
+	err = store.Iterate([]byte{dbkeys.ObjectTypeWebhookSubscription}, func(key kvstore.Key, value kvstore.Value) bool {
+		sub, recErr := SubscriptionFromBytes(value)
+		if recErr != nil {
+			log.Warnf("webhook: skipping invalid subscription record: %v", recErr)
+			return true
+		}
+		ret.subscriptions[sub.ID] = sub
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// AddSubscription stores the subscription. A new ID is assigned if it is empty.
+// The subscription receives the events of the blocks committed after it is added. A subscription to all the chains
+// receives the events of a chain from the first block of the chain received live
+func (d *Dispatcher) AddSubscription(sub *Subscription) (*Subscription, error) {
+	if u, err := url.Parse(sub.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, xerrors.Errorf("webhook: invalid URL '%s'", sub.URL)
+	}
+	for _, t := range sub.EventTypes {
+		switch t {
+		case publisher.ChainEventBlockCommitted, publisher.ChainEventRequestReceipt,
+			publisher.ChainEventContractEvent, publisher.ChainEventChainRotated:
+		default:
+			return nil, xerrors.Errorf("webhook: unknown event type '%s'", t)
+		}
+	}
+	if sub.ID == "" {
+		sub.ID = NewSubscriptionID()
+	}
+	if len(sub.ID) > maxSubscriptionIDLength {
+		return nil, xerrors.Errorf("webhook: subscription ID longer than %d bytes", maxSubscriptionIDLength)
+	}
+	var latest uint32
+	hasLatest := false
+	if sub.ChainID != nil && d.source != nil {
+		var err error
+		latest, err = d.source.LatestBlockIndex(sub.ChainID)
+		hasLatest = err == nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := d.store.Set(dbKeyForSubscription(sub.ID), sub.Bytes()); err != nil {
+		return nil, err
+	}
+	if hasLatest {
+		if err := d.queue.setCursor(sub.ID, sub.ChainID, latest+1); err != nil {
+			return nil, err
+		}
+	}
+	d.subscriptions[sub.ID] = sub
+	d.log.Infof("webhook: added %s", sub.String())
+	return sub, nil
+}
+
+// GetSubscriptions returns all the subscriptions
+func (d *Dispatcher) GetSubscriptions() []*Subscription {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	ret := make([]*Subscription, 0, len(d.subscriptions))
+	for _, sub := range d.subscriptions {
+		ret = append(ret, sub)
+	}
+	return ret
+}
+
+// GetSubscription returns the subscription with the given ID, or ErrSubscriptionNotFound
+func (d *Dispatcher) GetSubscription(id string) (*Subscription, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	sub, ok := d.subscriptions[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// DeleteSubscription deletes the subscription and drops its pending deliveries
+func (d *Dispatcher) DeleteSubscription(id string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.subscriptions[id]; !ok {
+		return ErrSubscriptionNotFound
+	}
+	if err := d.store.Delete(dbKeyForSubscription(id)); err != nil {
+		return err
+	}
+	delete(d.subscriptions, id)
+	if err := d.queue.removeAll(id); err != nil {
+		return err
+	}
+	d.log.Infof("webhook: deleted subscription %s", id)
+	return nil
+}
+
+// Run queues and delivers the events until the shutdown signal
+func (d *Dispatcher) Run(shutdownSignal <-chan struct{}) {
+	cl := events.NewClosure(d.handleChainEvents)
+	publisher.ChainEvents.Attach(cl)
+	defer publisher.ChainEvents.Detach(cl)
+	defer d.workers.Wait()
+
+	// the blocks committed while the node was down
+	d.catchUpAll()
+
+	ticker := time.NewTicker(d.config.MinBackoff)
+	defer ticker.Stop()
+	for {
+		d.queueReceived()
+		d.deliverPending()
+		select {
+		case <-shutdownSignal:
+			return
+		case <-d.signal:
+		case <-ticker.C:
+		}
+	}
+}
+
+// handleChainEvents buffers the published events of a block. It is called on the publishing path,
+// so it neither waits for the dispatcher nor writes to the DB
+func (d *Dispatcher) handleChainEvents(evts []*publisher.ChainEvent) {
+	if len(evts) == 0 {
+		return
+	}
+	d.receivedMutex.Lock()
+	if d.overflow || len(d.received) >= maxReceivedBlocks {
+		d.overflow = true
+		d.received = nil
+	} else {
+		d.received = append(d.received, evts)
+	}
+	d.receivedMutex.Unlock()
+	d.wakeUp()
+}
+
+func (d *Dispatcher) wakeUp() {
+	select {
+	case d.signal <- struct{}{}:
+	default:
+	}
+}
+
+// queueReceived queues the buffered events for the subscriptions
+func (d *Dispatcher) queueReceived() {
+	d.receivedMutex.Lock()
+	received, overflow := d.received, d.overflow
+	d.received, d.overflow = nil, false
+	d.receivedMutex.Unlock()
+
+	if overflow || d.stalled {
+		d.catchUpAll()
+	}
+	for _, evts := range received {
+		d.queueEvents(evts)
+	}
+}
+
+// queueEvents queues the events of a block for the matching subscriptions,
+// after the blocks of the chain the subscriptions have missed
+func (d *Dispatcher) queueEvents(evts []*publisher.ChainEvent) {
+	chainID, err := iscp.ChainIDFromBase58(evts[0].ChainID)
+	if err != nil {
+		d.log.Errorf("webhook: invalid chain ID in event: %v", err)
+		return
+	}
+	blockIndex := evts[0].Cursor.BlockIndex
+	bodies, err := marshalEvents(evts)
+	if err != nil {
+		d.log.Errorf("webhook: cannot serialize the events of block #%d: %v", blockIndex, err)
+		return
+	}
+	d.mutex.Lock()
+	subs := make([]*Subscription, 0)
+	nexts := make([]uint32, 0)
+	for _, sub := range d.subscriptions {
+		if sub.ChainID != nil && !sub.ChainID.Equals(chainID) {
+			continue
+		}
+		next, ok := d.queue.cursor(sub.ID, chainID)
+		if !ok {
+			// the first block of the chain received for the subscription
+			next = blockIndex
+		}
+		subs = append(subs, sub)
+		nexts = append(nexts, next)
+	}
+	d.mutex.Unlock()
+
+	for i, sub := range subs {
+		next := nexts[i]
+		if next < blockIndex {
+			next = d.catchUp(sub, chainID, next, blockIndex-1)
+		}
+		if next == blockIndex {
+			d.queueBlock(sub, chainID, blockIndex, evts, bodies)
+		}
+	}
+}
+
+// catchUpAll queues the events of the blocks committed after the cursors of the subscriptions
+func (d *Dispatcher) catchUpAll() {
+	d.stalled = false
+	if d.source == nil {
+		return
+	}
+	type subCursor struct {
+		sub *Subscription
+		cursor
+	}
+	d.mutex.Lock()
+	cursors := make([]subCursor, 0)
+	for _, sub := range d.subscriptions {
+		for _, c := range d.queue.allCursors(sub.ID) {
+			cursors = append(cursors, subCursor{sub: sub, cursor: c})
+		}
+	}
+	d.mutex.Unlock()
+
+	for _, c := range cursors {
+		latest, err := d.source.LatestBlockIndex(c.chainID)
+		if err != nil {
+			// the chain is not active (yet). Its blocks are queued when the next one is received
+			continue
+		}
+		if c.nextBlock <= latest {
+			d.catchUp(c.sub, c.chainID, c.nextBlock, latest)
+		}
+	}
+}
+
+// catchUp queues the events of the blocks from next up to upTo for the subscription, reading them from the source.
+// Returns the index of the next block to queue, which is after upTo unless the queue of the subscription is full
+func (d *Dispatcher) catchUp(sub *Subscription, chainID *iscp.ChainID, next, upTo uint32) uint32 {
+	if d.source == nil {
+		d.log.Warnf("webhook: the events of blocks #%d-#%d of chain %s are lost for subscription %s",
+			next, upTo, chainID.Base58(), sub.ID)
+		d.setCursor(sub, chainID, upTo+1)
+		return upTo + 1
+	}
+	for ; next <= upTo; next++ {
+		evts, ok, err := d.source.BlockEvents(chainID, next)
+		if err == nil && !ok {
+			err = xerrors.New("the block does not exist")
+		}
+		var bodies [][]byte
+		if err == nil {
+			bodies, err = marshalEvents(evts)
+		}
+		if err != nil {
+			// e.g. the block has been pruned
+			d.log.Warnf("webhook: skipping the events of block #%d of chain %s for subscription %s: %v",
+				next, chainID.Base58(), sub.ID, err)
+			if !d.setCursor(sub, chainID, next+1) {
+				return next
+			}
+			continue
+		}
+		if !d.queueBlock(sub, chainID, next, evts, bodies) {
+			return next
+		}
+	}
+	return next
+}
+
+// queueBlock queues the events of the block which match the subscription and advances the cursor of
+// the subscription past the block. Returns false if the block could not be queued, it is then queued later
+func (d *Dispatcher) queueBlock(sub *Subscription, chainID *iscp.ChainID, blockIndex uint32, evts []*publisher.ChainEvent, bodies [][]byte) bool {
+	matching := make([][]byte, 0, len(evts))
+	for i, evt := range evts {
+		if sub.Matches(evt) {
+			matching = append(matching, bodies[i])
+		}
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.subscriptions[sub.ID]; !ok {
+		// the subscription has been deleted meanwhile
+		return true
+	}
+	err := d.queue.pushBlock(sub.ID, chainID, blockIndex, matching)
+	if err == nil {
+		return true
+	}
+	d.stalled = true
+	if xerrors.Is(err, errQueueFull) {
+		d.log.Warnf("webhook: %d deliveries are pending for subscription %s, the events of block #%d of chain %s are queued later",
+			d.config.MaxQueued, sub.ID, blockIndex, chainID.Base58())
+		return false
+	}
+	d.log.Errorf("webhook: cannot queue the events of block #%d for subscription %s: %v", blockIndex, sub.ID, err)
+	return false
+}
+
+func (d *Dispatcher) setCursor(sub *Subscription, chainID *iscp.ChainID, nextBlock uint32) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.subscriptions[sub.ID]; !ok {
+		return true
+	}
+	if err := d.queue.setCursor(sub.ID, chainID, nextBlock); err != nil {
+		d.log.Errorf("webhook: cannot update the cursor of subscription %s: %v", sub.ID, err)
+		d.stalled = true
+		return false
+	}
+	return true
+}
+
+func marshalEvents(evts []*publisher.ChainEvent) ([][]byte, error) {
+	ret := make([][]byte, len(evts))
+	for i, evt := range evts {
+		var err error
+		if ret[i], err = json.Marshal(evt); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// deliverPending starts a worker for each subscription with a delivery which is due, unless one is running already.
+// Each worker POSTs the deliveries of its subscription in the order of the queue
+func (d *Dispatcher) deliverPending() {
+	now := time.Now()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, id := range d.queue.dueSubscriptions(now) {
+		if d.delivering[id] {
+			continue
+		}
+		d.delivering[id] = true
+		d.workers.Add(1)
+		go func(id string) {
+			defer d.workers.Done()
+			if err := d.deliverSubscription(id, now); err != nil {
+				d.log.Errorf("webhook: cannot update the queue: %v", err)
+			}
+			d.mutex.Lock()
+			delete(d.delivering, id)
+			d.mutex.Unlock()
+			// deliveries may have been queued while the worker was finishing
+			d.wakeUp()
+		}(id)
+	}
+}
+
+// deliverSubscription POSTs the deliveries of the subscription until one fails or the queue is empty
+func (d *Dispatcher) deliverSubscription(id string, now time.Time) error {
+	for {
+		d.mutex.Lock()
+		sub, ok := d.subscriptions[id]
+		if !ok {
+			// the subscription has been deleted meanwhile
+			d.mutex.Unlock()
+			return nil
+		}
+		dl, err := d.queue.first(id)
+		d.mutex.Unlock()
+		if err != nil || dl == nil {
+			return err
+		}
+		err = d.post(sub, dl)
+		d.mutex.Lock()
+		if _, ok := d.subscriptions[id]; !ok {
+			d.mutex.Unlock()
+			return nil
+		}
+		failed := err != nil
+		if !failed {
+			err = d.queue.remove(dl)
+		} else {
+			dl.Attempts++
+			if int(dl.Attempts) >= d.config.MaxAttempts {
+				d.log.Errorf("webhook: dropping delivery %d to %s after %d attempts: %v", dl.seq, sub.URL, dl.Attempts, err)
+				err = d.queue.remove(dl)
+			} else {
+				d.log.Warnf("webhook: delivery %d to %s failed, attempt %d: %v", dl.seq, sub.URL, dl.Attempts, err)
+				dl.NextAttempt = now.Add(d.backoff(dl.Attempts))
+				err = d.queue.update(dl)
+			}
+		}
+		d.mutex.Unlock()
+		if err != nil || failed {
+			return err
+		}
+	}
+}
+
+func (d *Dispatcher) backoff(attempts uint16) time.Duration {
+	ret := d.config.MinBackoff
+	for i := uint16(1); i < attempts && ret < d.config.MaxBackoff; i++ {
+		ret *= 2
+	}
+	if ret > d.config.MaxBackoff {
+		ret = d.config.MaxBackoff
+	}
+	return ret
+}
+
+func (d *Dispatcher) post(sub *Subscription, dl *delivery) error {
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(dl.Body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, fmt.Sprintf("%s-%d", sub.ID, dl.seq))
+	req.Header.Set(HeaderSubscription, sub.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderPubKey, d.keyPair.PublicKey.String())
+	req.Header.Set(HeaderSignature, d.keyPair.PrivateKey.Sign(signedData(timestamp, dl.Body)).String())
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
+
+// signedData returns the data signed by the node: the timestamp header, a dot and the body
+func signedData(timestamp string, body []byte) []byte {
+	ret := make([]byte, 0, len(timestamp)+1+len(body))
+	ret = append(ret, timestamp...)
+	ret = append(ret, '.')
+	return append(ret, body...)
+}
+
+// VerifySignature checks the signature of a delivery, given the values of its headers and its body
+func VerifySignature(pubKey ed25519.PublicKey, timestamp, signature string, body []byte) bool {
+	sigBytes, err := base58.Decode(signature)
+	if err != nil {
+		return false
+	}
+	sig, _, err := ed25519.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return false
+	}
+	return pubKey.VerifySignature(signedData(timestamp, body), sig)
+}
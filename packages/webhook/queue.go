@@ -0,0 +1,325 @@
+package webhook
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/database/dbkeys"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"golang.org/x/xerrors"
+)
+
+// delivery is an event waiting to be POSTed to the endpoint of a subscription
+type delivery struct {
+	seq            uint64 // not persistent. Set from key
+	SubscriptionID string
+	Body           []byte
+	Attempts       uint16
+	NextAttempt    time.Time
+}
+
+func deliveryFromBytes(seq uint64, data []byte) (*delivery, error) {
+	mu := marshalutil.New(data)
+	ret := &delivery{seq: seq}
+	var err error
+	if ret.SubscriptionID, err = readString(mu); err != nil {
+		return nil, err
+	}
+	size, err := mu.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	if ret.Body, err = mu.ReadBytes(int(size)); err != nil {
+		return nil, err
+	}
+	if ret.Attempts, err = mu.ReadUint16(); err != nil {
+		return nil, err
+	}
+	if ret.NextAttempt, err = mu.ReadTime(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (d *delivery) Bytes() []byte {
+	mu := marshalutil.New()
+	writeString(mu, d.SubscriptionID)
+	mu.WriteUint32(uint32(len(d.Body))).
+		WriteBytes(d.Body).
+		WriteUint16(d.Attempts).
+		WriteTime(d.NextAttempt)
+	return mu.Bytes()
+}
+
+// dbKeyForDelivery groups the deliveries by subscription, so that the queue of a subscription is read without the others
+func dbKeyForDelivery(subscriptionID string, seq uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], seq)
+	return dbkeys.MakeKey(dbkeys.ObjectTypeWebhookDelivery, dbKeyPrefixForSubscription(subscriptionID), buf[:])
+}
+
+func dbKeyPrefixForSubscription(subscriptionID string) []byte {
+	return append([]byte{byte(len(subscriptionID))}, subscriptionID...)
+}
+
+func dbKeyForSubscription(id string) []byte {
+	return dbkeys.MakeKey(dbkeys.ObjectTypeWebhookSubscription, []byte(id))
+}
+
+func dbKeyForCursor(subscriptionID string, chainID *iscp.ChainID) []byte {
+	return dbkeys.MakeKey(dbkeys.ObjectTypeWebhookCursor, dbKeyPrefixForSubscription(subscriptionID), chainID.Bytes())
+}
+
+var errQueueFull = xerrors.New("webhook queue: the queue of the subscription is full")
+
+// queue is the durable queue of the deliveries, stored in the node DB.
+// Each subscription has its own FIFO queue of at most maxQueued deliveries. Only the first delivery of a
+// queue is attempted, so the time of its next attempt is kept in memory to find the queues which are due
+// without reading the DB.
+// The queue also keeps for each subscription and chain the cursor, the index of the next block whose events
+// are to be queued. The events of a block are queued together with the cursor, so a block is queued exactly once
+type queue struct {
+	store     kvstore.KVStore
+	maxQueued int
+	nextSeq   uint64
+	counts    map[string]int               // number of deliveries in the queue of each subscription
+	due       map[string]time.Time         // next attempt of the first delivery of each non empty queue
+	cursors   map[string]map[string]cursor // cursors of each subscription, by chain ID in base58
+}
+
+type cursor struct {
+	chainID   *iscp.ChainID
+	nextBlock uint32
+}
+
+func newQueue(store kvstore.KVStore, maxQueued int) (*queue, error) {
+	ret := &queue{
+		store:     store,
+		maxQueued: maxQueued,
+		counts:    make(map[string]int),
+		due:       make(map[string]time.Time),
+		cursors:   make(map[string]map[string]cursor),
+	}
+	if err := ret.loadCursors(); err != nil {
+		return nil, err
+	}
+	first := make(map[string]*delivery)
+	err := ret.iterate(nil, func(d *delivery) {
+		ret.counts[d.SubscriptionID]++
+		if d.seq >= ret.nextSeq {
+			ret.nextSeq = d.seq + 1
+		}
+		if f, ok := first[d.SubscriptionID]; !ok || d.seq < f.seq {
+			first[d.SubscriptionID] = d
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	for id, d := range first {
+		ret.due[id] = d.NextAttempt
+	}
+	return ret, nil
+}
+
+func (q *queue) loadCursors() error {
+	var innerErr error
+	err := q.store.Iterate([]byte{dbkeys.ObjectTypeWebhookCursor}, func(key kvstore.Key, value kvstore.Value) bool {
+		if len(key) < 2 || len(key) < 2+int(key[1]) || len(value) != 4 {
+			innerErr = xerrors.Errorf("webhook queue: wrong cursor record %x", key)
+			return false
+		}
+		subscriptionID := string(key[2 : 2+int(key[1])])
+		chainID, err := iscp.ChainIDFromBytes(key[2+int(key[1]):])
+		if err != nil {
+			innerErr = xerrors.Errorf("webhook queue: %w", err)
+			return false
+		}
+		q.setCursorInMemory(subscriptionID, chainID, binary.BigEndian.Uint32(value))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return innerErr
+}
+
+func (q *queue) setCursorInMemory(subscriptionID string, chainID *iscp.ChainID, nextBlock uint32) {
+	if _, ok := q.cursors[subscriptionID]; !ok {
+		q.cursors[subscriptionID] = make(map[string]cursor)
+	}
+	q.cursors[subscriptionID][chainID.Base58()] = cursor{chainID: chainID, nextBlock: nextBlock}
+}
+
+func cursorBytes(nextBlock uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], nextBlock)
+	return buf[:]
+}
+
+// cursor returns the index of the next block of the chain to be queued for the subscription,
+// false if no block of the chain has been queued for it yet
+func (q *queue) cursor(subscriptionID string, chainID *iscp.ChainID) (uint32, bool) {
+	c, ok := q.cursors[subscriptionID][chainID.Base58()]
+	return c.nextBlock, ok
+}
+
+// allCursors returns the cursors of the subscription
+func (q *queue) allCursors(subscriptionID string) []cursor {
+	ret := make([]cursor, 0, len(q.cursors[subscriptionID]))
+	for _, c := range q.cursors[subscriptionID] {
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+// setCursor sets the index of the next block of the chain to be queued for the subscription, without queueing anything
+func (q *queue) setCursor(subscriptionID string, chainID *iscp.ChainID, nextBlock uint32) error {
+	if err := q.store.Set(dbKeyForCursor(subscriptionID, chainID), cursorBytes(nextBlock)); err != nil {
+		return xerrors.Errorf("webhook queue: %w", err)
+	}
+	q.setCursorInMemory(subscriptionID, chainID, nextBlock)
+	return nil
+}
+
+// pushBlock appends the events of the block to the queue of the subscription and advances its cursor
+// past the block, in one DB batch. The events of a block are not split: if they do not fit in the queue,
+// nothing is queued, unless the queue is empty. Not thread safe
+func (q *queue) pushBlock(subscriptionID string, chainID *iscp.ChainID, blockIndex uint32, bodies [][]byte) error {
+	count := q.counts[subscriptionID]
+	if count > 0 && count+len(bodies) > q.maxQueued {
+		return errQueueFull
+	}
+	batch := q.store.Batched()
+	for i, body := range bodies {
+		d := &delivery{SubscriptionID: subscriptionID, Body: body}
+		if err := batch.Set(dbKeyForDelivery(subscriptionID, q.nextSeq+uint64(i)), d.Bytes()); err != nil {
+			batch.Cancel()
+			return xerrors.Errorf("webhook queue: %w", err)
+		}
+	}
+	if err := batch.Set(dbKeyForCursor(subscriptionID, chainID), cursorBytes(blockIndex+1)); err != nil {
+		batch.Cancel()
+		return xerrors.Errorf("webhook queue: %w", err)
+	}
+	if err := batch.Commit(); err != nil {
+		return xerrors.Errorf("webhook queue: %w", err)
+	}
+	q.nextSeq += uint64(len(bodies))
+	if count == 0 && len(bodies) > 0 {
+		q.due[subscriptionID] = time.Time{}
+	}
+	if len(bodies) > 0 {
+		q.counts[subscriptionID] = count + len(bodies)
+	}
+	q.setCursorInMemory(subscriptionID, chainID, blockIndex+1)
+	return nil
+}
+
+// update stores the delivery after a failed attempt. It must be the first one of its queue
+func (q *queue) update(d *delivery) error {
+	if err := q.store.Set(dbKeyForDelivery(d.SubscriptionID, d.seq), d.Bytes()); err != nil {
+		return err
+	}
+	q.due[d.SubscriptionID] = d.NextAttempt
+	return nil
+}
+
+// remove deletes the delivery. It must be the first one of its queue, the next one is due at once
+func (q *queue) remove(d *delivery) error {
+	if err := q.store.Delete(dbKeyForDelivery(d.SubscriptionID, d.seq)); err != nil {
+		return err
+	}
+	q.counts[d.SubscriptionID]--
+	if q.counts[d.SubscriptionID] > 0 {
+		q.due[d.SubscriptionID] = time.Time{}
+		return nil
+	}
+	delete(q.counts, d.SubscriptionID)
+	delete(q.due, d.SubscriptionID)
+	return nil
+}
+
+// removeAll deletes the queue and the cursors of the subscription
+func (q *queue) removeAll(subscriptionID string) error {
+	pending, err := q.pending(subscriptionID)
+	if err != nil {
+		return err
+	}
+	for _, d := range pending {
+		if err := q.store.Delete(dbKeyForDelivery(d.SubscriptionID, d.seq)); err != nil {
+			return err
+		}
+	}
+	for _, c := range q.cursors[subscriptionID] {
+		if err := q.store.Delete(dbKeyForCursor(subscriptionID, c.chainID)); err != nil {
+			return err
+		}
+	}
+	delete(q.counts, subscriptionID)
+	delete(q.due, subscriptionID)
+	delete(q.cursors, subscriptionID)
+	return nil
+}
+
+// dueSubscriptions returns the subscriptions with a delivery to attempt at the given time
+func (q *queue) dueSubscriptions(now time.Time) []string {
+	ret := make([]string, 0)
+	for id, t := range q.due {
+		if !t.After(now) {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
+
+// first returns the delivery to attempt for the subscription, nil if its queue is empty
+func (q *queue) first(subscriptionID string) (*delivery, error) {
+	var ret *delivery
+	err := q.iterate(dbKeyPrefixForSubscription(subscriptionID), func(d *delivery) {
+		if ret == nil || d.seq < ret.seq {
+			ret = d
+		}
+	})
+	return ret, err
+}
+
+// pending returns the deliveries in the queue of the subscription, in the order they were pushed
+func (q *queue) pending(subscriptionID string) ([]*delivery, error) {
+	ret := make([]*delivery, 0)
+	err := q.iterate(dbKeyPrefixForSubscription(subscriptionID), func(d *delivery) {
+		ret = append(ret, d)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].seq < ret[j].seq
+	})
+	return ret, nil
+}
+
+// iterate calls f for each delivery with a key starting with the prefix, in no particular order
+func (q *queue) iterate(prefix []byte, f func(d *delivery)) error {
+	var innerErr error
+	err := q.store.Iterate(dbkeys.MakeKey(dbkeys.ObjectTypeWebhookDelivery, prefix), func(key kvstore.Key, value kvstore.Value) bool {
+		if len(key) < 10 || len(key) != 1+1+int(key[1])+8 {
+			innerErr = xerrors.Errorf("webhook queue: wrong key %x", key)
+			return false
+		}
+		d, err := deliveryFromBytes(binary.BigEndian.Uint64(key[len(key)-8:]), value)
+		if err != nil {
+			innerErr = xerrors.Errorf("webhook queue: %w", err)
+			return false
+		}
+		f(d)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return innerErr
+}
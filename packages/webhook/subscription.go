@@ -0,0 +1,159 @@
+// Package webhook delivers the structured chain events to HTTP endpoints subscribed by the node operator.
+// The deliveries are queued in the node DB and retried with backoff until the endpoint accepts them,
+// or until the maximum number of attempts.
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+)
+
+// maxSubscriptionIDLength is the maximum length of the ID of a subscription, which prefixes the DB keys of its deliveries
+const maxSubscriptionIDLength = 255
+
+// Subscription is an HTTP endpoint the chain events are POSTed to, with the filters of the events
+type Subscription struct {
+	ID  string
+	URL string
+	// ChainID selects the events of one chain. nil means all chains
+	ChainID *iscp.ChainID
+	// Contract selects the receipts of the requests to the contract and the events emitted by the contract.
+	// 0 means all events
+	Contract iscp.Hname
+	// EventTypes selects the types of the events. Empty means all types
+	EventTypes []string
+}
+
+// NewSubscriptionID returns a random ID for a new subscription
+func NewSubscriptionID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// Matches returns true if the event passes the filters of the subscription
+func (s *Subscription) Matches(evt *publisher.ChainEvent) bool {
+	if s.ChainID != nil && s.ChainID.Base58() != evt.ChainID {
+		return false
+	}
+	if len(s.EventTypes) > 0 {
+		found := false
+		for _, t := range s.EventTypes {
+			if t == evt.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Contract != 0 {
+		switch payload := evt.Payload.(type) {
+		case *publisher.RequestReceipt:
+			return payload.Contract == s.Contract.String()
+		case *publisher.ContractEvent:
+			return payload.Contract == s.Contract.String()
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func SubscriptionFromBytes(data []byte) (*Subscription, error) {
+	return SubscriptionFromMarshalUtil(marshalutil.New(data))
+}
+
+func SubscriptionFromMarshalUtil(mu *marshalutil.MarshalUtil) (*Subscription, error) {
+	ret := &Subscription{}
+	var err error
+	if ret.ID, err = readString(mu); err != nil {
+		return nil, err
+	}
+	if ret.URL, err = readString(mu); err != nil {
+		return nil, err
+	}
+	hasChainID, err := mu.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+	if hasChainID {
+		aliasAddr, err := ledgerstate.AliasAddressFromMarshalUtil(mu)
+		if err != nil {
+			return nil, err
+		}
+		ret.ChainID = iscp.NewChainID(aliasAddr)
+	}
+	if err := ret.Contract.ReadFromMarshalUtil(mu); err != nil {
+		return nil, err
+	}
+	n, err := mu.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+	ret.EventTypes = make([]string, n)
+	for i := range ret.EventTypes {
+		if ret.EventTypes[i], err = readString(mu); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (s *Subscription) Bytes() []byte {
+	mu := marshalutil.New()
+	writeString(mu, s.ID)
+	writeString(mu, s.URL)
+	mu.WriteBool(s.ChainID != nil)
+	if s.ChainID != nil {
+		mu.WriteBytes(s.ChainID.Bytes())
+	}
+	s.Contract.WriteToMarshalUtil(mu)
+	mu.WriteUint16(uint16(len(s.EventTypes)))
+	for _, t := range s.EventTypes {
+		writeString(mu, t)
+	}
+	return mu.Bytes()
+}
+
+func (s *Subscription) String() string {
+	chainID := "*"
+	if s.ChainID != nil {
+		chainID = s.ChainID.Base58()
+	}
+	contract := "*"
+	if s.Contract != 0 {
+		contract = s.Contract.String()
+	}
+	types := "*"
+	if len(s.EventTypes) > 0 {
+		types = strings.Join(s.EventTypes, ",")
+	}
+	return fmt.Sprintf("Subscription(%s) %s chain: %s, contract: %s, types: %s", s.ID, s.URL, chainID, contract, types)
+}
+
+func readString(mu *marshalutil.MarshalUtil) (string, error) {
+	size, err := mu.ReadUint16()
+	if err != nil {
+		return "", err
+	}
+	data, err := mu.ReadBytes(int(size))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func writeString(mu *marshalutil.MarshalUtil, s string) {
+	mu.WriteUint16(uint16(len(s))).WriteBytes([]byte(s))
+}
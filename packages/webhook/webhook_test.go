@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+var testConfig = Config{
+	MaxAttempts: 3,
+	MinBackoff:  10 * time.Millisecond,
+	MaxBackoff:  20 * time.Millisecond,
+	Timeout:     time.Second,
+}
+
+func testEvents(chainID *iscp.ChainID, blockIndex uint32) []*publisher.ChainEvent {
+	return []*publisher.ChainEvent{
+		{
+			Type:    publisher.ChainEventRequestReceipt,
+			ChainID: chainID.Base58(),
+			Cursor:  publisher.Cursor{BlockIndex: blockIndex, EventIndex: 0},
+			Payload: &publisher.RequestReceipt{Contract: iscp.Hn("test").String()},
+		},
+		{
+			Type:    publisher.ChainEventContractEvent,
+			ChainID: chainID.Base58(),
+			Cursor:  publisher.Cursor{BlockIndex: blockIndex, EventIndex: 1},
+			Payload: &publisher.ContractEvent{Contract: iscp.Hn("test").String(), Message: "hello"},
+		},
+		{
+			Type:    publisher.ChainEventBlockCommitted,
+			ChainID: chainID.Base58(),
+			Cursor:  publisher.Cursor{BlockIndex: blockIndex, EventIndex: 2},
+			Payload: &publisher.BlockCommitted{},
+		},
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	chainID := iscp.RandomChainID()
+	evts := testEvents(chainID, 1)
+
+	sub := &Subscription{}
+	for _, evt := range evts {
+		require.True(t, sub.Matches(evt))
+	}
+	sub = &Subscription{ChainID: iscp.RandomChainID()}
+	require.False(t, sub.Matches(evts[0]))
+	sub = &Subscription{ChainID: chainID, EventTypes: []string{publisher.ChainEventBlockCommitted}}
+	require.False(t, sub.Matches(evts[0]))
+	require.True(t, sub.Matches(evts[2]))
+	sub = &Subscription{Contract: iscp.Hn("test")}
+	require.True(t, sub.Matches(evts[0]))
+	require.True(t, sub.Matches(evts[1]))
+	require.False(t, sub.Matches(evts[2]))
+	sub = &Subscription{Contract: iscp.Hn("other")}
+	require.False(t, sub.Matches(evts[1]))
+}
+
+func TestSubscriptionBytes(t *testing.T) {
+	sub := &Subscription{
+		ID:         NewSubscriptionID(),
+		URL:        "http://localhost:1234/hook",
+		ChainID:    iscp.RandomChainID(),
+		Contract:   iscp.Hn("test"),
+		EventTypes: []string{publisher.ChainEventContractEvent, publisher.ChainEventChainRotated},
+	}
+	back, err := SubscriptionFromBytes(sub.Bytes())
+	require.NoError(t, err)
+	require.EqualValues(t, sub, back)
+
+	sub = &Subscription{ID: NewSubscriptionID(), URL: "https://example.com"}
+	back, err = SubscriptionFromBytes(sub.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, back.ChainID)
+	require.EqualValues(t, 0, back.Contract)
+	require.Empty(t, back.EventTypes)
+}
+
+type testEndpoint struct {
+	mutex    sync.Mutex
+	failures int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (e *testEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.failures > 0 {
+		e.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	e.bodies = append(e.bodies, body)
+	e.headers = append(e.headers, r.Header.Clone())
+}
+
+func (e *testEndpoint) received() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return len(e.bodies)
+}
+
+func TestDelivery(t *testing.T) {
+	endpoint := &testEndpoint{failures: 2}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	keyPair := ed25519.GenerateKeyPair()
+	d, err := New(testlogger.NewLogger(t), mapdb.NewMapDB(), &keyPair, nil, testConfig)
+	require.NoError(t, err)
+	chainID := iscp.RandomChainID()
+	sub, err := d.AddSubscription(&Subscription{URL: server.URL, ChainID: chainID, Contract: iscp.Hn("test")})
+	require.NoError(t, err)
+	_, err = d.AddSubscription(&Subscription{URL: "ftp://localhost"})
+	require.Error(t, err)
+	_, err = d.AddSubscription(&Subscription{URL: server.URL, EventTypes: []string{"state"}})
+	require.Error(t, err)
+
+	shutdown := make(chan struct{})
+	defer close(shutdown)
+	go d.Run(shutdown)
+	time.Sleep(20 * time.Millisecond)
+
+	publisher.PublishChainEvents(testEvents(chainID, 1))
+	publisher.PublishChainEvents(testEvents(iscp.RandomChainID(), 1))
+	// the first attempts fail, the events are delivered in order after the retries
+	require.Eventually(t, func() bool { return endpoint.received() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	require.Contains(t, string(endpoint.bodies[0]), `"eventIndex":0`)
+	require.Contains(t, string(endpoint.bodies[1]), `"eventIndex":1`)
+	for i, h := range endpoint.headers {
+		require.EqualValues(t, sub.ID, h.Get(HeaderSubscription))
+		require.EqualValues(t, keyPair.PublicKey.String(), h.Get(HeaderPubKey))
+		require.True(t, VerifySignature(keyPair.PublicKey, h.Get(HeaderTimestamp), h.Get(HeaderSignature), endpoint.bodies[i]))
+		require.False(t, VerifySignature(keyPair.PublicKey, h.Get(HeaderTimestamp)+"1", h.Get(HeaderSignature), endpoint.bodies[i]))
+	}
+}
+
+func TestDeliveryDropped(t *testing.T) {
+	endpoint := &testEndpoint{failures: testConfig.MaxAttempts}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	keyPair := ed25519.GenerateKeyPair()
+	store := mapdb.NewMapDB()
+	d, err := New(testlogger.NewLogger(t), store, &keyPair, nil, testConfig)
+	require.NoError(t, err)
+	sub, err := d.AddSubscription(&Subscription{URL: server.URL, EventTypes: []string{publisher.ChainEventBlockCommitted}})
+	require.NoError(t, err)
+
+	chainID := iscp.RandomChainID()
+	d.handleChainEvents(testEvents(chainID, 1))
+	d.handleChainEvents(testEvents(chainID, 2))
+	d.queueReceived()
+	for i := 0; i < testConfig.MaxAttempts; i++ {
+		d.deliverPending()
+		d.workers.Wait()
+		time.Sleep(testConfig.MaxBackoff)
+	}
+	// the first event is dropped after the maximum number of attempts, the second one is delivered
+	d.deliverPending()
+	d.workers.Wait()
+	require.EqualValues(t, 1, endpoint.received())
+	require.Contains(t, string(endpoint.bodies[0]), `"blockIndex":2`)
+	pending, err := d.queue.pending(sub.ID)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	require.Empty(t, d.queue.dueSubscriptions(time.Now()))
+}
+
+func TestDeliveryDefaults(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	d, err := New(testlogger.NewLogger(t), mapdb.NewMapDB(), &keyPair, nil, Config{})
+	require.NoError(t, err)
+	require.EqualValues(t, DefaultMaxAttempts, d.config.MaxAttempts)
+	require.EqualValues(t, DefaultMaxQueued, d.config.MaxQueued)
+}
+
+func TestQueueBounded(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	config := testConfig
+	config.MaxQueued = 4
+	d, err := New(testlogger.NewLogger(t), mapdb.NewMapDB(), &keyPair, nil, config)
+	require.NoError(t, err)
+	sub1, err := d.AddSubscription(&Subscription{URL: "http://localhost:1"})
+	require.NoError(t, err)
+	sub2, err := d.AddSubscription(&Subscription{URL: "http://localhost:1", EventTypes: []string{publisher.ChainEventBlockCommitted}})
+	require.NoError(t, err)
+
+	// the events of a block beyond the limit of a subscription are not queued, the other subscriptions are not affected
+	d.handleChainEvents(testEvents(iscp.RandomChainID(), 1))
+	d.handleChainEvents(testEvents(iscp.RandomChainID(), 2))
+	d.queueReceived()
+	require.True(t, d.stalled)
+	pending, err := d.queue.pending(sub1.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	require.Contains(t, string(pending[2].Body), `"blockIndex":1`)
+	pending, err = d.queue.pending(sub2.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	// only the first delivery of each queue is due, until it is attempted again
+	now := time.Now()
+	require.ElementsMatch(t, []string{sub1.ID, sub2.ID}, d.queue.dueSubscriptions(now))
+	pending[0].Attempts++
+	pending[0].NextAttempt = now.Add(time.Minute)
+	require.NoError(t, d.queue.update(pending[0]))
+	require.ElementsMatch(t, []string{sub1.ID}, d.queue.dueSubscriptions(now))
+	require.NoError(t, d.queue.remove(pending[0]))
+	require.ElementsMatch(t, []string{sub1.ID, sub2.ID}, d.queue.dueSubscriptions(now))
+}
+
+func TestQueueDurable(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	store := mapdb.NewMapDB()
+	d, err := New(testlogger.NewLogger(t), store, &keyPair, nil, testConfig)
+	require.NoError(t, err)
+	sub, err := d.AddSubscription(&Subscription{URL: "http://localhost:1"})
+	require.NoError(t, err)
+	chainID := iscp.RandomChainID()
+	d.handleChainEvents(testEvents(chainID, 1))
+	d.queueReceived()
+
+	// the subscriptions, the pending deliveries and the cursors are reloaded from the DB
+	d, err = New(testlogger.NewLogger(t), store, &keyPair, nil, testConfig)
+	require.NoError(t, err)
+	require.Len(t, d.GetSubscriptions(), 1)
+	require.ElementsMatch(t, []string{sub.ID}, d.queue.dueSubscriptions(time.Now()))
+	pending, err := d.queue.pending(sub.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	for i, dl := range pending {
+		require.EqualValues(t, i, dl.seq)
+		require.Contains(t, string(dl.Body), `"eventIndex":`+strconv.Itoa(i))
+	}
+	require.EqualValues(t, 3, d.queue.nextSeq)
+	next, ok := d.queue.cursor(sub.ID, chainID)
+	require.True(t, ok)
+	require.EqualValues(t, 2, next)
+
+	require.NoError(t, d.DeleteSubscription(sub.ID))
+	require.ErrorIs(t, d.DeleteSubscription(sub.ID), ErrSubscriptionNotFound)
+	pending, err = d.queue.pending(sub.ID)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	require.Empty(t, d.queue.dueSubscriptions(time.Now()))
+	_, ok = d.queue.cursor(sub.ID, chainID)
+	require.False(t, ok)
+}
+
+type testEventSource struct {
+	chainID *iscp.ChainID
+	latest  uint32
+	pruned  uint32 // the blocks before it have been pruned
+}
+
+func (s *testEventSource) LatestBlockIndex(chainID *iscp.ChainID) (uint32, error) {
+	if !chainID.Equals(s.chainID) {
+		return 0, xerrors.New("unknown chain")
+	}
+	return s.latest, nil
+}
+
+func (s *testEventSource) BlockEvents(chainID *iscp.ChainID, blockIndex uint32) ([]*publisher.ChainEvent, bool, error) {
+	if !chainID.Equals(s.chainID) || blockIndex > s.latest {
+		return nil, false, nil
+	}
+	if blockIndex < s.pruned {
+		return nil, false, xerrors.New("pruned")
+	}
+	return testEvents(chainID, blockIndex), true, nil
+}
+
+func pendingBlocks(t *testing.T, d *Dispatcher, subscriptionID string) []uint32 {
+	pending, err := d.queue.pending(subscriptionID)
+	require.NoError(t, err)
+	ret := make([]uint32, 0)
+	for _, dl := range pending {
+		var evt publisher.ChainEvent
+		require.NoError(t, json.Unmarshal(dl.Body, &evt))
+		if evt.Type == publisher.ChainEventBlockCommitted {
+			ret = append(ret, evt.Cursor.BlockIndex)
+		}
+	}
+	return ret
+}
+
+func TestCatchUp(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	store := mapdb.NewMapDB()
+	source := &testEventSource{chainID: iscp.RandomChainID(), latest: 3, pruned: 6}
+	d, err := New(testlogger.NewLogger(t), store, &keyPair, source, testConfig)
+	require.NoError(t, err)
+	sub, err := d.AddSubscription(&Subscription{URL: "http://localhost:1", ChainID: source.chainID})
+	require.NoError(t, err)
+	next, ok := d.queue.cursor(sub.ID, source.chainID)
+	require.True(t, ok)
+	require.EqualValues(t, 4, next)
+
+	// the blocks which were not received live are queued from the source, in order
+	source.latest = 6
+	d.handleChainEvents(testEvents(source.chainID, 6))
+	d.handleChainEvents(testEvents(source.chainID, 6))
+	d.queueReceived()
+	require.EqualValues(t, []uint32{6}, pendingBlocks(t, d, sub.ID))
+	source.pruned = 0
+
+	// the blocks committed while the node was down
+	source.latest = 8
+	d, err = New(testlogger.NewLogger(t), store, &keyPair, source, testConfig)
+	require.NoError(t, err)
+	d.catchUpAll()
+	require.EqualValues(t, []uint32{6, 7, 8}, pendingBlocks(t, d, sub.ID))
+
+	d.handleChainEvents(testEvents(source.chainID, 8))
+	d.queueReceived()
+	require.EqualValues(t, []uint32{6, 7, 8}, pendingBlocks(t, d, sub.ID))
+}
+
+func TestCatchUpQueueFull(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	config := testConfig
+	config.MaxQueued = 6
+	source := &testEventSource{chainID: iscp.RandomChainID()}
+	d, err := New(testlogger.NewLogger(t), mapdb.NewMapDB(), &keyPair, source, config)
+	require.NoError(t, err)
+	sub, err := d.AddSubscription(&Subscription{URL: "http://localhost:1", ChainID: source.chainID})
+	require.NoError(t, err)
+
+	// the blocks which do not fit in the queue are queued once it has room
+	source.latest = 3
+	d.handleChainEvents(testEvents(source.chainID, 3))
+	d.queueReceived()
+	require.True(t, d.stalled)
+	require.EqualValues(t, []uint32{1, 2}, pendingBlocks(t, d, sub.ID))
+	pending, err := d.queue.pending(sub.ID)
+	require.NoError(t, err)
+	for _, dl := range pending {
+		require.NoError(t, d.queue.remove(dl))
+	}
+	d.queueReceived()
+	require.False(t, d.stalled)
+	require.EqualValues(t, []uint32{3}, pendingBlocks(t, d, sub.ID))
+}
+
+func TestConcurrentDelivery(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	endpoint := &testEndpoint{}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	keyPair := ed25519.GenerateKeyPair()
+	d, err := New(testlogger.NewLogger(t), mapdb.NewMapDB(), &keyPair, nil, testConfig)
+	require.NoError(t, err)
+	_, err = d.AddSubscription(&Subscription{URL: slow.URL})
+	require.NoError(t, err)
+	_, err = d.AddSubscription(&Subscription{URL: server.URL})
+	require.NoError(t, err)
+
+	// the slow endpoint does not hold back the deliveries to the other one
+	d.handleChainEvents(testEvents(iscp.RandomChainID(), 1))
+	d.queueReceived()
+	d.deliverPending()
+	require.Eventually(t, func() bool { return endpoint.received() == 3 }, 5*time.Second, 10*time.Millisecond)
+}
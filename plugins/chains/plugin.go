@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	_ "github.com/iotaledger/wasp/packages/chain/chainimpl"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chain/nodeconnimpl"
 	"github.com/iotaledger/wasp/packages/chains"
 	metricspkg "github.com/iotaledger/wasp/packages/metrics"
@@ -36,19 +37,34 @@ func Init() *node.Plugin {
 
 func configure(_ *node.Plugin) {
 	log = logger.NewLogger(PluginName)
+	// the chains need the node identity and the DKShares
+	registry.OnKeystoreUnlocked(configureChains)
 }
 
-func run(_ *node.Plugin) {
+func configureChains() {
 	allChains = chains.New(
 		log,
 		processors.Config,
 		parameters.GetInt(parameters.OffledgerBroadcastUpToNPeers),
 		time.Duration(parameters.GetInt(parameters.OffledgerBroadcastInterval))*time.Millisecond,
 		parameters.GetBool(parameters.PullMissingRequestsFromCommittee),
+		&mempool.Config{
+			MaxBatchRequests:          parameters.GetInt(parameters.MempoolMaxBatchRequests),
+			MaxBatchBytes:             parameters.GetInt(parameters.MempoolMaxBatchBytes),
+			MaxBatchRequestsPerSender: parameters.GetInt(parameters.MempoolMaxBatchRequestsPerSender),
+			MaxPoolRequestsPerSender:  parameters.GetInt(parameters.MempoolMaxPoolRequestsPerSender),
+			OffLedgerTTL:              time.Duration(parameters.GetInt(parameters.MempoolOffLedgerTTL)) * time.Second,
+		},
 		peering.DefaultNetworkProvider(),
 		database.GetOrCreateKVStore,
 	)
+}
+
+func run(_ *node.Plugin) {
 	err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		if !registry.WaitKeystoreUnlocked(shutdownSignal) {
+			return
+		}
 		if parameters.GetBool(parameters.MetricsEnabled) {
 			allMetrics = metrics.AllMetrics()
 		}
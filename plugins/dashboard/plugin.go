@@ -184,6 +184,11 @@ func run(_ *node.Plugin) {
 }
 
 func worker(shutdownSignal <-chan struct{}) {
+	// the dashboard shows the peering status, which is only available when the keystore is unlocked
+	if !registry.WaitKeystoreUnlocked(shutdownSignal) {
+		d.Stop()
+		return
+	}
 	stopped := make(chan struct{})
 	go func() {
 		defer close(stopped)
@@ -4,7 +4,6 @@
 package dkg
 
 import (
-	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/logger"
 	hive_node "github.com/iotaledger/hive.go/node"
 	dkg_pkg "github.com/iotaledger/wasp/packages/dkg"
@@ -19,25 +18,13 @@ const pluginName = "DKG"
 var defaultNode *dkg_pkg.Node // A singleton.
 
 // Init is an entry point for the plugin.
+// The DKG node needs the node identity, so it is created when the keystore is unlocked.
 func Init() *hive_node.Plugin {
 	configure := func(_ *hive_node.Plugin) {
 		log := logger.NewLogger(pluginName)
-		registry := registry.DefaultRegistry()
-		peeringProvider := peering.DefaultNetworkProvider()
-		var err error
-		var nodeIdentity *ed25519.KeyPair
-		if nodeIdentity, err = registry.GetNodeIdentity(); err != nil {
-			panic("cannot get the node key")
-		}
-		defaultNode, err = dkg_pkg.NewNode(
-			nodeIdentity,
-			peeringProvider,
-			registry,
-			log.Desugar().WithOptions(zap.IncreaseLevel(logger.LevelWarn)).Sugar(),
-		)
-		if err != nil {
-			panic(xerrors.Errorf("failed to initialize the DKG node: %w", err))
-		}
+		registry.OnKeystoreUnlocked(func() {
+			configureNode(log)
+		})
 	}
 	run := func(_ *hive_node.Plugin) {
 		// Nothing to run here.
@@ -45,8 +32,26 @@ func Init() *hive_node.Plugin {
 	return hive_node.NewPlugin(pluginName, hive_node.Enabled, configure, run)
 }
 
+func configureNode(log *logger.Logger) {
+	reg := registry.DefaultRegistry()
+	nodeIdentity, err := reg.GetNodeIdentity()
+	if err != nil {
+		panic("cannot get the node key")
+	}
+	defaultNode, err = dkg_pkg.NewNode(
+		nodeIdentity,
+		peering.DefaultNetworkProvider(),
+		reg,
+		log.Desugar().WithOptions(zap.IncreaseLevel(logger.LevelWarn)).Sugar(),
+	)
+	if err != nil {
+		panic(xerrors.Errorf("failed to initialize the DKG node: %w", err))
+	}
+}
+
 // DefaultNode returns the default instance of the DKG Node Provider.
 // It should be used to access all the DKG Node functions (not the DKG Initiator's).
+// It is nil while the keystore is locked.
 func DefaultNode() *dkg_pkg.Node {
 	return defaultNode
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/plugins/processors"
 )
 
 const PluginName = "Metrics"
@@ -22,6 +23,7 @@ func Init() *node.Plugin {
 func configure(_ *node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	allMetrics = metrics.New(log)
+	allMetrics.RegisterProcessorCache(processors.Config)
 }
 
 func run(_ *node.Plugin) {
@@ -11,7 +11,6 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/util/ready"
-	"github.com/iotaledger/wasp/plugins/peering"
 )
 
 // PluginName is the name of the NodeConn plugin.
@@ -49,7 +48,7 @@ func run(_ *node.Plugin) {
 			return addr, conn, err
 		})
 
-		nodeConn = txstream.New(peering.DefaultNetworkProvider().Self().NetID(), log, dial)
+		nodeConn = txstream.New(parameters.GetString(parameters.PeeringMyNetID), log, dial)
 		initialized.SetReady()
 		defer nodeConn.Close()
 
@@ -4,7 +4,6 @@
 package peering
 
 import (
-	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
@@ -25,36 +24,21 @@ var (
 )
 
 // Init is an entry point for this plugin.
+// The network provider needs the node identity, so it is created when the keystore is unlocked.
 func Init() *node.Plugin {
 	configure := func(_ *node.Plugin) {
 		log = logger.NewLogger(pluginName)
-		var err error
-		var nodeKeyPair *ed25519.KeyPair
-		if nodeKeyPair, err = registry.DefaultRegistry().GetNodeIdentity(); err != nil {
-			panic(err)
-		}
-		if err != nil {
-			log.Panicf("Init.peering: %v", err)
-		}
-		netID := parameters.GetString(parameters.PeeringMyNetID)
-		netImpl, tnmImpl, err := peering_lpp.NewNetworkProvider(
-			netID,
-			parameters.GetInt(parameters.PeeringPort),
-			nodeKeyPair,
-			registry.DefaultRegistry(),
-			log,
-		)
-		if err != nil {
-			log.Panicf("Init.peering: %v", err)
-		}
-		defaultNetworkProvider = netImpl
-		defaultTrustedNetworkManager = tnmImpl
-		log.Infof("------------- NetID is %s ------------------", netID)
+		registry.OnKeystoreUnlocked(configureNetwork)
 	}
 	run := func(_ *node.Plugin) {
 		err := daemon.BackgroundWorker(
 			"WaspPeering",
-			defaultNetworkProvider.Run,
+			func(shutdownSignal <-chan struct{}) {
+				if !registry.WaitKeystoreUnlocked(shutdownSignal) {
+					return
+				}
+				defaultNetworkProvider.Run(shutdownSignal)
+			},
 			parameters.PriorityPeering,
 		)
 		if err != nil {
@@ -64,7 +48,29 @@ func Init() *node.Plugin {
 	return node.NewPlugin(pluginName, node.Enabled, configure, run)
 }
 
+func configureNetwork() {
+	nodeKeyPair, err := registry.DefaultRegistry().GetNodeIdentity()
+	if err != nil {
+		log.Panicf("Init.peering: %v", err)
+	}
+	netID := parameters.GetString(parameters.PeeringMyNetID)
+	netImpl, tnmImpl, err := peering_lpp.NewNetworkProvider(
+		netID,
+		parameters.GetInt(parameters.PeeringPort),
+		nodeKeyPair,
+		registry.DefaultRegistry(),
+		log,
+	)
+	if err != nil {
+		log.Panicf("Init.peering: %v", err)
+	}
+	defaultNetworkProvider = netImpl
+	defaultTrustedNetworkManager = tnmImpl
+	log.Infof("------------- NetID is %s ------------------", netID)
+}
+
 // DefaultNetworkProvider returns the default network provider implementation.
+// It is nil while the keystore is locked.
 func DefaultNetworkProvider() peering_pkg.NetworkProvider {
 	return defaultNetworkProvider
 }
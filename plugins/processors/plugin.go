@@ -5,6 +5,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
 	"github.com/iotaledger/wasp/packages/iscp/coreutil"
+	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 )
 
@@ -34,6 +35,7 @@ func configure(ctx *node.Plugin) {
 		)
 	}
 	Config = processors.NewConfig(nativeContracts...)
+	Config.SetCacheBudget(uint64(parameters.GetInt(parameters.ProcessorsCacheSize)) * 1024 * 1024)
 }
 
 func run(_ *node.Plugin) {
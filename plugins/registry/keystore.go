@@ -0,0 +1,108 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/registry"
+)
+
+var (
+	// keystoreInits are the initializations of the plugins waiting for the keystore to be unlocked
+	keystoreInits []func()
+	// keystoreReady is closed when the keystore is unlocked and the waiting plugins are initialized
+	keystoreReady = make(chan struct{})
+)
+
+// initKeystore unlocks the encrypted registry, or encrypts a plain one if a secret is configured.
+// Without a configured secret, an encrypted registry stays locked until the operator unlocks it
+// through the admin API: the node does not start peering, DKG and consensus before that.
+func initKeystore(log *logger.Logger, reg *registry.Impl) {
+	secret, err := registry.ReadKeystoreSecret(
+		parameters.GetString(parameters.KeystorePassphraseFile),
+		parameters.GetString(parameters.KeystoreKeyFile),
+	)
+	if err != nil {
+		log.Panicf("%v", err)
+	}
+	switch {
+	case !reg.IsKeystoreEncrypted():
+		if secret == nil {
+			log.Warnf("the node identity and the DKShares are stored unencrypted. Configure a keystore secret to encrypt them")
+			break
+		}
+		log.Infof("encrypting the keystore...")
+		if err := reg.EncryptKeystore(secret); err != nil {
+			log.Panicf("cannot encrypt the keystore: %v", err)
+		}
+		log.Warnf("the database files may still contain the plain records until they are compacted")
+	case secret != nil:
+		if err := reg.UnlockKeystore(secret); err != nil {
+			log.Panicf("cannot unlock the keystore: %v", err)
+		}
+	}
+	if reg.IsKeystoreLocked() {
+		log.Warnf("the keystore is locked: unlock it with 'wasp-cli keystore unlock'. " +
+			"Peering, DKG and the chains are started after that")
+		return
+	}
+	close(keystoreReady)
+}
+
+// runKeystore initializes the waiting plugins when the keystore is unlocked, in the order they were registered
+func runKeystore(log *logger.Logger, reg *registry.Impl) {
+	if !reg.IsKeystoreLocked() {
+		return
+	}
+	err := daemon.BackgroundWorker("Keystore", func(shutdownSignal <-chan struct{}) {
+		select {
+		case <-reg.KeystoreUnlocked():
+		case <-shutdownSignal:
+			return
+		}
+		for _, init := range keystoreInits {
+			init()
+		}
+		keystoreInits = nil
+		close(keystoreReady)
+	}, parameters.PriorityKeystore)
+	if err != nil {
+		log.Panicf("failed to start the keystore worker: %v", err)
+	}
+}
+
+// OnKeystoreUnlocked registers the initialization of a plugin which needs the node identity or the DKShares.
+// It must be called while the plugins are configured. The initialization is run at once if the keystore
+// is not locked, otherwise when the keystore is unlocked.
+func OnKeystoreUnlocked(init func()) {
+	select {
+	case <-keystoreReady:
+		init()
+	default:
+		keystoreInits = append(keystoreInits, init)
+	}
+}
+
+// WaitKeystoreUnlocked blocks until the keystore is unlocked and the plugins waiting for it are initialized.
+// Returns false if the node is shut down before that.
+func WaitKeystoreUnlocked(shutdownSignal <-chan struct{}) bool {
+	select {
+	case <-keystoreReady:
+		return true
+	case <-shutdownSignal:
+		return false
+	}
+}
+
+// IsKeystoreReady returns true if the keystore is unlocked and the plugins waiting for it are initialized.
+func IsKeystoreReady() bool {
+	select {
+	case <-keystoreReady:
+		return true
+	default:
+		return false
+	}
+}
@@ -12,7 +12,10 @@ import (
 
 const pluginName = "Registry"
 
-var defaultRegistry *registry.Impl // A singleton.
+var (
+	log             *logger.Logger
+	defaultRegistry *registry.Impl // A singleton.
+)
 
 // DefaultRegistry returns an initialized default registry.
 func DefaultRegistry() *registry.Impl {
@@ -22,13 +25,12 @@ func DefaultRegistry() *registry.Impl {
 // Init is an entry point for the plugin.
 func Init() *hive_node.Plugin {
 	configure := func(_ *hive_node.Plugin) {
-		defaultRegistry = registry.NewRegistry(
-			logger.NewLogger(pluginName),
-			database.GetRegistryKVStore(),
-		)
+		log = logger.NewLogger(pluginName)
+		defaultRegistry = registry.NewRegistry(log, database.GetRegistryKVStore())
+		initKeystore(log, defaultRegistry)
 	}
 	run := func(_ *hive_node.Plugin) {
-		// Nothing to run here.
+		runKeystore(log, defaultRegistry)
 	}
 	return hive_node.NewPlugin(pluginName, hive_node.Enabled, configure, run)
 }
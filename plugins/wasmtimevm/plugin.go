@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/vm/vmtypes"
 	"github.com/iotaledger/wasp/packages/wasmvm/wasmhost"
 	"github.com/iotaledger/wasp/plugins/processors"
@@ -26,10 +27,17 @@ func Init() *node.Plugin {
 func configure(_ *node.Plugin) {
 	log = logger.NewLogger(pluginName)
 
+	// compiled modules are kept on disk, so that they are not compiled again when the node restarts
+	if dir := parameters.GetString(parameters.WasmModuleCacheDir); dir != "" {
+		if err := wasmhost.InitModuleCacheDir(dir); err != nil {
+			log.Warnf("the disk cache of the compiled Wasm modules is disabled: %v", err)
+		} else {
+			wasmhost.ModuleCacheDir = dir
+		}
+	}
+
 	// register VM type(s)
 	err := processors.Config.RegisterVMType(vmtypes.WasmTime, func(binary []byte) (iscp.VMProcessor, error) {
-		// TODO (via config?) pass non-default timeout for WasmTime processor like this:
-		// WasmTimeout = 3 * time.Second
 		return wasmhost.GetProcessor(binary, log)
 	})
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/iotaledger/wasp/plugins/peering"
 	"github.com/iotaledger/wasp/plugins/registry"
 	"github.com/iotaledger/wasp/plugins/wal"
+	"github.com/iotaledger/wasp/plugins/webhooks"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/pangpanglabs/echoswagger/v2"
@@ -46,25 +47,41 @@ func Init() *node.Plugin {
 func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 
-	Server = echoswagger.New(echo.New(), "/doc", &echoswagger.Info{
+	// the endpoints need the node identity, so they are added when the keystore is unlocked
+	registry.OnKeystoreUnlocked(configureEndpoints)
+}
+
+func newServer() echoswagger.ApiRoot {
+	server := echoswagger.New(echo.New(), "/doc", &echoswagger.Info{
 		Title:       "Wasp API",
 		Description: "REST API for the IOTA Wasp node",
 		Version:     wasp.Version,
 	})
 
-	Server.Echo().HideBanner = true
-	Server.Echo().HidePort = true
-	Server.Echo().HTTPErrorHandler = httperrors.HTTPErrorHandler
-	Server.Echo().Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+	server.Echo().HideBanner = true
+	server.Echo().HidePort = true
+	server.Echo().HTTPErrorHandler = httperrors.HTTPErrorHandler
+	server.Echo().Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: `${time_rfc3339_nano} ${remote_ip} ${method} ${uri} ${status} error="${error}"` + "\n",
 	}))
-	Server.Echo().Use(middleware.CORSWithConfig(middleware.CORSConfig{
+	server.Echo().Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
 		AllowMethods: []string{"*"},
 	}))
+	return server
+}
+
+func configureEndpoints() {
+	Server = newServer()
 
-	auth.AddAuthentication(Server.Echo(), parameters.GetStringToString(parameters.WebAPIAuth))
+	var jwtAuth *auth.JWTAuth
+	authConfig := parameters.GetStringToString(parameters.WebAPIAuth)
+	if authConfig["scheme"] == auth.SchemeJWT {
+		jwtAuth = newJWTAuth()
+	} else {
+		auth.AddAuthentication(Server.Echo(), authConfig)
+	}
 
 	network := peering.DefaultNetworkProvider()
 	if network == nil {
@@ -88,7 +105,30 @@ func configure(*node.Plugin) {
 		gracefulshutdown.Shutdown,
 		allMetrics,
 		wal.GetWAL(),
+		webhooks.GetDispatcher(),
+		jwtAuth,
+	)
+}
+
+func newJWTAuth() *auth.JWTAuth {
+	nodeKeyPair, err := registry.DefaultRegistry().GetNodeIdentity()
+	if err != nil {
+		log.Panicf("cannot get the node identity: %v", err)
+	}
+	users := auth.UsersFromConfig(
+		parameters.GetStringToString(parameters.WebAPIJWTUsers),
+		parameters.GetStringToString(parameters.WebAPIJWTUserScopes),
+	)
+	jwtAuth, err := auth.NewJWTAuth(
+		nodeKeyPair,
+		time.Duration(parameters.GetInt(parameters.WebAPIJWTDuration))*time.Second,
+		users,
+		parameters.GetStringSlice(parameters.WebAPIJWTPublicScopes),
 	)
+	if err != nil {
+		log.Panicf("invalid JWT configuration: %v", err)
+	}
+	return jwtAuth
 }
 
 func adminWhitelist() []net.IP {
@@ -107,13 +147,32 @@ func run(_ *node.Plugin) {
 }
 
 func worker(shutdownSignal <-chan struct{}) {
+	if !registry.IsKeystoreReady() {
+		// while the keystore is locked, only the endpoints to unlock it are served
+		locked := newServer()
+		webapi.InitLocked(locked, adminWhitelist(), registry.DefaultRegistry)
+		unlocked := make(chan struct{})
+		go func() {
+			if registry.WaitKeystoreUnlocked(shutdownSignal) {
+				close(unlocked)
+			}
+		}()
+		if !serve(locked, shutdownSignal, unlocked) {
+			return
+		}
+	}
+	serve(Server, shutdownSignal, nil)
+}
+
+// serve runs the server until the node is shut down or the done channel is closed.
+// Returns true only in the latter case.
+func serve(server echoswagger.ApiRoot, shutdownSignal, done <-chan struct{}) bool {
 	stopped := make(chan struct{})
-	server := Server.Echo()
 	go func() {
 		defer close(stopped)
 		bindAddr := parameters.GetString(parameters.WebAPIBindAddress)
 		log.Infof("%s started, bind-address=%s", PluginName, bindAddr)
-		if err := server.Start(bindAddr); err != nil {
+		if err := server.Echo().Start(bindAddr); err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				log.Errorf("Error serving: %s", err)
 			}
@@ -121,16 +180,21 @@ func worker(shutdownSignal <-chan struct{}) {
 	}()
 
 	// stop if we are shutting down or the server could not be started
+	isDone := false
 	select {
 	case <-shutdownSignal:
 	case <-stopped:
+	case <-done:
+		isDone = true
 	}
 
 	log.Infof("Stopping %s ...", PluginName)
 	defer log.Infof("Stopping %s ... done", PluginName)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	if err := server.Shutdown(ctx); err != nil {
+	if err := server.Echo().Shutdown(ctx); err != nil {
 		log.Errorf("Error stopping: %s", err)
 	}
+	<-stopped
+	return isDone
 }
@@ -0,0 +1,100 @@
+// Package webhooks is a plugin delivering the chain events to the webhook subscriptions of the node.
+package webhooks
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webhook"
+	"github.com/iotaledger/wasp/plugins/chains"
+	"github.com/iotaledger/wasp/plugins/database"
+	"github.com/iotaledger/wasp/plugins/registry"
+	"golang.org/x/xerrors"
+)
+
+const PluginName = "Webhooks"
+
+var (
+	log *logger.Logger
+	// dispatcher holds the *webhook.Dispatcher. It is set when the keystore is unlocked,
+	// concurrently with the other plugins reading it
+	dispatcher atomic.Value
+)
+
+func Init() *node.Plugin {
+	return node.NewPlugin(PluginName, node.Enabled, configure, run)
+}
+
+func configure(_ *node.Plugin) {
+	if !parameters.GetBool(parameters.WebhooksEnabled) {
+		return
+	}
+	log = logger.NewLogger(PluginName)
+	// the deliveries are signed with the node identity
+	registry.OnKeystoreUnlocked(configureDispatcher)
+}
+
+func configureDispatcher() {
+	keyPair, err := registry.DefaultRegistry().GetNodeIdentity()
+	if err != nil {
+		log.Panicf("cannot get the node identity: %v", err)
+	}
+	d, err := webhook.New(log, database.GetRegistryKVStore(), keyPair, eventSource{}, webhook.Config{
+		MaxAttempts: parameters.GetInt(parameters.WebhooksMaxAttempts),
+		MaxQueued:   parameters.GetInt(parameters.WebhooksMaxQueued),
+		MinBackoff:  time.Duration(parameters.GetInt(parameters.WebhooksMinBackoff)) * time.Second,
+		MaxBackoff:  time.Duration(parameters.GetInt(parameters.WebhooksMaxBackoff)) * time.Second,
+		Timeout:     time.Duration(parameters.GetInt(parameters.WebhooksTimeout)) * time.Second,
+	})
+	if err != nil {
+		log.Panicf("cannot initialize the webhook dispatcher: %v", err)
+	}
+	dispatcher.Store(d)
+}
+
+func run(_ *node.Plugin) {
+	if !parameters.GetBool(parameters.WebhooksEnabled) {
+		return
+	}
+	err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		if !registry.WaitKeystoreUnlocked(shutdownSignal) {
+			return
+		}
+		GetDispatcher().Run(shutdownSignal)
+	}, parameters.PriorityWebhooks)
+	if err != nil {
+		log.Errorf("failed to start as daemon: %s", err)
+	}
+}
+
+// GetDispatcher returns the webhook dispatcher, or nil if the webhooks are disabled
+func GetDispatcher() *webhook.Dispatcher {
+	d, _ := dispatcher.Load().(*webhook.Dispatcher)
+	return d
+}
+
+// eventSource reads the events of the past blocks from the state of the active chains
+type eventSource struct{}
+
+func (eventSource) LatestBlockIndex(chainID *iscp.ChainID) (uint32, error) {
+	ch := chains.AllChains().Get(chainID)
+	if ch == nil {
+		return 0, xerrors.Errorf("chain %s is not active", chainID.Base58())
+	}
+	return chain.NewBlockEventSource(ch).LatestBlockIndex()
+}
+
+func (eventSource) BlockEvents(chainID *iscp.ChainID, blockIndex uint32) ([]*publisher.ChainEvent, bool, error) {
+	ch := chains.AllChains().Get(chainID)
+	if ch == nil {
+		return nil, false, xerrors.Errorf("chain %s is not active", chainID.Base58())
+	}
+	return chain.NewBlockEventSource(ch).BlockEvents(blockIndex)
+}
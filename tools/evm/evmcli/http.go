@@ -69,9 +69,9 @@ func (j *JSONRPCServer) serveHTTP(rpcsrv *rpc.Server) {
 		AllowMethods: []string{http.MethodPost, http.MethodGet},
 		AllowHeaders: []string{"*"},
 	}))
-	e.Any("/", echo.WrapHandler(rpcsrv))
+	e.Any("/", echo.WrapHandler(jsonrpc.NewHTTPHandler(rpcsrv, j.corsAllowOrigins)))
 
-	fmt.Printf("Starting JSON-RPC server on %s\n", j.listenAddr)
+	fmt.Printf("Starting JSON-RPC server on %s (HTTP and WebSocket)\n", j.listenAddr)
 	if err := e.Start(j.listenAddr); err != nil {
 		if !errors.Is(err, http.ErrServerClosed) {
 			log.Check(err)
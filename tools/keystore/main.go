@@ -0,0 +1,64 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/wasp/packages/database/registrykvstore"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+// the registry DB is stored in this subdirectory of the wasp DB directory
+const registryDBDir = "CHAIN_REGISTRY"
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s (-passphrase-file <file> | -key-file <file>) <waspdb dir>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Encrypts the node identity and the DKShares stored in the registry of a stopped wasp node.\n")
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	passphraseFile := flag.String("passphrase-file", "", "file containing the passphrase of the keystore")
+	keyFile := flag.String("key-file", "", "key file of the keystore")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		usage()
+	}
+	secret, err := registry.ReadKeystoreSecret(*passphraseFile, *keyFile)
+	check(err)
+	if secret == nil {
+		usage()
+	}
+
+	dbDir := fmt.Sprintf("%s/%s", flag.Arg(0), registryDBDir)
+	_, err = os.Stat(dbDir)
+	check(err)
+	db, err := database.NewDB(dbDir)
+	check(err)
+	defer db.Close()
+
+	reg := registry.NewRegistry(testlogger.NewSimple(false).Named("keystore"), registrykvstore.New(db.NewStore()))
+	if reg.IsKeystoreEncrypted() {
+		check(reg.UnlockKeystore(secret))
+		fmt.Println("The keystore is already encrypted, the secret is correct.")
+		return
+	}
+	check(reg.EncryptKeystore(secret))
+	fmt.Println("The keystore has been encrypted.")
+	fmt.Println("The database files and its backups may still contain the plain records until they are compacted.")
+}
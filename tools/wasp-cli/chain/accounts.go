@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/wasp/client/chainclient"
 	"github.com/iotaledger/wasp/packages/iscp"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
@@ -38,34 +39,85 @@ var listAccountsCmd = &cobra.Command{
 	},
 }
 
-var balanceCmd = &cobra.Command{
-	Use:   "balance <agentid>",
-	Short: "Show balance of on-chain account",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		agentID, err := iscp.NewAgentIDFromString(args[0])
-		log.Check(err)
+func balanceCmd() *cobra.Command {
+	var history, historyOffset uint32
 
-		ret, err := SCClient(accounts.Contract.Hname()).CallView(accounts.FuncViewBalance.Name,
-			dict.Dict{
-				accounts.ParamAgentID: agentID.Bytes(),
-			})
-		log.Check(err)
-
-		header := []string{"color", "amount"}
-		rows := make([][]string, len(ret))
-		i := 0
-		for k, v := range ret {
-			color, _, err := ledgerstate.ColorFromBytes([]byte(k))
+	cmd := &cobra.Command{
+		Use:   "balance <agentid>",
+		Short: "Show balance of on-chain account",
+		Long: "Show balance of on-chain account.\n" +
+			"With --history the latest credits and debits of the account are shown too. " +
+			"The history is only kept while it is enabled on the chain, see 'chain gov set-chain-info --account-history'.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			agentID, err := iscp.NewAgentIDFromString(args[0])
 			log.Check(err)
-			bal, err := codec.DecodeUint64(v)
+
+			ret, err := SCClient(accounts.Contract.Hname()).CallView(accounts.FuncViewBalance.Name,
+				dict.Dict{
+					accounts.ParamAgentID: agentID.Bytes(),
+				})
 			log.Check(err)
 
-			rows[i] = []string{color.String(), fmt.Sprintf("%d", bal)}
-			i++
+			header := []string{"color", "amount"}
+			rows := make([][]string, len(ret))
+			i := 0
+			for k, v := range ret {
+				color, _, err := ledgerstate.ColorFromBytes([]byte(k))
+				log.Check(err)
+				bal, err := codec.DecodeUint64(v)
+				log.Check(err)
+
+				rows[i] = []string{color.String(), fmt.Sprintf("%d", bal)}
+				i++
+			}
+			log.PrintTable(header, rows)
+
+			if history > 0 {
+				logAccountHistory(agentID, historyOffset, history)
+			}
+		},
+	}
+	cmd.Flags().Uint32Var(&history, "history", 0, "number of latest history entries to show")
+	cmd.Flags().Uint32Var(&historyOffset, "history-offset", 0, "number of latest history entries to skip")
+	return cmd
+}
+
+func logAccountHistory(agentID *iscp.AgentID, offset, limit uint32) {
+	ret, err := SCClient(accounts.Contract.Hname()).CallView(accounts.FuncGetAccountHistory.Name,
+		dict.Dict{
+			accounts.ParamAgentID:       agentID.Bytes(),
+			accounts.ParamHistoryOffset: codec.EncodeUint32(offset),
+			accounts.ParamHistoryLimit:  codec.EncodeUint32(limit),
+		})
+	log.Check(err)
+	length, err := codec.DecodeUint32(ret.MustGet(accounts.ParamHistoryLength), 0)
+	log.Check(err)
+	arr := collections.NewArray16ReadOnly(ret, accounts.ParamHistoryEntries)
+
+	log.Printf("\nHistory: %d of %d entries, latest first\n", arr.MustLen(), length)
+	header := []string{"block", "request", "kind", "counterparty", "balances"}
+	rows := make([][]string, arr.MustLen())
+	for i := range rows {
+		entry, err := accounts.HistoryEntryFromBytes(arr.MustGetAt(uint16(i)))
+		log.Check(err)
+		kind := "debit"
+		if entry.Credit {
+			kind = "credit"
 		}
-		log.PrintTable(header, rows)
-	},
+		counterparty := "L1"
+		if entry.Counterparty != nil {
+			counterparty = entry.Counterparty.String()
+		}
+		rows[i] = []string{
+			fmt.Sprintf("%d", entry.BlockIndex),
+			entry.RequestID.Base58(),
+			kind,
+			counterparty,
+			entry.Balances.String(),
+		}
+	}
+	log.PrintTable(header, rows)
 }
 
 var depositCmd = &cobra.Command{
@@ -27,6 +27,7 @@ func blockCmd() *cobra.Command {
 			log.Printf("Total requests: %d\n", bi.TotalRequests)
 			log.Printf("Successful requests: %d\n", bi.NumSuccessfulRequests)
 			log.Printf("Off-ledger requests: %d\n", bi.NumOffLedgerRequests)
+			log.Printf("Scheduled calls: %d\n", bi.NumScheduledCalls)
 			log.Printf("\n")
 			logRequestsInBlock(bi.BlockIndex)
 			log.Printf("\n")
@@ -78,12 +79,15 @@ func logReceipt(receipt *blocklog.RequestReceipt, index ...uint16) {
 	}
 
 	kind := "on-ledger"
-	if req.IsOffLedger() {
+	_, scheduled := req.(*request.Scheduled)
+	if scheduled {
+		kind = "scheduled"
+	} else if req.IsOffLedger() {
 		kind = "off-ledger"
 	}
 
 	timestamp := "n/a"
-	if !req.IsOffLedger() {
+	if !req.IsOffLedger() || scheduled {
 		timestamp = req.Timestamp().UTC().Format(time.RFC3339)
 	}
 
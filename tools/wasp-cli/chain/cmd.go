@@ -28,8 +28,11 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(listContractsCmd)
 	chainCmd.AddCommand(deployContractCmd)
 	chainCmd.AddCommand(listAccountsCmd)
-	chainCmd.AddCommand(balanceCmd)
+	chainCmd.AddCommand(balanceCmd())
 	chainCmd.AddCommand(depositCmd)
+	chainCmd.AddCommand(withdrawCmd())
+	chainCmd.AddCommand(harvestCmd())
+	chainCmd.AddCommand(transferCmd())
 	chainCmd.AddCommand(listBlobsCmd)
 	chainCmd.AddCommand(storeBlobCmd)
 	chainCmd.AddCommand(showBlobCmd)
@@ -40,6 +43,8 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(callViewCmd)
 	chainCmd.AddCommand(activateCmd)
 	chainCmd.AddCommand(deactivateCmd)
+	chainCmd.AddCommand(snapshotCmd())
+	chainCmd.AddCommand(govCmd())
 
 	for _, p := range plugins {
 		p(chainCmd)
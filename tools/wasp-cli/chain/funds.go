@@ -0,0 +1,261 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/client/chainclient"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/tools/wasp-cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/util"
+	"github.com/iotaledger/wasp/tools/wasp-cli/wallet"
+)
+
+// l1WaitTimeout is how long to wait for tokens sent by a chain to show up in the wallet
+const l1WaitTimeout = 1 * time.Minute
+
+func withdrawCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw",
+		Short: "Withdraw all funds from the sender's on-chain account to the wallet address",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			chainID := GetCurrentChainID()
+			agentID := walletAgentID()
+
+			l1Before := l1Balances()
+			onChainBefore := onChainBalances(chainID, agentID)
+
+			postAccountsRequest(chainID, accounts.FuncWithdraw.Name, nil, nil)
+
+			l1After := waitForWithdrawal(l1Before, onChainBefore)
+			logBalanceChanges("L1 balance (wallet)", l1Before, l1After)
+			logBalanceChanges(fmt.Sprintf("On-chain balance (%s)", agentID), onChainBefore, onChainBalances(chainID, agentID))
+		},
+	}
+}
+
+func harvestCmd() *cobra.Command {
+	var color string
+	var amount uint64
+
+	cmd := &cobra.Command{
+		Use:   "harvest",
+		Short: "Move funds from the chain's common account to the chain owner's on-chain account",
+		Long: "Move funds from the chain's common account to the chain owner's on-chain account.\n" +
+			"Only the chain owner can harvest. If --amount is not given, the whole common account is harvested.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			chainID := GetCurrentChainID()
+			agentID := walletAgentID()
+
+			params := dict.Dict{}
+			if amount > 0 {
+				params.Set(accounts.ParamWithdrawAmount, codec.EncodeUint64(amount))
+				params.Set(accounts.ParamWithdrawColor, codec.EncodeColor(colorFromString(color)))
+			}
+
+			l1Before := l1Balances()
+			onChainBefore := onChainBalances(chainID, agentID)
+
+			postAccountsRequest(chainID, accounts.FuncHarvest.Name, params, nil)
+
+			logBalanceChanges("L1 balance (wallet)", l1Before, l1Balances())
+			logBalanceChanges(fmt.Sprintf("On-chain balance (%s)", agentID), onChainBefore, onChainBalances(chainID, agentID))
+		},
+	}
+
+	cmd.Flags().StringVar(&color, "color", "IOTA", "color of the tokens to harvest (used with --amount)")
+	cmd.Flags().Uint64Var(&amount, "amount", 0, "amount of tokens to harvest (default: harvest everything)")
+	return cmd
+}
+
+func transferCmd() *cobra.Command {
+	var toChain string
+	var toAgent string
+
+	cmd := &cobra.Command{
+		Use:   "transfer --to-chain <chain> <color>:<amount> [<color>:amount ...]",
+		Short: "Move funds from the sender's on-chain account to an account on another chain",
+		Long: "Move funds from the sender's on-chain account to an account on another chain.\n" +
+			"All funds in the sender's account on the current chain are withdrawn to the wallet address,\n" +
+			"then the given amounts are deposited to the target account on the other chain.\n" +
+			"Funds that are not transferred remain in the wallet.\n\n" +
+			"The transfer is not atomic: it consists of two requests, one on each chain. If the deposit fails,\n" +
+			"the withdrawn funds remain in the wallet. The fee of the deposit is taken from the deposited tokens,\n" +
+			"so the target account may receive less than the given amounts.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if toChain == "" {
+				log.Fatalf("--to-chain is required")
+			}
+			transfer := parseColoredBalances(args)
+			sourceChainID := GetCurrentChainID()
+			targetChainID := chainIDFromAliasOrBase58(toChain)
+			if targetChainID.Equals(sourceChainID) {
+				log.Fatalf("source and target chain are the same")
+			}
+
+			agentID := walletAgentID()
+			targetAgentID := agentID
+			params := dict.Dict{}
+			if toAgent != "" {
+				var err error
+				targetAgentID, err = iscp.NewAgentIDFromString(toAgent)
+				log.Check(err)
+				params.Set(accounts.ParamAgentID, codec.EncodeAgentID(targetAgentID))
+			}
+
+			l1Before := l1Balances()
+			sourceBefore := onChainBalances(sourceChainID, agentID)
+			targetBefore := onChainBalances(targetChainID, targetAgentID)
+
+			log.Printf("Withdrawing funds from chain %s...\n", sourceChainID.Base58())
+			postAccountsRequest(sourceChainID, accounts.FuncWithdraw.Name, nil, nil)
+			l1AfterWithdraw := waitForWithdrawal(l1Before, sourceBefore)
+			// the wallet balance is checked after the withdrawal, so the fees of the withdraw request are already paid
+			for col, amount := range transfer {
+				if l1AfterWithdraw.Get(col) < amount {
+					log.Fatalf("not enough %s in the wallet after withdrawal: have %d, need %d",
+						col.String(), l1AfterWithdraw.Get(col), amount)
+				}
+			}
+
+			log.Printf("Depositing funds to chain %s...\n", targetChainID.Base58())
+			postAccountsRequest(targetChainID, accounts.FuncDeposit.Name, params, transfer)
+
+			logBalanceChanges("L1 balance (wallet)", l1Before, l1Balances())
+			logBalanceChanges(fmt.Sprintf("On-chain balance in chain %s (%s)", sourceChainID.Base58(), agentID),
+				sourceBefore, onChainBalances(sourceChainID, agentID))
+			logBalanceChanges(fmt.Sprintf("On-chain balance in chain %s (%s)", targetChainID.Base58(), targetAgentID),
+				targetBefore, onChainBalances(targetChainID, targetAgentID))
+		},
+	}
+
+	cmd.Flags().StringVar(&toChain, "to-chain", "", "alias or base58 ID of the target chain")
+	cmd.Flags().StringVar(&toAgent, "to-agent", "", "agent ID of the target account (default: the sender's account)")
+	return cmd
+}
+
+// postAccountsRequest posts an on-ledger request to the accounts contract of the given chain,
+// waits until it is processed and fails if the receipt contains an error.
+// If transfer is nil, 1 iota is sent along with the request.
+func postAccountsRequest(chainID *iscp.ChainID, funcName string, params dict.Dict, transfer colored.Balances) {
+	if transfer == nil {
+		transfer = colored.NewBalancesForIotas(1)
+	}
+	client := chainClient(chainID)
+	tx := util.WithSCTransaction(chainID, func() (*ledgerstate.Transaction, error) {
+		return client.Post1Request(accounts.Contract.Hname(), iscp.Hn(funcName), chainclient.PostRequestParams{
+			Transfer: transfer,
+			Args:     requestargs.New().AddEncodeSimpleMany(params),
+		})
+	}, true)
+	for _, reqID := range request.RequestsInTransaction(chainID, tx) {
+		log.Check(client.CheckRequestResult(reqID))
+	}
+}
+
+func chainClient(chainID *iscp.ChainID) *chainclient.Client {
+	return chainclient.New(
+		config.GoshimmerClient(),
+		config.WaspClient(),
+		chainID,
+		wallet.Load().KeyPair(),
+	)
+}
+
+func chainIDFromAliasOrBase58(s string) *iscp.ChainID {
+	if chainID, err := iscp.ChainIDFromBase58(s); err == nil {
+		return chainID
+	}
+	return GetChainFromAlias(s)
+}
+
+func walletAgentID() *iscp.AgentID {
+	return iscp.NewAgentID(wallet.Load().Address(), 0)
+}
+
+func l1Balances() colored.Balances {
+	outs, err := config.GoshimmerClient().GetConfirmedOutputs(wallet.Load().Address())
+	log.Check(err)
+	bals, _ := colored.OutputBalancesByColor(outs)
+	return bals
+}
+
+// waitForWithdrawal polls the wallet balance until the withdrawn tokens are confirmed on L1.
+// l1Before is the wallet balance before posting the withdraw request, which itself spends 1 iota.
+func waitForWithdrawal(l1Before, withdrawn colored.Balances) colored.Balances {
+	if withdrawn.IsEmpty() {
+		return l1Balances()
+	}
+	log.Printf("Waiting for the funds to arrive in the wallet...\n")
+	posted := l1Before.Clone().SubNoOverflow(colored.IOTA, 1)
+	deadline := time.Now().Add(l1WaitTimeout)
+	for {
+		after := l1Balances()
+		for col := range withdrawn {
+			if after.Get(col) > posted.Get(col) {
+				return after
+			}
+		}
+		if time.Now().After(deadline) {
+			log.Fatalf("timeout waiting for the withdrawn funds to arrive in the wallet")
+		}
+		time.Sleep(1 * time.Second)
+	}
+}
+
+func onChainBalances(chainID *iscp.ChainID, agentID *iscp.AgentID) colored.Balances {
+	ret, err := chainClient(chainID).CallView(accounts.Contract.Hname(), accounts.FuncViewBalance.Name, dict.Dict{
+		accounts.ParamAgentID: agentID.Bytes(),
+	})
+	log.Check(err)
+	bals, err := colored.BalancesFromDict(ret)
+	log.Check(err)
+	return bals
+}
+
+func logBalanceChanges(title string, before, after colored.Balances) {
+	log.Printf("%s:\n", title)
+	diff := after.Diff(before)
+	header := []string{"color", "before", "after", "change"}
+	rows := make([][]string, 0)
+	colors := make([]colored.Color, 0)
+	for col := range allBalanceColors(before, after) {
+		colors = append(colors, col)
+	}
+	colored.Sort(colors)
+	for _, col := range colors {
+		rows = append(rows, []string{
+			col.String(),
+			fmt.Sprintf("%d", before.Get(col)),
+			fmt.Sprintf("%d", after.Get(col)),
+			fmt.Sprintf("%+d", diff[col]),
+		})
+	}
+	log.PrintTable(header, rows)
+}
+
+func allBalanceColors(bals ...colored.Balances) map[colored.Color]bool {
+	ret := make(map[colored.Color]bool)
+	for _, b := range bals {
+		for col := range b {
+			ret[col] = true
+		}
+	}
+	return ret
+}
@@ -0,0 +1,549 @@
+package chain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/wasp/client/chainclient"
+	"github.com/iotaledger/wasp/packages/iscp"
+	"github.com/iotaledger/wasp/packages/iscp/colored"
+	"github.com/iotaledger/wasp/packages/iscp/request"
+	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/tools/wasp-cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/util"
+	"github.com/iotaledger/wasp/tools/wasp-cli/wallet"
+	"github.com/spf13/cobra"
+)
+
+var govOffLedger bool
+
+func govCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gov <command>",
+		Short: "Administer the chain through the governance and root core contracts",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+	cmd.PersistentFlags().BoolVarP(&govOffLedger, "off-ledger", "o", false,
+		"post the requests as off-ledger requests. The sender must have funds on its on-chain account",
+	)
+
+	cmd.AddCommand(govInfoCmd())
+	cmd.AddCommand(govSetChainInfoCmd())
+	cmd.AddCommand(govFeePolicyCmd())
+	cmd.AddCommand(govSetFeePolicyCmd())
+	cmd.AddCommand(govEstimateFeeCmd())
+	cmd.AddCommand(govOwnerCmd())
+	cmd.AddCommand(govDelegateOwnershipCmd())
+	cmd.AddCommand(govClaimOwnershipCmd())
+	cmd.AddCommand(govStateControllersCmd())
+	cmd.AddCommand(govAddStateControllerCmd())
+	cmd.AddCommand(govRemoveStateControllerCmd())
+	cmd.AddCommand(govRotateStateControllerCmd())
+	cmd.AddCommand(govNodesCmd())
+	cmd.AddCommand(govAddCandidateNodeCmd())
+	cmd.AddCommand(govRevokeAccessNodeCmd())
+	cmd.AddCommand(govChangeAccessNodesCmd())
+	cmd.AddCommand(govGrantDeployCmd())
+	cmd.AddCommand(govRevokeDeployCmd())
+	cmd.AddCommand(govRequireDeployPermissionsCmd())
+	return cmd
+}
+
+// postGovRequest posts a request to a core contract, by default as an on-ledger request with 1 iota attached
+func postGovRequest(contract iscp.Hname, entryPoint string, params dict.Dict) {
+	args := requestargs.New().AddEncodeSimpleMany(params)
+	if govOffLedger {
+		util.WithOffLedgerRequest(GetCurrentChainID(), func() (*request.OffLedger, error) {
+			return Client().PostOffLedgerRequest(contract, iscp.Hn(entryPoint), chainclient.PostRequestParams{
+				Args:  args,
+				Nonce: uint64(time.Now().UnixNano()),
+			})
+		})
+		return
+	}
+	util.WithSCTransaction(GetCurrentChainID(), func() (*ledgerstate.Transaction, error) {
+		return Client().Post1Request(contract, iscp.Hn(entryPoint), chainclient.PostRequestParams{
+			Args:     args,
+			Transfer: colored.NewBalancesForIotas(1),
+		})
+	})
+}
+
+func callGovView(fname string, params dict.Dict) dict.Dict {
+	ret, err := SCClient(governance.Contract.Hname()).CallView(fname, params)
+	log.Check(err)
+	return ret
+}
+
+func parseAgentID(s string) *iscp.AgentID {
+	agentID, err := iscp.NewAgentIDFromString(s)
+	log.Check(err)
+	return agentID
+}
+
+func parseAddress(s string) ledgerstate.Address {
+	addr, err := ledgerstate.AddressFromBase58EncodedString(s)
+	log.Check(err)
+	return addr
+}
+
+func parsePubKey(s string) ed25519.PublicKey {
+	pubKey, err := ed25519.PublicKeyFromString(s)
+	log.Check(err)
+	return pubKey
+}
+
+func govInfoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Show the configuration of the chain",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			info := callGovView(governance.FuncGetChainInfo.Name, nil)
+
+			chainID, err := codec.DecodeChainID(info.MustGet(governance.VarChainID))
+			log.Check(err)
+			ownerID, err := codec.DecodeAgentID(info.MustGet(governance.VarChainOwnerID))
+			log.Check(err)
+			description, err := codec.DecodeString(info.MustGet(governance.VarDescription), "")
+			log.Check(err)
+			maxBlobSize, err := codec.DecodeUint32(info.MustGet(governance.VarMaxBlobSize), 0)
+			log.Check(err)
+			maxEventSize, err := codec.DecodeUint16(info.MustGet(governance.VarMaxEventSize), 0)
+			log.Check(err)
+			maxEventsPerReq, err := codec.DecodeUint16(info.MustGet(governance.VarMaxEventsPerReq), 0)
+			log.Check(err)
+			blockKeepAmount, err := codec.DecodeUint32(info.MustGet(governance.VarBlockKeepAmount), 0)
+			log.Check(err)
+			accountHistory, err := codec.DecodeBool(info.MustGet(governance.VarAccountHistory), false)
+			log.Check(err)
+			wasmMaxMemoryPages, err := codec.DecodeUint32(info.MustGet(governance.VarWasmMaxMemoryPages), 0)
+			log.Check(err)
+			wasmMaxTableSize, err := codec.DecodeUint32(info.MustGet(governance.VarWasmMaxTableSize), 0)
+			log.Check(err)
+			wasmMaxCallDepth, err := codec.DecodeUint32(info.MustGet(governance.VarWasmMaxCallDepth), 0)
+			log.Check(err)
+
+			keep := "all"
+			if blockKeepAmount > 0 {
+				keep = fmt.Sprintf("%d", blockKeepAmount)
+			}
+			log.PrintTable([]string{"parameter", "value"}, [][]string{
+				{"chain ID", chainID.Base58()},
+				{"owner", ownerID.String()},
+				{"description", description},
+				{"max blob size", fmt.Sprintf("%d", maxBlobSize)},
+				{"max event size", fmt.Sprintf("%d", maxEventSize)},
+				{"max events per request", fmt.Sprintf("%d", maxEventsPerReq)},
+				{"blocks kept", keep},
+				{"account history", fmt.Sprintf("%v", accountHistory)},
+				{"wasm max memory pages", fmt.Sprintf("%d", wasmMaxMemoryPages)},
+				{"wasm max table size", fmt.Sprintf("%d", wasmMaxTableSize)},
+				{"wasm max call depth", fmt.Sprintf("%d", wasmMaxCallDepth)},
+			})
+		},
+	}
+}
+
+func govSetChainInfoCmd() *cobra.Command {
+	// the flags have the types of the parameters, so that out of range values are rejected when parsed
+	var maxBlobSize, blockKeepAmount uint32
+	var maxEventSize, maxEventsPerRequest uint16
+	var wasmMaxMemoryPages, wasmMaxTableSize, wasmMaxCallDepth uint32
+	var accountHistory bool
+	cmd := &cobra.Command{
+		Use:   "set-chain-info",
+		Short: "Change the configuration of the chain. Only the given parameters are changed",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			params := dict.New()
+			if cmd.Flags().Changed("max-blob-size") {
+				params.Set(governance.ParamMaxBlobSize, codec.EncodeUint32(maxBlobSize))
+			}
+			if cmd.Flags().Changed("max-event-size") {
+				params.Set(governance.ParamMaxEventSize, codec.EncodeUint16(maxEventSize))
+			}
+			if cmd.Flags().Changed("max-events-per-request") {
+				params.Set(governance.ParamMaxEventsPerRequest, codec.EncodeUint16(maxEventsPerRequest))
+			}
+			if cmd.Flags().Changed("block-keep-amount") {
+				params.Set(governance.ParamBlockKeepAmount, codec.EncodeUint32(blockKeepAmount))
+			}
+			if cmd.Flags().Changed("account-history") {
+				params.Set(governance.ParamAccountHistory, codec.EncodeBool(accountHistory))
+			}
+			if cmd.Flags().Changed("wasm-max-memory-pages") {
+				params.Set(governance.ParamWasmMaxMemoryPages, codec.EncodeUint32(wasmMaxMemoryPages))
+			}
+			if cmd.Flags().Changed("wasm-max-table-size") {
+				params.Set(governance.ParamWasmMaxTableSize, codec.EncodeUint32(wasmMaxTableSize))
+			}
+			if cmd.Flags().Changed("wasm-max-call-depth") {
+				params.Set(governance.ParamWasmMaxCallDepth, codec.EncodeUint32(wasmMaxCallDepth))
+			}
+			if len(params) == 0 {
+				log.Fatalf("nothing to change, see %s --help", cmd.CommandPath())
+			}
+			postGovRequest(governance.Contract.Hname(), governance.FuncSetChainInfo.Name, params)
+		},
+	}
+	cmd.Flags().Uint32Var(&maxBlobSize, "max-blob-size", 0, "maximum size of a blob")
+	cmd.Flags().Uint16Var(&maxEventSize, "max-event-size", 0, "maximum size of a single event")
+	cmd.Flags().Uint16Var(&maxEventsPerRequest, "max-events-per-request", 0, "maximum number of events per request")
+	cmd.Flags().Uint32Var(&blockKeepAmount, "block-keep-amount", 0, "number of latest blocks to keep, 0 to keep all blocks")
+	cmd.Flags().BoolVar(&accountHistory, "account-history", false, "keep the history of credits and debits of each account")
+	cmd.Flags().Uint32Var(&wasmMaxMemoryPages, "wasm-max-memory-pages", 0, "maximum memory of Wasm smart contracts, in pages of 64Kb")
+	cmd.Flags().Uint32Var(&wasmMaxTableSize, "wasm-max-table-size", 0, "maximum number of table elements of Wasm smart contracts")
+	cmd.Flags().Uint32Var(&wasmMaxCallDepth, "wasm-max-call-depth", 0, "maximum depth of nested calls in Wasm smart contracts")
+	return cmd
+}
+
+func govFeePolicyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-policy",
+		Short: "Show the fee policy of the chain",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			feePolicy, err := governance.DecodeFeePolicy(callGovView(governance.FuncGetFeePolicy.Name, nil))
+			log.Check(err)
+			log.PrintTable([]string{"parameter", "value"}, [][]string{
+				{"fee color", feePolicy.FeeColor.String()},
+				{"gas price", fmt.Sprintf("%d per %d gas", feePolicy.GasPrice, governance.GasPriceUnit)},
+				{"min fee", fmt.Sprintf("%d", feePolicy.MinFee)},
+				{"validator fee share", fmt.Sprintf("%d%%", feePolicy.ValidatorFeeShare)},
+			})
+		},
+	}
+}
+
+// mustInt64 converts the value of the flag to the int64 parameter expected by the governance contract
+func mustInt64(v uint64, flag string) int64 {
+	if v > math.MaxInt64 {
+		log.Fatalf("--%s must not exceed %d", flag, int64(math.MaxInt64))
+	}
+	return int64(v)
+}
+
+// govSetFeePolicyCmd replaces the per-contract fees of setContractFee, which the governance contract does not have anymore
+func govSetFeePolicyCmd() *cobra.Command {
+	var feeColor string
+	var gasPrice, minFee uint64
+	var validatorFeeShare uint16
+	cmd := &cobra.Command{
+		Use:   "set-fee-policy",
+		Short: "Change the fee policy of the chain. Only the given parameters are changed",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			params := dict.New()
+			if cmd.Flags().Changed("fee-color") {
+				params.Set(governance.ParamFeeColor, codec.EncodeColor(colorFromString(feeColor)))
+			}
+			if cmd.Flags().Changed("gas-price") {
+				params.Set(governance.ParamGasPrice, codec.EncodeInt64(mustInt64(gasPrice, "gas-price")))
+			}
+			if cmd.Flags().Changed("min-fee") {
+				params.Set(governance.ParamMinFee, codec.EncodeInt64(mustInt64(minFee, "min-fee")))
+			}
+			if cmd.Flags().Changed("validator-fee-share") {
+				if validatorFeeShare > governance.MaxValidatorFeeShare {
+					log.Fatalf("--validator-fee-share must not exceed %d", governance.MaxValidatorFeeShare)
+				}
+				params.Set(governance.ParamValidatorFeeShare, codec.EncodeInt64(int64(validatorFeeShare)))
+			}
+			if len(params) == 0 {
+				log.Fatalf("nothing to change, see %s --help", cmd.CommandPath())
+			}
+			postGovRequest(governance.Contract.Hname(), governance.FuncSetFeePolicy.Name, params)
+		},
+	}
+	cmd.Flags().StringVar(&feeColor, "fee-color", "IOTA", "color of the tokens accepted for fees")
+	cmd.Flags().Uint64Var(&gasPrice, "gas-price", 0, fmt.Sprintf("fee tokens per %d gas", governance.GasPriceUnit))
+	cmd.Flags().Uint64Var(&minFee, "min-fee", 0, "minimum fee charged for any request")
+	cmd.Flags().Uint16Var(&validatorFeeShare, "validator-fee-share", 0, "part of the fee in percent which goes to the validators")
+	return cmd
+}
+
+func govEstimateFeeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "estimate-fee [gas-budget]",
+		Short: "Show the maximum fee charged for a request with the given gas budget (default budget if omitted)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			params := dict.New()
+			if len(args) > 0 {
+				budget, err := strconv.ParseUint(args[0], 10, 64)
+				log.Check(err)
+				params.Set(governance.ParamGas, codec.EncodeUint64(budget))
+			}
+			ret := callGovView(governance.FuncEstimateFee.Name, params)
+			feeColor, err := codec.DecodeColor(ret.MustGet(governance.VarFeeColor))
+			log.Check(err)
+			fee, err := codec.DecodeUint64(ret.MustGet(governance.ParamFee))
+			log.Check(err)
+			budget, err := codec.DecodeUint64(ret.MustGet(governance.ParamGas))
+			log.Check(err)
+			log.Printf("Fee: %d %s for a gas budget of %d\n", fee, feeColor.String(), budget)
+		},
+	}
+}
+
+func govOwnerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "owner",
+		Short: "Show the owner of the chain",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ret := callGovView(governance.FuncGetChainOwner.Name, nil)
+			ownerID, err := codec.DecodeAgentID(ret.MustGet(governance.ParamChainOwner))
+			log.Check(err)
+			log.Printf("Owner: %s\n", ownerID.String())
+		},
+	}
+}
+
+func govDelegateOwnershipCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegate-ownership <agentid>",
+		Short: "Delegate the ownership of the chain. The new owner must claim it with claim-ownership",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncDelegateChainOwnership.Name, dict.Dict{
+				governance.ParamChainOwner: codec.EncodeAgentID(parseAgentID(args[0])),
+			})
+		},
+	}
+}
+
+func govClaimOwnershipCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim-ownership",
+		Short: "Claim the ownership of the chain delegated to the wallet address",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncClaimChainOwnership.Name, nil)
+		},
+	}
+}
+
+func govStateControllersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "state-controllers",
+		Short: "List the addresses the state controller of the chain can be rotated to",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ret := callGovView(governance.FuncGetAllowedStateControllerAddresses.Name, nil)
+			rows := make([][]string, 0)
+			if ret.MustHas(governance.ParamAllowedStateControllerAddresses) {
+				arr := collections.NewArray16ReadOnly(ret, governance.ParamAllowedStateControllerAddresses)
+				for i := uint16(0); i < arr.MustLen(); i++ {
+					addr, err := codec.DecodeAddress(arr.MustGetAt(i))
+					log.Check(err)
+					rows = append(rows, []string{addr.Base58()})
+				}
+			}
+			log.Printf("Total %d allowed state controller address(es)\n", len(rows))
+			log.PrintTable([]string{"address"}, rows)
+		},
+	}
+}
+
+func govAddStateControllerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-state-controller <address>",
+		Short: "Allow rotating the state controller of the chain to the address",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncAddAllowedStateControllerAddress.Name, dict.Dict{
+				governance.ParamStateControllerAddress: codec.EncodeAddress(parseAddress(args[0])),
+			})
+		},
+	}
+}
+
+func govRemoveStateControllerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-state-controller <address>",
+		Short: "Remove the address from the allowed state controller addresses",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncRemoveAllowedStateControllerAddress.Name, dict.Dict{
+				governance.ParamStateControllerAddress: codec.EncodeAddress(parseAddress(args[0])),
+			})
+		},
+	}
+}
+
+func govRotateStateControllerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-state-controller <address>",
+		Short: "Rotate the state controller of the chain to the address. The address must be allowed first",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncRotateStateController.Name, dict.Dict{
+				governance.ParamStateControllerAddress: codec.EncodeAddress(parseAddress(args[0])),
+			})
+		},
+	}
+}
+
+func govNodesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "nodes",
+		Short: "List the access nodes and the candidate nodes of the chain",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			res := governance.NewGetChainNodesResponseFromDict(
+				callGovView(governance.FuncGetChainNodes.Name, governance.GetChainNodesRequest{}.AsDict()),
+			)
+			log.Printf("Access nodes: %d\n", len(res.AccessNodes))
+			rows := make([][]string, len(res.AccessNodes))
+			for i, pubKey := range res.AccessNodes {
+				rows[i] = []string{pubKey.String()}
+			}
+			log.PrintTable([]string{"pubkey"}, rows)
+
+			log.Printf("Candidate nodes: %d\n", len(res.AccessNodeCandidates))
+			rows = make([][]string, len(res.AccessNodeCandidates))
+			for i, ani := range res.AccessNodeCandidates {
+				pubKey, _, err := ed25519.PublicKeyFromBytes(ani.NodePubKey)
+				log.Check(err)
+				owner, _, err := ledgerstate.AddressFromBytes(ani.ValidatorAddr)
+				log.Check(err)
+				rows[i] = []string{pubKey.String(), owner.Base58(), strconv.FormatBool(ani.ForCommittee), ani.AccessAPI}
+			}
+			log.PrintTable([]string{"pubkey", "owner", "committee", "access API"}, rows)
+		},
+	}
+}
+
+// ownNodeAccessInfo returns the access node info of the node wasp-cli is connected to, certified
+// by the node as owned by the wallet address
+func ownNodeAccessInfo() *governance.AccessNodeInfo {
+	self, err := config.WaspClient().GetPeeringSelf()
+	log.Check(err)
+	pubKey := parsePubKey(self.PubKey)
+	ownerAddress := wallet.Load().Address()
+	cert, err := config.WaspClient().NodeOwnershipCertificate(pubKey, ownerAddress)
+	log.Check(err)
+	return &governance.AccessNodeInfo{
+		NodePubKey:    pubKey.Bytes(),
+		ValidatorAddr: ownerAddress.Bytes(),
+		Certificate:   cert.Bytes(),
+	}
+}
+
+func govAddCandidateNodeCmd() *cobra.Command {
+	var forCommittee bool
+	var accessAPI string
+	cmd := &cobra.Command{
+		Use:   "add-candidate-node",
+		Short: "Apply for the node wasp-cli is connected to become an access node of the chain",
+		Long: "Apply for the node wasp-cli is connected to become an access node, or a committee node candidate, " +
+			"of the chain. The wallet address must be the owner of the node. The application must be accepted by " +
+			"the chain owner with change-access-nodes.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ani := ownNodeAccessInfo()
+			ani.ForCommittee = forCommittee
+			ani.AccessAPI = accessAPI
+			postGovRequest(governance.Contract.Hname(), governance.FuncAddCandidateNode.Name, ani.ToAddCandidateNodeParams())
+		},
+	}
+	cmd.Flags().BoolVar(&forCommittee, "for-committee", false, "apply also as a candidate for the committee")
+	cmd.Flags().StringVar(&accessAPI, "access-api", "", "URL of the web API of the node")
+	return cmd
+}
+
+func govRevokeAccessNodeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-access-node",
+		Short: "Withdraw the node wasp-cli is connected to from the access nodes and candidates of the chain",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(governance.Contract.Hname(), governance.FuncRevokeAccessNode.Name, ownNodeAccessInfo().ToRevokeAccessNodeParams())
+		},
+	}
+}
+
+func govChangeAccessNodesCmd() *cobra.Command {
+	var accept, remove, drop []string
+	cmd := &cobra.Command{
+		Use:   "change-access-nodes",
+		Short: "Accept candidates as access nodes, remove access nodes or drop candidates",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			req := governance.NewChangeAccessNodesRequest()
+			for _, s := range accept {
+				req.Accept(parsePubKey(s))
+			}
+			for _, s := range remove {
+				req.Remove(parsePubKey(s))
+			}
+			for _, s := range drop {
+				req.Drop(parsePubKey(s))
+			}
+			if len(accept)+len(remove)+len(drop) == 0 {
+				log.Fatalf("nothing to change, see %s --help", cmd.CommandPath())
+			}
+			postGovRequest(governance.Contract.Hname(), governance.FuncChangeAccessNodes.Name, req.AsDict())
+		},
+	}
+	cmd.Flags().StringSliceVar(&accept, "accept", nil, "pubkeys of the candidates to accept as access nodes")
+	cmd.Flags().StringSliceVar(&remove, "remove", nil, "pubkeys of the access nodes to turn back into candidates")
+	cmd.Flags().StringSliceVar(&drop, "drop", nil, "pubkeys of the nodes to drop from the access nodes and the candidates")
+	return cmd
+}
+
+func govGrantDeployCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-deploy <agentid>",
+		Short: "Grant the permission to deploy contracts on the chain",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(root.Contract.Hname(), root.FuncGrantDeployPermission.Name, dict.Dict{
+				root.ParamDeployer: codec.EncodeAgentID(parseAgentID(args[0])),
+			})
+		},
+	}
+}
+
+func govRevokeDeployCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-deploy <agentid>",
+		Short: "Revoke the permission to deploy contracts on the chain",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			postGovRequest(root.Contract.Hname(), root.FuncRevokeDeployPermission.Name, dict.Dict{
+				root.ParamDeployer: codec.EncodeAgentID(parseAgentID(args[0])),
+			})
+		},
+	}
+}
+
+func govRequireDeployPermissionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "require-deploy-permissions <true|false>",
+		Short: "Enable or disable the deploy permission check. When disabled, anyone can deploy contracts",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			enabled, err := strconv.ParseBool(args[0])
+			log.Check(err)
+			postGovRequest(root.Contract.Hname(), root.FuncRequireDeployPermissions.Name, dict.Dict{
+				root.ParamDeployPermissionsEnabled: codec.EncodeBool(enabled),
+			})
+		},
+	}
+}
@@ -79,10 +79,13 @@ var infoCmd = &cobra.Command{
 				log.Printf("Delegated owner: %s\n", delegated.String())
 			}
 
-			feeColor, defaultOwnerFee, defaultValidatorFee, err := governance.GetDefaultFeeInfo(info)
+			fees, err := SCClient(governance.Contract.Hname()).CallView(governance.FuncGetFeePolicy.Name, nil)
 			log.Check(err)
-			log.Printf("Default owner fee: %d %s\n", defaultOwnerFee, feeColor.String())
-			log.Printf("Default validator fee: %d %s\n", defaultValidatorFee, feeColor.String())
+			feePolicy, err := governance.DecodeFeePolicy(fees)
+			log.Check(err)
+			log.Printf("Gas price: %d %s per %d gas\n", feePolicy.GasPrice, feePolicy.FeeColor.String(), governance.GasPriceUnit)
+			log.Printf("Minimum fee: %d %s\n", feePolicy.MinFee, feePolicy.FeeColor.String())
+			log.Printf("Validator fee share: %d%%\n", feePolicy.ValidatorFeeShare)
 		}
 	},
 }
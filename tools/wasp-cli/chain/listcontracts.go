@@ -1,13 +1,7 @@
 package chain
 
 import (
-	"fmt"
-
-	"github.com/iotaledger/wasp/packages/iscp/colored"
-	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/collections"
-	"github.com/iotaledger/wasp/packages/kv/dict"
-	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 	"github.com/spf13/cobra"
@@ -31,8 +25,6 @@ var listContractsCmd = &cobra.Command{
 			"description",
 			"proghash",
 			"creator",
-			"owner fee",
-			"validator fee",
 		}
 		rows := make([][]string, len(contracts))
 		i := 0
@@ -42,28 +34,12 @@ var listContractsCmd = &cobra.Command{
 				creator = c.Creator.String()
 			}
 
-			fees, err := SCClient(governance.Contract.Hname()).CallView(governance.FuncGetFeeInfo.Name, dict.Dict{
-				governance.ParamHname: c.Hname().Bytes(),
-			})
-			log.Check(err)
-
-			ownerFee, err := codec.DecodeUint64(fees.MustGet(governance.VarOwnerFee))
-			log.Check(err)
-
-			validatorFee, err := codec.DecodeUint64(fees.MustGet(governance.VarValidatorFee))
-			log.Check(err)
-
-			feeColor, err := codec.DecodeColor(fees.MustGet(governance.VarFeeColor), colored.IOTA)
-			log.Check(err)
-
 			rows[i] = []string{
 				hname.String(),
 				c.Name,
 				c.Description,
 				c.ProgramHash.String(),
 				creator,
-				fmt.Sprintf("%d %s", ownerFee, feeColor),
-				fmt.Sprintf("%d %s", validatorFee, feeColor),
 			}
 			i++
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/wasp/packages/iscp/colored"
 	"github.com/iotaledger/wasp/packages/iscp/request"
 	"github.com/iotaledger/wasp/packages/iscp/requestargs"
+	"github.com/iotaledger/wasp/packages/webapi/model"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 	"github.com/iotaledger/wasp/tools/wasp-cli/util"
 	"github.com/spf13/cobra"
@@ -19,6 +20,7 @@ import (
 func postRequestCmd() *cobra.Command {
 	var transfer []string
 	var offLedger bool
+	var dryRun bool
 
 	cmd := &cobra.Command{
 		Use:   "post-request <name> <funcname> [params]",
@@ -34,6 +36,19 @@ func postRequestCmd() *cobra.Command {
 
 			scClient := SCClient(iscp.Hn(args[0]))
 
+			if dryRun {
+				var res *model.DryRunResponse
+				var err error
+				if offLedger {
+					params.Nonce = uint64(time.Now().UnixNano())
+					res, err = scClient.DryRunOffLedgerRequest(fname, params)
+				} else {
+					res, err = scClient.DryRunOnLedgerRequest(fname, params)
+				}
+				log.Check(err)
+				logDryRunResult(res)
+				return
+			}
 			if offLedger {
 				params.Nonce = uint64(time.Now().UnixNano())
 				util.WithOffLedgerRequest(GetCurrentChainID(), func() (*request.OffLedger, error) {
@@ -53,10 +68,29 @@ func postRequestCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
 		"post an off-ledger request",
 	)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"run the request on the current state of the chain without posting it, "+
+			"and show the result, events, gas and fees",
+	)
 
 	return cmd
 }
 
+func logDryRunResult(res *model.DryRunResponse) {
+	if res.Error != "" {
+		log.Printf("Error: %s\n", res.Error)
+	}
+	log.Printf("Gas burned: %d of %d\n", res.GasBurned, res.GasBudget)
+	log.Printf("Fee: %d %s\n", res.Fee, res.FeeColor)
+	log.Printf("Outputs created: %d\n", len(res.Outputs))
+	log.Printf("Events: %d\n", len(res.Events))
+	for _, evt := range res.Events {
+		log.Printf("  %s: %s\n", evt.Contract, evt.Message)
+	}
+	log.Printf("Result:\n")
+	util.PrintDictAsJSON(res.Result)
+}
+
 func colorFromString(s string) colored.Color {
 	if s == colored.IOTA.String() {
 		return colored.IOTA
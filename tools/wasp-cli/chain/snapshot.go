@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"os"
+
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/spf13/cobra"
+)
+
+func snapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot <command>",
+		Short: "Export or import a snapshot of the chain state",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+	cmd.AddCommand(snapshotExportCmd())
+	cmd.AddCommand(snapshotImportCmd())
+	return cmd
+}
+
+func snapshotExportCmd() *cobra.Command {
+	var blockIndex int
+	cmd := &cobra.Command{
+		Use:   "export <filename>",
+		Short: "Write a snapshot of the chain state to a file",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := os.Create(args[0])
+			log.Check(err)
+			defer f.Close()
+			if blockIndex < 0 {
+				log.Check(Client().WaspClient.ExportSnapshot(GetCurrentChainID(), f))
+			} else {
+				log.Check(Client().WaspClient.ExportSnapshot(GetCurrentChainID(), f, uint32(blockIndex)))
+			}
+			log.Printf("Snapshot written to %s\n", args[0])
+		},
+	}
+	cmd.Flags().IntVarP(&blockIndex, "block", "", -1, "index of the block the snapshot is taken at (default: latest block)")
+	return cmd
+}
+
+func snapshotImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <filename>",
+		Short: "Import a snapshot of the chain state in the node. The chain must be deactivated",
+		Long: "Import a snapshot of the chain state in the node. The chain must be deactivated, and the node must not " +
+			"have a state of the chain yet. Once the chain is activated, the snapshot is checked against the ledger " +
+			"and only the blocks after the snapshot are synced.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := os.Open(args[0])
+			log.Check(err)
+			defer f.Close()
+			info, err := Client().WaspClient.ImportSnapshot(GetCurrentChainID(), f)
+			log.Check(err)
+			log.Printf("Imported snapshot at block #%d, state hash %s\n", info.BlockIndex, info.StateHash)
+		},
+	}
+}
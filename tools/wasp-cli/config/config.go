@@ -46,6 +46,8 @@ func Init(rootCmd *cobra.Command) {
 
 	rootCmd.AddCommand(configSetCmd)
 	rootCmd.AddCommand(checkVersionsCmd)
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
 }
 
 func Read() {
@@ -79,9 +81,8 @@ func GoshimmerClient() *goshimmer.Client {
 }
 
 func WaspClient() *client.WaspClient {
-	// TODO: add authentication for /adm
 	log.Verbosef("using Wasp host %s\n", WaspAPI())
-	return client.NewWaspClient(WaspAPI())
+	return client.NewWaspClient(WaspAPI()).WithToken(AuthToken())
 }
 
 func WaspAPI() string {
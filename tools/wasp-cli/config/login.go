@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/iotaledger/wasp/client"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const authTokenConfigVar = "authentication.token"
+
+var loginCmd = &cobra.Command{
+	Use:   "login <username> <password>",
+	Short: "Get a JWT token from the Wasp node and store it in the configuration",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		res, err := client.NewWaspClient(WaspAPI()).Login(args[0], args[1])
+		log.Check(err)
+		Set(authTokenConfigVar, res.JWT)
+		log.Printf("Logged in to %s, token valid until %s\n", WaspAPI(), res.ExpiresAt.Local())
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored JWT token from the configuration",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Set(authTokenConfigVar, "")
+	},
+}
+
+func AuthToken() string {
+	return viper.GetString(authTokenConfigVar)
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/spf13/cobra"
+)
+
+var keystoreCmd = &cobra.Command{
+	Use:   "keystore <command>",
+	Short: "Manage the keystore of the node.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Check(cmd.Help())
+	},
+}
+
+func Init(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(keystoreCmd)
+	keystoreCmd.AddCommand(statusCmd)
+	keystoreCmd.AddCommand(initUnlockCmd())
+}
@@ -0,0 +1,22 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"github.com/iotaledger/wasp/tools/wasp-cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the keystore of the node is encrypted and locked.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := config.WaspClient().KeystoreStatus()
+		log.Check(err)
+		log.Printf("Encrypted: %v\n", status.Encrypted)
+		log.Printf("Locked:    %v\n", status.Locked)
+	},
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/tools/wasp-cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func initUnlockCmd() *cobra.Command {
+	var passphraseFile, keyFile string
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Unlock the keystore of the node, which then starts peering, DKG and the chains.",
+		Long: `Unlock the keystore of the node, which then starts peering, DKG and the chains.
+Without --passphrase-file or --key-file, the passphrase is prompted for, or read from the standard input
+if it is not a terminal.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			secret, err := registry.ReadKeystoreSecret(passphraseFile, keyFile)
+			log.Check(err)
+			if secret == nil {
+				secret = readPassphrase()
+			}
+			log.Check(config.WaspClient().KeystoreUnlock(secret))
+			log.Printf("keystore unlocked\n")
+		},
+	}
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "", "file containing the keystore passphrase")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "file containing the keystore key")
+	return cmd
+}
+
+func readPassphrase() []byte {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		data, err := io.ReadAll(os.Stdin)
+		log.Check(err)
+		return bytes.TrimRight(data, "\r\n")
+	}
+	os.Stderr.WriteString("Keystore passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	os.Stderr.WriteString("\n")
+	log.Check(err)
+	return passphrase
+}
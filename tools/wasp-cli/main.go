@@ -8,6 +8,7 @@ import (
 	"github.com/iotaledger/wasp/tools/wasp-cli/chain"
 	"github.com/iotaledger/wasp/tools/wasp-cli/config"
 	"github.com/iotaledger/wasp/tools/wasp-cli/decode"
+	"github.com/iotaledger/wasp/tools/wasp-cli/keystore"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 	"github.com/iotaledger/wasp/tools/wasp-cli/metrics"
 	"github.com/iotaledger/wasp/tools/wasp-cli/peering"
@@ -36,6 +37,7 @@ func init() {
 	chain.Init(rootCmd)
 	decode.Init(rootCmd)
 	peering.Init(rootCmd)
+	keystore.Init(rootCmd)
 	metrics.Init(rootCmd)
 }
 